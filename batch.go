@@ -0,0 +1,314 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// defaultBatchMaxRequests is the default maximum number of sub-requests per batch.
+const defaultBatchMaxRequests = 20
+
+// defaultBatchMaxBodySize is the default maximum batch request body size (1 MB).
+const defaultBatchMaxBodySize = 1 << 20
+
+// batchKey marks the context of batch sub-requests, so that nested batches
+// are rejected whatever path reaches the batch endpoint.
+type batchKey struct{}
+
+// batchForwardedHeaders are the proxy headers copied from the batch request
+// to every sub-request. Sub-requests cannot set them, so their ClientIP,
+// Scheme and Host are those of the batch request.
+var batchForwardedHeaders = []string{"Forwarded", "X-Forwarded-For", "X-Forwarded-Host", "X-Forwarded-Proto", "X-Real-Ip"}
+
+// BatchRequest is a single sub-request in a JSON batch.
+type BatchRequest struct {
+	// ID is an optional client-defined identifier echoed in the response.
+	ID string `json:"id,omitempty"`
+
+	// Method is the HTTP method (e.g., "GET", "POST").
+	Method string `json:"method"`
+
+	// Path is the request path including query string (e.g., "/users/1?fields=name").
+	Path string `json:"path"`
+
+	// Headers are additional request headers for this sub-request.
+	// They override headers shared from the batch request. Forwarding
+	// headers (Forwarded, X-Forwarded-For, X-Forwarded-Host,
+	// X-Forwarded-Proto, X-Real-IP) are rejected: sub-requests keep the
+	// client address, TLS state and forwarding headers of the batch request.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Body is the JSON request body.
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// BatchResponse is the result of a single sub-request in a JSON batch.
+type BatchResponse struct {
+	// ID is the identifier from the corresponding BatchRequest.
+	ID string `json:"id,omitempty"`
+
+	// Status is the HTTP status code of the sub-response.
+	Status int `json:"status"`
+
+	// Headers are the sub-response headers, with all their values
+	// (e.g., {"Set-Cookie": ["a=1", "b=2"]}).
+	Headers http.Header `json:"headers,omitempty"`
+
+	// Body is the sub-response body.
+	// JSON bodies are embedded as-is, other bodies are encoded as a JSON string.
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// BatchConfig configures the batch endpoint registered by Router.ServeBatch.
+type BatchConfig struct {
+	// MaxRequests is the maximum number of sub-requests per batch.
+	// Default: 20
+	MaxRequests int
+
+	// MaxBodySize is the maximum batch request body size in bytes.
+	// Larger batches are rejected with 413 Content Too Large.
+	// Default: 1 MB
+	MaxBodySize int64
+
+	// SharedHeaders are copied from the batch request to every sub-request,
+	// so sub-requests share the caller's authentication.
+	// Default: Authorization, Cookie
+	SharedHeaders []string
+
+	// MaxCost is the maximum total cost of the sub-requests of a batch.
+	// Costlier batches are rejected with 422 Unprocessable Entity before
+	// any sub-request runs.
+	// Default: 0 (unlimited)
+	MaxCost int
+
+	// Cost estimates the cost of a sub-request.
+	// Default: the limit query parameter of the sub-request (1 if absent)
+	// multiplied by 1 plus the number of relations in its include query
+	// parameter (see ListCost)
+	Cost func(req *BatchRequest) int
+}
+
+// ServeBatch registers a POST endpoint that executes a JSON array of sub-requests.
+//
+// Each sub-request is routed through the Router (including all middleware)
+// sequentially, sharing the batch request's context and authentication headers.
+// The response is a JSON array of sub-responses in the same order, each with
+// its own status code. This reduces round trips for clients on high-latency links.
+//
+// Sub-requests routed to a batch endpoint are rejected. Batches larger
+// than MaxBodySize are rejected with 413 Content Too Large, batches with
+// more than MaxRequests sub-requests with 400 Bad Request (decoding stops
+// at the first extra sub-request), and batches whose total cost exceeds
+// MaxCost with 422 Unprocessable Entity.
+//
+// Example request:
+//
+//	POST /batch
+//	[
+//	  {"id": "1", "method": "GET", "path": "/users/1"},
+//	  {"id": "2", "method": "POST", "path": "/users", "body": {"name": "John"}}
+//	]
+//
+// Example response:
+//
+//	[
+//	  {"id": "1", "status": 200, "body": {"id": 1, "name": "Alice"}},
+//	  {"id": "2", "status": 201, "headers": {"Location": ["/users/2"]}, "body": {"id": 2, "name": "John"}}
+//	]
+//
+// Example:
+//
+//	router := fursy.New()
+//	router.Use(middleware.JWT(secret))
+//	router.GET("/users/:id", getUser)
+//	router.POST("/users", createUser)
+//
+//	router.ServeBatch("/batch", fursy.BatchConfig{MaxRequests: 50})
+func (r *Router) ServeBatch(path string, config ...BatchConfig) {
+	var cfg BatchConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	if cfg.MaxRequests <= 0 {
+		cfg.MaxRequests = defaultBatchMaxRequests
+	}
+	if cfg.MaxBodySize <= 0 {
+		cfg.MaxBodySize = defaultBatchMaxBodySize
+	}
+	if cfg.SharedHeaders == nil {
+		cfg.SharedHeaders = []string{"Authorization", "Cookie"}
+	}
+	if cfg.Cost == nil {
+		cfg.Cost = batchItemCost
+	}
+
+	r.POST(path, func(c *Context) error {
+		if c.Request.Context().Value(batchKey{}) != nil {
+			return c.Problem(BadRequest("nested batch requests are not allowed"))
+		}
+
+		reqs, err := decodeBatch(http.MaxBytesReader(c.Response, c.Request.Body, cfg.MaxBodySize), cfg.MaxRequests)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return c.Problem(NewProblem(http.StatusRequestEntityTooLarge, "Content Too Large",
+					fmt.Sprintf("batch request exceeds %d bytes", cfg.MaxBodySize)))
+			}
+			return c.Problem(BadRequest(err.Error()))
+		}
+		if cfg.MaxCost > 0 {
+			cost := 0
+			for i := range reqs {
+				cost += cfg.Cost(&reqs[i])
+			}
+			if cost > cfg.MaxCost {
+				return c.Problem(costProblem(cost, cfg.MaxCost))
+			}
+		}
+
+		resps := make([]BatchResponse, len(reqs))
+		for i := range reqs {
+			resps[i] = r.serveBatchItem(c, &reqs[i], cfg.SharedHeaders)
+		}
+
+		return c.JSON(http.StatusOK, resps)
+	})
+}
+
+// decodeBatch decodes the JSON array of sub-requests from body, one element
+// at a time, so that decoding stops at the first sub-request over maxRequests.
+func decodeBatch(body io.Reader, maxRequests int) ([]BatchRequest, error) {
+	dec := json.NewDecoder(body)
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("invalid batch request: %w", err)
+	}
+	if tok != json.Delim('[') {
+		return nil, errors.New("invalid batch request: expected a JSON array")
+	}
+
+	var reqs []BatchRequest
+	for dec.More() {
+		if len(reqs) == maxRequests {
+			return nil, fmt.Errorf("batch exceeds maximum of %d requests", maxRequests)
+		}
+		var req BatchRequest
+		if err := dec.Decode(&req); err != nil {
+			return nil, fmt.Errorf("invalid batch request: %w", err)
+		}
+		reqs = append(reqs, req)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("invalid batch request: %w", err)
+	}
+	return reqs, nil
+}
+
+// serveBatchItem executes a single sub-request and records its response.
+func (r *Router) serveBatchItem(c *Context, item *BatchRequest, shared []string) BatchResponse {
+	resp := BatchResponse{ID: item.ID}
+
+	fail := func(status int, detail string) BatchResponse {
+		body, _ := json.Marshal(NewProblem(status, http.StatusText(status), detail))
+		resp.Status = status
+		resp.Headers = http.Header{"Content-Type": {"application/problem+json"}}
+		resp.Body = body
+		return resp
+	}
+
+	if item.Method == "" || item.Path == "" || item.Path[0] != '/' {
+		return fail(http.StatusBadRequest, "method and absolute path are required")
+	}
+	for k := range item.Headers {
+		if slices.Contains(batchForwardedHeaders, http.CanonicalHeaderKey(k)) {
+			return fail(http.StatusBadRequest, fmt.Sprintf("header %q cannot be set by a sub-request", k))
+		}
+	}
+	ctx := context.WithValue(c.Request.Context(), batchKey{}, true)
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(item.Method), item.Path, bytes.NewReader(item.Body))
+	if err != nil {
+		return fail(http.StatusBadRequest, err.Error())
+	}
+	req.RemoteAddr = c.Request.RemoteAddr
+	req.Host = c.Request.Host
+	req.TLS = c.Request.TLS
+	for _, h := range batchForwardedHeaders {
+		if v := c.Request.Header.Values(h); len(v) > 0 {
+			req.Header[h] = v
+		}
+	}
+	if len(item.Body) > 0 {
+		req.Header.Set("Content-Type", MIMEApplicationJSON)
+	}
+	for _, h := range shared {
+		if v := c.Request.Header.Values(h); len(v) > 0 {
+			req.Header[http.CanonicalHeaderKey(h)] = v
+		}
+	}
+	for k, v := range item.Headers {
+		req.Header.Set(k, v)
+	}
+
+	rec := &batchResponseWriter{header: make(http.Header)}
+	r.ServeHTTP(rec, req)
+
+	resp.Status = rec.status
+	if resp.Status == 0 {
+		resp.Status = http.StatusOK
+	}
+	if len(rec.header) > 0 {
+		resp.Headers = rec.header
+	}
+	if body := bytes.TrimSpace(rec.body.Bytes()); len(body) > 0 {
+		if json.Valid(body) {
+			resp.Body = body
+		} else {
+			resp.Body, _ = json.Marshal(string(body))
+		}
+	}
+
+	return resp
+}
+
+// batchItemCost is the default cost of a sub-request (see BatchConfig.Cost).
+func batchItemCost(req *BatchRequest) int {
+	_, rawQuery, _ := strings.Cut(req.Path, "?")
+	q, _ := url.ParseQuery(rawQuery)
+	return queryCost(q, 1)
+}
+
+// batchResponseWriter captures a sub-response in memory.
+type batchResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *batchResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *batchResponseWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *batchResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
@@ -0,0 +1,241 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newBatchRouter creates a router with a simple auth middleware and batch endpoint.
+func newBatchRouter() *Router {
+	r := New()
+	r.Use(func(c *Context) error {
+		if c.Request.URL.Path != "/batch" && c.GetHeader("Authorization") != "Bearer token" {
+			return c.Problem(Unauthorized("missing token"))
+		}
+		return c.Next()
+	})
+
+	r.GET("/users/:id", func(c *Context) error {
+		return c.OK(map[string]string{"id": c.Param("id")})
+	})
+	POST[TestRequest, TestResponse](r, "/users", func(c *Box[TestRequest, TestResponse]) error {
+		return c.Created("/users/2", TestResponse{ID: 2, Message: c.ReqBody.Name})
+	})
+	r.GET("/ping", func(c *Context) error {
+		return c.Text("pong")
+	})
+	r.GET("/session", func(c *Context) error {
+		c.Response.Header().Add("Set-Cookie", "a=1")
+		c.Response.Header().Add("Set-Cookie", "b=2")
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	r.ServeBatch("/batch", BatchConfig{MaxRequests: 5, MaxBodySize: 512})
+	r.ServeBatch("/v2/batch")
+	return r
+}
+
+// TestServeBatch tests executing multiple sub-requests in one batch.
+func TestServeBatch(t *testing.T) {
+	r := newBatchRouter()
+
+	body := `[
+		{"id":"a","method":"GET","path":"/users/1"},
+		{"id":"b","method":"POST","path":"/users","body":{"name":"John"}},
+		{"id":"c","method":"GET","path":"/ping"},
+		{"id":"d","method":"GET","path":"/missing"}
+	]`
+	req := httptest.NewRequest(http.MethodPost, "/batch", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer token")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resps []BatchResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resps); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(resps) != 4 {
+		t.Fatalf("expected 4 responses, got %d", len(resps))
+	}
+
+	expected := []struct {
+		id     string
+		status int
+		body   string
+	}{
+		{"a", 200, `{"id":"1"}`},
+		{"b", 201, `{"id":2,"message":"John"}`},
+		{"c", 200, `"pong"`},
+		{"d", 404, `"Not Found"`},
+	}
+	for i, exp := range expected {
+		if resps[i].ID != exp.id || resps[i].Status != exp.status || string(resps[i].Body) != exp.body {
+			t.Errorf("response %d: expected %+v, got id=%s status=%d body=%s",
+				i, exp, resps[i].ID, resps[i].Status, resps[i].Body)
+		}
+	}
+	if loc := resps[1].Headers.Get("Location"); loc != "/users/2" {
+		t.Errorf("expected Location /users/2, got %q", loc)
+	}
+}
+
+// TestServeBatch_SharedAuth tests that sub-requests inherit the caller's authentication.
+func TestServeBatch_SharedAuth(t *testing.T) {
+	r := newBatchRouter()
+
+	body := `[{"method":"GET","path":"/users/1"}]`
+	req := httptest.NewRequest(http.MethodPost, "/batch", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resps []BatchResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resps); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if resps[0].Status != http.StatusUnauthorized {
+		t.Errorf("expected status 401 without shared token, got %d", resps[0].Status)
+	}
+}
+
+// TestServeBatch_Invalid tests rejected batches and sub-requests.
+func TestServeBatch_Invalid(t *testing.T) {
+	r := newBatchRouter()
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"invalid JSON", `{`, http.StatusBadRequest},
+		{"not an array", `{}`, http.StatusBadRequest},
+		{"too many requests", `[{},{},{},{},{},{}]`, http.StatusBadRequest},
+		{"too many requests before invalid JSON", `[{},{},{},{},{},{},{`, http.StatusBadRequest},
+		{"body too large", `[{"path":"` + strings.Repeat("a", 512) + `"}]`, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/batch", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, w.Code)
+			}
+		})
+	}
+
+	body := `[{"method":"POST","path":"/batch","body":[]},{"method":"POST","path":"/v2/batch","body":[]},{"method":"GET","path":"relative"}]`
+	req := httptest.NewRequest(http.MethodPost, "/batch", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer token")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resps []BatchResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resps); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(resps) != 3 {
+		t.Fatalf("expected 3 responses, got %d", len(resps))
+	}
+	for i, resp := range resps {
+		if resp.Status != http.StatusBadRequest {
+			t.Errorf("response %d: expected status 400, got %d", i, resp.Status)
+		}
+	}
+}
+
+// TestServeBatch_MultiValueHeaders tests that all header values of a
+// sub-response are kept.
+func TestServeBatch_MultiValueHeaders(t *testing.T) {
+	r := newBatchRouter()
+
+	body := `[{"method":"GET","path":"/session"}]`
+	req := httptest.NewRequest(http.MethodPost, "/batch", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer token")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resps []BatchResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resps); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if cookies := resps[0].Headers.Values("Set-Cookie"); len(cookies) != 2 || cookies[0] != "a=1" || cookies[1] != "b=2" {
+		t.Errorf("expected Set-Cookie [a=1 b=2], got %v", cookies)
+	}
+}
+
+// TestServeBatch_Forwarded tests that sub-requests keep the client address,
+// TLS state and forwarding headers of the batch request.
+func TestServeBatch_Forwarded(t *testing.T) {
+	r := newBatchRouter()
+	r.SetTrustedProxies("192.0.2.0/24")
+	r.GET("/whoami", func(c *Context) error {
+		return c.OK(map[string]any{"ip": c.ClientIP(), "scheme": c.Scheme(), "tls": c.Request.TLS != nil})
+	})
+
+	serve := func(body string) []BatchResponse {
+		req := httptest.NewRequest(http.MethodPost, "/batch", strings.NewReader(body))
+		req.RemoteAddr = "192.0.2.1:1234"
+		req.TLS = &tls.ConnectionState{}
+		req.Header.Set("Authorization", "Bearer token")
+		req.Header.Set("X-Forwarded-For", "203.0.113.7")
+		req.Header.Set("X-Forwarded-Proto", "https")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		var resps []BatchResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resps); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		return resps
+	}
+
+	resps := serve(`[{"method":"GET","path":"/whoami"}]`)
+	if got := string(resps[0].Body); got != `{"ip":"203.0.113.7","scheme":"https","tls":true}` {
+		t.Errorf("sub-request saw %s", got)
+	}
+
+	for _, h := range []string{"X-Forwarded-For", "x-real-ip", "Forwarded"} {
+		resps = serve(`[{"method":"GET","path":"/whoami","headers":{"` + h + `":"10.0.0.1"}}]`)
+		if resps[0].Status != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", h, resps[0].Status)
+		}
+	}
+}
+
+// TestServeBatch_MaxCost tests rejecting batches exceeding the cost limit.
+func TestServeBatch_MaxCost(t *testing.T) {
+	r := New()
+	r.GET("/users", func(c *Context) error {
+		return c.OK([]string{})
+	})
+	r.ServeBatch("/batch", BatchConfig{MaxCost: 100})
+
+	tests := []struct {
+		body     string
+		wantCode int
+	}{
+		{`[{"method":"GET","path":"/users?limit=50"},{"method":"GET","path":"/users?limit=25&include=roles"}]`, http.StatusOK},
+		{`[{"method":"GET","path":"/users?limit=50&include=roles,teams"}]`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/batch", strings.NewReader(tt.body)))
+		if w.Code != tt.wantCode {
+			t.Errorf("%s: status = %d, want %d", tt.body, w.Code, tt.wantCode)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/coregx/fursy/internal/binding"
+)
+
+// BindStream incrementally decodes a JSON array request body and calls fn for each item.
+//
+// Unlike Box.Bind(), which loads the whole request body into memory, BindStream
+// decodes one array element at a time. This allows bulk-import endpoints to
+// process very large payloads (100k+ elements) under constant memory.
+//
+// If a validator is set via Router.SetValidator(), each item is validated
+// before fn is called. Validation errors are returned as ValidationErrors with
+// field names prefixed by the item index (e.g., "[3].email"), so they can be
+// sent directly using ValidationProblem.
+//
+// Processing stops at the first decode, validation, or callback error.
+// Items processed before the error are not rolled back.
+//
+// Example:
+//
+//	type ImportUser struct {
+//	    Email string `json:"email" validate:"required,email"`
+//	}
+//
+//	fursy.POST[fursy.Empty, ImportResult](router, "/users/import", func(c *fursy.Box[fursy.Empty, ImportResult]) error {
+//	    count := 0
+//	    err := fursy.BindStream(c.Context, func(u ImportUser) error {
+//	        count++
+//	        return db.InsertUser(u)
+//	    })
+//	    if err != nil {
+//	        var verrs fursy.ValidationErrors
+//	        if errors.As(err, &verrs) {
+//	            return c.Problem(fursy.ValidationProblem(verrs))
+//	        }
+//	        return c.Problem(fursy.BadRequest(err.Error()))
+//	    }
+//	    return c.OK(ImportResult{Imported: count})
+//	})
+func BindStream[T any](c *Context, fn func(item T) error) error {
+	return binding.StreamJSONArray(c.Request, func(index int, item *T) error {
+		if c.router != nil && c.router.validator != nil {
+			if err := c.router.validator.Validate(item); err != nil {
+				return indexValidationError(index, err)
+			}
+		}
+		return fn(*item)
+	})
+}
+
+// indexValidationError prefixes validation error fields with the array index.
+// Non-validation errors are wrapped with the index for context.
+func indexValidationError(index int, err error) error {
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		return fmt.Errorf("item %d: %w", index, err)
+	}
+
+	indexed := make(ValidationErrors, len(verrs))
+	for i, ve := range verrs {
+		ve.Field = fmt.Sprintf("[%d].%s", index, ve.Field)
+		indexed[i] = ve
+	}
+	return indexed
+}
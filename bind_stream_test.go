@@ -0,0 +1,151 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestBindStream tests item-by-item decoding of a JSON array body.
+func TestBindStream(t *testing.T) {
+	r := New()
+
+	var names []string
+	POST[Empty, Empty](r, "/import", func(c *Box[Empty, Empty]) error {
+		err := BindStream(c.Context, func(item TestRequest) error {
+			names = append(names, item.Name)
+			return nil
+		})
+		if err != nil {
+			return c.Problem(BadRequest(err.Error()))
+		}
+		return c.NoContentSuccess()
+	})
+
+	body := `[{"name":"a"},{"name":"b"},{"name":"c"}]`
+	req := httptest.NewRequest(http.MethodPost, "/import", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if strings.Join(names, ",") != "a,b,c" {
+		t.Errorf("expected a,b,c, got %v", names)
+	}
+}
+
+// TestBindStream_LargeArray tests streaming of a large array.
+func TestBindStream_LargeArray(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i := 0; i < 10000; i++ {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(`{"name":"user` + strconv.Itoa(i) + `"}`)
+	}
+	sb.WriteByte(']')
+
+	c := newContext()
+	c.Request = httptest.NewRequest(http.MethodPost, "/import", strings.NewReader(sb.String()))
+
+	count := 0
+	err := BindStream(c, func(_ TestRequest) error {
+		count++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 10000 {
+		t.Errorf("expected 10000 items, got %d", count)
+	}
+}
+
+// TestBindStream_Errors tests invalid stream bodies.
+func TestBindStream_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+	}{
+		{"empty body", "", "application/json"},
+		{"not an array", `{"name":"a"}`, "application/json"},
+		{"invalid item", `[{"name":"a"},{"name":1}]`, "application/json"},
+		{"truncated", `[{"name":"a"},`, "application/json"},
+		{"unsupported media type", `[]`, "application/xml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newContext()
+			c.Request = httptest.NewRequest(http.MethodPost, "/import", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", tt.contentType)
+
+			err := BindStream(c, func(_ TestRequest) error { return nil })
+			if err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+// TestBindStream_Validation tests per-item validation with indexed field names.
+func TestBindStream_Validation(t *testing.T) {
+	r := New()
+	r.SetValidator(&emailValidator{})
+
+	processed := 0
+	c := newContext()
+	c.router = r
+	body := `[{"name":"a","email":"a@example.com","age":20},{"name":"b","email":"invalid","age":20}]`
+	c.Request = httptest.NewRequest(http.MethodPost, "/import", strings.NewReader(body))
+
+	err := BindStream(c, func(_ CreateUserRequest) error {
+		processed++
+		return nil
+	})
+
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	if verrs[0].Field != "[1].email" {
+		t.Errorf("expected field [1].email, got %q", verrs[0].Field)
+	}
+	if processed != 1 {
+		t.Errorf("expected 1 processed item, got %d", processed)
+	}
+}
+
+// TestBindStream_CallbackError tests that callback errors stop processing.
+func TestBindStream_CallbackError(t *testing.T) {
+	errStop := errors.New("stop")
+
+	c := newContext()
+	c.Request = httptest.NewRequest(http.MethodPost, "/import", strings.NewReader(`[{},{},{}]`))
+
+	calls := 0
+	err := BindStream(c, func(_ TestRequest) error {
+		calls++
+		if calls == 2 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("expected errStop, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
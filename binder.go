@@ -0,0 +1,83 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"mime"
+	"slices"
+)
+
+// Binder decodes a request body into v (a pointer).
+// Binders are registered via Router.RegisterBinder and used by Box.Bind.
+type Binder func(c *Context, v any) error
+
+// RegisterBinder registers a request body decoder for a media type used by Box.Bind.
+//
+// Box.Bind selects the binder by the request's Content-Type. Registered binders
+// take precedence over the built-in JSON, XML and form binders, so
+// registering a built-in media type replaces its default decoding.
+// Registered media types are also listed as request body content in the
+// generated OpenAPI document.
+//
+// Panics if the media type is invalid or bind is nil.
+//
+// Example:
+//
+//	router.RegisterBinder("application/msgpack", func(c *fursy.Context, v any) error {
+//	    return msgpack.NewDecoder(c.Request.Body).Decode(v)
+//	})
+func (r *Router) RegisterBinder(mediaType string, bind Binder) *Router {
+	if bind == nil {
+		panic("fursy: binder cannot be nil")
+	}
+	mt, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		panic("fursy: invalid binder media type: " + mediaType)
+	}
+
+	if r.binders == nil {
+		r.binders = make(map[string]Binder)
+	}
+	if _, ok := r.binders[mt]; !ok {
+		r.binderTypes = append(r.binderTypes, mt)
+	}
+	r.binders[mt] = bind
+
+	return r
+}
+
+// customBinder returns the registered binder for the request's Content-Type (if any).
+func (c *Context) customBinder() Binder {
+	if c.router == nil || c.router.binders == nil {
+		return nil
+	}
+	mt, _, err := mime.ParseMediaType(c.Request.Header.Get("Content-Type"))
+	if err != nil {
+		return nil
+	}
+	return c.router.binders[mt]
+}
+
+// requestMediaTypes returns the request body media types documented in OpenAPI.
+func (r *Router) requestMediaTypes() []string {
+	types := []string{MIMEApplicationJSON}
+	for _, mt := range r.binderTypes {
+		if !slices.Contains(types, mt) {
+			types = append(types, mt)
+		}
+	}
+	return types
+}
+
+// responseMediaTypes returns the response body media types documented in OpenAPI.
+func (r *Router) responseMediaTypes() []string {
+	types := []string{MIMEApplicationJSON}
+	for _, mt := range r.renderOffers {
+		if r.renderers[mt] != nil && !slices.Contains(types, mt) {
+			types = append(types, mt)
+		}
+	}
+	return types
+}
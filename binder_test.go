@@ -0,0 +1,98 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type binderTestUser struct {
+	Name string `json:"name"`
+}
+
+// lineBinder decodes "name" from a text/x-line body (test codec).
+func lineBinder(c *Context, v any) error {
+	line, _ := bufio.NewReader(c.Request.Body).ReadString('\n')
+	v.(*binderTestUser).Name = strings.TrimSpace(line)
+	return nil
+}
+
+// TestRouter_RegisterBinder tests custom binders in Box.Bind.
+func TestRouter_RegisterBinder(t *testing.T) {
+	r := New()
+	r.RegisterBinder("text/x-line", lineBinder)
+	POST[binderTestUser, binderTestUser](r, "/users", func(c *Box[binderTestUser, binderTestUser]) error {
+		return c.OK(*c.ReqBody)
+	})
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        string
+	}{
+		{"custom binder", "text/x-line; charset=utf-8", "Alice\n", `{"name":"Alice"}`},
+		{"built-in JSON", "application/json", `{"name":"Bob"}`, `{"name":"Bob"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK || strings.TrimSpace(w.Body.String()) != tt.want {
+				t.Errorf("expected 200 %s, got %d %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestRouter_RegisterBinder_OpenAPI tests that registered codecs appear in OpenAPI content maps.
+func TestRouter_RegisterBinder_OpenAPI(t *testing.T) {
+	r := New()
+	r.RegisterBinder(MIMEApplicationMsgPack, lineBinder)
+	r.RegisterRenderer(MIMEApplicationMsgPack, func(c *Context, status int, data any) error {
+		return c.NoContent(status)
+	})
+	r.handleRoute(http.MethodPost, "/users", func(c *Context) error { return nil }, nil, func(route *RouteInfo) {
+		route.RequestType = reflect.TypeFor[binderTestUser]()
+		route.ResponseType = reflect.TypeFor[binderTestUser]()
+	})
+
+	doc, err := r.GenerateOpenAPI(Info{Title: "Test", Version: "1.0"})
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI failed: %v", err)
+	}
+
+	op := doc.Paths["/users"].Post
+	for _, mt := range []string{MIMEApplicationJSON, MIMEApplicationMsgPack} {
+		if _, ok := op.RequestBody.Content[mt]; !ok {
+			t.Errorf("expected request body content %q", mt)
+		}
+		if _, ok := op.Responses["200"].Content[mt]; !ok {
+			t.Errorf("expected response content %q", mt)
+		}
+	}
+	if _, ok := op.Responses["200"].Content[MIMETextPlain]; ok {
+		t.Error("built-in renderers should not be listed in response content")
+	}
+}
+
+// TestRouter_RegisterBinder_Invalid tests that invalid registrations panic.
+func TestRouter_RegisterBinder_Invalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	New().RegisterBinder("text/x-line", nil)
+}
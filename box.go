@@ -5,7 +5,10 @@
 package fursy
 
 import (
+	"errors"
 	"net/http"
+	"reflect"
+	"strings"
 
 	"github.com/coregx/fursy/internal/binding"
 )
@@ -61,6 +64,20 @@ type Box[Req, Res any] struct {
 	// Set this field or use type-safe response methods (OK, Created, etc.)
 	// For handlers with no response body, use Empty type.
 	ResBody *Res
+
+	// status is the response status code set via Status().
+	// Zero means the default (successStatus).
+	status int
+
+	// successStatus is the success status of the route (see fursy.Status).
+	// Zero means 200 OK.
+	successStatus int
+
+	// pool is the pool of the route, nil for a Box created with newBox.
+	pool *boxPool[Req, Res]
+
+	// pooledReq is the request body taken from the pool, if any.
+	pooledReq *Req
 }
 
 // newBox creates a new generic Box from a Context.
@@ -203,14 +220,126 @@ func (c *Box[Req, Res]) UpdatedNoContent() error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// ========================================
+// Chained Response Methods
+// ========================================
+
+// Status sets the response status code for Send and NegotiateRes.
+// Returns the Box for method chaining.
+//
+// Example:
+//
+//	return c.Status(http.StatusPartialContent).Send(partial)
+func (c *Box[Req, Res]) Status(code int) *Box[Req, Res] {
+	c.status = code
+	return c
+}
+
+// Location sets the Location response header.
+// Returns the Box for method chaining.
+//
+// Example:
+//
+//	return c.Status(http.StatusCreated).Location("/users/123").NegotiateRes()
+func (c *Box[Req, Res]) Location(url string) *Box[Req, Res] {
+	c.SetHeader("Location", url)
+	return c
+}
+
+// Send sends data as a JSON response using the status set via Status().
+// Defaults to the success status of the route (see fursy.Status), or
+// 200 OK, if no status was set.
+//
+// Example:
+//
+//	return c.Status(http.StatusAccepted).Send(TaskResponse{TaskID: "abc123"})
+func (c *Box[Req, Res]) Send(data Res) error {
+	c.ResBody = &data
+	status := c.statusOrDefault()
+	if status == http.StatusNoContent {
+		return c.NoContent(status)
+	}
+	return c.JSON(status, data)
+}
+
+// ResponseFormat returns the media type that NegotiateRes would use
+// for the current request, based on the Accept header.
+//
+// Returns an empty string if no supported format is acceptable.
+//
+// Example:
+//
+//	if c.ResponseFormat() == fursy.MIMEApplicationXML {
+//	    c.SetHeader("X-Format", "legacy")
+//	}
+func (c *Box[Req, Res]) ResponseFormat() string {
+	return c.NegotiateFormat(c.negotiateOffers()...)
+}
+
+// NegotiateRes sends ResBody in the format selected by the Accept header
+// (JSON, XML, plain text or a registered renderer), using the status set via Status()
+// or the success status of the route (see fursy.Status).
+//
+// If ResBody is nil or the status is 204 No Content, a response with no body is sent.
+// Returns 406 Not Acceptable if no supported format is acceptable.
+//
+// Example:
+//
+//	c.ResBody = &UserResponse{ID: 1, Name: "John"}
+//	return c.NegotiateRes()
+//
+//	// Or with custom status:
+//	return c.Status(http.StatusCreated).Location("/users/1").NegotiateRes()
+func (c *Box[Req, Res]) NegotiateRes() error {
+	if c.ResBody == nil || c.statusOrDefault() == http.StatusNoContent {
+		return c.NoContent(c.statusOrDefault())
+	}
+	return c.Negotiate(c.statusOrDefault(), *c.ResBody)
+}
+
+// statusOrDefault returns the status set via Status(), the success
+// status of the route, or 200 OK.
+func (c *Box[Req, Res]) statusOrDefault() int {
+	switch {
+	case c.status != 0:
+		return c.status
+	case c.successStatus != 0:
+		return c.successStatus
+	default:
+		return http.StatusOK
+	}
+}
+
 // Bind binds the request body to ReqBody based on Content-Type.
 //
+// Fields tagged `path:"name"` and `query:"name"` are bound from path and
+// query parameters. Requests without a body only bind these fields, so
+// a GET handler can receive typed path parameters:
+//
+//	type GetUserRequest struct {
+//	    ID int `path:"id" validate:"required,min=1"`
+//	}
+//
 // Supported content types:
 //   - application/json (default)
 //   - application/xml, text/xml
 //   - application/x-www-form-urlencoded
 //   - multipart/form-data
 //
+// Multipart uploads are bound to fields of type *multipart.FileHeader
+// (or []*multipart.FileHeader for several files) named by their form tag.
+// The maxsize and accept tags check each file; failures are returned as
+// ValidationErrors:
+//
+//	type UploadAvatarRequest struct {
+//	    UserID int                   `form:"user_id"`
+//	    Avatar *multipart.FileHeader `form:"avatar" file:"true" maxsize:"5MB" accept:"image/png,image/jpeg"`
+//	}
+//
+// Patch documents (application/merge-patch+json, application/json-patch+json)
+// are not bound: only path and query fields are, and the handler applies the
+// patch to the stored resource with Context.ApplyPatch.
+//
 // If a validator is set via Router.SetValidator(), the request body
 // will be automatically validated after binding. Validation errors
 // are returned as ValidationErrors.
@@ -233,12 +362,47 @@ func (c *Box[Req, Res]) Bind() error {
 		return nil
 	}
 
-	// Allocate request body
-	req := new(Req)
+	// Allocate request body (reused across requests if pooled)
+	req := c.newReq()
 
-	// Bind using the binding system
-	if err := binding.Bind(c.Request, req); err != nil {
-		return err
+	// Patch documents are applied by the handler (see Context.ApplyPatch);
+	// only URL fields are bound and nothing is validated yet
+	if isPatchMediaType(c.Request.Header.Get("Content-Type")) {
+		if hasURLFields[Req]() {
+			if err := c.bindURL(req); err != nil {
+				return err
+			}
+		}
+		c.ReqBody = req
+		return nil
+	}
+
+	// Requests without a body (e.g., GET /users/:id) only bind URL fields
+	urlFields := hasURLFields[Req]()
+	if !urlFields || hasRequestBody(c.Request) {
+		// Bind using a registered binder (see Router.RegisterBinder) or the binding system
+		if bind := c.customBinder(); bind != nil {
+			if err := bind(c.Context, req); err != nil {
+				return err
+			}
+		} else {
+			// Parse multipart forms with the route's or router's memory limit; the binder reuses the result
+			if strings.HasPrefix(c.Request.Header.Get("Content-Type"), MIMEMultipartForm) && c.Request.MultipartForm == nil {
+				if err := c.ParseForm(); err != nil {
+					return err
+				}
+			}
+			if err := binding.Bind(c.Request, req); err != nil {
+				return fileValidationError(err)
+			}
+		}
+	}
+
+	// Bind path, query and header tagged fields; they take precedence over the body
+	if urlFields {
+		if err := c.bindURL(req); err != nil {
+			return err
+		}
 	}
 
 	// Validate if validator is set
@@ -251,3 +415,39 @@ func (c *Box[Req, Res]) Bind() error {
 	c.ReqBody = req
 	return nil
 }
+
+// bindURL binds path, query and header tagged fields of req.
+func (c *Box[Req, Res]) bindURL(req *Req) error {
+	params := make(map[string][]string, len(c.params))
+	for _, p := range c.params {
+		params[p.Key] = []string{p.Value}
+	}
+	if err := binding.MapTagged(req, binding.TagPath, params); err != nil {
+		return err
+	}
+	if err := binding.MapTagged(req, binding.TagQuery, c.Request.URL.Query()); err != nil {
+		return err
+	}
+	return binding.MapTagged(req, binding.TagHeader, c.Request.Header)
+}
+
+// hasURLFields reports whether Req has path, query or header tagged fields.
+func hasURLFields[Req any]() bool {
+	t := reflect.TypeFor[Req]()
+	return binding.HasTagged(t, binding.TagPath) || binding.HasTagged(t, binding.TagQuery) ||
+		binding.HasTagged(t, binding.TagHeader)
+}
+
+// hasRequestBody reports whether req may carry a body.
+func hasRequestBody(req *http.Request) bool {
+	return req.Body != nil && req.Body != http.NoBody && req.ContentLength != 0
+}
+
+// fileValidationError converts a failed file check into ValidationErrors.
+func fileValidationError(err error) error {
+	var fileErr *binding.FileError
+	if errors.As(err, &fileErr) {
+		return ValidationErrors{{Field: fileErr.Field, Tag: fileErr.Tag, Message: fileErr.Message}}
+	}
+	return err
+}
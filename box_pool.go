@@ -0,0 +1,58 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import "sync"
+
+// boxPool reuses the Box and request body of a generic route, so a
+// generic handler allocates about as much as a plain HandlerFunc.
+//
+// Each generic route has its own pool, as Box[Req, Res] and Req differ per route.
+type boxPool[Req, Res any] struct {
+	boxes sync.Pool
+	reqs  sync.Pool
+}
+
+// newBoxPool creates the pool of a generic route.
+func newBoxPool[Req, Res any]() *boxPool[Req, Res] {
+	p := &boxPool[Req, Res]{}
+	p.boxes.New = func() any { return &Box[Req, Res]{pool: p} }
+	p.reqs.New = func() any { return new(Req) }
+	return p
+}
+
+// get returns a zeroed Box for base.
+func (p *boxPool[Req, Res]) get(base *Context) *Box[Req, Res] {
+	c := p.boxes.Get().(*Box[Req, Res])
+	c.Context = base
+	return c
+}
+
+// put zeroes c and returns it to the pool, with its request body if the
+// router pools request bodies (see Options.PoolRequestBodies).
+func (p *boxPool[Req, Res]) put(c *Box[Req, Res]) {
+	if c.pooledReq != nil {
+		var zero Req
+		*c.pooledReq = zero
+		p.reqs.Put(c.pooledReq)
+	}
+	*c = Box[Req, Res]{pool: p}
+	p.boxes.Put(c)
+}
+
+// newReq returns a zeroed request body, from the pool if the router pools
+// request bodies.
+func (c *Box[Req, Res]) newReq() *Req {
+	if c.pool == nil || c.router == nil || !c.router.poolRequestBodies {
+		return new(Req)
+	}
+	if c.pooledReq == nil {
+		c.pooledReq = c.pool.reqs.Get().(*Req)
+	} else {
+		var zero Req
+		*c.pooledReq = zero // Bind called again.
+	}
+	return c.pooledReq
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type pooledRequest struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+// TestBoxPool_Zeroing tests that pooled boxes and request bodies do not
+// leak state between requests.
+func TestBoxPool_Zeroing(t *testing.T) {
+	for _, pooled := range []bool{false, true} {
+		r := NewWithOptions(WithRequestBodyPooling(pooled))
+		POST[pooledRequest, Empty](r, "/items", func(c *Box[pooledRequest, Empty]) error {
+			if c.status != 0 || c.ResBody != nil {
+				t.Errorf("pooled=%v: box not zeroed: status=%d ResBody=%v", pooled, c.status, c.ResBody)
+			}
+			c.Status(http.StatusCreated)
+			return c.String(c.statusOrDefault(), c.ReqBody.Name+":"+strings.Join(c.ReqBody.Tags, ","))
+		})
+
+		for _, tt := range []struct{ body, want string }{
+			{`{"name":"a","tags":["x","y"]}`, "a:x,y"},
+			{`{"name":"b"}`, "b:"},
+			{`{}`, ":"},
+		} {
+			req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", MIMEApplicationJSON)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != http.StatusCreated || w.Body.String() != tt.want {
+				t.Errorf("pooled=%v: %s = %d %q, want 201 %q", pooled, tt.body, w.Code, w.Body.String(), tt.want)
+			}
+		}
+	}
+}
+
+// TestBoxPool_BindTwice tests that binding again starts from a zero request body.
+func TestBoxPool_BindTwice(t *testing.T) {
+	r := NewWithOptions(WithRequestBodyPooling(true))
+	p := newBoxPool[pooledRequest, Empty]()
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"a","tags":["x"]}`))
+	req.Header.Set("Content-Type", MIMEApplicationJSON)
+	base := newContext()
+	base.init(httptest.NewRecorder(), req, r, nil)
+
+	c := p.get(base)
+	if err := c.Bind(); err != nil {
+		t.Fatalf("Bind: %v", err)
+	}
+
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"b"}`))
+	c.Request.Header.Set("Content-Type", MIMEApplicationJSON)
+	if err := c.Bind(); err != nil {
+		t.Fatalf("Bind: %v", err)
+	}
+	if c.ReqBody.Name != "b" || c.ReqBody.Tags != nil {
+		t.Errorf("ReqBody = %+v, want {Name:b Tags:[]}", *c.ReqBody)
+	}
+	p.put(c)
+}
@@ -6,8 +6,12 @@ package fursy
 
 import (
 	"bytes"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -455,3 +459,304 @@ func TestBox_ConvenienceMethods_RESTWorkflow(t *testing.T) {
 		t.Errorf("DELETE: expected status 204, got %d", w.Code)
 	}
 }
+
+// TestBox_StatusSend tests chained Status and Send.
+func TestBox_StatusSend(t *testing.T) {
+	r := New()
+
+	GET[Empty, TestResponse](r, "/partial", func(c *Box[Empty, TestResponse]) error {
+		return c.Status(http.StatusPartialContent).Send(TestResponse{ID: 1, Message: "partial"})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/partial", http.NoBody)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusPartialContent {
+		t.Errorf("expected status 206, got %d", w.Code)
+	}
+	expectedBody := `{"id":1,"message":"partial"}` + "\n"
+	if w.Body.String() != expectedBody {
+		t.Errorf("expected body %q, got %q", expectedBody, w.Body.String())
+	}
+}
+
+// TestBox_NegotiateRes tests content-negotiated responses with custom status and Location.
+func TestBox_NegotiateRes(t *testing.T) {
+	r := New()
+
+	POST[Empty, TestResponse](r, "/users", func(c *Box[Empty, TestResponse]) error {
+		c.ResBody = &TestResponse{ID: 7, Message: "created"}
+		return c.Status(http.StatusCreated).Location("/users/7").NegotiateRes()
+	})
+
+	tests := []struct {
+		accept      string
+		contentType string
+		contains    string
+	}{
+		{"application/json", "application/json; charset=utf-8", `"id":7`},
+		{"application/xml", "application/xml; charset=utf-8", "<ID>7</ID>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/users", http.NoBody)
+			req.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusCreated {
+				t.Errorf("expected status 201, got %d", w.Code)
+			}
+			if loc := w.Header().Get("Location"); loc != "/users/7" {
+				t.Errorf("expected Location /users/7, got %q", loc)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != tt.contentType {
+				t.Errorf("expected Content-Type %q, got %q", tt.contentType, ct)
+			}
+			if !bytes.Contains(w.Body.Bytes(), []byte(tt.contains)) {
+				t.Errorf("expected body to contain %q, got %q", tt.contains, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestBox_NegotiateRes_NilBody tests NegotiateRes without a response body.
+func TestBox_NegotiateRes_NilBody(t *testing.T) {
+	r := New()
+
+	DELETE[Empty, TestResponse](r, "/users/:id", func(c *Box[Empty, TestResponse]) error {
+		return c.Status(http.StatusNoContent).NegotiateRes()
+	})
+
+	req := httptest.NewRequest(http.MethodDelete, "/users/1", http.NoBody)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected status 204, got %d", w.Code)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("expected empty body, got %q", w.Body.String())
+	}
+}
+
+// TestBox_ResponseFormat tests access to the negotiated response format.
+func TestBox_ResponseFormat(t *testing.T) {
+	r := New()
+
+	var format string
+	GET[Empty, TestResponse](r, "/test", func(c *Box[Empty, TestResponse]) error {
+		format = c.ResponseFormat()
+		return c.NoContentSuccess()
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
+	req.Header.Set("Accept", "text/xml;q=0.9, text/plain;q=0.5")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if format != MIMETextXML {
+		t.Errorf("expected %q, got %q", MIMETextXML, format)
+	}
+}
+
+// getUserRequest is a request type bound from the URL.
+type getUserRequest struct {
+	ID     int      `path:"id"`
+	Fields []string `query:"fields"`
+	Active *bool    `query:"active"`
+}
+
+// TestBox_Bind_URLFields tests binding path and query tagged fields without a body.
+func TestBox_Bind_URLFields(t *testing.T) {
+	r := New()
+
+	var got getUserRequest
+	GET[getUserRequest, Empty](r, "/users/:id", func(c *Box[getUserRequest, Empty]) error {
+		got = *c.ReqBody
+		return c.NoContentSuccess()
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42?fields=name&fields=email&active=true", http.NoBody)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", w.Code)
+	}
+	if got.ID != 42 {
+		t.Errorf("expected ID 42, got %d", got.ID)
+	}
+	if len(got.Fields) != 2 || got.Fields[0] != "name" || got.Fields[1] != "email" {
+		t.Errorf("expected fields [name email], got %v", got.Fields)
+	}
+	if got.Active == nil || !*got.Active {
+		t.Errorf("expected active true, got %v", got.Active)
+	}
+}
+
+// TestBox_Bind_URLFieldsWithBody tests that path fields override body fields.
+func TestBox_Bind_URLFieldsWithBody(t *testing.T) {
+	type updateRequest struct {
+		ID   int    `json:"id" path:"id"`
+		Name string `json:"name"`
+	}
+
+	r := New()
+
+	var got updateRequest
+	PUT[updateRequest, Empty](r, "/users/:id", func(c *Box[updateRequest, Empty]) error {
+		got = *c.ReqBody
+		return c.NoContentSuccess()
+	})
+
+	req := httptest.NewRequest(http.MethodPut, "/users/7", bytes.NewBufferString(`{"id":1,"name":"Alice"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", w.Code)
+	}
+	if got.ID != 7 || got.Name != "Alice" {
+		t.Errorf("expected {7 Alice}, got %+v", got)
+	}
+}
+
+// TestBox_Bind_HeaderFields tests binding header tagged fields.
+func TestBox_Bind_HeaderFields(t *testing.T) {
+	type versionedRequest struct {
+		ID      int `path:"id"`
+		Version int `header:"X-Api-Version"`
+	}
+
+	r := New()
+
+	var got versionedRequest
+	GET[versionedRequest, Empty](r, "/users/:id", func(c *Box[versionedRequest, Empty]) error {
+		got = *c.ReqBody
+		return c.NoContentSuccess()
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", http.NoBody)
+	req.Header.Set("x-api-version", "3")
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", w.Code)
+	}
+	if got.ID != 42 || got.Version != 3 {
+		t.Errorf("expected {42 3}, got %+v", got)
+	}
+
+	params := urlParameters(reflect.TypeFor[versionedRequest]())
+	if len(params) != 2 || params[1].In != "header" || params[1].Name != "X-Api-Version" {
+		t.Errorf("expected X-Api-Version header parameter, got %+v", params)
+	}
+}
+
+// TestBox_Bind_URLFieldsInvalid tests that invalid path values fail binding.
+func TestBox_Bind_URLFieldsInvalid(t *testing.T) {
+	r := New()
+
+	var bindErr error
+	r.SetErrorHandler(func(c *Context, err error) {
+		bindErr = err
+		_ = c.String(http.StatusBadRequest, err.Error())
+	})
+	GET[getUserRequest, Empty](r, "/users/:id", func(c *Box[getUserRequest, Empty]) error {
+		return c.NoContentSuccess()
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/abc", http.NoBody)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+	if bindErr == nil || !strings.Contains(bindErr.Error(), `path parameter "id"`) {
+		t.Errorf("expected path parameter error, got %v", bindErr)
+	}
+}
+
+// TestBox_Bind_URLFieldsValidated tests that URL fields are validated.
+func TestBox_Bind_URLFieldsValidated(t *testing.T) {
+	r := New()
+	r.SetValidator(&mockValidator{shouldFail: true})
+
+	called := false
+	GET[getUserRequest, Empty](r, "/users/:id", func(c *Box[getUserRequest, Empty]) error {
+		called = true
+		return c.NoContentSuccess()
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/1", http.NoBody)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	if called {
+		t.Error("expected handler not to be called when validation fails")
+	}
+}
+
+// uploadRequest is a request type with a file field.
+type uploadRequest struct {
+	Title  string                `form:"title"`
+	Avatar *multipart.FileHeader `form:"avatar" file:"true" maxsize:"1KB" accept:"image/png"`
+}
+
+// TestBox_Bind_File tests binding an uploaded file and rejecting invalid ones.
+func TestBox_Bind_File(t *testing.T) {
+	r := New()
+
+	var bindErr error
+	r.SetErrorHandler(func(c *Context, err error) {
+		bindErr = err
+		_ = c.String(http.StatusUnprocessableEntity, err.Error())
+	})
+
+	var got uploadRequest
+	POST[uploadRequest, Empty](r, "/avatar", func(c *Box[uploadRequest, Empty]) error {
+		got = *c.ReqBody
+		return c.NoContentSuccess()
+	})
+
+	upload := func(contentType string) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		writer := multipart.NewWriter(&buf)
+		_ = writer.WriteField("title", "Me")
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", `form-data; name="avatar"; filename="me.png"`)
+		header.Set("Content-Type", contentType)
+		part, _ := writer.CreatePart(header)
+		_, _ = part.Write([]byte("png"))
+		_ = writer.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/avatar", &buf)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := upload("image/png"); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if got.Title != "Me" || got.Avatar == nil || got.Avatar.Filename != "me.png" {
+		t.Errorf("unexpected request %+v", got)
+	}
+
+	upload("image/gif")
+	verrs, ok := bindErr.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Field != "avatar" || verrs[0].Tag != "accept" {
+		t.Errorf("expected accept ValidationErrors, got %v", bindErr)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheOptions describes the caching policy of a response (RFC 9111).
+// Zero durations are omitted.
+type CacheOptions struct {
+	// Public allows shared caches (CDNs, proxies) to store the response,
+	// even if it would normally be non-cacheable (e.g., authenticated requests).
+	Public bool
+
+	// Private restricts caching to the client's private cache.
+	// Takes precedence over Public.
+	Private bool
+
+	// NoCache requires caches to revalidate with the origin before each use.
+	NoCache bool
+
+	// NoStore forbids storing the response in any cache.
+	// When set, all other directives except NoTransform are omitted.
+	NoStore bool
+
+	// MaxAge is the time the response is considered fresh (max-age).
+	// Also sets the Expires header for HTTP/1.0 caches.
+	MaxAge time.Duration
+
+	// SharedMaxAge overrides MaxAge for shared caches (s-maxage).
+	SharedMaxAge time.Duration
+
+	// StaleWhileRevalidate lets caches serve a stale response while
+	// revalidating in the background (RFC 5861).
+	StaleWhileRevalidate time.Duration
+
+	// StaleIfError lets caches serve a stale response when the origin
+	// returns an error (RFC 5861).
+	StaleIfError time.Duration
+
+	// MustRevalidate forbids serving a stale response without revalidation.
+	MustRevalidate bool
+
+	// ProxyRevalidate is MustRevalidate for shared caches only.
+	ProxyRevalidate bool
+
+	// Immutable indicates the response will not change while fresh
+	// (e.g., fingerprinted static assets).
+	Immutable bool
+
+	// NoTransform forbids intermediaries from transforming the response.
+	NoTransform bool
+
+	// SurrogateMaxAge sets the Surrogate-Control header for CDNs
+	// (e.g., Fastly, Akamai). CDNs strip it before forwarding the response,
+	// so the CDN can cache longer than browsers.
+	SurrogateMaxAge time.Duration
+
+	// SurrogateKeys sets the Surrogate-Key header (space-separated tags)
+	// used by CDNs for targeted purging.
+	SurrogateKeys []string
+}
+
+// String returns the Cache-Control header value for the options.
+func (o CacheOptions) String() string {
+	var directives []string
+
+	if o.NoStore {
+		directives = append(directives, "no-store")
+		if o.NoTransform {
+			directives = append(directives, "no-transform")
+		}
+		return strings.Join(directives, ", ")
+	}
+
+	switch {
+	case o.Private:
+		directives = append(directives, "private")
+	case o.Public:
+		directives = append(directives, "public")
+	}
+	if o.NoCache {
+		directives = append(directives, "no-cache")
+	}
+	if o.MaxAge > 0 {
+		directives = append(directives, "max-age="+seconds(o.MaxAge))
+	}
+	if o.SharedMaxAge > 0 && !o.Private {
+		directives = append(directives, "s-maxage="+seconds(o.SharedMaxAge))
+	}
+	if o.StaleWhileRevalidate > 0 {
+		directives = append(directives, "stale-while-revalidate="+seconds(o.StaleWhileRevalidate))
+	}
+	if o.StaleIfError > 0 {
+		directives = append(directives, "stale-if-error="+seconds(o.StaleIfError))
+	}
+	if o.MustRevalidate {
+		directives = append(directives, "must-revalidate")
+	}
+	if o.ProxyRevalidate && !o.Private {
+		directives = append(directives, "proxy-revalidate")
+	}
+	if o.Immutable {
+		directives = append(directives, "immutable")
+	}
+	if o.NoTransform {
+		directives = append(directives, "no-transform")
+	}
+
+	return strings.Join(directives, ", ")
+}
+
+// seconds formats d as whole seconds (rounded down, at least 0).
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64(max(d/time.Second, 0)), 10)
+}
+
+// CacheControl sets the Cache-Control header (and the related Expires,
+// Pragma, Surrogate-Control and Surrogate-Key headers) from opts.
+//
+// Example (fingerprinted static asset):
+//
+//	c.CacheControl(fursy.CacheOptions{
+//	    Public:    true,
+//	    MaxAge:    365 * 24 * time.Hour,
+//	    Immutable: true,
+//	})
+//	// Cache-Control: public, max-age=31536000, immutable
+//
+// Example (CDN caches for an hour, browsers for a minute):
+//
+//	c.CacheControl(fursy.CacheOptions{
+//	    Public:          true,
+//	    MaxAge:          time.Minute,
+//	    SurrogateMaxAge: time.Hour,
+//	    SurrogateKeys:   []string{"products", "product-" + id},
+//	})
+func (c *Context) CacheControl(opts CacheOptions) {
+	h := c.Response.Header()
+
+	h.Set("Cache-Control", opts.String())
+
+	switch {
+	case opts.NoStore || opts.NoCache:
+		h.Set("Pragma", "no-cache")
+		h.Set("Expires", expiredDate)
+	case opts.MaxAge > 0:
+		h.Del("Pragma")
+		h.Set("Expires", time.Now().Add(opts.MaxAge).UTC().Format(http.TimeFormat))
+	}
+
+	if opts.SurrogateMaxAge > 0 && !opts.NoStore {
+		h.Set("Surrogate-Control", "max-age="+seconds(opts.SurrogateMaxAge))
+	}
+	if len(opts.SurrogateKeys) > 0 {
+		h.Set("Surrogate-Key", strings.Join(opts.SurrogateKeys, " "))
+	}
+}
+
+// expiredDate is an Expires value in the past, marking the response as already stale.
+const expiredDate = "Thu, 01 Jan 1970 00:00:00 GMT"
+
+// NoCache marks the response as non-cacheable by browsers, proxies and CDNs.
+//
+// Sets:
+//
+//	Cache-Control: no-store
+//	Pragma: no-cache
+//	Expires: Thu, 01 Jan 1970 00:00:00 GMT
+//	Surrogate-Control: no-store
+//
+// Example:
+//
+//	router.GET("/me", func(c *fursy.Context) error {
+//	    c.NoCache()
+//	    return c.OK(currentUser)
+//	})
+func (c *Context) NoCache() {
+	c.CacheControl(CacheOptions{NoStore: true})
+	c.Response.Header().Set("Surrogate-Control", "no-store")
+}
+
+// Expires sets the Expires header to t (in HTTP date format).
+//
+// Prefer CacheControl with MaxAge: Cache-Control takes precedence over
+// Expires in HTTP/1.1 caches. Expires is useful for a fixed point in time,
+// such as the end of a sale.
+//
+// Example:
+//
+//	c.Expires(saleEndsAt)
+func (c *Context) Expires(t time.Time) {
+	c.Response.Header().Set("Expires", t.UTC().Format(http.TimeFormat))
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// serveWithCache runs handler and returns the response headers.
+func serveWithCache(t *testing.T, handler HandlerFunc) http.Header {
+	t.Helper()
+	router := New()
+	router.GET("/", handler)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	return rec.Header()
+}
+
+func TestCacheOptions_String(t *testing.T) {
+	tests := []struct {
+		name string
+		opts CacheOptions
+		want string
+	}{
+		{"empty", CacheOptions{}, ""},
+		{"public max-age", CacheOptions{Public: true, MaxAge: time.Hour}, "public, max-age=3600"},
+		{
+			"immutable asset",
+			CacheOptions{Public: true, MaxAge: 365 * 24 * time.Hour, Immutable: true},
+			"public, max-age=31536000, immutable",
+		},
+		{"private wins over public", CacheOptions{Public: true, Private: true, MaxAge: time.Minute}, "private, max-age=60"},
+		{"private drops shared directives", CacheOptions{Private: true, SharedMaxAge: time.Hour, ProxyRevalidate: true}, "private"},
+		{
+			"shared max-age and stale",
+			CacheOptions{Public: true, MaxAge: time.Minute, SharedMaxAge: time.Hour, StaleWhileRevalidate: 30 * time.Second, StaleIfError: 24 * time.Hour},
+			"public, max-age=60, s-maxage=3600, stale-while-revalidate=30, stale-if-error=86400",
+		},
+		{"no-cache revalidate", CacheOptions{NoCache: true, MustRevalidate: true}, "no-cache, must-revalidate"},
+		{"no-store wins", CacheOptions{NoStore: true, Public: true, MaxAge: time.Hour, NoTransform: true}, "no-store, no-transform"},
+		{"sub-second rounds down", CacheOptions{MaxAge: 1500 * time.Millisecond}, "max-age=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContext_CacheControl_MaxAge(t *testing.T) {
+	h := serveWithCache(t, func(c *Context) error {
+		c.CacheControl(CacheOptions{Public: true, MaxAge: time.Hour})
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	if got := h.Get("Cache-Control"); got != "public, max-age=3600" {
+		t.Errorf("Cache-Control = %q", got)
+	}
+	if h.Get("Pragma") != "" {
+		t.Errorf("unexpected Pragma %q", h.Get("Pragma"))
+	}
+
+	expires, err := http.ParseTime(h.Get("Expires"))
+	if err != nil {
+		t.Fatalf("invalid Expires %q: %v", h.Get("Expires"), err)
+	}
+	if d := time.Until(expires); d < 59*time.Minute || d > time.Hour+time.Second {
+		t.Errorf("Expires %v is not about an hour from now", expires)
+	}
+}
+
+func TestContext_CacheControl_Surrogate(t *testing.T) {
+	h := serveWithCache(t, func(c *Context) error {
+		c.CacheControl(CacheOptions{
+			Public:          true,
+			MaxAge:          time.Minute,
+			SurrogateMaxAge: time.Hour,
+			SurrogateKeys:   []string{"products", "product-42"},
+		})
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	if got := h.Get("Surrogate-Control"); got != "max-age=3600" {
+		t.Errorf("Surrogate-Control = %q", got)
+	}
+	if got := h.Get("Surrogate-Key"); got != "products product-42" {
+		t.Errorf("Surrogate-Key = %q", got)
+	}
+}
+
+func TestContext_NoCache(t *testing.T) {
+	h := serveWithCache(t, func(c *Context) error {
+		c.NoCache()
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	want := map[string]string{
+		"Cache-Control":     "no-store",
+		"Pragma":            "no-cache",
+		"Expires":           "Thu, 01 Jan 1970 00:00:00 GMT",
+		"Surrogate-Control": "no-store",
+	}
+	for k, v := range want {
+		if got := h.Get(k); got != v {
+			t.Errorf("%s = %q, want %q", k, got, v)
+		}
+	}
+}
+
+func TestContext_CacheControl_ReplacesNoCache(t *testing.T) {
+	h := serveWithCache(t, func(c *Context) error {
+		c.NoCache()
+		c.CacheControl(CacheOptions{Private: true, MaxAge: time.Minute})
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	if got := h.Get("Cache-Control"); got != "private, max-age=60" {
+		t.Errorf("Cache-Control = %q", got)
+	}
+	if h.Get("Pragma") != "" {
+		t.Errorf("expected Pragma to be removed, got %q", h.Get("Pragma"))
+	}
+}
+
+func TestContext_Expires(t *testing.T) {
+	at := time.Date(2030, 1, 2, 15, 4, 5, 0, time.FixedZone("X", 3600))
+	h := serveWithCache(t, func(c *Context) error {
+		c.Expires(at)
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	if got := h.Get("Expires"); got != "Wed, 02 Jan 2030 14:04:05 GMT" {
+		t.Errorf("Expires = %q", got)
+	}
+}
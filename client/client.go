@@ -0,0 +1,428 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package client provides a resilient HTTP client for outbound calls from handlers.
+//
+// Client is an http.RoundTripper adding:
+//   - Retries with jittered exponential backoff (idempotent requests only)
+//   - Hedged requests: a duplicate request is sent when the first one is
+//     slower than a latency threshold, and the first response wins
+//   - Per-host circuit breaking, failing fast while a host is unhealthy
+//   - Propagation of trace and request ID headers from the inbound request
+//
+// Example:
+//
+//	users := client.New(client.Config{
+//	    MaxAttempts: 3,
+//	    HedgeAfter:  50 * time.Millisecond,
+//	})
+//
+//	router.GET("/profile/:id", func(c *fursy.Context) error {
+//	    req, err := users.NewRequest(c, http.MethodGet, "http://users/v1/users/"+c.Param("id"), nil)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    resp, err := users.Do(req)
+//	    if errors.Is(err, client.ErrCircuitOpen) {
+//	        return c.Problem(fursy.ServiceUnavailable("User service unavailable"))
+//	    }
+//	    if err != nil {
+//	        return err
+//	    }
+//	    defer resp.Body.Close()
+//	    // ...
+//	})
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// Default values for the Client.
+const (
+	// DefaultMaxAttempts is the default number of attempts per request.
+	DefaultMaxAttempts = 3
+
+	// DefaultMaxHedges is the default number of hedged requests per attempt.
+	DefaultMaxHedges = 1
+
+	// DefaultBreakerFailures is the default number of consecutive failures
+	// opening the circuit of a host.
+	DefaultBreakerFailures = 5
+
+	// DefaultBreakerTimeout is the default time a circuit stays open before
+	// a probe request is allowed.
+	DefaultBreakerTimeout = 30 * time.Second
+)
+
+// DefaultPropagateHeaders lists the inbound request headers copied by NewRequest:
+// W3C Trace Context, W3C Baggage and request IDs.
+var DefaultPropagateHeaders = []string{"Traceparent", "Tracestate", "Baggage", "X-Request-Id"}
+
+// ErrCircuitOpen is returned when the circuit of the request host is open.
+var ErrCircuitOpen = errors.New("client: circuit breaker is open")
+
+// Config defines the config for the Client.
+type Config struct {
+	// Transport performs the requests.
+	// Default: http.DefaultTransport
+	Transport http.RoundTripper
+
+	// MaxAttempts is the number of attempts of idempotent requests
+	// (GET, HEAD, OPTIONS, TRACE, PUT, DELETE, or with an Idempotency-Key
+	// header). Requests with a body are only retried if it can be replayed
+	// (http.Request.GetBody). Set to 1 to disable retries.
+	// Default: 3
+	MaxAttempts int
+
+	// Backoff returns the delay before retry attempt+1. A Retry-After header
+	// of the failed response takes precedence.
+	// Default: ExponentialBackoff(100ms, 2s)
+	Backoff func(attempt int) time.Duration
+
+	// Retryable reports whether an attempt failed and may be retried.
+	// Default: DefaultRetryable
+	Retryable func(resp *http.Response, err error) bool
+
+	// HedgeAfter sends a hedged (duplicate) request when an attempt of an
+	// idempotent request has not completed after this duration. The first
+	// successful response is returned and the other requests are canceled.
+	// Default: 0 (hedging disabled)
+	HedgeAfter time.Duration
+
+	// MaxHedges is the maximum number of hedged requests per attempt.
+	// Default: 1
+	MaxHedges int
+
+	// BreakerFailures is the number of consecutive failed attempts (network
+	// errors and 5xx responses) opening the circuit of a host. While open,
+	// requests fail fast with ErrCircuitOpen; after BreakerTimeout, a single
+	// probe request is let through and closes the circuit on success.
+	// Set to -1 to disable circuit breaking.
+	// Default: 5
+	BreakerFailures int
+
+	// BreakerTimeout is the time a circuit stays open.
+	// Default: 30s
+	BreakerTimeout time.Duration
+
+	// PropagateHeaders lists the inbound request headers copied by NewRequest.
+	// Default: DefaultPropagateHeaders
+	PropagateHeaders []string
+
+	// Propagate is called with every outgoing request, e.g. to inject
+	// OpenTelemetry context:
+	//
+	//	Propagate: func(req *http.Request) {
+	//	    otel.GetTextMapPropagator().Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+	//	},
+	//
+	// Default: nil
+	Propagate func(req *http.Request)
+}
+
+// Client is a resilient http.RoundTripper for outbound calls.
+//
+// Client is safe for concurrent use.
+type Client struct {
+	config Config
+	http   *http.Client
+
+	mu       sync.Mutex
+	breakers map[string]*breaker
+}
+
+// New creates a Client.
+//
+// Example:
+//
+//	c := client.New(client.Config{HedgeAfter: 100 * time.Millisecond})
+//	resp, err := c.Do(req)
+//
+//	// Or as the transport of an existing http.Client or SDK:
+//	sdk := payments.NewClient(payments.WithHTTPClient(c.HTTPClient()))
+func New(config Config) *Client {
+	// Set defaults.
+	if config.Transport == nil {
+		config.Transport = http.DefaultTransport
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.Backoff == nil {
+		config.Backoff = ExponentialBackoff(100*time.Millisecond, 2*time.Second)
+	}
+	if config.Retryable == nil {
+		config.Retryable = DefaultRetryable
+	}
+	if config.MaxHedges <= 0 {
+		config.MaxHedges = DefaultMaxHedges
+	}
+	if config.BreakerFailures == 0 {
+		config.BreakerFailures = DefaultBreakerFailures
+	}
+	if config.BreakerTimeout <= 0 {
+		config.BreakerTimeout = DefaultBreakerTimeout
+	}
+	if config.PropagateHeaders == nil {
+		config.PropagateHeaders = DefaultPropagateHeaders
+	}
+
+	cl := &Client{
+		config:   config,
+		breakers: make(map[string]*breaker),
+	}
+	cl.http = &http.Client{Transport: cl}
+	return cl
+}
+
+// HTTPClient returns an http.Client using the Client as transport.
+func (cl *Client) HTTPClient() *http.Client {
+	return cl.http
+}
+
+// Do sends the request (see http.Client.Do).
+func (cl *Client) Do(req *http.Request) (*http.Response, error) {
+	return cl.http.Do(req)
+}
+
+// NewRequest creates an outbound request bound to the context of the inbound
+// request, so it is canceled with it, and copies the PropagateHeaders of the
+// inbound request.
+func (cl *Client) NewRequest(c *fursy.Context, method, url string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(c.Request.Context(), method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range cl.config.PropagateHeaders {
+		if value := c.Request.Header.Get(name); value != "" {
+			req.Header.Set(name, value)
+		}
+	}
+	return req, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (cl *Client) RoundTrip(req *http.Request) (*http.Response, error) {
+	b := cl.breaker(req.URL.Host)
+	if b != nil && !b.allow() {
+		return nil, fmt.Errorf("%w: %s", ErrCircuitOpen, req.URL.Host)
+	}
+
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	repeatable := replayable && isIdempotent(req)
+	attempts := 1
+	if repeatable {
+		attempts = cl.config.MaxAttempts
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		resp, err := cl.attempt(req, repeatable)
+		if b != nil {
+			b.record((err != nil && !errors.Is(err, context.Canceled)) || (err == nil && resp.StatusCode >= http.StatusInternalServerError))
+		}
+		if !cl.config.Retryable(resp, err) || attempt >= attempts || ctx.Err() != nil || (b != nil && b.isOpen()) {
+			return resp, err
+		}
+
+		delay := cl.config.Backoff(attempt)
+		if d, ok := retryAfter(resp); ok {
+			delay = d
+		}
+		discard(resp)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, errors.Join(err, ctx.Err())
+		}
+	}
+}
+
+// result is the outcome of a single request.
+type result struct {
+	resp *http.Response
+	err  error
+	i    int // index of the hedged request
+}
+
+// attempt sends one attempt, hedged if enabled and the request is repeatable.
+func (cl *Client) attempt(req *http.Request, repeatable bool) (*http.Response, error) {
+	if !repeatable || cl.config.HedgeAfter <= 0 {
+		return cl.send(req.Context(), req)
+	}
+
+	results := make(chan result, cl.config.MaxHedges+1)
+	var cancels []context.CancelFunc
+	launch := func() {
+		ctx, cancel := context.WithCancel(req.Context())
+		i := len(cancels)
+		cancels = append(cancels, cancel)
+		go func() {
+			resp, err := cl.send(ctx, req)
+			results <- result{resp, err, i}
+		}()
+	}
+
+	launch()
+	inFlight := 1
+	timer := time.NewTimer(cl.config.HedgeAfter)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			if len(cancels) <= cl.config.MaxHedges {
+				launch()
+				inFlight++
+				timer.Reset(cl.config.HedgeAfter)
+			}
+		case r := <-results:
+			inFlight--
+			if inFlight > 0 && cl.config.Retryable(r.resp, r.err) {
+				// Wait for the other requests.
+				discard(r.resp)
+				cancels[r.i]()
+				continue
+			}
+
+			// Cancel and release the losing requests.
+			for i, cancel := range cancels {
+				if i != r.i {
+					cancel()
+				}
+			}
+			go func(n int) {
+				for range n {
+					discard((<-results).resp)
+				}
+			}(inFlight)
+
+			if r.resp == nil {
+				cancels[r.i]()
+				return nil, r.err
+			}
+			r.resp.Body = &cancelBody{ReadCloser: r.resp.Body, cancel: cancels[r.i]}
+			return r.resp, r.err
+		}
+	}
+}
+
+// send sends a copy of req bound to ctx.
+func (cl *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	out := req.Clone(ctx)
+	if req.GetBody != nil && req.Body != nil && req.Body != http.NoBody {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		out.Body = body
+	}
+	if cl.config.Propagate != nil {
+		cl.config.Propagate(out)
+	}
+	return cl.config.Transport.RoundTrip(out)
+}
+
+// breaker returns the circuit breaker of a host (nil if disabled).
+func (cl *Client) breaker(host string) *breaker {
+	if cl.config.BreakerFailures < 0 {
+		return nil
+	}
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	b, ok := cl.breakers[host]
+	if !ok {
+		b = &breaker{threshold: cl.config.BreakerFailures, timeout: cl.config.BreakerTimeout}
+		cl.breakers[host] = b
+	}
+	return b
+}
+
+// DefaultRetryable reports network errors and 429, 502, 503 and 504
+// responses as retryable. Canceled requests are not retried.
+func DefaultRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// ExponentialBackoff returns a backoff function doubling the delay from base
+// up to maxDelay, with random jitter (50-100% of the delay) to spread retries.
+func ExponentialBackoff(base, maxDelay time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		delay := base
+		for i := 1; i < attempt && delay < maxDelay; i++ {
+			delay *= 2
+		}
+		delay = min(delay, maxDelay)
+
+		half := delay / 2
+		if half <= 0 {
+			return delay
+		}
+		return half + rand.N(half+1) //nolint:gosec // Jitter does not need a secure source.
+	}
+}
+
+// isIdempotent reports whether a request may be sent more than once.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != "" || req.Header.Get("X-Idempotency-Key") != ""
+}
+
+// retryAfter returns the delay of a Retry-After header in seconds.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// discard drains and closes a response body so the connection can be reused.
+func discard(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	_ = resp.Body.Close()
+}
+
+// cancelBody cancels the context of a hedged request when its body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and cancels the request context.
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,258 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy"
+	"github.com/coregx/fursy/client"
+)
+
+// noBackoff retries immediately.
+func noBackoff(int) time.Duration { return 0 }
+
+func get(t *testing.T, cl *client.Client, url string) (*http.Response, error) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cl.Do(req)
+}
+
+func TestClient_Retry(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	cl := client.New(client.Config{Backoff: noBackoff})
+	resp, err := get(t, cl, srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != "ok" || calls.Load() != 3 {
+		t.Errorf("got %d %q after %d calls", resp.StatusCode, body, calls.Load())
+	}
+}
+
+func TestClient_RetryExhausted(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	cl := client.New(client.Config{MaxAttempts: 2, Backoff: noBackoff})
+	resp, err := get(t, cl, srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway || calls.Load() != 2 {
+		t.Errorf("got %d after %d calls", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestClient_NoRetryForNonIdempotent(t *testing.T) {
+	var calls atomic.Int32
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	cl := client.New(client.Config{Backoff: noBackoff})
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader("order"))
+	resp, err := cl.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if calls.Load() != 1 {
+		t.Errorf("POST sent %d times, want 1", calls.Load())
+	}
+
+	// An Idempotency-Key makes POST retryable; the body is replayed.
+	calls.Store(0)
+	bodies = nil
+	req, _ = http.NewRequest(http.MethodPost, srv.URL, strings.NewReader("order"))
+	req.Header.Set("Idempotency-Key", "k1")
+	resp, err = cl.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if calls.Load() != 3 || bodies[2] != "order" {
+		t.Errorf("POST with Idempotency-Key sent %d times, bodies %q", calls.Load(), bodies)
+	}
+}
+
+func TestClient_Hedge(t *testing.T) {
+	var calls atomic.Int32
+	canceled := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			// First request is slow: the hedged request wins.
+			select {
+			case <-r.Context().Done():
+				canceled <- struct{}{}
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		_, _ = io.WriteString(w, "hedged")
+	}))
+	defer srv.Close()
+
+	cl := client.New(client.Config{HedgeAfter: 20 * time.Millisecond})
+	start := time.Now()
+	resp, err := get(t, cl, srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if string(body) != "hedged" || time.Since(start) > 2*time.Second {
+		t.Errorf("got %q after %v", body, time.Since(start))
+	}
+	select {
+	case <-canceled:
+	case <-time.After(2 * time.Second):
+		t.Error("slow request not canceled")
+	}
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	var calls atomic.Int32
+	var healthy atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	cl := client.New(client.Config{
+		MaxAttempts:     1,
+		BreakerFailures: 2,
+		BreakerTimeout:  50 * time.Millisecond,
+	})
+
+	for range 2 {
+		resp, err := get(t, cl, srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+	if _, err := get(t, cl, srv.URL); !errors.Is(err, client.ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("calls = %d, want 2 (open circuit fails fast)", calls.Load())
+	}
+
+	// After the timeout, a successful probe closes the circuit.
+	time.Sleep(60 * time.Millisecond)
+	healthy.Store(true)
+	for range 2 {
+		resp, err := get(t, cl, srv.URL)
+		if err != nil {
+			t.Fatalf("after recovery: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+}
+
+func TestClient_NewRequestPropagation(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer srv.Close()
+
+	cl := client.New(client.Config{
+		Propagate: func(req *http.Request) { req.Header.Set("X-Caller", "api") },
+	})
+
+	router := fursy.New()
+	router.GET("/", func(c *fursy.Context) error {
+		req, err := cl.NewRequest(c, http.MethodGet, srv.URL, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := cl.Do(req)
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Set("X-Request-ID", "req-1")
+	req.Header.Set("Authorization", "Bearer secret")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d", w.Code)
+	}
+	if got.Get("Traceparent") == "" || got.Get("X-Request-Id") != "req-1" || got.Get("X-Caller") != "api" {
+		t.Errorf("headers = %v", got)
+	}
+	if got.Get("Authorization") != "" {
+		t.Error("Authorization must not be propagated")
+	}
+}
+
+func TestClient_ContextCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	cl := client.New(client.Config{Backoff: func(int) time.Duration { return time.Hour }})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, http.NoBody)
+	if _, err := cl.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := client.ExponentialBackoff(100*time.Millisecond, time.Second)
+	for attempt, maxDelay := range map[int]time.Duration{1: 100 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		if d := backoff(attempt); d < maxDelay/2 || d > maxDelay {
+			t.Errorf("attempt %d: delay %v not in [%v, %v]", attempt, d, maxDelay/2, maxDelay)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrClientGone is returned by the response methods of Context (JSON, XML,
+// HTML, Problem) and by Context.Next when the client has disconnected and
+// the router is created with Options.CancelOnDisconnect.
+var ErrClientGone = errors.New("fursy: client disconnected")
+
+// IsClientGone reports whether the client has disconnected, i.e. the
+// request context was canceled (net/http cancels it when the connection
+// closes). Deadlines set with RouteOptions.Timeout do not count.
+//
+// Long-running handlers should check it (or select on
+// c.Request.Context().Done()) between steps, to stop working for a caller
+// that is no longer there.
+//
+// Example:
+//
+//	for _, chunk := range reportChunks {
+//	    if c.IsClientGone() {
+//	        return fursy.ErrClientGone
+//	    }
+//	    report.Add(compute(chunk))
+//	}
+func (c *Context) IsClientGone() bool {
+	return c.Request != nil && errors.Is(c.Request.Context().Err(), context.Canceled)
+}
+
+// shouldCancel reports whether the request should stop because the client
+// has disconnected (see Options.CancelOnDisconnect).
+func (c *Context) shouldCancel() bool {
+	return c.router != nil && c.router.cancelOnDisconnect && c.IsClientGone()
+}
+
+// CanceledRequests returns the number of requests whose client disconnected
+// before the handler chain returned.
+//
+// Example:
+//
+//	router.GET("/debug/requests", func(c *fursy.Context) error {
+//	    return c.JSON(200, map[string]int64{
+//	        "in_flight": router.InFlight(),
+//	        "canceled":  router.CanceledRequests(),
+//	    })
+//	})
+func (r *Router) CanceledRequests() int64 {
+	return r.canceledRequests.Load()
+}
+
+// finishChain handles the error returned by the handler chain of c.
+// Requests whose client disconnected are counted, and get no error
+// response when Options.CancelOnDisconnect is set.
+func (r *Router) finishChain(c *Context, err error) {
+	if c.IsClientGone() {
+		r.canceledRequests.Add(1)
+		if r.cancelOnDisconnect {
+			return
+		}
+	}
+	if err != nil {
+		r.handleError(c, err)
+	}
+}
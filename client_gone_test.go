@@ -0,0 +1,120 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// goneRequest returns a request whose client has disconnected.
+func goneRequest(path string) *http.Request {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return httptest.NewRequest(http.MethodGet, path, http.NoBody).WithContext(ctx)
+}
+
+// TestContext_IsClientGone tests client disconnect detection.
+func TestContext_IsClientGone(t *testing.T) {
+	c := newContext()
+	c.Request = httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	if c.IsClientGone() {
+		t.Error("expected client to be connected")
+	}
+
+	c.Request = goneRequest("/")
+	if !c.IsClientGone() {
+		t.Error("expected client to be gone")
+	}
+
+	// Deadlines are not disconnects.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	c.Request = c.Request.WithContext(ctx)
+	if c.IsClientGone() {
+		t.Error("expected deadline not to count as disconnect")
+	}
+}
+
+// TestRouter_CancelOnDisconnect tests stopping requests whose client disconnected.
+func TestRouter_CancelOnDisconnect(t *testing.T) {
+	var handlerCalled, errorHandled bool
+	var renderErr error
+	r := NewWithOptions(WithCancelOnDisconnect(true))
+	r.SetErrorHandler(func(c *Context, err error) {
+		errorHandled = true
+	})
+	ctx, disconnect := context.WithCancel(context.Background())
+	r.Use(func(c *Context) error {
+		if c.Request.Context() == ctx {
+			// The client disconnects while the middleware runs.
+			disconnect()
+		}
+		renderErr = c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+		return c.Next()
+	})
+	r.GET("/report", func(c *Context) error {
+		handlerCalled = true
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/report", http.NoBody).WithContext(ctx))
+
+	if !errors.Is(renderErr, ErrClientGone) {
+		t.Errorf("JSON error = %v, want ErrClientGone", renderErr)
+	}
+	if handlerCalled {
+		t.Error("expected handler not to be called")
+	}
+	if errorHandled {
+		t.Error("expected no error response")
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("expected empty body, got %q", w.Body.String())
+	}
+	if got := r.CanceledRequests(); got != 1 {
+		t.Errorf("CanceledRequests() = %d, want 1", got)
+	}
+
+	// Requests of clients gone before routing run no handler.
+	renderErr = nil
+	r.ServeHTTP(httptest.NewRecorder(), goneRequest("/report"))
+	if renderErr != nil || handlerCalled || r.CanceledRequests() != 2 {
+		t.Errorf("chain ran for a gone client (canceled %d)", r.CanceledRequests())
+	}
+
+	// Connected clients are served.
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/report", http.NoBody))
+	if !handlerCalled || w.Body.Len() == 0 || r.CanceledRequests() != 2 {
+		t.Errorf("handler called %v, body %q, canceled %d", handlerCalled, w.Body.String(), r.CanceledRequests())
+	}
+}
+
+// TestRouter_CanceledRequests tests counting canceled requests without CancelOnDisconnect.
+func TestRouter_CanceledRequests(t *testing.T) {
+	handlerCalled := false
+	r := New()
+	r.GET("/report", func(c *Context) error {
+		handlerCalled = true
+		return c.JSON(http.StatusOK, "done")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, goneRequest("/report"))
+
+	if !handlerCalled || w.Body.Len() == 0 {
+		t.Errorf("expected request to be served, body %q", w.Body.String())
+	}
+	if got := r.CanceledRequests(); got != 1 {
+		t.Errorf("CanceledRequests() = %d, want 1", got)
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"crypto/tls"
+	"net/netip"
+	"strings"
+)
+
+// SetTrustedProxies sets the proxies whose forwarding headers are trusted by
+// Context.ClientIP. Each entry is an IP address ("10.0.0.1") or a CIDR
+// prefix ("10.0.0.0/8", "fd00::/8").
+//
+// By default no proxy is trusted and ClientIP returns the peer address,
+// so clients cannot spoof their IP with X-Forwarded-For.
+//
+// Panics if an entry is not a valid IP address or CIDR prefix.
+//
+// Example:
+//
+//	// Behind a load balancer in the private network.
+//	router.SetTrustedProxies("10.0.0.0/8", "127.0.0.1")
+func (r *Router) SetTrustedProxies(proxies ...string) *Router {
+	prefixes := make([]netip.Prefix, 0, len(proxies))
+	for _, p := range proxies {
+		prefix, err := parseTrustedProxy(strings.TrimSpace(p))
+		if err != nil {
+			panic("fursy: invalid trusted proxy: " + p)
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	r.trustedProxies = prefixes
+	return r
+}
+
+// parseTrustedProxy parses an IP address or CIDR prefix.
+func parseTrustedProxy(s string) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		return prefix.Masked(), err
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// isTrustedProxy reports whether ip belongs to a trusted proxy.
+func (r *Router) isTrustedProxy(ip string) bool {
+	if len(r.trustedProxies) == 0 {
+		return false
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range r.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns the IP address of the client.
+//
+// If the peer is a trusted proxy (see Router.SetTrustedProxies), the
+// X-Forwarded-For header is walked from right to left, skipping trusted
+// proxies, and the first untrusted address is returned. X-Real-IP is used
+// when X-Forwarded-For is absent. Otherwise the peer address is returned.
+//
+// Example:
+//
+//	router.SetTrustedProxies("10.0.0.0/8")
+//
+//	router.GET("/whoami", func(c *fursy.Context) error {
+//	    return c.String(200, c.ClientIP())
+//	})
+func (c *Context) ClientIP() string {
+	ip := remoteIP(c.Request)
+	if c.router == nil || !c.router.isTrustedProxy(ip) {
+		return ip
+	}
+
+	if xff := c.Request.Header.Values("X-Forwarded-For"); len(xff) > 0 {
+		hops := strings.Split(strings.Join(xff, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if _, err := netip.ParseAddr(hop); err != nil {
+				// Malformed hop: stop at the last address we can trust.
+				return ip
+			}
+			ip = hop
+			if !c.router.isTrustedProxy(hop) {
+				return hop
+			}
+		}
+		return ip
+	}
+
+	if realIP := strings.TrimSpace(c.Request.Header.Get("X-Real-IP")); realIP != "" {
+		if _, err := netip.ParseAddr(realIP); err == nil {
+			return realIP
+		}
+	}
+
+	return ip
+}
+
+// Scheme returns the request scheme, "http" or "https".
+//
+// If the peer is a trusted proxy (see Router.SetTrustedProxies), the
+// X-Forwarded-Proto value set by the outermost trusted proxy is honored
+// (see ClientIP); otherwise the scheme is "https" only for TLS connections.
+//
+// Example:
+//
+//	if c.Scheme() != "https" {
+//	    return c.Problem(fursy.Forbidden("HTTPS required"))
+//	}
+func (c *Context) Scheme() string {
+	if c.Request.TLS != nil {
+		return "https"
+	}
+
+	proto := strings.ToLower(c.forwarded("X-Forwarded-Proto"))
+	if proto == "https" || proto == "http" {
+		return proto
+	}
+
+	return "http"
+}
+
+// TLS returns the TLS connection state of the request, or nil for plain
+// HTTP connections (including TLS terminated by a proxy).
+//
+// It gives access to the negotiated protocol version, cipher suite, ALPN
+// protocol, server name and verified client certificates.
+//
+// Example:
+//
+//	if state := c.TLS(); state != nil && len(state.PeerCertificates) > 0 {
+//	    client := state.PeerCertificates[0].Subject.CommonName
+//	    ...
+//	}
+func (c *Context) TLS() *tls.ConnectionState {
+	return c.Request.TLS
+}
+
+// Host returns the host requested by the client.
+//
+// If the peer is a trusted proxy (see Router.SetTrustedProxies), the
+// X-Forwarded-Host value set by the outermost trusted proxy is honored
+// (see ClientIP); otherwise Request.Host is returned.
+func (c *Context) Host() string {
+	if host := c.forwarded("X-Forwarded-Host"); host != "" && !strings.ContainsAny(host, "/\\@?# ") {
+		return host
+	}
+	return c.Request.Host
+}
+
+// forwarded returns the value of an X-Forwarded-Proto or X-Forwarded-Host
+// header set by the outermost trusted proxy, or "" if the peer is not a
+// trusted proxy.
+//
+// Each proxy appends its value, so values left of it were sent by the
+// client. Like ClientIP, the trusted proxies are counted by walking
+// X-Forwarded-For from right to left, and the value is taken at the same
+// position from the right.
+func (c *Context) forwarded(name string) string {
+	if c.router == nil || !c.router.isTrustedProxy(remoteIP(c.Request)) {
+		return ""
+	}
+	values := c.Request.Header.Values(name)
+	if len(values) == 0 {
+		return ""
+	}
+	values = strings.Split(strings.Join(values, ","), ",")
+
+	proxies := 1 // the peer
+	if xff := c.Request.Header.Values("X-Forwarded-For"); len(xff) > 0 {
+		hops := strings.Split(strings.Join(xff, ","), ",")
+		for i := len(hops) - 1; i >= 0 && proxies < len(values); i-- {
+			hop := strings.TrimSpace(hops[i])
+			if _, err := netip.ParseAddr(hop); err != nil || !c.router.isTrustedProxy(hop) {
+				break
+			}
+			proxies++
+		}
+	}
+	return strings.TrimSpace(values[len(values)-proxies])
+}
@@ -0,0 +1,248 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestContext_ClientIP tests trusted-proxy aware client IP extraction.
+func TestContext_ClientIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		trusted    []string
+		remoteAddr string
+		headers    map[string]string
+		want       string
+	}{
+		{
+			name:       "no trusted proxies ignores headers",
+			remoteAddr: "203.0.113.7:1234",
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4", "X-Real-IP": "5.6.7.8"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "untrusted peer ignores headers",
+			trusted:    []string{"10.0.0.0/8"},
+			remoteAddr: "203.0.113.7:1234",
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "trusted peer uses forwarded client",
+			trusted:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4"},
+			want:       "1.2.3.4",
+		},
+		{
+			name:       "skips trusted hops from the right",
+			trusted:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Forwarded-For": "9.9.9.9, 1.2.3.4, 10.0.0.2"},
+			want:       "1.2.3.4",
+		},
+		{
+			name:       "spoofed leftmost entry is ignored",
+			trusted:    []string{"10.0.0.1"},
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Forwarded-For": "127.0.0.1, 1.2.3.4"},
+			want:       "1.2.3.4",
+		},
+		{
+			name:       "all hops trusted returns leftmost",
+			trusted:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Forwarded-For": "10.1.1.1, 10.0.0.2"},
+			want:       "10.1.1.1",
+		},
+		{
+			name:       "malformed hop stops the walk",
+			trusted:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4, garbage"},
+			want:       "10.0.0.1",
+		},
+		{
+			name:       "X-Real-IP from trusted peer",
+			trusted:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Real-IP": "1.2.3.4"},
+			want:       "1.2.3.4",
+		},
+		{
+			name:       "IPv6 peer",
+			trusted:    []string{"::1"},
+			remoteAddr: "[::1]:1234",
+			headers:    map[string]string{"X-Forwarded-For": "2001:db8::1"},
+			want:       "2001:db8::1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := New()
+			router.SetTrustedProxies(tt.trusted...)
+
+			var got string
+			router.GET("/", func(c *Context) error {
+				got = c.ClientIP()
+				return c.NoContent(http.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			req.RemoteAddr = tt.remoteAddr
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("ClientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRouter_SetTrustedProxies_Invalid tests that invalid entries panic.
+func TestRouter_SetTrustedProxies_Invalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for invalid trusted proxy")
+		}
+	}()
+	New().SetTrustedProxies("not-an-ip")
+}
+
+// TestContext_SchemeAndHost tests forwarded scheme and host handling.
+func TestContext_SchemeAndHost(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		tls        bool
+		headers    map[string]string
+		wantScheme string
+		wantHost   string
+	}{
+		{
+			name:       "plain request",
+			remoteAddr: "203.0.113.7:1234",
+			wantScheme: "http",
+			wantHost:   "example.com",
+		},
+		{
+			name:       "TLS request",
+			remoteAddr: "203.0.113.7:1234",
+			tls:        true,
+			wantScheme: "https",
+			wantHost:   "example.com",
+		},
+		{
+			name:       "untrusted peer ignores headers",
+			remoteAddr: "203.0.113.7:1234",
+			headers:    map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "evil.com"},
+			wantScheme: "http",
+			wantHost:   "example.com",
+		},
+		{
+			name:       "trusted peer uses forwarded headers",
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Forwarded-Proto": "HTTPS", "X-Forwarded-Host": "api.example.com"},
+			wantScheme: "https",
+			wantHost:   "api.example.com",
+		},
+		{
+			name:       "spoofed values left of the proxy are ignored",
+			remoteAddr: "10.0.0.1:1234",
+			headers: map[string]string{
+				"X-Forwarded-For":   "198.51.100.1",
+				"X-Forwarded-Proto": "https, http",
+				"X-Forwarded-Host":  "evil.com, api.example.com",
+			},
+			wantScheme: "http",
+			wantHost:   "api.example.com",
+		},
+		{
+			name:       "outermost trusted proxy wins",
+			remoteAddr: "10.0.0.1:1234",
+			headers: map[string]string{
+				"X-Forwarded-For":   "198.51.100.1, 10.0.0.2",
+				"X-Forwarded-Proto": "http, https, http",
+				"X-Forwarded-Host":  "evil.com, api.example.com, internal",
+			},
+			wantScheme: "https",
+			wantHost:   "api.example.com",
+		},
+		{
+			name:       "invalid forwarded host",
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Forwarded-Host": "evil.com/path"},
+			wantScheme: "http",
+			wantHost:   "example.com",
+		},
+		{
+			name:       "trusted peer with invalid proto",
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Forwarded-Proto": "ftp"},
+			wantScheme: "http",
+			wantHost:   "example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := New()
+			router.SetTrustedProxies("10.0.0.0/8")
+
+			var scheme, host string
+			router.GET("/", func(c *Context) error {
+				scheme, host = c.Scheme(), c.Host()
+				return c.NoContent(http.StatusNoContent)
+			})
+
+			target := "http://example.com/"
+			if tt.tls {
+				target = "https://example.com/"
+			}
+			req := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+			req.RemoteAddr = tt.remoteAddr
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			if scheme != tt.wantScheme || host != tt.wantHost {
+				t.Errorf("Scheme(), Host() = %q, %q, want %q, %q", scheme, host, tt.wantScheme, tt.wantHost)
+			}
+		})
+	}
+}
+
+// TestContext_TLS tests access to the TLS connection state.
+func TestContext_TLS(t *testing.T) {
+	r := New()
+	r.GET("/", func(c *Context) error {
+		if state := c.TLS(); state != nil {
+			return c.String(http.StatusOK, state.ServerName)
+		}
+		return c.String(http.StatusOK, "plain")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if w.Body.String() != "plain" {
+		t.Errorf("plain request: got %q, want %q", w.Body.String(), "plain")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "https://api.example.com/", http.NoBody)
+	req.TLS.ServerName = "api.example.com"
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Body.String() != "api.example.com" {
+		t.Errorf("TLS request: got %q, want %q", w.Body.String(), "api.example.com")
+	}
+}
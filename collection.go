@@ -0,0 +1,826 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// postmanSchema is the schema URL of Postman collections (format v2.1).
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// defaultCollectionBaseURL is the base URL of collections when the
+// document has no servers.
+const defaultCollectionBaseURL = "http://localhost:8080"
+
+// Collection variables. Authentication values are left empty, as
+// placeholders to fill in Postman or Bruno.
+const (
+	collectionVarBaseURL  = "baseUrl"
+	collectionVarToken    = "token"
+	collectionVarUsername = "username"
+	collectionVarPassword = "password"
+	collectionVarAPIKey   = "apiKey"
+)
+
+// PostmanCollection is a Postman collection (format v2.1), generated
+// from an OpenAPI document with OpenAPI.PostmanCollection.
+type PostmanCollection struct {
+	Info     PostmanInfo       `json:"info"`
+	Item     []PostmanItem     `json:"item"`
+	Variable []PostmanKeyValue `json:"variable,omitempty"`
+}
+
+// PostmanInfo describes a Postman collection.
+type PostmanInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+// PostmanItem is a request of a Postman collection, or a folder of
+// requests (one per OpenAPI tag).
+type PostmanItem struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Item        []PostmanItem   `json:"item,omitempty"`
+	Request     *PostmanRequest `json:"request,omitempty"`
+}
+
+// PostmanRequest is the request of a Postman item.
+type PostmanRequest struct {
+	Method      string            `json:"method"`
+	Header      []PostmanKeyValue `json:"header"`
+	URL         PostmanURL        `json:"url"`
+	Body        *PostmanBody      `json:"body,omitempty"`
+	Auth        *PostmanAuth      `json:"auth,omitempty"`
+	Description string            `json:"description,omitempty"`
+}
+
+// PostmanURL is the URL of a Postman request.
+type PostmanURL struct {
+	Raw      string            `json:"raw"`
+	Host     []string          `json:"host"`
+	Path     []string          `json:"path,omitempty"`
+	Query    []PostmanKeyValue `json:"query,omitempty"`
+	Variable []PostmanKeyValue `json:"variable,omitempty"`
+}
+
+// PostmanBody is the body of a Postman request.
+type PostmanBody struct {
+	// Mode is "raw", "urlencoded" or "formdata".
+	Mode       string            `json:"mode"`
+	Raw        string            `json:"raw,omitempty"`
+	URLEncoded []PostmanKeyValue `json:"urlencoded,omitempty"`
+	FormData   []PostmanKeyValue `json:"formdata,omitempty"`
+	Options    map[string]any    `json:"options,omitempty"`
+}
+
+// PostmanAuth is the authentication of a Postman request.
+type PostmanAuth struct {
+	// Type is "bearer", "basic" or "apikey".
+	Type   string            `json:"type"`
+	Bearer []PostmanKeyValue `json:"bearer,omitempty"`
+	Basic  []PostmanKeyValue `json:"basic,omitempty"`
+	APIKey []PostmanKeyValue `json:"apikey,omitempty"`
+}
+
+// PostmanKeyValue is a Postman header, parameter, form field, variable
+// or authentication attribute.
+type PostmanKeyValue struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitzero"`
+}
+
+// collectionRequest is an operation of an OpenAPI document, as a request
+// of a Postman or Bruno collection.
+type collectionRequest struct {
+	folder      string // First tag of the operation.
+	name        string
+	description string
+	method      string
+	path        string // Path with :name parameters.
+	openAPIPath string
+
+	pathParams []collectionParam
+	query      []collectionParam
+	headers    []collectionParam
+
+	contentType string
+	bodyMode    string // "json", "urlencoded", "formdata", "raw" or "".
+	bodyRaw     string
+	bodyFields  []collectionParam
+
+	auth *collectionAuth
+}
+
+// collectionParam is a parameter, header or form field of a request.
+// Optional parameters are disabled.
+type collectionParam struct {
+	name        string
+	value       string
+	description string
+	disabled    bool
+	file        bool
+}
+
+// collectionAuth is the authentication of a request.
+type collectionAuth struct {
+	kind string // "bearer", "basic" or "apikey".
+	name string // API key name.
+	in   string // API key location: "header" or "query".
+}
+
+// variables returns the collection variables used by the authentication.
+func (a *collectionAuth) variables() []string {
+	switch {
+	case a == nil:
+		return nil
+	case a.kind == "basic":
+		return []string{collectionVarUsername, collectionVarPassword}
+	case a.kind == "apikey":
+		return []string{collectionVarAPIKey}
+	default:
+		return []string{collectionVarToken}
+	}
+}
+
+// PostmanCollection converts the document to a Postman collection (format
+// v2.1), for teams testing the API with Postman.
+//
+// Each operation becomes a request, in a folder named after its first tag,
+// with its path, query and header parameters, an example body built from
+// the request body schema (or its example), and an authentication
+// placeholder derived from its security requirements (bearer token for
+// RequireAuth routes without security schemes). The base URL and the
+// credentials are collection variables ({{baseUrl}}, {{token}}, ...).
+//
+// Example:
+//
+//	doc, _ := router.GenerateOpenAPI(info)
+//	if err := doc.PostmanCollection().WriteFile("api.postman_collection.json"); err != nil {
+//	    log.Fatal(err)
+//	}
+func (doc *OpenAPI) PostmanCollection() *PostmanCollection {
+	c := &PostmanCollection{
+		Info: PostmanInfo{
+			Name:        doc.Info.Title,
+			Description: doc.Info.Description,
+			Schema:      postmanSchema,
+		},
+		Item: []PostmanItem{},
+	}
+
+	var variables []string
+	folders := make(map[string]int)
+	for _, req := range doc.collectionRequests() {
+		variables = append(variables, req.auth.variables()...)
+
+		item := req.postmanItem()
+		if req.folder == "" {
+			c.Item = append(c.Item, item)
+			continue
+		}
+		i, ok := folders[req.folder]
+		if !ok {
+			i = len(c.Item)
+			folders[req.folder] = i
+			c.Item = append(c.Item, PostmanItem{Name: req.folder, Description: doc.tagDescription(req.folder)})
+		}
+		c.Item[i].Item = append(c.Item[i].Item, item)
+	}
+
+	for _, v := range doc.collectionVariables(variables) {
+		c.Variable = append(c.Variable, PostmanKeyValue{Key: v[0], Value: v[1], Type: schemaTypeString})
+	}
+	return c
+}
+
+// JSON returns the collection as indented JSON.
+func (c *PostmanCollection) JSON() ([]byte, error) {
+	return json.Marshal(c, jsontext.WithIndent("  "), json.Deterministic(true))
+}
+
+// WriteFile writes the collection as JSON to the named file,
+// to import in Postman (File > Import).
+func (c *PostmanCollection) WriteFile(name string) error {
+	data, err := c.JSON()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, data, 0o644) //nolint:gosec // Collections are not secret.
+}
+
+// BrunoCollection converts the document to the files of a Bruno collection
+// by slash-separated relative path: bruno.json, an environments/Local.bru
+// environment holding the base URL and credential placeholders, and one
+// .bru request file per operation, in a folder per tag (see
+// PostmanCollection for the content of the requests).
+//
+// TRACE operations are left out, as Bruno does not support them.
+func (doc *OpenAPI) BrunoCollection() map[string][]byte {
+	files := make(map[string][]byte)
+
+	manifest, _ := json.Marshal(map[string]any{ //nolint:errcheck // Marshaling strings cannot fail.
+		"version": "1",
+		"name":    doc.Info.Title,
+		"type":    "collection",
+		"ignore":  []string{"node_modules", ".git"},
+	}, jsontext.WithIndent("  "), json.Deterministic(true))
+	files["bruno.json"] = manifest
+
+	var variables []string
+	seq := make(map[string]int)
+	for _, req := range doc.collectionRequests() {
+		if req.method == http.MethodTrace {
+			continue
+		}
+		variables = append(variables, req.auth.variables()...)
+
+		dir := ""
+		if req.folder != "" {
+			dir = brunoFileName(req.folder) + "/"
+		}
+		name := brunoFileName(req.name)
+		for n := 2; files[dir+name+".bru"] != nil; n++ {
+			name = brunoFileName(req.name) + " " + strconv.Itoa(n)
+		}
+		seq[dir]++
+		files[dir+name+".bru"] = req.bru(seq[dir])
+	}
+
+	var env strings.Builder
+	env.WriteString("vars {\n")
+	for _, v := range doc.collectionVariables(variables) {
+		fmt.Fprintf(&env, "  %s: %s\n", v[0], v[1])
+	}
+	env.WriteString("}\n")
+	files["environments/Local.bru"] = []byte(env.String())
+
+	return files
+}
+
+// WriteBruno writes the Bruno collection of the document to dir (see
+// BrunoCollection), to open in Bruno (Open Collection). Existing files
+// are overwritten.
+//
+// Example:
+//
+//	doc, _ := router.GenerateOpenAPI(info)
+//	if err := doc.WriteBruno("collections/api"); err != nil {
+//	    log.Fatal(err)
+//	}
+func (doc *OpenAPI) WriteBruno(dir string) error {
+	for name, data := range doc.BrunoCollection() {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { //nolint:gosec // Collection directories are not secret.
+			return err
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil { //nolint:gosec // Collections are not secret.
+			return err
+		}
+	}
+	return nil
+}
+
+// PostmanCollectionHandler returns a handler serving the routes as
+// a Postman collection (see OpenAPI.PostmanCollection), generated with
+// the info set with WithInfo. Register it on an admin route.
+//
+// Example:
+//
+//	admin := router.Group("/admin", adminAuth)
+//	admin.GET("/collection.postman.json", router.PostmanCollectionHandler())
+func (r *Router) PostmanCollectionHandler() HandlerFunc {
+	return func(c *Context) error {
+		doc, err := r.GenerateOpenAPI(r.openAPIInfo())
+		if err != nil {
+			return err
+		}
+		data, err := doc.PostmanCollection().JSON()
+		if err != nil {
+			return err
+		}
+		c.SetHeader("Content-Disposition", `attachment; filename="`+collectionFileName(doc.Info.Title)+`.postman_collection.json"`)
+		return c.Blob(http.StatusOK, "application/json; charset=utf-8", data)
+	}
+}
+
+// BrunoCollectionHandler returns a handler serving the routes as a zip
+// archive of a Bruno collection (see OpenAPI.BrunoCollection), generated
+// with the info set with WithInfo. Register it on an admin route.
+//
+// Example:
+//
+//	admin := router.Group("/admin", adminAuth)
+//	admin.GET("/collection.bruno.zip", router.BrunoCollectionHandler())
+func (r *Router) BrunoCollectionHandler() HandlerFunc {
+	return func(c *Context) error {
+		doc, err := r.GenerateOpenAPI(r.openAPIInfo())
+		if err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+		archive := zip.NewWriter(&buf)
+		files := doc.BrunoCollection()
+		for _, name := range slices.Sorted(maps.Keys(files)) {
+			w, err := archive.Create(name)
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(files[name]); err != nil {
+				return err
+			}
+		}
+		if err := archive.Close(); err != nil {
+			return err
+		}
+
+		c.SetHeader("Content-Disposition", `attachment; filename="`+collectionFileName(doc.Info.Title)+`.bruno.zip"`)
+		return c.Blob(http.StatusOK, "application/zip", buf.Bytes())
+	}
+}
+
+// collectionRequests returns the operations of the document as requests,
+// sorted by path.
+func (doc *OpenAPI) collectionRequests() []collectionRequest {
+	var requests []collectionRequest
+	for _, path := range slices.Sorted(maps.Keys(doc.Paths)) {
+		item := doc.Paths[path]
+		for _, m := range []struct {
+			method string
+			op     *Operation
+		}{
+			{http.MethodGet, item.Get},
+			{http.MethodPost, item.Post},
+			{http.MethodPut, item.Put},
+			{http.MethodPatch, item.Patch},
+			{http.MethodDelete, item.Delete},
+			{http.MethodHead, item.Head},
+			{http.MethodOptions, item.Options},
+			{http.MethodTrace, item.Trace},
+		} {
+			if m.op != nil {
+				requests = append(requests, doc.collectionRequest(m.method, path, item.Parameters, m.op))
+			}
+		}
+	}
+	return requests
+}
+
+// collectionRequest converts an operation to a request.
+func (doc *OpenAPI) collectionRequest(method, path string, pathParams []Parameter, op *Operation) collectionRequest {
+	req := collectionRequest{
+		name:        op.Summary,
+		description: op.Description,
+		method:      method,
+		path:        collectionPath(path),
+		openAPIPath: path,
+		auth:        doc.collectionAuth(op),
+	}
+	if req.name == "" {
+		req.name = op.OperationID
+	}
+	if req.name == "" {
+		req.name = method + " " + req.path
+	}
+	if len(op.Tags) > 0 {
+		req.folder = op.Tags[0]
+	}
+
+	// Operation parameters override path item parameters.
+	params := slices.Clone(op.Parameters)
+	for _, p := range pathParams {
+		if !slices.ContainsFunc(params, func(o Parameter) bool { return o.Name == p.Name && o.In == p.In }) {
+			params = append(params, p)
+		}
+	}
+	for _, p := range params {
+		param := collectionParam{
+			name:        p.Name,
+			value:       exampleString(doc.declaredExample(p.Schema)),
+			description: p.Description,
+			disabled:    !p.Required,
+		}
+		switch p.In {
+		case "path":
+			param.disabled = false
+			req.pathParams = append(req.pathParams, param)
+		case "query":
+			req.query = append(req.query, param)
+		case "header":
+			req.headers = append(req.headers, param)
+		}
+	}
+
+	// Path parameters may be undocumented.
+	for _, segment := range strings.Split(req.path, "/") {
+		name, ok := strings.CutPrefix(segment, ":")
+		if ok && !slices.ContainsFunc(req.pathParams, func(p collectionParam) bool { return p.name == name }) {
+			req.pathParams = append(req.pathParams, collectionParam{name: name})
+		}
+	}
+
+	doc.collectionBody(&req, op.RequestBody)
+	return req
+}
+
+// collectionBody sets the example body of a request, preferring JSON
+// and form media types.
+func (doc *OpenAPI) collectionBody(req *collectionRequest, body *RequestBody) {
+	if body == nil || len(body.Content) == 0 {
+		return
+	}
+
+	mediaTypes := slices.Sorted(maps.Keys(body.Content))
+	req.contentType = mediaTypes[0]
+	for _, preferred := range []string{MIMEApplicationJSON, MIMEApplicationForm, MIMEMultipartForm} {
+		if _, ok := body.Content[preferred]; ok {
+			req.contentType = preferred
+			break
+		}
+	}
+
+	media := body.Content[req.contentType]
+	example := media.Example
+	if example == nil && len(media.Examples) > 0 {
+		example = media.Examples[slices.Min(slices.Collect(maps.Keys(media.Examples)))].Value
+	}
+	if example == nil {
+		example = doc.schemaExample(media.Schema)
+	}
+
+	switch {
+	case req.contentType == MIMEApplicationForm || req.contentType == MIMEMultipartForm:
+		req.bodyMode = "urlencoded"
+		if req.contentType == MIMEMultipartForm {
+			req.bodyMode = "formdata"
+		}
+		fields, _ := example.(map[string]any)
+		schema := doc.derefSchema(media.Schema)
+		for _, name := range slices.Sorted(maps.Keys(fields)) {
+			field := collectionParam{name: name, value: exampleString(fields[name])}
+			if schema != nil {
+				prop := doc.derefSchema(schema.Properties[name])
+				if prop != nil && prop.Items != nil {
+					prop = doc.derefSchema(prop.Items)
+				}
+				field.file = prop != nil && prop.Format == "binary"
+			}
+			req.bodyFields = append(req.bodyFields, field)
+		}
+	case req.contentType == MIMEApplicationJSON || strings.HasSuffix(req.contentType, "+json"):
+		data, err := json.Marshal(example, jsontext.WithIndent("  "), json.Deterministic(true))
+		if err == nil {
+			req.bodyMode, req.bodyRaw = "json", string(data)
+		}
+	default:
+		req.bodyMode = "raw"
+		req.bodyRaw, _ = example.(string)
+	}
+}
+
+// declaredExample returns the example, default or first enum value of
+// a schema, or nil: parameters without one are left empty to be filled in.
+func (doc *OpenAPI) declaredExample(s *Schema) any {
+	s = doc.derefSchema(s)
+	switch {
+	case s == nil:
+		return nil
+	case s.Example != nil:
+		return s.Example
+	case s.Default != nil:
+		return s.Default
+	case len(s.Enum) > 0:
+		return s.Enum[0]
+	default:
+		return nil
+	}
+}
+
+// derefSchema resolves a schema reference to a component schema.
+func (doc *OpenAPI) derefSchema(s *Schema) *Schema {
+	if s != nil && s.Ref != "" {
+		return doc.resolveSchemaRef(s.Ref)
+	}
+	return s
+}
+
+// collectionAuth returns the authentication of an operation: its first
+// security requirement with a supported scheme (the document requirements
+// if the operation has none), or a bearer token if it requires
+// authentication (RouteOptions.RequireAuth) without declaring schemes.
+func (doc *OpenAPI) collectionAuth(op *Operation) *collectionAuth {
+	security := op.Security
+	if security == nil {
+		security = doc.Security
+	}
+	if doc.Components != nil {
+		for _, requirement := range security {
+			for _, name := range slices.Sorted(maps.Keys(requirement)) {
+				scheme, ok := doc.Components.SecuritySchemes[name]
+				if !ok {
+					continue
+				}
+				switch {
+				case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+					return &collectionAuth{kind: "basic"}
+				case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "bearer"),
+					scheme.Type == "oauth2", scheme.Type == "openIdConnect":
+					return &collectionAuth{kind: "bearer"}
+				case scheme.Type == "apiKey" && (scheme.In == "header" || scheme.In == "query"):
+					return &collectionAuth{kind: "apikey", name: scheme.Name, in: scheme.In}
+				}
+			}
+		}
+	}
+	if op.Extensions["x-require-auth"] == true {
+		return &collectionAuth{kind: "bearer"}
+	}
+	return nil
+}
+
+// collectionVariables returns the collection variables as name and value
+// pairs: the base URL (from the first server) and the used credentials.
+func (doc *OpenAPI) collectionVariables(used []string) [][2]string {
+	baseURL := defaultCollectionBaseURL
+	if len(doc.Servers) > 0 {
+		server := doc.Servers[0]
+		baseURL = server.URL
+		for name, v := range server.Variables {
+			baseURL = strings.ReplaceAll(baseURL, "{"+name+"}", v.Default)
+		}
+		if strings.HasPrefix(baseURL, "/") {
+			baseURL = defaultCollectionBaseURL + baseURL
+		}
+		baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+
+	variables := [][2]string{{collectionVarBaseURL, baseURL}}
+	for _, name := range []string{collectionVarToken, collectionVarUsername, collectionVarPassword, collectionVarAPIKey} {
+		if slices.Contains(used, name) {
+			variables = append(variables, [2]string{name, ""})
+		}
+	}
+	return variables
+}
+
+// tagDescription returns the description of a document tag.
+func (doc *OpenAPI) tagDescription(name string) string {
+	for _, tag := range doc.Tags {
+		if tag.Name == name {
+			return tag.Description
+		}
+	}
+	return ""
+}
+
+// collectionPath converts an OpenAPI path ("/users/{id}") to a collection
+// path ("/users/:id").
+func collectionPath(path string) string {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(path, '{')
+		end := strings.IndexByte(path, '}')
+		if start < 0 || end < start {
+			b.WriteString(path)
+			return b.String()
+		}
+		b.WriteString(path[:start])
+		b.WriteString(":")
+		b.WriteString(path[start+1 : end])
+		path = path[end+1:]
+	}
+}
+
+// url returns the URL of the request, with the enabled query parameters.
+func (r *collectionRequest) url() string {
+	url := "{{" + collectionVarBaseURL + "}}" + r.path
+	sep := "?"
+	for _, q := range r.query {
+		if !q.disabled {
+			url += sep + q.name + "=" + q.value
+			sep = "&"
+		}
+	}
+	return url
+}
+
+// postmanItem converts the request to a Postman item.
+func (r *collectionRequest) postmanItem() PostmanItem {
+	toKeyValues := func(params []collectionParam) []PostmanKeyValue {
+		var kvs []PostmanKeyValue
+		for _, p := range params {
+			kv := PostmanKeyValue{Key: p.name, Value: p.value, Description: p.description, Disabled: p.disabled}
+			if p.file {
+				kv.Type, kv.Value = "file", ""
+			} else if r.bodyMode == "formdata" {
+				kv.Type = "text"
+			}
+			kvs = append(kvs, kv)
+		}
+		return kvs
+	}
+
+	req := &PostmanRequest{
+		Method: r.method,
+		Header: toKeyValues(r.headers),
+		URL: PostmanURL{
+			Raw:      r.url(),
+			Host:     []string{"{{" + collectionVarBaseURL + "}}"},
+			Query:    toKeyValues(r.query),
+			Variable: toKeyValues(r.pathParams),
+		},
+		Description: r.description,
+	}
+	if req.Header == nil {
+		req.Header = []PostmanKeyValue{}
+	}
+	if path := strings.Trim(r.path, "/"); path != "" {
+		req.URL.Path = strings.Split(path, "/")
+	}
+
+	switch r.bodyMode {
+	case "json", "raw":
+		req.Header = append(req.Header, PostmanKeyValue{Key: "Content-Type", Value: r.contentType})
+		req.Body = &PostmanBody{Mode: "raw", Raw: r.bodyRaw}
+		if r.bodyMode == "json" {
+			req.Body.Options = map[string]any{"raw": map[string]any{"language": "json"}}
+		}
+	case "urlencoded":
+		req.Body = &PostmanBody{Mode: "urlencoded", URLEncoded: toKeyValues(r.bodyFields)}
+	case "formdata":
+		req.Body = &PostmanBody{Mode: "formdata", FormData: toKeyValues(r.bodyFields)}
+	}
+
+	if r.auth != nil {
+		variable := func(name string) string { return "{{" + name + "}}" }
+		req.Auth = &PostmanAuth{Type: r.auth.kind}
+		switch r.auth.kind {
+		case "basic":
+			req.Auth.Basic = []PostmanKeyValue{
+				{Key: "username", Value: variable(collectionVarUsername), Type: schemaTypeString},
+				{Key: "password", Value: variable(collectionVarPassword), Type: schemaTypeString},
+			}
+		case "apikey":
+			req.Auth.APIKey = []PostmanKeyValue{
+				{Key: "key", Value: r.auth.name, Type: schemaTypeString},
+				{Key: "value", Value: variable(collectionVarAPIKey), Type: schemaTypeString},
+				{Key: "in", Value: r.auth.in, Type: schemaTypeString},
+			}
+		default:
+			req.Auth.Bearer = []PostmanKeyValue{
+				{Key: "token", Value: variable(collectionVarToken), Type: schemaTypeString},
+			}
+		}
+	}
+
+	return PostmanItem{Name: r.name, Request: req}
+}
+
+// bru converts the request to a Bruno request file.
+func (r *collectionRequest) bru(seq int) []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "meta {\n  name: %s\n  type: http\n  seq: %d\n}\n", bruLine(r.name), seq)
+
+	bodyMode := map[string]string{
+		"json":       "json",
+		"raw":        "text",
+		"urlencoded": "formUrlEncoded",
+		"formdata":   "multipartForm",
+	}[r.bodyMode]
+	if bodyMode == "" {
+		bodyMode = "none"
+	}
+	authMode := "none"
+	if r.auth != nil {
+		authMode = r.auth.kind
+	}
+	fmt.Fprintf(&b, "\n%s {\n  url: %s\n  body: %s\n  auth: %s\n}\n", strings.ToLower(r.method), r.url(), bodyMode, authMode)
+
+	bruParams(&b, "params:query", r.query)
+	bruParams(&b, "params:path", r.pathParams)
+	headers := r.headers
+	if r.bodyMode == "raw" {
+		headers = append(slices.Clip(headers), collectionParam{name: "Content-Type", value: r.contentType})
+	}
+	bruParams(&b, "headers", headers)
+
+	if r.auth != nil {
+		switch r.auth.kind {
+		case "basic":
+			fmt.Fprintf(&b, "\nauth:basic {\n  username: {{%s}}\n  password: {{%s}}\n}\n", collectionVarUsername, collectionVarPassword)
+		case "apikey":
+			placement := "header"
+			if r.auth.in == "query" {
+				placement = "queryparams"
+			}
+			fmt.Fprintf(&b, "\nauth:apikey {\n  key: %s\n  value: {{%s}}\n  placement: %s\n}\n", r.auth.name, collectionVarAPIKey, placement)
+		default:
+			fmt.Fprintf(&b, "\nauth:bearer {\n  token: {{%s}}\n}\n", collectionVarToken)
+		}
+	}
+
+	switch r.bodyMode {
+	case "json":
+		bruText(&b, "body:json", r.bodyRaw)
+	case "raw":
+		bruText(&b, "body:text", r.bodyRaw)
+	case "urlencoded":
+		bruParams(&b, "body:form-urlencoded", r.bodyFields)
+	case "formdata":
+		fields := slices.Clone(r.bodyFields)
+		for i := range fields {
+			if fields[i].file {
+				fields[i].value = "@file()"
+			}
+		}
+		bruParams(&b, "body:multipart-form", fields)
+	}
+
+	if r.description != "" {
+		bruText(&b, "docs", r.description)
+	}
+	return []byte(b.String())
+}
+
+// bruParams writes a Bruno key-value block; disabled entries are prefixed with ~.
+func bruParams(b *strings.Builder, block string, params []collectionParam) {
+	if len(params) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n%s {\n", block)
+	for _, p := range params {
+		prefix := ""
+		if p.disabled {
+			prefix = "~"
+		}
+		fmt.Fprintf(b, "  %s%s: %s\n", prefix, p.name, bruLine(p.value))
+	}
+	b.WriteString("}\n")
+}
+
+// bruText writes a Bruno text block, indenting the text.
+func bruText(b *strings.Builder, block, text string) {
+	fmt.Fprintf(b, "\n%s {\n", block)
+	for line := range strings.Lines(text) {
+		b.WriteString("  " + strings.TrimRight(line, "\n") + "\n")
+	}
+	b.WriteString("}\n")
+}
+
+// bruLine returns s on a single line, for Bruno values.
+func bruLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// brunoFileName returns a file name for a Bruno request or folder name.
+func brunoFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) || r < ' ' {
+			return '-'
+		}
+		return r
+	}, name)
+	name = strings.Trim(name, " .")
+	if name == "" {
+		return "request"
+	}
+	return name
+}
+
+// collectionFileName returns a download file name for a collection title.
+func collectionFileName(title string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		case r == ' ' || r == '.':
+			return '_'
+		default:
+			return -1
+		}
+	}, title)
+	if name == "" {
+		return "api"
+	}
+	return name
+}
@@ -0,0 +1,282 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json/v2"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// collectionRouter returns a router documenting a small user API.
+func collectionRouter() *Router {
+	type createUser struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+	type user struct {
+		ID int `json:"id"`
+	}
+
+	r := New()
+	r.WithInfo(Info{Title: "Users API", Version: "1.0.0"})
+	r.WithServer(Server{URL: "https://{env}.example.com/v1", Variables: map[string]ServerVariable{"env": {Default: "staging"}}})
+	POST[createUser, user](r, "/users", func(c *Box[createUser, user]) error {
+		return nil
+	})
+	r.HandleWithOptions(http.MethodGet, "/users/:id", func(c *Context) error { return nil }, &RouteOptions{
+		Summary:     "Get user",
+		Description: "Returns a user.",
+		Tags:        []string{"users"},
+		RequireAuth: true,
+		Parameters: []RouteParameter{
+			{Name: "fields", In: "query", Description: "Fields to return"},
+			{Name: "X-Request-ID", In: "header", Required: true},
+		},
+	})
+	return r
+}
+
+func TestOpenAPI_PostmanCollection(t *testing.T) {
+	doc, err := collectionRouter().GenerateOpenAPI(Info{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := doc.PostmanCollection()
+
+	if c.Info.Name != "Users API" || c.Info.Schema != postmanSchema {
+		t.Errorf("unexpected info: %+v", c.Info)
+	}
+	if len(c.Variable) != 2 || c.Variable[0].Value != "https://staging.example.com/v1" || c.Variable[1].Key != "token" {
+		t.Errorf("unexpected variables: %+v", c.Variable)
+	}
+
+	var create, get *PostmanRequest
+	for _, item := range c.Item {
+		switch {
+		case item.Request != nil && item.Request.Method == http.MethodPost:
+			create = item.Request
+		case item.Name == "users" && len(item.Item) == 1:
+			get = item.Item[0].Request
+		}
+	}
+	if create == nil || get == nil {
+		t.Fatalf("unexpected items: %+v", c.Item)
+	}
+
+	if create.URL.Raw != "{{baseUrl}}/users" || create.Auth != nil {
+		t.Errorf("unexpected create request: %+v", create)
+	}
+	var body map[string]any
+	if err := json.Unmarshal([]byte(create.Body.Raw), &body); err != nil {
+		t.Fatalf("invalid JSON body %q: %v", create.Body.Raw, err)
+	}
+	if body["name"] != "string" || body["email"] != "string" {
+		t.Errorf("unexpected example body: %v", body)
+	}
+
+	if get.URL.Raw != "{{baseUrl}}/users/:id" || len(get.URL.Variable) != 1 || get.URL.Variable[0].Key != "id" {
+		t.Errorf("unexpected get URL: %+v", get.URL)
+	}
+	if len(get.URL.Query) != 1 || !get.URL.Query[0].Disabled {
+		t.Errorf("expected optional query parameter to be disabled: %+v", get.URL.Query)
+	}
+	if len(get.Header) != 1 || get.Header[0].Key != "X-Request-ID" || get.Header[0].Disabled {
+		t.Errorf("unexpected headers: %+v", get.Header)
+	}
+	if get.Auth == nil || get.Auth.Type != "bearer" || get.Auth.Bearer[0].Value != "{{token}}" {
+		t.Errorf("expected bearer auth placeholder, got %+v", get.Auth)
+	}
+
+	data, err := c.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte(`"schema": "`+postmanSchema+`"`)) {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+}
+
+func TestOpenAPI_CollectionAuthAndForms(t *testing.T) {
+	doc := &OpenAPI{
+		Info: Info{Title: "Files"},
+		Paths: map[string]PathItem{
+			"/files": {
+				Post: &Operation{
+					Security: []SecurityRequirement{{"apiKey": {}}},
+					RequestBody: &RequestBody{Content: map[string]MediaType{
+						MIMEMultipartForm: {Schema: &Schema{Ref: "#/components/schemas/Upload"}},
+					}},
+				},
+				Get: &Operation{},
+			},
+		},
+		Security: []SecurityRequirement{{"basic": {}}},
+		Components: &Components{
+			Schemas: map[string]*Schema{
+				"Upload": {Type: "object", Properties: map[string]*Schema{
+					"file":  {Type: "string", Format: "binary"},
+					"title": {Type: "string", Example: "report"},
+				}},
+			},
+			SecuritySchemes: map[string]SecurityScheme{
+				"apiKey": {Type: "apiKey", Name: "X-API-Key", In: "header"},
+				"basic":  {Type: "http", Scheme: "basic"},
+			},
+		},
+	}
+
+	c := doc.PostmanCollection()
+	if len(c.Item) != 2 {
+		t.Fatalf("expected 2 items, got %+v", c.Item)
+	}
+	get, post := c.Item[0].Request, c.Item[1].Request
+	if get.Auth == nil || get.Auth.Type != "basic" {
+		t.Errorf("expected document basic auth, got %+v", get.Auth)
+	}
+	if post.Auth == nil || post.Auth.Type != "apikey" || post.Auth.APIKey[0].Value != "X-API-Key" {
+		t.Errorf("expected API key auth, got %+v", post.Auth)
+	}
+	if post.Body == nil || post.Body.Mode != "formdata" || len(post.Body.FormData) != 2 {
+		t.Fatalf("unexpected body: %+v", post.Body)
+	}
+	if file := post.Body.FormData[0]; file.Key != "file" || file.Type != "file" {
+		t.Errorf("unexpected file field: %+v", file)
+	}
+	if title := post.Body.FormData[1]; title.Value != "report" {
+		t.Errorf("unexpected title field: %+v", title)
+	}
+}
+
+func TestOpenAPI_BrunoCollection(t *testing.T) {
+	doc, err := collectionRouter().GenerateOpenAPI(Info{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := doc.BrunoCollection()
+
+	if !bytes.Contains(files["bruno.json"], []byte(`"name": "Users API"`)) {
+		t.Errorf("unexpected bruno.json: %s", files["bruno.json"])
+	}
+	env := string(files["environments/Local.bru"])
+	if !strings.Contains(env, "baseUrl: https://staging.example.com/v1") || !strings.Contains(env, "token: ") {
+		t.Errorf("unexpected environment:\n%s", env)
+	}
+
+	get := string(files["users/Get user.bru"])
+	for _, want := range []string{
+		"name: Get user",
+		"get {\n  url: {{baseUrl}}/users/:id\n  body: none\n  auth: bearer\n}",
+		"params:query {\n  ~fields: \n}",
+		"headers {\n  X-Request-ID: \n}",
+		"auth:bearer {\n  token: {{token}}\n}",
+		"docs {\n  Returns a user.\n}",
+	} {
+		if !strings.Contains(get, want) {
+			t.Errorf("expected %q in:\n%s", want, get)
+		}
+	}
+
+	var create string
+	for name, data := range files {
+		if strings.HasPrefix(string(data), "meta {\n  name: POST") {
+			create = name
+		}
+	}
+	if !strings.Contains(string(files[create]), "body:json {\n  {\n    \"email\": \"string\",") {
+		t.Errorf("unexpected create request %s:\n%s", create, files[create])
+	}
+}
+
+func TestOpenAPI_WriteCollections(t *testing.T) {
+	doc, err := collectionRouter().GenerateOpenAPI(Info{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+
+	if err := doc.PostmanCollection().WriteFile(filepath.Join(dir, "api.json")); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.WriteBruno(filepath.Join(dir, "bruno")); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"api.json", "bruno/bruno.json", "bruno/users/Get user.bru"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestRouter_CollectionHandlers(t *testing.T) {
+	r := collectionRouter()
+	admin := r.Group("/admin")
+	admin.GET("/collection.postman.json", r.PostmanCollectionHandler())
+	admin.GET("/collection.bruno.zip", r.BrunoCollectionHandler())
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/collection.postman.json", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Header().Get("Content-Disposition"), "Users_API.postman_collection.json") {
+		t.Fatalf("unexpected response: %d %v", w.Code, w.Header())
+	}
+	var c PostmanCollection
+	if err := json.Unmarshal(w.Body.Bytes(), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Info.Name != "Users API" {
+		t.Errorf("unexpected collection: %+v", c.Info)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/collection.bruno.zip", nil))
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/zip" {
+		t.Fatalf("unexpected response: %d %v", w.Code, w.Header())
+	}
+	archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, f := range archive.File {
+		if f.Name == "bruno.json" {
+			rc, _ := f.Open()
+			data, _ := io.ReadAll(rc)
+			rc.Close()
+			found = bytes.Contains(data, []byte("Users API"))
+		}
+	}
+	if !found {
+		t.Error("expected bruno.json in archive")
+	}
+}
+
+func TestSchemaExample_Recursive(t *testing.T) {
+	minID := 1.0
+	doc := &OpenAPI{Components: &Components{Schemas: map[string]*Schema{
+		"Node": {Type: "object", Properties: map[string]*Schema{
+			"id":       {Type: "integer", Minimum: &minID},
+			"children": {Type: "array", Items: &Schema{Ref: "#/components/schemas/Node"}},
+			"created":  {Type: "string", Format: "date-time", ReadOnly: true},
+		}},
+	}}}
+
+	example, ok := doc.schemaExample(&Schema{Ref: "#/components/schemas/Node"}).(map[string]any)
+	if !ok || example["id"] != int64(1) {
+		t.Fatalf("unexpected example: %v", example)
+	}
+	if _, ok := example["created"]; ok {
+		t.Error("expected read-only property to be left out")
+	}
+	if children, ok := example["children"].([]any); !ok || len(children) != 1 || children[0] != nil {
+		t.Errorf("expected recursion to stop, got %v", example["children"])
+	}
+}
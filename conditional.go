@@ -0,0 +1,106 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import "strings"
+
+// RequireIfMatch implements optimistic concurrency control for PUT, PATCH
+// and DELETE: the request must carry an If-Match header matching current,
+// the ETag of the resource as currently stored (RFC 9110, Section 13.1.1).
+//
+// If the header is missing, a 428 Precondition Required problem is sent;
+// if it does not match, a 412 Precondition Failed problem is sent. In both
+// cases ok is false and err is the result of sending the problem, so the
+// handler returns it without modifying the resource.
+//
+// If-Match uses the strong comparison: weak tags never match. "*" matches
+// any current representation; pass a zero ETag if the resource does not exist.
+//
+// Example:
+//
+//	router.PUT("/users/:id", func(c *fursy.Context) error {
+//	    user, err := store.Get(c.Param("id"))
+//	    if err != nil {
+//	        return c.Problem(fursy.NotFound("user not found"))
+//	    }
+//	    if ok, err := c.RequireIfMatch(fursy.ETag{Value: strconv.Itoa(user.Version)}); !ok {
+//	        return err
+//	    }
+//	    // ... update the user and increment its version ...
+//	    c.SetTypedHeader(fursy.ETag{Value: strconv.Itoa(user.Version)})
+//	    return c.OK(user)
+//	})
+func (c *Context) RequireIfMatch(current ETag) (ok bool, err error) {
+	header := c.Request.Header.Values("If-Match")
+	if len(header) == 0 {
+		return false, c.Problem(PreconditionRequired("request must be conditional: send If-Match with the resource ETag"))
+	}
+	if !ifMatch(header, current) {
+		return false, c.Problem(PreconditionFailed("resource has been modified: If-Match does not match the current ETag"))
+	}
+	return true, nil
+}
+
+// ifMatch reports whether the If-Match field values match current.
+func ifMatch(values []string, current ETag) bool {
+	exists := current.Value != ""
+	for _, value := range values {
+		for tag := range strings.SplitSeq(value, ",") {
+			tag = strings.TrimSpace(tag)
+			switch {
+			case tag == "*":
+				if exists {
+					return true
+				}
+			case exists && !current.Weak && tag == current.String():
+				// Strong comparison: neither tag is weak, so the quoted values
+				// match exactly; "W/..." never equals a strong tag.
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// conditionalDocs documents RouteOptions.RequireIfMatch: the required
+// If-Match header, the ETag header of successful responses and the 412 and
+// 428 responses.
+func conditionalDocs(op *Operation, problemResponse func(string) Response) {
+	op.Parameters = append(op.Parameters, Parameter{
+		Name:        "If-Match",
+		In:          "header",
+		Description: "ETag of the resource as last read (optimistic concurrency control)",
+		Required:    true,
+		Schema:      &Schema{Type: schemaTypeString},
+	})
+	for status, resp := range op.Responses {
+		if len(status) == 3 && status[0] == '2' {
+			if resp.Headers == nil {
+				resp.Headers = make(map[string]Header)
+			}
+			if _, exists := resp.Headers["ETag"]; !exists {
+				resp.Headers["ETag"] = Header{
+					Description: "ETag of the updated resource",
+					Schema:      &Schema{Type: schemaTypeString},
+				}
+			}
+			op.Responses[status] = resp
+		}
+	}
+	op.Responses["412"] = problemResponse("Precondition Failed")
+	op.Responses["428"] = problemResponse("Precondition Required")
+}
+
+// requireIfMatchPolicy rejects requests without an If-Match header with
+// 428 Precondition Required, so that clients cannot skip the concurrency
+// check of Context.RequireIfMatch.
+func requireIfMatchPolicy(next HandlerFunc) HandlerFunc {
+	return func(c *Context) error {
+		if len(c.Request.Header.Values("If-Match")) == 0 {
+			return c.Problem(PreconditionRequired("request must be conditional: send If-Match with the resource ETag"))
+		}
+		return next(c)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestContext_RequireIfMatch tests optimistic concurrency checks.
+func TestContext_RequireIfMatch(t *testing.T) {
+	r := New()
+	r.PUT("/docs/:id", func(c *Context) error {
+		current := ETag{Value: "v7"}
+		if c.Param("id") == "missing" {
+			current = ETag{}
+		}
+		if ok, err := c.RequireIfMatch(current); !ok {
+			return err
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name    string
+		path    string
+		ifMatch []string
+		status  int
+	}{
+		{"missing header", "/docs/1", nil, http.StatusPreconditionRequired},
+		{"match", "/docs/1", []string{`"v7"`}, http.StatusNoContent},
+		{"match in list", "/docs/1", []string{`"v6", "v7"`}, http.StatusNoContent},
+		{"match in second field", "/docs/1", []string{`"v5"`, `"v7"`}, http.StatusNoContent},
+		{"stale", "/docs/1", []string{`"v6"`}, http.StatusPreconditionFailed},
+		{"weak never matches", "/docs/1", []string{`W/"v7"`}, http.StatusPreconditionFailed},
+		{"wildcard", "/docs/1", []string{"*"}, http.StatusNoContent},
+		{"wildcard without resource", "/docs/missing", []string{"*"}, http.StatusPreconditionFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, tt.path, http.NoBody)
+			for _, v := range tt.ifMatch {
+				req.Header.Add("If-Match", v)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			if tt.status >= 400 && !strings.Contains(w.Header().Get("Content-Type"), "application/problem+json") {
+				t.Errorf("Content-Type = %q, want problem+json", w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+// TestRouteOptions_RequireIfMatch tests the If-Match route policy and its documentation.
+func TestRouteOptions_RequireIfMatch(t *testing.T) {
+	r := New()
+	called := false
+	r.HandleWithOptions(http.MethodPatch, "/docs/:id", func(c *Context) error {
+		called = true
+		return c.NoContent(http.StatusNoContent)
+	}, &RouteOptions{RequireIfMatch: true})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPatch, "/docs/1", http.NoBody))
+	if w.Code != http.StatusPreconditionRequired || called {
+		t.Errorf("without If-Match: status = %d, called = %v; want 428, not called", w.Code, called)
+	}
+
+	req := httptest.NewRequest(http.MethodPatch, "/docs/1", http.NoBody)
+	req.Header.Set("If-Match", `"v1"`)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent || !called {
+		t.Errorf("with If-Match: status = %d, called = %v; want 204, called", w.Code, called)
+	}
+
+	doc, err := r.GenerateOpenAPI(Info{Title: "Test", Version: "1.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := doc.Paths["/docs/{id}"].Patch
+	found := false
+	for _, p := range op.Parameters {
+		found = found || (p.Name == "If-Match" && p.In == "header" && p.Required)
+	}
+	if !found {
+		t.Errorf("parameters = %+v, want required If-Match header", op.Parameters)
+	}
+	for _, status := range []string{"412", "428"} {
+		if _, ok := op.Responses[status]; !ok {
+			t.Errorf("missing %s response", status)
+		}
+	}
+	if _, ok := op.Responses["200"].Headers["ETag"]; !ok {
+		t.Errorf("200 response headers = %v, want ETag", op.Responses["200"].Headers)
+	}
+}
@@ -11,8 +11,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
 
+	"github.com/coregx/fursy/internal/binding"
 	"github.com/coregx/fursy/internal/negotiate"
 )
 
@@ -72,14 +78,55 @@ type Context struct {
 	// query is a lazy-loaded cache of parsed query parameters.
 	query map[string][]string
 
+	// route is the pattern of the matched route ("" if none).
+	route string
+
+	// routeName is the name of the matched route ("" if none).
+	routeName string
+
+	// fields is the sparse fieldset selected by the request (nil = all
+	// fields; see RouteOptions.Fields).
+	fields fieldSet
+
+	// locale and location are the locale and time zone of the request
+	// (see SetLocale and SetTimezone).
+	locale   string
+	location *time.Location
+
+	// localizer localizes the JSON responses of the route (see RouteOptions.Localize).
+	localizer *localizer
+
+	// slots store the values of framework keys (see DataSlot); slotsSet
+	// has bit s set if slot s was set.
+	slots    [numDataSlots]any
+	slotsSet uint8
+
 	// data stores arbitrary values for passing data between middleware.
+	// Allocated on the first Set of a key without a slot.
 	data map[string]any
 
+	// errorHandler is the group error handler for the matched route (if any).
+	errorHandler ErrorHandler
+
+	// multipartMemory is the multipart memory limit of the route
+	// (see RouteOptions.MaxMultipartMemory; 0 = router limit).
+	multipartMemory int64
+
+	// formParsed and formErr record the result of ParseForm.
+	formParsed bool
+	formErr    error
+
 	// Middleware chain execution.
 	// Pre-allocated with capacity 16 to avoid allocations for typical middleware chains.
 	handlers []HandlerFunc
 	index    int
 	aborted  bool
+
+	// middlewareRuns records middleware runs (see Named and Router.SetMiddlewareTiming).
+	middlewareRuns []MiddlewareRun
+
+	// openRuns stacks the indexes of middleware runs in progress.
+	openRuns []int
 }
 
 const (
@@ -96,7 +143,6 @@ const (
 // This is called by the Router's sync.Pool.
 func newContext() *Context {
 	return &Context{
-		data:     make(map[string]any),
 		params:   make([]Param, 0, 8),        // Pre-allocate params buffer (typical: 1-4 params).
 		handlers: make([]HandlerFunc, 0, 16), // Pre-allocate handlers buffer (typical: 3-8 middleware).
 	}
@@ -110,7 +156,7 @@ func (c *Context) init(w http.ResponseWriter, r *http.Request, router *Router, p
 	c.router = router
 	c.params = params
 	c.query = nil // reset query cache
-	// data map is reused (cleared in reset)
+	// data slots and map are reused (cleared in reset)
 }
 
 // reset clears the context for reuse in the pool.
@@ -120,6 +166,16 @@ func (c *Context) reset() {
 	c.Response = nil
 	c.router = nil
 	c.query = nil
+	c.route = ""
+	c.routeName = ""
+	c.fields = nil
+	c.locale = ""
+	c.location = nil
+	c.localizer = nil
+	c.errorHandler = nil
+	c.multipartMemory = 0
+	c.formParsed = false
+	c.formErr = nil
 
 	// Reset params slice: keep capacity if reasonable, otherwise reallocate.
 	// This prevents memory leaks from holding large backing arrays.
@@ -131,10 +187,10 @@ func (c *Context) reset() {
 		c.params = c.params[:0]
 	}
 
-	// Clear data map but keep allocation.
-	for k := range c.data {
-		delete(c.data, k)
-	}
+	// Clear data slots, and data map but keep allocation.
+	c.slots = [numDataSlots]any{}
+	c.slotsSet = 0
+	clear(c.data)
 
 	// Reset handlers slice: keep capacity if reasonable, otherwise reallocate.
 	if cap(c.handlers) > maxHandlersCapacity {
@@ -147,6 +203,8 @@ func (c *Context) reset() {
 
 	c.index = -1
 	c.aborted = false
+	c.middlewareRuns = c.middlewareRuns[:0]
+	c.openRuns = c.openRuns[:0]
 }
 
 // Next executes the next handler in the middleware chain.
@@ -165,6 +223,12 @@ func (c *Context) reset() {
 func (c *Context) Next() error {
 	c.index++
 	if c.index < len(c.handlers) && !c.aborted {
+		if c.shouldCancel() {
+			return ErrClientGone
+		}
+		if c.router != nil && c.router.middlewareTiming {
+			return c.timedCall(c.handlers[c.index])
+		}
 		return c.handlers[c.index](c)
 	}
 	return nil
@@ -219,6 +283,67 @@ func (c *Context) Param(name string) string {
 	return ""
 }
 
+// Route returns the pattern of the matched route (e.g., "/users/:id"),
+// or "" if no route matched. Use it to label metrics, traces and
+// profiles per endpoint without the cardinality of raw paths. It is set
+// before router middleware runs.
+//
+// Example:
+//
+//	requests.WithLabelValues(c.Request.Method, c.Route()).Inc()
+func (c *Context) Route() string {
+	return c.route
+}
+
+// RouteName returns the name of the matched route (see RouteOptions.Name
+// and RouteName), or "" if the route is unnamed or no route matched.
+//
+// Example:
+//
+//	router.HandleWithOptions("GET", "/users/:id", getUser, &fursy.RouteOptions{Name: "users.get"})
+//
+//	// In middleware:
+//	slog.Info("request", "route", c.RouteName()) // "users.get"
+func (c *Context) RouteName() string {
+	return c.routeName
+}
+
+// Params returns the URL parameters of the matched route, in path order.
+// The slice is owned by the context and only valid during the request.
+func (c *Context) Params() []Param {
+	return c.params
+}
+
+// SetParam sets the value of the URL parameter name, adding it if missing.
+// It lets middleware normalize path parameters before handlers read them.
+//
+// Example:
+//
+//	c.SetParam("slug", strings.ToLower(c.Param("slug")))
+func (c *Context) SetParam(name, value string) {
+	for i := range c.params {
+		if c.params[i].Key == name {
+			c.params[i].Value = value
+			return
+		}
+	}
+	c.params = append(c.params, Param{Key: name, Value: value})
+}
+
+// SetQuery replaces the query parameters of the request.
+// It updates Request.URL.RawQuery and the cache used by Query, so
+// middleware can normalize query parameters before handlers read them.
+//
+// Example:
+//
+//	query := c.Request.URL.Query()
+//	query.Del("debug")
+//	c.SetQuery(query)
+func (c *Context) SetQuery(values url.Values) {
+	c.Request.URL.RawQuery = values.Encode()
+	c.query = values
+}
+
 // Query returns the first value for the named query parameter.
 // Returns empty string if the parameter doesn't exist.
 //
@@ -270,20 +395,196 @@ func (c *Context) QueryValues(name string) []string {
 	return c.query[name]
 }
 
+// BindQuery binds the query parameters to the `query` tagged fields of the
+// struct obj points to. Conversion errors are returned as 400 Bad Request
+// problems.
+//
+// Binding conventions:
+//   - slice fields receive repeated parameters (tag=a&tag=b or tag[]=a&tag[]=b),
+//     or comma-separated lists with the comma option (`query:"ids,comma"`);
+//   - map fields receive bracket syntax keys (filter[status]=active);
+//   - struct fields bind their own query tagged fields in bracket syntax.
+//
+// Handlers registered with typed request structs (Box) use the same
+// conventions, and document them in OpenAPI with the style and explode
+// of each parameter.
+//
+// Example:
+//
+//	type SearchQuery struct {
+//	    Tags   []string          `query:"tag"`
+//	    IDs    []int             `query:"ids,comma"`
+//	    Filter map[string]string `query:"filter"`
+//	}
+//
+//	// Request: /search?tag=go&tag=web&ids=1,2&filter[status]=active
+//	var q SearchQuery
+//	if err := c.BindQuery(&q); err != nil {
+//	    return c.Problem(err.(fursy.Problem))
+//	}
+func (c *Context) BindQuery(obj any) error {
+	if c.query == nil {
+		c.query = c.Request.URL.Query()
+	}
+	if err := binding.MapTagged(obj, binding.TagQuery, c.query); err != nil {
+		return BadRequest(err.Error())
+	}
+	return nil
+}
+
+// BindHeader binds the request headers to the `header` tagged fields of
+// the struct obj points to, then validates it with the router's validator
+// (see Router.SetValidator). Header names are case-insensitive; slice
+// fields receive all fields of a header, or comma-separated lists with the
+// comma option (`header:"Accept-Features,comma"`). Conversion errors are
+// returned as 400 Bad Request problems.
+//
+// Structured headers (RFC 8941) are parsed with HeaderItem, HeaderList and
+// HeaderDictionary.
+//
+// Example:
+//
+//	type VersionHeaders struct {
+//	    Version  int      `header:"X-Api-Version" validate:"required,min=1"`
+//	    Features []string `header:"X-Features,comma"`
+//	}
+//
+//	var h VersionHeaders
+//	if err := c.BindHeader(&h); err != nil {
+//	    return err
+//	}
+func (c *Context) BindHeader(obj any) error {
+	if err := binding.MapTagged(obj, binding.TagHeader, c.Request.Header); err != nil {
+		return BadRequest(err.Error())
+	}
+	if c.router != nil && c.router.validator != nil {
+		return c.router.validator.Validate(obj)
+	}
+	return nil
+}
+
+// QueryTime parses the named query parameter as a time. It tries the given
+// layouts in order, or the router's layouts (Options.TimeLayouts, default
+// time.RFC3339) if none are given.
+//
+// Returns the zero time if the parameter is missing, and a 400 Bad Request
+// problem if it matches no layout.
+//
+// Example:
+//
+//	// Request: /events?since=2025-01-02T15:04:05Z
+//	since, err := c.QueryTime("since")
+//	if err != nil {
+//	    return c.Problem(err.(fursy.Problem))
+//	}
+//
+//	day, err := c.QueryTime("day", time.DateOnly)
+func (c *Context) QueryTime(name string, layouts ...string) (time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if len(layouts) == 0 && c.router != nil {
+		layouts = c.router.timeLayouts
+	}
+	if len(layouts) == 0 {
+		layouts = []string{time.RFC3339}
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, queryProblem(name, "must be a time in the format "+strings.Join(layouts, " or "))
+}
+
+// QueryDuration parses the named query parameter as a duration
+// (e.g., "90s", "1h30m"; see time.ParseDuration).
+//
+// Returns 0 if the parameter is missing, and a 400 Bad Request problem if
+// it is not a valid duration.
+//
+// Example:
+//
+//	// Request: /metrics?window=5m
+//	window, err := c.QueryDuration("window")
+//	if err != nil {
+//	    return c.Problem(err.(fursy.Problem))
+//	}
+func (c *Context) QueryDuration(name string) (time.Duration, error) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, queryProblem(name, `must be a duration (e.g., "90s", "1h30m")`)
+	}
+	return d, nil
+}
+
+// queryProblem returns the 400 Bad Request problem of an invalid query parameter.
+func queryProblem(name, message string) Problem {
+	return BadRequest(fmt.Sprintf("query parameter %q %s", name, message))
+}
+
+// ParseForm parses the request body as a URL-encoded or multipart form,
+// once per request, and returns the parse error, if any.
+// Form, PostForm and Box.Bind call it.
+//
+// Multipart forms are parsed with the route's MaxMultipartMemory, or the
+// router's (32 MB by default): larger files are stored in temporary files.
+// A form exceeding the limit (non-file values over the limit plus 10 MB,
+// or a body over RouteOptions.MaxBodySize) fails with a 413 Content Too
+// Large problem, and a malformed form with a 400 Bad Request problem.
+//
+// Example:
+//
+//	if err := c.ParseForm(); err != nil {
+//	    return err
+//	}
+//	title := c.PostForm("title")
+func (c *Context) ParseForm() error {
+	if c.formParsed {
+		return c.formErr
+	}
+	c.formParsed = true
+
+	err := c.Request.ParseMultipartForm(c.maxMultipartMemory())
+	if err == nil || errors.Is(err, http.ErrNotMultipart) {
+		return nil // URL-encoded forms are parsed before ErrNotMultipart.
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, multipart.ErrMessageTooLarge):
+		c.formErr = NewProblem(http.StatusRequestEntityTooLarge, "Content Too Large",
+			fmt.Sprintf("multipart form exceeds the %d bytes memory limit", c.maxMultipartMemory()))
+	case errors.As(err, &maxBytesErr):
+		c.formErr = NewProblem(http.StatusRequestEntityTooLarge, "Content Too Large",
+			fmt.Sprintf("request body exceeds %d bytes", maxBytesErr.Limit))
+	default:
+		c.formErr = BadRequest("invalid form: " + err.Error())
+	}
+	return c.formErr
+}
+
 // Form returns the first value for the named form parameter.
 // It checks both POST/PUT body parameters and URL query parameters.
 // Form parameters take precedence over query parameters.
 //
-// For multipart forms, it parses up to 32MB of data.
+// For multipart forms, it parses up to the route's or router's
+// MaxMultipartMemory (32 MB by default) in memory. If the form cannot be
+// parsed, Form returns "": call ParseForm to get the error.
 //
 // Example:
 //
 //	// POST /login with body: username=john&password=secret
 //	username := c.Form("username") // "john"
 func (c *Context) Form(name string) string {
-	if c.Request.Form == nil {
-		_ = c.Request.ParseMultipartForm(32 << 20) // 32 MB - error ignored as FormValue handles it
-	}
+	_ = c.ParseForm() // The error is returned by ParseForm.
 	return c.Request.FormValue(name)
 }
 
@@ -303,6 +604,8 @@ func (c *Context) FormDefault(name, defaultValue string) string {
 
 // PostForm returns the form value from POST/PUT body only (not URL query).
 // Unlike Form(), this does not fall back to query parameters.
+// If the form cannot be parsed, PostForm returns "": call ParseForm to
+// get the error.
 //
 // Example:
 //
@@ -310,9 +613,7 @@ func (c *Context) FormDefault(name, defaultValue string) string {
 //	name := c.PostForm("name") // "john"
 //	id := c.PostForm("id")     // "" (not in POST body)
 func (c *Context) PostForm(name string) string {
-	if c.Request.PostForm == nil {
-		_ = c.Request.ParseMultipartForm(32 << 20) // 32 MB - error ignored as PostFormValue handles it
-	}
+	_ = c.ParseForm() // The error is returned by ParseForm.
 	return c.Request.PostFormValue(name)
 }
 
@@ -322,7 +623,7 @@ func (c *Context) PostForm(name string) string {
 //
 //	return c.String(200, "Hello, World!")
 func (c *Context) String(code int, s string) error {
-	c.Response.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	c.Response.Header().Set("Content-Type", c.contentType("text/plain"))
 	c.Response.WriteHeader(code)
 	_, err := c.Response.Write([]byte(s))
 	return err
@@ -331,14 +632,16 @@ func (c *Context) String(code int, s string) error {
 // JSON sends a JSON response.
 // The obj is encoded using encoding/json and sent with application/json content type.
 //
+// Responses up to the buffer limit (see Options.ResponseBufferLimit) are
+// encoded before anything is written: if encoding fails, JSON returns an
+// *EncodeError and the error handler sends the error response instead of a
+// truncated body with status code.
+//
 // Example:
 //
 //	return c.JSON(200, map[string]string{"message": "success"})
 func (c *Context) JSON(code int, obj any) error {
-	c.Response.Header().Set("Content-Type", "application/json; charset=utf-8")
-	c.Response.WriteHeader(code)
-	encoder := json.NewEncoder(c.Response)
-	return encoder.Encode(obj)
+	return c.writeJSON(code, obj, "")
 }
 
 // JSONIndent sends a JSON response with indentation for pretty-printing.
@@ -348,11 +651,7 @@ func (c *Context) JSON(code int, obj any) error {
 //
 //	return c.JSONIndent(200, data, "  ") // 2-space indent
 func (c *Context) JSONIndent(code int, obj any, indent string) error {
-	c.Response.Header().Set("Content-Type", "application/json; charset=utf-8")
-	c.Response.WriteHeader(code)
-	encoder := json.NewEncoder(c.Response)
-	encoder.SetIndent("", indent)
-	return encoder.Encode(obj)
+	return c.writeJSON(code, obj, indent)
 }
 
 // XML sends an XML response.
@@ -367,12 +666,39 @@ func (c *Context) JSONIndent(code int, obj any, indent string) error {
 //	}
 //	return c.XML(200, User{ID: "123", Name: "John"})
 func (c *Context) XML(code int, obj any) error {
-	c.Response.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	if c.shouldCancel() {
+		return ErrClientGone
+	}
+	c.Response.Header().Set("Content-Type", c.contentType("application/xml"))
 	c.Response.WriteHeader(code)
 	encoder := xml.NewEncoder(c.Response)
+	if masked, ok := c.maskResponse(obj); ok {
+		// Masked copies have unnamed types: name the elements after the
+		// response type, unless it has an XMLName field.
+		if start, named := xmlStartElement(reflect.TypeOf(obj)); !named {
+			return encoder.EncodeElement(masked, start)
+		}
+		return encoder.Encode(masked)
+	}
 	return encoder.Encode(obj)
 }
 
+// xmlStartElement returns the element named after the type of the values
+// of t (the element type of pointers, slices and arrays), and whether the
+// type names its element with an XMLName field instead.
+func xmlStartElement(t reflect.Type) (xml.StartElement, bool) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct {
+		if _, ok := t.FieldByName("XMLName"); ok {
+			return xml.StartElement{}, true
+		}
+	}
+	name, _, _ := strings.Cut(t.Name(), "[")
+	return xml.StartElement{Name: xml.Name{Local: name}}, false
+}
+
 // Markdown sends a markdown text response with status 200.
 // Sets Content-Type to "text/markdown; charset=utf-8".
 //
@@ -390,7 +716,7 @@ func (c *Context) XML(code int, obj any) error {
 //	    return c.Markdown(md)
 //	})
 func (c *Context) Markdown(content string) error {
-	c.Response.Header().Set("Content-Type", MIMETextMarkdown+"; charset=utf-8")
+	c.Response.Header().Set("Content-Type", c.contentType(MIMETextMarkdown))
 	c.Response.WriteHeader(200)
 	_, err := c.Response.Write([]byte(content))
 	return err
@@ -572,18 +898,36 @@ func (c *Context) GetHeader(key string) string {
 //	// In handler:
 //	userID := c.Get("userID").(string)
 func (c *Context) Get(key string) any {
+	if s := dataSlot(key); s >= 0 {
+		return c.slots[s]
+	}
 	return c.data[key]
 }
 
 // Set stores data in the context.
 // This is useful for passing data between middleware and handlers.
 //
+// Framework keys (e.g., "request_id", "jwt") are stored in fixed slots
+// (see DataSlot); other keys in a map allocated on first use.
+//
+// Values are not visible through c.Request.Context(), unless the key is
+// mirrored (see Router.MirrorContextKeys); use StdContext to pass them to
+// context-aware code.
+//
 // Example:
 //
 //	c.Set("userID", "123")
 //	c.Set("authenticated", true)
 func (c *Context) Set(key string, value any) {
+	if s := dataSlot(key); s >= 0 {
+		c.SetSlot(s, value)
+		return
+	}
+	if c.data == nil {
+		c.data = make(map[string]any)
+	}
 	c.data[key] = value
+	c.mirrorValue(key, value)
 }
 
 // GetString retrieves a string value from the context.
@@ -593,7 +937,7 @@ func (c *Context) Set(key string, value any) {
 //
 //	userID := c.GetString("userID")
 func (c *Context) GetString(key string) string {
-	if v, ok := c.data[key].(string); ok {
+	if v, ok := c.Get(key).(string); ok {
 		return v
 	}
 	return ""
@@ -606,7 +950,7 @@ func (c *Context) GetString(key string) string {
 //
 //	page := c.GetInt("page")
 func (c *Context) GetInt(key string) int {
-	if v, ok := c.data[key].(int); ok {
+	if v, ok := c.Get(key).(int); ok {
 		return v
 	}
 	return 0
@@ -619,7 +963,7 @@ func (c *Context) GetInt(key string) int {
 //
 //	authenticated := c.GetBool("authenticated")
 func (c *Context) GetBool(key string) bool {
-	if v, ok := c.data[key].(bool); ok {
+	if v, ok := c.Get(key).(bool); ok {
 		return v
 	}
 	return false
@@ -646,9 +990,21 @@ func (c *Context) GetBool(key string) bool {
 //	    }
 //	    return c.Problem(BadRequest(err.Error()))
 //	}
+//
+// Browsers are sent the HTML error page of the status instead, if configured
+// (see Router.SetErrorPages).
 func (c *Context) Problem(p Problem) error {
+	if c.shouldCancel() {
+		return ErrClientGone
+	}
+	if name, ok := c.errorPage(p); ok {
+		if err := c.HTML(p.Status, name, p); err == nil {
+			return nil
+		}
+	}
+
 	// Set proper Content-Type for RFC 9457.
-	c.Response.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
+	c.Response.Header().Set("Content-Type", c.contentType("application/problem+json"))
 	c.Response.WriteHeader(p.Status)
 	encoder := json.NewEncoder(c.Response)
 	return encoder.Encode(p)
@@ -700,6 +1056,7 @@ func (c *Context) NegotiateFormat(offered ...string) string {
 //   - application/xml, text/xml (XML)
 //   - text/html (HTML - requires HTMLData and HTMLTemplate)
 //   - text/plain (Plain text)
+//   - Any media type registered via Router.RegisterRenderer
 //
 // Returns ErrNotAcceptable if no acceptable format is found.
 //
@@ -716,16 +1073,21 @@ func (c *Context) NegotiateFormat(offered ...string) string {
 //	// Client with "Accept: application/xml" receives XML
 func (c *Context) Negotiate(status int, data any) error {
 	// Set Vary: Accept for proper caching.
-	c.SetHeader("Vary", "Accept")
-
-	// Determine offered formats (common formats).
-	offered := []string{MIMEApplicationJSON, MIMEApplicationXML, MIMETextXML, MIMETextPlain}
+	c.AddVary("Accept")
 
-	format := c.NegotiateFormat(offered...)
+	format := c.NegotiateFormat(c.negotiateOffers()...)
 	if format == "" {
 		return c.Problem(NotAcceptable("No acceptable content type available"))
 	}
 
+	// Custom renderers (see Router.RegisterRenderer) take precedence.
+	// They and the plain text format are passed the masked response (see
+	// SetUnmasker); JSON and XML mask it themselves.
+	if render := c.customRenderer(format); render != nil {
+		data, _ = c.maskResponse(data)
+		return render(c, status, data)
+	}
+
 	// Render based on negotiated format.
 	switch format {
 	case MIMEApplicationJSON:
@@ -734,12 +1096,16 @@ func (c *Context) Negotiate(status int, data any) error {
 		return c.XML(status, data)
 	case MIMETextPlain:
 		// Plain text - use string representation.
+		data, _ = c.maskResponse(data)
 		return c.String(status, fmt.Sprintf("%v", data))
 	default:
 		return c.Problem(InternalServerError("Unsupported content type: " + format))
 	}
 }
 
+// negotiateOffers lists the formats rendered by Negotiate, in order of preference.
+var negotiateOffers = []string{MIMEApplicationJSON, MIMEApplicationXML, MIMETextXML, MIMETextPlain}
+
 // Accepts returns true if the specified media type is acceptable
 // based on the request's Accept header.
 //
@@ -777,6 +1143,126 @@ func (c *Context) AcceptsAny(mediaTypes ...string) string {
 	return c.NegotiateFormat(mediaTypes...)
 }
 
+// NegotiateLanguage returns the best offered language tag based on the
+// Accept-Language header (RFC 9110 Section 12.5.4) and adds Accept-Language to Vary.
+//
+// Language ranges match tags by prefix ("en" matches "en-US"), and more specific
+// ranges take precedence. Returns the first offered tag if the header is missing,
+// or an empty string if none of the offered tags are acceptable.
+//
+// Example:
+//
+//	lang := c.NegotiateLanguage("en", "de", "fr")
+//	if lang == "" {
+//	    lang = "en" // Fallback instead of 406.
+//	}
+//	c.SetHeader("Content-Language", lang)
+func (c *Context) NegotiateLanguage(offered ...string) string {
+	c.AddVary("Accept-Language")
+	return negotiate.Language(c.Request.Header.Get("Accept-Language"), offered)
+}
+
+// NegotiateCharset returns the best offered charset based on the
+// Accept-Charset header (RFC 9110 Section 12.5.2) and adds Accept-Charset to Vary.
+//
+// Returns the first offered charset if the header is missing,
+// or an empty string if none of the offered charsets are acceptable.
+//
+// Example:
+//
+//	charset := c.NegotiateCharset("utf-8", "iso-8859-1")
+func (c *Context) NegotiateCharset(offered ...string) string {
+	c.AddVary("Accept-Charset")
+	return negotiate.Charset(c.Request.Header.Get("Accept-Charset"), offered)
+}
+
+// NegotiateEncoding returns the best offered content coding based on the
+// Accept-Encoding header (RFC 9110 Section 12.5.3) and adds Accept-Encoding to Vary.
+//
+// "identity" is acceptable unless explicitly excluded, so offer it last
+// to fall back to an uncompressed response. Returns the first offered coding
+// if the header is missing, or an empty string if none are acceptable.
+//
+// Example:
+//
+//	switch c.NegotiateEncoding("br", "gzip", "identity") {
+//	case "br":
+//	    // Brotli-compress response.
+//	case "gzip":
+//	    // Gzip-compress response.
+//	}
+func (c *Context) NegotiateEncoding(offered ...string) string {
+	c.AddVary("Accept-Encoding")
+	return negotiate.Encoding(c.Request.Header.Get("Accept-Encoding"), offered)
+}
+
+// AddVary adds request header names to the Vary response header.
+//
+// Names already present are not duplicated, so middleware and handlers
+// can each declare the headers their response depends on. Adding "*"
+// replaces the header with "Vary: *".
+//
+// Example:
+//
+//	c.AddVary("Accept-Encoding")
+//	c.AddVary("Accept-Language", "Accept-Encoding")
+//	// Vary: Accept-Encoding, Accept-Language
+func (c *Context) AddVary(headers ...string) {
+	AddVaryHeader(c.Response.Header(), headers...)
+}
+
+// Vary adds request header names to the Vary response header.
+// It is equivalent to AddVary.
+func (c *Context) Vary(headers ...string) {
+	AddVaryHeader(c.Response.Header(), headers...)
+}
+
+// AddVaryHeader adds request header names to the Vary header of h,
+// without duplicating names already present.
+//
+// Use it where only an http.Header is available (e.g., in net/http
+// middleware); handlers and fursy middleware use Context.AddVary.
+//
+// Example:
+//
+//	fursy.AddVaryHeader(w.Header(), "Origin")
+func AddVaryHeader(h http.Header, headers ...string) {
+	existing := h.Values("Vary")
+
+	for _, name := range headers {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if name == "*" {
+			h.Set("Vary", "*")
+			return
+		}
+		name = http.CanonicalHeaderKey(name)
+		if varyContains(existing, name) {
+			continue
+		}
+		existing = append(existing, name)
+	}
+
+	if len(existing) > 0 {
+		h.Set("Vary", strings.Join(existing, ", "))
+	}
+}
+
+// varyContains reports whether the Vary header values contain name (or "*").
+func varyContains(values []string, name string) bool {
+	for _, v := range values {
+		for _, field := range strings.Split(v, ",") {
+			field = strings.TrimSpace(field)
+			if field == "*" || strings.EqualFold(field, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // NotAcceptable creates a 406 Not Acceptable Problem.
 //
 // RFC 9110 Section 15.5.7: The 406 Not Acceptable status code indicates that
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestContext_Param tests URL parameter extraction.
@@ -122,6 +123,122 @@ func TestContext_QueryValues(t *testing.T) {
 	}
 }
 
+// TestContext_BindQuery tests binding query parameters into a struct.
+func TestContext_BindQuery(t *testing.T) {
+	type searchQuery struct {
+		Tags   []string          `query:"tag"`
+		IDs    []int             `query:"ids,comma"`
+		Filter map[string]string `query:"filter"`
+	}
+
+	c := newContext()
+	c.Request = httptest.NewRequest("GET", "/search?tag=go&tag=web&ids=1,2&filter[status]=active", http.NoBody)
+
+	var q searchQuery
+	if err := c.BindQuery(&q); err != nil {
+		t.Fatalf("BindQuery failed: %v", err)
+	}
+	if len(q.Tags) != 2 || len(q.IDs) != 2 || q.IDs[1] != 2 || q.Filter["status"] != "active" {
+		t.Errorf("BindQuery = %+v", q)
+	}
+
+	c.Request = httptest.NewRequest("GET", "/search?ids=1,x", http.NoBody)
+	c.query = nil
+	var p Problem
+	if err := c.BindQuery(&q); !errors.As(err, &p) || p.Status != http.StatusBadRequest {
+		t.Errorf("expected 400 problem, got %v", err)
+	}
+}
+
+// TestContext_BindHeader tests binding request headers into a struct.
+func TestContext_BindHeader(t *testing.T) {
+	type versionHeaders struct {
+		Version  int      `header:"x-api-version"`
+		Features []string `header:"X-Features,comma"`
+	}
+
+	c := newContext()
+	c.Request = httptest.NewRequest("GET", "/", http.NoBody)
+	c.Request.Header.Set("X-Api-Version", "2")
+	c.Request.Header.Add("X-Features", "beta, dark-mode")
+	c.Request.Header.Add("X-Features", "search")
+
+	var h versionHeaders
+	if err := c.BindHeader(&h); err != nil {
+		t.Fatalf("BindHeader failed: %v", err)
+	}
+	if h.Version != 2 || len(h.Features) != 3 || h.Features[1] != "dark-mode" {
+		t.Errorf("BindHeader = %+v", h)
+	}
+
+	c.Request.Header.Set("X-Api-Version", "two")
+	var p Problem
+	if err := c.BindHeader(&h); !errors.As(err, &p) || p.Status != http.StatusBadRequest {
+		t.Errorf("expected 400 problem, got %v", err)
+	}
+}
+
+// TestContext_QueryTime tests parsing time query parameters.
+func TestContext_QueryTime(t *testing.T) {
+	c := newContext()
+	c.Request = httptest.NewRequest("GET", "/events?since=2025-01-02T15:04:05Z&day=2025-01-02&bad=yesterday", http.NoBody)
+
+	since, err := c.QueryTime("since")
+	if err != nil || !since.Equal(time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)) {
+		t.Errorf("QueryTime(since) = %v, %v", since, err)
+	}
+	day, err := c.QueryTime("day", time.RFC3339, time.DateOnly)
+	if err != nil || day.Day() != 2 {
+		t.Errorf("QueryTime(day) = %v, %v", day, err)
+	}
+	if missing, err := c.QueryTime("until"); err != nil || !missing.IsZero() {
+		t.Errorf("QueryTime(until) = %v, %v", missing, err)
+	}
+
+	_, err = c.QueryTime("bad")
+	var p Problem
+	if !errors.As(err, &p) || p.Status != http.StatusBadRequest || !strings.Contains(p.Detail, `"bad"`) {
+		t.Errorf("expected 400 problem, got %v", err)
+	}
+
+	// Router layouts apply when the handler passes none.
+	r := NewWithOptions(WithTimeLayouts(time.DateOnly))
+	r.GET("/events", func(c *Context) error {
+		day, err := c.QueryTime("day")
+		if err != nil {
+			return c.Problem(err.(Problem))
+		}
+		return c.String(http.StatusOK, day.Format(time.DateOnly))
+	})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/events?day=2025-01-02", http.NoBody))
+	if w.Code != http.StatusOK || w.Body.String() != "2025-01-02" {
+		t.Errorf("status = %d, body = %q", w.Code, w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/events?day=2025-01-02T15:04:05Z", http.NoBody))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+// TestContext_QueryDuration tests parsing duration query parameters.
+func TestContext_QueryDuration(t *testing.T) {
+	c := newContext()
+	c.Request = httptest.NewRequest("GET", "/metrics?window=1h30m&bad=5", http.NoBody)
+
+	if d, err := c.QueryDuration("window"); err != nil || d != 90*time.Minute {
+		t.Errorf("QueryDuration(window) = %v, %v", d, err)
+	}
+	if d, err := c.QueryDuration("missing"); err != nil || d != 0 {
+		t.Errorf("QueryDuration(missing) = %v, %v", d, err)
+	}
+	var p Problem
+	if _, err := c.QueryDuration("bad"); !errors.As(err, &p) || p.Status != http.StatusBadRequest {
+		t.Errorf("expected 400 problem, got %v", err)
+	}
+}
+
 // TestContext_Form tests form parameter extraction.
 func TestContext_Form(t *testing.T) {
 	form := url.Values{}
@@ -1216,3 +1333,41 @@ func TestContext_Markdown(t *testing.T) {
 		})
 	}
 }
+
+// TestContext_SetParam tests replacing and adding path parameters.
+func TestContext_SetParam(t *testing.T) {
+	c := newContext()
+	c.params = []Param{{Key: "id", Value: "123"}}
+
+	c.SetParam("id", "456")
+	c.SetParam("slug", "hello")
+
+	if got := c.Param("id"); got != "456" {
+		t.Errorf("Param(id) = %q, want %q", got, "456")
+	}
+	if got := c.Param("slug"); got != "hello" {
+		t.Errorf("Param(slug) = %q, want %q", got, "hello")
+	}
+	if got := len(c.Params()); got != 2 {
+		t.Errorf("len(Params()) = %d, want 2", got)
+	}
+}
+
+// TestContext_SetQuery tests replacing query parameters.
+func TestContext_SetQuery(t *testing.T) {
+	c := newContext()
+	c.Request = httptest.NewRequest("GET", "/test?page=2", http.NoBody)
+
+	if got := c.Query("page"); got != "2" {
+		t.Fatalf("Query(page) = %q, want %q", got, "2")
+	}
+
+	c.SetQuery(url.Values{"page": {"3"}})
+
+	if got := c.Query("page"); got != "3" {
+		t.Errorf("Query(page) = %q, want %q", got, "3")
+	}
+	if got := c.Request.URL.RawQuery; got != "page=3" {
+		t.Errorf("RawQuery = %q, want %q", got, "page=3")
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"context"
+	"maps"
+)
+
+// DataSlot is a fixed storage slot of a Context for a framework key,
+// accessed by index instead of through the data map.
+//
+// Context.Set and Context.Get store the keys of the slots (e.g.,
+// "request_id") in their slots transparently; Context.Slot and
+// Context.SetSlot skip the key lookup on hot paths.
+type DataSlot int
+
+// Data slots of the framework keys.
+const (
+	SlotRequestID DataSlot = iota // "request_id"
+	SlotTraceID                   // "trace_id"
+	SlotUser                      // "User" (middleware.UserContextKey)
+	SlotJWT                       // "jwt" (middleware.JWTContextKey)
+	SlotJWTToken                  // "jwt_token" (middleware.JWTTokenContextKey)
+
+	numDataSlots
+)
+
+// dataSlotKeys are the keys of the data slots, by slot.
+var dataSlotKeys = [numDataSlots]string{
+	SlotRequestID: "request_id",
+	SlotTraceID:   "trace_id",
+	SlotUser:      "User",
+	SlotJWT:       "jwt",
+	SlotJWTToken:  "jwt_token",
+}
+
+// String returns the key of the slot.
+func (s DataSlot) String() string {
+	return dataSlotKeys[s]
+}
+
+// dataSlot returns the slot of key, or -1 if key is stored in the data map.
+func dataSlot(key string) DataSlot {
+	switch key {
+	case "request_id":
+		return SlotRequestID
+	case "trace_id":
+		return SlotTraceID
+	case "User":
+		return SlotUser
+	case "jwt":
+		return SlotJWT
+	case "jwt_token":
+		return SlotJWTToken
+	}
+	return -1
+}
+
+// Slot returns the value stored in slot s, or nil.
+//
+// Example:
+//
+//	requestID, _ := c.Slot(fursy.SlotRequestID).(string)
+func (c *Context) Slot(s DataSlot) any {
+	return c.slots[s]
+}
+
+// SetSlot stores value in slot s, like Set with the key of the slot.
+//
+// Example:
+//
+//	c.SetSlot(fursy.SlotRequestID, id) // Same as c.Set("request_id", id).
+func (c *Context) SetSlot(s DataSlot, value any) {
+	c.slots[s] = value
+	c.slotsSet |= 1 << s
+	c.mirrorValue(dataSlotKeys[s], value)
+}
+
+// snapshotData returns a copy of the values stored with Set, or nil if none.
+func (c *Context) snapshotData() map[string]any {
+	data := maps.Clone(c.data)
+	for s := range numDataSlots {
+		if c.slotsSet&(1<<s) == 0 {
+			continue
+		}
+		if data == nil {
+			data = make(map[string]any)
+		}
+		data[dataSlotKeys[s]] = c.slots[s]
+	}
+	return data
+}
+
+// ContextKey is the request context key of values stored with Context.Set,
+// as seen by code that only receives a context.Context
+// (see Context.StdContext and Router.MirrorContextKeys).
+//
+// Example:
+//
+//	userID, _ := ctx.Value(fursy.ContextKey("user_id")).(string)
+type ContextKey string
+
+// ValueFrom returns the value stored with Context.Set under key, as seen
+// through ctx, or nil. ctx must come from Context.StdContext or be a
+// request context with key mirrored (see Router.MirrorContextKeys).
+//
+// Example:
+//
+//	func (r *Repo) Find(ctx context.Context, id int) (*User, error) {
+//	    tenant, _ := fursy.ValueFrom(ctx, "tenant").(string)
+//	    ...
+//	}
+func ValueFrom(ctx context.Context, key string) any {
+	return ctx.Value(ContextKey(key))
+}
+
+// StdContext returns the request context extended with the values stored
+// with Set, which are looked up with ContextKey (or ValueFrom).
+//
+// The values are a snapshot taken at the time of the call, so the
+// returned context can outlive the request (e.g., in a goroutine).
+//
+// Example:
+//
+//	router.GET("/orders", func(c *fursy.Context) error {
+//	    orders, err := db.ListOrders(c.StdContext()) // Query hooks see request_id and user_id.
+//	    ...
+//	})
+func (c *Context) StdContext() context.Context {
+	return dataContext{Context: c.Request.Context(), data: c.snapshotData()}
+}
+
+// dataContext serves Context.Set values for ContextKey lookups.
+type dataContext struct {
+	context.Context
+	data map[string]any
+}
+
+// Value implements context.Context.
+func (d dataContext) Value(key any) any {
+	if k, ok := key.(ContextKey); ok {
+		if v, ok := d.data[string(k)]; ok {
+			return v
+		}
+	}
+	return d.Context.Value(key)
+}
+
+// MirrorContextKeys makes Context.Set also store the values of keys in the
+// request context under ContextKey(key), so they flow into context-aware
+// libraries (database drivers, HTTP clients, slog handlers) that only
+// receive c.Request.Context().
+//
+// Each mirrored Set replaces c.Request with a derived request, so mirror
+// only the few keys that need it.
+//
+// Example:
+//
+//	router.MirrorContextKeys("request_id", "user_id")
+func (r *Router) MirrorContextKeys(keys ...string) *Router {
+	for _, key := range keys {
+		r.MirrorContextKey(key, ContextKey(key))
+	}
+	return r
+}
+
+// MirrorContextKey makes Context.Set also store the value of key in the
+// request context under ctxKey, for libraries that look values up with
+// their own key type.
+//
+// Example:
+//
+//	// Make the authenticated user visible to an audit library.
+//	router.MirrorContextKey(middleware.UserContextKey, audit.ActorKey)
+func (r *Router) MirrorContextKey(key string, ctxKey any) *Router {
+	if ctxKey == nil {
+		panic("fursy: context key cannot be nil")
+	}
+	if r.contextKeys == nil {
+		r.contextKeys = make(map[string]any)
+	}
+	r.contextKeys[key] = ctxKey
+	return r
+}
+
+// mirrorValue stores value in the request context if key is mirrored.
+func (c *Context) mirrorValue(key string, value any) {
+	if c.router == nil || c.Request == nil {
+		return
+	}
+	if ctxKey, ok := c.router.contextKeys[key]; ok {
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), ctxKey, value))
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// auditKey is a library-specific context key type.
+type auditKey struct{}
+
+// TestContext_StdContext tests exposing Set values through a context.Context.
+func TestContext_StdContext(t *testing.T) {
+	type parentKey struct{}
+
+	r := New()
+	var ctx context.Context
+	r.GET("/", func(c *Context) error {
+		c.Set("request_id", "req-1")
+		ctx = c.StdContext()
+		c.Set("late", true)
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req = req.WithContext(context.WithValue(req.Context(), parentKey{}, "parent"))
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	// The snapshot outlives the request.
+	if got := ValueFrom(ctx, "request_id"); got != "req-1" {
+		t.Errorf("request_id = %v, want req-1", got)
+	}
+	if got := ctx.Value(ContextKey("late")); got != nil {
+		t.Errorf("expected values set after StdContext to be absent, got %v", got)
+	}
+	if got := ctx.Value(parentKey{}); got != "parent" {
+		t.Errorf("expected parent values, got %v", got)
+	}
+	if got := ctx.Value("request_id"); got != nil {
+		t.Errorf("expected plain string keys to be ignored, got %v", got)
+	}
+}
+
+// TestRouter_MirrorContextKeys tests mirroring Set values into the request context.
+func TestRouter_MirrorContextKeys(t *testing.T) {
+	r := New()
+	r.MirrorContextKeys("request_id").MirrorContextKey("user", auditKey{})
+
+	r.Use(func(c *Context) error {
+		c.Set("request_id", "req-1")
+		c.Set("user", "alice")
+		c.Set("other", 1)
+		return c.Next()
+	})
+
+	var requestID, user, other any
+	r.GET("/", func(c *Context) error {
+		ctx := c.Request.Context()
+		requestID = ValueFrom(ctx, "request_id")
+		user = ctx.Value(auditKey{})
+		other = ValueFrom(ctx, "other")
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	if requestID != "req-1" {
+		t.Errorf("request_id = %v, want req-1", requestID)
+	}
+	if user != "alice" {
+		t.Errorf("user = %v, want alice", user)
+	}
+	if other != nil {
+		t.Errorf("expected unmirrored key to be absent, got %v", other)
+	}
+}
+
+// TestRouter_MirrorContextKey_NilPanics tests that a nil context key panics.
+func TestRouter_MirrorContextKey_NilPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for nil context key")
+		}
+	}()
+	New().MirrorContextKey("user", nil)
+}
+
+// TestContext_DataSlots tests storing framework keys in fixed slots.
+func TestContext_DataSlots(t *testing.T) {
+	r := New().MirrorContextKeys("request_id")
+	c := r.pool.Get().(*Context)
+	c.init(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody), r, nil)
+
+	c.Set("request_id", "req-1")
+	c.SetSlot(SlotJWT, "claims")
+	c.Set("jwt_token", nil)
+	c.Set("tenant", "acme")
+
+	if got := c.Slot(SlotRequestID); got != "req-1" {
+		t.Errorf("Slot(SlotRequestID) = %v, want req-1", got)
+	}
+	if got := c.GetString("jwt"); got != "claims" {
+		t.Errorf("Get(jwt) = %v, want claims", got)
+	}
+	if got := ValueFrom(c.Request.Context(), "request_id"); got != "req-1" {
+		t.Errorf("mirrored request_id = %v, want req-1", got)
+	}
+
+	ctx := context.WithValue(context.Background(), ContextKey("jwt_token"), "parent")
+	c.Request = c.Request.WithContext(ctx)
+	std := c.StdContext()
+	if ValueFrom(std, "tenant") != "acme" || ValueFrom(std, "jwt") != "claims" {
+		t.Errorf("StdContext should see slot and map values")
+	}
+	if got := ValueFrom(std, "jwt_token"); got != nil {
+		t.Errorf("slot set to nil should shadow the parent value, got %v", got)
+	}
+
+	c.reset()
+	if c.Get("request_id") != nil || c.Get("tenant") != nil || len(c.snapshotData()) != 0 {
+		t.Error("data should be empty after reset")
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		c.Set("User", "alice")
+		_ = c.Get("User")
+		c.reset()
+	})
+	if allocs != 0 {
+		t.Errorf("Set/Get of slot keys allocates %v times, want 0", allocs)
+	}
+}
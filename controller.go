@@ -0,0 +1,154 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Controller groups the handlers of a resource as methods of a struct.
+// Register it with Router.Controller or RouteGroup.Controller.
+//
+// Handler methods have the HandlerFunc signature: func(*fursy.Context) error.
+type Controller interface {
+	// Routes maps the names of handler methods to route patterns
+	// ("METHOD /path"), relative to the controller prefix.
+	Routes() map[string]string
+}
+
+// Controller registers the handler methods of ctrl under prefix, in a group
+// with the given middleware (see Router.Group).
+//
+// Each route gets an OperationID derived from the method and the controller
+// type ("Get" on UserController → "getUser") and is tagged with the
+// controller name ("user"), unless the controller implements
+// interface{ Tags() []string }.
+//
+// Panics if a route pattern is malformed or names a method that is missing
+// or does not have the HandlerFunc signature.
+//
+// Example:
+//
+//	type UserController struct {
+//	    db *sql.DB
+//	}
+//
+//	func (uc *UserController) Routes() map[string]string {
+//	    return map[string]string{
+//	        "List":   "GET /",
+//	        "Get":    "GET /:id",
+//	        "Create": "POST /",
+//	    }
+//	}
+//
+//	func (uc *UserController) Get(c *fursy.Context) error { ... }
+//
+//	router.Controller("/users", &UserController{db: db}, auth)
+func (r *Router) Controller(prefix string, ctrl Controller, middleware ...HandlerFunc) *RouteGroup {
+	g := r.Group(prefix, middleware...)
+	registerController(g, ctrl)
+	return g
+}
+
+// Controller registers the handler methods of ctrl under the group prefix
+// plus prefix, with the group middleware followed by middleware
+// (see Router.Controller).
+//
+// Example:
+//
+//	v1 := router.Group("/api/v1", auth)
+//	v1.Controller("/users", &UserController{db: db})
+func (g *RouteGroup) Controller(prefix string, ctrl Controller, middleware ...HandlerFunc) *RouteGroup {
+	child := g.Group(prefix)
+	child.Use(middleware...)
+	registerController(child, ctrl)
+	return child
+}
+
+// handlerFuncType is the type of controller handler methods.
+var handlerFuncType = reflect.TypeFor[func(*Context) error]()
+
+// registerController registers the routes of ctrl on g, sorted by method name.
+func registerController(g *RouteGroup, ctrl Controller) {
+	if ctrl == nil {
+		panic("fursy: controller cannot be nil")
+	}
+
+	value := reflect.ValueOf(ctrl)
+	name := controllerName(value.Type())
+	tags := []string{lowerFirst(name)}
+	if t, ok := ctrl.(interface{ Tags() []string }); ok {
+		tags = t.Tags()
+	}
+
+	routes := ctrl.Routes()
+	methods := make([]string, 0, len(routes))
+	for method := range routes {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	for _, method := range methods {
+		pattern := routes[method]
+		httpMethod, path, ok := strings.Cut(strings.TrimSpace(pattern), " ")
+		path = strings.TrimSpace(path)
+		if !ok || httpMethod == "" || !strings.HasPrefix(path, "/") {
+			panic(fmt.Sprintf("fursy: controller %s: invalid route %q for %s (want \"METHOD /path\")", name, pattern, method))
+		}
+
+		m := value.MethodByName(method)
+		if !m.IsValid() {
+			panic(fmt.Sprintf("fursy: controller %s has no method %s", name, method))
+		}
+		if m.Type() != handlerFuncType {
+			panic(fmt.Sprintf("fursy: controller %s method %s must be func(*fursy.Context) error, got %s", name, method, m.Type()))
+		}
+		handler := HandlerFunc(m.Interface().(func(*Context) error))
+
+		if path == "/" && g.prefix != "" {
+			path = ""
+		}
+		g.handle(strings.ToUpper(httpMethod), path, handler, nil, func(route *RouteInfo) {
+			route.OperationID = lowerFirst(method) + upperFirst(name)
+			route.Tags = tags
+		})
+	}
+}
+
+// controllerName returns the controller type name without pointer and
+// "Controller" suffix (e.g., *UserController → "User").
+func controllerName(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if name := strings.TrimSuffix(t.Name(), "Controller"); name != "" {
+		return name
+	}
+	return t.Name()
+}
+
+// lowerFirst lower-cases the first letter of s.
+func lowerFirst(s string) string {
+	return mapFirst(s, unicode.ToLower)
+}
+
+// upperFirst upper-cases the first letter of s.
+func upperFirst(s string) string {
+	return mapFirst(s, unicode.ToUpper)
+}
+
+// mapFirst applies f to the first letter of s.
+func mapFirst(s string, f func(rune) rune) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return s
+	}
+	return string(f(r)) + s[size:]
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+type UserController struct {
+	prefix string
+}
+
+func (uc *UserController) Routes() map[string]string {
+	return map[string]string{
+		"List":   "GET /",
+		"Get":    "GET /:id",
+		"Create": "post /",
+	}
+}
+
+func (uc *UserController) List(c *Context) error {
+	return c.String(http.StatusOK, uc.prefix+"list")
+}
+
+func (uc *UserController) Get(c *Context) error {
+	return c.String(http.StatusOK, uc.prefix+"get "+c.Param("id"))
+}
+
+func (uc *UserController) Create(c *Context) error {
+	return c.String(http.StatusCreated, uc.prefix+"create")
+}
+
+type taggedController struct{}
+
+func (taggedController) Routes() map[string]string { return map[string]string{"Ping": "GET /ping"} }
+func (taggedController) Tags() []string            { return []string{"health"} }
+func (taggedController) Ping(c *Context) error     { return c.NoContent(http.StatusNoContent) }
+
+type badController struct{ routes map[string]string }
+
+func (b badController) Routes() map[string]string { return b.routes }
+func (badController) Wrong(c *Context) string     { return "" }
+
+// TestRouter_Controller tests controller registration and routing.
+func TestRouter_Controller(t *testing.T) {
+	r := New()
+	var calls int
+	r.Controller("/users", &UserController{prefix: "u:"}, func(c *Context) error {
+		calls++
+		return c.Next()
+	})
+
+	tests := []struct {
+		method, path string
+		status       int
+		body         string
+	}{
+		{http.MethodGet, "/users", http.StatusOK, "u:list"},
+		{http.MethodGet, "/users/42", http.StatusOK, "u:get 42"},
+		{http.MethodPost, "/users", http.StatusCreated, "u:create"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, http.NoBody))
+		if w.Code != tt.status || w.Body.String() != tt.body {
+			t.Errorf("%s %s = %d %q, want %d %q", tt.method, tt.path, w.Code, w.Body.String(), tt.status, tt.body)
+		}
+	}
+	if calls != len(tests) {
+		t.Errorf("controller middleware ran %d times, want %d", calls, len(tests))
+	}
+}
+
+// TestRouter_ControllerRouteInfo tests derived operation IDs and tags.
+func TestRouter_ControllerRouteInfo(t *testing.T) {
+	r := New()
+	r.Controller("/users", &UserController{})
+	r.Controller("", taggedController{})
+
+	want := map[string]struct {
+		operationID string
+		tag         string
+	}{
+		"GET /users":     {"listUser", "user"},
+		"GET /users/:id": {"getUser", "user"},
+		"POST /users":    {"createUser", "user"},
+		"GET /ping":      {"pingTagged", "health"},
+	}
+	routes := r.Routes()
+	if len(routes) != len(want) {
+		t.Fatalf("got %d routes, want %d", len(routes), len(want))
+	}
+	for _, route := range routes {
+		w, ok := want[route.Method+" "+route.Path]
+		if !ok {
+			t.Errorf("unexpected route %s %s", route.Method, route.Path)
+			continue
+		}
+		if route.OperationID != w.operationID {
+			t.Errorf("%s %s: OperationID = %q, want %q", route.Method, route.Path, route.OperationID, w.operationID)
+		}
+		if !slices.Equal(route.Tags, []string{w.tag}) {
+			t.Errorf("%s %s: Tags = %v, want [%s]", route.Method, route.Path, route.Tags, w.tag)
+		}
+	}
+}
+
+// TestRouteGroup_Controller tests controllers registered on a group.
+func TestRouteGroup_Controller(t *testing.T) {
+	r := New()
+	var order []string
+	api := r.Group("/api", func(c *Context) error {
+		order = append(order, "group")
+		return c.Next()
+	})
+	api.Controller("/users", &UserController{}, func(c *Context) error {
+		order = append(order, "controller")
+		return c.Next()
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/users/7", http.NoBody))
+	if w.Body.String() != "get 7" {
+		t.Errorf("body = %q, want %q", w.Body.String(), "get 7")
+	}
+	if !slices.Equal(order, []string{"group", "controller"}) {
+		t.Errorf("middleware order = %v, want [group controller]", order)
+	}
+}
+
+// TestRouter_ControllerPanics tests invalid controllers.
+func TestRouter_ControllerPanics(t *testing.T) {
+	tests := []struct {
+		name   string
+		routes map[string]string
+	}{
+		{"missing method", map[string]string{"Missing": "GET /"}},
+		{"wrong signature", map[string]string{"Wrong": "GET /"}},
+		{"no method", map[string]string{"Wrong": "/"}},
+		{"relative path", map[string]string{"Wrong": "GET users"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			New().Controller("/bad", badController{routes: tt.routes})
+		})
+	}
+}
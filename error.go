@@ -5,7 +5,10 @@
 // Package fursy provides common HTTP errors for the FURSY router.
 package fursy
 
-import "errors"
+import (
+	"errors"
+	"net/http"
+)
 
 // Common HTTP errors.
 var (
@@ -24,3 +27,45 @@ var (
 	// ErrInternalServerError is returned for server errors.
 	ErrInternalServerError = errors.New("internal server error")
 )
+
+// ErrorHandler handles an error returned by a handler or middleware chain.
+// It is responsible for writing the error response.
+//
+// Error handlers can be set on the Router (global) and on RouteGroups
+// (for a subtree). The most specific handler wins.
+//
+// Example:
+//
+//	api := router.Group("/api")
+//	api.SetErrorHandler(func(c *fursy.Context, err error) {
+//	    var p fursy.Problem
+//	    if errors.As(err, &p) {
+//	        _ = c.Problem(p)
+//	        return
+//	    }
+//	    _ = c.Problem(fursy.InternalServerError(err.Error()))
+//	})
+type ErrorHandler func(c *Context, err error)
+
+// defaultErrorHandler sends a plain text 500 Internal Server Error response.
+//
+// When error pages are configured (see Router.SetErrorPages), Problem errors
+// are sent with their status and other errors as 500 problems, so browsers
+// get the error page and API clients get application/problem+json.
+// Response encoding errors (see EncodeError) are always sent as 500 problems.
+func defaultErrorHandler(c *Context, err error) {
+	var encErr *EncodeError
+	if errors.As(err, &encErr) {
+		_ = c.Problem(InternalServerError(""))
+		return
+	}
+	if c.hasErrorPages() {
+		var p Problem
+		if !errors.As(err, &p) {
+			p = InternalServerError("")
+		}
+		_ = c.Problem(p)
+		return
+	}
+	_ = c.String(http.StatusInternalServerError, "Internal Server Error")
+}
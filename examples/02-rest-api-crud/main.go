@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/coregx/fursy"
 	"github.com/coregx/fursy/middleware"
@@ -26,15 +27,15 @@ func main() {
 	setupRoutes(router, handlers)
 
 	// Start server.
-	slog.Info("Server starting", "port", 8080)
-	slog.Info("API endpoints available:")
-	slog.Info("  POST   /users       - Create user")
-	slog.Info("  GET    /users       - List all users")
-	slog.Info("  GET    /users/:id   - Get user by ID")
-	slog.Info("  PUT    /users/:id   - Update user")
-	slog.Info("  DELETE /users/:id   - Delete user")
-
-	if err := http.ListenAndServe(":8080", router); err != nil {
+	srv := &http.Server{
+		Addr:              ":8080",
+		Handler:           router,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	router.SetServer(srv)
+	router.LogSummary(slog.Default())
+
+	if err := srv.ListenAndServe(); err != nil {
 		slog.Error("Server failed", "error", err)
 		os.Exit(1)
 	}
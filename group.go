@@ -4,6 +4,12 @@
 
 package fursy
 
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
 // RouteGroup represents a group of routes that share the same path prefix and middleware.
 // Groups allow organizing routes hierarchically and applying middleware to specific route sets.
 //
@@ -28,6 +34,18 @@ type RouteGroup struct {
 	// middleware stores group-specific middleware.
 	// These are combined with router middleware when registering routes.
 	middleware []HandlerFunc
+
+	// parent is the parent group (nil for top-level groups).
+	// Used to inherit the error handler.
+	parent *RouteGroup
+
+	// errorHandler handles errors from routes in this group and nested groups.
+	// Set using SetErrorHandler(). Falls back to parent group, then router.
+	errorHandler ErrorHandler
+
+	// notFoundHandler handles unmatched paths under this group's prefix.
+	// Set using SetNotFoundHandler().
+	notFoundHandler HandlerFunc
 }
 
 // Use registers middleware to the route group.
@@ -79,7 +97,153 @@ func (g *RouteGroup) Group(prefix string, middleware ...HandlerFunc) *RouteGroup
 		prefix:     g.prefix + prefix,
 		router:     g.router,
 		middleware: groupMiddleware,
+		parent:     g,
+	}
+}
+
+// Without returns a group with the same prefix as g and g's middleware
+// except those named names (see Named and MiddlewareName), e.g. so a
+// public subtree of an authenticated group can drop the auth middleware
+// instead of relying on a Skipper.
+//
+// The returned group is nested in g, so it inherits g's error handler.
+// Router middleware (Router.Use) always runs and cannot be removed.
+//
+// Panics if g has no middleware named one of names.
+//
+// Example:
+//
+//	api := router.Group("/api", fursy.Named("auth", middleware.JWT(secret)), audit)
+//	api.GET("/orders", listOrders)            // auth + audit
+//
+//	public := api.Without("auth")
+//	public.GET("/status", status)             // GET /api/status, audit only
+func (g *RouteGroup) Without(names ...string) *RouteGroup {
+	for _, name := range names {
+		g.middlewareIndex(name) // Panics if missing.
+	}
+
+	middleware := make([]HandlerFunc, 0, len(g.middleware))
+	for _, mw := range g.middleware {
+		if !slices.Contains(names, MiddlewareName(mw)) {
+			middleware = append(middleware, mw)
+		}
+	}
+	return g.derive(middleware)
+}
+
+// Replace returns a group with the same prefix as g and g's middleware,
+// with the middleware named name replaced by mw at the same position.
+// Like Without, the returned group is nested in g.
+//
+// Panics if g has no middleware named name or mw is nil.
+//
+// Example:
+//
+//	api := router.Group("/api", fursy.Named("ratelimit", middleware.RateLimit(10, 20)))
+//	uploads := api.Replace("ratelimit", middleware.RateLimit(1, 2))
+//	uploads.POST("/uploads", upload)
+func (g *RouteGroup) Replace(name string, mw HandlerFunc) *RouteGroup {
+	if mw == nil {
+		panic("fursy: middleware cannot be nil")
+	}
+
+	middleware := slices.Clone(g.middleware)
+	middleware[g.middlewareIndex(name)] = mw
+	return g.derive(middleware)
+}
+
+// middlewareIndex returns the index of the group middleware named name.
+func (g *RouteGroup) middlewareIndex(name string) int {
+	i := slices.IndexFunc(g.middleware, func(mw HandlerFunc) bool {
+		return MiddlewareName(mw) == name
+	})
+	if i < 0 {
+		panic(fmt.Sprintf("fursy: group %q has no middleware named %q", g.prefix, name))
 	}
+	return i
+}
+
+// derive returns a group nested in g with the same prefix and middleware.
+func (g *RouteGroup) derive(middleware []HandlerFunc) *RouteGroup {
+	return &RouteGroup{
+		prefix:     g.prefix,
+		router:     g.router,
+		middleware: middleware,
+		parent:     g,
+	}
+}
+
+// SetErrorHandler sets the error handler for routes in this group.
+//
+// The handler applies to all routes registered on this group and its nested
+// groups (unless they set their own), overriding the router's error handler.
+// This allows an /api subtree to return problem+json while an HTML subtree
+// renders error pages.
+//
+// Example:
+//
+//	api := router.Group("/api")
+//	api.SetErrorHandler(func(c *fursy.Context, err error) {
+//	    _ = c.Problem(fursy.InternalServerError(err.Error()))
+//	})
+//
+//	web := router.Group("/app")
+//	web.SetErrorHandler(func(c *fursy.Context, err error) {
+//	    _ = renderErrorPage(c, err)
+//	})
+func (g *RouteGroup) SetErrorHandler(h ErrorHandler) *RouteGroup {
+	g.errorHandler = h
+	return g
+}
+
+// SetNotFoundHandler sets the handler for unmatched paths under this group's prefix.
+//
+// When several groups match a path, the group with the longest prefix wins.
+// Errors returned by the handler are passed to the group's error handler.
+//
+// Example:
+//
+//	api := router.Group("/api")
+//	api.SetNotFoundHandler(func(c *fursy.Context) error {
+//	    return c.Problem(fursy.NotFound("unknown API endpoint"))
+//	})
+func (g *RouteGroup) SetNotFoundHandler(h HandlerFunc) *RouteGroup {
+	if g.notFoundHandler == nil && h != nil {
+		g.router.notFoundGroups = append(g.router.notFoundGroups, g)
+	}
+	if h == nil {
+		groups := g.router.notFoundGroups[:0]
+		for _, ng := range g.router.notFoundGroups {
+			if ng != g {
+				groups = append(groups, ng)
+			}
+		}
+		g.router.notFoundGroups = groups
+	}
+	g.notFoundHandler = h
+	return g
+}
+
+// resolveErrorHandler returns the error handler of this group or its nearest ancestor.
+// Returns nil if no group in the chain has an error handler.
+func (g *RouteGroup) resolveErrorHandler() ErrorHandler {
+	for cur := g; cur != nil; cur = cur.parent {
+		if cur.errorHandler != nil {
+			return cur.errorHandler
+		}
+	}
+	return nil
+}
+
+// hasPathPrefix reports whether path is under this group's prefix
+// (matching on segment boundaries).
+func (g *RouteGroup) hasPathPrefix(path string) bool {
+	if !strings.HasPrefix(path, g.prefix) {
+		return false
+	}
+	rest := path[len(g.prefix):]
+	return rest == "" || rest[0] == '/' || strings.HasSuffix(g.prefix, "/")
 }
 
 // GET registers a GET route on the group.
@@ -178,6 +342,27 @@ func (g *RouteGroup) OPTIONS(path string, handler HandlerFunc) {
 //	api := router.Group("/api")
 //	api.Handle("GET", "/users", handler)  // Registers GET /api/users
 func (g *RouteGroup) Handle(method, path string, handler HandlerFunc) {
+	g.handle(method, path, handler, nil, nil)
+}
+
+// HandleWithOptions registers a group route with route metadata and
+// operational settings (see Router.HandleWithOptions). The route policies
+// run after the router and group middleware.
+//
+// Example:
+//
+//	api := router.Group("/api", auth)
+//	api.HandleWithOptions(http.MethodGet, "/me", me, &fursy.RouteOptions{
+//	    Summary:     "Current user",
+//	    RequireAuth: true,
+//	})
+func (g *RouteGroup) HandleWithOptions(method, path string, handler HandlerFunc, opts *RouteOptions) {
+	g.handle(method, path, handler, opts, nil)
+}
+
+// handle registers a group route with opts, whose metadata is completed
+// by update.
+func (g *RouteGroup) handle(method, path string, handler HandlerFunc, opts *RouteOptions, update func(route *RouteInfo)) {
 	// Combine group prefix with route path
 	fullPath := g.prefix + path
 
@@ -186,7 +371,7 @@ func (g *RouteGroup) Handle(method, path string, handler HandlerFunc) {
 
 	// Register route on parent router with group handlers
 	// The router will combine its own middleware with these handlers in ServeHTTP
-	g.router.handleWithGroupMiddleware(method, fullPath, groupHandlers)
+	g.router.handleWithGroupMiddleware(g, method, fullPath, groupHandlers, nil, opts, update)
 }
 
 // combineMiddleware combines group middleware and the handler.
@@ -5,8 +5,10 @@
 package fursy
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -176,6 +178,39 @@ func TestGroup_Routes(t *testing.T) {
 	})
 }
 
+// TestGroup_HandleWithOptions tests route options on group routes.
+func TestGroup_HandleWithOptions(t *testing.T) {
+	r := New()
+	api := r.Group("/api", func(c *Context) error {
+		if c.GetHeader("Authorization") != "" {
+			c.Set("User", "alice")
+		}
+		return c.Next()
+	})
+	api.HandleWithOptions(http.MethodGet, "/me", func(c *Context) error {
+		return c.Text("alice")
+	}, &RouteOptions{Summary: "Current user", RequireAuth: true})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/me", http.NoBody))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("without credentials: status = %d, want 401", w.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/me", http.NoBody)
+	req.Header.Set("Authorization", "Bearer token")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("with credentials: status = %d, want 200", w.Code)
+	}
+
+	routes := r.Routes()
+	if len(routes) != 1 || routes[0].Summary != "Current user" || !routes[0].RequireAuth {
+		t.Errorf("unexpected route metadata %+v", routes)
+	}
+}
+
 // TestGroup_NestedGroups tests nested group functionality.
 func TestGroup_NestedGroups(t *testing.T) {
 	t.Run("2-level nesting", func(t *testing.T) {
@@ -505,3 +540,206 @@ type testError struct {
 func (e *testError) Error() string {
 	return e.message
 }
+
+// TestGroup_SetErrorHandler tests per-group error handlers.
+func TestGroup_SetErrorHandler(t *testing.T) {
+	r := New()
+	r.SetErrorHandler(func(c *Context, _ error) {
+		_ = c.String(http.StatusInternalServerError, "router")
+	})
+
+	api := r.Group("/api")
+	api.SetErrorHandler(func(c *Context, err error) {
+		_ = c.Problem(InternalServerError(err.Error()))
+	})
+	v1 := api.Group("/v1")
+	v1.GET("/fail", func(_ *Context) error { return ErrInternalServerError })
+
+	web := r.Group("/web")
+	web.GET("/fail", func(_ *Context) error { return ErrInternalServerError })
+
+	tests := []struct {
+		path        string
+		contentType string
+		body        string
+	}{
+		{"/api/v1/fail", "application/problem+json; charset=utf-8", "internal server error"},
+		{"/web/fail", "text/plain; charset=utf-8", "router"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+
+			if w.Code != http.StatusInternalServerError {
+				t.Errorf("expected status 500, got %d", w.Code)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != tt.contentType {
+				t.Errorf("expected Content-Type %q, got %q", tt.contentType, ct)
+			}
+			if !strings.Contains(w.Body.String(), tt.body) {
+				t.Errorf("expected body to contain %q, got %q", tt.body, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestGroup_SetNotFoundHandler tests per-group NotFound handlers.
+func TestGroup_SetNotFoundHandler(t *testing.T) {
+	r := New()
+	r.SetNotFoundHandler(func(c *Context) error {
+		return c.String(http.StatusNotFound, "router 404")
+	})
+
+	api := r.Group("/api")
+	api.SetNotFoundHandler(func(c *Context) error {
+		return c.Problem(NotFound("unknown API endpoint"))
+	})
+	admin := api.Group("/admin")
+	admin.SetNotFoundHandler(func(c *Context) error {
+		return c.String(http.StatusNotFound, "admin 404")
+	})
+	api.GET("/users", func(c *Context) error { return c.String(http.StatusOK, "users") })
+
+	tests := []struct {
+		path string
+		body string
+	}{
+		{"/api/unknown", "unknown API endpoint"},
+		{"/api", "unknown API endpoint"},
+		{"/api/admin/x", "admin 404"},
+		{"/apikeys", "router 404"},
+		{"/other", "router 404"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+
+			if w.Code != http.StatusNotFound {
+				t.Errorf("expected status 404, got %d", w.Code)
+			}
+			if !strings.Contains(w.Body.String(), tt.body) {
+				t.Errorf("expected body to contain %q, got %q", tt.body, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestGroup_NotFoundHandlerError tests that NotFound handler errors use the group error handler.
+func TestGroup_NotFoundHandlerError(t *testing.T) {
+	r := New()
+	api := r.Group("/api")
+	api.SetErrorHandler(func(c *Context, _ error) {
+		_ = c.String(http.StatusTeapot, "api error")
+	})
+	api.SetNotFoundHandler(func(_ *Context) error { return ErrNotFound })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/missing", http.NoBody))
+
+	if w.Code != http.StatusTeapot || w.Body.String() != "api error" {
+		t.Errorf("expected group error handler response, got %d %q", w.Code, w.Body.String())
+	}
+}
+
+// TestGroup_Without tests dropping named middleware for a subtree.
+func TestGroup_Without(t *testing.T) {
+	r := New()
+
+	var ran []string
+	mark := func(name string) HandlerFunc {
+		return func(c *Context) error {
+			ran = append(ran, name)
+			return c.Next()
+		}
+	}
+
+	api := r.Group("/api", Named("auth", mark("auth")), Named("audit", mark("audit")))
+	api.SetErrorHandler(func(c *Context, _ error) {
+		_ = c.String(http.StatusTeapot, "api error")
+	})
+	api.GET("/orders", func(c *Context) error { return nil })
+
+	public := api.Without("auth")
+	public.GET("/status", func(c *Context) error { return errors.New("status failed") })
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		ran = nil
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+		return w
+	}
+
+	serve("/api/orders")
+	if strings.Join(ran, ",") != "auth,audit" {
+		t.Errorf("/api/orders ran %v, want [auth audit]", ran)
+	}
+
+	w := serve("/api/status")
+	if strings.Join(ran, ",") != "audit" {
+		t.Errorf("/api/status ran %v, want [audit]", ran)
+	}
+	if w.Code != http.StatusTeapot {
+		t.Errorf("expected inherited error handler, got status %d", w.Code)
+	}
+	if len(api.middleware) != 2 {
+		t.Errorf("expected original group to keep its middleware, got %d", len(api.middleware))
+	}
+
+	var routeMiddleware []string
+	for _, route := range r.Routes() {
+		if route.Path == "/api/status" {
+			routeMiddleware = route.Middleware
+		}
+	}
+	if strings.Join(routeMiddleware, ",") != "audit" {
+		t.Errorf("expected RouteInfo.Middleware [audit], got %v", routeMiddleware)
+	}
+}
+
+// TestGroup_Replace tests replacing named middleware for a subtree.
+func TestGroup_Replace(t *testing.T) {
+	r := New()
+
+	var ran []string
+	mark := func(name string) HandlerFunc {
+		return func(c *Context) error {
+			ran = append(ran, name)
+			return c.Next()
+		}
+	}
+
+	api := r.Group("/api", Named("limit", mark("limit")), mark("after"))
+	uploads := api.Replace("limit", Named("limit", mark("strict-limit")))
+	uploads.POST("/uploads", func(c *Context) error { return nil })
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/uploads", http.NoBody))
+
+	if strings.Join(ran, ",") != "strict-limit,after" {
+		t.Errorf("ran %v, want [strict-limit after]", ran)
+	}
+}
+
+// TestGroup_WithoutUnknownPanics tests that unknown middleware names panic.
+func TestGroup_WithoutUnknownPanics(t *testing.T) {
+	r := New()
+	api := r.Group("/api", Named("auth", func(c *Context) error { return c.Next() }))
+
+	for name, fn := range map[string]func(){
+		"Without":     func() { api.Without("missing") },
+		"Replace":     func() { api.Replace("missing", func(c *Context) error { return nil }) },
+		"Replace nil": func() { api.Replace("auth", nil) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
@@ -45,11 +45,20 @@ type Handler[Req, Res any] func(*Box[Req, Res]) error
 //  3. Calls the generic handler
 //  4. Returns any error from binding or handler execution
 //
+// Boxes are reused through a per-route pool, so, like Context, a Box must
+// not be retained after the handler returns.
+//
+// The success status of the route (0 = 200 OK) is the default status of
+// Box.Send and Box.NegotiateRes.
+//
 // This is used internally by Router.GET, Router.POST, etc. to support generic handlers.
-func adaptGenericHandler[Req, Res any](handler Handler[Req, Res]) HandlerFunc {
+func adaptGenericHandler[Req, Res any](handler Handler[Req, Res], status int) HandlerFunc {
+	pool := newBoxPool[Req, Res]()
 	return func(base *Context) error {
-		// Create generic context
-		ctx := newBox[Req, Res](base)
+		// Get generic context from the route pool
+		ctx := pool.get(base)
+		defer pool.put(ctx)
+		ctx.successStatus = status
 
 		// Bind request body
 		if err := ctx.Bind(); err != nil {
@@ -0,0 +1,111 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"bytes"
+	"html/template"
+)
+
+// mimeApplicationProblemJSON is the RFC 9457 problem details media type.
+const mimeApplicationProblemJSON = "application/problem+json"
+
+// SetHTMLTemplates sets the templates rendered by Context.HTML and error pages.
+//
+// Example:
+//
+//	router.SetHTMLTemplates(template.Must(template.ParseGlob("templates/*.html")))
+//
+//	router.GET("/", func(c *fursy.Context) error {
+//	    return c.HTML(200, "index.html", data)
+//	})
+func (r *Router) SetHTMLTemplates(t *template.Template) *Router {
+	r.htmlTemplates = t
+	return r
+}
+
+// HTMLTemplates returns the templates set with SetHTMLTemplates (nil if none),
+// e.g. to share them with other renderers such as email templates.
+func (r *Router) HTMLTemplates() *template.Template {
+	return r.htmlTemplates
+}
+
+// SetErrorPages maps status codes to HTML templates used for error responses.
+// Status 0 maps the fallback template for unmapped statuses.
+//
+// Error pages require SetHTMLTemplates. Once set, Context.Problem renders the
+// page of the problem status (with the Problem as template data) for clients
+// preferring text/html, such as browsers; other clients still receive
+// application/problem+json. The default error, NotFound and MethodNotAllowed
+// responses are sent as problems, so both audiences are served by a single
+// registration. Statuses without a page (or a missing template) fall back
+// to problem+json.
+//
+// Example:
+//
+//	router.SetHTMLTemplates(templates).SetErrorPages(map[int]string{
+//	    404: "404.html",
+//	    0:   "500.html",
+//	})
+//
+//	// 404.html:
+//	// <h1>{{.Title}}</h1><p>{{.Detail}}</p>
+func (r *Router) SetErrorPages(pages map[int]string) *Router {
+	r.errorPages = pages
+	return r
+}
+
+// HTML renders the named template set with Router.SetHTMLTemplates.
+//
+// The template is rendered before anything is written, so template errors
+// are returned without sending a partial response.
+//
+// Example:
+//
+//	return c.HTML(200, "user.html", user)
+func (c *Context) HTML(code int, name string, data any) error {
+	if c.router == nil || c.router.htmlTemplates == nil {
+		panic("fursy: HTML templates not configured - use Router.SetHTMLTemplates")
+	}
+	if c.shouldCancel() {
+		return ErrClientGone
+	}
+
+	var buf bytes.Buffer
+	if err := c.router.htmlTemplates.ExecuteTemplate(&buf, name, data); err != nil {
+		return err
+	}
+
+	c.Response.Header().Set("Content-Type", c.contentType(MIMETextHTML))
+	c.Response.WriteHeader(code)
+	_, err := c.Response.Write(buf.Bytes())
+	return err
+}
+
+// hasErrorPages reports whether error pages are configured.
+func (c *Context) hasErrorPages() bool {
+	return c.router != nil && c.router.htmlTemplates != nil && len(c.router.errorPages) > 0
+}
+
+// errorPage returns the error page template for the problem, if configured
+// and preferred by the client over problem+json.
+func (c *Context) errorPage(p Problem) (string, bool) {
+	if !c.hasErrorPages() {
+		return "", false
+	}
+	name, ok := c.router.errorPages[p.Status]
+	if !ok {
+		name, ok = c.router.errorPages[0]
+	}
+	if !ok || c.router.htmlTemplates.Lookup(name) == nil {
+		return "", false
+	}
+
+	c.AddVary("Accept")
+	if c.NegotiateFormat(mimeApplicationProblemJSON, MIMETextHTML) != MIMETextHTML {
+		return "", false
+	}
+	return name, true
+}
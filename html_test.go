@@ -0,0 +1,138 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const browserAccept = "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"
+
+func newErrorPagesRouter() *Router {
+	templates := template.Must(template.New("").Parse(`
+{{define "index.html"}}<p>Hello {{.}}</p>{{end}}
+{{define "404.html"}}<h1>{{.Title}}</h1><p>{{.Detail}}</p>{{end}}
+{{define "error.html"}}<h1>Error {{.Status}}</h1>{{end}}
+{{define "broken.html"}}{{template "missing"}}{{end}}`))
+
+	router := New()
+	router.SetHTMLTemplates(templates).SetErrorPages(map[int]string{
+		404: "404.html",
+		409: "broken.html",
+		0:   "error.html",
+	})
+	router.GET("/", func(c *Context) error {
+		return c.HTML(http.StatusOK, "index.html", "<world>")
+	})
+	router.GET("/users/:id", func(c *Context) error {
+		return c.Problem(NotFound("User " + c.Param("id") + " not found"))
+	})
+	router.GET("/fail", func(_ *Context) error {
+		return errors.New("database is down")
+	})
+	router.GET("/conflict", func(_ *Context) error {
+		return Conflict("Version mismatch")
+	})
+	return router
+}
+
+func TestContext_HTML(t *testing.T) {
+	router := newErrorPagesRouter()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	if w.Code != http.StatusOK || w.Body.String() != "<p>Hello &lt;world&gt;</p>" {
+		t.Errorf("got %d %q", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "text/html; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+}
+
+func TestContext_HTMLWithoutTemplates(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	c := &Context{router: New()}
+	_ = c.HTML(http.StatusOK, "index.html", nil)
+}
+
+func TestErrorPages(t *testing.T) {
+	router := newErrorPagesRouter()
+
+	tests := []struct {
+		name        string
+		path        string
+		method      string
+		accept      string
+		status      int
+		contentType string
+		body        string
+	}{
+		{"problem page", "/users/7", http.MethodGet, browserAccept, 404, "text/html", "<h1>Not Found</h1><p>User 7 not found</p>"},
+		{"problem json", "/users/7", http.MethodGet, "application/json", 404, "application/problem+json", `"detail":"User 7 not found"`},
+		{"no accept", "/users/7", http.MethodGet, "", 404, "application/problem+json", `"status":404`},
+		{"any accept", "/users/7", http.MethodGet, "*/*", 404, "application/problem+json", `"status":404`},
+		{"returned problem", "/conflict", http.MethodGet, "application/json", 409, "application/problem+json", "Version mismatch"},
+		{"broken template", "/conflict", http.MethodGet, browserAccept, 409, "application/problem+json", "Version mismatch"},
+		{"error fallback page", "/fail", http.MethodGet, browserAccept, 500, "text/html", "<h1>Error 500</h1>"},
+		{"error json hides cause", "/fail", http.MethodGet, "application/json", 500, "application/problem+json", `"title":"Internal Server Error"`},
+		{"not found page", "/missing", http.MethodGet, browserAccept, 404, "text/html", "<h1>Not Found</h1>"},
+		{"method not allowed page", "/", http.MethodPost, browserAccept, 405, "text/html", "<h1>Error 405</h1>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, tt.contentType) {
+				t.Errorf("Content-Type = %q, want %q", ct, tt.contentType)
+			}
+			if !strings.Contains(w.Body.String(), tt.body) {
+				t.Errorf("body = %q, want %q", w.Body.String(), tt.body)
+			}
+			if strings.Contains(w.Body.String(), "database is down") {
+				t.Error("error cause leaked to the client")
+			}
+			if w.Header().Get("Vary") != "Accept" {
+				t.Errorf("Vary = %q, want Accept", w.Header().Get("Vary"))
+			}
+		})
+	}
+}
+
+func TestErrorPages_NotConfigured(t *testing.T) {
+	router := New()
+	router.SetErrorPages(map[int]string{404: "404.html"}) // no templates
+	router.GET("/fail", func(_ *Context) error { return NotFound("x") })
+
+	req := httptest.NewRequest(http.MethodGet, "/missing", http.NoBody)
+	req.Header.Set("Accept", browserAccept)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound || w.Body.String() != "Not Found" {
+		t.Errorf("got %d %q, want plain 404", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fail", http.NoBody))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("got %d, want default 500", w.Code)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+)
+
+// WrapH adapts a standard http.Handler to a HandlerFunc, e.g. to mount
+// pprof, promhttp or legacy endpoints.
+//
+// Route parameters are available to h through http.Request.PathValue,
+// and router and group middleware run as for any other route.
+//
+// Example:
+//
+//	router.GET("/metrics", fursy.WrapH(promhttp.Handler()))
+//	router.Any("/debug/pprof/*path", fursy.WrapH(http.DefaultServeMux))
+//
+//	// Legacy handler reading the route parameter.
+//	router.GET("/users/:id", fursy.WrapF(func(w http.ResponseWriter, r *http.Request) {
+//	    fmt.Fprintf(w, "user %s", r.PathValue("id"))
+//	}))
+func WrapH(h http.Handler) HandlerFunc {
+	if h == nil {
+		panic("fursy: handler cannot be nil")
+	}
+
+	return func(c *Context) error {
+		for _, p := range c.params {
+			c.Request.SetPathValue(p.Key, p.Value)
+		}
+		h.ServeHTTP(c.Response, c.Request)
+		return nil
+	}
+}
+
+// WrapF adapts a standard http.HandlerFunc to a HandlerFunc (see WrapH).
+//
+// Example:
+//
+//	router.GET("/debug/pprof/profile", fursy.WrapF(pprof.Profile))
+func WrapF(f http.HandlerFunc) HandlerFunc {
+	if f == nil {
+		panic("fursy: handler cannot be nil")
+	}
+	return WrapH(f)
+}
+
+// WrapMiddleware adapts standard net/http middleware to fursy middleware.
+//
+// The rest of the chain runs inside mw with the request and response
+// writer mw passes on (e.g., a request with added context values or a
+// compressing writer). If mw does not call the next handler, the chain
+// stops there.
+//
+// mw must call the next handler synchronously: middleware that serves it
+// in another goroutine (such as http.TimeoutHandler) is not supported.
+//
+// Example:
+//
+//	router.Use(fursy.WrapMiddleware(gziphandler.GzipHandler))
+//	router.Use(fursy.WrapMiddleware(chimiddleware.RealIP))
+func WrapMiddleware(mw func(http.Handler) http.Handler) HandlerFunc {
+	if mw == nil {
+		panic("fursy: middleware cannot be nil")
+	}
+
+	return func(c *Context) error {
+		var err error
+		w, req := c.Response, c.Request
+
+		next := http.HandlerFunc(func(nw http.ResponseWriter, nreq *http.Request) {
+			c.Response, c.Request = nw, nreq
+			if nreq != req {
+				c.query = nil // The request (and its URL) may have changed.
+			}
+			err = c.Next()
+		})
+		mw(next).ServeHTTP(w, req)
+
+		// Restore the writer: a wrapping writer may not be usable after mw returns.
+		c.Response = w
+		return err
+	}
+}
+
+// ToHTTPHandler adapts fursy middleware to standard net/http middleware,
+// to reuse it outside of a Router (e.g., with http.ServeMux).
+//
+// The middleware runs without a router: route parameters, router settings
+// and error handlers are not available, and an error it returns is
+// answered with 500 Internal Server Error.
+//
+// Example:
+//
+//	mux := http.NewServeMux()
+//	mux.Handle("/legacy/", fursy.ToHTTPHandler(middleware.Secure())(legacyHandler))
+func ToHTTPHandler(mw HandlerFunc) func(http.Handler) http.Handler {
+	if mw == nil {
+		panic("fursy: middleware cannot be nil")
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			c := newContext()
+			c.init(w, req, nil, nil)
+			c.handlers = append(c.handlers, mw, func(c *Context) error {
+				next.ServeHTTP(c.Response, c.Request)
+				return nil
+			})
+			c.index = -1
+
+			if err := c.Next(); err != nil {
+				defaultErrorHandler(c, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestWrapH tests mounting standard handlers with route parameters and middleware.
+func TestWrapH(t *testing.T) {
+	r := New()
+	r.Use(func(c *Context) error {
+		c.SetHeader("X-Middleware", "ran")
+		return c.Next()
+	})
+	r.GET("/users/:id/files/*path", WrapF(func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(w, "%s %s", req.PathValue("id"), req.PathValue("path"))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42/files/a/b.txt", http.NoBody)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Body.String(); got != "42 a/b.txt" {
+		t.Errorf("body = %q, want %q", got, "42 a/b.txt")
+	}
+	if w.Header().Get("X-Middleware") != "ran" {
+		t.Error("expected router middleware to run")
+	}
+}
+
+// TestWrapH_NilPanics tests that nil handlers panic.
+func TestWrapH_NilPanics(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"WrapH":          func() { WrapH(nil) },
+		"WrapF":          func() { WrapF(nil) },
+		"WrapMiddleware": func() { WrapMiddleware(nil) },
+		"ToHTTPHandler":  func() { ToHTTPHandler(nil) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
+
+// upperWriter upper-cases the response body.
+type upperWriter struct {
+	http.ResponseWriter
+}
+
+func (w upperWriter) Write(b []byte) (int, error) {
+	return w.ResponseWriter.Write([]byte(strings.ToUpper(string(b))))
+}
+
+// TestWrapMiddleware tests running standard middleware in the chain.
+func TestWrapMiddleware(t *testing.T) {
+	type ctxKey struct{}
+
+	r := New()
+	r.Use(WrapMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			ctx := context.WithValue(req.Context(), ctxKey{}, "from std")
+			next.ServeHTTP(upperWriter{w}, req.WithContext(ctx))
+		})
+	}))
+	r.GET("/", func(c *Context) error {
+		value, _ := c.Request.Context().Value(ctxKey{}).(string)
+		return c.String(http.StatusOK, value)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Body.String(); got != "FROM STD" {
+		t.Errorf("body = %q, want %q", got, "FROM STD")
+	}
+}
+
+// TestWrapMiddleware_ShortCircuit tests standard middleware that stops the chain.
+func TestWrapMiddleware_ShortCircuit(t *testing.T) {
+	r := New()
+	r.Use(WrapMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			http.Error(w, "denied", http.StatusForbidden)
+		})
+	}))
+
+	called := false
+	r.GET("/", func(c *Context) error {
+		called = true
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden || called {
+		t.Errorf("expected 403 without calling the handler, got %d (called %v)", w.Code, called)
+	}
+}
+
+// TestWrapMiddleware_Error tests that chain errors reach the error handler.
+func TestWrapMiddleware_Error(t *testing.T) {
+	r := New()
+	var got error
+	r.SetErrorHandler(func(c *Context, err error) {
+		got = err
+		_ = c.String(http.StatusTeapot, "handled")
+	})
+	r.Use(WrapMiddleware(func(next http.Handler) http.Handler { return next }))
+
+	want := errors.New("boom")
+	r.GET("/", func(c *Context) error { return want })
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	if !errors.Is(got, want) {
+		t.Errorf("error = %v, want %v", got, want)
+	}
+}
+
+// TestToHTTPHandler tests using fursy middleware with net/http.
+func TestToHTTPHandler(t *testing.T) {
+	mw := ToHTTPHandler(func(c *Context) error {
+		if c.GetHeader("Authorization") == "" {
+			return c.String(http.StatusUnauthorized, "unauthorized")
+		}
+		c.SetHeader("X-Checked", "yes")
+		return c.Next()
+	})
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set("Authorization", "Bearer x")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Body.String() != "ok" || w.Header().Get("X-Checked") != "yes" {
+		t.Errorf("unexpected response %d %q", w.Code, w.Body.String())
+	}
+
+	failing := ToHTTPHandler(func(c *Context) error { return errors.New("boom") })(http.NotFoundHandler())
+	w = httptest.NewRecorder()
+	failing.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+}
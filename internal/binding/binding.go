@@ -99,11 +99,14 @@ func (multipartBinder) Bind(req *http.Request, obj any) error {
 		return fmt.Errorf("parse multipart form error: %w", err)
 	}
 
-	if req.MultipartForm == nil || len(req.MultipartForm.Value) == 0 {
+	if req.MultipartForm == nil || (len(req.MultipartForm.Value) == 0 && len(req.MultipartForm.File) == 0) {
 		return ErrEmptyRequestBody
 	}
 
-	return mapForm(obj, req.MultipartForm.Value)
+	if err := mapForm(obj, req.MultipartForm.Value); err != nil {
+		return err
+	}
+	return mapFiles(obj, req.MultipartForm.File)
 }
 
 // mapForm maps form values to struct fields.
@@ -128,6 +131,11 @@ func mapForm(ptr any, form map[string][]string) error {
 
 		structField := typ.Field(i)
 
+		// File fields are bound by mapFiles
+		if IsFileField(structField) {
+			continue
+		}
+
 		// Get form tag or use field name
 		formTag := structField.Tag.Get("form")
 		if formTag == "" {
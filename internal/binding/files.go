@@ -0,0 +1,200 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package binding
+
+import (
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Struct tags of file fields.
+const (
+	// TagFile marks a field bound from an uploaded file (e.g., `file:"true"`).
+	// Fields of type *multipart.FileHeader and []*multipart.FileHeader are
+	// file fields even without it.
+	TagFile = "file"
+
+	// TagMaxSize limits the size of each uploaded file (e.g., `maxsize:"5MB"`).
+	TagMaxSize = "maxsize"
+
+	// TagAccept lists the accepted media types of uploaded files
+	// (e.g., `accept:"image/png,image/jpeg"` or `accept:"image/*"`).
+	TagAccept = "accept"
+)
+
+var (
+	fileHeaderType      = reflect.TypeFor[*multipart.FileHeader]()
+	fileHeaderSliceType = reflect.TypeFor[[]*multipart.FileHeader]()
+)
+
+// FileError is returned when an uploaded file fails a maxsize or accept check.
+type FileError struct {
+	// Field is the form name of the file.
+	Field string
+
+	// Tag is the failed check: "maxsize" or "accept".
+	Tag string
+
+	// Message is a human-readable error message.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *FileError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// IsFileField reports whether field is bound from an uploaded file.
+func IsFileField(field reflect.StructField) bool {
+	return field.Type == fileHeaderType || field.Type == fileHeaderSliceType || field.Tag.Get(TagFile) == "true"
+}
+
+// HasFileFields reports whether the struct type t (or the struct it points
+// to) has file fields.
+func HasFileFields(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if field := t.Field(i); field.IsExported() && IsFileField(field) {
+			return true
+		}
+	}
+	return false
+}
+
+// FormName returns the form name of field: its form tag or its name.
+func FormName(field reflect.StructField) string {
+	if name := field.Tag.Get("form"); name != "" {
+		return name
+	}
+	return field.Name
+}
+
+// mapFiles maps uploaded files to the file fields of the struct ptr points to
+// and checks their maxsize and accept tags.
+func mapFiles(ptr any, files map[string][]*multipart.FileHeader) error {
+	val := reflect.ValueOf(ptr).Elem()
+	typ := val.Type()
+
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Field(i)
+		structField := typ.Field(i)
+		if !field.CanSet() || !IsFileField(structField) {
+			continue
+		}
+
+		name := FormName(structField)
+		if name == "-" {
+			continue
+		}
+
+		if structField.Type != fileHeaderType && structField.Type != fileHeaderSliceType {
+			return fmt.Errorf("file field %s must be *multipart.FileHeader or []*multipart.FileHeader", structField.Name)
+		}
+
+		headers := files[name]
+		if len(headers) == 0 {
+			continue
+		}
+
+		for _, fh := range headers {
+			if err := checkFile(name, structField.Tag, fh); err != nil {
+				return err
+			}
+		}
+
+		if structField.Type == fileHeaderType {
+			field.Set(reflect.ValueOf(headers[0]))
+		} else {
+			field.Set(reflect.ValueOf(headers))
+		}
+	}
+
+	return nil
+}
+
+// checkFile checks fh against the maxsize and accept tags.
+func checkFile(name string, tag reflect.StructTag, fh *multipart.FileHeader) error {
+	if maxSize := tag.Get(TagMaxSize); maxSize != "" {
+		limit, err := parseSize(maxSize)
+		if err != nil {
+			return fmt.Errorf("file field %s: invalid maxsize %q: %w", name, maxSize, err)
+		}
+		if fh.Size > limit {
+			return &FileError{
+				Field:   name,
+				Tag:     TagMaxSize,
+				Message: fmt.Sprintf("file %q exceeds the maximum size of %s", fh.Filename, maxSize),
+			}
+		}
+	}
+
+	if accept := tag.Get(TagAccept); accept != "" {
+		mediaType, _, _ := mime.ParseMediaType(fh.Header.Get("Content-Type"))
+		if !acceptsMediaType(accept, mediaType) {
+			return &FileError{
+				Field:   name,
+				Tag:     TagAccept,
+				Message: fmt.Sprintf("file %q has unsupported media type %q (accepted: %s)", fh.Filename, mediaType, accept),
+			}
+		}
+	}
+
+	return nil
+}
+
+// acceptsMediaType reports whether mediaType matches one of the
+// comma-separated patterns of accept ("type/subtype" or "type/*").
+func acceptsMediaType(accept, mediaType string) bool {
+	if mediaType == "" {
+		return false
+	}
+	for _, pattern := range strings.Split(accept, ",") {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == mediaType || pattern == "*/*" {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSize parses a size in bytes with an optional KB, MB or GB suffix
+// (powers of 1024), e.g. "512KB" or "5MB".
+func parseSize(s string) (int64, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+		{"B", 1},
+	} {
+		if rest, ok := strings.CutSuffix(s, unit.suffix); ok {
+			s, multiplier = strings.TrimSpace(rest), unit.size
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return n * multiplier, nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package binding
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// UploadTestStruct has file fields.
+type UploadTestStruct struct {
+	Title       string                  `form:"title"`
+	Avatar      *multipart.FileHeader   `form:"avatar" file:"true" maxsize:"1KB" accept:"image/*"`
+	Attachments []*multipart.FileHeader `form:"attachments"`
+}
+
+// newUploadRequest builds a multipart request with the given files
+// (form name, filename, content type, size).
+func newUploadRequest(t *testing.T, files ...[4]string) *http.Request {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	writer.WriteField("title", "Profile")
+	for _, f := range files {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", `form-data; name="`+f[0]+`"; filename="`+f[1]+`"`)
+		header.Set("Content-Type", f[2])
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write([]byte(strings.Repeat("x", len(f[3]))))
+	}
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", &buf)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+// TestMultipartBinder_Files tests binding uploaded files.
+func TestMultipartBinder_Files(t *testing.T) {
+	req := newUploadRequest(t,
+		[4]string{"avatar", "me.png", "image/png", "small"},
+		[4]string{"attachments", "a.txt", "text/plain", "a"},
+		[4]string{"attachments", "b.txt", "text/plain", "b"},
+	)
+
+	var result UploadTestStruct
+	if err := multipartBinding.Bind(req, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Title != "Profile" {
+		t.Errorf("Title = %q, want %q", result.Title, "Profile")
+	}
+	if result.Avatar == nil || result.Avatar.Filename != "me.png" {
+		t.Errorf("Avatar = %+v, want me.png", result.Avatar)
+	}
+	if len(result.Attachments) != 2 || result.Attachments[1].Filename != "b.txt" {
+		t.Errorf("Attachments = %+v, want a.txt and b.txt", result.Attachments)
+	}
+}
+
+// TestMultipartBinder_FileChecks tests the maxsize and accept tags.
+func TestMultipartBinder_FileChecks(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    [4]string
+		wantTag string
+	}{
+		{"too large", [4]string{"avatar", "big.png", "image/png", strings.Repeat("x", 2048)}, TagMaxSize},
+		{"wrong type", [4]string{"avatar", "doc.pdf", "application/pdf", "x"}, TagAccept},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result UploadTestStruct
+			err := multipartBinding.Bind(newUploadRequest(t, tt.file), &result)
+
+			var fileErr *FileError
+			if !errors.As(err, &fileErr) {
+				t.Fatalf("expected FileError, got %v", err)
+			}
+			if fileErr.Field != "avatar" || fileErr.Tag != tt.wantTag {
+				t.Errorf("got %+v, want field avatar and tag %s", fileErr, tt.wantTag)
+			}
+		})
+	}
+}
+
+// TestMultipartBinder_OnlyFiles tests a multipart body without text fields.
+func TestMultipartBinder_OnlyFiles(t *testing.T) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, _ := writer.CreateFormFile("attachments", "a.txt")
+	part.Write([]byte("a"))
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", &buf)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	var result UploadTestStruct
+	if err := multipartBinding.Bind(req, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Attachments) != 1 {
+		t.Errorf("Attachments = %+v, want 1 file", result.Attachments)
+	}
+}
+
+// TestHasFileFields tests detecting file fields.
+func TestHasFileFields(t *testing.T) {
+	if !HasFileFields(reflect.TypeFor[UploadTestStruct]()) {
+		t.Error("expected file fields")
+	}
+	if HasFileFields(reflect.TypeFor[BindTestStruct]()) {
+		t.Error("expected no file fields")
+	}
+}
+
+// TestParseSize tests size parsing.
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"100", 100},
+		{"100B", 100},
+		{"512KB", 512 << 10},
+		{"5mb", 5 << 20},
+		{"1 GB", 1 << 30},
+	}
+	for _, tt := range tests {
+		got, err := parseSize(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("parseSize(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+		}
+	}
+
+	if _, err := parseSize("lots"); err == nil {
+		t.Error("expected error for invalid size")
+	}
+}
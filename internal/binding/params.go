@@ -0,0 +1,265 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"net/textproto"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Struct tags of fields bound from the URL rather than the body.
+const (
+	// TagPath binds a field from a path parameter (e.g., `path:"id"`).
+	TagPath = "path"
+
+	// TagQuery binds a field from a query parameter (e.g., `query:"page"`).
+	TagQuery = "query"
+
+	// TagHeader binds a field from a request header (e.g., `header:"X-Api-Version"`).
+	// Header names are canonicalized, so the tag is case-insensitive.
+	TagHeader = "header"
+)
+
+// Tag option of slice fields bound from comma-separated lists
+// (e.g., `query:"ids,comma"` binds ids=1,2,3).
+const OptionComma = "comma"
+
+// FieldTag returns the parameter name of field for tag and whether the
+// field is bound from comma-separated lists. The name is empty if field
+// has no tag or is tagged "-".
+func FieldTag(field reflect.StructField, tag string) (name string, comma bool) {
+	name, opts, _ := strings.Cut(field.Tag.Get(tag), ",")
+	if name == "-" {
+		return "", false
+	}
+	for opt := range strings.SplitSeq(opts, ",") {
+		if opt == OptionComma {
+			comma = true
+		}
+	}
+	return name, comma
+}
+
+// MapTagged maps values to the fields of the struct ptr points to whose
+// tag names a key of values. Fields without the tag are left untouched.
+//
+// The binding conventions are:
+//   - slice fields receive all values of the key (tag=a&tag=b), including
+//     those of the bracket form "tag[]"; with the comma option, each value
+//     is also split on commas (tag=a,b);
+//   - map fields receive the keys in bracket syntax (filter[status]=active
+//     binds map key "status");
+//   - struct fields bind their own tagged fields in bracket syntax
+//     (filter[status]=active binds the field tagged "status"), at any depth;
+//   - other fields receive the first value.
+func MapTagged(ptr any, tag string, values map[string][]string) error {
+	val := reflect.ValueOf(ptr)
+	if val.Kind() != reflect.Ptr {
+		return errors.New("binding element must be a pointer")
+	}
+
+	val = val.Elem()
+	if val.Kind() != reflect.Struct {
+		return errors.New("binding element must be a struct")
+	}
+
+	return mapTaggedStruct(val, tag, "", values)
+}
+
+// mapTaggedStruct maps values to the tagged fields of the struct val.
+// Field keys are nested in prefix with bracket syntax (prefix[name]).
+func mapTaggedStruct(val reflect.Value, tag, prefix string, values map[string][]string) error {
+	typ := val.Type()
+
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+
+		name, comma := FieldTag(typ.Field(i), tag)
+		if name == "" {
+			continue
+		}
+		key := name
+		if tag == TagHeader {
+			key = textproto.CanonicalMIMEHeaderKey(name)
+		}
+		if prefix != "" {
+			key = prefix + "[" + name + "]"
+		}
+
+		if err := mapTaggedField(field, tag, key, comma, values); err != nil {
+			return fmt.Errorf("%s parameter %q: %w", tag, key, err)
+		}
+	}
+
+	return nil
+}
+
+// mapTaggedField maps the values of key to field.
+func mapTaggedField(field reflect.Value, tag, key string, comma bool, values map[string][]string) error {
+	typ := field.Type()
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch {
+	case typ.Kind() == reflect.Struct && hasNested(values, key):
+		target := field
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				field.Set(reflect.New(typ))
+			}
+			target = field.Elem()
+		}
+		return mapTaggedStruct(target, tag, key, values)
+
+	case typ.Kind() == reflect.Map:
+		return mapTaggedMap(field, key, comma, values)
+	}
+
+	vals := slices.Concat(values[key], values[key+"[]"])
+	if comma {
+		vals = splitComma(vals)
+	}
+	if len(vals) == 0 {
+		return nil
+	}
+	return setTaggedField(field, vals)
+}
+
+// mapTaggedMap sets the map field from the keys "key[name]" of values.
+func mapTaggedMap(field reflect.Value, key string, comma bool, values map[string][]string) error {
+	typ := field.Type()
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	var m reflect.Value
+	for k, vals := range values {
+		name, ok := bracketKey(k, key)
+		if !ok || len(vals) == 0 {
+			continue
+		}
+		if comma {
+			vals = splitComma(vals)
+		}
+
+		mapKey := reflect.New(typ.Key()).Elem()
+		if err := setField(mapKey, name); err != nil {
+			return fmt.Errorf("key %q: %w", name, err)
+		}
+		elem := reflect.New(typ.Elem()).Elem()
+		if err := setTaggedField(elem, vals); err != nil {
+			return fmt.Errorf("key %q: %w", name, err)
+		}
+
+		if !m.IsValid() {
+			m = reflect.MakeMap(typ)
+		}
+		m.SetMapIndex(mapKey, elem)
+	}
+
+	if !m.IsValid() {
+		return nil
+	}
+	if field.Kind() == reflect.Ptr {
+		ptr := reflect.New(typ)
+		ptr.Elem().Set(m)
+		m = ptr
+	}
+	field.Set(m)
+	return nil
+}
+
+// bracketKey returns name if k is "prefix[name]" or "prefix[name][]".
+func bracketKey(k, prefix string) (string, bool) {
+	rest, ok := strings.CutPrefix(k, prefix+"[")
+	if !ok {
+		return "", false
+	}
+	rest = strings.TrimSuffix(rest, "[]")
+	name, ok := strings.CutSuffix(rest, "]")
+	if !ok || name == "" || strings.ContainsAny(name, "[]") {
+		return "", false
+	}
+	return name, true
+}
+
+// hasNested reports whether values has a key in bracket syntax under prefix.
+func hasNested(values map[string][]string, prefix string) bool {
+	for k := range values {
+		if strings.HasPrefix(k, prefix+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// splitComma splits each value on commas, dropping empty elements.
+func splitComma(values []string) []string {
+	var split []string
+	for _, v := range values {
+		for part := range strings.SplitSeq(v, ",") {
+			// Header lists separate elements with optional whitespace
+			if part = strings.TrimSpace(part); part != "" {
+				split = append(split, part)
+			}
+		}
+	}
+	return split
+}
+
+// setTaggedField sets field from values, filling slices element by element.
+func setTaggedField(field reflect.Value, values []string) error {
+	if field.Kind() == reflect.Ptr {
+		elem := reflect.New(field.Type().Elem())
+		if err := setTaggedField(elem.Elem(), values); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+
+	if field.Kind() != reflect.Slice {
+		return setField(field, values[0])
+	}
+
+	slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+	for i, v := range values {
+		if err := setField(slice.Index(i), v); err != nil {
+			return err
+		}
+	}
+	field.Set(slice)
+	return nil
+}
+
+// HasTagged reports whether the struct type t (or the struct it points to)
+// has an exported field with tag.
+func HasTagged(t reflect.Type, tag string) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if name, _ := FieldTag(field, tag); name != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package binding
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ParamsTestStruct has path and query tagged fields.
+type ParamsTestStruct struct {
+	ID     uint64   `path:"id"`
+	Page   *int     `query:"page"`
+	Tags   []string `query:"tag"`
+	Name   string   `json:"name"`
+	Hidden string   `query:"-"`
+}
+
+// TestMapTagged tests binding tagged fields.
+func TestMapTagged(t *testing.T) {
+	var result ParamsTestStruct
+	result.Name = "kept"
+
+	if err := MapTagged(&result, TagPath, map[string][]string{"id": {"42"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	query := map[string][]string{
+		"page":   {"3"},
+		"tag":    {"a", "b"},
+		"Hidden": {"x"},
+		"-":      {"x"},
+	}
+	if err := MapTagged(&result, TagQuery, query); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.ID != 42 {
+		t.Errorf("ID = %d, want %d", result.ID, 42)
+	}
+	if result.Page == nil || *result.Page != 3 {
+		t.Errorf("Page = %v, want 3", result.Page)
+	}
+	if !reflect.DeepEqual(result.Tags, []string{"a", "b"}) {
+		t.Errorf("Tags = %v, want [a b]", result.Tags)
+	}
+	if result.Name != "kept" {
+		t.Errorf("Name = %q, want %q", result.Name, "kept")
+	}
+	if result.Hidden != "" {
+		t.Errorf("Hidden = %q, want empty", result.Hidden)
+	}
+}
+
+// QueryConventionsStruct has query fields using each binding convention.
+type QueryConventionsStruct struct {
+	Tags   []string          `query:"tag"`
+	IDs    []int             `query:"ids,comma"`
+	Filter map[string]string `query:"filter"`
+	Ranges map[string][]int  `query:"range,comma"`
+	Page   *struct {
+		Size   int `query:"size"`
+		Cursor struct {
+			After string `query:"after"`
+		} `query:"cursor"`
+	} `query:"page"`
+}
+
+// TestMapTagged_Conventions tests repeated, comma-separated and bracket syntax parameters.
+func TestMapTagged_Conventions(t *testing.T) {
+	query := map[string][]string{
+		"tag":                 {"a"},
+		"tag[]":               {"b"},
+		"ids":                 {"1,2", "3"},
+		"filter[status]":      {"active"},
+		"filter[role]":        {"admin"},
+		"filter[a][b]":        {"ignored"},
+		"range[age]":          {"18,65"},
+		"page[size]":          {"20"},
+		"page[cursor][after]": {"xyz"},
+	}
+	var result QueryConventionsStruct
+	if err := MapTagged(&result, TagQuery, query); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(result.Tags, []string{"a", "b"}) {
+		t.Errorf("Tags = %v, want [a b]", result.Tags)
+	}
+	if !reflect.DeepEqual(result.IDs, []int{1, 2, 3}) {
+		t.Errorf("IDs = %v, want [1 2 3]", result.IDs)
+	}
+	if !reflect.DeepEqual(result.Filter, map[string]string{"status": "active", "role": "admin"}) {
+		t.Errorf("Filter = %v", result.Filter)
+	}
+	if !reflect.DeepEqual(result.Ranges, map[string][]int{"age": {18, 65}}) {
+		t.Errorf("Ranges = %v", result.Ranges)
+	}
+	if result.Page == nil || result.Page.Size != 20 || result.Page.Cursor.After != "xyz" {
+		t.Errorf("Page = %+v", result.Page)
+	}
+
+	// Absent nested parameters leave pointers nil.
+	result = QueryConventionsStruct{}
+	if err := MapTagged(&result, TagQuery, map[string][]string{"tag": {"a"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Page != nil || result.Filter != nil {
+		t.Errorf("expected nil Page and Filter, got %+v", result)
+	}
+
+	// Errors name the bracket key.
+	err := MapTagged(&result, TagQuery, map[string][]string{"page[size]": {"big"}})
+	if err == nil || !strings.Contains(err.Error(), `query parameter "page[size]"`) {
+		t.Errorf("expected page[size] error, got %v", err)
+	}
+}
+
+// TestMapTagged_InvalidValue tests that conversion errors name the parameter.
+func TestMapTagged_InvalidValue(t *testing.T) {
+	var result ParamsTestStruct
+	err := MapTagged(&result, TagPath, map[string][]string{"id": {"abc"}})
+	if err == nil || !strings.Contains(err.Error(), `path parameter "id"`) {
+		t.Errorf("expected path parameter error, got %v", err)
+	}
+}
+
+// TestMapTagged_NotStructPointer tests binding into invalid targets.
+func TestMapTagged_NotStructPointer(t *testing.T) {
+	var s string
+	if err := MapTagged(s, TagPath, nil); err == nil {
+		t.Error("expected error for non-pointer")
+	}
+	if err := MapTagged(&s, TagPath, nil); err == nil {
+		t.Error("expected error for non-struct pointer")
+	}
+}
+
+// TestHasTagged tests detecting tagged fields.
+func TestHasTagged(t *testing.T) {
+	typ := reflect.TypeFor[ParamsTestStruct]()
+	if !HasTagged(typ, TagPath) || !HasTagged(typ, TagQuery) {
+		t.Error("expected path and query tagged fields")
+	}
+	if HasTagged(reflect.TypeFor[BindTestStruct](), TagPath) {
+		t.Error("expected no path tagged fields")
+	}
+	if !HasTagged(reflect.PointerTo(typ), TagPath) {
+		t.Error("expected pointer types to be inspected")
+	}
+	if HasTagged(reflect.TypeFor[string](), TagPath) {
+		t.Error("expected false for non-struct type")
+	}
+}
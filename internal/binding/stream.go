@@ -0,0 +1,71 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package binding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrNotJSONArray is returned when a streamed request body is not a JSON array.
+var ErrNotJSONArray = errors.New("request body is not a JSON array")
+
+// StreamJSONArray decodes a JSON array request body item by item.
+//
+// Each element is decoded into a fresh *T and passed to fn together with its
+// index. Only one element is held in memory at a time, so arbitrarily large
+// arrays can be processed with constant memory.
+//
+// Decoding stops at the first error returned by fn.
+func StreamJSONArray[T any](req *http.Request, fn func(index int, item *T) error) error {
+	contentType := req.Header.Get("Content-Type")
+	if idx := strings.Index(contentType, ";"); idx != -1 {
+		contentType = contentType[:idx]
+	}
+	contentType = strings.TrimSpace(contentType)
+	if contentType != "" && contentType != "application/json" {
+		return fmt.Errorf("%w: %s", ErrUnsupportedMediaType, contentType)
+	}
+
+	if req.Body == nil || req.Body == http.NoBody || req.ContentLength == 0 {
+		return ErrEmptyRequestBody
+	}
+
+	decoder := json.NewDecoder(req.Body)
+
+	// Opening bracket.
+	tok, err := decoder.Token()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return ErrEmptyRequestBody
+		}
+		return fmt.Errorf("json decode error: %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return ErrNotJSONArray
+	}
+
+	// Array elements.
+	for i := 0; decoder.More(); i++ {
+		item := new(T)
+		if err := decoder.Decode(item); err != nil {
+			return fmt.Errorf("json decode error at index %d: %w", i, err)
+		}
+		if err := fn(i, item); err != nil {
+			return err
+		}
+	}
+
+	// Closing bracket.
+	if _, err := decoder.Token(); err != nil {
+		return fmt.Errorf("json decode error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package negotiate
+
+import "strings"
+
+// weightedToken represents a single element of a token-based Accept-* header.
+//
+// RFC 9110 Sections 12.5.2-12.5.4:
+//
+//	Accept-Charset  = #( ( token / "*" ) [ weight ] )
+//	Accept-Encoding = #( ( codings / "*" ) [ weight ] )
+//	Accept-Language = #( language-range [ weight ] )
+type weightedToken struct {
+	Value   string  // Lowercased token, e.g., "gzip", "en-us", "*"
+	Quality float64 // q-value (0.0 to 1.0), default 1.0
+}
+
+// parseWeighted parses a token-based Accept-* header value.
+//
+// Example:
+//
+//	tokens := parseWeighted("gzip, br;q=0.9, *;q=0")
+//	// Returns: [gzip (q=1.0), br (q=0.9), * (q=0)]
+func parseWeighted(header string) []weightedToken {
+	parts := strings.Split(header, ",")
+	tokens := make([]weightedToken, 0, len(parts))
+
+	for _, part := range parts {
+		value, params, _ := strings.Cut(part, ";")
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			continue
+		}
+
+		wt := weightedToken{Value: value, Quality: 1.0}
+		for _, param := range strings.Split(params, ";") {
+			key, v, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && strings.TrimSpace(key) == "q" {
+				wt.Quality = parseQuality(strings.TrimSpace(v))
+			}
+		}
+		tokens = append(tokens, wt)
+	}
+
+	return tokens
+}
+
+// selectOffer returns the offer with the highest quality (ties: first offered).
+//
+// match returns the specificity of a token for an offer (0 = no match).
+// For each offer, the quality of the most specific matching token is used,
+// so "gzip;q=0, *" excludes gzip but allows everything else.
+// defaultQuality is used for offers that no token matches.
+func selectOffer(tokens []weightedToken, offered []string, match func(token, offer string) int, defaultQuality func(offer string) float64) string {
+	best, bestQuality := "", 0.0
+
+	for _, offer := range offered {
+		lower := strings.ToLower(offer)
+
+		quality, specificity := 0.0, 0
+		for _, t := range tokens {
+			if s := match(t.Value, lower); s > specificity {
+				quality, specificity = t.Quality, s
+			}
+		}
+		if specificity == 0 && defaultQuality != nil {
+			quality = defaultQuality(lower)
+		}
+
+		if quality > bestQuality {
+			best, bestQuality = offer, quality
+		}
+	}
+
+	return best
+}
+
+// matchExact matches tokens exactly or via the "*" wildcard.
+func matchExact(token, offer string) int {
+	switch token {
+	case offer:
+		return 2
+	case "*":
+		return 1
+	}
+	return 0
+}
+
+// Language selects the best language tag from offered tags
+// based on the Accept-Language header (RFC 9110 Section 12.5.4).
+//
+// Matching uses RFC 4647 basic filtering: a language range matches a tag
+// if it equals the tag or is a prefix followed by "-" ("en" matches "en-US").
+// More specific ranges take precedence over less specific ones.
+//
+// Returns the first offered tag if the header is empty,
+// or an empty string if no offered tag is acceptable.
+//
+// Example:
+//
+//	Language("de-CH, de;q=0.9, en;q=0.8", []string{"en", "de"})
+//	// Returns: "de"
+func Language(acceptLanguage string, offered []string) string {
+	if len(offered) == 0 {
+		return ""
+	}
+	if strings.TrimSpace(acceptLanguage) == "" {
+		return offered[0]
+	}
+
+	return selectOffer(parseWeighted(acceptLanguage), offered, func(token, offer string) int {
+		switch {
+		case token == "*":
+			return 1
+		case token == offer:
+			return len(token) + 1
+		case strings.HasPrefix(offer, token+"-"):
+			return len(token)
+		}
+		return 0
+	}, nil)
+}
+
+// Charset selects the best charset from offered charsets
+// based on the Accept-Charset header (RFC 9110 Section 12.5.2).
+//
+// Returns the first offered charset if the header is empty,
+// or an empty string if no offered charset is acceptable.
+//
+// Example:
+//
+//	Charset("iso-8859-5, utf-8;q=0.8", []string{"utf-8", "iso-8859-5"})
+//	// Returns: "iso-8859-5"
+func Charset(acceptCharset string, offered []string) string {
+	if len(offered) == 0 {
+		return ""
+	}
+	if strings.TrimSpace(acceptCharset) == "" {
+		return offered[0]
+	}
+
+	return selectOffer(parseWeighted(acceptCharset), offered, matchExact, nil)
+}
+
+// Encoding selects the best content coding from offered codings
+// based on the Accept-Encoding header (RFC 9110 Section 12.5.3).
+//
+// The "identity" coding is acceptable unless explicitly excluded
+// ("identity;q=0" or "*;q=0"), but any explicitly accepted coding is preferred.
+//
+// Returns the first offered coding if the header is empty,
+// or an empty string if no offered coding is acceptable.
+//
+// Example:
+//
+//	Encoding("gzip;q=0.8, br", []string{"gzip", "br", "identity"})
+//	// Returns: "br"
+func Encoding(acceptEncoding string, offered []string) string {
+	if len(offered) == 0 {
+		return ""
+	}
+	if strings.TrimSpace(acceptEncoding) == "" {
+		return offered[0]
+	}
+
+	return selectOffer(parseWeighted(acceptEncoding), offered, matchExact, func(offer string) float64 {
+		if offer == "identity" {
+			return 0.001 // Lowest non-zero q-value.
+		}
+		return 0
+	})
+}
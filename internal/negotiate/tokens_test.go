@@ -0,0 +1,82 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package negotiate
+
+import "testing"
+
+func TestLanguage(t *testing.T) {
+	tests := []struct {
+		name    string
+		accept  string
+		offered []string
+		want    string
+	}{
+		{"empty header", "", []string{"en", "de"}, "en"},
+		{"exact", "de", []string{"en", "de"}, "de"},
+		{"prefix range", "de", []string{"en-US", "de-DE"}, "de-DE"},
+		{"q weighting", "de-CH, de;q=0.9, en;q=0.8", []string{"en", "de"}, "de"},
+		{"case insensitive", "EN-us", []string{"de", "en-US"}, "en-US"},
+		{"wildcard", "fr, *;q=0.5", []string{"en", "de"}, "en"},
+		{"excluded", "*, en;q=0", []string{"en", "de"}, "de"},
+		{"no match", "fr", []string{"en", "de"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Language(tt.accept, tt.offered); got != tt.want {
+				t.Errorf("Language(%q) = %q, want %q", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCharset(t *testing.T) {
+	tests := []struct {
+		name    string
+		accept  string
+		offered []string
+		want    string
+	}{
+		{"empty header", "", []string{"utf-8"}, "utf-8"},
+		{"q weighting", "iso-8859-5, utf-8;q=0.8", []string{"utf-8", "iso-8859-5"}, "iso-8859-5"},
+		{"case insensitive", "UTF-8", []string{"iso-8859-1", "utf-8"}, "utf-8"},
+		{"wildcard", "*", []string{"utf-8"}, "utf-8"},
+		{"no match", "iso-8859-5", []string{"utf-8"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Charset(tt.accept, tt.offered); got != tt.want {
+				t.Errorf("Charset(%q) = %q, want %q", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	offered := []string{"gzip", "br", "identity"}
+
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{"empty header", "", "gzip"},
+		{"q weighting", "gzip;q=0.8, br", "br"},
+		{"equal q uses server preference", "br, gzip", "gzip"},
+		{"identity implicit", "deflate", "identity"},
+		{"identity excluded", "deflate, identity;q=0", ""},
+		{"wildcard excluded", "*;q=0", ""},
+		{"wildcard with exclusion", "*, gzip;q=0", "br"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Encoding(tt.accept, offered); got != tt.want {
+				t.Errorf("Encoding(%q) = %q, want %q", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
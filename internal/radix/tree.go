@@ -30,6 +30,9 @@ func New() *Tree {
 
 // Insert adds a new route to the tree with the given handler.
 // Returns an error if the path is invalid or conflicts with existing routes.
+//
+// Trailing optional parameters (e.g., /users/:id/:action?) register the
+// route once per optional segment: /users/:id and /users/:id/:action.
 func (t *Tree) Insert(path string, handler interface{}) error {
 	if path == "" {
 		return fmt.Errorf("path cannot be empty")
@@ -45,7 +48,37 @@ func (t *Tree) Insert(path string, handler interface{}) error {
 	}
 
 	fullPath := path
-	return t.insertNode(path, handler, t.root, fullPath)
+	for _, p := range ExpandOptional(path) {
+		if err := t.insertNode(p, handler, t.root, fullPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExpandOptional returns the paths registered by a path with trailing
+// optional parameters, shortest first.
+func ExpandOptional(path string) []string {
+	if !strings.HasSuffix(path, "?") {
+		return []string{path}
+	}
+
+	segments := strings.Split(path, "/")
+	first := len(segments)
+	for first > 0 && strings.HasSuffix(segments[first-1], "?") {
+		first--
+		segments[first] = strings.TrimSuffix(segments[first], "?")
+	}
+
+	paths := make([]string, 0, len(segments)-first+1)
+	for i := first; i <= len(segments); i++ {
+		p := strings.Join(segments[:i], "/")
+		if p == "" {
+			p = "/"
+		}
+		paths = append(paths, p)
+	}
+	return paths
 }
 
 // insertNode is the recursive implementation of Insert.
@@ -149,67 +182,71 @@ func (t *Tree) insertNode(path string, handler interface{}, n *node, fullPath st
 
 	// Consume matched prefix from path
 	if i < len(path) {
-		path = path[i:]
-
-		// Check for wildcard at current position
-		if path[0] == ':' || path[0] == '*' {
-			return t.insertWildcard(path, handler, n, fullPath)
-		}
+		return t.insertChild(path[i:], handler, n, fullPath)
+	}
 
-		// Check if path contains wildcard later - need to split
-		wildcardIdx := findWildcardIndex(path)
-		if wildcardIdx > 0 {
-			// Split: static prefix + wildcard part
-			staticPart := path[:wildcardIdx]
-			wildcardPart := path[wildcardIdx:]
+	// Path consumed, this node is the endpoint
+	if n.handler != nil {
+		return fmt.Errorf("route already exists: %s", fullPath)
+	}
 
-			// Try to find existing child for static part
-			c := staticPart[0]
-			child := n.findChild(c)
+	n.handler = handler
+	n.fullPath = fullPath
+	return nil
+}
 
-			if child != nil {
-				// Continue down the tree
-				child.incrementPriority()
-				return t.insertNode(staticPart+wildcardPart, handler, child, fullPath)
-			}
+// insertChild inserts the remaining path below n, which matched the
+// preceding part of the route.
+func (t *Tree) insertChild(path string, handler interface{}, n *node, fullPath string) error {
+	// Check for wildcard at current position
+	if path[0] == ':' || path[0] == '*' {
+		return t.insertWildcard(path, handler, n, fullPath)
+	}
 
-			// Create static node, then recurse for wildcard
-			staticNode := &node{
-				path:  staticPart,
-				nType: static,
-			}
-			n.addChild(staticNode)
-			return t.insertWildcard(wildcardPart, handler, staticNode, fullPath)
-		}
+	// Check if path contains wildcard later - need to split
+	wildcardIdx := findWildcardIndex(path)
+	if wildcardIdx > 0 {
+		// Split: static prefix + wildcard part
+		staticPart := path[:wildcardIdx]
+		wildcardPart := path[wildcardIdx:]
 
-		// No wildcards - try to find existing child
-		c := path[0]
+		// Try to find existing child for static part
+		c := staticPart[0]
 		child := n.findChild(c)
 
 		if child != nil {
 			// Continue down the tree
 			child.incrementPriority()
-			return t.insertNode(path, handler, child, fullPath)
+			return t.insertNode(staticPart+wildcardPart, handler, child, fullPath)
 		}
 
-		// Create new child
-		child = &node{
-			path:     path,
-			nType:    static,
-			handler:  handler,
-			fullPath: fullPath,
+		// Create static node, then recurse for wildcard
+		staticNode := &node{
+			path:  staticPart,
+			nType: static,
 		}
-		n.addChild(child)
-		return nil
+		n.addChild(staticNode)
+		return t.insertWildcard(wildcardPart, handler, staticNode, fullPath)
 	}
 
-	// Path consumed, this node is the endpoint
-	if n.handler != nil {
-		return fmt.Errorf("route already exists: %s", fullPath)
+	// No wildcards - try to find existing child
+	c := path[0]
+	child := n.findChild(c)
+
+	if child != nil {
+		// Continue down the tree
+		child.incrementPriority()
+		return t.insertNode(path, handler, child, fullPath)
 	}
 
-	n.handler = handler
-	n.fullPath = fullPath
+	// Create new child
+	child = &node{
+		path:     path,
+		nType:    static,
+		handler:  handler,
+		fullPath: fullPath,
+	}
+	n.addChild(child)
 	return nil
 }
 
@@ -229,17 +266,15 @@ func (t *Tree) insertWildcard(path string, handler interface{}, n *node, fullPat
 	var wildcardType nodeType
 	var end int
 
-	if path[0] == ':' {
-		wildcardType = param
-		// Find end of param name (next / or end of string)
-		end = 1
-		for end < len(path) && path[end] != '/' {
-			end++
-		}
-	} else { // path[0] == '*'
+	wildcardType = param
+	if path[0] == '*' {
 		wildcardType = catchAll
-		// Catch-all must be last segment
-		end = len(path)
+	}
+	// Find end of wildcard name (next / or end of string).
+	// A catch-all followed by more segments matches mid-path.
+	end = 1
+	for end < len(path) && path[end] != '/' {
+		end++
 	}
 
 	// Extract wildcard name
@@ -259,10 +294,13 @@ func (t *Tree) insertWildcard(path string, handler interface{}, n *node, fullPat
 		if existingName != wildcardName {
 			return fmt.Errorf("conflicting wildcard names: %s vs %s", existingName, wildcardName)
 		}
+		if existingWild.nType != wildcardType {
+			return fmt.Errorf("conflicting wildcard types: %s vs %s", existingWild.path, path[:end])
+		}
 
 		// Continue with existing wildcard node
 		if end < len(path) {
-			return t.insertNode(path[end:], handler, existingWild, fullPath)
+			return t.insertChild(path[end:], handler, existingWild, fullPath)
 		}
 
 		if existingWild.handler != nil {
@@ -285,10 +323,6 @@ func (t *Tree) insertWildcard(path string, handler interface{}, n *node, fullPat
 
 	// If more path remains after param, check what's next
 	if end < len(path) {
-		if wildcardType == catchAll {
-			return fmt.Errorf("catch-all must be the last segment")
-		}
-
 		remaining := path[end:]
 		// Remaining path should start with / (e.g., "/posts" or "/:id")
 		if remaining != "" {
@@ -420,6 +454,19 @@ func (t *Tree) lookupWildcard(path string, n *node, params []Param) (interface{}
 	paramName := n.path[1:] // Skip ':' or '*'
 
 	if n.nType == catchAll {
+		// Mid-path catch-all: the longest capture (at least one segment)
+		// whose remainder matches the following segments.
+		if len(n.children) > 0 {
+			for end := strings.LastIndexByte(path, '/'); end > 0; end = strings.LastIndexByte(path[:end], '/') {
+				ps := append(params, Param{Key: paramName, Value: path[:end]})
+				for _, child := range n.children {
+					if handler, ps, found := t.lookupNode(path[end:], child, ps); found {
+						return handler, ps, true
+					}
+				}
+			}
+		}
+
 		// Catch-all captures entire remaining path
 		params = append(params, Param{
 			Key:   paramName,
@@ -470,29 +517,52 @@ func (t *Tree) lookupWildcard(path string, n *node, params []Param) (interface{}
 }
 
 // validatePath validates the path format.
+//
+// Wildcards must have a name. A route has at most one catch-all, which
+// may be followed by more segments. Optional parameters (":name?") must
+// be the trailing segments of the route.
 func validatePath(path string) error {
+	catchAlls := 0
+	optional := false
 	for i := 0; i < len(path); i++ {
 		c := path[i]
 
+		if c == '/' && optional && i+1 < len(path) && path[i+1] != ':' {
+			return fmt.Errorf("optional parameters must be the last segments")
+		}
+
 		// Check for wildcard
 		if c == ':' || c == '*' {
 			// Ensure there's a name after wildcard
-			if i+1 >= len(path) || path[i+1] == '/' {
+			if i+1 >= len(path) || path[i+1] == '/' || path[i+1] == '?' {
 				return fmt.Errorf("wildcard name cannot be empty at position %d", i)
 			}
 
-			// Catch-all must be last
+			// Find end of wildcard name
+			end := i + 1
+			for end < len(path) && path[end] != '/' {
+				end++
+			}
+
 			if c == '*' {
-				// Find end of wildcard name
-				end := i + 1
-				for end < len(path) && path[end] != '/' {
-					end++
-				}
-				// If not at end, error
-				if end < len(path) {
-					return fmt.Errorf("catch-all must be the last segment")
+				catchAlls++
+				if catchAlls > 1 {
+					return fmt.Errorf("only one catch-all is allowed per route")
 				}
 			}
+
+			switch {
+			case path[end-1] == '?' && c == '*':
+				return fmt.Errorf("catch-all cannot be optional")
+			case path[end-1] == '?':
+				optional = true
+			case optional:
+				return fmt.Errorf("optional parameters must be the last segments")
+			}
+			if q := strings.IndexByte(path[i:end-1], '?'); q != -1 {
+				return fmt.Errorf("'?' must end the wildcard name at position %d", i+q)
+			}
+			i = end - 1
 		}
 	}
 
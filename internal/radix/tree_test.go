@@ -207,7 +207,7 @@ func TestTree_InsertWildcard(t *testing.T) {
 		{"valid wildcard", "/files/*filepath", false},
 		{"wildcard at root", "/*path", false},
 		{"wildcard invalid", "/files/*", true},       // No name
-		{"wildcard not last", "/files/*/docs", true}, // Must be last
+		{"wildcard not last", "/files/*/docs", true}, // No name
 	}
 
 	for _, tt := range tests {
@@ -437,3 +437,133 @@ func TestTree_LargeRouteSet(t *testing.T) {
 		}
 	}
 }
+
+// TestTree_SharedParamPrefix tests routes continuing after the same parameter.
+func TestTree_SharedParamPrefix(t *testing.T) {
+	tree := New()
+	for i, path := range []string{"/users/:id/posts", "/users/:id/comments", "/users/:id", "/users/:id/posts/:post"} {
+		if err := tree.Insert(path, i); err != nil {
+			t.Fatalf("Insert(%q) error = %v", path, err)
+		}
+	}
+
+	tests := []struct {
+		path        string
+		wantHandler interface{}
+		wantParams  string
+	}{
+		{"/users/5/posts", 0, "[{id 5}]"},
+		{"/users/5/comments", 1, "[{id 5}]"},
+		{"/users/5", 2, "[{id 5}]"},
+		{"/users/5/posts/9", 3, "[{id 5} {post 9}]"},
+	}
+	for _, tt := range tests {
+		handler, params, found := tree.Lookup(tt.path)
+		if !found || handler != tt.wantHandler || fmt.Sprint(params) != tt.wantParams {
+			t.Errorf("Lookup(%q) = %v %v %v, want %v %s", tt.path, handler, params, found, tt.wantHandler, tt.wantParams)
+		}
+	}
+}
+
+// TestTree_MidPathCatchAll tests catch-all parameters followed by more segments.
+func TestTree_MidPathCatchAll(t *testing.T) {
+	tree := New()
+	for path, handler := range map[string]string{
+		"/registry/*image/manifests/:ref": "manifest",
+		"/registry/*image/blobs/:digest":  "blob",
+		"/files/*path/raw":                "raw",
+		"/files/*path":                    "file",
+	} {
+		if err := tree.Insert(path, handler); err != nil {
+			t.Fatalf("Insert(%q) error = %v", path, err)
+		}
+	}
+
+	tests := []struct {
+		path        string
+		wantHandler interface{}
+		wantParams  string
+		wantFound   bool
+	}{
+		{"/registry/library/redis/manifests/latest", "manifest", "[{image library/redis} {ref latest}]", true},
+		{"/registry/redis/blobs/sha256:abc", "blob", "[{image redis} {digest sha256:abc}]", true},
+		// The longest capture wins.
+		{"/registry/a/manifests/b/manifests/c", "manifest", "[{image a/manifests/b} {ref c}]", true},
+		{"/files/docs/readme.md/raw", "raw", "[{path docs/readme.md}]", true},
+		{"/files/docs/readme.md", "file", "[{path docs/readme.md}]", true},
+		{"/registry/redis/manifests/", nil, "", false},
+		{"/registry/manifests/latest", nil, "", false}, // At least one segment.
+		{"/registry/redis", nil, "", false},
+	}
+	for _, tt := range tests {
+		handler, params, found := tree.Lookup(tt.path)
+		if found != tt.wantFound {
+			t.Errorf("Lookup(%q) found = %v, want %v", tt.path, found, tt.wantFound)
+			continue
+		}
+		if found && (handler != tt.wantHandler || fmt.Sprint(params) != tt.wantParams) {
+			t.Errorf("Lookup(%q) = %v %v, want %v %s", tt.path, handler, params, tt.wantHandler, tt.wantParams)
+		}
+	}
+}
+
+// TestTree_OptionalParams tests trailing optional parameters.
+func TestTree_OptionalParams(t *testing.T) {
+	tree := New()
+	if err := tree.Insert("/users/:id/:action?", "user"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tree.Insert("/:lang?/:page?", "page"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path       string
+		wantParams string
+	}{
+		{"/users/5", "[{id 5}]"},
+		{"/users/5/edit", "[{id 5} {action edit}]"},
+		{"/", "[]"},
+		{"/en", "[{lang en}]"},
+		{"/en/about", "[{lang en} {page about}]"},
+	}
+	for _, tt := range tests {
+		_, params, found := tree.Lookup(tt.path)
+		if !found || fmt.Sprint(params) != tt.wantParams {
+			t.Errorf("Lookup(%q) = %v %v, want %s", tt.path, params, found, tt.wantParams)
+		}
+	}
+
+	// The expanded routes conflict with existing routes.
+	if err := tree.Insert("/users/:id", "other"); err == nil {
+		t.Error("Insert() should return error for route registered by optional parameter")
+	}
+}
+
+// TestTree_InvalidPatterns tests rejected optional and catch-all patterns.
+func TestTree_InvalidPatterns(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"optional not last", "/users/:id?/edit"},
+		{"required after optional", "/users/:id?/:action"},
+		{"optional catch-all", "/files/*path?"},
+		{"two catch-alls", "/a/*b/c/*d"},
+		{"empty optional name", "/users/:?"},
+		{"question mark inside name", "/users/:i?d"},
+		{"param and catch-all", "/files/:name"}, // Conflicts with /files/*name below.
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := New()
+			if err := tree.Insert("/files/*name", "handler"); err != nil {
+				t.Fatal(err)
+			}
+			if err := tree.Insert(tt.path, "handler"); err == nil {
+				t.Errorf("Insert(%q) should return error", tt.path)
+			}
+		})
+	}
+}
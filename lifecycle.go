@@ -0,0 +1,201 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"time"
+)
+
+// lifecycleHooks stores the request lifecycle hooks of a router.
+type lifecycleHooks struct {
+	request  []func(c *Context)
+	response []func(c *Context, status int, dur time.Duration)
+	err      []func(c *Context, err error)
+	panic    []func(c *Context, rec any)
+}
+
+// lifecycleHooks returns the hooks of the router, creating them if needed.
+func (r *Router) lifecycleHooks() *lifecycleHooks {
+	if r.hooks == nil {
+		r.hooks = &lifecycleHooks{}
+	}
+	return r.hooks
+}
+
+// OnRequest registers a function called at the start of every request,
+// before the middleware chain runs (including unmatched requests).
+//
+// Lifecycle hooks run regardless of the middleware configuration, so
+// observability and integrations can attach once without depending on
+// their position in the middleware chain. Like middleware, hooks must be
+// registered before the router serves requests.
+//
+// Example:
+//
+//	router.OnRequest(func(c *fursy.Context) {
+//	    inFlight.Inc()
+//	})
+//	router.OnResponse(func(c *fursy.Context, status int, dur time.Duration) {
+//	    inFlight.Dec()
+//	    requestDuration.WithLabelValues(strconv.Itoa(status)).Observe(dur.Seconds())
+//	})
+func (r *Router) OnRequest(f func(c *Context)) *Router {
+	if f == nil {
+		panic("fursy: hook cannot be nil")
+	}
+	h := r.lifecycleHooks()
+	h.request = append(h.request, f)
+	return r
+}
+
+// OnResponse registers a function called when a request completes, with the
+// response status and the request duration. It is also called for requests
+// that panic (with status 500 if no response was written).
+//
+// See OnRequest for lifecycle hook semantics.
+func (r *Router) OnResponse(f func(c *Context, status int, dur time.Duration)) *Router {
+	if f == nil {
+		panic("fursy: hook cannot be nil")
+	}
+	h := r.lifecycleHooks()
+	h.response = append(h.response, f)
+	return r
+}
+
+// OnError registers a function called when the handler chain returns an
+// error, before the error handler writes the response.
+//
+// See OnRequest for lifecycle hook semantics.
+//
+// Example:
+//
+//	router.OnError(func(c *fursy.Context, err error) {
+//	    var p fursy.Problem
+//	    if !errors.As(err, &p) {
+//	        errorTracker.Capture(c.Request.Context(), err)
+//	    }
+//	})
+func (r *Router) OnError(f func(c *Context, err error)) *Router {
+	if f == nil {
+		panic("fursy: hook cannot be nil")
+	}
+	h := r.lifecycleHooks()
+	h.err = append(h.err, f)
+	return r
+}
+
+// OnPanic registers a function called when a handler panics, with the
+// recovered value.
+//
+// The router does not recover panics itself: after the hooks run, the panic
+// continues to the server. Panics recovered by the Recovery middleware are
+// reported through Context.NotifyPanic, so hooks see them either way.
+//
+// See OnRequest for lifecycle hook semantics.
+func (r *Router) OnPanic(f func(c *Context, rec any)) *Router {
+	if f == nil {
+		panic("fursy: hook cannot be nil")
+	}
+	h := r.lifecycleHooks()
+	h.panic = append(h.panic, f)
+	return r
+}
+
+// NotifyPanic runs the router's OnPanic hooks for a recovered panic.
+//
+// It is called by recovery middleware that handles the panic instead of
+// letting it reach the router. Recovered panics are counted in Router.Stats.
+func (c *Context) NotifyPanic(rec any) {
+	if c.router == nil {
+		return
+	}
+	c.router.stats.panics.Add(1)
+	if c.router.hooks == nil {
+		return
+	}
+	for _, f := range c.router.hooks.panic {
+		f(c, rec)
+	}
+}
+
+// notifyError runs the router's OnError hooks.
+func (c *Context) notifyError(err error) {
+	if c.router == nil || c.router.hooks == nil {
+		return
+	}
+	for _, f := range c.router.hooks.err {
+		f(c, err)
+	}
+}
+
+// startRequest runs the OnRequest hooks and returns the writer recording the
+// response status, to be passed to finishRequest when the request completes.
+func (h *lifecycleHooks) startRequest(c *Context) *hookResponseWriter {
+	hw := &hookResponseWriter{ResponseWriter: c.Response}
+	c.Response = hw
+	for _, f := range h.request {
+		f(c)
+	}
+	return hw
+}
+
+// finishRequest runs the OnPanic hooks (re-panicking afterwards) and the
+// OnResponse hooks. It must be deferred.
+func (h *lifecycleHooks) finishRequest(c *Context, hw *hookResponseWriter, start time.Time) {
+	rec := recover()
+	status := hw.status
+	if rec != nil {
+		for _, f := range h.panic {
+			f(c, rec)
+		}
+		if status == 0 {
+			status = http.StatusInternalServerError
+		}
+	}
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	dur := time.Since(start)
+	for _, f := range h.response {
+		f(c, status, dur)
+	}
+	if rec != nil {
+		panic(rec)
+	}
+}
+
+// hookResponseWriter records the response status for OnResponse hooks.
+type hookResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status code and calls the underlying WriteHeader.
+func (w *hookResponseWriter) WriteHeader(code int) {
+	if w.status == 0 && code >= 200 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write records an implicit 200 status and calls the underlying Write.
+func (w *hookResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush flushes the underlying ResponseWriter, if supported.
+func (w *hookResponseWriter) Flush() {
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap returns the underlying ResponseWriter (see http.ResponseController).
+func (w *hookResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// hookLog records lifecycle hook calls.
+type hookLog struct {
+	events []string
+}
+
+func (l *hookLog) add(format string, args ...any) {
+	l.events = append(l.events, fmt.Sprintf(format, args...))
+}
+
+func newHookedRouter(log *hookLog) *Router {
+	router := New()
+	router.OnRequest(func(c *Context) {
+		log.add("request %s", c.Request.URL.Path)
+	}).OnResponse(func(_ *Context, status int, dur time.Duration) {
+		if dur <= 0 {
+			log.add("bad duration")
+		}
+		log.add("response %d", status)
+	}).OnError(func(_ *Context, err error) {
+		log.add("error %v", err)
+	}).OnPanic(func(_ *Context, rec any) {
+		log.add("panic %v", rec)
+	})
+	return router
+}
+
+func TestLifecycleHooks(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		want   string
+	}{
+		{"ok", http.MethodGet, "/ok", "request /ok|handler|response 201"},
+		{"implicit status", http.MethodGet, "/implicit", "request /implicit|response 200"},
+		{"error", http.MethodGet, "/error", "request /error|error boom|response 500"},
+		{"not found", http.MethodGet, "/missing", "request /missing|response 404"},
+		{"method not allowed", http.MethodPost, "/ok", "request /ok|response 405"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := &hookLog{}
+			router := newHookedRouter(log)
+			router.Use(func(c *Context) error {
+				return c.Next()
+			})
+			router.GET("/ok", func(c *Context) error {
+				log.add("handler")
+				return c.String(http.StatusCreated, "created")
+			})
+			router.GET("/implicit", func(_ *Context) error { return nil })
+			router.GET("/error", func(_ *Context) error { return errors.New("boom") })
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, http.NoBody))
+			if got := strings.Join(log.events, "|"); got != tt.want {
+				t.Errorf("events = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLifecycleHooks_Panic(t *testing.T) {
+	log := &hookLog{}
+	router := newHookedRouter(log)
+	router.GET("/panic", func(_ *Context) error { panic("oops") })
+
+	func() {
+		defer func() {
+			if rec := recover(); rec != "oops" {
+				t.Errorf("recovered %v, want the panic to continue", rec)
+			}
+		}()
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/panic", http.NoBody))
+	}()
+
+	if got := strings.Join(log.events, "|"); got != "request /panic|panic oops|response 500" {
+		t.Errorf("events = %q", got)
+	}
+}
+
+func TestLifecycleHooks_NotifyPanic(t *testing.T) {
+	log := &hookLog{}
+	router := newHookedRouter(log)
+	// Recovery-style middleware handling the panic itself.
+	router.Use(func(c *Context) (err error) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				c.NotifyPanic(rec)
+				err = c.String(http.StatusInternalServerError, "recovered")
+			}
+		}()
+		return c.Next()
+	})
+	router.GET("/panic", func(_ *Context) error { panic("oops") })
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/panic", http.NoBody))
+	if got := strings.Join(log.events, "|"); got != "request /panic|panic oops|response 500" {
+		t.Errorf("events = %q", got)
+	}
+}
+
+func TestLifecycleHooks_ResponseController(t *testing.T) {
+	router := New()
+	router.OnResponse(func(*Context, int, time.Duration) {})
+	router.GET("/stream", func(c *Context) error {
+		c.Response.WriteHeader(http.StatusOK)
+		return http.NewResponseController(c.Response).Flush()
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stream", http.NoBody))
+	if !w.Flushed {
+		t.Error("response not flushed through the hook writer")
+	}
+}
+
+func TestLifecycleHooks_NilPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	New().OnRequest(nil)
+}
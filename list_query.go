@@ -0,0 +1,284 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Default sort limit used when QueryConfig.MaxSort is zero.
+const defaultMaxSort = 3
+
+// Filter operators supported by Context.ListQuery.
+const (
+	FilterEq   = "eq"   // filter[status]=active or filter[status][eq]=active
+	FilterNe   = "ne"   // filter[status][ne]=deleted
+	FilterGt   = "gt"   // filter[age][gt]=18
+	FilterGte  = "gte"  // filter[age][gte]=18
+	FilterLt   = "lt"   // filter[age][lt]=65
+	FilterLte  = "lte"  // filter[age][lte]=65
+	FilterIn   = "in"   // filter[status][in]=active,pending
+	FilterLike = "like" // filter[name][like]=john
+)
+
+// filterOps lists all supported filter operators.
+var filterOps = []string{FilterEq, FilterNe, FilterGt, FilterGte, FilterLt, FilterLte, FilterIn, FilterLike}
+
+// QueryConfig configures list query parsing for Context.ListQuery.
+//
+// Only fields listed in SortFields and FilterFields are accepted, so
+// clients cannot sort or filter by arbitrary (e.g., unindexed or private) columns.
+type QueryConfig struct {
+	// Pagination configures the "limit", "offset", "page" and "cursor" parameters.
+	Pagination PaginationConfig
+
+	// SortFields lists the fields accepted by the "sort" parameter.
+	// Default: nil (sorting is rejected)
+	SortFields []string
+
+	// DefaultSort is used when the "sort" parameter is absent
+	// (e.g., "-created_at,id").
+	DefaultSort string
+
+	// MaxSort is the maximum number of sort fields.
+	// Default: 3
+	MaxSort int
+
+	// FilterFields lists the fields accepted as "filter[field]" parameters.
+	// Default: nil (filtering is rejected)
+	FilterFields []string
+
+	// FilterOps lists the accepted filter operators.
+	// Default: all operators (FilterEq, FilterNe, ..., FilterLike)
+	FilterOps []string
+}
+
+// SortField is a single sort criterion.
+type SortField struct {
+	// Field is the field name.
+	Field string
+
+	// Desc is true for descending order ("-field").
+	Desc bool
+}
+
+// Filter is a single filter criterion.
+type Filter struct {
+	// Field is the field name.
+	Field string
+
+	// Op is the operator (FilterEq, FilterGte, ...).
+	Op string
+
+	// Values contains the filter value. FilterIn filters have one value
+	// per comma-separated element; all other operators have exactly one.
+	Values []string
+}
+
+// Value returns the first filter value.
+func (f Filter) Value() string {
+	if len(f.Values) == 0 {
+		return ""
+	}
+	return f.Values[0]
+}
+
+// Query holds list endpoint parameters parsed from the query string:
+// pagination, sorting and filtering.
+//
+// The SQL builder of the database plugin (database.BuildListSQL) translates it
+// to WHERE, ORDER BY and LIMIT/OFFSET fragments.
+type Query struct {
+	Pagination
+
+	// Sort contains the sort criteria in priority order.
+	Sort []SortField
+
+	// Filters contains the filter criteria, sorted by field and operator.
+	Filters []Filter
+}
+
+// Filter returns the first filter for field, if any.
+//
+// Example:
+//
+//	if f, ok := q.Filter("status"); ok {
+//	    users = filterByStatus(users, f.Value())
+//	}
+func (q Query) Filter(field string) (Filter, bool) {
+	for _, f := range q.Filters {
+		if f.Field == field {
+			return f, true
+		}
+	}
+	return Filter{}, false
+}
+
+// ListQuery parses and validates common list endpoint query parameters:
+//
+//	?limit=20&offset=40 | ?limit=20&page=3 | ?limit=20&cursor=abc
+//	&sort=-created_at,name
+//	&filter[status]=active&filter[age][gte]=18&filter[role][in]=admin,owner
+//
+// Sort fields are comma-separated; a "-" prefix sorts descending.
+// Filters use "filter[field]=value" (equality) or "filter[field][op]=value".
+//
+// Returns ValidationErrors if any parameter is invalid or a field is not
+// allowed by the config, which can be sent directly using ValidationProblem.
+//
+// Example:
+//
+//	router.GET("/users", func(c *fursy.Context) error {
+//	    q, err := c.ListQuery(fursy.QueryConfig{
+//	        SortFields:   []string{"created_at", "name"},
+//	        DefaultSort:  "-created_at",
+//	        FilterFields: []string{"status", "age"},
+//	    })
+//	    if err != nil {
+//	        return c.Problem(fursy.ValidationProblem(err.(fursy.ValidationErrors)))
+//	    }
+//	    users, total := db.ListUsers(q)
+//	    return c.OKPage(users, q.Meta(total, ""))
+//	})
+func (c *Context) ListQuery(config QueryConfig) (Query, error) {
+	// Set defaults.
+	if config.MaxSort <= 0 {
+		config.MaxSort = defaultMaxSort
+	}
+	if config.FilterOps == nil {
+		config.FilterOps = filterOps
+	}
+
+	var (
+		q    Query
+		errs ValidationErrors
+	)
+
+	p, err := c.Pagination(config.Pagination)
+	if verrs, ok := err.(ValidationErrors); ok {
+		errs = append(errs, verrs...)
+	}
+	q.Pagination = p
+
+	rawSort := c.Query("sort")
+	if rawSort == "" {
+		rawSort = config.DefaultSort
+	}
+	if rawSort != "" {
+		q.Sort = parseSort(rawSort, config, &errs)
+	}
+
+	q.Filters = parseFilters(c.Request.URL.Query(), config, &errs)
+
+	if !errs.IsEmpty() {
+		return Query{}, errs
+	}
+
+	return q, nil
+}
+
+// parseSort parses a comma-separated sort expression ("-created_at,name").
+func parseSort(raw string, config QueryConfig, errs *ValidationErrors) []SortField {
+	parts := strings.Split(raw, ",")
+	if len(parts) > config.MaxSort {
+		errs.Add("sort", "max", "sort accepts at most "+strconv.Itoa(config.MaxSort)+" fields")
+		return nil
+	}
+
+	fields := make([]SortField, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+
+		var sf SortField
+		switch {
+		case strings.HasPrefix(part, "-"):
+			sf = SortField{Field: part[1:], Desc: true}
+		case strings.HasPrefix(part, "+"):
+			sf = SortField{Field: part[1:]}
+		default:
+			sf = SortField{Field: part}
+		}
+
+		if !slices.Contains(config.SortFields, sf.Field) {
+			errs.Add("sort", "oneof", "cannot sort by "+strconv.Quote(sf.Field))
+			continue
+		}
+		if slices.ContainsFunc(fields, func(f SortField) bool { return f.Field == sf.Field }) {
+			errs.Add("sort", "unique", "duplicate sort field "+strconv.Quote(sf.Field))
+			continue
+		}
+		fields = append(fields, sf)
+	}
+	return fields
+}
+
+// parseFilters parses "filter[field]" and "filter[field][op]" parameters.
+func parseFilters(values map[string][]string, config QueryConfig, errs *ValidationErrors) []Filter {
+	var filters []Filter
+
+	for key, vals := range values {
+		if !strings.HasPrefix(key, "filter[") {
+			continue
+		}
+
+		field, op, ok := parseFilterKey(key)
+		if !ok {
+			errs.Add(key, "format", "filter must be filter[field] or filter[field][op]")
+			continue
+		}
+		if !slices.Contains(config.FilterFields, field) {
+			errs.Add(key, "oneof", "cannot filter by "+strconv.Quote(field))
+			continue
+		}
+		if !slices.Contains(config.FilterOps, op) {
+			errs.Add(key, "oneof", "unsupported filter operator "+strconv.Quote(op))
+			continue
+		}
+
+		for _, v := range vals {
+			f := Filter{Field: field, Op: op, Values: []string{v}}
+			if op == FilterIn {
+				f.Values = strings.Split(v, ",")
+			}
+			filters = append(filters, f)
+		}
+	}
+
+	// Map iteration order is random; keep the result deterministic.
+	sort.SliceStable(filters, func(i, j int) bool {
+		if filters[i].Field != filters[j].Field {
+			return filters[i].Field < filters[j].Field
+		}
+		return filters[i].Op < filters[j].Op
+	})
+
+	return filters
+}
+
+// parseFilterKey splits "filter[field]" or "filter[field][op]".
+func parseFilterKey(key string) (field, op string, ok bool) {
+	rest := strings.TrimPrefix(key, "filter[")
+
+	field, rest, ok = strings.Cut(rest, "]")
+	if !ok || field == "" {
+		return "", "", false
+	}
+
+	if rest == "" {
+		return field, FilterEq, true
+	}
+
+	if !strings.HasPrefix(rest, "[") || !strings.HasSuffix(rest, "]") {
+		return "", "", false
+	}
+	op = rest[1 : len(rest)-1]
+	if op == "" || strings.ContainsAny(op, "[]") {
+		return "", "", false
+	}
+	return field, op, true
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// listQueryConfig is the config used by list query tests.
+var listQueryConfig = QueryConfig{
+	SortFields:   []string{"created_at", "name", "id"},
+	DefaultSort:  "-created_at",
+	MaxSort:      2,
+	FilterFields: []string{"status", "age", "role"},
+}
+
+// parseListQuery parses the query string with listQueryConfig.
+func parseListQuery(t *testing.T, query string) (Query, error) {
+	t.Helper()
+	c := newContext()
+	c.Request = httptest.NewRequest(http.MethodGet, "/users"+query, http.NoBody)
+	return c.ListQuery(listQueryConfig)
+}
+
+// TestContext_ListQuery tests parsing of pagination, sorting and filtering.
+func TestContext_ListQuery(t *testing.T) {
+	q, err := parseListQuery(t, "?limit=10&page=2&sort=name,-id"+
+		"&filter[status]=active&filter[age][gte]=18&filter[role][in]=admin,owner")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if q.Limit != 10 || q.Offset != 10 || q.Page != 2 {
+		t.Errorf("unexpected pagination: %+v", q.Pagination)
+	}
+
+	wantSort := []SortField{{Field: "name"}, {Field: "id", Desc: true}}
+	if !reflect.DeepEqual(q.Sort, wantSort) {
+		t.Errorf("expected sort %+v, got %+v", wantSort, q.Sort)
+	}
+
+	wantFilters := []Filter{
+		{Field: "age", Op: FilterGte, Values: []string{"18"}},
+		{Field: "role", Op: FilterIn, Values: []string{"admin", "owner"}},
+		{Field: "status", Op: FilterEq, Values: []string{"active"}},
+	}
+	if !reflect.DeepEqual(q.Filters, wantFilters) {
+		t.Errorf("expected filters %+v, got %+v", wantFilters, q.Filters)
+	}
+
+	if f, ok := q.Filter("status"); !ok || f.Value() != "active" {
+		t.Errorf("expected status filter, got %+v", f)
+	}
+	if _, ok := q.Filter("missing"); ok {
+		t.Error("expected no filter for missing field")
+	}
+}
+
+// TestContext_ListQuery_DefaultSort tests that DefaultSort applies without a sort parameter.
+func TestContext_ListQuery_DefaultSort(t *testing.T) {
+	q, err := parseListQuery(t, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []SortField{{Field: "created_at", Desc: true}}
+	if !reflect.DeepEqual(q.Sort, want) {
+		t.Errorf("expected sort %+v, got %+v", want, q.Sort)
+	}
+	if q.Filters != nil {
+		t.Errorf("expected no filters, got %+v", q.Filters)
+	}
+}
+
+// TestContext_ListQuery_Errors tests validation of sort and filter parameters.
+func TestContext_ListQuery_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{"sort field not allowed", "?sort=password", "sort"},
+		{"too many sort fields", "?sort=name,id,created_at", "sort"},
+		{"duplicate sort field", "?sort=name,-name", "sort"},
+		{"filter field not allowed", "?filter[password]=x", "filter[password]"},
+		{"unknown operator", "?filter[age][between]=1", "filter[age][between]"},
+		{"malformed filter", "?filter[age", "filter[age"},
+		{"nested filter", "?filter[age][gte][x]=1", "filter[age][gte][x]"},
+		{"pagination errors are included", "?limit=0", "limit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseListQuery(t, tt.query)
+			verrs, ok := err.(ValidationErrors)
+			if !ok {
+				t.Fatalf("expected ValidationErrors, got %v", err)
+			}
+			if _, ok := verrs.Fields()[tt.wantErr]; !ok {
+				t.Errorf("expected error for field %q, got %v", tt.wantErr, verrs)
+			}
+		})
+	}
+}
+
+// TestContext_ListQuery_FilterOps tests restricting filter operators.
+func TestContext_ListQuery_FilterOps(t *testing.T) {
+	c := newContext()
+	c.Request = httptest.NewRequest(http.MethodGet, "/users?filter[name][like]=jo", http.NoBody)
+
+	_, err := c.ListQuery(QueryConfig{
+		FilterFields: []string{"name"},
+		FilterOps:    []string{FilterEq},
+	})
+	if err == nil {
+		t.Fatal("expected error for disallowed operator")
+	}
+}
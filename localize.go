@@ -0,0 +1,279 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Localization lists the fields of JSON responses formatted for the
+// locale and time zone of the request (see RouteOptions.Localize), for
+// dashboard-style APIs consumed directly by user interfaces.
+//
+// The locale is the one set with Context.SetLocale (e.g., by i18n
+// middleware), else the LocaleParam query parameter, else "en". The time
+// zone is the one set with Context.SetTimezone, else the TimezoneParam
+// query parameter (an IANA name such as "Europe/Berlin"), else UTC.
+// Unknown time zones are rejected with 400 Bad Request.
+//
+// Localized fields are sent as strings. Fields are dotted paths in the
+// JSON document (e.g., "items.created_at"); arrays are localized element
+// by element.
+//
+// Example:
+//
+//	// GET /stats?locale=de&tz=Europe/Berlin
+//	// {"revenue":"1.234.567,5","updated_at":"02.01.2025 16:04"}
+//	router.HandleWithOptions(http.MethodGet, "/stats", stats, &fursy.RouteOptions{
+//	    Localize: &fursy.Localization{
+//	        Times:   []string{"updated_at"},
+//	        Numbers: []string{"revenue"},
+//	    },
+//	})
+type Localization struct {
+	// Times are the paths of time fields (RFC 3339 strings in the JSON
+	// document), converted to the request time zone and formatted with
+	// TimeLayout.
+	Times []string
+
+	// Numbers are the paths of numeric fields, formatted with the decimal
+	// and group separators of the request locale.
+	Numbers []string
+
+	// TimeLayout is the layout of localized times.
+	// Default: the layout of the request locale (see LocaleFormat)
+	TimeLayout string
+
+	// LocaleParam is the query parameter selecting the locale.
+	// Default: "locale"
+	LocaleParam string
+
+	// TimezoneParam is the query parameter selecting the time zone.
+	// Default: "tz"
+	TimezoneParam string
+}
+
+// LocaleFormat describes how a locale formats numbers and times.
+type LocaleFormat struct {
+	// Decimal is the decimal separator (e.g., "," for "de").
+	Decimal string
+
+	// Group is the digit group separator (e.g., "." for "de").
+	Group string
+
+	// TimeLayout is the layout of times (e.g., "02.01.2006 15:04").
+	TimeLayout string
+}
+
+var (
+	localeFormatsMu sync.RWMutex
+
+	// localeFormats are the registered locale formats by language tag.
+	localeFormats = map[string]LocaleFormat{
+		"en":    {Decimal: ".", Group: ",", TimeLayout: "01/02/2006 3:04 PM"},
+		"en-GB": {Decimal: ".", Group: ",", TimeLayout: "02/01/2006 15:04"},
+		"de":    {Decimal: ",", Group: ".", TimeLayout: "02.01.2006 15:04"},
+		"fr":    {Decimal: ",", Group: " ", TimeLayout: "02/01/2006 15:04"},
+		"es":    {Decimal: ",", Group: ".", TimeLayout: "02/01/2006 15:04"},
+		"it":    {Decimal: ",", Group: ".", TimeLayout: "02/01/2006 15:04"},
+		"pt":    {Decimal: ",", Group: ".", TimeLayout: "02/01/2006 15:04"},
+		"nl":    {Decimal: ",", Group: ".", TimeLayout: "02-01-2006 15:04"},
+		"ru":    {Decimal: ",", Group: " ", TimeLayout: "02.01.2006 15:04"},
+		"ja":    {Decimal: ".", Group: ",", TimeLayout: "2006/01/02 15:04"},
+		"zh":    {Decimal: ".", Group: ",", TimeLayout: "2006/01/02 15:04"},
+	}
+)
+
+// RegisterLocaleFormat registers the format of the locale tag (e.g.,
+// "pl" or "de-CH"), replacing the built-in format if any. Formats are
+// looked up by tag, then by language ("de" for "de-AT"), then "en".
+//
+// Example:
+//
+//	fursy.RegisterLocaleFormat("de-CH", fursy.LocaleFormat{
+//	    Decimal: ".", Group: "’", TimeLayout: "02.01.2006 15:04",
+//	})
+func RegisterLocaleFormat(tag string, format LocaleFormat) {
+	localeFormatsMu.Lock()
+	defer localeFormatsMu.Unlock()
+	localeFormats[tag] = format
+}
+
+// lookupLocaleFormat returns the format of the locale tag.
+func lookupLocaleFormat(tag string) LocaleFormat {
+	localeFormatsMu.RLock()
+	defer localeFormatsMu.RUnlock()
+
+	tag = strings.ReplaceAll(tag, "_", "-")
+	for _, candidate := range []string{tag, strings.ToLower(tag), strings.SplitN(tag, "-", 2)[0]} {
+		if f, ok := localeFormats[candidate]; ok {
+			return f
+		}
+	}
+	return localeFormats["en"]
+}
+
+// SetLocale sets the locale of the request (e.g., "de-DE"), used to
+// localize responses (see Localization). i18n middleware calls it after
+// negotiating the language.
+//
+// Example:
+//
+//	router.Use(func(c *fursy.Context) error {
+//	    c.SetLocale(c.NegotiateLanguage("en", "de", "fr"))
+//	    return c.Next()
+//	})
+func (c *Context) SetLocale(tag string) {
+	c.locale = tag
+}
+
+// Locale returns the locale set with SetLocale ("" if none).
+func (c *Context) Locale() string {
+	return c.locale
+}
+
+// SetTimezone sets the time zone of the request, used to localize
+// responses (see Localization).
+func (c *Context) SetTimezone(loc *time.Location) {
+	c.location = loc
+}
+
+// Timezone returns the time zone set with SetTimezone (nil if none).
+func (c *Context) Timezone() *time.Location {
+	return c.location
+}
+
+// localizer formats the localized fields of a JSON document.
+type localizer struct {
+	times    fieldSet
+	numbers  fieldSet
+	format   LocaleFormat
+	layout   string
+	location *time.Location
+}
+
+// localizePolicy resolves the locale and time zone of the request and
+// localizes the JSON responses of the route.
+func localizePolicy(next HandlerFunc, l *Localization) HandlerFunc {
+	times, numbers := parseFieldSet(l.Times), parseFieldSet(l.Numbers)
+	localeParam, timezoneParam := l.LocaleParam, l.TimezoneParam
+	if localeParam == "" {
+		localeParam = "locale"
+	}
+	if timezoneParam == "" {
+		timezoneParam = "tz"
+	}
+
+	return func(c *Context) error {
+		locale := c.locale
+		if locale == "" {
+			locale = c.Query(localeParam)
+		}
+		loc := c.location
+		if loc == nil {
+			loc = time.UTC
+			if name := c.Query(timezoneParam); name != "" {
+				var err error
+				if loc, err = time.LoadLocation(name); err != nil {
+					return c.Problem(BadRequest("unknown time zone " + name))
+				}
+			}
+		}
+
+		lz := &localizer{times: times, numbers: numbers, format: lookupLocaleFormat(locale), layout: l.TimeLayout, location: loc}
+		if lz.layout == "" {
+			lz.layout = lz.format.TimeLayout
+		}
+		c.localizer = lz
+		return next(c)
+	}
+}
+
+// localize formats the localized fields of v.
+func (l *localizer) localize(v any) any {
+	return l.walk(v, l.times, l.numbers)
+}
+
+// walk formats the fields of v selected by times and numbers.
+func (l *localizer) walk(v any, times, numbers fieldSet) any {
+	switch v := v.(type) {
+	case []any:
+		for i, item := range v {
+			v[i] = l.walk(item, times, numbers)
+		}
+	case map[string]any:
+		for key, child := range v {
+			t, isTime := times[key]
+			n, isNumber := numbers[key]
+			switch {
+			case isTime && t == nil:
+				v[key] = l.leaf(child, l.formatTime)
+			case isNumber && n == nil:
+				v[key] = l.leaf(child, l.formatNumber)
+			case isTime || isNumber:
+				v[key] = l.walk(child, t, n)
+			}
+		}
+	}
+	return v
+}
+
+// leaf formats the value v of a localized field, element by element for arrays.
+func (l *localizer) leaf(v any, format func(any) any) any {
+	if items, ok := v.([]any); ok {
+		for i, item := range items {
+			items[i] = format(item)
+		}
+		return items
+	}
+	return format(v)
+}
+
+// formatTime formats an RFC 3339 time in the request time zone.
+// Other values are returned unchanged.
+func (l *localizer) formatTime(v any) any {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return v
+	}
+	return t.In(l.location).Format(l.layout)
+}
+
+// formatNumber formats a number with the separators of the request
+// locale. Other values and numbers with an exponent are returned unchanged.
+func (l *localizer) formatNumber(v any) any {
+	n, ok := v.(json.Number)
+	if !ok || strings.ContainsAny(string(n), "eE") {
+		return v
+	}
+
+	s := string(n)
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	integer, fraction, hasFraction := strings.Cut(s, ".")
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, d := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(l.format.Group)
+		}
+		b.WriteRune(d)
+	}
+	if hasFraction {
+		b.WriteString(l.format.Decimal)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
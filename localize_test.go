@@ -0,0 +1,95 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// localizedStats is a dashboard response with time and number fields.
+type localizedStats struct {
+	ID        int       `json:"id"`
+	Revenue   float64   `json:"revenue"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Series    []struct {
+		Value int `json:"value"`
+	} `json:"series"`
+}
+
+// TestLocalize tests formatting response fields for the request locale.
+func TestLocalize(t *testing.T) {
+	stats := localizedStats{ID: 1234, Revenue: 1234567.5, UpdatedAt: time.Date(2025, 1, 2, 15, 4, 0, 0, time.UTC)}
+	stats.Series = append(stats.Series, struct {
+		Value int `json:"value"`
+	}{Value: -98765})
+
+	r := New()
+	handler := func(c *Context) error { return c.JSON(http.StatusOK, stats) }
+	r.HandleWithOptions(http.MethodGet, "/stats", handler, &RouteOptions{
+		Localize: &Localization{Times: []string{"updated_at"}, Numbers: []string{"revenue", "series.value"}},
+	})
+	r.HandleWithOptions(http.MethodGet, "/fixed", func(c *Context) error {
+		c.SetLocale("ja")
+		return handler(c)
+	}, &RouteOptions{Localize: &Localization{Times: []string{"updated_at"}, TimeLayout: time.DateOnly}})
+
+	tests := []struct {
+		path     string
+		wantCode int
+		want     []string
+	}{
+		{"/stats", http.StatusOK, []string{`"id":1234`, `"revenue":"1,234,567.5"`, `"updated_at":"01/02/2025 3:04 PM"`, `"value":"-98,765"`}},
+		{"/stats?locale=de-AT&tz=Europe/Berlin", http.StatusOK, []string{`"revenue":"1.234.567,5"`, `"updated_at":"02.01.2025 16:04"`}},
+		{"/stats?tz=Mars/Olympus", http.StatusBadRequest, nil},
+		{"/fixed", http.StatusOK, []string{`"updated_at":"2025-01-02"`, `"revenue":1234567.5`}},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+		if w.Code != tt.wantCode {
+			t.Errorf("%s: status = %d, want %d", tt.path, w.Code, tt.wantCode)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(w.Body.String(), want) {
+				t.Errorf("%s: body %s does not contain %s", tt.path, w.Body.String(), want)
+			}
+		}
+	}
+}
+
+// TestContext_SetLocale tests the locale and time zone set by middleware.
+func TestContext_SetLocale(t *testing.T) {
+	RegisterLocaleFormat("xx", LocaleFormat{Decimal: "!", Group: "_", TimeLayout: time.Kitchen})
+	t.Cleanup(func() {
+		localeFormatsMu.Lock()
+		delete(localeFormats, "xx")
+		localeFormatsMu.Unlock()
+	})
+
+	r := New()
+	r.Use(func(c *Context) error {
+		c.SetLocale("xx-YY")
+		c.SetTimezone(time.FixedZone("UTC-5", -5*3600))
+		return c.Next()
+	})
+	GET[Empty, localizedStats](r, "/stats", func(c *Box[Empty, localizedStats]) error {
+		if c.Locale() != "xx-YY" || c.Timezone() == nil {
+			t.Errorf("Locale() = %q, Timezone() = %v", c.Locale(), c.Timezone())
+		}
+		return c.OK(localizedStats{Revenue: 1234.25, UpdatedAt: time.Date(2025, 1, 2, 15, 4, 0, 0, time.UTC)})
+	}, Localize(Localization{Times: []string{"updated_at"}, Numbers: []string{"revenue"}}))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stats?locale=de", http.NoBody))
+	for _, want := range []string{`"revenue":"1_234!25"`, `"updated_at":"10:04AM"`} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("body %s does not contain %s", w.Body.String(), want)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/coregx/fursy/internal/radix"
+)
+
+// Manifest declares routes by name, so gateways and BFFs can be
+// reconfigured by editing a file instead of code. Handlers and
+// middleware are resolved by name from a HandlerRegistry (see
+// Router.LoadManifest).
+//
+// Manifests are parsed from JSON with ParseManifest. The fields also have
+// yaml tags, so YAML manifests can be decoded with a YAML library:
+//
+//	prefix: /api
+//	middleware: [requestID]
+//	routes:
+//	  - method: GET
+//	    path: /users/:id
+//	    handler: users.get
+//	    middleware: [auth]
+//	    summary: Get a user
+//	    tags: [users]
+type Manifest struct {
+	// Prefix is prepended to the paths of the routes (e.g., "/api").
+	Prefix string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+
+	// Middleware names the middleware of all the routes, run after the
+	// router middleware.
+	Middleware []string `json:"middleware,omitempty" yaml:"middleware,omitempty"`
+
+	// Routes are the declared routes.
+	Routes []ManifestRoute `json:"routes" yaml:"routes"`
+}
+
+// ManifestRoute is a route of a Manifest.
+type ManifestRoute struct {
+	// Method is the HTTP method (e.g., "GET").
+	Method string `json:"method" yaml:"method"`
+
+	// Path is the route path, relative to the manifest prefix.
+	Path string `json:"path" yaml:"path"`
+
+	// Handler names the route handler in the registry.
+	Handler string `json:"handler" yaml:"handler"`
+
+	// Middleware names the middleware of the route, run after the
+	// manifest middleware.
+	Middleware []string `json:"middleware,omitempty" yaml:"middleware,omitempty"`
+
+	// Summary, Description, Tags, OperationID and Deprecated are
+	// documented in OpenAPI (see RouteOptions). Deprecated routes also
+	// send the Deprecation header and are counted by DeprecatedRoutes.
+	Summary     string   `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	OperationID string   `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Deprecated  bool     `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+}
+
+// ParseManifest parses a JSON manifest. Unknown fields are rejected, so
+// misspelled keys are reported instead of ignored.
+func ParseManifest(data []byte) (*Manifest, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var m Manifest
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("fursy: invalid manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// HandlerRegistry names the handlers and middleware that manifests can
+// reference (see Router.LoadManifest).
+type HandlerRegistry struct {
+	handlers   map[string]HandlerFunc
+	middleware map[string]HandlerFunc
+}
+
+// NewHandlerRegistry creates an empty registry.
+//
+// Example:
+//
+//	registry := fursy.NewHandlerRegistry().
+//	    Handler("users.get", users.Get).
+//	    Handler("users.list", users.List).
+//	    Middleware("auth", middleware.JWT(secret))
+func NewHandlerRegistry() *HandlerRegistry {
+	return &HandlerRegistry{
+		handlers:   make(map[string]HandlerFunc),
+		middleware: make(map[string]HandlerFunc),
+	}
+}
+
+// Handler registers a handler under name.
+// Panics if h is nil or name is already registered.
+func (reg *HandlerRegistry) Handler(name string, h HandlerFunc) *HandlerRegistry {
+	if h == nil {
+		panic("fursy: handler cannot be nil")
+	}
+	if _, ok := reg.handlers[name]; ok {
+		panic(fmt.Sprintf("fursy: handler %q already registered", name))
+	}
+	reg.handlers[name] = h
+	return reg
+}
+
+// Middleware registers a middleware under name. It is reported under
+// that name by Routes (see Named).
+// Panics if mw is nil or name is already registered.
+func (reg *HandlerRegistry) Middleware(name string, mw HandlerFunc) *HandlerRegistry {
+	if mw == nil {
+		panic("fursy: middleware cannot be nil")
+	}
+	if _, ok := reg.middleware[name]; ok {
+		panic(fmt.Sprintf("fursy: middleware %q already registered", name))
+	}
+	reg.middleware[name] = Named(name, mw)
+	return reg
+}
+
+// LoadManifest registers the routes of m with the handlers and
+// middleware of reg.
+//
+// The whole manifest is validated first: unknown handler or middleware
+// names, missing methods, and invalid or conflicting paths (with each
+// other or with registered routes) are reported together as a joined
+// error of *RouteConfigError, and no route is registered. Call it at
+// startup, so a bad manifest stops the deployment.
+//
+// Example:
+//
+//	data, err := os.ReadFile("routes.json")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	m, err := fursy.ParseManifest(data)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if err := router.LoadManifest(m, registry); err != nil {
+//	    log.Fatal(err)
+//	}
+func (r *Router) LoadManifest(m *Manifest, reg *HandlerRegistry) error {
+	// Validate and register under one lock, so that a concurrent
+	// registration cannot add a conflicting route in between.
+	r.routeMu.Lock()
+	defer r.routeMu.Unlock()
+
+	if err := r.validateManifest(m, reg); err != nil {
+		return err
+	}
+
+	base := r.Group(m.Prefix, lookupMiddleware(reg, m.Middleware)...)
+	for _, mr := range m.Routes {
+		g := base.Group("")
+		g.Use(lookupMiddleware(reg, mr.Middleware)...)
+		r.addGroupRoute(g, strings.ToUpper(mr.Method), g.prefix+mr.Path, g.combineMiddleware(reg.handlers[mr.Handler]), nil, &RouteOptions{
+			Summary:     mr.Summary,
+			Description: mr.Description,
+			Tags:        mr.Tags,
+			OperationID: mr.OperationID,
+			Deprecated:  mr.Deprecated,
+		}, nil)
+	}
+	return nil
+}
+
+// validateManifest reports the problems of m (see LoadManifest).
+// It must be called with routeMu held.
+func (r *Router) validateManifest(m *Manifest, reg *HandlerRegistry) error {
+	var errs []error
+	report := func(method, path, format string, args ...any) {
+		errs = append(errs, &RouteConfigError{Method: method, Path: path, Message: "manifest: " + fmt.Sprintf(format, args...)})
+	}
+
+	for _, name := range m.Middleware {
+		if reg.middleware[name] == nil {
+			report("*", m.Prefix, "unknown middleware %q", name)
+		}
+	}
+
+	// Insert the paths in copies of the route trees to find conflicts
+	// before registering any route.
+	trees := make(map[string]*radix.Tree)
+	for _, mr := range m.Routes {
+		method, path := strings.ToUpper(mr.Method), m.Prefix+mr.Path
+		if method == "" || strings.ContainsAny(method, " \t") {
+			report(mr.Method, path, "invalid method")
+			continue
+		}
+		if reg.handlers[mr.Handler] == nil {
+			report(method, path, "unknown handler %q", mr.Handler)
+		}
+		for _, name := range mr.Middleware {
+			if reg.middleware[name] == nil {
+				report(method, path, "unknown middleware %q", name)
+			}
+		}
+
+		tree := trees[method]
+		if tree == nil {
+			tree = radix.New()
+			for _, e := range r.tableEntries[method] {
+				_ = tree.Insert(e.path, e.entry)
+			}
+			trees[method] = tree
+		}
+		if err := tree.Insert(path, struct{}{}); err != nil {
+			report(method, path, "%v", err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// lookupMiddleware returns the middleware of reg named names.
+func lookupMiddleware(reg *HandlerRegistry, names []string) []HandlerFunc {
+	middleware := make([]HandlerFunc, 0, len(names))
+	for _, name := range names {
+		middleware = append(middleware, reg.middleware[name])
+	}
+	return middleware
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newManifestRegistry returns a registry of the test manifests.
+func newManifestRegistry() *HandlerRegistry {
+	return NewHandlerRegistry().
+		Handler("users.get", func(c *Context) error {
+			return c.Text("user " + c.Param("id") + " " + c.Response.Header().Get("X-Chain"))
+		}).
+		Middleware("trace", func(c *Context) error {
+			c.Response.Header().Add("X-Chain", "trace")
+			return c.Next()
+		}).
+		Middleware("auth", func(c *Context) error {
+			if c.GetHeader("Authorization") == "" {
+				return c.Problem(Unauthorized(""))
+			}
+			c.Response.Header().Add("X-Chain", "auth")
+			return c.Next()
+		})
+}
+
+// TestRouter_LoadManifest tests registering routes from a manifest.
+func TestRouter_LoadManifest(t *testing.T) {
+	m, err := ParseManifest([]byte(`{
+		"prefix": "/api",
+		"middleware": ["trace"],
+		"routes": [
+			{"method": "get", "path": "/users/:id", "handler": "users.get", "middleware": ["auth"],
+			 "summary": "Get a user", "tags": ["users"], "operationId": "getUser"},
+			{"method": "GET", "path": "/public/:id", "handler": "users.get", "deprecated": true}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := New()
+	if err := r.LoadManifest(m, newManifestRegistry()); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/users/7", http.NoBody)
+	req.Header.Set("Authorization", "Bearer x")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Body.String() != "user 7 trace" || strings.Join(w.Header().Values("X-Chain"), ",") != "trace,auth" {
+		t.Errorf("body = %q, chain = %v", w.Body.String(), w.Header().Values("X-Chain"))
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/users/7", http.NoBody))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status without auth = %d, want 401", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/7", http.NoBody))
+	if w.Header().Get("Deprecation") != "true" || len(r.DeprecatedRoutes()) != 1 {
+		t.Errorf("deprecated route: headers = %v", w.Header())
+	}
+
+	routes := r.Routes()
+	if routes[0].OperationID != "getUser" || routes[0].Summary != "Get a user" || !routes[1].Deprecated {
+		t.Errorf("metadata not applied: %+v", routes)
+	}
+	if got := strings.Join(routes[0].Middleware, ","); got != "trace,auth" {
+		t.Errorf("middleware = %s, want trace,auth", got)
+	}
+}
+
+// TestRouter_LoadManifest_Invalid tests validating manifests before registration.
+func TestRouter_LoadManifest_Invalid(t *testing.T) {
+	r := New()
+	r.GET("/api/users/:name", func(c *Context) error { return nil })
+
+	m := &Manifest{
+		Prefix:     "/api",
+		Middleware: []string{"missing-mw"},
+		Routes: []ManifestRoute{
+			{Method: "GET", Path: "/users/:id", Handler: "users.get"},
+			{Method: "POST", Path: "/users", Handler: "users.create", Middleware: []string{"auth", "nope"}},
+			{Method: "", Path: "/x", Handler: "users.get"},
+		},
+	}
+	err := r.LoadManifest(m, newManifestRegistry())
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{`unknown middleware "missing-mw"`, `unknown handler "users.create"`, `unknown middleware "nope"`, "invalid method", "GET /api/users/:id"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should contain %q", err, want)
+		}
+	}
+	var rce *RouteConfigError
+	if !errors.As(err, &rce) {
+		t.Error("errors should be *RouteConfigError")
+	}
+	if len(r.Routes()) != 1 {
+		t.Errorf("no route should be registered, got %d", len(r.Routes()))
+	}
+
+	if _, err := ParseManifest([]byte(`{"routes": [{"methd": "GET"}]}`)); err == nil {
+		t.Error("unknown fields should be rejected")
+	}
+}
@@ -0,0 +1,535 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaskFunc masks the value of a field tagged with mask.
+// Non-string values are passed in their JSON text form (e.g., "1234").
+type MaskFunc func(value string) string
+
+var (
+	masksMu sync.RWMutex
+
+	// masks are the registered masks by name.
+	masks = map[string]MaskFunc{
+		"redact": func(string) string { return "[REDACTED]" },
+		"last4":  maskLast4,
+		"email":  maskEmail,
+	}
+
+	// maskPlans caches the mask plan of response types (nil if the type
+	// has no masked fields).
+	maskPlans sync.Map // reflect.Type → *maskPlan
+)
+
+// RegisterMask registers a mask for the mask struct tag, replacing the
+// built-in mask of the same name if any. The built-in masks are "redact"
+// ("[REDACTED]"), "last4" ("************4242") and "email"
+// ("j***@example.com"); the "omit" mask removes the field.
+//
+// Example:
+//
+//	fursy.RegisterMask("initials", func(v string) string {
+//	    return initials(v)
+//	})
+func RegisterMask(name string, fn MaskFunc) {
+	masksMu.Lock()
+	defer masksMu.Unlock()
+	masks[name] = fn
+}
+
+// Unmasker reports whether the caller of the request may see the
+// unmasked value of fields requiring permission (the perm option of the
+// mask tag, "" if none).
+type Unmasker func(c *Context, permission string) bool
+
+// SetUnmasker sets the function deciding which callers see masked fields
+// unmasked, e.g. from the roles stored by the authentication middleware.
+//
+// Struct fields of JSON responses tagged with mask are masked for the
+// other callers, so the same handler can serve admin and end-user
+// audiences. The tag names the mask and, optionally, the permission
+// passed to the Unmasker:
+//
+//	type Customer struct {
+//	    Name  string `json:"name"`
+//	    Email string `json:"email" mask:"email"`
+//	    Card  string `json:"card" mask:"last4,perm=billing:read"`
+//	    Notes string `json:"notes" mask:"omit,perm=support"`
+//	}
+//
+// Masks apply to every response format (Context.JSON, Context.XML, Box
+// responses, Negotiate and the renderers registered with RegisterRenderer),
+// through nested structs, pointers, slices and maps of the response type.
+// Values stored in interface fields, and types implementing json.Marshaler
+// or xml.Marshaler, are not inspected.
+//
+// Default: nil (masked fields are always masked).
+//
+// Example:
+//
+//	router.SetUnmasker(func(c *fursy.Context, permission string) bool {
+//	    claims, _ := c.Get("jwt").(*Claims)
+//	    return claims != nil && (claims.Role == "admin" || claims.Has(permission))
+//	})
+func (r *Router) SetUnmasker(fn Unmasker) *Router {
+	r.unmasker = fn
+	return r
+}
+
+// maskLast4 masks all but the last 4 characters.
+func maskLast4(v string) string {
+	runes := []rune(v)
+	if len(runes) <= 4 {
+		return strings.Repeat("*", len(runes))
+	}
+	return strings.Repeat("*", len(runes)-4) + string(runes[len(runes)-4:])
+}
+
+// maskEmail masks the local part of an email address but its first character.
+func maskEmail(v string) string {
+	local, domain, ok := strings.Cut(v, "@")
+	if !ok || local == "" {
+		return "[REDACTED]"
+	}
+	first := []rune(local)[0]
+	return string(first) + "***@" + domain
+}
+
+// maskRule is the parsed mask tag of a field.
+type maskRule struct {
+	mask       string
+	permission string
+}
+
+// maskPlan copies values of a response type with their fields masked.
+// Structs with masked fields are copied to a struct type with the same
+// fields and tags, whose masked fields hold any value: the masked value,
+// or the original value for callers allowed to see it. Copies have no
+// methods, so every encoder sees the masked fields.
+type maskPlan struct {
+	src    reflect.Type
+	dst    reflect.Type // nil until a recursive type is planned.
+	elem   *maskPlan    // Pointers, slices, arrays and maps.
+	fields []maskField  // Structs, one per field of dst.
+}
+
+// maskField is a field of a struct maskPlan.
+type maskField struct {
+	index     int       // Index of the field in the source struct.
+	rule      *maskRule // Masked field.
+	plan      *maskPlan // Field whose value has masked fields (nil to copy the value).
+	dynamic   bool      // The copy is held in an any field (recursive types).
+	omitEmpty bool      // Empty values stay empty (omitempty json option).
+}
+
+var (
+	anyType           = reflect.TypeFor[any]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	xmlMarshalerType  = reflect.TypeFor[xml.Marshaler]()
+)
+
+// maskPlanOf returns the mask plan of values of type t (nil if t has no
+// masked fields).
+func maskPlanOf(t reflect.Type) *maskPlan {
+	if t == nil {
+		return nil
+	}
+	if plan, ok := maskPlans.Load(t); ok {
+		return plan.(*maskPlan)
+	}
+	planner := &maskPlanner{structs: make(map[reflect.Type]*maskPlan)}
+	plan := planner.plan(t)
+	planner.finish()
+	maskPlans.Store(t, plan)
+	return plan
+}
+
+// hasMasks reports whether values of type t have masked fields. Types
+// that marshal themselves are encoded as is.
+func hasMasks(t reflect.Type, seen map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] || marshalsItself(t) {
+		return false
+	}
+	seen[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		if _, ok := field.Tag.Lookup("mask"); ok || hasMasks(field.Type, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// marshalsItself reports whether values of t implement json.Marshaler or
+// xml.Marshaler.
+func marshalsItself(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	return pt.Implements(jsonMarshalerType) || pt.Implements(xmlMarshalerType)
+}
+
+// maskPlanner builds the mask plan of a type.
+type maskPlanner struct {
+	structs map[reflect.Type]*maskPlan // Struct plans, complete or being built.
+	plans   []*maskPlan
+}
+
+// plan returns the plan of t (nil if t has no masked fields).
+func (p *maskPlanner) plan(t reflect.Type) *maskPlan {
+	if !hasMasks(t, map[reflect.Type]bool{}) {
+		return nil
+	}
+	if t.Kind() == reflect.Struct {
+		return p.planStruct(t)
+	}
+	return p.wrap(t, p.plan(t.Elem()))
+}
+
+// wrap returns the plan of the pointer, slice, array or map type t of
+// elements planned by elem.
+func (p *maskPlanner) wrap(t reflect.Type, elem *maskPlan) *maskPlan {
+	plan := &maskPlan{src: t, elem: elem}
+	if elem.dst != nil {
+		plan.dst = wrapType(t, elem.dst)
+	}
+	p.plans = append(p.plans, plan)
+	return plan
+}
+
+// planStruct returns the plan of the struct t. Embedded structs are
+// always copied, since struct types with methods cannot be embedded in
+// the copies. The plan of a struct being built has no dst type yet.
+func (p *maskPlanner) planStruct(t reflect.Type) *maskPlan {
+	if plan, ok := p.structs[t]; ok {
+		return plan
+	}
+	plan := &maskPlan{src: t}
+	p.structs[t] = plan
+
+	fields := make([]reflect.StructField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		field := maskField{index: i}
+		df := reflect.StructField{Name: sf.Name, Type: sf.Type, Tag: maskFieldTag(sf.Tag, false), Anonymous: sf.Anonymous}
+
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		switch {
+		case sf.Anonymous && ft.Kind() == reflect.Struct:
+			// Promoted fields are encoded the same whatever the field name.
+			df.Name = exportedName(sf.Name)
+			field.plan = p.planStruct(ft)
+			if sf.Type.Kind() == reflect.Pointer {
+				field.plan = p.wrap(sf.Type, field.plan)
+			}
+		case !sf.IsExported():
+			continue
+		default:
+			df.Anonymous = false
+			if tag, ok := sf.Tag.Lookup("mask"); ok {
+				field.rule = parseMaskTag(tag)
+				_, opts, _ := strings.Cut(sf.Tag.Get("json"), ",")
+				field.omitEmpty = slices.Contains(strings.Split(opts, ","), "omitempty")
+				df.Type = anyType
+				df.Tag = maskFieldTag(sf.Tag, field.rule.mask == "omit")
+			} else {
+				field.plan = p.plan(sf.Type)
+			}
+		}
+
+		if field.plan != nil {
+			if field.plan.dst == nil {
+				field.dynamic = true
+				df.Type, df.Anonymous = anyType, false
+			} else {
+				df.Type = field.plan.dst
+			}
+		}
+		plan.fields = append(plan.fields, field)
+		fields = append(fields, df)
+	}
+
+	plan.dst = reflect.StructOf(fields)
+	return plan
+}
+
+// finish sets the dst types of the plans wrapping recursive structs.
+func (p *maskPlanner) finish() {
+	for _, plan := range p.plans {
+		plan.finish()
+	}
+}
+
+// finish sets the dst type of plan once its elements are planned.
+func (plan *maskPlan) finish() reflect.Type {
+	if plan.dst == nil {
+		plan.dst = wrapType(plan.src, plan.elem.finish())
+	}
+	return plan.dst
+}
+
+// wrapType returns the pointer, slice, array or map type t with elements
+// of type elem.
+func wrapType(t, elem reflect.Type) reflect.Type {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return reflect.PointerTo(elem)
+	case reflect.Slice:
+		return reflect.SliceOf(elem)
+	case reflect.Array:
+		return reflect.ArrayOf(t.Len(), elem)
+	default:
+		return reflect.MapOf(t.Key(), elem)
+	}
+}
+
+// exportedName returns name with its first letter upper case.
+func exportedName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	switch {
+	case unicode.IsUpper(r):
+		return name
+	case unicode.IsLower(r):
+		return string(unicode.ToUpper(r)) + name[size:]
+	default:
+		return "X" + name
+	}
+}
+
+// maskFieldTag returns the tag of a field of a masked copy: tag without
+// the mask key, with the omitempty option on the json and xml keys of
+// omitted fields.
+func maskFieldTag(tag reflect.StructTag, omit bool) reflect.StructTag {
+	var b strings.Builder
+	seen := make(map[string]bool)
+	add := func(key, value string) {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(key + ":" + strconv.Quote(value))
+	}
+
+	// Parse the key:"value" pairs as reflect.StructTag.Lookup does.
+	rest := string(tag)
+	for {
+		rest = strings.TrimLeft(rest, " ")
+		key, quoted, ok := strings.Cut(rest, ":")
+		if !ok || key == "" || strings.ContainsAny(key, " \"") {
+			break
+		}
+		qvalue, err := strconv.QuotedPrefix(quoted)
+		if err != nil {
+			break
+		}
+		rest = quoted[len(qvalue):]
+		value, _ := strconv.Unquote(qvalue)
+
+		seen[key] = true
+		switch {
+		case key == "mask":
+			continue
+		case omit && (key == "json" || key == "xml") && value != "-":
+			if _, opts, _ := strings.Cut(value, ","); !slices.Contains(strings.Split(opts, ","), "omitempty") {
+				value += ",omitempty"
+			}
+		}
+		add(key, value)
+	}
+
+	if omit {
+		for _, key := range []string{"json", "xml"} {
+			if !seen[key] {
+				add(key, ",omitempty")
+			}
+		}
+	}
+	return reflect.StructTag(b.String())
+}
+
+// parseMaskTag parses a mask tag (e.g., "last4,perm=billing:read").
+// Unknown masks panic when the response is masked.
+func parseMaskTag(tag string) *maskRule {
+	name, opts, _ := strings.Cut(tag, ",")
+	rule := &maskRule{mask: name}
+	for opt := range strings.SplitSeq(opts, ",") {
+		if perm, ok := strings.CutPrefix(strings.TrimSpace(opt), "perm="); ok {
+			rule.permission = perm
+		}
+	}
+	return rule
+}
+
+// maskResponse returns a copy of the response obj with its masked fields
+// masked for the caller, and false if obj has no masked fields. It is
+// shared by the JSON and XML responses, Negotiate and custom renderers.
+func (c *Context) maskResponse(obj any) (any, bool) {
+	plan := maskPlanOf(reflect.TypeOf(obj))
+	if plan == nil {
+		return obj, false
+	}
+	return (&masker{c: c}).copy(plan, reflect.ValueOf(obj)).Interface(), true
+}
+
+// masker masks responses for the caller of a request.
+type masker struct {
+	c        *Context
+	unmasked map[string]bool // Unmasker results by permission.
+}
+
+// copy returns a masked copy of v, of type plan.dst.
+func (m *masker) copy(plan *maskPlan, v reflect.Value) reflect.Value {
+	switch plan.src.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return reflect.Zero(plan.dst)
+		}
+		out := reflect.New(plan.elem.dst)
+		out.Elem().Set(m.copy(plan.elem, v.Elem()))
+		return out
+	case reflect.Slice, reflect.Array:
+		var out reflect.Value
+		if plan.src.Kind() == reflect.Array {
+			out = reflect.New(plan.dst).Elem()
+		} else if v.IsNil() {
+			return reflect.Zero(plan.dst)
+		} else {
+			out = reflect.MakeSlice(plan.dst, v.Len(), v.Len())
+		}
+		for i := range v.Len() {
+			out.Index(i).Set(m.copy(plan.elem, v.Index(i)))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(plan.dst)
+		}
+		out := reflect.MakeMapWithSize(plan.dst, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			value := reflect.New(iter.Value().Type()).Elem()
+			value.Set(iter.Value())
+			out.SetMapIndex(iter.Key(), m.copy(plan.elem, value))
+		}
+		return out
+	}
+
+	out := reflect.New(plan.dst).Elem()
+	for i, field := range plan.fields {
+		src, dst := v.Field(field.index), out.Field(i)
+		switch {
+		case field.rule != nil:
+			switch {
+			case field.omitEmpty && isEmptyValue(src):
+			case m.canUnmask(field.rule.permission):
+				dst.Set(src)
+			case field.rule.mask != "omit":
+				if masked := maskValue(src, field.rule.mask); masked != nil {
+					dst.Set(reflect.ValueOf(masked))
+				}
+			}
+		case field.plan == nil:
+			dst.Set(src)
+		case field.dynamic:
+			// Nil values leave the field nil, for omitempty.
+			if k := src.Kind(); (k != reflect.Pointer && k != reflect.Slice && k != reflect.Map) || !src.IsNil() {
+				dst.Set(m.copy(field.plan, src))
+			}
+		default:
+			dst.Set(m.copy(field.plan, src))
+		}
+	}
+	return out
+}
+
+// isEmptyValue reports whether v is empty for the omitempty option.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Struct:
+		return false
+	default:
+		return v.IsZero()
+	}
+}
+
+// canUnmask reports whether the caller may see fields requiring permission.
+func (m *masker) canUnmask(permission string) bool {
+	if m.c.router == nil || m.c.router.unmasker == nil {
+		return false
+	}
+	if ok, cached := m.unmasked[permission]; cached {
+		return ok
+	}
+	if m.unmasked == nil {
+		m.unmasked = make(map[string]bool)
+	}
+	ok := m.c.router.unmasker(m.c, permission)
+	m.unmasked[permission] = ok
+	return ok
+}
+
+// maskValue masks v with the named mask, element by element for slices
+// and arrays. Nil values stay nil.
+func maskValue(v reflect.Value, name string) any {
+	masksMu.RLock()
+	fn, ok := masks[name]
+	masksMu.RUnlock()
+	if !ok {
+		panic(fmt.Sprintf("fursy: unknown mask %q", name))
+	}
+
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return fn(v.String())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			items := make([]any, v.Len())
+			for i := range items {
+				items[i] = maskValue(v.Index(i), name)
+			}
+			return items
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+	}
+
+	text, err := json.Marshal(v.Interface())
+	if err != nil {
+		return fn(fmt.Sprint(v))
+	}
+	return fn(string(text))
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type maskedBase struct {
+	SSN string `json:"ssn" mask:"redact"`
+}
+
+// maskedCustomer is a response with masked, nested and embedded fields.
+type maskedCustomer struct {
+	maskedBase
+	Name     string            `json:"name"`
+	Email    string            `json:"email" mask:"email"`
+	Card     string            `json:"card" mask:"last4,perm=billing"`
+	PIN      int               `json:"pin" mask:"redact,perm=billing"`
+	Notes    string            `json:"notes,omitempty" mask:"omit,perm=support"`
+	Contacts []*maskedCustomer `json:"contacts,omitempty"`
+	Tags     map[string]maskedBase
+}
+
+// TestMask tests masking JSON responses by caller permission.
+func TestMask(t *testing.T) {
+	customer := maskedCustomer{
+		maskedBase: maskedBase{SSN: "123-45-6789"},
+		Name:       "Alice",
+		Email:      "alice@example.com",
+		Card:       "4111111111114242",
+		PIN:        1234,
+		Notes:      "VIP",
+		Contacts:   []*maskedCustomer{{Name: "Bob", Card: "5555"}},
+		Tags:       map[string]maskedBase{"spouse": {SSN: "987"}},
+	}
+
+	r := New()
+	r.SetUnmasker(func(c *Context, permission string) bool {
+		return permission != "" && strings.Contains(c.Request.Header.Get("X-Permissions"), permission)
+	})
+	r.GET("/customers/1", func(c *Context) error {
+		return c.JSON(http.StatusOK, customer)
+	})
+	GET[Empty, []maskedCustomer](r, "/customers", func(c *Box[Empty, []maskedCustomer]) error {
+		return c.OK([]maskedCustomer{customer})
+	})
+
+	tests := []struct {
+		path, perms string
+		want        []string
+		notWant     []string
+	}{
+		{"/customers/1", "", []string{
+			`"ssn":"[REDACTED]"`, `"name":"Alice"`, `"email":"a***@example.com"`,
+			`"card":"************4242"`, `"pin":"[REDACTED]"`, `"card":"****"`, `"Tags":{"spouse":{"ssn":"[REDACTED]"}}`,
+		}, []string{"notes"}},
+		{"/customers/1", "billing", []string{
+			`"card":"4111111111114242"`, `"pin":1234`, `"card":"5555"`, `"ssn":"[REDACTED]"`,
+		}, []string{"notes"}},
+		{"/customers", "support", []string{`"notes":"VIP"`, `"card":"************4242"`}, nil},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+		req.Header.Set("X-Permissions", tt.perms)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: status = %d", tt.path, w.Code)
+		}
+		body := w.Body.String()
+		for _, s := range tt.want {
+			if !strings.Contains(body, s) {
+				t.Errorf("%s (%q): body %s should contain %s", tt.path, tt.perms, body, s)
+			}
+		}
+		for _, s := range tt.notWant {
+			if strings.Contains(body, s) {
+				t.Errorf("%s (%q): body %s should not contain %s", tt.path, tt.perms, body, s)
+			}
+		}
+	}
+}
+
+// TestMask_UnexportedEmbedded tests masking the promoted fields of
+// unexported embedded structs, which are read through the exported path.
+func TestMask_UnexportedEmbedded(t *testing.T) {
+	type history struct {
+		Previous map[string]maskedBase `json:"previous"`
+		Cards    [1]maskedCard         `json:"cards"`
+	}
+	type account struct {
+		*maskedBase
+		history
+		Name string `json:"name"`
+	}
+
+	c := newContext()
+	w := httptest.NewRecorder()
+	c.Response = w
+	err := c.JSON(http.StatusOK, account{
+		maskedBase: &maskedBase{SSN: "123"},
+		history: history{
+			Previous: map[string]maskedBase{"2024": {SSN: "456"}},
+			Cards:    [1]maskedCard{{Holder: "Alice", Number: "4111"}},
+		},
+		Name: "Alice",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := w.Body.String()
+	for _, s := range []string{`"previous":{"2024":{"ssn":"[REDACTED]"}}`, `"number":"[REDACTED]"`, `"name":"Alice"`} {
+		if !strings.Contains(body, s) {
+			t.Errorf("body %s should contain %s", body, s)
+		}
+	}
+	if strings.Contains(body, "123") || strings.Contains(body, "4111") {
+		t.Errorf("body %s leaks masked values", body)
+	}
+}
+
+// TestRegisterMask tests custom masks and unmasked types.
+func TestRegisterMask(t *testing.T) {
+	RegisterMask("upper", strings.ToUpper)
+	type payload struct {
+		Code string `json:"code" mask:"upper"`
+	}
+
+	c := newContext()
+	w := httptest.NewRecorder()
+	c.Response = w
+	if err := c.JSON(http.StatusOK, payload{Code: "abc"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(w.Body.String()); got != `{"code":"ABC"}` {
+		t.Errorf("body = %s", got)
+	}
+
+	type node struct {
+		Children []node `json:"children"`
+	}
+	if maskPlanOf(reflect.TypeOf(map[string]any{})) != nil || maskPlanOf(reflect.TypeOf(node{})) != nil {
+		t.Error("types without mask tags should have no mask plan")
+	}
+}
+
+// maskedCard is a response masked in every format.
+type maskedCard struct {
+	Holder string `json:"holder" xml:"holder"`
+	Number string `json:"number" xml:"number" mask:"redact"`
+	CVV    int    `json:"cvv" xml:"cvv" mask:"omit"`
+	PIN    int    `json:"pin" xml:"pin" mask:"last4"`
+}
+
+// TestMask_Formats tests masking the responses of every format.
+func TestMask_Formats(t *testing.T) {
+	card := maskedCard{Holder: "Alice", Number: "4111111111111111", CVV: 123, PIN: 98765}
+
+	r := New()
+	r.RegisterRenderer("application/x-card", func(c *Context, status int, data any) error {
+		body, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		return c.Blob(status, "application/x-card", body)
+	})
+	r.GET("/card", func(c *Context) error { return c.Negotiate(http.StatusOK, card) })
+	r.GET("/cards.xml", func(c *Context) error { return c.XML(http.StatusOK, []maskedCard{card}) })
+
+	tests := []struct {
+		path, accept string
+		want         []string
+	}{
+		{"/card", "application/json", []string{`"number":"[REDACTED]"`, `"pin":"*8765"`}},
+		{"/card", "application/xml", []string{"<maskedCard>", "<number>[REDACTED]</number>", "<pin>*8765</pin>"}},
+		{"/card", "text/plain", []string{"[REDACTED]", "*8765"}},
+		{"/card", "application/x-card", []string{`"number":"[REDACTED]"`, `"pin":"*8765"`}},
+		{"/cards.xml", "", []string{"<maskedCard><holder>Alice</holder><number>[REDACTED]</number>"}},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+		req.Header.Set("Accept", tt.accept)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		body := w.Body.String()
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s (%s): status = %d: %s", tt.path, tt.accept, w.Code, body)
+		}
+		for _, s := range append(tt.want, "Alice") {
+			if !strings.Contains(body, s) {
+				t.Errorf("%s (%s): body %s should contain %s", tt.path, tt.accept, body, s)
+			}
+		}
+		for _, s := range []string{"4111111111111111", "123", "98765"} {
+			if strings.Contains(body, s) {
+				t.Errorf("%s (%s): body %s leaks %s", tt.path, tt.accept, body, s)
+			}
+		}
+	}
+}
@@ -0,0 +1,383 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package metering provides usage metering for usage-based billing.
+//
+// The Meter middleware measures the requests of each tenant (count, bytes
+// in and out, execution time), aggregates them in memory per tenant and
+// route, and writes the aggregates in batches to a Sink: a database table
+// (see plugins/database.MeteringSink), a broker topic (see
+// plugins/broker.MeteringSink), or any custom implementation.
+//
+// Example:
+//
+//	meter := metering.New(metering.Config{
+//	    Sink:       database.MeteringSink(db, database.MeteringSinkConfig{}),
+//	    TenantFunc: func(c *fursy.Context) string { return c.GetString("tenant") },
+//	})
+//	router.OnShutdown(meter.Close)
+//	router.Use(meter.Middleware())
+package metering
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// Default values for the Meter.
+const (
+	// DefaultFlushInterval is the default interval between batch writes.
+	DefaultFlushInterval = 10 * time.Second
+
+	// DefaultMaxBatch is the default number of aggregates triggering an
+	// early batch write.
+	DefaultMaxBatch = 1000
+)
+
+// Usage is the usage of a tenant on a route, aggregated over a batch window.
+type Usage struct {
+	// Tenant is the tenant (or API key) the usage is billed to.
+	Tenant string `json:"tenant"`
+
+	// Route is the route pattern (e.g., "/users/:id").
+	Route string `json:"route"`
+
+	// Requests is the number of requests.
+	Requests int64 `json:"requests"`
+
+	// Errors is the number of requests that failed with an error or
+	// a 5xx status.
+	Errors int64 `json:"errors"`
+
+	// BytesIn is the number of request body bytes read.
+	BytesIn int64 `json:"bytes_in"`
+
+	// BytesOut is the number of response body bytes written.
+	BytesOut int64 `json:"bytes_out"`
+
+	// Duration is the total execution time of the requests
+	// (nanoseconds in JSON).
+	Duration time.Duration `json:"duration"`
+
+	// Start is the time of the first request of the window.
+	Start time.Time `json:"start"`
+
+	// End is the time of the last request of the window.
+	End time.Time `json:"end"`
+}
+
+// add merges u into the aggregate.
+func (a *Usage) add(u Usage) {
+	a.Requests += u.Requests
+	a.Errors += u.Errors
+	a.BytesIn += u.BytesIn
+	a.BytesOut += u.BytesOut
+	a.Duration += u.Duration
+	if a.Start.IsZero() || u.Start.Before(a.Start) {
+		a.Start = u.Start
+	}
+	if u.End.After(a.End) {
+		a.End = u.End
+	}
+}
+
+// Sink stores batches of usage aggregates.
+//
+// A batch that fails to be written is merged back into the pending
+// aggregates and written again with the next batch, so a Sink should write
+// a batch atomically (e.g., in a single transaction).
+type Sink interface {
+	// Write stores a batch of usage aggregates.
+	Write(ctx context.Context, batch []Usage) error
+}
+
+// SinkFunc is an adapter to use an ordinary function as a Sink.
+type SinkFunc func(ctx context.Context, batch []Usage) error
+
+// Write calls f(ctx, batch).
+func (f SinkFunc) Write(ctx context.Context, batch []Usage) error {
+	return f(ctx, batch)
+}
+
+// Config defines the configuration of a Meter.
+type Config struct {
+	// Sink stores the batches (required).
+	Sink Sink
+
+	// TenantFunc extracts the tenant (or API key) from the request.
+	// Requests with an empty tenant are not metered.
+	// Required.
+	TenantFunc func(c *fursy.Context) string
+
+	// FlushInterval is the interval between batch writes.
+	// Default: 10 seconds
+	FlushInterval time.Duration
+
+	// MaxBatch is the number of pending aggregates (tenant and route pairs)
+	// triggering a batch write before the interval elapses.
+	// Default: 1000
+	MaxBatch int
+
+	// ErrorHandler is called when a batch write fails.
+	// Default: nil (the batch is retried silently)
+	ErrorHandler func(err error)
+
+	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
+	// Default: nil (middleware always executes)
+	Skipper fursy.Skipper
+}
+
+// usageKey identifies an aggregate.
+type usageKey struct {
+	tenant string
+	route  string
+}
+
+// Meter aggregates usage and writes it in batches to a Sink.
+//
+// Meter is safe for concurrent use.
+type Meter struct {
+	config Config
+
+	mu      sync.Mutex
+	pending map[usageKey]*Usage
+
+	writeMu sync.Mutex // Serializes batch writes.
+	full    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+// New creates a Meter and starts writing batches in the background.
+// Call Close on shutdown to write the last batch.
+//
+// Example:
+//
+//	meter := metering.New(metering.Config{
+//	    Sink: metering.SinkFunc(func(ctx context.Context, batch []metering.Usage) error {
+//	        return billing.Report(ctx, batch)
+//	    }),
+//	    TenantFunc:    func(c *fursy.Context) string { return c.Request.Header.Get("X-API-Key") },
+//	    FlushInterval: time.Minute,
+//	})
+//	defer meter.Close()
+func New(config Config) *Meter {
+	// Validate config.
+	if config.Sink == nil {
+		panic("metering: sink is required")
+	}
+	if config.TenantFunc == nil {
+		panic("metering: tenant function is required")
+	}
+
+	// Set defaults.
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = DefaultFlushInterval
+	}
+	if config.MaxBatch <= 0 {
+		config.MaxBatch = DefaultMaxBatch
+	}
+
+	m := &Meter{
+		config:  config,
+		pending: make(map[usageKey]*Usage),
+		full:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go m.run()
+	return m
+}
+
+// Middleware returns the middleware metering the requests.
+func (m *Meter) Middleware() fursy.HandlerFunc {
+	return func(c *fursy.Context) error {
+		// Skip if Skipper returns true.
+		if m.config.Skipper.ShouldSkip(c) {
+			return c.Next()
+		}
+
+		tenant := m.config.TenantFunc(c)
+		if tenant == "" {
+			return c.Next()
+		}
+
+		start := time.Now()
+
+		body := &countingBody{ReadCloser: c.Request.Body}
+		if c.Request.Body != nil && c.Request.Body != http.NoBody {
+			c.Request.Body = body
+		}
+		mw := &meterResponseWriter{ResponseWriter: c.Response, statusCode: http.StatusOK}
+		c.Response = mw
+
+		err := c.Next()
+
+		end := time.Now()
+
+		usage := Usage{
+			Tenant:   tenant,
+			Route:    c.Route(),
+			Requests: 1,
+			BytesIn:  body.n,
+			BytesOut: mw.bytesWritten,
+			Duration: end.Sub(start),
+			Start:    start,
+			End:      end,
+		}
+		if err != nil || mw.statusCode >= 500 {
+			usage.Errors = 1
+		}
+		m.Record(usage)
+		return err
+	}
+}
+
+// Record adds usage to the pending aggregates, e.g. to meter work done
+// outside of requests (background jobs, WebSocket messages).
+func (m *Meter) Record(usage Usage) {
+	key := usageKey{tenant: usage.Tenant, route: usage.Route}
+
+	m.mu.Lock()
+	m.merge(key, usage)
+	full := len(m.pending) >= m.config.MaxBatch
+	m.mu.Unlock()
+
+	if full {
+		select {
+		case m.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// merge merges usage into the aggregate of key; m.mu must be held.
+func (m *Meter) merge(key usageKey, usage Usage) {
+	if a, ok := m.pending[key]; ok {
+		a.add(usage)
+		return
+	}
+	m.pending[key] = &usage
+}
+
+// Flush writes the pending aggregates to the sink. On failure they are
+// kept pending and the error is returned.
+func (m *Meter) Flush(ctx context.Context) error {
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+
+	m.mu.Lock()
+	if len(m.pending) == 0 {
+		m.mu.Unlock()
+		return nil
+	}
+	batch := make([]Usage, 0, len(m.pending))
+	for _, u := range m.pending {
+		batch = append(batch, *u)
+	}
+	clear(m.pending)
+	m.mu.Unlock()
+
+	err := m.config.Sink.Write(ctx, batch)
+	if err != nil {
+		// Keep the batch for the next write.
+		m.mu.Lock()
+		for _, u := range batch {
+			m.merge(usageKey{tenant: u.Tenant, route: u.Route}, u)
+		}
+		m.mu.Unlock()
+	}
+	return err
+}
+
+// Close stops the background writes and writes the pending aggregates.
+// Usage recorded after Close is not written. It is safe to call Close
+// more than once.
+func (m *Meter) Close() {
+	m.once.Do(func() {
+		close(m.stop)
+		<-m.done
+		m.flush()
+	})
+}
+
+// run writes a batch every FlushInterval or when MaxBatch is reached.
+func (m *Meter) run() {
+	defer close(m.done)
+
+	ticker := time.NewTicker(m.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+		case <-m.full:
+		}
+		m.flush()
+	}
+}
+
+// flush writes the pending aggregates, reporting failures to the ErrorHandler.
+func (m *Meter) flush() {
+	ctx, cancel := context.WithTimeout(context.Background(), m.config.FlushInterval)
+	defer cancel()
+
+	if err := m.Flush(ctx); err != nil && m.config.ErrorHandler != nil {
+		m.config.ErrorHandler(err)
+	}
+}
+
+// countingBody counts the bytes read from a request body.
+type countingBody struct {
+	io.ReadCloser
+	n int64
+}
+
+// Read implements io.Reader.
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+// meterResponseWriter wraps http.ResponseWriter to capture the status code and bytes written.
+type meterResponseWriter struct {
+	http.ResponseWriter
+	statusCode   int
+	bytesWritten int64
+	wroteHeader  bool
+}
+
+// WriteHeader captures the status code and calls the underlying WriteHeader.
+func (w *meterResponseWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.statusCode = code
+		w.wroteHeader = true
+		w.ResponseWriter.WriteHeader(code)
+	}
+}
+
+// Write captures bytes written and calls the underlying Write.
+func (w *meterResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytesWritten += int64(n)
+	return n, err
+}
+
+// Unwrap returns the underlying ResponseWriter.
+func (w *meterResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
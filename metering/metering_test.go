@@ -0,0 +1,180 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package metering
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// recordingSink records the written batches and fails while failing is set.
+type recordingSink struct {
+	mu      sync.Mutex
+	batches [][]Usage
+	failing bool
+}
+
+func (s *recordingSink) Write(_ context.Context, batch []Usage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failing {
+		return errors.New("sink unavailable")
+	}
+	s.batches = append(s.batches, batch)
+	return nil
+}
+
+func (s *recordingSink) setFailing(failing bool) {
+	s.mu.Lock()
+	s.failing = failing
+	s.mu.Unlock()
+}
+
+// usage returns the written usage by tenant and route.
+func (s *recordingSink) usage() map[usageKey]Usage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	total := make(map[usageKey]Usage)
+	for _, batch := range s.batches {
+		for _, u := range batch {
+			key := usageKey{tenant: u.Tenant, route: u.Route}
+			a := total[key]
+			a.add(u)
+			total[key] = a
+		}
+	}
+	return total
+}
+
+func tenant(c *fursy.Context) string {
+	return c.Request.Header.Get("X-Tenant")
+}
+
+func newMeteredRouter(m *Meter) *fursy.Router {
+	r := fursy.New()
+	r.Use(m.Middleware())
+	r.POST("/items", func(c *fursy.Context) error {
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			return err
+		}
+		return c.String(http.StatusCreated, "created")
+	})
+	r.GET("/fail", func(*fursy.Context) error {
+		return errors.New("boom")
+	})
+	return r
+}
+
+func serveTenant(r *fursy.Router, method, path, tenant, body string) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if tenant != "" {
+		req.Header.Set("X-Tenant", tenant)
+	}
+	r.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+func TestMeter_Middleware(t *testing.T) {
+	sink := &recordingSink{}
+	m := New(Config{Sink: sink, TenantFunc: tenant, FlushInterval: time.Hour})
+	r := newMeteredRouter(m)
+
+	serveTenant(r, http.MethodPost, "/items", "acme", "12345")
+	serveTenant(r, http.MethodPost, "/items", "acme", "123")
+	serveTenant(r, http.MethodGet, "/fail", "acme", "")
+	serveTenant(r, http.MethodPost, "/items", "globex", "")
+	serveTenant(r, http.MethodPost, "/items", "", "anonymous")
+	m.Close()
+
+	usage := sink.usage()
+	if len(usage) != 3 {
+		t.Fatalf("expected 3 aggregates, got %+v", usage)
+	}
+	items := usage[usageKey{tenant: "acme", route: "/items"}]
+	if items.Requests != 2 || items.Errors != 0 || items.BytesIn != 8 || items.BytesOut != int64(2*len("created")) {
+		t.Errorf("unexpected acme /items usage: %+v", items)
+	}
+	if items.Duration <= 0 || items.Start.IsZero() || items.End.Before(items.Start) {
+		t.Errorf("unexpected acme /items timing: %+v", items)
+	}
+	if fail := usage[usageKey{tenant: "acme", route: "/fail"}]; fail.Requests != 1 || fail.Errors != 1 {
+		t.Errorf("unexpected acme /fail usage: %+v", fail)
+	}
+	if globex := usage[usageKey{tenant: "globex", route: "/items"}]; globex.Requests != 1 {
+		t.Errorf("unexpected globex usage: %+v", globex)
+	}
+}
+
+func TestMeter_MaxBatch(t *testing.T) {
+	sink := &recordingSink{}
+	m := New(Config{Sink: sink, TenantFunc: tenant, FlushInterval: time.Hour, MaxBatch: 2})
+	defer m.Close()
+
+	m.Record(Usage{Tenant: "a", Requests: 1})
+	m.Record(Usage{Tenant: "b", Requests: 1})
+
+	deadline := time.Now().Add(time.Second)
+	for len(sink.usage()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected an early batch write")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestMeter_FlushRetry(t *testing.T) {
+	sink := &recordingSink{failing: true}
+	var errs []error
+	m := New(Config{
+		Sink:          sink,
+		TenantFunc:    tenant,
+		FlushInterval: time.Hour,
+		ErrorHandler:  func(err error) { errs = append(errs, err) },
+	})
+
+	m.Record(Usage{Tenant: "acme", Requests: 1, BytesOut: 10})
+	if err := m.Flush(context.Background()); err == nil {
+		t.Fatal("expected flush error")
+	}
+
+	// The failed batch is merged with the next usage.
+	m.Record(Usage{Tenant: "acme", Requests: 2, BytesOut: 5})
+	sink.setFailing(false)
+	m.Close()
+
+	if len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if got := sink.usage()[usageKey{tenant: "acme"}]; got.Requests != 3 || got.BytesOut != 15 {
+		t.Errorf("unexpected usage: %+v", got)
+	}
+	if len(sink.batches) != 1 {
+		t.Errorf("expected a single batch, got %d", len(sink.batches))
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	for name, config := range map[string]Config{
+		"no sink":   {TenantFunc: tenant},
+		"no tenant": {Sink: &recordingSink{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			New(config)
+		})
+	}
+}
@@ -6,9 +6,12 @@
 package middleware
 
 import (
+	"crypto/subtle"
 	"encoding/base64"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/coregx/fursy"
 )
@@ -34,10 +37,25 @@ type BasicAuthConfig struct {
 	Realm string
 
 	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
 	// Default: nil (middleware always executes)
-	Skipper func(c *fursy.Context) bool
+	Skipper fursy.Skipper
+
+	// MaxFailures locks a username after this many consecutive failed
+	// attempts. Locked usernames get 429 Too Many Requests with a
+	// Retry-After header, even with the correct password.
+	// Default: 0 (no lockout)
+	MaxFailures int
+
+	// LockoutDuration is how long a username stays locked. Failures older
+	// than LockoutDuration are forgotten.
+	// Default: 15 minutes
+	LockoutDuration time.Duration
 }
 
+// DefaultBasicAuthLockoutDuration is the default lockout duration of BasicAuth.
+const DefaultBasicAuthLockoutDuration = 15 * time.Minute
+
 // BasicAuth returns a middleware that provides HTTP Basic Authentication.
 //
 // The middleware:
@@ -94,10 +112,18 @@ func BasicAuthWithConfig(config BasicAuthConfig) fursy.HandlerFunc {
 	if config.Realm == "" {
 		config.Realm = DefaultRealm
 	}
+	if config.LockoutDuration <= 0 {
+		config.LockoutDuration = DefaultBasicAuthLockoutDuration
+	}
+
+	var lockout *failureStore
+	if config.MaxFailures > 0 {
+		lockout = newAuthLockout(config.MaxFailures, config.LockoutDuration)
+	}
 
 	return func(c *fursy.Context) error {
 		// Skip if Skipper returns true.
-		if config.Skipper != nil && config.Skipper(c) {
+		if config.Skipper.ShouldSkip(c) {
 			return c.Next()
 		}
 
@@ -105,10 +131,25 @@ func BasicAuthWithConfig(config BasicAuthConfig) fursy.HandlerFunc {
 		auth := c.Request.Header.Get("Authorization")
 		username, password := parseBasicAuth(auth)
 
+		// Reject locked usernames before checking the password. The attempt
+		// is counted as a failure before it is validated, so that concurrent
+		// guesses cannot all pass the lockout check.
+		if lockout != nil && username != "" {
+			if attempt, ok := lockout.reserve(username); !ok {
+				retryAfter := attempt.state.retryAfter
+				c.SetHeader("Retry-After", strconv.Itoa(int(retryAfter.Round(time.Second)/time.Second)))
+				return c.Problem(fursy.TooManyRequests("too many failed login attempts"))
+			}
+		}
+
 		// Validate credentials.
 		if username != "" || password != "" {
 			identity, err := config.Validator(c, username, password)
 			if err == nil && identity != nil {
+				if lockout != nil {
+					lockout.reset(username)
+				}
+
 				// Store user identity in context.
 				c.Set(UserContextKey, identity)
 				return c.Next()
@@ -148,6 +189,9 @@ func parseBasicAuth(auth string) (username, password string) {
 
 // BasicAuthAccounts creates a validator that checks credentials against a map.
 // This is a convenience function for simple username:password authentication.
+// Passwords are compared in constant time.
+//
+// Prefer BasicAuthHashedAccounts to avoid keeping plaintext passwords.
 //
 // Example:
 //
@@ -158,9 +202,54 @@ func parseBasicAuth(auth string) (username, password string) {
 //	router.Use(middleware.BasicAuth(middleware.BasicAuthAccounts(accounts)))
 func BasicAuthAccounts(accounts map[string]string) ValidatorFunc {
 	return func(_ *fursy.Context, username, password string) (interface{}, error) {
-		if expectedPassword, ok := accounts[username]; ok && expectedPassword == password {
+		expectedPassword, ok := accounts[username]
+		if ok && subtle.ConstantTimeCompare([]byte(password), []byte(expectedPassword)) == 1 {
 			return username, nil
 		}
 		return nil, fursy.ErrUnauthorized
 	}
 }
+
+// BasicAuthSecret creates a validator for a single account whose password
+// comes from src. Any of the source's secrets (current or previous) is
+// accepted, so the password can be rotated without locking out clients
+// that have not been updated yet.
+//
+// Example:
+//
+//	router.Use(middleware.BasicAuth(middleware.BasicAuthSecret(
+//	    "metrics", fursy.FileSecret("/run/secrets/metrics-password"),
+//	)))
+func BasicAuthSecret(username string, src fursy.SecretSource) ValidatorFunc {
+	return func(_ *fursy.Context, user, password string) (interface{}, error) {
+		if subtle.ConstantTimeCompare([]byte(user), []byte(username)) != 1 {
+			return nil, fursy.ErrUnauthorized
+		}
+
+		secrets, err := src.Secrets()
+		if err != nil {
+			return nil, err
+		}
+		for _, secret := range secrets {
+			// Empty secrets of custom sources never match.
+			if len(secret) > 0 && subtle.ConstantTimeCompare([]byte(password), secret) == 1 {
+				return username, nil
+			}
+		}
+		return nil, fursy.ErrUnauthorized
+	}
+}
+
+// maxAuthLockoutEntries bounds the number of usernames tracked by BasicAuth.
+const maxAuthLockoutEntries = 10000
+
+// newAuthLockout creates the store locking usernames for duration after
+// every maxFailures consecutive failures.
+func newAuthLockout(maxFailures int, duration time.Duration) *failureStore {
+	return newFailureStore(maxAuthLockoutEntries, duration, func(failures int) time.Duration {
+		if failures%maxFailures == 0 {
+			return duration
+		}
+		return 0
+	})
+}
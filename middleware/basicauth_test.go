@@ -9,7 +9,11 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/coregx/fursy"
 )
@@ -450,3 +454,216 @@ func TestBasicAuth_MultipleRequests(t *testing.T) {
 		t.Errorf("expected validator called 3 times, got %d", callCount)
 	}
 }
+
+// TestBasicAuthSecret tests a single account with a rotated password.
+func TestBasicAuthSecret(t *testing.T) {
+	r := fursy.New()
+	r.Use(BasicAuth(BasicAuthSecret("metrics", fursy.StaticSecrets([]byte("new"), []byte("old")))))
+	r.GET("/metrics", func(c *fursy.Context) error {
+		return c.String(http.StatusOK, c.GetString(UserContextKey))
+	})
+
+	tests := []struct {
+		user, password string
+		want           int
+	}{
+		{"metrics", "new", http.StatusOK},
+		{"metrics", "old", http.StatusOK},
+		{"metrics", "wrong", http.StatusUnauthorized},
+		{"admin", "new", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody)
+		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(tt.user+":"+tt.password)))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != tt.want {
+			t.Errorf("%s:%s: expected status %d, got %d", tt.user, tt.password, tt.want, w.Code)
+		}
+	}
+}
+
+// basicAuthRequest sends a request with Basic credentials.
+func basicAuthRequest(r *fursy.Router, user, password string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+password)))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// TestBasicAuth_Lockout tests locking a username after repeated failures.
+func TestBasicAuth_Lockout(t *testing.T) {
+	r := fursy.New()
+	r.Use(BasicAuthWithConfig(BasicAuthConfig{
+		Validator:       BasicAuthAccounts(map[string]string{"admin": "secret", "user": "pass"}),
+		MaxFailures:     3,
+		LockoutDuration: time.Minute,
+	}))
+	r.GET("/test", func(c *fursy.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+
+	// A success resets the failure count.
+	basicAuthRequest(r, "admin", "wrong")
+	basicAuthRequest(r, "admin", "wrong")
+	if w := basicAuthRequest(r, "admin", "secret"); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	for range 3 {
+		if w := basicAuthRequest(r, "admin", "wrong"); w.Code != http.StatusUnauthorized {
+			t.Fatalf("expected status 401, got %d", w.Code)
+		}
+	}
+
+	// Locked, even with the correct password.
+	w := basicAuthRequest(r, "admin", "secret")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", w.Code)
+	}
+	if retry := w.Header().Get("Retry-After"); retry != "60" {
+		t.Errorf("expected Retry-After 60, got %q", retry)
+	}
+
+	// Other usernames are not affected.
+	if w := basicAuthRequest(r, "user", "pass"); w.Code != http.StatusOK {
+		t.Errorf("expected status 200 for other user, got %d", w.Code)
+	}
+}
+
+// TestBasicAuthHashedAccounts tests hashed credentials.
+func TestBasicAuthHashedAccounts(t *testing.T) {
+	hash, err := HashPassword("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := fursy.New()
+	r.Use(BasicAuth(BasicAuthHashedAccounts(map[string]string{"admin": hash}, VerifyPassword)))
+	r.GET("/test", func(c *fursy.Context) error {
+		return c.String(http.StatusOK, c.GetString(UserContextKey))
+	})
+
+	if w := basicAuthRequest(r, "admin", "secret"); w.Code != http.StatusOK || w.Body.String() != "admin" {
+		t.Errorf("expected 200 admin, got %d %q", w.Code, w.Body.String())
+	}
+	if w := basicAuthRequest(r, "admin", "wrong"); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
+	}
+	if w := basicAuthRequest(r, "nobody", "secret"); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 for unknown user, got %d", w.Code)
+	}
+}
+
+// TestBasicAuthHashedAccounts_UnknownUser tests that unknown usernames
+// are verified against a hash, so they cannot be enumerated by timing.
+func TestBasicAuthHashedAccounts_UnknownUser(t *testing.T) {
+	var verified []string
+	verify := func(hash, password string) bool {
+		verified = append(verified, hash)
+		return hash == "hash-"+password
+	}
+	validator := BasicAuthHashedAccounts(map[string]string{"bob": "hash-b", "alice": "hash-a"}, verify)
+
+	if _, err := validator(nil, "nobody", "a"); !errors.Is(err, fursy.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for unknown user, got %v", err)
+	}
+	if len(verified) != 1 || verified[0] != "hash-a" {
+		t.Errorf("unknown user should be verified against the first account hash, verified %v", verified)
+	}
+}
+
+// TestBasicAuthHashed_UnknownUser tests that passwords of unknown
+// usernames are verified, against the first hash the provider returned.
+func TestBasicAuthHashed_UnknownUser(t *testing.T) {
+	var verified []string
+	verify := func(hash, password string) bool {
+		verified = append(verified, hash)
+		return hash == "hash-"+password
+	}
+	validator := BasicAuthHashed(func(_ *fursy.Context, username string) (string, error) {
+		if username != "bob" {
+			return "", fursy.ErrUnauthorized
+		}
+		return "hash-b", nil
+	}, verify)
+
+	for _, username := range []string{"nobody", "bob", "nobody"} {
+		validator(nil, username, "x")
+	}
+	want := []string{unknownUserHash, "hash-b", "hash-b"}
+	if !slices.Equal(verified, want) {
+		t.Errorf("verified %v, want %v", verified, want)
+	}
+}
+
+// TestBasicAuth_LockoutSurvivesFlood tests that flooding the lockout store
+// with new usernames does not unlock a locked one.
+func TestBasicAuth_LockoutSurvivesFlood(t *testing.T) {
+	lockout := newAuthLockout(2, time.Minute)
+	lockout.reserve("victim")
+	lockout.reserve("victim")
+
+	for i := range maxAuthLockoutEntries * 2 {
+		lockout.reserve("spray-" + strconv.Itoa(i))
+	}
+	if lockout.state("victim").retryAfter <= 0 {
+		t.Error("locked username was evicted by new usernames")
+	}
+	if n := len(lockout.entries); n > maxAuthLockoutEntries {
+		t.Errorf("store holds %d usernames, want at most %d", n, maxAuthLockoutEntries)
+	}
+}
+
+// TestBasicAuthHashed_ProviderError tests provider errors.
+func TestBasicAuthHashed_ProviderError(t *testing.T) {
+	validator := BasicAuthHashed(func(*fursy.Context, string) (string, error) {
+		return "", errors.New("db down")
+	}, VerifyPassword)
+
+	if _, err := validator(nil, "admin", "secret"); err == nil {
+		t.Error("expected provider error")
+	}
+}
+
+// TestVerifyPassword tests PBKDF2 hashing and verification.
+func TestVerifyPassword(t *testing.T) {
+	hash, err := HashPassword("s3cret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "$pbkdf2-sha256$600000$") {
+		t.Errorf("unexpected hash format %q", hash)
+	}
+
+	other, _ := HashPassword("s3cret")
+	if other == hash {
+		t.Error("expected random salt to produce different hashes")
+	}
+
+	tests := []struct {
+		name     string
+		hash     string
+		password string
+		want     bool
+	}{
+		{"match", hash, "s3cret", true},
+		{"mismatch", hash, "wrong", false},
+		{"empty hash", "", "s3cret", false},
+		{"bcrypt hash", "$2a$10$abcdefghijklmnopqrstuv", "s3cret", false},
+		{"bad iterations", "$pbkdf2-sha256$x$c2FsdA$a2V5", "s3cret", false},
+		{"bad salt", "$pbkdf2-sha256$1$!!$a2V5", "s3cret", false},
+		{"missing key", "$pbkdf2-sha256$1$c2FsdA", "s3cret", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyPassword(tt.hash, tt.password); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
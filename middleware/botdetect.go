@@ -0,0 +1,320 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package middleware provides bot detection middleware.
+package middleware
+
+import (
+	"strings"
+
+	"github.com/coregx/fursy"
+)
+
+// BotDecisionKey is the context key for the BotDecision of the request.
+const BotDecisionKey = "bot_decision"
+
+// BotAction is the action taken for a request by BotDetection.
+// Actions are ordered by severity: when several detectors match,
+// the most severe action wins.
+type BotAction int
+
+// Bot actions.
+const (
+	// BotAllow lets the request through untouched.
+	BotAllow BotAction = iota
+
+	// BotTag lets the request through and records the decision in the
+	// context for handlers and logs.
+	BotTag
+
+	// BotThrottle passes the request through BotDetectionConfig.Throttle.
+	BotThrottle
+
+	// BotBlock rejects the request with BotDetectionConfig.BlockHandler.
+	BotBlock
+)
+
+// String returns the lowercase name of the action.
+func (a BotAction) String() string {
+	switch a {
+	case BotAllow:
+		return "allow"
+	case BotTag:
+		return "tag"
+	case BotThrottle:
+		return "throttle"
+	case BotBlock:
+		return "block"
+	default:
+		return "unknown"
+	}
+}
+
+// BotDecision is the result of a bot detector.
+type BotDecision struct {
+	// Action is the action taken for the request.
+	Action BotAction
+
+	// Detector names the detector that made the decision
+	// (e.g., "user-agent", "ip-reputation", "ja3").
+	Detector string
+
+	// Reason describes why the detector matched (e.g., the matched pattern).
+	Reason string
+}
+
+// BotDetector inspects a request and returns a decision.
+// Detectors return a zero BotDecision (BotAllow) when they do not match.
+type BotDetector func(c *fursy.Context) BotDecision
+
+// DefaultBotUserAgents are the User-Agent substrings matched by
+// UserAgentDetector when no patterns are given.
+var DefaultBotUserAgents = []string{
+	"bot", "crawler", "spider", "scrapy", "curl/", "wget/",
+	"python-requests", "python-urllib", "go-http-client", "java/",
+	"libwww-perl", "httpclient", "headlesschrome", "phantomjs",
+}
+
+// BotDetectionConfig defines the configuration for the BotDetection middleware.
+type BotDetectionConfig struct {
+	// Detectors inspect each request. All detectors run and the most
+	// severe decision wins (detection stops early on BotBlock).
+	// Required.
+	Detectors []BotDetector
+
+	// Throttle is the middleware applied to requests with BotThrottle.
+	// Default: RateLimit of 1 request/second (burst 5) per client IP
+	Throttle fursy.HandlerFunc
+
+	// BlockHandler is called for requests with BotBlock.
+	// Default: 403 Forbidden problem
+	BlockHandler func(c *fursy.Context, decision BotDecision) error
+
+	// OnDecision is called for every request that is not allowed outright
+	// (tagged, throttled or blocked), e.g. for logging or metrics.
+	// Default: nil
+	OnDecision func(c *fursy.Context, decision BotDecision)
+
+	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
+	// Default: nil (middleware always executes)
+	Skipper fursy.Skipper
+}
+
+// BotDetection returns a middleware that runs the given detectors on
+// every request and tags, throttles or blocks it.
+//
+// The decision is stored in the context (see Bot) and logged by Logger.
+//
+// Example:
+//
+//	router.Use(middleware.BotDetection(
+//	    middleware.UserAgentDetector(middleware.BotTag),
+//	    middleware.IPReputationDetector(func(c *fursy.Context, ip string) (middleware.BotAction, string) {
+//	        if blocklist.Contains(ip) {
+//	            return middleware.BotBlock, "blocklist"
+//	        }
+//	        return middleware.BotAllow, ""
+//	    }),
+//	))
+func BotDetection(detectors ...BotDetector) fursy.HandlerFunc {
+	return BotDetectionWithConfig(BotDetectionConfig{
+		Detectors: detectors,
+	})
+}
+
+// BotDetectionWithConfig returns a middleware with custom configuration.
+//
+// Example:
+//
+//	router.Use(middleware.BotDetectionWithConfig(middleware.BotDetectionConfig{
+//	    Detectors: []middleware.BotDetector{
+//	        middleware.UserAgentDetector(middleware.BotThrottle),
+//	        middleware.JA3Detector(middleware.JA3FromHeader("X-JA3-Hash"), map[string]middleware.BotAction{
+//	            "e7d705a3286e19ea42f587b344ee6865": middleware.BotBlock,
+//	        }),
+//	    },
+//	    Throttle: middleware.RateLimit(0.5, 2),
+//	    OnDecision: func(c *fursy.Context, d middleware.BotDecision) {
+//	        botRequests.WithLabelValues(d.Action.String(), d.Detector).Inc()
+//	    },
+//	}))
+func BotDetectionWithConfig(config BotDetectionConfig) fursy.HandlerFunc {
+	// Validate config.
+	if len(config.Detectors) == 0 {
+		panic("fursy/middleware: BotDetection requires at least one detector")
+	}
+
+	// Set defaults.
+	if config.Throttle == nil {
+		config.Throttle = RateLimitWithConfig(RateLimitConfig{
+			Rate:  1,
+			Burst: 5,
+			KeyFunc: func(c *fursy.Context) string {
+				return c.ClientIP()
+			},
+		})
+	}
+	if config.BlockHandler == nil {
+		config.BlockHandler = defaultBotBlockHandler
+	}
+
+	return func(c *fursy.Context) error {
+		// Skip if Skipper returns true.
+		if config.Skipper.ShouldSkip(c) {
+			return c.Next()
+		}
+
+		decision := detectBot(c, config.Detectors)
+		if decision.Action == BotAllow {
+			return c.Next()
+		}
+
+		c.Set(BotDecisionKey, decision)
+		if config.OnDecision != nil {
+			config.OnDecision(c, decision)
+		}
+
+		switch decision.Action {
+		case BotBlock:
+			return config.BlockHandler(c, decision)
+		case BotThrottle:
+			return config.Throttle(c)
+		default:
+			return c.Next()
+		}
+	}
+}
+
+// detectBot runs detectors and returns the most severe decision.
+func detectBot(c *fursy.Context, detectors []BotDetector) BotDecision {
+	var decision BotDecision
+	for _, detect := range detectors {
+		d := detect(c)
+		if d.Action > decision.Action {
+			decision = d
+		}
+		if decision.Action == BotBlock {
+			break
+		}
+	}
+	return decision
+}
+
+// defaultBotBlockHandler sends a 403 Forbidden problem.
+func defaultBotBlockHandler(c *fursy.Context, _ BotDecision) error {
+	return c.Problem(fursy.Forbidden("Automated requests are not allowed"))
+}
+
+// Bot returns the BotDecision of the request. It is BotAllow if the
+// request was not inspected by BotDetection or no detector matched.
+//
+// Example:
+//
+//	if middleware.Bot(c).Action == middleware.BotTag {
+//	    // Serve a cached page to crawlers.
+//	}
+func Bot(c *fursy.Context) BotDecision {
+	decision, _ := c.Get(BotDecisionKey).(BotDecision)
+	return decision
+}
+
+// UserAgentDetector returns a detector that applies action to requests
+// whose User-Agent contains one of patterns (case-insensitive) or is empty.
+// If no patterns are given, DefaultBotUserAgents is used.
+//
+// Example:
+//
+//	middleware.UserAgentDetector(middleware.BotBlock, "sqlmap", "nikto")
+func UserAgentDetector(action BotAction, patterns ...string) BotDetector {
+	if len(patterns) == 0 {
+		patterns = DefaultBotUserAgents
+	}
+	lower := make([]string, len(patterns))
+	for i, p := range patterns {
+		lower[i] = strings.ToLower(p)
+	}
+
+	return func(c *fursy.Context) BotDecision {
+		ua := strings.ToLower(c.Request.UserAgent())
+		if ua == "" {
+			return BotDecision{Action: action, Detector: "user-agent", Reason: "missing user agent"}
+		}
+		for _, p := range lower {
+			if strings.Contains(ua, p) {
+				return BotDecision{Action: action, Detector: "user-agent", Reason: p}
+			}
+		}
+		return BotDecision{}
+	}
+}
+
+// IPReputationDetector returns a detector that asks lookup about the
+// client IP (see fursy.Context.ClientIP). lookup returns the action and
+// a reason, e.g. the name of the list or service that flagged the IP.
+//
+// lookup is called on every request; cache results of remote services.
+//
+// Example:
+//
+//	middleware.IPReputationDetector(func(c *fursy.Context, ip string) (middleware.BotAction, string) {
+//	    if score := reputation.Score(ip); score > 80 {
+//	        return middleware.BotBlock, "score " + strconv.Itoa(score)
+//	    }
+//	    return middleware.BotAllow, ""
+//	})
+func IPReputationDetector(lookup func(c *fursy.Context, ip string) (BotAction, string)) BotDetector {
+	if lookup == nil {
+		panic("fursy/middleware: IPReputationDetector lookup cannot be nil")
+	}
+
+	return func(c *fursy.Context) BotDecision {
+		action, reason := lookup(c, c.ClientIP())
+		if action == BotAllow {
+			return BotDecision{}
+		}
+		return BotDecision{Action: action, Detector: "ip-reputation", Reason: reason}
+	}
+}
+
+// JA3Detector returns a detector that looks up the JA3 TLS fingerprint
+// of the request, obtained with fingerprint, in fingerprints.
+// Requests without a fingerprint are allowed.
+//
+// Go's crypto/tls does not expose the raw ClientHello, so the fingerprint
+// usually comes from the TLS-terminating proxy (see JA3FromHeader).
+//
+// Example:
+//
+//	middleware.JA3Detector(middleware.JA3FromHeader("Cf-Ja3-Hash"), map[string]middleware.BotAction{
+//	    "e7d705a3286e19ea42f587b344ee6865": middleware.BotBlock,
+//	})
+func JA3Detector(fingerprint func(c *fursy.Context) string, fingerprints map[string]BotAction) BotDetector {
+	if fingerprint == nil {
+		panic("fursy/middleware: JA3Detector fingerprint cannot be nil")
+	}
+
+	return func(c *fursy.Context) BotDecision {
+		hash := strings.ToLower(fingerprint(c))
+		if hash == "" {
+			return BotDecision{}
+		}
+		action, ok := fingerprints[hash]
+		if !ok || action == BotAllow {
+			return BotDecision{}
+		}
+		return BotDecision{Action: action, Detector: "ja3", Reason: hash}
+	}
+}
+
+// JA3FromHeader returns a fingerprint function reading the JA3 hash from
+// the request header name, as set by a TLS-terminating proxy or CDN.
+//
+// Only use it when the proxy overwrites the header: otherwise clients
+// can send any fingerprint they like.
+func JA3FromHeader(name string) func(c *fursy.Context) string {
+	return func(c *fursy.Context) string {
+		return strings.TrimSpace(c.Request.Header.Get(name))
+	}
+}
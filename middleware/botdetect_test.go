@@ -0,0 +1,176 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/coregx/fursy"
+)
+
+// botTestRouter returns a router with the given middleware that
+// echoes the bot decision.
+func botTestRouter(mw fursy.HandlerFunc) *fursy.Router {
+	r := fursy.New()
+	r.Use(mw)
+	r.GET("/", func(c *fursy.Context) error {
+		d := Bot(c)
+		return c.String(http.StatusOK, d.Action.String()+":"+d.Detector+":"+d.Reason)
+	})
+	return r
+}
+
+func TestBotDetection_UserAgent(t *testing.T) {
+	r := botTestRouter(BotDetection(UserAgentDetector(BotTag)))
+
+	tests := []struct {
+		name string
+		ua   string
+		want string
+	}{
+		{"browser", "Mozilla/5.0 (X11; Linux x86_64) Firefox/130.0", "allow::"},
+		{"curl", "curl/8.5.0", "tag:user-agent:curl/"},
+		{"crawler case-insensitive", "Googlebot/2.1", "tag:user-agent:bot"},
+		{"missing", "", "tag:user-agent:missing user agent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			req.Header.Set("User-Agent", tt.ua)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK || w.Body.String() != tt.want {
+				t.Errorf("expected 200 %q, got %d %q", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestBotDetection_Block(t *testing.T) {
+	var decisions []BotDecision
+	r := botTestRouter(BotDetectionWithConfig(BotDetectionConfig{
+		Detectors: []BotDetector{
+			UserAgentDetector(BotTag),
+			IPReputationDetector(func(_ *fursy.Context, ip string) (BotAction, string) {
+				if ip == "203.0.113.7" {
+					return BotBlock, "blocklist"
+				}
+				return BotAllow, ""
+			}),
+		},
+		OnDecision: func(_ *fursy.Context, d BotDecision) {
+			decisions = append(decisions, d)
+		},
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.RemoteAddr = "203.0.113.7:1234"
+	req.Header.Set("User-Agent", "curl/8.5.0")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", w.Code)
+	}
+	want := BotDecision{Action: BotBlock, Detector: "ip-reputation", Reason: "blocklist"}
+	if len(decisions) != 1 || decisions[0] != want {
+		t.Errorf("expected OnDecision with %+v, got %+v", want, decisions)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.RemoteAddr = "198.51.100.1:1234"
+	req.Header.Set("User-Agent", "Mozilla/5.0")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || len(decisions) != 1 {
+		t.Errorf("expected allowed request without decision, got %d (%d decisions)", w.Code, len(decisions))
+	}
+}
+
+func TestBotDetection_Throttle(t *testing.T) {
+	r := botTestRouter(BotDetectionWithConfig(BotDetectionConfig{
+		Detectors: []BotDetector{UserAgentDetector(BotThrottle, "scraper")},
+		Throttle:  RateLimit(0.001, 1),
+	}))
+
+	codes := make([]int, 0, 3)
+	for _, ua := range []string{"scraper/1.0", "scraper/1.0", "Mozilla/5.0"} {
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		req.Header.Set("User-Agent", ua)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		codes = append(codes, w.Code)
+	}
+
+	want := []int{http.StatusOK, http.StatusTooManyRequests, http.StatusOK}
+	for i := range want {
+		if codes[i] != want[i] {
+			t.Errorf("expected statuses %v, got %v", want, codes)
+			break
+		}
+	}
+}
+
+func TestBotDetection_JA3(t *testing.T) {
+	const hash = "e7d705a3286e19ea42f587b344ee6865"
+	r := botTestRouter(BotDetection(JA3Detector(JA3FromHeader("X-JA3-Hash"), map[string]BotAction{
+		hash: BotBlock,
+	})))
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{"known fingerprint", strings.ToUpper(hash), http.StatusForbidden},
+		{"unknown fingerprint", "0123456789abcdef0123456789abcdef", http.StatusOK},
+		{"no fingerprint", "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			req.Header.Set("X-JA3-Hash", tt.header)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
+
+func TestBotDetection_Logger(t *testing.T) {
+	var buf bytes.Buffer
+	r := fursy.New()
+	r.Use(LoggerWithConfig(LoggerConfig{Logger: JSONLogger(&buf)}))
+	r.Use(BotDetection(UserAgentDetector(BotTag)))
+	r.GET("/", func(c *fursy.Context) error { return c.NoContent(http.StatusNoContent) })
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set("User-Agent", "wget/1.21")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !strings.Contains(buf.String(), `"bot_action":"tag"`) || !strings.Contains(buf.String(), `"bot_detector":"user-agent"`) {
+		t.Errorf("JSON log should contain bot fields, got %s", buf.String())
+	}
+}
+
+func TestBotDetection_InvalidConfig(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	BotDetection()
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package middleware provides per-request resource budget middleware.
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"runtime/metrics"
+	"runtime/pprof"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// Resources checked by the Budget middleware.
+const (
+	BudgetDuration   = "duration"
+	BudgetAllocBytes = "alloc_bytes"
+	BudgetAllocs     = "allocs"
+)
+
+// BudgetUsage is the resource usage of a request measured by Budget.
+type BudgetUsage struct {
+	// Method and Route identify the endpoint (Route is the route pattern).
+	Method string
+	Route  string
+
+	// Duration is the execution time of the rest of the chain.
+	Duration time.Duration
+
+	// AllocBytes and Allocs are the heap bytes and objects allocated
+	// while the request was served.
+	AllocBytes uint64
+	Allocs     uint64
+
+	// Exceeded lists the exceeded budgets (BudgetDuration,
+	// BudgetAllocBytes, BudgetAllocs).
+	Exceeded []string
+}
+
+// BudgetConfig defines the configuration for the Budget middleware.
+type BudgetConfig struct {
+	// MaxDuration is the execution time budget of a request.
+	// Default: 0 (not checked)
+	MaxDuration time.Duration
+
+	// MaxAllocBytes is the heap allocation budget of a request in bytes.
+	// Default: 0 (not checked)
+	MaxAllocBytes uint64
+
+	// MaxAllocs is the budget of heap objects allocated by a request.
+	// Default: 0 (not checked)
+	MaxAllocs uint64
+
+	// SampleRate is the fraction of requests measured (0 < rate <= 1).
+	// Unsampled requests only get profile labels.
+	// Default: 1 (every request)
+	SampleRate float64
+
+	// DisableProfileLabels disables the pprof labels "route" and "method"
+	// set while the rest of the chain runs. Routers created with
+	// Options.ProfileLabels already set them for the whole chain.
+	// Default: false
+	DisableProfileLabels bool
+
+	// OnExceeded is called for sampled requests exceeding a budget.
+	// Default: log a warning with Logger
+	OnExceeded func(c *fursy.Context, usage BudgetUsage)
+
+	// Logger logs requests exceeding a budget when OnExceeded is nil.
+	// Default: slog.Default()
+	Logger *slog.Logger
+
+	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
+	// Default: nil (middleware always executes)
+	Skipper fursy.Skipper
+}
+
+// Budget returns a debug middleware that flags requests taking longer than
+// maxDuration, and tags CPU and heap profiles with the route pattern and
+// method (pprof labels "route" and "method"), so profiles can be sliced per
+// endpoint (e.g., go tool pprof -tagfocus route=/users/:id).
+//
+// It is meant for development, load tests and canaries rather than for
+// every production request; see BudgetWithConfig for allocation budgets
+// and sampling.
+//
+// Example:
+//
+//	router.Use(middleware.Budget(50 * time.Millisecond))
+func Budget(maxDuration time.Duration) fursy.HandlerFunc {
+	return BudgetWithConfig(BudgetConfig{
+		MaxDuration: maxDuration,
+	})
+}
+
+// BudgetWithConfig returns a Budget middleware with custom configuration.
+//
+// Allocations are read from runtime/metrics, which counts the allocations
+// of the whole process: the figures of a request include those of requests
+// served concurrently. They are exact when requests do not overlap (e.g.,
+// replaying traffic one request at a time); under load, use the heap
+// profile, whose samples carry the route labels, for exact attribution.
+//
+// Example:
+//
+//	router.Use(middleware.BudgetWithConfig(middleware.BudgetConfig{
+//	    MaxDuration:   100 * time.Millisecond,
+//	    MaxAllocBytes: 1 << 20,
+//	    SampleRate:    0.1,
+//	    OnExceeded: func(c *fursy.Context, u middleware.BudgetUsage) {
+//	        overBudget.WithLabelValues(u.Route, u.Exceeded[0]).Inc()
+//	    },
+//	}))
+func BudgetWithConfig(config BudgetConfig) fursy.HandlerFunc {
+	// Validate config.
+	if config.SampleRate < 0 || config.SampleRate > 1 {
+		panic("fursy/middleware: Budget sample rate must be between 0 and 1")
+	}
+
+	// Set defaults.
+	if config.SampleRate == 0 {
+		config.SampleRate = 1
+	}
+	if config.OnExceeded == nil {
+		logger := config.Logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+		config.OnExceeded = func(c *fursy.Context, u BudgetUsage) {
+			logger.WarnContext(c.Request.Context(), "request over budget",
+				"method", u.Method,
+				"route", u.Route,
+				"exceeded", u.Exceeded,
+				"duration", u.Duration,
+				"alloc_bytes", u.AllocBytes,
+				"allocs", u.Allocs,
+			)
+		}
+	}
+	measureAllocs := config.MaxAllocBytes > 0 || config.MaxAllocs > 0
+
+	return func(c *fursy.Context) error {
+		// Skip if Skipper returns true.
+		if config.Skipper.ShouldSkip(c) {
+			return c.Next()
+		}
+
+		next := c.Next
+		if !config.DisableProfileLabels {
+			next = func() error {
+				return withProfileLabels(c, c.Next)
+			}
+		}
+
+		if config.SampleRate < 1 && rand.Float64() >= config.SampleRate {
+			return next()
+		}
+
+		var before [2]metrics.Sample
+		if measureAllocs {
+			readAllocs(&before)
+		}
+		start := time.Now()
+
+		err := next()
+
+		usage := BudgetUsage{
+			Method:   c.Request.Method,
+			Route:    c.Route(),
+			Duration: time.Since(start),
+		}
+		if measureAllocs {
+			var after [2]metrics.Sample
+			readAllocs(&after)
+			usage.AllocBytes = after[0].Value.Uint64() - before[0].Value.Uint64()
+			usage.Allocs = after[1].Value.Uint64() - before[1].Value.Uint64()
+		}
+
+		if config.MaxDuration > 0 && usage.Duration > config.MaxDuration {
+			usage.Exceeded = append(usage.Exceeded, BudgetDuration)
+		}
+		if config.MaxAllocBytes > 0 && usage.AllocBytes > config.MaxAllocBytes {
+			usage.Exceeded = append(usage.Exceeded, BudgetAllocBytes)
+		}
+		if config.MaxAllocs > 0 && usage.Allocs > config.MaxAllocs {
+			usage.Exceeded = append(usage.Exceeded, BudgetAllocs)
+		}
+		if len(usage.Exceeded) > 0 {
+			config.OnExceeded(c, usage)
+		}
+
+		return err
+	}
+}
+
+// readAllocs reads the cumulative heap allocation metrics into samples.
+func readAllocs(samples *[2]metrics.Sample) {
+	samples[0].Name = "/gc/heap/allocs:bytes"
+	samples[1].Name = "/gc/heap/allocs:objects"
+	metrics.Read(samples[:])
+}
+
+// withProfileLabels runs next with the pprof labels "route" and "method",
+// restoring the request context afterwards.
+func withProfileLabels(c *fursy.Context, next func() error) error {
+	route := c.Route()
+	if route == "" {
+		route = "unmatched"
+	}
+
+	req := c.Request
+	var err error
+	pprof.Do(req.Context(), pprof.Labels("route", route, "method", req.Method), func(ctx context.Context) {
+		c.Request = req.WithContext(ctx)
+		err = next()
+	})
+	c.Request = req
+	return err
+}
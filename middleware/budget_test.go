@@ -0,0 +1,109 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"runtime/pprof"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+var budgetSink []byte
+
+func TestBudget_Exceeded(t *testing.T) {
+	var got []BudgetUsage
+	router := fursy.New()
+	router.Use(BudgetWithConfig(BudgetConfig{
+		MaxDuration:   5 * time.Millisecond,
+		MaxAllocBytes: 64 << 10,
+		OnExceeded: func(c *fursy.Context, u BudgetUsage) {
+			got = append(got, u)
+		},
+	}))
+	router.GET("/slow/:id", func(c *fursy.Context) error {
+		time.Sleep(10 * time.Millisecond)
+		return c.NoContent(http.StatusNoContent)
+	})
+	router.GET("/heavy", func(c *fursy.Context) error {
+		budgetSink = make([]byte, 1<<20)
+		return c.NoContent(http.StatusNoContent)
+	})
+	router.GET("/fast", func(c *fursy.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	for _, path := range []string{"/slow/1", "/heavy", "/fast"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("%s: status = %d", path, w.Code)
+		}
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 requests over budget, got %+v", got)
+	}
+	if got[0].Route != "/slow/:id" || !slices.Contains(got[0].Exceeded, BudgetDuration) {
+		t.Errorf("slow request: %+v", got[0])
+	}
+	if got[1].Route != "/heavy" || !slices.Contains(got[1].Exceeded, BudgetAllocBytes) || got[1].AllocBytes < 1<<20 {
+		t.Errorf("heavy request: %+v", got[1])
+	}
+}
+
+func TestBudget_ProfileLabels(t *testing.T) {
+	var route, method string
+	router := fursy.New()
+	router.Use(Budget(time.Minute))
+	router.POST("/users/:id", func(c *fursy.Context) error {
+		route, _ = pprof.Label(c.Request.Context(), "route")
+		method, _ = pprof.Label(c.Request.Context(), "method")
+		return nil
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/users/7", http.NoBody))
+
+	if route != "/users/:id" || method != http.MethodPost {
+		t.Errorf("labels = route %q, method %q", route, method)
+	}
+}
+
+func TestBudget_Sampling(t *testing.T) {
+	calls := 0
+	router := fursy.New()
+	router.Use(BudgetWithConfig(BudgetConfig{
+		MaxDuration:          time.Nanosecond,
+		SampleRate:           0.000001,
+		DisableProfileLabels: true,
+		OnExceeded:           func(*fursy.Context, BudgetUsage) { calls++ },
+	}))
+	router.GET("/", func(c *fursy.Context) error {
+		if _, ok := pprof.Label(c.Request.Context(), "route"); ok {
+			t.Error("profile labels set although disabled")
+		}
+		return nil
+	})
+
+	for range 100 {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	}
+	if calls > 1 {
+		t.Errorf("OnExceeded called %d times, expected requests to be sampled out", calls)
+	}
+}
+
+func TestBudget_InvalidSampleRate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	BudgetWithConfig(BudgetConfig{SampleRate: 2})
+}
@@ -94,8 +94,9 @@ type CircuitBreakerConfig struct {
 	OnStateChange func(from, to State)
 
 	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
 	// Default: nil (middleware always executes)
-	Skipper func(c *fursy.Context) bool
+	Skipper fursy.Skipper
 
 	// IsSuccessful determines if a request is considered successful.
 	// Default: status < 500 (5xx errors are failures)
@@ -290,12 +291,13 @@ func CircuitBreakerWithConfig(config CircuitBreakerConfig) fursy.HandlerFunc {
 
 	return func(c *fursy.Context) error {
 		// Skip if Skipper returns true.
-		if config.Skipper != nil && config.Skipper(c) {
+		if config.Skipper.ShouldSkip(c) {
 			return c.Next()
 		}
 
 		// Check if circuit breaker allows request.
 		if err := cb.beforeRequest(); err != nil {
+			currentMetrics().CircuitBreakerShortCircuited(c, config.Name)
 			return config.ErrorHandler(c)
 		}
 
@@ -476,6 +478,7 @@ func (cb *circuitBreaker) setState(newState State) {
 	}
 
 	cb.state = newState
+	currentMetrics().CircuitBreakerStateChanged(cb.config.Name, oldState, newState)
 
 	// Call state change callback if configured.
 	if cb.config.OnStateChange != nil {
@@ -0,0 +1,247 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package middleware provides per-client concurrency limiting middleware.
+package middleware
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// ConcurrencyLimitConfig defines the configuration for the ConcurrencyLimit middleware.
+type ConcurrencyLimitConfig struct {
+	// Max is the maximum number of concurrent requests per key.
+	// Required (must be positive).
+	Max int
+
+	// QueueTimeout is how long a request over the limit waits for a slot
+	// before it is rejected. Zero rejects immediately.
+	// Default: 0
+	QueueTimeout time.Duration
+
+	// KeyFunc extracts the limit key from the request.
+	// Default: client IP (c.ClientIP(), trusted-proxy aware)
+	KeyFunc func(c *fursy.Context) string
+
+	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
+	// Default: nil (middleware always executes)
+	Skipper fursy.Skipper
+
+	// ErrorHandler is called when a request is rejected.
+	// Default: 429 Too Many Requests problem
+	ErrorHandler func(c *fursy.Context) error
+}
+
+// ConcurrencyLimit returns a middleware that limits concurrent requests per client IP.
+//
+// Unlike RateLimit, which limits the request rate, ConcurrencyLimit limits
+// how many requests of a client are active at the same time. This protects
+// long-lived SSE and WebSocket endpoints, where a single client could
+// otherwise open enough streams to exhaust the hub.
+//
+// The client IP is taken from c.ClientIP(); configure Router.SetTrustedProxies
+// when running behind a proxy.
+//
+// Example:
+//
+//	streams := router.Group("/stream", middleware.ConcurrencyLimit(5))
+//	streams.GET("/events", eventsHandler)
+func ConcurrencyLimit(maxPerIP int) fursy.HandlerFunc {
+	return ConcurrencyLimitWithConfig(ConcurrencyLimitConfig{
+		Max: maxPerIP,
+	})
+}
+
+// ConcurrencyLimitWithConfig returns a middleware with custom configuration.
+//
+// Example (queue for up to 2 seconds, per user):
+//
+//	router.Use(middleware.ConcurrencyLimitWithConfig(middleware.ConcurrencyLimitConfig{
+//	    Max:          10,
+//	    QueueTimeout: 2 * time.Second,
+//	    KeyFunc: func(c *fursy.Context) string {
+//	        return c.GetString("user_id")
+//	    },
+//	}))
+func ConcurrencyLimitWithConfig(config ConcurrencyLimitConfig) fursy.HandlerFunc {
+	// Validate config.
+	if config.Max <= 0 {
+		panic("fursy/middleware: ConcurrencyLimit max must be positive")
+	}
+
+	// Set defaults.
+	if config.KeyFunc == nil {
+		config.KeyFunc = func(c *fursy.Context) string {
+			return c.ClientIP()
+		}
+	}
+	if config.ErrorHandler == nil {
+		config.ErrorHandler = defaultConcurrencyLimitErrorHandler
+	}
+
+	limiter := newConcurrencyLimiter(config.Max)
+
+	return func(c *fursy.Context) error {
+		// Skip if Skipper returns true.
+		if config.Skipper.ShouldSkip(c) {
+			return c.Next()
+		}
+
+		key := config.KeyFunc(c)
+		slot := limiter.acquire(c, key, config.QueueTimeout)
+		if slot == nil {
+			return config.ErrorHandler(c)
+		}
+		defer limiter.release(key, slot)
+
+		return c.Next()
+	}
+}
+
+// defaultConcurrencyLimitErrorHandler sends a 429 Too Many Requests problem.
+func defaultConcurrencyLimitErrorHandler(c *fursy.Context) error {
+	c.SetHeader("Retry-After", "1")
+	return c.Problem(fursy.TooManyRequests("too many concurrent requests"))
+}
+
+// concurrencyLimiter holds a semaphore per key.
+// Keys are removed when they have no active or waiting requests,
+// so memory is bounded by the number of concurrently active clients.
+type concurrencyLimiter struct {
+	mu    sync.Mutex
+	slots map[string]*concurrencySlot
+	max   int
+}
+
+// concurrencySlot is the semaphore of a single key.
+type concurrencySlot struct {
+	sem  chan struct{}
+	refs int // Active and waiting requests.
+}
+
+// newConcurrencyLimiter creates a limiter allowing max concurrent requests per key.
+func newConcurrencyLimiter(maxPerKey int) *concurrencyLimiter {
+	return &concurrencyLimiter{
+		slots: make(map[string]*concurrencySlot),
+		max:   maxPerKey,
+	}
+}
+
+// acquire takes a slot for key, waiting up to timeout.
+// It returns nil if no slot became available or the request was canceled.
+func (l *concurrencyLimiter) acquire(c *fursy.Context, key string, timeout time.Duration) *concurrencySlot {
+	l.mu.Lock()
+	slot, ok := l.slots[key]
+	if !ok {
+		slot = &concurrencySlot{sem: make(chan struct{}, l.max)}
+		l.slots[key] = slot
+	}
+	slot.refs++
+	l.mu.Unlock()
+
+	select {
+	case slot.sem <- struct{}{}:
+		return slot
+	default:
+	}
+
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		select {
+		case slot.sem <- struct{}{}:
+			return slot
+		case <-timer.C:
+		case <-c.Request.Context().Done():
+		}
+	}
+
+	l.unref(key, slot)
+	return nil
+}
+
+// release frees the slot taken by acquire.
+func (l *concurrencyLimiter) release(key string, slot *concurrencySlot) {
+	<-slot.sem
+	l.unref(key, slot)
+}
+
+// unref drops a reference to slot, removing it when unused.
+func (l *concurrencyLimiter) unref(key string, slot *concurrencySlot) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	slot.refs--
+	if slot.refs == 0 {
+		delete(l.slots, key)
+	}
+}
+
+// LimitConnsPerIP limits the number of open connections per peer IP.
+//
+// Connections over the limit are closed as soon as they are accepted,
+// before any request is read. It uses the router's connection hooks, so the
+// server must be set with Router.SetServer (or use
+// Router.ListenAndServeWithShutdown).
+//
+// The peer IP is the connection's remote address: behind a proxy all
+// clients share the proxy's address, so use ConcurrencyLimit instead.
+//
+// Example:
+//
+//	router := fursy.New()
+//	middleware.LimitConnsPerIP(router, 50)
+//
+//	if err := router.ListenAndServeWithShutdown(":8080"); err != nil {
+//	    log.Fatal(err)
+//	}
+func LimitConnsPerIP(router *fursy.Router, maxPerIP int) {
+	if maxPerIP <= 0 {
+		panic("fursy/middleware: LimitConnsPerIP max must be positive")
+	}
+
+	var (
+		mu    sync.Mutex
+		conns = make(map[string]int)
+	)
+
+	router.OnConnOpen(func(conn net.Conn) {
+		ip := connIP(conn)
+
+		mu.Lock()
+		conns[ip]++
+		over := conns[ip] > maxPerIP
+		mu.Unlock()
+
+		if over {
+			_ = conn.Close()
+		}
+	})
+
+	router.OnConnClose(func(conn net.Conn) {
+		ip := connIP(conn)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if conns[ip]--; conns[ip] <= 0 {
+			delete(conns, ip)
+		}
+	})
+}
+
+// connIP returns the IP of a connection's remote address.
+func connIP(conn net.Conn) string {
+	addr := conn.RemoteAddr().String()
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
@@ -0,0 +1,289 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// newBlockingRouter returns a router whose /slow handler signals entered and waits for release.
+func newBlockingRouter(mw fursy.HandlerFunc, entered chan<- struct{}, release <-chan struct{}) *fursy.Router {
+	router := fursy.New()
+	router.Use(mw)
+	router.GET("/slow", func(c *fursy.Context) error {
+		entered <- struct{}{}
+		<-release
+		return c.String(http.StatusOK, "OK")
+	})
+	return router
+}
+
+// serveFrom serves a GET /slow request from remoteAddr.
+func serveFrom(router *fursy.Router, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/slow", http.NoBody)
+	req.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestConcurrencyLimit_RejectsOverLimit(t *testing.T) {
+	entered := make(chan struct{}, 4)
+	release := make(chan struct{})
+	router := newBlockingRouter(ConcurrencyLimit(2), entered, release)
+
+	var wg sync.WaitGroup
+	codes := make(chan int, 2)
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes <- serveFrom(router, "1.2.3.4:1000").Code
+		}()
+	}
+	<-entered
+	<-entered
+
+	// Third request from the same IP is rejected.
+	rec := serveFrom(router, "1.2.3.4:1001")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429, got %d", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("expected Retry-After header")
+	}
+
+	// Another IP is not affected.
+	done := make(chan int, 1)
+	go func() { done <- serveFrom(router, "5.6.7.8:1000").Code }()
+	<-entered
+
+	close(release)
+	wg.Wait()
+	close(codes)
+	for code := range codes {
+		if code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", code)
+		}
+	}
+	if code := <-done; code != http.StatusOK {
+		t.Errorf("other IP: expected status 200, got %d", code)
+	}
+
+	// Slots are released after completion.
+	if rec := serveFrom(router, "1.2.3.4:1002"); rec.Code != http.StatusOK {
+		t.Errorf("after release: expected status 200, got %d", rec.Code)
+	}
+}
+
+func TestConcurrencyLimit_QueueTimeout(t *testing.T) {
+	entered := make(chan struct{}, 2)
+	release := make(chan struct{})
+	router := newBlockingRouter(ConcurrencyLimitWithConfig(ConcurrencyLimitConfig{
+		Max:          1,
+		QueueTimeout: time.Second,
+	}), entered, release)
+
+	first := make(chan int, 1)
+	go func() { first <- serveFrom(router, "1.2.3.4:1000").Code }()
+	<-entered
+
+	// The queued request proceeds once the first completes.
+	second := make(chan int, 1)
+	go func() { second <- serveFrom(router, "1.2.3.4:1001").Code }()
+
+	time.Sleep(20 * time.Millisecond)
+	release <- struct{}{}
+	<-entered
+	close(release)
+
+	if code := <-first; code != http.StatusOK {
+		t.Errorf("first: expected status 200, got %d", code)
+	}
+	if code := <-second; code != http.StatusOK {
+		t.Errorf("queued: expected status 200, got %d", code)
+	}
+}
+
+func TestConcurrencyLimit_QueueTimeoutExpires(t *testing.T) {
+	entered := make(chan struct{}, 1)
+	release := make(chan struct{})
+	router := newBlockingRouter(ConcurrencyLimitWithConfig(ConcurrencyLimitConfig{
+		Max:          1,
+		QueueTimeout: 20 * time.Millisecond,
+	}), entered, release)
+
+	first := make(chan int, 1)
+	go func() { first <- serveFrom(router, "1.2.3.4:1000").Code }()
+	<-entered
+
+	start := time.Now()
+	rec := serveFrom(router, "1.2.3.4:1001")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429, got %d", rec.Code)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("expected request to wait for the queue timeout, waited %v", elapsed)
+	}
+
+	close(release)
+	<-first
+}
+
+func TestConcurrencyLimit_TrustedProxy(t *testing.T) {
+	entered := make(chan struct{}, 1)
+	release := make(chan struct{})
+	router := newBlockingRouter(ConcurrencyLimit(1), entered, release)
+	router.SetTrustedProxies("10.0.0.0/8")
+
+	serve := func(client string) int {
+		req := httptest.NewRequest(http.MethodGet, "/slow", http.NoBody)
+		req.RemoteAddr = "10.0.0.1:1000"
+		req.Header.Set("X-Forwarded-For", client)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	first := make(chan int, 1)
+	go func() { first <- serve("1.2.3.4") }()
+	<-entered
+
+	// Same proxy, different client: allowed.
+	second := make(chan int, 1)
+	go func() { second <- serve("5.6.7.8") }()
+	<-entered
+
+	// Same client: rejected.
+	if code := serve("1.2.3.4"); code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429, got %d", code)
+	}
+
+	close(release)
+	<-first
+	if code := <-second; code != http.StatusOK {
+		t.Errorf("expected status 200 for other client, got %d", code)
+	}
+}
+
+func TestConcurrencyLimit_Skipper(t *testing.T) {
+	router := fursy.New()
+	router.Use(ConcurrencyLimitWithConfig(ConcurrencyLimitConfig{
+		Max: 1,
+		Skipper: func(c *fursy.Context) bool {
+			return true
+		},
+		KeyFunc: func(c *fursy.Context) string {
+			t.Error("KeyFunc should not be called when skipped")
+			return ""
+		},
+	}))
+	router.GET("/", func(c *fursy.Context) error {
+		return c.String(http.StatusOK, "OK")
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+}
+
+func TestConcurrencyLimit_ReleasesKeys(t *testing.T) {
+	limiter := newConcurrencyLimiter(1)
+	c := &fursy.Context{Request: httptest.NewRequest(http.MethodGet, "/", http.NoBody)}
+
+	slot := limiter.acquire(c, "a", 0)
+	if slot == nil {
+		t.Fatal("expected slot")
+	}
+	if limiter.acquire(c, "a", 0) != nil {
+		t.Fatal("expected limit to be reached")
+	}
+	limiter.release("a", slot)
+
+	if n := len(limiter.slots); n != 0 {
+		t.Errorf("expected no tracked keys, got %d", n)
+	}
+}
+
+func TestConcurrencyLimit_InvalidMax(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for non-positive max")
+		}
+	}()
+	ConcurrencyLimit(0)
+}
+
+func TestLimitConnsPerIP(t *testing.T) {
+	router := fursy.New()
+	router.GET("/", func(c *fursy.Context) error {
+		return c.String(http.StatusOK, "OK")
+	})
+	LimitConnsPerIP(router, 1)
+
+	ts := httptest.NewUnstartedServer(router)
+	router.SetServer(ts.Config)
+	ts.Start()
+	defer ts.Close()
+
+	addr := ts.Listener.Addr().String()
+
+	first, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Close()
+
+	// Wait until the first connection is tracked.
+	deadline := time.Now().Add(2 * time.Second)
+	for router.OpenConns() < 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("first connection not tracked")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// Second connection from the same IP is closed by the server.
+	second, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Close()
+
+	_ = second.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, err := second.Write([]byte("GET / HTTP/1.1\r\nHost: x\r\n\r\n")); err == nil {
+		buf := make([]byte, 1)
+		if _, err := second.Read(buf); err == nil {
+			t.Error("expected second connection to be closed")
+		}
+	}
+
+	// After the first connection closes, a new one is accepted.
+	_ = first.Close()
+	for router.OpenConns() > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("connections not released")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	resp, err := ts.Client().Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+}
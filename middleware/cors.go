@@ -59,6 +59,11 @@ type CORSConfig struct {
 	// Default: 0 (no caching)
 	MaxAge time.Duration
 
+	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
+	// Default: nil (middleware always executes)
+	Skipper fursy.Skipper
+
 	// Internal maps for efficient lookup.
 	allowOriginMap map[string]bool
 	allowMethodMap map[string]bool
@@ -124,6 +129,17 @@ func CORSWithConfig(config CORSConfig) fursy.HandlerFunc {
 	config.init()
 
 	return func(c *fursy.Context) error {
+		// Skip if Skipper returns true.
+		if config.Skipper.ShouldSkip(c) {
+			return c.Next()
+		}
+
+		// Responses that echo the origin depend on it; caches must not
+		// serve them to other origins (or to requests without Origin).
+		if config.echoesOrigin() {
+			c.AddVary(headerOrigin)
+		}
+
 		origin := c.Request.Header.Get(headerOrigin)
 		if origin == "" {
 			// Not a CORS request.
@@ -139,8 +155,10 @@ func CORSWithConfig(config CORSConfig) fursy.HandlerFunc {
 			}
 
 			// Handle preflight request.
+			c.AddVary(headerRequestMethod, headerRequestHeaders)
 			headers := c.Request.Header.Get(headerRequestHeaders)
-			config.setPreflightHeaders(origin, method, headers, c.Response.Header())
+			allowed := config.setPreflightHeaders(origin, method, headers, c.Response.Header())
+			currentMetrics().CORSPreflight(c, allowed)
 
 			// Write 204 response and stop processing.
 			c.Abort()
@@ -168,6 +186,11 @@ func (cfg *CORSConfig) isOriginAllowed(origin string) bool {
 	return cfg.AllowOrigins == "*" || cfg.allowOriginMap[origin]
 }
 
+// echoesOrigin reports whether Access-Control-Allow-Origin depends on the request origin.
+func (cfg *CORSConfig) echoesOrigin() bool {
+	return cfg.AllowOrigins != "*" || cfg.AllowCredentials
+}
+
 // setActualHeaders sets CORS headers for actual requests.
 func (cfg *CORSConfig) setActualHeaders(origin string, headers http.Header) {
 	if !cfg.isOriginAllowed(origin) {
@@ -182,10 +205,11 @@ func (cfg *CORSConfig) setActualHeaders(origin string, headers http.Header) {
 }
 
 // setPreflightHeaders sets CORS headers for preflight OPTIONS requests.
-func (cfg *CORSConfig) setPreflightHeaders(origin, method, reqHeaders string, headers http.Header) {
+// It reports whether the preflight request is allowed.
+func (cfg *CORSConfig) setPreflightHeaders(origin, method, reqHeaders string, headers http.Header) bool {
 	allowed, allowedHeaders := cfg.isPreflightAllowed(origin, method, reqHeaders)
 	if !allowed {
-		return
+		return false
 	}
 
 	cfg.setOriginHeader(origin, headers)
@@ -203,6 +227,8 @@ func (cfg *CORSConfig) setPreflightHeaders(origin, method, reqHeaders string, he
 	if allowedHeaders != "" {
 		headers.Set(headerAllowHeaders, reqHeaders)
 	}
+
+	return true
 }
 
 // isPreflightAllowed checks if the preflight request is allowed.
@@ -38,6 +38,27 @@ func TestCORS(t *testing.T) {
 	}
 }
 
+// TestCORS_Skipper tests skipping CORS handling for matching paths.
+func TestCORS_Skipper(t *testing.T) {
+	r := fursy.New()
+	r.Use(CORSWithConfig(CORSConfig{
+		Skipper: fursy.SkipPaths("/internal/*"),
+	}))
+
+	r.GET("/internal/stats", func(c *fursy.Context) error {
+		return c.String(200, "OK")
+	})
+
+	req := httptest.NewRequest("GET", "/internal/stats", http.NoBody)
+	req.Header.Set("Origin", "https://example.com")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("expected no CORS headers for skipped path, got %s", w.Header().Get("Access-Control-Allow-Origin"))
+	}
+}
+
 // TestCORS_NoOrigin tests request without Origin header.
 func TestCORS_NoOrigin(t *testing.T) {
 	r := fursy.New()
@@ -492,3 +513,75 @@ func TestCORSConfig_IsPreflightAllowed(t *testing.T) {
 		}
 	})
 }
+
+// TestCORS_Vary tests that responses depending on Origin declare it in Vary.
+func TestCORS_Vary(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  CORSConfig
+		method  string
+		origin  string
+		headers map[string]string
+		want    string
+	}{
+		{
+			name:   "wildcard origin does not vary",
+			config: CORSConfig{},
+			method: http.MethodGet,
+			origin: "https://example.com",
+			want:   "",
+		},
+		{
+			name:   "specific origins vary on Origin",
+			config: CORSConfig{AllowOrigins: "https://example.com"},
+			method: http.MethodGet,
+			origin: "https://example.com",
+			want:   "Origin",
+		},
+		{
+			name:   "requests without Origin also vary",
+			config: CORSConfig{AllowOrigins: "https://example.com"},
+			method: http.MethodGet,
+			want:   "Origin",
+		},
+		{
+			name:   "credentials vary on Origin",
+			config: CORSConfig{AllowCredentials: true},
+			method: http.MethodGet,
+			origin: "https://example.com",
+			want:   "Origin",
+		},
+		{
+			name:    "preflight varies on request method and headers",
+			config:  CORSConfig{AllowOrigins: "https://example.com"},
+			method:  http.MethodOptions,
+			origin:  "https://example.com",
+			headers: map[string]string{"Access-Control-Request-Method": "PUT"},
+			want:    "Origin, Access-Control-Request-Method, Access-Control-Request-Headers",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := fursy.New()
+			r.Use(CORSWithConfig(tt.config))
+			r.Handle(tt.method, "/test", func(c *fursy.Context) error {
+				return c.String(200, "OK")
+			})
+
+			req := httptest.NewRequest(tt.method, "/test", http.NoBody)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if vary := w.Header().Get("Vary"); vary != tt.want {
+				t.Errorf("expected Vary %q, got %q", tt.want, vary)
+			}
+		})
+	}
+}
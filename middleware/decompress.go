@@ -0,0 +1,293 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package middleware provides request body decompression middleware.
+package middleware
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/coregx/fursy"
+)
+
+// Default values for the Decompress middleware.
+const (
+	// DefaultDecompressMaxSize is the default maximum decompressed body size (10 MB).
+	DefaultDecompressMaxSize = 10 << 20
+
+	// DefaultDecompressMaxRatio is the default maximum decompressed/compressed size ratio.
+	DefaultDecompressMaxRatio = 100
+
+	// decompressRatioThreshold is the decompressed size below which the ratio is not checked.
+	// Small payloads (e.g., repetitive JSON) legitimately compress very well.
+	decompressRatioThreshold = 64 << 10
+
+	// maxContentEncodings is the maximum number of content codings of a request body.
+	maxContentEncodings = 2
+)
+
+// ErrDecompressedBodyTooLarge is returned when reading a decompressed request body
+// exceeds DecompressConfig.MaxSize or DecompressConfig.MaxRatio.
+var ErrDecompressedBodyTooLarge = errors.New("decompressed request body too large")
+
+// Decoder wraps a compressed reader with a decompressing reader.
+type Decoder func(r io.Reader) (io.ReadCloser, error)
+
+// DecompressConfig defines the configuration for the Decompress middleware.
+type DecompressConfig struct {
+	// Decoders maps Content-Encoding tokens to decoders.
+	// Entries are added to (and override) the built-in decoders:
+	// gzip, x-gzip and deflate.
+	//
+	// Use it to add Brotli (br) or Zstandard (zstd) support.
+	// Default: nil (built-in decoders only)
+	Decoders map[string]Decoder
+
+	// MaxSize is the maximum decompressed body size in bytes.
+	// Negative value disables the limit.
+	// Default: 10 MB
+	MaxSize int64
+
+	// MaxRatio is the maximum ratio of decompressed to compressed size,
+	// protecting against decompression bombs. It is checked once the
+	// decompressed body exceeds 64 KB.
+	// Negative value disables the check.
+	// Default: 100
+	MaxRatio float64
+
+	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
+	// Default: nil (middleware always executes)
+	Skipper fursy.Skipper
+}
+
+// Decompress returns a middleware that transparently decompresses request bodies
+// sent with Content-Encoding gzip or deflate.
+//
+// The body is decompressed while it is read, so binding works as usual.
+// Content-Encoding and Content-Length are removed from the request.
+//
+// Responses:
+//   - 415 Unsupported Media Type for unknown encodings and bodies encoded
+//     more than twice (e.g. "gzip, gzip, gzip"), which only serve to
+//     multiply the decoding work
+//   - 400 Bad Request for corrupt compressed data
+//   - 413 Content Too Large when the decompressed body exceeds the limits
+//
+// Example:
+//
+//	router := fursy.New()
+//	router.Use(middleware.Decompress())
+//
+//	fursy.POST[[]Reading, fursy.Empty](router, "/ingest", ingest)
+func Decompress() fursy.HandlerFunc {
+	return DecompressWithConfig(DecompressConfig{})
+}
+
+// DecompressWithConfig returns a middleware with custom configuration.
+//
+// Example (adding Brotli and Zstandard):
+//
+//	import (
+//	    "github.com/andybalholm/brotli"
+//	    "github.com/klauspost/compress/zstd"
+//	)
+//
+//	router.Use(middleware.DecompressWithConfig(middleware.DecompressConfig{
+//	    MaxSize: 50 << 20, // 50 MB
+//	    Decoders: map[string]middleware.Decoder{
+//	        "br": func(r io.Reader) (io.ReadCloser, error) {
+//	            return io.NopCloser(brotli.NewReader(r)), nil
+//	        },
+//	        "zstd": func(r io.Reader) (io.ReadCloser, error) {
+//	            d, err := zstd.NewReader(r)
+//	            if err != nil {
+//	                return nil, err
+//	            }
+//	            return d.IOReadCloser(), nil
+//	        },
+//	    },
+//	}))
+func DecompressWithConfig(config DecompressConfig) fursy.HandlerFunc {
+	// Set defaults.
+	if config.MaxSize == 0 {
+		config.MaxSize = DefaultDecompressMaxSize
+	}
+	if config.MaxRatio == 0 {
+		config.MaxRatio = DefaultDecompressMaxRatio
+	}
+
+	decoders := map[string]Decoder{
+		"gzip":    gzipDecoder,
+		"x-gzip":  gzipDecoder,
+		"deflate": deflateDecoder,
+	}
+	for name, dec := range config.Decoders {
+		decoders[strings.ToLower(name)] = dec
+	}
+
+	return func(c *fursy.Context) error {
+		// Skip if Skipper returns true.
+		if config.Skipper.ShouldSkip(c) {
+			return c.Next()
+		}
+
+		encodings := parseContentEncoding(c.Request.Header.Values("Content-Encoding"))
+		if len(encodings) == 0 || c.Request.Body == nil || c.Request.Body == http.NoBody {
+			return c.Next()
+		}
+		if len(encodings) > maxContentEncodings {
+			return c.Problem(fursy.NewProblem(http.StatusUnsupportedMediaType, "Unsupported Media Type",
+				fmt.Sprintf("request body has %d content encodings, at most %d are supported", len(encodings), maxContentEncodings)))
+		}
+
+		// Encodings are listed in the order they were applied; decode in reverse.
+		compressed := &countingReader{r: c.Request.Body}
+		var (
+			reader  io.Reader = compressed
+			closers []io.Closer
+		)
+		for i := len(encodings) - 1; i >= 0; i-- {
+			dec, ok := decoders[encodings[i]]
+			if !ok {
+				closeAll(closers)
+				return c.Problem(fursy.NewProblem(http.StatusUnsupportedMediaType, "Unsupported Media Type",
+					fmt.Sprintf("unsupported Content-Encoding %q", encodings[i])))
+			}
+			rc, err := dec(reader)
+			if err != nil {
+				closeAll(closers)
+				return c.Problem(fursy.BadRequest("invalid " + encodings[i] + " request body"))
+			}
+			closers = append(closers, rc)
+			reader = rc
+		}
+
+		body := &decompressReader{
+			r:          reader,
+			original:   c.Request.Body,
+			closers:    closers,
+			compressed: compressed,
+			maxSize:    config.MaxSize,
+			maxRatio:   config.MaxRatio,
+		}
+		c.Request.Body = body
+		c.Request.ContentLength = -1
+		c.Request.Header.Del("Content-Encoding")
+		c.Request.Header.Del("Content-Length")
+
+		err := c.Next()
+		switch {
+		case err == nil:
+			return nil
+		case body.tooLarge:
+			return c.Problem(fursy.NewProblem(http.StatusRequestEntityTooLarge, "Content Too Large",
+				"decompressed request body exceeds the allowed size"))
+		case body.corrupt:
+			return c.Problem(fursy.BadRequest("invalid compressed request body"))
+		default:
+			return err
+		}
+	}
+}
+
+// gzipDecoder decodes gzip data.
+func gzipDecoder(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+// deflateDecoder decodes HTTP "deflate" data (zlib format, RFC 9110).
+func deflateDecoder(r io.Reader) (io.ReadCloser, error) {
+	return zlib.NewReader(r)
+}
+
+// parseContentEncoding returns the lowercase codings of the Content-Encoding
+// header lines, ignoring "identity".
+func parseContentEncoding(values []string) []string {
+	var encodings []string
+	for _, enc := range strings.Split(strings.Join(values, ","), ",") {
+		enc = strings.ToLower(strings.TrimSpace(enc))
+		if enc != "" && enc != "identity" {
+			encodings = append(encodings, enc)
+		}
+	}
+	return encodings
+}
+
+// closeAll closes decoders in reverse order.
+func closeAll(closers []io.Closer) {
+	for i := len(closers) - 1; i >= 0; i-- {
+		_ = closers[i].Close()
+	}
+}
+
+// countingReader counts the bytes read from the compressed body.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read implements io.Reader.
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// decompressReader enforces size and ratio limits on the decompressed body.
+type decompressReader struct {
+	r          io.Reader
+	original   io.Closer
+	closers    []io.Closer
+	compressed *countingReader
+	n          int64
+	maxSize    int64
+	maxRatio   float64
+
+	// tooLarge and corrupt record why reading failed.
+	tooLarge bool
+	corrupt  bool
+}
+
+// Read implements io.Reader.
+func (d *decompressReader) Read(p []byte) (int, error) {
+	if d.tooLarge {
+		return 0, ErrDecompressedBodyTooLarge
+	}
+
+	n, err := d.r.Read(p)
+	d.n += int64(n)
+
+	if d.maxSize > 0 && d.n > d.maxSize {
+		d.tooLarge = true
+		return 0, ErrDecompressedBodyTooLarge
+	}
+	if d.maxRatio > 0 && d.n > decompressRatioThreshold &&
+		float64(d.n) > d.maxRatio*float64(max(d.compressed.n, 1)) {
+		d.tooLarge = true
+		return 0, ErrDecompressedBodyTooLarge
+	}
+
+	if err != nil && err != io.EOF {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			d.tooLarge = true
+		} else {
+			d.corrupt = true
+		}
+	}
+	return n, err
+}
+
+// Close closes the decoders and the original body.
+func (d *decompressReader) Close() error {
+	closeAll(d.closers)
+	return d.original.Close()
+}
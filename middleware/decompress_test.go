@@ -0,0 +1,215 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/coregx/fursy"
+)
+
+func gzipData(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	_ = zw.Close()
+	return buf.Bytes()
+}
+
+func zlibData(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	_ = zw.Close()
+	return buf.Bytes()
+}
+
+// newDecompressRouter creates a router that echoes the request body.
+func newDecompressRouter(config DecompressConfig) *fursy.Router {
+	r := fursy.New()
+	r.Use(DecompressWithConfig(config))
+	r.POST("/ingest", func(c *fursy.Context) error {
+		data, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, c.Request.Header.Get("Content-Encoding")+"|"+string(data))
+	})
+	return r
+}
+
+// TestDecompress tests decoding of supported encodings.
+func TestDecompress(t *testing.T) {
+	r := newDecompressRouter(DecompressConfig{})
+	payload := []byte(`{"temperature":21.5}`)
+
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+	}{
+		{"gzip", "gzip", gzipData(t, payload)},
+		{"x-gzip", "x-gzip", gzipData(t, payload)},
+		{"deflate", "deflate", zlibData(t, payload)},
+		{"case insensitive", "GZIP", gzipData(t, payload)},
+		{"multiple", "deflate, gzip", gzipData(t, zlibData(t, payload))},
+		{"identity", "identity", payload},
+		{"none", "", payload},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/ingest", bytes.NewReader(tt.body))
+			if tt.encoding != "" {
+				req.Header.Set("Content-Encoding", tt.encoding)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			want := string(payload)
+			if tt.encoding == "identity" {
+				want = "identity|" + want
+			} else {
+				want = "|" + want
+			}
+			if w.Body.String() != want {
+				t.Errorf("expected %q, got %q", want, w.Body.String())
+			}
+		})
+	}
+
+	// Codings can be split across header lines.
+	req := httptest.NewRequest(http.MethodPost, "/ingest", bytes.NewReader(gzipData(t, zlibData(t, payload))))
+	req.Header.Add("Content-Encoding", "deflate")
+	req.Header.Add("Content-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if want := "|" + string(payload); w.Body.String() != want {
+		t.Errorf("expected %q for multiple header lines, got %q", want, w.Body.String())
+	}
+}
+
+// TestDecompress_Errors tests rejection of unsupported and corrupt bodies.
+func TestDecompress_Errors(t *testing.T) {
+	r := newDecompressRouter(DecompressConfig{})
+	valid := gzipData(t, []byte(strings.Repeat("data", 100)))
+
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+		want     int
+	}{
+		{"unsupported", "br", []byte("x"), http.StatusUnsupportedMediaType},
+		{"invalid header", "gzip", []byte("not gzip"), http.StatusBadRequest},
+		{"truncated", "gzip", valid[:len(valid)/2], http.StatusBadRequest},
+		{"too many encodings", "gzip, gzip, gzip", valid, http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/ingest", bytes.NewReader(tt.body))
+			req.Header.Set("Content-Encoding", tt.encoding)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+			if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/problem+json") {
+				t.Errorf("expected problem+json, got %q", ct)
+			}
+		})
+	}
+}
+
+// TestDecompress_Limits tests size and ratio protection against decompression bombs.
+func TestDecompress_Limits(t *testing.T) {
+	bomb := gzipData(t, make([]byte, 1<<20)) // 1 MB of zeros, ~1 KB compressed.
+	random := make([]byte, 128<<10)
+	for i := range random {
+		random[i] = byte(i*7919 + i/251)
+	}
+
+	tests := []struct {
+		name   string
+		config DecompressConfig
+		body   []byte
+		want   int
+	}{
+		{"ratio exceeded", DecompressConfig{}, bomb, http.StatusRequestEntityTooLarge},
+		{"ratio disabled", DecompressConfig{MaxRatio: -1}, bomb, http.StatusOK},
+		{"size exceeded", DecompressConfig{MaxSize: 64 << 10, MaxRatio: -1}, bomb, http.StatusRequestEntityTooLarge},
+		{"within limits", DecompressConfig{MaxSize: 1 << 20}, gzipData(t, random), http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newDecompressRouter(tt.config)
+			req := httptest.NewRequest(http.MethodPost, "/ingest", bytes.NewReader(tt.body))
+			req.Header.Set("Content-Encoding", "gzip")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
+
+// TestDecompress_CustomDecoder tests registering an additional encoding.
+func TestDecompress_CustomDecoder(t *testing.T) {
+	r := newDecompressRouter(DecompressConfig{
+		Decoders: map[string]Decoder{
+			"upper": func(r io.Reader) (io.ReadCloser, error) {
+				data, err := io.ReadAll(r)
+				if err != nil {
+					return nil, err
+				}
+				return io.NopCloser(strings.NewReader(strings.ToUpper(string(data)))), nil
+			},
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/ingest", strings.NewReader("hello"))
+	req.Header.Set("Content-Encoding", "upper")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Body.String() != "|HELLO" {
+		t.Errorf("expected %q, got %q", "|HELLO", w.Body.String())
+	}
+}
+
+// TestDecompress_Skipper tests skipping the middleware.
+func TestDecompress_Skipper(t *testing.T) {
+	r := newDecompressRouter(DecompressConfig{Skipper: fursy.SkipPaths("/ingest")})
+
+	req := httptest.NewRequest(http.MethodPost, "/ingest", strings.NewReader("raw"))
+	req.Header.Set("Content-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Body.String() != "gzip|raw" {
+		t.Errorf("expected body to be passed through, got %q", w.Body.String())
+	}
+}
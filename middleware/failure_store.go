@@ -0,0 +1,220 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// failureStore tracks consecutive failed attempts per key (username,
+// account or IP) for BasicAuth and LoginThrottle.
+//
+// It keeps at most maxKeys keys. When it is full, the least recently
+// failed unlocked key is forgotten, so flooding the store with new keys
+// cannot unlock a locked one; a locked key is only forgotten when all the
+// keys are locked. Eviction is O(1).
+type failureStore struct {
+	mu       sync.Mutex
+	entries  map[string]*list.Element
+	unlocked list.List // *failureEntry, least recently failed first.
+	locked   list.List // *failureEntry, earliest locked first.
+	maxKeys  int
+
+	// window is the time after the last failure when the failures of an
+	// unlocked key are forgotten.
+	window time.Duration
+
+	// lockout returns how long a key is locked after its nth consecutive
+	// failure (0 = not locked).
+	lockout func(failures int) time.Duration
+}
+
+// failureEntry holds the failures of a key.
+type failureEntry struct {
+	key         string
+	failures    int
+	lastFailure time.Time
+	lockedUntil time.Time
+}
+
+// failureState is the state of a key.
+type failureState struct {
+	failures   int
+	retryAfter time.Duration
+}
+
+// newFailureStore creates a store of up to maxKeys keys.
+func newFailureStore(maxKeys int, window time.Duration, lockout func(failures int) time.Duration) *failureStore {
+	return &failureStore{
+		entries: make(map[string]*list.Element),
+		maxKeys: maxKeys,
+		window:  window,
+		lockout: lockout,
+	}
+}
+
+// state returns the current state of key.
+func (s *failureStore) state(key string) failureState {
+	if key == "" {
+		return failureState{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if e := s.entry(key, now); e != nil {
+		return e.state(now)
+	}
+	return failureState{}
+}
+
+// failureReservation is an attempt recorded as a failure by reserve.
+type failureReservation struct {
+	key   string
+	state failureState // State of the key after the attempt.
+
+	// lockedUntil is the lock set by the attempt, previousLock the lock
+	// it replaced.
+	lockedUntil  time.Time
+	previousLock time.Time
+}
+
+// reserve records an attempt of key as a failure before its outcome is
+// known, unless key is locked: checking and counting atomically keeps
+// concurrent attempts from all passing the lockout check. It returns
+// false with the state of key if key is locked. Attempts that succeed
+// are refunded.
+func (s *failureStore) reserve(key string) (failureReservation, bool) {
+	if key == "" {
+		return failureReservation{}, true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	e := s.entry(key, now)
+	if e == nil {
+		e = s.add(key)
+	} else if now.Before(e.lockedUntil) {
+		return failureReservation{key: key, state: e.state(now)}, false
+	}
+
+	previousLock := e.lockedUntil
+	s.record(e, now)
+	return failureReservation{key: key, state: e.state(now), lockedUntil: e.lockedUntil, previousLock: previousLock}, true
+}
+
+// refund cancels the failure recorded by the reservation r, including
+// the lock it set unless a later failure locked the key again.
+func (s *failureStore) refund(r failureReservation) {
+	if r.key == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	e := s.entry(r.key, now)
+	if e == nil {
+		return
+	}
+	e.failures = max(e.failures-1, 0)
+	if e.lockedUntil.Equal(r.lockedUntil) {
+		e.lockedUntil = r.previousLock
+	}
+	if e.failures == 0 && !now.Before(e.lockedUntil) {
+		s.remove(s.entries[r.key])
+		return
+	}
+	s.touch(e, now)
+}
+
+// reset forgets the failures of key.
+func (s *failureStore) reset(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.entries[key]; ok {
+		s.remove(elem)
+	}
+}
+
+// add adds an entry for key, evicting a key if the store is full.
+// It must be called with mu held.
+func (s *failureStore) add(key string) *failureEntry {
+	if len(s.entries) >= s.maxKeys {
+		s.evict()
+	}
+	return &failureEntry{key: key}
+}
+
+// record records a failure of e at now. It must be called with mu held.
+func (s *failureStore) record(e *failureEntry, now time.Time) {
+	e.failures++
+	e.lastFailure = now
+	if lockout := s.lockout(e.failures); lockout > 0 {
+		e.lockedUntil = now.Add(lockout)
+	}
+	s.touch(e, now)
+}
+
+// entry returns the entry of key, nil if its failures are forgotten.
+// It must be called with mu held.
+func (s *failureStore) entry(key string, now time.Time) *failureEntry {
+	elem, ok := s.entries[key]
+	if !ok {
+		return nil
+	}
+	e := elem.Value.(*failureEntry)
+	if now.Sub(e.lastFailure) > s.window && !now.Before(e.lockedUntil) {
+		s.remove(elem)
+		return nil
+	}
+	return e
+}
+
+// touch moves e to the back of the list matching its lock state.
+// It must be called with mu held.
+func (s *failureStore) touch(e *failureEntry, now time.Time) {
+	if elem, ok := s.entries[e.key]; ok {
+		s.unlocked.Remove(elem)
+		s.locked.Remove(elem)
+	}
+	l := &s.unlocked
+	if now.Before(e.lockedUntil) {
+		l = &s.locked
+	}
+	s.entries[e.key] = l.PushBack(e)
+}
+
+// evict forgets the least recently failed unlocked key, or the key
+// locked first if all the keys are locked. It must be called with mu held.
+func (s *failureStore) evict() {
+	elem := s.unlocked.Front()
+	if elem == nil {
+		elem = s.locked.Front()
+	}
+	if elem != nil {
+		s.remove(elem)
+	}
+}
+
+// remove forgets the key of elem. It must be called with mu held.
+func (s *failureStore) remove(elem *list.Element) {
+	// Remove is a no-op on the list elem does not belong to.
+	s.unlocked.Remove(elem)
+	s.locked.Remove(elem)
+	delete(s.entries, elem.Value.(*failureEntry).key)
+}
+
+// state returns the state of e at now.
+func (e *failureEntry) state(now time.Time) failureState {
+	return failureState{failures: e.failures, retryAfter: max(e.lockedUntil.Sub(now), 0)}
+}
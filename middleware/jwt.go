@@ -35,11 +35,19 @@ var (
 	ErrJWTNotValidYet = errors.New("jwt token not valid yet")
 )
 
+// Claim validation errors.
+var (
+	errJWTIssuer   = errors.New("invalid jwt issuer")
+	errJWTAudience = errors.New("invalid jwt audience")
+)
+
 // JWTConfig defines the configuration for the JWT middleware.
 type JWTConfig struct {
 	// SigningKey is the key used to validate JWT signatures.
 	// For HS256: []byte("secret-key")
 	// For RS256/ES256: *rsa.PublicKey, *ecdsa.PublicKey, or []byte(publicKeyPEM)
+	// For HMAC key rotation: a fursy.SecretSource; tokens signed with any of
+	// its secrets (current or previous) are accepted.
 	// Required.
 	SigningKey interface{}
 
@@ -50,8 +58,9 @@ type JWTConfig struct {
 	SigningMethod string
 
 	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
 	// Default: nil (middleware always executes)
-	Skipper func(c *fursy.Context) bool
+	Skipper fursy.Skipper
 
 	// TokenLookup is a string in the form of "<source>:<name>" that specifies
 	// where to extract the token from.
@@ -59,6 +68,8 @@ type JWTConfig struct {
 	//   - "header:<name>" - Authorization header (default: "header:Authorization")
 	//   - "query:<name>" - URL query parameter
 	//   - "cookie:<name>" - Cookie
+	// Multiple sources can be comma-separated and are tried in order
+	// (e.g., "header:Authorization,query:access_token").
 	// Default: "header:Authorization"
 	TokenLookup string
 
@@ -130,6 +141,10 @@ type JWTConfig struct {
 //	}
 //	router.Use(middleware.JWTWithConfig(config))
 //
+// Example with key rotation (HS256):
+//
+//	router.Use(middleware.JWT(fursy.EnvSecret("JWT_SECRET", "JWT_SECRET_PREVIOUS")))
+//
 // Access claims in handlers:
 //
 //	router.GET("/protected", func(c *fursy.Context) error {
@@ -194,6 +209,13 @@ func JWTWithConfig(config JWTConfig) fursy.HandlerFunc {
 		config.ErrorHandler = defaultJWTErrorHandler
 	}
 
+	// Report validation failures to Metrics.
+	errorHandler := config.ErrorHandler
+	config.ErrorHandler = func(c *fursy.Context, err error) error {
+		currentMetrics().JWTValidationFailed(c, jwtFailureReason(err))
+		return errorHandler(c, err)
+	}
+
 	// Build allowed algorithms map for efficient lookup.
 	allowedAlgos := make(map[string]bool)
 	if len(config.AllowedAlgorithms) > 0 {
@@ -215,21 +237,21 @@ func JWTWithConfig(config JWTConfig) fursy.HandlerFunc {
 	}
 
 	// Parse TokenLookup.
-	parts := strings.Split(config.TokenLookup, ":")
-	if len(parts) != 2 {
-		panic("fursy/middleware: invalid TokenLookup format (expected '<source>:<name>')")
-	}
-	extractorSource := parts[0]
-	extractorParam := parts[1]
+	lookups := parseTokenLookup(config.TokenLookup)
 
 	return func(c *fursy.Context) error {
 		// Skip if Skipper returns true.
-		if config.Skipper != nil && config.Skipper(c) {
+		if config.Skipper.ShouldSkip(c) {
 			return c.Next()
 		}
 
 		// Extract token.
-		tokenString := extractToken(c, extractorSource, extractorParam, config.AuthScheme)
+		var tokenString string
+		for _, l := range lookups {
+			if tokenString = extractToken(c, l.source, l.param, config.AuthScheme); tokenString != "" {
+				break
+			}
+		}
 		if tokenString == "" {
 			return config.ErrorHandler(c, ErrJWTMissing)
 		}
@@ -248,7 +270,7 @@ func JWTWithConfig(config JWTConfig) fursy.HandlerFunc {
 				return nil, fmt.Errorf("%w: expected %s, got %s", ErrJWTAlgorithm, config.SigningMethod, alg)
 			}
 
-			return config.SigningKey, nil
+			return verificationKey(config.SigningKey)
 		})
 
 		if err != nil {
@@ -269,14 +291,14 @@ func JWTWithConfig(config JWTConfig) fursy.HandlerFunc {
 		// Validate issuer if configured.
 		if config.ValidateIssuer != "" {
 			if !validateClaim(claims, "iss", config.ValidateIssuer) {
-				return config.ErrorHandler(c, errors.New("invalid jwt issuer"))
+				return config.ErrorHandler(c, errJWTIssuer)
 			}
 		}
 
 		// Validate audience if configured.
 		if config.ValidateAudience != "" {
 			if !validateClaim(claims, "aud", config.ValidateAudience) {
-				return config.ErrorHandler(c, errors.New("invalid jwt audience"))
+				return config.ErrorHandler(c, errJWTAudience)
 			}
 		}
 
@@ -295,6 +317,52 @@ func JWTWithConfig(config JWTConfig) fursy.HandlerFunc {
 	}
 }
 
+// tokenLookup is a single "<source>:<name>" entry of JWTConfig.TokenLookup.
+type tokenLookup struct {
+	source string
+	param  string
+}
+
+// verificationKey returns the key used to verify tokens.
+// A fursy.SecretSource yields a key set of all its secrets, which the
+// parser tries in order.
+func verificationKey(signingKey interface{}) (interface{}, error) {
+	src, ok := signingKey.(fursy.SecretSource)
+	if !ok {
+		return signingKey, nil
+	}
+
+	secrets, err := src.Secrets()
+	if err != nil {
+		return nil, err
+	}
+
+	// Empty secrets of custom sources are never accepted.
+	keys := make([]jwt.VerificationKey, 0, len(secrets))
+	for _, secret := range secrets {
+		if len(secret) > 0 {
+			keys = append(keys, secret)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fursy.ErrSecretEmpty
+	}
+	return jwt.VerificationKeySet{Keys: keys}, nil
+}
+
+// parseTokenLookup parses a comma-separated list of "<source>:<name>" entries.
+func parseTokenLookup(value string) []tokenLookup {
+	var lookups []tokenLookup
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) != 2 {
+			panic("fursy/middleware: invalid TokenLookup format (expected '<source>:<name>')")
+		}
+		lookups = append(lookups, tokenLookup{source: parts[0], param: parts[1]})
+	}
+	return lookups
+}
+
 // extractToken extracts the JWT token from the request based on the configured source.
 func extractToken(c *fursy.Context, source, param, authScheme string) string {
 	switch source {
@@ -391,6 +459,7 @@ func defaultJWTErrorHandler(c *fursy.Context, err error) error {
 type JWTHelper struct{}
 
 // GenerateToken generates a new JWT token with the provided claims and signing key.
+// If signingKey is a fursy.SecretSource, its current secret is used.
 //
 // Example:
 //
@@ -430,6 +499,15 @@ func (JWTHelper) GenerateToken(claims jwt.Claims, signingKey interface{}, method
 		return "", fmt.Errorf("unsupported signing method: %s", method)
 	}
 
+	// Sign with the current secret of a SecretSource.
+	if src, ok := signingKey.(fursy.SecretSource); ok {
+		key, err := fursy.CurrentSecret(src)
+		if err != nil {
+			return "", err
+		}
+		signingKey = key
+	}
+
 	token := jwt.NewWithClaims(signingMethod, claims)
 	return token.SignedString(signingKey)
 }
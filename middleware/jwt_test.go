@@ -1062,3 +1062,44 @@ func TestJWTHelper_GenerateToken_ECDSA_Methods(t *testing.T) {
 		})
 	}
 }
+
+func TestJWT_SecretSourceRotation(t *testing.T) {
+	oldToken := generateValidToken([]byte("old-secret"), "HS256")
+	newToken, err := JWTHelper{}.GenerateToken(jwt.MapClaims{
+		"sub": testSubject,
+		"exp": time.Now().Add(time.Minute).Unix(),
+	}, fursy.StaticSecrets([]byte("new-secret"), []byte("old-secret")), "HS256")
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	otherToken := generateValidToken([]byte("other-secret"), "HS256")
+
+	router := fursy.New()
+	router.Use(JWT(fursy.StaticSecrets([]byte("new-secret"), []byte("old-secret"))))
+	router.GET("/protected", func(c *fursy.Context) error {
+		return c.String(200, "ok")
+	})
+
+	tests := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{"current key", newToken, 200},
+		{"previous key", oldToken, 200},
+		{"unknown key", otherToken, 401},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/protected", http.NoBody)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, rec.Code)
+			}
+		})
+	}
+}
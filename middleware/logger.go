@@ -22,13 +22,21 @@ type LoggerConfig struct {
 	// If nil, a default logger writing to os.Stdout will be created.
 	Logger *slog.Logger
 
-	// SkipPaths is a list of URL paths to skip logging.
+	// SkipPaths is a list of URL path patterns to skip logging.
 	// Useful for health checks, metrics endpoints, etc.
+	// Supports the fursy.SkipPaths pattern syntax (e.g., "/health", "/static/*").
 	SkipPaths []string
 
 	// SkipFunc is a custom function to determine if a request should be skipped.
 	// If both SkipPaths and SkipFunc are provided, a request is skipped if either matches.
+	//
+	// Deprecated: Use Skipper instead.
 	SkipFunc func(*http.Request) bool
+
+	// Skipper defines a function to skip the middleware.
+	// Combined with SkipPaths and SkipFunc: a request is skipped if any matches.
+	// Default: nil (middleware always executes)
+	Skipper fursy.Skipper
 }
 
 // Logger returns a middleware that logs HTTP requests using structured logging (slog).
@@ -74,19 +82,21 @@ func LoggerWithConfig(config LoggerConfig) fursy.HandlerFunc {
 		}))
 	}
 
-	// Pre-compile skip paths map for O(1) lookup
-	skipPaths := make(map[string]bool, len(config.SkipPaths))
-	for _, path := range config.SkipPaths {
-		skipPaths[path] = true
+	// Combine skip paths, legacy SkipFunc and Skipper into a single Skipper
+	skipper := config.Skipper
+	if len(config.SkipPaths) > 0 {
+		skipper = skipper.Or(fursy.SkipPaths(config.SkipPaths...))
+	}
+	if config.SkipFunc != nil {
+		skipFunc := config.SkipFunc
+		skipper = skipper.Or(func(c *fursy.Context) bool {
+			return skipFunc(c.Request)
+		})
 	}
 
 	return func(c *fursy.Context) error {
 		// Check if request should be skipped
-		if skipPaths[c.Request.URL.Path] {
-			return c.Next()
-		}
-
-		if config.SkipFunc != nil && config.SkipFunc(c.Request) {
+		if skipper.ShouldSkip(c) {
 			return c.Next()
 		}
 
@@ -120,6 +130,25 @@ func LoggerWithConfig(config LoggerConfig) fursy.HandlerFunc {
 			slog.Int64("bytes", lrw.bytesWritten),
 		}
 
+		// Add split route variant if present
+		if variant := c.Variant(); variant != "" {
+			attrs = append(attrs, slog.String("variant", variant))
+		}
+
+		// Add per-middleware self time (ms) if recorded (see fursy.Named
+		// and Router.SetMiddlewareTiming)
+		if timings := middlewareTimings(c); len(timings) > 0 {
+			attrs = append(attrs, slog.Group("middleware", timings...))
+		}
+
+		// Add bot detection decision if present
+		if bot := Bot(c); bot.Action != BotAllow {
+			attrs = append(attrs,
+				slog.String("bot_action", bot.Action.String()),
+				slog.String("bot_detector", bot.Detector),
+			)
+		}
+
 		// Add error if present
 		if err != nil {
 			attrs = append(attrs, slog.String("error", err.Error()))
@@ -261,3 +290,16 @@ func JSONLogger(w io.Writer) *slog.Logger {
 		Level: slog.LevelInfo,
 	}))
 }
+
+// middlewareTimings returns the self time in milliseconds of each
+// completed middleware run of the request.
+func middlewareTimings(c *fursy.Context) []any {
+	runs := c.MiddlewareRuns()
+	timings := make([]any, 0, len(runs))
+	for _, run := range runs {
+		if run.Duration > 0 {
+			timings = append(timings, slog.Float64(run.Name, float64(run.Self.Nanoseconds())/1e6))
+		}
+	}
+	return timings
+}
@@ -6,11 +6,13 @@ package middleware
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/coregx/fursy"
 )
@@ -91,6 +93,31 @@ func TestLogger_JSONFormat(t *testing.T) {
 	}
 }
 
+// TestLogger_Variant tests logging the variant of a split route.
+func TestLogger_Variant(t *testing.T) {
+	var buf bytes.Buffer
+
+	r := fursy.New()
+	r.Use(LoggerWithConfig(LoggerConfig{
+		Logger: JSONLogger(&buf),
+	}))
+
+	ok := func(c *fursy.Context) error { return c.NoContent(204) }
+	r.Split("GET", "/checkout", ok, ok, fursy.SplitPolicy{Percentage: 100})
+	r.GET("/plain", ok)
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/checkout", http.NoBody))
+	if !strings.Contains(buf.String(), `"variant":"canary"`) {
+		t.Errorf("JSON log should contain variant field, got %s", buf.String())
+	}
+
+	buf.Reset()
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/plain", http.NoBody))
+	if strings.Contains(buf.String(), "variant") {
+		t.Errorf("JSON log should not contain variant for plain routes, got %s", buf.String())
+	}
+}
+
 // TestLogger_SkipPaths tests skipping specified paths.
 func TestLogger_SkipPaths(t *testing.T) {
 	var buf bytes.Buffer
@@ -172,6 +199,42 @@ func TestLogger_SkipFunc(t *testing.T) {
 	}
 }
 
+// TestLogger_Skipper tests pattern-based skipping with fursy.Skipper.
+func TestLogger_Skipper(t *testing.T) {
+	var buf bytes.Buffer
+	logger := DefaultLogger(&buf)
+
+	r := fursy.New()
+	r.Use(LoggerWithConfig(LoggerConfig{
+		Logger:  logger,
+		Skipper: fursy.SkipPaths("/static/*"),
+	}))
+
+	r.GET("/static/*path", func(c *fursy.Context) error {
+		return c.String(200, "asset")
+	})
+
+	r.GET("/api/users", func(c *fursy.Context) error {
+		return c.String(200, "users")
+	})
+
+	// Request to skipped pattern
+	req1 := httptest.NewRequest("GET", "/static/js/app.js", http.NoBody)
+	r.ServeHTTP(httptest.NewRecorder(), req1)
+
+	if buf.String() != "" {
+		t.Error("requests matching /static/* should not be logged")
+	}
+
+	// Request to normal path
+	req2 := httptest.NewRequest("GET", "/api/users", http.NoBody)
+	r.ServeHTTP(httptest.NewRecorder(), req2)
+
+	if !strings.Contains(buf.String(), "/api/users") {
+		t.Error("normal path /api/users should be logged")
+	}
+}
+
 // TestLogger_StatusCodes tests different status code handling.
 func TestLogger_StatusCodes(t *testing.T) {
 	tests := []struct {
@@ -491,3 +554,37 @@ func TestLogger_IntegrationWithGroups(t *testing.T) {
 		t.Errorf("log should contain full path with group prefix, got: %s", output)
 	}
 }
+
+// TestLogger_MiddlewareTimings tests the per-middleware latency breakdown.
+func TestLogger_MiddlewareTimings(t *testing.T) {
+	var buf bytes.Buffer
+
+	r := fursy.New()
+	r.SetMiddlewareTiming(true)
+	r.Use(LoggerWithConfig(LoggerConfig{
+		Logger: JSONLogger(&buf),
+	}))
+	r.Use(fursy.Named("slow", func(c *fursy.Context) error {
+		time.Sleep(10 * time.Millisecond)
+		return c.Next()
+	}))
+	r.GET("/", func(c *fursy.Context) error { return c.NoContent(204) })
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", http.NoBody))
+
+	var entry struct {
+		Middleware map[string]float64 `json:"middleware"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Middleware["slow"] < 10 {
+		t.Errorf("expected slow middleware self time >= 10ms, got %v", entry.Middleware)
+	}
+	if _, ok := entry.Middleware["middleware.LoggerWithConfig"]; ok {
+		t.Errorf("the running logger should not be reported, got %v", entry.Middleware)
+	}
+	if len(entry.Middleware) != 2 {
+		t.Errorf("expected slow middleware and handler, got %v", entry.Middleware)
+	}
+}
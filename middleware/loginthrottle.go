@@ -0,0 +1,344 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package middleware provides login throttling (brute-force protection) middleware.
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// Default values for the LoginThrottle middleware.
+const (
+	// DefaultLoginMaxAccountFailures is the default number of failures per account before lockout.
+	DefaultLoginMaxAccountFailures = 5
+
+	// DefaultLoginMaxIPFailures is the default number of failures per IP before lockout.
+	DefaultLoginMaxIPFailures = 20
+
+	// DefaultLoginBaseLockout is the default duration of the first lockout.
+	DefaultLoginBaseLockout = time.Second
+
+	// DefaultLoginMaxLockout is the default maximum lockout duration.
+	DefaultLoginMaxLockout = 15 * time.Minute
+
+	// DefaultLoginWindow is the default time after which failures are forgotten.
+	DefaultLoginWindow = time.Hour
+)
+
+// maxLoginThrottleKeys bounds the number of accounts and IPs tracked.
+const maxLoginThrottleKeys = 100000
+
+// LoginEventType is the type of a security event reported by LoginThrottle.
+type LoginEventType string
+
+// Security events reported by LoginThrottle.
+const (
+	// LoginSucceeded is reported after a successful login.
+	LoginSucceeded LoginEventType = "login_succeeded"
+
+	// LoginFailed is reported after a failed login.
+	LoginFailed LoginEventType = "login_failed"
+
+	// LoginLockedOut is reported when an account or IP gets locked.
+	LoginLockedOut LoginEventType = "login_locked_out"
+
+	// LoginBlocked is reported when a request is rejected because of a lockout.
+	LoginBlocked LoginEventType = "login_blocked"
+
+	// LoginCaptchaRequired is reported when a request is rejected for a missing or invalid CAPTCHA.
+	LoginCaptchaRequired LoginEventType = "login_captcha_required"
+)
+
+// LoginEvent is a security event reported by LoginThrottle.
+type LoginEvent struct {
+	// Type is the event type.
+	Type LoginEventType
+
+	// Account is the account identifier (may be empty).
+	Account string
+
+	// IP is the client IP.
+	IP string
+
+	// Failures is the number of consecutive failures of the account
+	// (or the IP, if Account is empty).
+	Failures int
+
+	// RetryAfter is the remaining lockout duration
+	// (LoginLockedOut and LoginBlocked only).
+	RetryAfter time.Duration
+}
+
+// LoginThrottleConfig defines the configuration for the LoginThrottle middleware.
+type LoginThrottleConfig struct {
+	// AccountFunc extracts the account identifier (username, email) from the request.
+	// Return "" if unknown; then only the IP is throttled.
+	// Required.
+	AccountFunc func(c *fursy.Context) string
+
+	// IPFunc extracts the client IP.
+	// Default: c.ClientIP() (trusted-proxy aware)
+	IPFunc func(c *fursy.Context) string
+
+	// MaxAccountFailures is the number of consecutive failures per account
+	// before it is locked. Protects single accounts from password guessing.
+	// Default: 5
+	MaxAccountFailures int
+
+	// MaxIPFailures is the number of consecutive failures per IP before it
+	// is locked. Protects against password spraying across accounts.
+	// Default: 20
+	MaxIPFailures int
+
+	// BaseLockout is the duration of the first lockout. Every further failure
+	// doubles it (exponential backoff), up to MaxLockout.
+	// Default: 1 second
+	BaseLockout time.Duration
+
+	// MaxLockout is the maximum lockout duration.
+	// Default: 15 minutes
+	MaxLockout time.Duration
+
+	// Window is the time after the last failure when failures are forgotten.
+	// Default: 1 hour
+	Window time.Duration
+
+	// CaptchaAfter requires a CAPTCHA once an account or IP has this many
+	// consecutive failures. CaptchaFunc must be set.
+	// Default: 0 (no CAPTCHA)
+	CaptchaAfter int
+
+	// CaptchaFunc verifies the CAPTCHA of the request (e.g., by calling the
+	// reCAPTCHA or hCaptcha API with a token from the request).
+	// Default: nil
+	CaptchaFunc func(c *fursy.Context) bool
+
+	// IsFailure reports whether the login failed, given the response status
+	// and the handler's error.
+	// Default: status 401 or 403, or err is fursy.ErrUnauthorized
+	// or a 401/403 fursy.Problem
+	IsFailure func(c *fursy.Context, status int, err error) bool
+
+	// OnEvent is called for every security event (e.g., for audit logging or alerting).
+	// Default: nil
+	OnEvent func(c *fursy.Context, event LoginEvent)
+
+	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
+	// Default: nil (middleware always executes)
+	Skipper fursy.Skipper
+
+	// ErrorHandler is called when a request is rejected because of a lockout.
+	// Default: 429 Too Many Requests problem with Retry-After
+	ErrorHandler func(c *fursy.Context, retryAfter time.Duration) error
+
+	// CaptchaHandler is called when a CAPTCHA is required but missing or invalid.
+	// Default: 403 Forbidden problem
+	CaptchaHandler func(c *fursy.Context) error
+}
+
+// LoginThrottle returns a middleware that protects login endpoints against
+// brute-force attacks.
+//
+// Unlike RateLimit, it only counts failed logins, per account and per IP.
+// After too many consecutive failures the account or IP is locked with
+// exponential backoff; a successful login resets the account's counter.
+// The handler's outcome is detected from the response status (401/403
+// are failures, see LoginThrottleConfig.IsFailure).
+//
+// Example:
+//
+//	auth := router.Group("/auth", middleware.LoginThrottle(func(c *fursy.Context) string {
+//	    return c.Request.FormValue("email")
+//	}))
+//	auth.POST("/login", login)
+func LoginThrottle(accountFunc func(c *fursy.Context) string) fursy.HandlerFunc {
+	return LoginThrottleWithConfig(LoginThrottleConfig{
+		AccountFunc: accountFunc,
+	})
+}
+
+// LoginThrottleWithConfig returns a middleware with custom configuration.
+//
+// Example (CAPTCHA after 3 failures, audit log):
+//
+//	auth := router.Group("/auth", middleware.LoginThrottleWithConfig(middleware.LoginThrottleConfig{
+//	    AccountFunc:  func(c *fursy.Context) string { return c.Request.FormValue("email") },
+//	    CaptchaAfter: 3,
+//	    CaptchaFunc:  func(c *fursy.Context) bool { return verifyCaptcha(c.Request.FormValue("captcha")) },
+//	    OnEvent: func(c *fursy.Context, e middleware.LoginEvent) {
+//	        slog.Warn("auth event", "type", e.Type, "account", e.Account, "ip", e.IP, "failures", e.Failures)
+//	    },
+//	}))
+//	auth.POST("/login", login)
+func LoginThrottleWithConfig(config LoginThrottleConfig) fursy.HandlerFunc {
+	// Validate config.
+	if config.AccountFunc == nil {
+		panic("fursy/middleware: LoginThrottle AccountFunc cannot be nil")
+	}
+	if config.CaptchaAfter > 0 && config.CaptchaFunc == nil {
+		panic("fursy/middleware: LoginThrottle CaptchaAfter requires CaptchaFunc")
+	}
+
+	// Set defaults.
+	if config.IPFunc == nil {
+		config.IPFunc = func(c *fursy.Context) string {
+			return c.ClientIP()
+		}
+	}
+	if config.MaxAccountFailures <= 0 {
+		config.MaxAccountFailures = DefaultLoginMaxAccountFailures
+	}
+	if config.MaxIPFailures <= 0 {
+		config.MaxIPFailures = DefaultLoginMaxIPFailures
+	}
+	if config.BaseLockout <= 0 {
+		config.BaseLockout = DefaultLoginBaseLockout
+	}
+	if config.MaxLockout <= 0 {
+		config.MaxLockout = DefaultLoginMaxLockout
+	}
+	if config.Window <= 0 {
+		config.Window = DefaultLoginWindow
+	}
+	if config.IsFailure == nil {
+		config.IsFailure = defaultLoginIsFailure
+	}
+	if config.OnEvent == nil {
+		config.OnEvent = func(*fursy.Context, LoginEvent) {}
+	}
+	if config.ErrorHandler == nil {
+		config.ErrorHandler = defaultLoginThrottleErrorHandler
+	}
+	if config.CaptchaHandler == nil {
+		config.CaptchaHandler = defaultLoginCaptchaHandler
+	}
+
+	accounts := newLoginThrottleStore(config.MaxAccountFailures, config)
+	ips := newLoginThrottleStore(config.MaxIPFailures, config)
+
+	// blocked rejects a request of a locked account or IP.
+	blocked := func(c *fursy.Context, event LoginEvent, accountState, ipState failureState) error {
+		event.Type = LoginBlocked
+		event.Failures = max(accountState.failures, ipState.failures)
+		event.RetryAfter = max(accountState.retryAfter, ipState.retryAfter)
+		config.OnEvent(c, event)
+		return config.ErrorHandler(c, event.RetryAfter)
+	}
+
+	return func(c *fursy.Context) error {
+		// Skip if Skipper returns true.
+		if config.Skipper.ShouldSkip(c) {
+			return c.Next()
+		}
+
+		account := config.AccountFunc(c)
+		ip := config.IPFunc(c)
+		event := LoginEvent{Account: account, IP: ip}
+
+		// Reject locked accounts and IPs.
+		accountState := accounts.state(account)
+		ipState := ips.state(ip)
+		if max(accountState.retryAfter, ipState.retryAfter) > 0 {
+			return blocked(c, event, accountState, ipState)
+		}
+
+		// Require a CAPTCHA after repeated failures.
+		if config.CaptchaAfter > 0 && max(accountState.failures, ipState.failures) >= config.CaptchaAfter {
+			if !config.CaptchaFunc(c) {
+				event.Type, event.Failures = LoginCaptchaRequired, max(accountState.failures, ipState.failures)
+				config.OnEvent(c, event)
+				return config.CaptchaHandler(c)
+			}
+		}
+
+		// Count the attempt as a failure before the handler runs, so that
+		// concurrent guesses cannot all pass the lockout check. It is
+		// refunded if the login succeeds.
+		accountAttempt, ok := accounts.reserve(account)
+		if !ok {
+			return blocked(c, event, accountAttempt.state, ipState)
+		}
+		ipAttempt, ok := ips.reserve(ip)
+		if !ok {
+			accounts.refund(accountAttempt)
+			return blocked(c, event, accountState, ipAttempt.state)
+		}
+
+		w := &cbResponseWriter{ResponseWriter: c.Response}
+		c.Response = w
+		err := c.Next()
+		c.Response = w.ResponseWriter
+
+		if !config.IsFailure(c, w.Status(), err) {
+			accounts.reset(account)
+			ips.refund(ipAttempt)
+			event.Type = LoginSucceeded
+			config.OnEvent(c, event)
+			return err
+		}
+
+		accountState, ipState = accountAttempt.state, ipAttempt.state
+		event.Type, event.Failures = LoginFailed, accountState.failures
+		if account == "" {
+			event.Failures = ipState.failures
+		}
+		config.OnEvent(c, event)
+
+		if retryAfter := max(accountState.retryAfter, ipState.retryAfter); retryAfter > 0 {
+			event.Type, event.RetryAfter = LoginLockedOut, retryAfter
+			config.OnEvent(c, event)
+		}
+
+		return err
+	}
+}
+
+// defaultLoginIsFailure treats 401/403 responses and errors as failed logins.
+func defaultLoginIsFailure(_ *fursy.Context, status int, err error) bool {
+	if status == http.StatusUnauthorized || status == http.StatusForbidden {
+		return true
+	}
+	if errors.Is(err, fursy.ErrUnauthorized) || errors.Is(err, fursy.ErrForbidden) {
+		return true
+	}
+	var p fursy.Problem
+	if errors.As(err, &p) {
+		return p.Status == http.StatusUnauthorized || p.Status == http.StatusForbidden
+	}
+	return false
+}
+
+// defaultLoginThrottleErrorHandler sends a 429 Too Many Requests problem.
+func defaultLoginThrottleErrorHandler(c *fursy.Context, retryAfter time.Duration) error {
+	c.SetHeader("Retry-After", strconv.Itoa(int((retryAfter+time.Second-1)/time.Second)))
+	return c.Problem(fursy.TooManyRequests("too many failed login attempts"))
+}
+
+// defaultLoginCaptchaHandler sends a 403 Forbidden problem.
+func defaultLoginCaptchaHandler(c *fursy.Context) error {
+	return c.Problem(fursy.Forbidden("captcha required"))
+}
+
+// newLoginThrottleStore creates the store of accounts or IPs, locking a
+// key from maxFailures consecutive failures on for
+// BaseLockout * 2^(failures-maxFailures), up to MaxLockout.
+func newLoginThrottleStore(maxFailures int, config LoginThrottleConfig) *failureStore {
+	return newFailureStore(maxLoginThrottleKeys, config.Window, func(failures int) time.Duration {
+		if failures < maxFailures {
+			return 0
+		}
+		lockout := config.BaseLockout
+		for i := maxFailures; i < failures && lockout < config.MaxLockout; i++ {
+			lockout *= 2
+		}
+		return min(lockout, config.MaxLockout)
+	})
+}
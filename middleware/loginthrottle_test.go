@@ -0,0 +1,279 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// newLoginRouter creates a router with a login endpoint accepting password "secret".
+func newLoginRouter(config LoginThrottleConfig) *fursy.Router {
+	if config.AccountFunc == nil {
+		config.AccountFunc = func(c *fursy.Context) string {
+			return c.Request.FormValue("user")
+		}
+	}
+
+	r := fursy.New()
+	r.Use(LoginThrottleWithConfig(config))
+	r.POST("/login", func(c *fursy.Context) error {
+		if c.Request.FormValue("password") != "secret" {
+			return c.Problem(fursy.Unauthorized("invalid credentials"))
+		}
+		return c.String(http.StatusOK, "welcome")
+	})
+	return r
+}
+
+// login posts credentials from ip.
+func login(r *fursy.Router, ip, user, password string, extra ...string) *httptest.ResponseRecorder {
+	form := url.Values{"user": {user}, "password": {password}}
+	for i := 0; i+1 < len(extra); i += 2 {
+		form.Set(extra[i], extra[i+1])
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.RemoteAddr = ip + ":1234"
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestLoginThrottle_AccountLockout(t *testing.T) {
+	r := newLoginRouter(LoginThrottleConfig{
+		MaxAccountFailures: 3,
+		BaseLockout:        time.Minute,
+	})
+
+	for range 3 {
+		if w := login(r, "10.0.0.1", "alice", "wrong"); w.Code != http.StatusUnauthorized {
+			t.Fatalf("expected status 401, got %d", w.Code)
+		}
+	}
+
+	// Locked, even with the correct password and from another IP.
+	w := login(r, "10.0.0.2", "alice", "secret")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", w.Code)
+	}
+	if retry := w.Header().Get("Retry-After"); retry != "60" {
+		t.Errorf("expected Retry-After 60, got %q", retry)
+	}
+
+	// Other accounts are not affected.
+	if w := login(r, "10.0.0.1", "bob", "secret"); w.Code != http.StatusOK {
+		t.Errorf("expected status 200 for other account, got %d", w.Code)
+	}
+}
+
+func TestLoginThrottle_SuccessResets(t *testing.T) {
+	r := newLoginRouter(LoginThrottleConfig{MaxAccountFailures: 3})
+
+	for range 2 {
+		login(r, "10.0.0.1", "alice", "wrong")
+	}
+	if w := login(r, "10.0.0.1", "alice", "secret"); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	for range 2 {
+		login(r, "10.0.0.1", "alice", "wrong")
+	}
+	if w := login(r, "10.0.0.1", "alice", "secret"); w.Code != http.StatusOK {
+		t.Errorf("expected failures to be reset by success, got %d", w.Code)
+	}
+}
+
+func TestLoginThrottle_IPLockout(t *testing.T) {
+	r := newLoginRouter(LoginThrottleConfig{
+		MaxAccountFailures: 10,
+		MaxIPFailures:      3,
+	})
+
+	// Password spraying: one attempt per account.
+	for _, user := range []string{"a", "b", "c"} {
+		login(r, "10.0.0.1", user, "wrong")
+	}
+
+	if w := login(r, "10.0.0.1", "d", "secret"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected IP to be locked, got %d", w.Code)
+	}
+	if w := login(r, "10.0.0.2", "d", "secret"); w.Code != http.StatusOK {
+		t.Errorf("expected other IP to pass, got %d", w.Code)
+	}
+}
+
+func TestLoginThrottle_ExponentialBackoff(t *testing.T) {
+	store := newLoginThrottleStore(2, LoginThrottleConfig{
+		BaseLockout: time.Second,
+		MaxLockout:  5 * time.Second,
+		Window:      time.Hour,
+	})
+
+	want := []time.Duration{0, time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if got := store.lockout(i + 1); got != w {
+			t.Errorf("failure %d: expected lockout %v, got %v", i+1, w, got)
+		}
+	}
+}
+
+func TestLoginThrottle_Captcha(t *testing.T) {
+	r := newLoginRouter(LoginThrottleConfig{
+		MaxAccountFailures: 5,
+		CaptchaAfter:       2,
+		CaptchaFunc: func(c *fursy.Context) bool {
+			return c.Request.FormValue("captcha") == "ok"
+		},
+	})
+
+	// No CAPTCHA needed before the threshold.
+	for range 2 {
+		if w := login(r, "10.0.0.1", "alice", "wrong"); w.Code != http.StatusUnauthorized {
+			t.Fatalf("expected status 401, got %d", w.Code)
+		}
+	}
+
+	if w := login(r, "10.0.0.1", "alice", "secret"); w.Code != http.StatusForbidden {
+		t.Errorf("expected CAPTCHA to be required, got %d", w.Code)
+	}
+	if w := login(r, "10.0.0.1", "alice", "secret", "captcha", "ok"); w.Code != http.StatusOK {
+		t.Errorf("expected login with CAPTCHA, got %d", w.Code)
+	}
+}
+
+func TestLoginThrottle_Events(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []LoginEventType
+	)
+	r := newLoginRouter(LoginThrottleConfig{
+		MaxAccountFailures: 2,
+		OnEvent: func(_ *fursy.Context, e LoginEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, e.Type)
+			if e.Account != "alice" || e.IP != "10.0.0.1" {
+				t.Errorf("unexpected event %+v", e)
+			}
+		},
+	})
+
+	login(r, "10.0.0.1", "alice", "secret")
+	login(r, "10.0.0.1", "alice", "wrong")
+	login(r, "10.0.0.1", "alice", "wrong")
+	login(r, "10.0.0.1", "alice", "secret")
+
+	want := []LoginEventType{LoginSucceeded, LoginFailed, LoginFailed, LoginLockedOut, LoginBlocked}
+	if len(events) != len(want) {
+		t.Fatalf("expected events %v, got %v", want, events)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("event %d: expected %s, got %s", i, want[i], events[i])
+		}
+	}
+}
+
+func TestLoginThrottle_ErrorReturned(t *testing.T) {
+	r := fursy.New()
+	r.Use(LoginThrottleWithConfig(LoginThrottleConfig{
+		AccountFunc:        func(*fursy.Context) string { return "alice" },
+		MaxAccountFailures: 1,
+	}))
+	r.POST("/login", func(*fursy.Context) error {
+		return fursy.ErrUnauthorized
+	})
+
+	login(r, "10.0.0.1", "alice", "wrong")
+	if w := login(r, "10.0.0.1", "alice", "wrong"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected returned error to count as failure, got %d", w.Code)
+	}
+}
+
+func TestLoginThrottle_InvalidConfig(t *testing.T) {
+	for name, config := range map[string]LoginThrottleConfig{
+		"missing AccountFunc": {},
+		"captcha without func": {
+			AccountFunc:  func(*fursy.Context) string { return "" },
+			CaptchaAfter: 3,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			LoginThrottleWithConfig(config)
+		})
+	}
+}
+
+// TestLoginThrottle_ConcurrentGuesses tests that concurrent failed logins
+// cannot exceed MaxAccountFailures (run with -race).
+func TestLoginThrottle_ConcurrentGuesses(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		guesses int
+	)
+	r := fursy.New()
+	r.Use(LoginThrottleWithConfig(LoginThrottleConfig{
+		AccountFunc:        func(c *fursy.Context) string { return c.Request.FormValue("user") },
+		MaxAccountFailures: 3,
+		BaseLockout:        time.Minute,
+	}))
+	r.POST("/login", func(c *fursy.Context) error {
+		mu.Lock()
+		guesses++
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		if c.Request.FormValue("password") != "secret" {
+			return c.Problem(fursy.Unauthorized("invalid credentials"))
+		}
+		return c.String(http.StatusOK, "welcome")
+	})
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			login(r, "10.0.0."+strconv.Itoa(i), "alice", "guess")
+		}()
+	}
+	wg.Wait()
+
+	if guesses != 3 {
+		t.Errorf("handler ran %d guesses, want 3 (MaxAccountFailures)", guesses)
+	}
+	if w := login(r, "10.0.1.1", "alice", "secret"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429 after the guesses, got %d", w.Code)
+	}
+}
+
+// TestLoginThrottle_SuccessRefundsIP tests that successful logins do not
+// count against the IP.
+func TestLoginThrottle_SuccessRefundsIP(t *testing.T) {
+	r := newLoginRouter(LoginThrottleConfig{MaxIPFailures: 2})
+	for i := range 5 {
+		if w := login(r, "10.0.0.1", "user"+strconv.Itoa(i), "secret"); w.Code != http.StatusOK {
+			t.Fatalf("login %d: expected status 200, got %d", i, w.Code)
+		}
+	}
+	login(r, "10.0.0.1", "mallory", "wrong")
+	if w := login(r, "10.0.0.1", "bob", "secret"); w.Code != http.StatusOK {
+		t.Errorf("expected status 200 after one IP failure, got %d", w.Code)
+	}
+}
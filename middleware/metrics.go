@@ -0,0 +1,120 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package middleware provides metrics hooks for built-in middleware.
+package middleware
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/coregx/fursy"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWT validation failure reasons reported to Metrics.
+const (
+	JWTFailureMissing     = "missing"
+	JWTFailureExpired     = "expired"
+	JWTFailureNotValidYet = "not_valid_yet"
+	JWTFailureAlgorithm   = "algorithm"
+	JWTFailureSignature   = "signature"
+	JWTFailureMalformed   = "malformed"
+	JWTFailureIssuer      = "issuer"
+	JWTFailureAudience    = "audience"
+	JWTFailureInvalid     = "invalid"
+)
+
+// Metrics receives internal events from built-in middleware so operators
+// can alert on them (e.g., a circuit breaker opening or a spike of
+// rejected tokens).
+//
+// Implementations must be safe for concurrent use and fast: they are
+// called on the request path. The OpenTelemetry plugin provides one
+// (opentelemetry.MiddlewareMetrics), which can also be exported to
+// Prometheus with the OpenTelemetry Prometheus exporter.
+type Metrics interface {
+	// RateLimitRejected is called when RateLimit rejects a request.
+	RateLimitRejected(c *fursy.Context)
+
+	// CircuitBreakerStateChanged is called when the circuit breaker
+	// named name changes state.
+	CircuitBreakerStateChanged(name string, from, to State)
+
+	// CircuitBreakerShortCircuited is called when the circuit breaker
+	// named name rejects a request without calling the handler.
+	CircuitBreakerShortCircuited(c *fursy.Context, name string)
+
+	// JWTValidationFailed is called when JWT rejects a request.
+	// reason is one of the JWTFailure* constants.
+	JWTValidationFailed(c *fursy.Context, reason string)
+
+	// CORSPreflight is called for every CORS preflight request.
+	// allowed reports whether the origin and method were allowed.
+	CORSPreflight(c *fursy.Context, allowed bool)
+}
+
+// metricsHolder wraps the Metrics set by SetMetrics.
+type metricsHolder struct {
+	m Metrics
+}
+
+// middlewareMetrics holds the Metrics set by SetMetrics (nil: discard events).
+var middlewareMetrics atomic.Pointer[metricsHolder]
+
+// SetMetrics sets the Metrics that receives events from built-in middleware.
+// Passing nil disables reporting. It is safe to call at any time, but is
+// usually called once at startup.
+//
+// Example:
+//
+//	middleware.SetMetrics(opentelemetry.MiddlewareMetrics(nil))
+func SetMetrics(m Metrics) {
+	if m == nil {
+		middlewareMetrics.Store(nil)
+		return
+	}
+	middlewareMetrics.Store(&metricsHolder{m: m})
+}
+
+// currentMetrics returns the Metrics set by SetMetrics.
+func currentMetrics() Metrics {
+	if h := middlewareMetrics.Load(); h != nil {
+		return h.m
+	}
+	return nopMetrics{}
+}
+
+// nopMetrics is the default Metrics, discarding all events.
+type nopMetrics struct{}
+
+func (nopMetrics) RateLimitRejected(*fursy.Context)                    {}
+func (nopMetrics) CircuitBreakerStateChanged(string, State, State)     {}
+func (nopMetrics) CircuitBreakerShortCircuited(*fursy.Context, string) {}
+func (nopMetrics) JWTValidationFailed(*fursy.Context, string)          {}
+func (nopMetrics) CORSPreflight(*fursy.Context, bool)                  {}
+
+// jwtFailureReason classifies a JWT validation error.
+func jwtFailureReason(err error) string {
+	switch {
+	case errors.Is(err, ErrJWTMissing):
+		return JWTFailureMissing
+	case errors.Is(err, ErrJWTExpired), errors.Is(err, jwt.ErrTokenExpired):
+		return JWTFailureExpired
+	case errors.Is(err, ErrJWTNotValidYet), errors.Is(err, jwt.ErrTokenNotValidYet):
+		return JWTFailureNotValidYet
+	case errors.Is(err, ErrJWTNoneAlgo), errors.Is(err, ErrJWTAlgorithm):
+		return JWTFailureAlgorithm
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid):
+		return JWTFailureSignature
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return JWTFailureMalformed
+	case errors.Is(err, errJWTIssuer):
+		return JWTFailureIssuer
+	case errors.Is(err, errJWTAudience):
+		return JWTFailureAudience
+	default:
+		return JWTFailureInvalid
+	}
+}
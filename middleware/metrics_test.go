@@ -0,0 +1,162 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// recordingMetrics is a Metrics recording events as strings.
+type recordingMetrics struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (m *recordingMetrics) record(event string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, event)
+}
+
+func (m *recordingMetrics) Events() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.events)
+}
+
+func (m *recordingMetrics) RateLimitRejected(*fursy.Context) {
+	m.record("ratelimit")
+}
+
+func (m *recordingMetrics) CircuitBreakerStateChanged(name string, from, to State) {
+	m.record("cb:" + name + ":" + from.String() + "->" + to.String())
+}
+
+func (m *recordingMetrics) CircuitBreakerShortCircuited(_ *fursy.Context, name string) {
+	m.record("cb:" + name + ":short")
+}
+
+func (m *recordingMetrics) JWTValidationFailed(_ *fursy.Context, reason string) {
+	m.record("jwt:" + reason)
+}
+
+func (m *recordingMetrics) CORSPreflight(_ *fursy.Context, allowed bool) {
+	if allowed {
+		m.record("cors:allowed")
+	} else {
+		m.record("cors:denied")
+	}
+}
+
+// useRecordingMetrics installs a recordingMetrics for the duration of the test.
+func useRecordingMetrics(t *testing.T) *recordingMetrics {
+	t.Helper()
+	m := &recordingMetrics{}
+	SetMetrics(m)
+	t.Cleanup(func() { SetMetrics(nil) })
+	return m
+}
+
+func TestMetrics_RateLimit(t *testing.T) {
+	m := useRecordingMetrics(t)
+
+	r := fursy.New()
+	r.Use(RateLimit(0.001, 1))
+	r.GET("/", func(c *fursy.Context) error { return c.NoContent(http.StatusNoContent) })
+
+	for range 3 {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	}
+
+	if got := m.Events(); !slices.Equal(got, []string{"ratelimit", "ratelimit"}) {
+		t.Errorf("expected 2 rejections, got %v", got)
+	}
+}
+
+func TestMetrics_CircuitBreaker(t *testing.T) {
+	m := useRecordingMetrics(t)
+
+	r := fursy.New()
+	r.Use(CircuitBreakerWithName("payments", CircuitBreakerConfig{
+		ConsecutiveFailures: 1,
+		Timeout:             time.Hour,
+	}))
+	r.GET("/", func(c *fursy.Context) error { return c.NoContent(http.StatusInternalServerError) })
+
+	for range 2 {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	}
+
+	want := []string{"cb:payments:closed->open", "cb:payments:short"}
+	if got := m.Events(); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestMetrics_JWT(t *testing.T) {
+	m := useRecordingMetrics(t)
+
+	r := fursy.New()
+	r.Use(JWTWithConfig(JWTConfig{
+		SigningKey:     []byte(testSecret),
+		ValidateIssuer: testIssuer,
+	}))
+	r.GET("/", func(c *fursy.Context) error { return c.NoContent(http.StatusNoContent) })
+
+	tokens := []string{
+		"",
+		"not-a-jwt",
+		generateExpiredToken([]byte(testSecret), "HS256"),
+		generateNotYetValidToken([]byte(testSecret), "HS256"),
+		generateValidToken([]byte("other-secret"), "HS256"),
+		generateValidToken([]byte(testSecret), "HS256"), // Missing issuer.
+	}
+	for _, token := range tokens {
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	want := []string{
+		"jwt:" + JWTFailureMissing,
+		"jwt:" + JWTFailureMalformed,
+		"jwt:" + JWTFailureExpired,
+		"jwt:" + JWTFailureNotValidYet,
+		"jwt:" + JWTFailureSignature,
+		"jwt:" + JWTFailureIssuer,
+	}
+	if got := m.Events(); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestMetrics_CORSPreflight(t *testing.T) {
+	m := useRecordingMetrics(t)
+
+	r := fursy.New()
+	r.Use(CORSWithConfig(CORSConfig{AllowOrigins: "https://example.com"}))
+	r.OPTIONS("/", func(c *fursy.Context) error { return c.NoContent(http.StatusNoContent) })
+
+	for _, origin := range []string{"https://example.com", "https://evil.com"} {
+		req := httptest.NewRequest(http.MethodOptions, "/", http.NoBody)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	want := []string{"cors:allowed", "cors:denied"}
+	if got := m.Events(); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
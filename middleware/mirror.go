@@ -0,0 +1,304 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package middleware provides request mirroring (traffic shadowing) middleware.
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// Default values for the Mirror middleware.
+const (
+	// DefaultMirrorMaxBodySize is the default maximum body size of mirrored requests (1 MB).
+	DefaultMirrorMaxBodySize = 1 << 20
+
+	// DefaultMirrorTimeout is the default timeout of a mirrored request.
+	DefaultMirrorTimeout = 5 * time.Second
+
+	// DefaultMirrorMaxInFlight is the default maximum number of concurrent mirrored requests.
+	DefaultMirrorMaxInFlight = 100
+)
+
+// MirrorHeader is set on mirrored requests so the shadow service can
+// recognize them (e.g., to skip side effects like sending emails).
+const MirrorHeader = "X-Mirrored-Request"
+
+// mirrorCredentialHeaders are the headers stripped from mirrored requests
+// unless MirrorConfig.ForwardCredentials is set.
+var mirrorCredentialHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization"}
+
+// MirrorConfig defines the configuration for the Mirror middleware.
+type MirrorConfig struct {
+	// Target is the base URL of the shadow service (e.g., "http://users-v2:8080").
+	// The request path and query are appended to it.
+	// Either Target or Handler is required.
+	Target string
+
+	// Handler receives mirrored requests instead of Target
+	// (e.g., a new implementation running in the same process).
+	// Its response is discarded.
+	Handler http.Handler
+
+	// Percentage is the share of requests to mirror (0-100).
+	// Negative value mirrors nothing.
+	// Default: 100
+	Percentage float64
+
+	// MaxBodySize is the maximum request body size in bytes to mirror.
+	// Requests with larger bodies are not mirrored.
+	// Default: 1 MB
+	MaxBodySize int64
+
+	// Timeout is the maximum duration of a mirrored request.
+	// Default: 5 seconds
+	Timeout time.Duration
+
+	// MaxInFlight is the maximum number of concurrent mirrored requests.
+	// Requests are not mirrored while the limit is reached, so a slow
+	// shadow service cannot pile up goroutines.
+	// Default: 100
+	MaxInFlight int
+
+	// ForwardCredentials forwards the Authorization, Cookie and
+	// Proxy-Authorization headers to the shadow service. Enable it only
+	// for shadow services trusted with user credentials (e.g., a new
+	// version of the same service).
+	// Default: false (credentials are stripped)
+	ForwardCredentials bool
+
+	// Client is the HTTP client used for Target.
+	// Default: http.DefaultClient
+	Client *http.Client
+
+	// OnResult is called (asynchronously) after each mirrored request with
+	// the response status (0 for Handler or on error) and the error, if any.
+	// Use it to log or count shadow failures.
+	// Default: nil
+	OnResult func(req *http.Request, status int, err error)
+
+	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
+	// Default: nil (middleware always executes)
+	Skipper fursy.Skipper
+}
+
+// Mirror returns a middleware that asynchronously copies every request
+// to a shadow service at target.
+//
+// The primary response is not affected: mirrored requests run in the
+// background, their responses are discarded and their failures are ignored.
+// This lets a new service version be tested with production traffic.
+// Credentials (Authorization, Cookie, Proxy-Authorization) are not
+// mirrored unless MirrorConfig.ForwardCredentials is set.
+//
+// Example:
+//
+//	api := router.Group("/api", middleware.Mirror("http://api-v2.internal:8080"))
+func Mirror(target string) fursy.HandlerFunc {
+	return MirrorWithConfig(MirrorConfig{
+		Target: target,
+	})
+}
+
+// MirrorWithConfig returns a middleware with custom configuration.
+//
+// Example (10% of traffic, read-only requests):
+//
+//	router.Use(middleware.MirrorWithConfig(middleware.MirrorConfig{
+//	    Target:     "http://api-v2.internal:8080",
+//	    Percentage: 10,
+//	    Skipper:    fursy.SkipMethods(http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete),
+//	    OnResult: func(req *http.Request, status int, err error) {
+//	        if err != nil || status >= 500 {
+//	            slog.Warn("shadow request failed", "path", req.URL.Path, "status", status, "error", err)
+//	        }
+//	    },
+//	}))
+func MirrorWithConfig(config MirrorConfig) fursy.HandlerFunc {
+	// Validate config.
+	var target *url.URL
+	switch {
+	case config.Handler != nil:
+	case config.Target != "":
+		var err error
+		target, err = url.Parse(config.Target)
+		if err != nil || target.Scheme == "" || target.Host == "" {
+			panic("fursy/middleware: invalid Mirror target: " + config.Target)
+		}
+	default:
+		panic("fursy/middleware: Mirror requires Target or Handler")
+	}
+
+	// Set defaults.
+	if config.Percentage == 0 {
+		config.Percentage = 100
+	}
+	if config.MaxBodySize <= 0 {
+		config.MaxBodySize = DefaultMirrorMaxBodySize
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultMirrorTimeout
+	}
+	if config.MaxInFlight <= 0 {
+		config.MaxInFlight = DefaultMirrorMaxInFlight
+	}
+	if config.Client == nil {
+		config.Client = http.DefaultClient
+	}
+
+	inFlight := make(chan struct{}, config.MaxInFlight)
+
+	return func(c *fursy.Context) error {
+		// Skip if Skipper returns true.
+		if config.Skipper.ShouldSkip(c) {
+			return c.Next()
+		}
+
+		if config.Percentage < 100 && rand.Float64()*100 >= config.Percentage {
+			return c.Next()
+		}
+
+		body, ok := mirrorBody(c.Request, config.MaxBodySize)
+		if !ok {
+			return c.Next()
+		}
+
+		select {
+		case inFlight <- struct{}{}:
+		default:
+			// Shadow service is saturated; drop this mirror.
+			return c.Next()
+		}
+
+		req := mirrorRequest(c.Request, target, body, config.ForwardCredentials)
+		go func() {
+			defer func() { <-inFlight }()
+
+			ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+			defer cancel()
+			req = req.WithContext(ctx)
+
+			status, err := sendMirror(req, config)
+			if config.OnResult != nil {
+				config.OnResult(req, status, err)
+			}
+		}()
+
+		return c.Next()
+	}
+}
+
+// mirrorBody reads and restores the request body.
+// It returns false if the body is larger than maxSize (the body stays readable).
+func mirrorBody(req *http.Request, maxSize int64) ([]byte, bool) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, true
+	}
+	if req.ContentLength > maxSize {
+		return nil, false
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxSize+1))
+	if err != nil || int64(len(body)) > maxSize {
+		// Restore what was read so the handler still sees the full body.
+		req.Body = readCloser{io.MultiReader(bytes.NewReader(body), req.Body), req.Body}
+		return nil, false
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, true
+}
+
+// readCloser combines a reader with the original body's Close.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// mirrorRequest builds the shadow copy of req, without its credentials
+// unless credentials is true.
+func mirrorRequest(req *http.Request, target *url.URL, body []byte, credentials bool) *http.Request {
+	u := *req.URL
+	if target != nil {
+		u.Scheme = target.Scheme
+		u.Host = target.Host
+		u.Path = strings.TrimSuffix(target.Path, "/") + req.URL.Path
+		u.RawPath = ""
+	}
+
+	mirror := &http.Request{
+		Method:        req.Method,
+		URL:           &u,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        req.Header.Clone(),
+		Host:          u.Host,
+		ContentLength: int64(len(body)),
+		RemoteAddr:    req.RemoteAddr,
+		RequestURI:    req.RequestURI,
+	}
+	if target == nil {
+		mirror.Host = req.Host
+	}
+	if body != nil {
+		mirror.Body = io.NopCloser(bytes.NewReader(body))
+		mirror.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	} else {
+		mirror.Body = http.NoBody
+	}
+	if !credentials {
+		for _, h := range mirrorCredentialHeaders {
+			mirror.Header.Del(h)
+		}
+	}
+	mirror.Header.Set(MirrorHeader, "true")
+
+	return mirror
+}
+
+// sendMirror delivers a mirrored request and discards the response.
+func sendMirror(req *http.Request, config MirrorConfig) (int, error) {
+	if config.Handler != nil {
+		config.Handler.ServeHTTP(discardResponseWriter{header: http.Header{}}, req)
+		return 0, nil
+	}
+
+	// Client requests must not have RequestURI set.
+	req.RequestURI = ""
+
+	resp, err := config.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// discardResponseWriter is an http.ResponseWriter that discards the response.
+type discardResponseWriter struct {
+	header http.Header
+}
+
+// Header implements http.ResponseWriter.
+func (w discardResponseWriter) Header() http.Header { return w.header }
+
+// Write implements http.ResponseWriter.
+func (w discardResponseWriter) Write(b []byte) (int, error) { return len(b), nil }
+
+// WriteHeader implements http.ResponseWriter.
+func (w discardResponseWriter) WriteHeader(int) {}
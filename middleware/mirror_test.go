@@ -0,0 +1,264 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// mirrored is a request received by a shadow service.
+type mirrored struct {
+	method string
+	uri    string
+	body   string
+	header http.Header
+}
+
+func TestMirror_Target(t *testing.T) {
+	received := make(chan mirrored, 1)
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- mirrored{r.Method, r.URL.RequestURI(), string(body), r.Header}
+		w.WriteHeader(http.StatusInternalServerError) // Ignored by the primary.
+	}))
+	defer shadow.Close()
+
+	router := fursy.New()
+	router.Use(Mirror(shadow.URL + "/shadow"))
+	router.POST("/users", func(c *fursy.Context) error {
+		body, _ := io.ReadAll(c.Request.Body)
+		return c.String(http.StatusCreated, "primary:"+string(body))
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/users?x=1", strings.NewReader(`{"name":"john"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated || w.Body.String() != `primary:{"name":"john"}` {
+		t.Errorf("primary response changed: %d %q", w.Code, w.Body.String())
+	}
+
+	select {
+	case m := <-received:
+		if m.method != http.MethodPost || m.uri != "/shadow/users?x=1" || m.body != `{"name":"john"}` {
+			t.Errorf("unexpected mirrored request %+v", m)
+		}
+		if m.header.Get("Content-Type") != "application/json" || m.header.Get(MirrorHeader) != "true" {
+			t.Errorf("unexpected mirrored headers %v", m.header)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("request was not mirrored")
+	}
+}
+
+func TestMirror_Handler(t *testing.T) {
+	received := make(chan mirrored, 1)
+
+	router := fursy.New()
+	router.Use(MirrorWithConfig(MirrorConfig{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			received <- mirrored{r.Method, r.URL.RequestURI(), string(body), r.Header}
+			_, _ = w.Write([]byte("discarded"))
+		}),
+	}))
+	router.PUT("/items/:id", func(c *fursy.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/items/7", strings.NewReader("data")))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected status 204, got %d", w.Code)
+	}
+
+	select {
+	case m := <-received:
+		if m.uri != "/items/7" || m.body != "data" {
+			t.Errorf("unexpected mirrored request %+v", m)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("request was not mirrored")
+	}
+}
+
+func TestMirror_Credentials(t *testing.T) {
+	for _, forward := range []bool{false, true} {
+		received := make(chan mirrored, 1)
+
+		router := fursy.New()
+		router.Use(MirrorWithConfig(MirrorConfig{
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received <- mirrored{r.Method, r.URL.RequestURI(), "", r.Header}
+			}),
+			ForwardCredentials: forward,
+		}))
+		router.GET("/me", func(c *fursy.Context) error {
+			return c.NoContent(http.StatusNoContent)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/me", http.NoBody)
+		req.Header.Set("Authorization", "Bearer secret")
+		req.Header.Set("Cookie", "session=secret")
+		req.Header.Set("Proxy-Authorization", "Basic secret")
+		req.Header.Set("X-Request-ID", "42")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		select {
+		case m := <-received:
+			for _, h := range []string{"Authorization", "Cookie", "Proxy-Authorization"} {
+				if got := m.header.Get(h) != ""; got != forward {
+					t.Errorf("ForwardCredentials=%v: %s forwarded = %v", forward, h, got)
+				}
+			}
+			if m.header.Get("X-Request-ID") != "42" {
+				t.Errorf("expected other headers to be mirrored, got %v", m.header)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("request was not mirrored")
+		}
+	}
+}
+
+func TestMirror_Percentage(t *testing.T) {
+	var count atomic.Int32
+	done := make(chan struct{}, 100)
+
+	router := fursy.New()
+	router.Use(MirrorWithConfig(MirrorConfig{
+		Handler: http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			count.Add(1)
+			done <- struct{}{}
+		}),
+		Percentage: -1,
+	}))
+	router.GET("/", func(c *fursy.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	for range 20 {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	if n := count.Load(); n != 0 {
+		t.Errorf("expected no mirrored requests, got %d", n)
+	}
+}
+
+func TestMirror_LargeBodyNotMirrored(t *testing.T) {
+	var count atomic.Int32
+
+	router := fursy.New()
+	router.Use(MirrorWithConfig(MirrorConfig{
+		Handler: http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			count.Add(1)
+		}),
+		MaxBodySize: 4,
+	}))
+	router.POST("/upload", func(c *fursy.Context) error {
+		body, _ := io.ReadAll(c.Request.Body)
+		return c.String(http.StatusOK, string(body))
+	})
+
+	// Unknown length: the body is read past the limit and must be restored.
+	req := httptest.NewRequest(http.MethodPost, "/upload", io.NopCloser(strings.NewReader("0123456789")))
+	req.ContentLength = -1
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Body.String() != "0123456789" {
+		t.Errorf("expected full body for primary handler, got %q", w.Body.String())
+	}
+	time.Sleep(20 * time.Millisecond)
+	if n := count.Load(); n != 0 {
+		t.Errorf("expected large body not to be mirrored, got %d", n)
+	}
+}
+
+func TestMirror_MaxInFlight(t *testing.T) {
+	release := make(chan struct{})
+	var count atomic.Int32
+
+	router := fursy.New()
+	router.Use(MirrorWithConfig(MirrorConfig{
+		Handler: http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			count.Add(1)
+			<-release
+		}),
+		MaxInFlight: 1,
+	}))
+	router.GET("/", func(c *fursy.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	for range 5 {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	if n := count.Load(); n != 1 {
+		t.Errorf("expected 1 mirrored request while saturated, got %d", n)
+	}
+}
+
+func TestMirror_OnResult(t *testing.T) {
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer shadow.Close()
+
+	results := make(chan int, 1)
+	router := fursy.New()
+	router.Use(MirrorWithConfig(MirrorConfig{
+		Target: shadow.URL,
+		OnResult: func(_ *http.Request, status int, err error) {
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			results <- status
+		},
+	}))
+	router.GET("/", func(c *fursy.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	select {
+	case status := <-results:
+		if status != http.StatusBadGateway {
+			t.Errorf("expected status 502, got %d", status)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("OnResult was not called")
+	}
+}
+
+func TestMirror_InvalidConfig(t *testing.T) {
+	for name, config := range map[string]MirrorConfig{
+		"missing target":  {},
+		"relative target": {Target: "/shadow"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			MirrorWithConfig(config)
+		})
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package middleware provides mutual TLS client certificate authentication middleware.
+package middleware
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// MTLSCertContextKey is the key used to store the verified client certificate
+// (*x509.Certificate) in the context.
+const MTLSCertContextKey = "mtls_cert"
+
+// Common mTLS errors.
+var (
+	ErrMTLSMissing = errors.New("missing client certificate")
+	ErrMTLSInvalid = errors.New("invalid client certificate")
+	ErrMTLSRevoked = errors.New("client certificate has been revoked")
+)
+
+// ClientIdentity is the identity extracted from a client certificate by MTLS.
+type ClientIdentity struct {
+	// CommonName is the subject common name (CN).
+	CommonName string
+
+	// DNSNames are the DNS subject alternative names.
+	DNSNames []string
+
+	// URIs are the URI subject alternative names (e.g., SPIFFE IDs
+	// such as "spiffe://example.org/ns/prod/sa/billing").
+	URIs []string
+
+	// EmailAddresses are the email subject alternative names.
+	EmailAddresses []string
+
+	// Certificate is the verified client certificate.
+	Certificate *x509.Certificate
+}
+
+// MTLSConfig defines the configuration for the MTLS middleware.
+type MTLSConfig struct {
+	// ClientCAs is the pool of CAs that client certificates must chain to.
+	// Required.
+	ClientCAs *x509.CertPool
+
+	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
+	// Default: nil (middleware always executes)
+	Skipper fursy.Skipper
+
+	// IsRevoked reports whether a certificate of the verified chain (client
+	// certificate first) has been revoked, e.g. by checking a CRL or an
+	// OCSP responder. An error rejects the request (fail closed).
+	// Default: nil (no revocation check)
+	IsRevoked func(cert *x509.Certificate) (bool, error)
+
+	// Identity extracts the identity stored under UserContextKey from the
+	// verified client certificate. An error rejects the request.
+	// Default: returns a ClientIdentity
+	Identity func(cert *x509.Certificate) (interface{}, error)
+
+	// ErrorHandler is called when the client certificate is missing or rejected.
+	// Default: returns 401 Unauthorized
+	ErrorHandler func(c *fursy.Context, err error) error
+}
+
+// MTLS returns a middleware that authenticates clients by their TLS
+// certificate, verified against the CAs in clientCAs.
+//
+// The server must request client certificates, e.g. with
+// tls.Config.ClientAuth set to tls.RequestClientCert or
+// tls.VerifyClientCertIfGiven, so that endpoints can be protected selectively.
+//
+// The middleware:
+//   - Verifies the client certificate chain against ClientCAs
+//   - Requires the client authentication extended key usage
+//   - Optionally checks revocation (see MTLSConfig.IsRevoked)
+//   - Stores the identity (SAN/CN) under UserContextKey and the
+//     certificate under MTLSCertContextKey
+//
+// TLS must be terminated by the Go server: behind a TLS-terminating proxy
+// there is no client certificate to verify.
+//
+// Example:
+//
+//	pool := x509.NewCertPool()
+//	pool.AppendCertsFromPEM(caPEM)
+//
+//	srv := &http.Server{
+//	    Addr:      ":8443",
+//	    Handler:   router,
+//	    TLSConfig: &tls.Config{ClientAuth: tls.RequestClientCert},
+//	}
+//
+//	internal := router.Group("/internal", middleware.MTLS(pool))
+//	internal.GET("/jobs", func(c *fursy.Context) error {
+//	    id := c.Get(middleware.UserContextKey).(middleware.ClientIdentity)
+//	    return c.String(200, "Hello, "+id.CommonName)
+//	})
+func MTLS(clientCAs *x509.CertPool) fursy.HandlerFunc {
+	return MTLSWithConfig(MTLSConfig{
+		ClientCAs: clientCAs,
+	})
+}
+
+// MTLSWithConfig returns a middleware with custom mTLS configuration.
+//
+// Example:
+//
+//	router.Use(middleware.MTLSWithConfig(middleware.MTLSConfig{
+//	    ClientCAs: pool,
+//	    IsRevoked: func(cert *x509.Certificate) (bool, error) {
+//	        return crl.Contains(cert.SerialNumber), nil
+//	    },
+//	    Identity: func(cert *x509.Certificate) (interface{}, error) {
+//	        if len(cert.URIs) == 0 {
+//	            return nil, errors.New("missing SPIFFE ID")
+//	        }
+//	        return cert.URIs[0].String(), nil
+//	    },
+//	}))
+func MTLSWithConfig(config MTLSConfig) fursy.HandlerFunc {
+	// Validate config.
+	if config.ClientCAs == nil {
+		panic("fursy/middleware: MTLS client CA pool cannot be nil")
+	}
+
+	// Set defaults.
+	if config.Identity == nil {
+		config.Identity = defaultClientIdentity
+	}
+
+	if config.ErrorHandler == nil {
+		config.ErrorHandler = defaultMTLSErrorHandler
+	}
+
+	return func(c *fursy.Context) error {
+		// Skip if Skipper returns true.
+		if config.Skipper.ShouldSkip(c) {
+			return c.Next()
+		}
+
+		state := c.TLS()
+		if state == nil || len(state.PeerCertificates) == 0 {
+			return config.ErrorHandler(c, ErrMTLSMissing)
+		}
+
+		// Verify the chain ourselves: the server may only request certificates.
+		cert := state.PeerCertificates[0]
+		intermediates := x509.NewCertPool()
+		for _, ic := range state.PeerCertificates[1:] {
+			intermediates.AddCert(ic)
+		}
+		chains, err := cert.Verify(x509.VerifyOptions{
+			Roots:         config.ClientCAs,
+			Intermediates: intermediates,
+			CurrentTime:   time.Now(),
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		if err != nil {
+			return config.ErrorHandler(c, fmt.Errorf("%w: %w", ErrMTLSInvalid, err))
+		}
+
+		// Check revocation of the client and intermediate certificates.
+		if config.IsRevoked != nil {
+			if err := checkRevocation(chains[0], config.IsRevoked); err != nil {
+				return config.ErrorHandler(c, err)
+			}
+		}
+
+		identity, err := config.Identity(cert)
+		if err != nil {
+			return config.ErrorHandler(c, fmt.Errorf("%w: %w", ErrMTLSInvalid, err))
+		}
+
+		c.Set(MTLSCertContextKey, cert)
+		c.Set(UserContextKey, identity)
+		return c.Next()
+	}
+}
+
+// checkRevocation checks the certificates of chain except the trusted root.
+func checkRevocation(chain []*x509.Certificate, isRevoked func(*x509.Certificate) (bool, error)) error {
+	for _, cert := range chain[:len(chain)-1] {
+		revoked, err := isRevoked(cert)
+		if err != nil {
+			return fmt.Errorf("%w: revocation check failed: %w", ErrMTLSInvalid, err)
+		}
+		if revoked {
+			return ErrMTLSRevoked
+		}
+	}
+	return nil
+}
+
+// defaultClientIdentity returns the ClientIdentity of cert.
+func defaultClientIdentity(cert *x509.Certificate) (interface{}, error) {
+	uris := make([]string, 0, len(cert.URIs))
+	for _, u := range cert.URIs {
+		uris = append(uris, u.String())
+	}
+	return ClientIdentity{
+		CommonName:     cert.Subject.CommonName,
+		DNSNames:       cert.DNSNames,
+		URIs:           uris,
+		EmailAddresses: cert.EmailAddresses,
+		Certificate:    cert,
+	}, nil
+}
+
+// defaultMTLSErrorHandler is the default error handler for mTLS failures.
+// The verification details are not disclosed to the client.
+func defaultMTLSErrorHandler(c *fursy.Context, err error) error {
+	detail := ErrMTLSInvalid.Error()
+	switch {
+	case errors.Is(err, ErrMTLSMissing):
+		detail = ErrMTLSMissing.Error()
+	case errors.Is(err, ErrMTLSRevoked):
+		detail = ErrMTLSRevoked.Error()
+	}
+	return c.Problem(fursy.Unauthorized(detail))
+}
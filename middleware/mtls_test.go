@@ -0,0 +1,239 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// testCA is a certificate authority issuing test client certificates.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pool *x509.CertPool
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &testCA{cert: cert, key: key, pool: pool}
+}
+
+// issue creates a client certificate with the given serial number.
+func (ca *testCA) issue(t *testing.T, serial int64, usage x509.ExtKeyUsage) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spiffe, _ := url.Parse("spiffe://example.org/billing")
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "billing"},
+		DNSNames:     []string{"billing.internal"},
+		URIs:         []*url.URL{spiffe},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func mtlsRequest(certs ...*x509.Certificate) *http.Request {
+	req := httptest.NewRequest("GET", "/test", http.NoBody)
+	if certs != nil {
+		req.TLS = &tls.ConnectionState{PeerCertificates: certs}
+	}
+	return req
+}
+
+// TestMTLS tests client certificate authentication.
+func TestMTLS(t *testing.T) {
+	ca := newTestCA(t)
+	other := newTestCA(t)
+
+	r := fursy.New()
+	r.Use(MTLS(ca.pool))
+	r.GET("/test", func(c *fursy.Context) error {
+		id := c.Get(UserContextKey).(ClientIdentity)
+		if _, ok := c.Get(MTLSCertContextKey).(*x509.Certificate); !ok {
+			t.Error("certificate not stored in context")
+		}
+		return c.String(200, id.CommonName+" "+strings.Join(id.DNSNames, ",")+" "+strings.Join(id.URIs, ","))
+	})
+
+	tests := []struct {
+		name   string
+		req    *http.Request
+		status int
+		body   string
+	}{
+		{"valid", mtlsRequest(ca.issue(t, 2, x509.ExtKeyUsageClientAuth)), 200, "billing billing.internal spiffe://example.org/billing"},
+		{"plain http", mtlsRequest(), 401, "missing client certificate"},
+		{"no certificate", mtlsRequest([]*x509.Certificate{}...), 401, "missing client certificate"},
+		{"unknown CA", mtlsRequest(other.issue(t, 2, x509.ExtKeyUsageClientAuth)), 401, "invalid client certificate"},
+		{"server certificate", mtlsRequest(ca.issue(t, 3, x509.ExtKeyUsageServerAuth)), 401, "invalid client certificate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, tt.req)
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, w.Code)
+			}
+			if !strings.Contains(w.Body.String(), tt.body) {
+				t.Errorf("expected body containing %q, got %s", tt.body, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestMTLS_Revocation tests the revocation check.
+func TestMTLS_Revocation(t *testing.T) {
+	ca := newTestCA(t)
+
+	r := fursy.New()
+	r.Use(MTLSWithConfig(MTLSConfig{
+		ClientCAs: ca.pool,
+		IsRevoked: func(cert *x509.Certificate) (bool, error) {
+			switch cert.SerialNumber.Int64() {
+			case 13:
+				return true, nil
+			case 99:
+				return false, errors.New("OCSP responder unavailable")
+			}
+			return false, nil
+		},
+	}))
+	r.GET("/test", func(c *fursy.Context) error {
+		return c.String(200, "OK")
+	})
+
+	tests := []struct {
+		serial int64
+		status int
+		body   string
+	}{
+		{2, 200, "OK"},
+		{13, 401, "client certificate has been revoked"},
+		{99, 401, "invalid client certificate"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, mtlsRequest(ca.issue(t, tt.serial, x509.ExtKeyUsageClientAuth)))
+		if w.Code != tt.status || !strings.Contains(w.Body.String(), tt.body) {
+			t.Errorf("serial %d: got %d %s, want %d %q", tt.serial, w.Code, w.Body.String(), tt.status, tt.body)
+		}
+	}
+}
+
+// TestMTLS_CustomIdentity tests a custom identity extractor and error handler.
+func TestMTLS_CustomIdentity(t *testing.T) {
+	ca := newTestCA(t)
+
+	var gotErr error
+	r := fursy.New()
+	r.Use(MTLSWithConfig(MTLSConfig{
+		ClientCAs: ca.pool,
+		Identity: func(cert *x509.Certificate) (interface{}, error) {
+			if cert.Subject.CommonName != "billing" {
+				return nil, errors.New("unknown service")
+			}
+			return cert.URIs[0].String(), nil
+		},
+		ErrorHandler: func(c *fursy.Context, err error) error {
+			gotErr = err
+			return c.String(http.StatusForbidden, "Forbidden")
+		},
+	}))
+	r.GET("/test", func(c *fursy.Context) error {
+		return c.String(200, c.GetString(UserContextKey))
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, mtlsRequest(ca.issue(t, 2, x509.ExtKeyUsageClientAuth)))
+	if w.Code != 200 || w.Body.String() != "spiffe://example.org/billing" {
+		t.Errorf("got %d %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, mtlsRequest())
+	if w.Code != http.StatusForbidden || !errors.Is(gotErr, ErrMTLSMissing) {
+		t.Errorf("got %d, err %v; want 403 and ErrMTLSMissing", w.Code, gotErr)
+	}
+}
+
+// TestMTLS_Skipper tests skipping the middleware.
+func TestMTLS_Skipper(t *testing.T) {
+	r := fursy.New()
+	r.Use(MTLSWithConfig(MTLSConfig{
+		ClientCAs: x509.NewCertPool(),
+		Skipper:   fursy.SkipPaths("/health"),
+	}))
+	r.GET("/health", func(c *fursy.Context) error {
+		return c.String(200, "OK")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/health", http.NoBody))
+	if w.Code != 200 {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+}
+
+// TestMTLS_NilPoolPanics tests config validation.
+func TestMTLS_NilPoolPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for nil client CA pool")
+		}
+	}()
+	MTLS(nil)
+}
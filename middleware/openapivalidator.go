@@ -0,0 +1,547 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package middleware provides OpenAPI request validation middleware.
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/coregx/fursy"
+)
+
+// OpenAPIValidatorConfig defines the configuration for the OpenAPIValidator middleware.
+type OpenAPIValidatorConfig struct {
+	// Document is the OpenAPI document to validate against.
+	// Default: generated from the router on the first request (see Router.GenerateOpenAPI)
+	Document *fursy.OpenAPI
+
+	// Strict rejects query parameters and JSON body properties
+	// that are not documented in the specification.
+	// Default: false (only documented parameters and properties are checked)
+	Strict bool
+
+	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
+	// Default: nil (middleware always executes)
+	Skipper fursy.Skipper
+
+	// ErrorHandler is called when the request does not match the specification.
+	// Field names are prefixed with the parameter location
+	// (e.g., "query.limit", "header.X-Api-Key", "body.email").
+	// Default: 400 Bad Request problem with an "errors" extension (see fursy.Violation)
+	ErrorHandler func(c *fursy.Context, errs fursy.ValidationErrors) error
+}
+
+// OpenAPIValidator returns a middleware that validates requests against
+// the router's own OpenAPI document.
+//
+// Path, query, header and cookie parameters are checked for presence and type,
+// and JSON request bodies are checked against the request body schema.
+// Invalid requests are rejected with 400 Bad Request (application/problem+json).
+// Requests that match no documented operation are passed through.
+//
+// Example:
+//
+//	router := fursy.New()
+//	router.Use(middleware.OpenAPIValidator())
+//
+//	fursy.POST[CreateUser, User](router, "/users", createUser)
+//
+//	// POST /users {"name": 42}
+//	// → 400 {"title":"Bad Request","errors":[{"detail":"must be of type string",
+//	//         "pointer":"/name","field":"body.name","code":"type"}],...}
+func OpenAPIValidator() fursy.HandlerFunc {
+	return OpenAPIValidatorWithConfig(OpenAPIValidatorConfig{})
+}
+
+// OpenAPIValidatorWithConfig returns a middleware with custom configuration.
+//
+// Example (strict mode for a public API):
+//
+//	router.Use(middleware.OpenAPIValidatorWithConfig(middleware.OpenAPIValidatorConfig{
+//	    Strict:  true,
+//	    Skipper: fursy.SkipPaths("/openapi.json", "/health"),
+//	}))
+func OpenAPIValidatorWithConfig(config OpenAPIValidatorConfig) fursy.HandlerFunc {
+	// Set defaults.
+	if config.ErrorHandler == nil {
+		config.ErrorHandler = defaultOpenAPIValidatorErrorHandler
+	}
+
+	var (
+		once   sync.Once
+		doc    = config.Document
+		docErr error
+	)
+
+	return func(c *fursy.Context) error {
+		// Skip if Skipper returns true.
+		if config.Skipper.ShouldSkip(c) {
+			return c.Next()
+		}
+
+		// Routes are usually registered after Use, so generate the document lazily.
+		once.Do(func() {
+			if doc == nil {
+				doc, docErr = c.Router().GenerateOpenAPI(fursy.Info{})
+			}
+		})
+		if docErr != nil {
+			return docErr
+		}
+
+		op := findOperation(doc, c.Request.Method, c.Request.URL.Path)
+		if op == nil {
+			return c.Next()
+		}
+
+		v := &requestValidator{doc: doc, strict: config.Strict}
+		v.validateParameters(c, op)
+		if err := v.validateBody(c, op); err != nil {
+			return err
+		}
+
+		if len(v.errs) > 0 {
+			return config.ErrorHandler(c, v.errs)
+		}
+		return c.Next()
+	}
+}
+
+// defaultOpenAPIValidatorErrorHandler sends a 400 Bad Request problem listing invalid fields.
+func defaultOpenAPIValidatorErrorHandler(c *fursy.Context, errs fursy.ValidationErrors) error {
+	detail := fmt.Sprintf("%d field(s) do not match the API specification", len(errs))
+	if len(errs) == 1 {
+		detail = errs[0].Field + " " + errs[0].Message
+	}
+
+	p := fursy.BadRequest(detail)
+	p.Extensions = map[string]any{
+		"errors": errs.Violations(),
+	}
+	return c.Problem(p)
+}
+
+// findOperation returns the operation for the request method and path, or nil.
+//
+// Templates with fewer parameters are preferred ("/users/me" over "/users/{id}").
+// A template ending in a parameter also matches deeper paths, since
+// wildcard routes ("/files/*path") are documented as "/files/{path}".
+func findOperation(doc *fursy.OpenAPI, method, path string) *fursy.Operation {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	var (
+		best         *fursy.Operation
+		bestParams   = -1
+		bestWildcard = true
+	)
+	for template, item := range doc.Paths {
+		op := pathItemOperation(&item, method)
+		if op == nil {
+			continue
+		}
+
+		params, wildcard, ok := matchPathTemplate(strings.Split(strings.Trim(template, "/"), "/"), segments)
+		if !ok {
+			continue
+		}
+
+		// Exact matches beat wildcard matches, then fewer parameters win.
+		if best == nil || (bestWildcard && !wildcard) || (bestWildcard == wildcard && params < bestParams) {
+			best, bestParams, bestWildcard = op, params, wildcard
+		}
+	}
+	return best
+}
+
+// matchPathTemplate matches path segments against OpenAPI template segments.
+// It returns the number of parameter segments and whether the last
+// parameter matched more than one segment.
+func matchPathTemplate(template, segments []string) (params int, wildcard, ok bool) {
+	if len(template) > len(segments) {
+		return 0, false, false
+	}
+
+	for i, seg := range template {
+		isParam := strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}")
+		switch {
+		case isParam:
+			params++
+		case seg != segments[i]:
+			return 0, false, false
+		}
+
+		if i == len(template)-1 && len(segments) > len(template) {
+			if !isParam {
+				return 0, false, false
+			}
+			wildcard = true
+		}
+	}
+	return params, wildcard, true
+}
+
+// pathItemOperation returns the operation for method, or nil.
+func pathItemOperation(item *fursy.PathItem, method string) *fursy.Operation {
+	switch method {
+	case http.MethodGet:
+		return item.Get
+	case http.MethodPost:
+		return item.Post
+	case http.MethodPut:
+		return item.Put
+	case http.MethodDelete:
+		return item.Delete
+	case http.MethodPatch:
+		return item.Patch
+	case http.MethodHead:
+		return item.Head
+	case http.MethodOptions:
+		return item.Options
+	case http.MethodTrace:
+		return item.Trace
+	default:
+		return nil
+	}
+}
+
+// requestValidator collects validation errors for a single request.
+type requestValidator struct {
+	doc    *fursy.OpenAPI
+	strict bool
+	errs   fursy.ValidationErrors
+}
+
+// report adds a validation error.
+func (v *requestValidator) report(field, tag, message string) {
+	// Body fields point into the JSON document ("body.address.zip" -> "/address/zip").
+	pointer := ""
+	if rest, ok := strings.CutPrefix(field, "body."); ok {
+		pointer = fursy.FieldPointer(rest)
+	}
+	v.errs = append(v.errs, fursy.ValidationError{
+		Field:   field,
+		Tag:     tag,
+		Message: message,
+		Pointer: pointer,
+	})
+}
+
+// validateParameters checks documented parameters and, in strict mode,
+// rejects undocumented query parameters.
+func (v *requestValidator) validateParameters(c *fursy.Context, op *fursy.Operation) {
+	query := c.Request.URL.Query()
+	documented := make(map[string]bool)
+
+	for _, p := range op.Parameters {
+		var values []string
+		switch p.In {
+		case "path":
+			if value := c.Param(p.Name); value != "" {
+				values = []string{value}
+			}
+		case "query":
+			documented[p.Name] = true
+			values = query[p.Name]
+		case "header":
+			values = c.Request.Header.Values(p.Name)
+		case "cookie":
+			if cookie, err := c.Request.Cookie(p.Name); err == nil {
+				values = []string{cookie.Value}
+			}
+		default:
+			continue
+		}
+
+		field := p.In + "." + p.Name
+		if len(values) == 0 {
+			if p.Required {
+				v.report(field, "required", "is required")
+			}
+			continue
+		}
+		v.validateParameterValues(field, v.resolve(p.Schema), values)
+	}
+
+	if v.strict {
+		names := make([]string, 0, len(query))
+		for name := range query {
+			if !documented[name] {
+				names = append(names, name)
+			}
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			v.report("query."+name, "unknown", "is not a documented parameter")
+		}
+	}
+}
+
+// validateParameterValues checks string parameter values against a schema.
+func (v *requestValidator) validateParameterValues(field string, schema *fursy.Schema, values []string) {
+	if schema == nil {
+		return
+	}
+
+	if schema.Type == "array" {
+		items := v.resolve(schema.Items)
+		for _, value := range values {
+			v.validateParameterValue(field, items, value)
+		}
+		return
+	}
+
+	if len(values) > 1 {
+		v.report(field, "type", "must be a single value")
+		return
+	}
+	v.validateParameterValue(field, schema, values[0])
+}
+
+// validateParameterValue checks a single string parameter value against a schema.
+func (v *requestValidator) validateParameterValue(field string, schema *fursy.Schema, value string) {
+	if schema == nil {
+		return
+	}
+
+	var err error
+	switch schema.Type {
+	case "integer":
+		if schema.Format == "uint" {
+			_, err = strconv.ParseUint(value, 10, 64)
+		} else {
+			_, err = strconv.ParseInt(value, 10, 64)
+		}
+	case "number":
+		_, err = strconv.ParseFloat(value, 64)
+	case "boolean":
+		_, err = strconv.ParseBool(value)
+	}
+	if err != nil {
+		v.report(field, "type", "must be of type "+schema.Type)
+		return
+	}
+
+	if len(schema.Enum) > 0 && !slices.ContainsFunc(schema.Enum, func(e any) bool {
+		return fmt.Sprint(e) == value
+	}) {
+		v.report(field, "enum", "must be one of "+formatEnum(schema.Enum))
+	}
+}
+
+// validateBody checks a JSON request body against the request body schema.
+// Non-JSON bodies are not validated. The body is restored for the handler.
+func (v *requestValidator) validateBody(c *fursy.Context, op *fursy.Operation) error {
+	rb := op.RequestBody
+	if rb == nil {
+		return nil
+	}
+
+	var data []byte
+	if c.Request.Body != nil && c.Request.Body != http.NoBody {
+		var err error
+		data, err = io.ReadAll(c.Request.Body)
+		if err != nil {
+			return err
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		if rb.Required {
+			v.report("body", "required", "is required")
+		}
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(c.Request.Header.Get("Content-Type"))
+	if mediaType != fursy.MIMEApplicationJSON && !strings.HasSuffix(mediaType, "+json") {
+		return nil
+	}
+
+	content, ok := rb.Content[fursy.MIMEApplicationJSON]
+	if !ok || content.Schema == nil {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var body any
+	if err := dec.Decode(&body); err != nil {
+		v.report("body", "json", "must be valid JSON")
+		return nil
+	}
+
+	v.validateValue("body", v.resolve(content.Schema), body)
+	return nil
+}
+
+// validateValue checks a decoded JSON value against a schema.
+//
+//nolint:gocognit,gocyclo,cyclop // JSON Schema validation requires a branch per keyword.
+func (v *requestValidator) validateValue(field string, schema *fursy.Schema, value any) {
+	if schema == nil {
+		return
+	}
+
+	// Composition keywords.
+	for _, s := range schema.AllOf {
+		v.validateValue(field, v.resolve(s), value)
+	}
+	if len(schema.AnyOf) > 0 && v.countMatches(schema.AnyOf, value) == 0 {
+		v.report(field, "anyOf", "must match at least one schema")
+	}
+	if len(schema.OneOf) > 0 && v.countMatches(schema.OneOf, value) != 1 {
+		v.report(field, "oneOf", "must match exactly one schema")
+	}
+
+	if value == nil {
+		if !schema.Nullable && schema.Type != "" {
+			v.report(field, "type", "must not be null")
+		}
+		return
+	}
+
+	switch schema.Type {
+	case "string":
+		if _, ok := value.(string); !ok {
+			v.report(field, "type", "must be of type string")
+			return
+		}
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			v.report(field, "type", "must be of type integer")
+			return
+		}
+		if _, err := strconv.ParseInt(n.String(), 10, 64); err != nil {
+			if _, err := strconv.ParseUint(n.String(), 10, 64); err != nil {
+				v.report(field, "type", "must be of type integer")
+				return
+			}
+		}
+		if schema.Format == "uint" && strings.HasPrefix(n.String(), "-") {
+			v.report(field, "min", "must not be negative")
+			return
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			v.report(field, "type", "must be of type number")
+			return
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			v.report(field, "type", "must be of type boolean")
+			return
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			v.report(field, "type", "must be of type array")
+			return
+		}
+		itemSchema := v.resolve(schema.Items)
+		for i, item := range items {
+			v.validateValue(field+"["+strconv.Itoa(i)+"]", itemSchema, item)
+		}
+	case "object":
+		// Objects without properties are unconstrained (generated for
+		// interfaces and opaque types such as time.Time).
+		if len(schema.Properties) == 0 && schema.AdditionalProperties == nil {
+			return
+		}
+		obj, ok := value.(map[string]any)
+		if !ok {
+			v.report(field, "type", "must be of type object")
+			return
+		}
+		v.validateObject(field, schema, obj)
+	}
+
+	if len(schema.Enum) > 0 && !slices.ContainsFunc(schema.Enum, func(e any) bool {
+		return fmt.Sprint(e) == fmt.Sprint(value)
+	}) {
+		v.report(field, "enum", "must be one of "+formatEnum(schema.Enum))
+	}
+}
+
+// validateObject checks required, known and additional properties of an object.
+func (v *requestValidator) validateObject(field string, schema *fursy.Schema, obj map[string]any) {
+	for _, name := range schema.Required {
+		if _, ok := obj[name]; !ok {
+			v.report(field+"."+name, "required", "is required")
+		}
+	}
+
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if prop, ok := schema.Properties[name]; ok {
+			v.validateValue(field+"."+name, v.resolve(prop), obj[name])
+			continue
+		}
+
+		switch additional := schema.AdditionalProperties.(type) {
+		case *fursy.Schema:
+			v.validateValue(field+"."+name, v.resolve(additional), obj[name])
+		case bool:
+			if !additional {
+				v.report(field+"."+name, "unknown", "is not a documented property")
+			}
+		default:
+			if v.strict {
+				v.report(field+"."+name, "unknown", "is not a documented property")
+			}
+		}
+	}
+}
+
+// countMatches returns how many schemas the value matches.
+func (v *requestValidator) countMatches(schemas []*fursy.Schema, value any) int {
+	matches := 0
+	for _, s := range schemas {
+		sub := &requestValidator{doc: v.doc, strict: v.strict}
+		sub.validateValue("", v.resolve(s), value)
+		if len(sub.errs) == 0 {
+			matches++
+		}
+	}
+	return matches
+}
+
+// resolve follows a local component reference ("#/components/schemas/Name").
+func (v *requestValidator) resolve(schema *fursy.Schema) *fursy.Schema {
+	if schema == nil || schema.Ref == "" {
+		return schema
+	}
+
+	name, ok := strings.CutPrefix(schema.Ref, "#/components/schemas/")
+	if !ok || v.doc.Components == nil {
+		return nil
+	}
+	return v.doc.Components.Schemas[name]
+}
+
+// formatEnum formats enum values for error messages.
+func formatEnum(values []any) string {
+	parts := make([]string, len(values))
+	for i, e := range values {
+		parts[i] = fmt.Sprint(e)
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
@@ -0,0 +1,298 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/coregx/fursy"
+)
+
+type validatorAddress struct {
+	City string `json:"city"`
+}
+
+type validatorUser struct {
+	Name    string            `json:"name" form:"name"`
+	Age     int               `json:"age"`
+	Email   string            `json:"email,omitempty"`
+	Tags    []string          `json:"tags,omitempty"`
+	Address *validatorAddress `json:"address,omitempty"`
+}
+
+// newValidatorRouter creates a router with documented routes for validator tests.
+func newValidatorRouter(config OpenAPIValidatorConfig) *fursy.Router {
+	r := fursy.New()
+	r.Use(OpenAPIValidatorWithConfig(config))
+
+	fursy.POST[validatorUser, validatorUser](r, "/users", func(c *fursy.Box[validatorUser, validatorUser]) error {
+		return c.Created("/users/1", *c.ReqBody)
+	})
+
+	r.HandleWithOptions(http.MethodGet, "/users/:id", func(c *fursy.Context) error {
+		return c.String(http.StatusOK, c.Param("id"))
+	}, &fursy.RouteOptions{
+		Parameters: []fursy.RouteParameter{
+			{Name: "id", In: "path", Required: true, Type: reflect.TypeFor[int]()},
+			{Name: "verbose", In: "query", Type: reflect.TypeFor[bool]()},
+			{Name: "X-Tenant", In: "header", Required: true},
+		},
+	})
+
+	r.GET("/users/me", func(c *fursy.Context) error {
+		return c.String(http.StatusOK, "me")
+	})
+
+	r.GET("/files/*path", func(c *fursy.Context) error {
+		return c.String(http.StatusOK, c.Param("path"))
+	})
+
+	return r
+}
+
+// validatorErrors decodes the "errors" extension of a problem response
+// into a map of field names to messages.
+func validatorErrors(t *testing.T, body string) map[string]string {
+	t.Helper()
+
+	var problem struct {
+		Status int               `json:"status"`
+		Errors []fursy.Violation `json:"errors"`
+	}
+	if err := json.Unmarshal([]byte(body), &problem); err != nil {
+		t.Fatalf("invalid problem JSON: %v: %s", err, body)
+	}
+	errs := make(map[string]string, len(problem.Errors))
+	for _, v := range problem.Errors {
+		errs[v.Field] = v.Detail
+	}
+	return errs
+}
+
+// TestOpenAPIValidator_Body tests JSON body validation against the request schema.
+func TestOpenAPIValidator_Body(t *testing.T) {
+	r := newValidatorRouter(OpenAPIValidatorConfig{})
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantErrors []string
+	}{
+		{"valid", `{"name":"Alice","age":30}`, http.StatusCreated, nil},
+		{"unknown property allowed", `{"name":"Alice","age":30,"extra":1}`, http.StatusCreated, nil},
+		{"wrong types", `{"name":42,"age":"old"}`, http.StatusBadRequest, []string{"body.name", "body.age"}},
+		{"missing required", `{"name":"Alice"}`, http.StatusBadRequest, []string{"body.age"}},
+		{"non-integer", `{"name":"Alice","age":1.5}`, http.StatusBadRequest, []string{"body.age"}},
+		{"array items", `{"name":"Alice","age":1,"tags":["a",2]}`, http.StatusBadRequest, []string{"body.tags[1]"}},
+		{"nested object", `{"name":"Alice","age":1,"address":{"city":7}}`, http.StatusBadRequest, []string{"body.address.city"}},
+		{"invalid JSON", `{"name":`, http.StatusBadRequest, []string{"body"}},
+		{"empty body", ``, http.StatusBadRequest, []string{"body"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantErrors == nil {
+				return
+			}
+
+			if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/problem+json") {
+				t.Errorf("expected problem+json, got %q", ct)
+			}
+			errs := validatorErrors(t, w.Body.String())
+			if len(errs) != len(tt.wantErrors) {
+				t.Errorf("expected errors %v, got %v", tt.wantErrors, errs)
+			}
+			for _, field := range tt.wantErrors {
+				if _, ok := errs[field]; !ok {
+					t.Errorf("expected error for %q, got %v", field, errs)
+				}
+			}
+		})
+	}
+}
+
+// TestOpenAPIValidator_BodyRestored tests that the handler can still bind the body.
+func TestOpenAPIValidator_BodyRestored(t *testing.T) {
+	r := newValidatorRouter(OpenAPIValidatorConfig{})
+
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"Alice","age":30}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var got validatorUser
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got.Name != "Alice" || got.Age != 30 {
+		t.Errorf("expected bound body, got %+v", got)
+	}
+}
+
+// TestOpenAPIValidator_Parameters tests path, query and header parameter validation.
+func TestOpenAPIValidator_Parameters(t *testing.T) {
+	r := newValidatorRouter(OpenAPIValidatorConfig{})
+
+	tests := []struct {
+		name       string
+		target     string
+		tenant     string
+		wantStatus int
+		wantErrors []string
+	}{
+		{"valid", "/users/42?verbose=true", "acme", http.StatusOK, nil},
+		{"undocumented query allowed", "/users/42?debug=1", "acme", http.StatusOK, nil},
+		{"wrong path type", "/users/abc", "acme", http.StatusBadRequest, []string{"path.id"}},
+		{"wrong query type", "/users/42?verbose=maybe", "acme", http.StatusBadRequest, []string{"query.verbose"}},
+		{"missing header", "/users/42", "", http.StatusBadRequest, []string{"header.X-Tenant"}},
+		{"static route preferred", "/users/me", "", http.StatusOK, nil},
+		{"wildcard route", "/files/a/b/c.txt", "", http.StatusOK, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.tenant != "" {
+				req.Header.Set("X-Tenant", tt.tenant)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantErrors == nil {
+				return
+			}
+			errs := validatorErrors(t, w.Body.String())
+			for _, field := range tt.wantErrors {
+				if _, ok := errs[field]; !ok {
+					t.Errorf("expected error for %q, got %v", field, errs)
+				}
+			}
+		})
+	}
+}
+
+// TestOpenAPIValidator_Strict tests rejection of undocumented query parameters and properties.
+func TestOpenAPIValidator_Strict(t *testing.T) {
+	r := newValidatorRouter(OpenAPIValidatorConfig{Strict: true})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42?verbose=1&debug=1", nil)
+	req.Header.Set("X-Tenant", "acme")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+	if errs := validatorErrors(t, w.Body.String()); len(errs) != 1 || errs["query.debug"] == "" {
+		t.Errorf("expected query.debug error, got %v", errs)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"Alice","age":30,"admin":true}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+	if errs := validatorErrors(t, w.Body.String()); errs["body.admin"] == "" {
+		t.Errorf("expected body.admin error, got %v", errs)
+	}
+	if !strings.Contains(w.Body.String(), `"pointer":"/admin"`) {
+		t.Errorf("expected pointer /admin, got %s", w.Body.String())
+	}
+}
+
+// TestOpenAPIValidator_Undocumented tests that unknown routes and methods pass through.
+func TestOpenAPIValidator_Undocumented(t *testing.T) {
+	r := newValidatorRouter(OpenAPIValidatorConfig{Strict: true})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/missing?x=1", nil))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+}
+
+// TestOpenAPIValidator_NonJSONBody tests that non-JSON bodies are not validated.
+func TestOpenAPIValidator_NonJSONBody(t *testing.T) {
+	r := fursy.New()
+	r.Use(OpenAPIValidator())
+
+	var got string
+	fursy.POST[validatorUser, fursy.Empty](r, "/upload", func(c *fursy.Box[validatorUser, fursy.Empty]) error {
+		got = c.ReqBody.Name
+		return c.NoContentSuccess()
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("name=Alice"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if got != "Alice" {
+		t.Errorf("expected restored body to be bound, got %q", got)
+	}
+}
+
+// TestOpenAPIValidator_CustomConfig tests a custom document, skipper and error handler.
+func TestOpenAPIValidator_CustomConfig(t *testing.T) {
+	doc := &fursy.OpenAPI{
+		Paths: map[string]fursy.PathItem{
+			"/items": {Get: &fursy.Operation{Parameters: []fursy.Parameter{
+				{Name: "sort", In: "query", Schema: &fursy.Schema{Type: "string", Enum: []any{"asc", "desc"}}},
+			}}},
+		},
+	}
+
+	r := fursy.New()
+	r.Use(OpenAPIValidatorWithConfig(OpenAPIValidatorConfig{
+		Document: doc,
+		Skipper:  fursy.SkipPaths("/skip"),
+		ErrorHandler: func(c *fursy.Context, errs fursy.ValidationErrors) error {
+			return c.String(http.StatusTeapot, errs[0].Field+": "+errs[0].Message)
+		},
+	}))
+	r.GET("/items", func(c *fursy.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items?sort=random", nil))
+
+	if w.Code != http.StatusTeapot {
+		t.Fatalf("expected status 418, got %d", w.Code)
+	}
+	if want := "query.sort: must be one of [asc, desc]"; w.Body.String() != want {
+		t.Errorf("expected %q, got %q", want, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items?sort=asc", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+}
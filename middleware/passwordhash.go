@@ -0,0 +1,210 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package middleware provides hashed password verification for BasicAuth.
+package middleware
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/coregx/fursy"
+)
+
+// PBKDF2 parameters used by HashPassword (OWASP 2025 recommendation for PBKDF2-HMAC-SHA256).
+const (
+	pbkdf2Iterations = 600000
+	pbkdf2SaltSize   = 16
+	pbkdf2KeySize    = 32
+	pbkdf2Prefix     = "$pbkdf2-sha256$"
+)
+
+// unknownUserHash is a HashPassword hash that passwords of unknown
+// usernames are verified against until a real hash has been looked up.
+var unknownUserHash = "$pbkdf2-sha256$600000$fgMaXmgQfCbqV/gx/M1LZw$4BX9ONdFfJectkIq+MRYrNwDdMqj+Dz/uoSBcqEhyVQ"
+
+// PasswordVerifier reports whether password matches the stored hash.
+//
+// The package only implements PBKDF2 (HashPassword and VerifyPassword),
+// with the standard library. bcrypt and argon2id are not provided; adapt
+// a library implementing them:
+//
+//	// golang.org/x/crypto/bcrypt
+//	bcryptVerifier := func(hash, password string) bool {
+//	    return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+//	}
+//
+//	// github.com/alexedwards/argon2id
+//	argon2Verifier := func(hash, password string) bool {
+//	    ok, err := argon2id.ComparePasswordAndHash(password, hash)
+//	    return err == nil && ok
+//	}
+type PasswordVerifier func(hash, password string) bool
+
+// UserProvider returns the stored password hash of username.
+// It returns an error (e.g., fursy.ErrUnauthorized) if the user does not exist.
+//
+// The database plugin provides one backed by SQL (database.PasswordHashLookup).
+type UserProvider func(c *fursy.Context, username string) (hash string, err error)
+
+// BasicAuthHashed creates a validator that checks passwords against hashes
+// returned by provider, using verify.
+// The identity stored in the context is the username.
+//
+// When provider returns an error (e.g., for an unknown username), the
+// password is still verified, against the first hash provider returned
+// (or a PBKDF2 hash before any), so that unknown usernames take as long
+// to reject as wrong passwords and cannot be enumerated by timing.
+//
+// Example:
+//
+//	router.Use(middleware.BasicAuthWithConfig(middleware.BasicAuthConfig{
+//	    Validator: middleware.BasicAuthHashed(
+//	        database.PasswordHashLookup("SELECT password_hash FROM users WHERE email = $1"),
+//	        middleware.VerifyPassword,
+//	    ),
+//	    MaxFailures: 5,
+//	}))
+func BasicAuthHashed(provider UserProvider, verify PasswordVerifier) ValidatorFunc {
+	if provider == nil || verify == nil {
+		panic("fursy/middleware: BasicAuthHashed provider and verifier cannot be nil")
+	}
+
+	// A hash in the format of verify, once provider has returned one.
+	var dummy atomic.Pointer[string]
+	dummy.Store(&unknownUserHash)
+
+	return func(c *fursy.Context, username, password string) (interface{}, error) {
+		hash, err := provider(c, username)
+		if err != nil {
+			verify(*dummy.Load(), password)
+			return nil, err
+		}
+		if hash != "" {
+			dummy.CompareAndSwap(&unknownUserHash, &hash)
+		}
+		if hash == "" || !verify(hash, password) {
+			return nil, fursy.ErrUnauthorized
+		}
+		return username, nil
+	}
+}
+
+// BasicAuthHashedAccounts creates a validator that checks credentials against
+// a map of usernames to password hashes, using verify.
+//
+// Passwords of unknown usernames are verified against the hash of another
+// account, so that they take as long to reject as wrong passwords and
+// usernames cannot be enumerated by timing.
+//
+// Example:
+//
+//	// Hashes created once with middleware.HashPassword("...").
+//	accounts := map[string]string{
+//	    "admin": "$pbkdf2-sha256$600000$...",
+//	}
+//	router.Use(middleware.BasicAuth(middleware.BasicAuthHashedAccounts(accounts, middleware.VerifyPassword)))
+func BasicAuthHashedAccounts(accounts map[string]string, verify PasswordVerifier) ValidatorFunc {
+	if verify == nil {
+		panic("fursy/middleware: BasicAuthHashedAccounts verifier cannot be nil")
+	}
+
+	// A fixed hash (of the first username) in the format of verify.
+	var dummy string
+	if len(accounts) > 0 {
+		dummy = accounts[slices.Min(slices.Collect(maps.Keys(accounts)))]
+	}
+
+	return func(_ *fursy.Context, username, password string) (interface{}, error) {
+		hash, ok := accounts[username]
+		if !ok {
+			if dummy != "" {
+				verify(dummy, password)
+			}
+			return nil, fursy.ErrUnauthorized
+		}
+		if hash == "" || !verify(hash, password) {
+			return nil, fursy.ErrUnauthorized
+		}
+		return username, nil
+	}
+}
+
+// HashPassword hashes password with PBKDF2-HMAC-SHA256 (600,000 iterations,
+// random 16-byte salt) using only the standard library.
+//
+// The result has the form "$pbkdf2-sha256$<iterations>$<salt>$<key>"
+// and is verified with VerifyPassword.
+//
+// Example:
+//
+//	hash, err := middleware.HashPassword("s3cret")
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, pbkdf2SaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key, err := pbkdf2.Key(sha256.New, password, salt, pbkdf2Iterations, pbkdf2KeySize)
+	if err != nil {
+		return "", err
+	}
+
+	return pbkdf2Prefix + strconv.Itoa(pbkdf2Iterations) + "$" +
+		base64.RawStdEncoding.EncodeToString(salt) + "$" +
+		base64.RawStdEncoding.EncodeToString(key), nil
+}
+
+// VerifyPassword reports whether password matches a hash created by HashPassword.
+// The comparison is constant-time. Malformed hashes never match.
+func VerifyPassword(hash, password string) bool {
+	iterations, salt, key, err := parsePasswordHash(hash)
+	if err != nil {
+		return false
+	}
+
+	derived, err := pbkdf2.Key(sha256.New, password, salt, iterations, len(key))
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(derived, key) == 1
+}
+
+// errPasswordHash is returned for hashes not created by HashPassword.
+var errPasswordHash = errors.New("invalid password hash")
+
+// parsePasswordHash splits "$pbkdf2-sha256$<iterations>$<salt>$<key>".
+func parsePasswordHash(hash string) (iterations int, salt, key []byte, err error) {
+	rest, ok := strings.CutPrefix(hash, pbkdf2Prefix)
+	if !ok {
+		return 0, nil, nil, errPasswordHash
+	}
+
+	parts := strings.Split(rest, "$")
+	if len(parts) != 3 {
+		return 0, nil, nil, errPasswordHash
+	}
+
+	iterations, err = strconv.Atoi(parts[0])
+	if err != nil || iterations <= 0 {
+		return 0, nil, nil, errPasswordHash
+	}
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[1]); err != nil {
+		return 0, nil, nil, errPasswordHash
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[2]); err != nil || len(key) == 0 {
+		return 0, nil, nil, errPasswordHash
+	}
+
+	return iterations, salt, key, nil
+}
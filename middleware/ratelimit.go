@@ -32,16 +32,24 @@ type RateLimitConfig struct {
 
 	// KeyFunc extracts the rate limit key from the request.
 	// Common strategies:
-	//   - IP-based: func(c) string { return c.RealIP() }
+	//   - IP-based: func(c) string { return c.ClientIP() }
 	//   - User-based: func(c) string { return c.GetString("user_id") }
 	//   - API key: func(c) string { return c.Request.Header.Get("X-API-Key") }
 	//   - Global: func(c) string { return "global" }
-	// Default: IP-based (c.RealIP())
+	// Default: IP-based (X-Real-IP, X-Forwarded-For or RemoteAddr)
 	KeyFunc func(c *fursy.Context) string
 
+	// CostFunc returns the number of tokens a request consumes, so
+	// expensive requests (e.g., bulk operations) drain the bucket faster.
+	// Use BodySizeCost to express Rate and Burst in bytes of request body.
+	// Requests costing more than Burst are always rejected.
+	// Default: nil (every request costs 1 token)
+	CostFunc func(c *fursy.Context) int
+
 	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
 	// Default: nil (middleware always executes)
-	Skipper func(c *fursy.Context) bool
+	Skipper fursy.Skipper
 
 	// Store is the storage for per-key limiters.
 	// If nil, uses in-memory map with cleanup goroutine.
@@ -202,7 +210,7 @@ func (s *inMemoryStore) Cleanup(expireAfter time.Duration) {
 //	    Rate:  5,
 //	    Burst: 10,
 //	    KeyFunc: func(c *fursy.Context) string {
-//	        return c.RealIP()
+//	        return c.ClientIP()
 //	    },
 //	}))
 //
@@ -292,7 +300,7 @@ func RateLimitWithConfig(config RateLimitConfig) fursy.HandlerFunc {
 
 	return func(c *fursy.Context) error {
 		// Skip if Skipper returns true.
-		if config.Skipper != nil && config.Skipper(c) {
+		if config.Skipper.ShouldSkip(c) {
 			return c.Next()
 		}
 
@@ -309,10 +317,15 @@ func RateLimitWithConfig(config RateLimitConfig) fursy.HandlerFunc {
 			limiter = config.Store.GetLimiter(key, rateLimit, config.Burst)
 		}
 
-		// Try to consume a token.
-		reservation := limiter.Reserve()
+		// Try to consume the tokens of the request.
+		cost := 1
+		if config.CostFunc != nil {
+			cost = config.CostFunc(c)
+		}
+		reservation := limiter.ReserveN(time.Now(), cost)
 		if !reservation.OK() {
-			// Rate limit exceeded (should not happen with valid config).
+			// Rate limit exceeded (cost exceeds burst).
+			currentMetrics().RateLimitRejected(c)
 			return config.ErrorHandler(c, time.Second)
 		}
 
@@ -320,6 +333,7 @@ func RateLimitWithConfig(config RateLimitConfig) fursy.HandlerFunc {
 		if delay > 0 {
 			// Rate limit exceeded - cancel reservation.
 			reservation.Cancel()
+			currentMetrics().RateLimitRejected(c)
 
 			// Set Retry-After header.
 			retryAfter := delay
@@ -361,6 +375,26 @@ func setRateLimitHeaders(c *fursy.Context, limiter *rate.Limiter, limit, _ int)
 	c.SetHeader("X-RateLimit-Reset", fmt.Sprintf("%d", reset))
 }
 
+// BodySizeCost is a RateLimitConfig.CostFunc charging the Content-Length
+// of the request body, so Rate and Burst are expressed in bytes per second.
+// Requests without a known body length cost 1 token; combine it with
+// RouteOptions.MaxBodySize to bound them.
+//
+// Example:
+//
+//	// Upload at most 1 MiB/s per client, with bursts of 8 MiB.
+//	router.POST("/upload", handler, middleware.RateLimitWithConfig(middleware.RateLimitConfig{
+//	    Rate:     1 << 20,
+//	    Burst:    8 << 20,
+//	    CostFunc: middleware.BodySizeCost,
+//	}))
+func BodySizeCost(c *fursy.Context) int {
+	if c.Request.ContentLength > 0 {
+		return int(c.Request.ContentLength)
+	}
+	return 1
+}
+
 // defaultRateLimitErrorHandler is the default error handler for rate limit exceeded.
 func defaultRateLimitErrorHandler(c *fursy.Context, retryAfter time.Duration) error {
 	// Set Retry-After header (RFC 6585).
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -650,3 +651,74 @@ func TestRateLimit_DefaultBurst(t *testing.T) {
 		t.Errorf("expected 429 after default burst, got %d", rec.Code)
 	}
 }
+
+func TestRateLimit_CostFunc(t *testing.T) {
+	router := fursy.New()
+	router.Use(RateLimitWithConfig(RateLimitConfig{
+		Rate:  0.01,
+		Burst: 10,
+		CostFunc: func(c *fursy.Context) int {
+			if c.Request.Method == http.MethodPost {
+				return 8
+			}
+			return 1
+		},
+	}))
+
+	router.GET("/", func(c *fursy.Context) error {
+		return c.String(200, "OK")
+	})
+	router.POST("/", func(c *fursy.Context) error {
+		return c.String(200, "OK")
+	})
+
+	serve := func(method string) int {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, "/", http.NoBody))
+		return rec.Code
+	}
+
+	if code := serve(http.MethodPost); code != 200 {
+		t.Errorf("expected expensive request to pass, got %d", code)
+	}
+	if code := serve(http.MethodPost); code != 429 {
+		t.Errorf("expected second expensive request to be limited, got %d", code)
+	}
+	for i := 0; i < 2; i++ {
+		if code := serve(http.MethodGet); code != 200 {
+			t.Errorf("cheap request %d: expected status 200, got %d", i+1, code)
+		}
+	}
+	if code := serve(http.MethodGet); code != 429 {
+		t.Errorf("expected bucket to be exhausted, got %d", code)
+	}
+}
+
+func TestBodySizeCost(t *testing.T) {
+	router := fursy.New()
+	router.Use(RateLimitWithConfig(RateLimitConfig{
+		Rate:     0.01,
+		Burst:    10,
+		CostFunc: BodySizeCost,
+	}))
+
+	router.POST("/", func(c *fursy.Context) error {
+		return c.String(200, "OK")
+	})
+
+	serve := func(body string) int {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+		return rec.Code
+	}
+
+	if code := serve("this body exceeds the burst"); code != 429 {
+		t.Errorf("expected oversized body to be limited, got %d", code)
+	}
+	if code := serve("123456"); code != 200 {
+		t.Errorf("expected status 200, got %d", code)
+	}
+	if code := serve("123456"); code != 429 {
+		t.Errorf("expected status 429, got %d", code)
+	}
+}
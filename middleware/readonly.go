@@ -0,0 +1,174 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package middleware provides read-only mode middleware.
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// ReadOnlySwitch turns read-only mode on and off at runtime, e.g. from an
+// admin endpoint or a failover hook. The zero value is off (read-write).
+// It is safe for concurrent use.
+type ReadOnlySwitch struct {
+	enabled atomic.Bool
+	reason  atomic.Pointer[string]
+}
+
+// Enable turns read-only mode on. The reason is the detail of the
+// problems sent to rejected requests ("" = default detail).
+func (s *ReadOnlySwitch) Enable(reason string) {
+	s.reason.Store(&reason)
+	s.enabled.Store(true)
+}
+
+// Disable turns read-only mode off.
+func (s *ReadOnlySwitch) Disable() {
+	s.enabled.Store(false)
+}
+
+// Enabled reports whether read-only mode is on.
+func (s *ReadOnlySwitch) Enabled() bool {
+	return s.enabled.Load()
+}
+
+// Reason returns the reason given to Enable.
+func (s *ReadOnlySwitch) Reason() string {
+	if r := s.reason.Load(); r != nil {
+		return *r
+	}
+	return ""
+}
+
+// ReadOnlyConfig defines the configuration for the ReadOnly middleware.
+type ReadOnlyConfig struct {
+	// Switch turns read-only mode on and off.
+	// Required.
+	Switch *ReadOnlySwitch
+
+	// Methods are the mutating methods rejected in read-only mode.
+	// Default: POST, PUT, PATCH, DELETE
+	Methods []string
+
+	// AllowPaths are path patterns still writable in read-only mode, e.g.
+	// login and token refresh endpoints (see fursy.SkipPaths for the syntax).
+	// Default: nil
+	AllowPaths []string
+
+	// Status is the status of rejected requests:
+	// 503 Service Unavailable or 405 Method Not Allowed.
+	// Default: 503
+	Status int
+
+	// RetryAfter is sent in the Retry-After header of rejected requests
+	// (0 = no header).
+	// Default: 0
+	RetryAfter time.Duration
+
+	// Skipper defines a function to skip the middleware.
+	// Default: nil (middleware always executes)
+	Skipper fursy.Skipper
+
+	// ErrorHandler is called when a request is rejected.
+	// Default: Status problem with the reason of the switch as detail
+	ErrorHandler func(c *fursy.Context) error
+}
+
+// ReadOnly returns a middleware that rejects mutating requests
+// (POST, PUT, PATCH, DELETE) with 503 Service Unavailable while the switch
+// is enabled, and lets reads through. Use it during database failovers and
+// migrations.
+//
+// Example:
+//
+//	var readOnly middleware.ReadOnlySwitch
+//	router.Use(middleware.ReadOnly(&readOnly))
+//
+//	// During a failover:
+//	readOnly.Enable("database failover in progress")
+//	defer readOnly.Disable()
+func ReadOnly(s *ReadOnlySwitch) fursy.HandlerFunc {
+	return ReadOnlyWithConfig(ReadOnlyConfig{Switch: s})
+}
+
+// ReadOnlyWithConfig returns a middleware with custom configuration.
+//
+// Example (keep authentication writable):
+//
+//	router.Use(middleware.ReadOnlyWithConfig(middleware.ReadOnlyConfig{
+//	    Switch:     &readOnly,
+//	    AllowPaths: []string{"/auth/*"},
+//	    RetryAfter: time.Minute,
+//	}))
+func ReadOnlyWithConfig(config ReadOnlyConfig) fursy.HandlerFunc {
+	// Validate config.
+	if config.Switch == nil {
+		panic("fursy/middleware: ReadOnly switch is required")
+	}
+
+	// Set defaults.
+	if len(config.Methods) == 0 {
+		config.Methods = []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+	}
+	if config.Status == 0 {
+		config.Status = http.StatusServiceUnavailable
+	}
+	if config.Status != http.StatusServiceUnavailable && config.Status != http.StatusMethodNotAllowed {
+		panic("fursy/middleware: ReadOnly status must be 503 or 405")
+	}
+	if config.ErrorHandler == nil {
+		config.ErrorHandler = readOnlyErrorHandler(config)
+	}
+
+	var allowed fursy.Skipper
+	if len(config.AllowPaths) > 0 {
+		allowed = fursy.SkipPaths(config.AllowPaths...)
+	}
+
+	return func(c *fursy.Context) error {
+		if !config.Switch.Enabled() || !slices.Contains(config.Methods, c.Request.Method) {
+			return c.Next()
+		}
+		if config.Skipper.ShouldSkip(c) || allowed.ShouldSkip(c) {
+			return c.Next()
+		}
+
+		if config.RetryAfter > 0 {
+			c.SetHeader("Retry-After", strconv.Itoa(int((config.RetryAfter+time.Second-1)/time.Second)))
+		}
+		return config.ErrorHandler(c)
+	}
+}
+
+// readOnlyErrorHandler returns the default handler of rejected requests:
+// a problem with the status of config. 405 responses list the methods
+// still allowed in the Allow header.
+func readOnlyErrorHandler(config ReadOnlyConfig) func(c *fursy.Context) error {
+	var allow []string
+	for _, m := range []string{http.MethodGet, http.MethodHead, http.MethodOptions} {
+		if !slices.Contains(config.Methods, m) {
+			allow = append(allow, m)
+		}
+	}
+
+	return func(c *fursy.Context) error {
+		detail := config.Switch.Reason()
+		if detail == "" {
+			detail = "the service is in read-only mode"
+		}
+		if config.Status == http.StatusMethodNotAllowed {
+			c.SetHeader("Allow", strings.Join(allow, ", "))
+			return c.Problem(fursy.MethodNotAllowed(detail))
+		}
+		return c.Problem(fursy.ServiceUnavailable(detail))
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// newReadOnlyRouter returns a router with mw and read and write routes.
+func newReadOnlyRouter(mw fursy.HandlerFunc) *fursy.Router {
+	router := fursy.New()
+	router.Use(mw)
+	ok := func(c *fursy.Context) error { return c.String(http.StatusOK, "OK") }
+	router.GET("/orders", ok)
+	router.POST("/orders", ok)
+	router.DELETE("/orders/:id", ok)
+	router.POST("/auth/login", ok)
+	return router
+}
+
+// serveMethod serves a request with method to path.
+func serveMethod(router *fursy.Router, method, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(method, path, http.NoBody))
+	return rec
+}
+
+func TestReadOnly_Toggle(t *testing.T) {
+	var readOnly ReadOnlySwitch
+	router := newReadOnlyRouter(ReadOnly(&readOnly))
+
+	if rec := serveMethod(router, http.MethodPost, "/orders"); rec.Code != http.StatusOK {
+		t.Errorf("disabled: expected status 200, got %d", rec.Code)
+	}
+
+	readOnly.Enable("database failover in progress")
+	if !readOnly.Enabled() {
+		t.Fatal("expected switch to be enabled")
+	}
+
+	rec := serveMethod(router, http.MethodPost, "/orders")
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("enabled: expected status 503, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "database failover in progress") {
+		t.Errorf("expected reason in problem detail, got %s", rec.Body.String())
+	}
+	if rec := serveMethod(router, http.MethodDelete, "/orders/1"); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("enabled DELETE: expected status 503, got %d", rec.Code)
+	}
+	if rec := serveMethod(router, http.MethodGet, "/orders"); rec.Code != http.StatusOK {
+		t.Errorf("enabled GET: expected status 200, got %d", rec.Code)
+	}
+
+	readOnly.Disable()
+	if rec := serveMethod(router, http.MethodPost, "/orders"); rec.Code != http.StatusOK {
+		t.Errorf("re-disabled: expected status 200, got %d", rec.Code)
+	}
+}
+
+func TestReadOnly_Config(t *testing.T) {
+	var readOnly ReadOnlySwitch
+	readOnly.Enable("")
+	router := newReadOnlyRouter(ReadOnlyWithConfig(ReadOnlyConfig{
+		Switch:     &readOnly,
+		AllowPaths: []string{"/auth/*"},
+		Status:     http.StatusMethodNotAllowed,
+		RetryAfter: 90 * time.Second,
+	}))
+
+	rec := serveMethod(router, http.MethodPost, "/orders")
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "90" {
+		t.Errorf("expected Retry-After 90, got %q", got)
+	}
+	if got := rec.Header().Get("Allow"); got != "GET, HEAD, OPTIONS" {
+		t.Errorf("expected Allow GET, HEAD, OPTIONS, got %q", got)
+	}
+	if !strings.Contains(rec.Body.String(), "read-only mode") {
+		t.Errorf("expected default detail, got %s", rec.Body.String())
+	}
+
+	if rec := serveMethod(router, http.MethodPost, "/auth/login"); rec.Code != http.StatusOK {
+		t.Errorf("allowed path: expected status 200, got %d", rec.Code)
+	}
+}
+
+func TestReadOnly_InvalidConfig(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for invalid status")
+		}
+	}()
+	ReadOnlyWithConfig(ReadOnlyConfig{Switch: &ReadOnlySwitch{}, Status: http.StatusForbidden})
+}
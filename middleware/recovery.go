@@ -6,16 +6,39 @@
 package middleware
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"os"
 	"runtime"
+	"strings"
 
 	"github.com/coregx/fursy"
 )
 
+// PanicInfo describes a recovered panic.
+// It is passed to RecoveryConfig.OnPanic.
+type PanicInfo struct {
+	// Value is the value passed to panic().
+	Value any
+
+	// Err is the panic value converted to an error.
+	Err error
+
+	// Stack is the formatted stack trace of the panicking goroutine.
+	// Framework frames are omitted unless RecoveryConfig.FullStackTrace is set.
+	// Empty if RecoveryConfig.DisableStackTrace is set.
+	Stack string
+
+	// IncidentID identifies this panic in logs, alerts and the problem response.
+	// It is taken from the IncidentIDHeader request header when present,
+	// so it correlates with upstream request IDs.
+	IncidentID string
+}
+
 // RecoveryConfig defines the configuration for the Recovery middleware.
 type RecoveryConfig struct {
 	// Logger is the slog.Logger instance to use for logging panics.
@@ -33,6 +56,27 @@ type RecoveryConfig struct {
 	// StackTraceSize is the maximum size of the stack trace buffer in bytes.
 	// Default: 4KB (4096 bytes).
 	StackTraceSize int
+
+	// FullStackTrace includes runtime, net/http and FURSY frames in the stack trace.
+	// Default: false (only application frames are included).
+	FullStackTrace bool
+
+	// OnPanic is called after a panic is recovered and logged, before the
+	// response is sent. Use it to report panics to Sentry or alerting systems.
+	// Router OnPanic hooks are also notified (see fursy.Context.NotifyPanic).
+	// Default: nil
+	OnPanic func(c *fursy.Context, info PanicInfo)
+
+	// ProblemResponse sends an RFC 9457 problem (application/problem+json)
+	// with an "incident_id" extension instead of a plain text response.
+	// Default: false (plain text "Internal Server Error").
+	ProblemResponse bool
+
+	// IncidentIDHeader is the request header used as the incident ID
+	// (for correlation with upstream request IDs). If the header is missing,
+	// a random ID is generated. The ID is also set on the response.
+	// Default: "X-Request-ID"
+	IncidentIDHeader string
 }
 
 // Recovery returns a middleware that recovers from panics in request handlers.
@@ -54,6 +98,15 @@ type RecoveryConfig struct {
 //	router.Use(middleware.RecoveryWithConfig(middleware.RecoveryConfig{
 //	    Logger: logger,
 //	}))
+//
+// With problem responses and alerting:
+//
+//	router.Use(middleware.RecoveryWithConfig(middleware.RecoveryConfig{
+//	    ProblemResponse: true,
+//	    OnPanic: func(c *fursy.Context, info middleware.PanicInfo) {
+//	        sentry.CaptureException(info.Err)
+//	    },
+//	}))
 func Recovery() fursy.HandlerFunc {
 	return RecoveryWithConfig(RecoveryConfig{})
 }
@@ -84,6 +137,10 @@ func RecoveryWithConfig(config RecoveryConfig) fursy.HandlerFunc {
 		stackTraceSize = 4096 // 4KB default
 	}
 
+	if config.IncidentIDHeader == "" {
+		config.IncidentIDHeader = "X-Request-ID"
+	}
+
 	return func(c *fursy.Context) (err error) {
 		defer func() {
 			if r := recover(); r != nil {
@@ -97,30 +154,86 @@ func RecoveryWithConfig(config RecoveryConfig) fursy.HandlerFunc {
 
 // handlePanic handles a recovered panic by logging and sending error response.
 func handlePanic(r interface{}, c *fursy.Context, logger *slog.Logger, config RecoveryConfig, stackTraceSize int) error {
-	// Get stack trace.
-	stack := getStackTrace(config.DisableStackTrace, stackTraceSize)
-
-	// Convert panic to error.
-	panicErr := convertPanicToError(r)
+	info := PanicInfo{
+		Value:      r,
+		Err:        convertPanicToError(r),
+		Stack:      getStackTrace(config.DisableStackTrace, config.FullStackTrace, stackTraceSize),
+		IncidentID: incidentID(c, config.IncidentIDHeader),
+	}
 
 	// Log panic.
-	logPanic(c, logger, panicErr, stack, config.DisableStackTrace)
+	logPanic(c, logger, info, config.DisableStackTrace)
 
 	// Print stack to stderr for visibility.
-	printStackToStderr(panicErr, stack, config)
+	printStackToStderr(info, config)
+
+	// Notify hooks (alerting, tracing).
+	if config.OnPanic != nil {
+		config.OnPanic(c, info)
+	}
+	c.NotifyPanic(r)
 
 	// Send 500 response.
+	c.SetHeader(config.IncidentIDHeader, info.IncidentID)
+	if config.ProblemResponse {
+		return c.Problem(fursy.InternalServerError("An unexpected error occurred.").
+			WithExtension("incident_id", info.IncidentID))
+	}
 	return c.String(http.StatusInternalServerError, "Internal Server Error")
 }
 
-// getStackTrace gets the current stack trace if not disabled.
-func getStackTrace(disableStackTrace bool, stackTraceSize int) []byte {
+// getStackTrace returns the formatted stack trace of the panicking goroutine.
+// Framework frames are skipped unless full is set.
+// The result is truncated to stackTraceSize bytes.
+func getStackTrace(disableStackTrace, full bool, stackTraceSize int) string {
 	if disableStackTrace {
-		return nil
+		return ""
 	}
 
-	stack := make([]byte, stackTraceSize)
-	return stack[:runtime.Stack(stack, false)]
+	pcs := make([]uintptr, 64)
+	pcs = pcs[:runtime.Callers(3, pcs)] // Skip runtime.Callers, getStackTrace, handlePanic.
+
+	var b strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if full || !isFrameworkFrame(frame) {
+			fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		}
+		if !more || b.Len() >= stackTraceSize {
+			break
+		}
+	}
+
+	stack := b.String()
+	if len(stack) > stackTraceSize {
+		stack = stack[:stackTraceSize]
+	}
+	return stack
+}
+
+// isFrameworkFrame reports whether the frame belongs to the Go runtime,
+// net/http, or FURSY itself (excluding test files).
+func isFrameworkFrame(frame runtime.Frame) bool {
+	switch {
+	case strings.HasPrefix(frame.Function, "runtime."),
+		strings.HasPrefix(frame.Function, "net/http."),
+		strings.HasPrefix(frame.Function, "testing."):
+		return true
+	case strings.HasPrefix(frame.Function, "github.com/coregx/fursy"):
+		return !strings.HasSuffix(frame.File, "_test.go")
+	}
+	return false
+}
+
+// incidentID returns the correlation ID from the request header or generates a new one.
+func incidentID(c *fursy.Context, header string) string {
+	if id := c.Request.Header.Get(header); id != "" {
+		return id
+	}
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
 }
 
 // convertPanicToError converts a panic value to an error.
@@ -132,27 +245,28 @@ func convertPanicToError(r interface{}) error {
 }
 
 // logPanic logs the panic with structured fields.
-func logPanic(c *fursy.Context, logger *slog.Logger, panicErr error, stack []byte, disableStackTrace bool) {
+func logPanic(c *fursy.Context, logger *slog.Logger, info PanicInfo, disableStackTrace bool) {
 	attrs := []slog.Attr{
-		slog.String("panic", panicErr.Error()),
+		slog.String("panic", info.Err.Error()),
+		slog.String("incident_id", info.IncidentID),
 		slog.String("method", c.Request.Method),
 		slog.String("path", c.Request.URL.Path),
 		slog.String("remote_addr", c.Request.RemoteAddr),
 	}
 
-	if !disableStackTrace && len(stack) > 0 {
-		attrs = append(attrs, slog.String("stack", string(stack)))
+	if !disableStackTrace && info.Stack != "" {
+		attrs = append(attrs, slog.String("stack", info.Stack))
 	}
 
 	logger.LogAttrs(c.Request.Context(), slog.LevelError, "Panic recovered", attrs...)
 }
 
 // printStackToStderr prints stack trace to stderr if enabled.
-func printStackToStderr(panicErr error, stack []byte, config RecoveryConfig) {
-	if config.DisablePrintStack || config.DisableStackTrace || len(stack) == 0 {
+func printStackToStderr(info PanicInfo, config RecoveryConfig) {
+	if config.DisablePrintStack || config.DisableStackTrace || info.Stack == "" {
 		return
 	}
-	fmt.Fprintf(os.Stderr, "PANIC: %v\n%s\n", panicErr, stack)
+	fmt.Fprintf(os.Stderr, "PANIC [%s]: %v\n%s\n", info.IncidentID, info.Err, info.Stack)
 }
 
 // PanicHandler is a simplified version of Recovery that only recovers panics
@@ -168,6 +282,8 @@ func PanicHandler() fursy.HandlerFunc {
 	return func(c *fursy.Context) (err error) {
 		defer func() {
 			if r := recover(); r != nil {
+				c.NotifyPanic(r)
+
 				// Convert panic to error.
 				if e, ok := r.(error); ok {
 					err = e
@@ -7,6 +7,7 @@ package middleware
 import (
 	"bytes"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -460,3 +461,133 @@ func TestRecovery_MultipleRequests(t *testing.T) {
 		t.Errorf("third request: expected status 500, got %d", w3.Code)
 	}
 }
+
+// TestRecovery_OnPanic tests the OnPanic hook.
+func TestRecovery_OnPanic(t *testing.T) {
+	var info PanicInfo
+	called := false
+
+	r := fursy.New()
+	r.Use(RecoveryWithConfig(RecoveryConfig{
+		Logger:            DefaultRecoveryLogger(io.Discard),
+		DisablePrintStack: true,
+		OnPanic: func(_ *fursy.Context, i PanicInfo) {
+			called = true
+			info = i
+		},
+	}))
+
+	r.GET("/panic", func(_ *fursy.Context) error {
+		panic("hook panic")
+	})
+
+	req := httptest.NewRequest("GET", "/panic", http.NoBody)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if !called {
+		t.Fatal("OnPanic should be called")
+	}
+	if info.Value != "hook panic" || info.Err.Error() != "hook panic" {
+		t.Errorf("unexpected panic info: %+v", info)
+	}
+	if info.IncidentID == "" || w.Header().Get("X-Request-ID") != info.IncidentID {
+		t.Errorf("expected incident ID in response header, got %q (info %q)", w.Header().Get("X-Request-ID"), info.IncidentID)
+	}
+
+	// Stack includes the handler but not framework frames.
+	if !strings.Contains(info.Stack, "TestRecovery_OnPanic") {
+		t.Errorf("stack should contain handler frame, got:\n%s", info.Stack)
+	}
+	for _, fw := range []string{"runtime.gopanic", "fursy.(*Router).ServeHTTP", "middleware.RecoveryWithConfig"} {
+		if strings.Contains(info.Stack, fw) {
+			t.Errorf("stack should not contain framework frame %q, got:\n%s", fw, info.Stack)
+		}
+	}
+}
+
+// TestRecovery_FullStackTrace tests that framework frames can be included.
+func TestRecovery_FullStackTrace(t *testing.T) {
+	var stack string
+
+	r := fursy.New()
+	r.Use(RecoveryWithConfig(RecoveryConfig{
+		Logger:            DefaultRecoveryLogger(io.Discard),
+		DisablePrintStack: true,
+		FullStackTrace:    true,
+		StackTraceSize:    64 * 1024,
+		OnPanic: func(_ *fursy.Context, i PanicInfo) {
+			stack = i.Stack
+		},
+	}))
+
+	r.GET("/panic", func(_ *fursy.Context) error {
+		panic("full stack")
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/panic", http.NoBody))
+
+	if !strings.Contains(stack, "fursy.(*Router).ServeHTTP") {
+		t.Errorf("full stack should contain framework frames, got:\n%s", stack)
+	}
+}
+
+// TestRecovery_ProblemResponse tests RFC 9457 responses with incident ID.
+func TestRecovery_ProblemResponse(t *testing.T) {
+	var buf bytes.Buffer
+
+	r := fursy.New()
+	r.Use(RecoveryWithConfig(RecoveryConfig{
+		Logger:            JSONRecoveryLogger(&buf),
+		DisablePrintStack: true,
+		ProblemResponse:   true,
+	}))
+
+	r.GET("/panic", func(_ *fursy.Context) error {
+		panic("problem panic")
+	})
+
+	req := httptest.NewRequest("GET", "/panic", http.NoBody)
+	req.Header.Set("X-Request-ID", "req-123")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/problem+json") {
+		t.Errorf("expected problem+json, got %q", ct)
+	}
+	if !strings.Contains(w.Body.String(), `"incident_id":"req-123"`) {
+		t.Errorf("expected incident_id in body, got %s", w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "problem panic") {
+		t.Errorf("panic message should not leak to client, got %s", w.Body.String())
+	}
+	if !strings.Contains(buf.String(), `"incident_id":"req-123"`) {
+		t.Errorf("expected incident_id in log, got %s", buf.String())
+	}
+}
+
+// TestRecovery_RouterOnPanic tests that recovered panics reach router OnPanic hooks.
+func TestRecovery_RouterOnPanic(t *testing.T) {
+	var recovered []interface{}
+	r := fursy.New()
+	r.OnPanic(func(_ *fursy.Context, rec any) {
+		recovered = append(recovered, rec)
+	})
+	r.Use(RecoveryWithConfig(RecoveryConfig{Logger: DefaultRecoveryLogger(io.Discard), DisablePrintStack: true}))
+	r.GET("/panic", func(_ *fursy.Context) error {
+		panic("test panic")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", http.NoBody))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", w.Code)
+	}
+	if len(recovered) != 1 || recovered[0] != "test panic" {
+		t.Errorf("OnPanic hooks got %v", recovered)
+	}
+}
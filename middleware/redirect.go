@@ -0,0 +1,173 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package middleware provides HTTPS and www redirect middleware.
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/coregx/fursy"
+)
+
+// ACMEChallengePath is the path prefix of ACME HTTP-01 challenges
+// (Let's Encrypt), which must be served over plain HTTP.
+const ACMEChallengePath = "/.well-known/acme-challenge/"
+
+// RedirectConfig defines the configuration for the redirect middleware
+// (HTTPSRedirect, WWWRedirect, NonWWWRedirect).
+type RedirectConfig struct {
+	// Code is the redirect status code.
+	// Use http.StatusPermanentRedirect (308) to preserve the method and
+	// body of non-GET requests.
+	// Default: 301 Moved Permanently
+	Code int
+
+	// ExemptPaths lists path prefixes that are never redirected.
+	// Default: []string{ACMEChallengePath}
+	ExemptPaths []string
+
+	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
+	// Default: nil (middleware always executes)
+	Skipper fursy.Skipper
+}
+
+// HTTPSRedirect returns a middleware that redirects HTTP requests to HTTPS.
+//
+// The scheme and host are taken from c.Scheme() and c.Host(), which honor
+// X-Forwarded-Proto and X-Forwarded-Host from trusted proxies (see
+// Router.SetTrustedProxies), so it works behind a TLS-terminating load balancer.
+// The port of the host is dropped, so the redirect targets the default
+// HTTPS port. ACME challenges are not redirected.
+//
+// Example:
+//
+//	router.SetTrustedProxies("10.0.0.0/8")
+//	router.Use(middleware.HTTPSRedirect())
+func HTTPSRedirect() fursy.HandlerFunc {
+	return HTTPSRedirectWithConfig(RedirectConfig{})
+}
+
+// HTTPSRedirectWithConfig returns an HTTPS redirect middleware with custom configuration.
+//
+// Example:
+//
+//	router.Use(middleware.HTTPSRedirectWithConfig(middleware.RedirectConfig{
+//	    Code:        http.StatusPermanentRedirect,
+//	    ExemptPaths: []string{middleware.ACMEChallengePath, "/health"},
+//	}))
+func HTTPSRedirectWithConfig(config RedirectConfig) fursy.HandlerFunc {
+	return redirectWithConfig(config, func(c *fursy.Context) (string, bool) {
+		if c.Scheme() == "https" {
+			return "", false
+		}
+		return "https://" + hostWithoutPort(c.Host()), true
+	})
+}
+
+// WWWRedirect returns a middleware that redirects requests to the "www."
+// subdomain (example.com → www.example.com). IP addresses and localhost
+// are not redirected.
+//
+// Example:
+//
+//	router.Use(middleware.WWWRedirect())
+func WWWRedirect() fursy.HandlerFunc {
+	return WWWRedirectWithConfig(RedirectConfig{})
+}
+
+// WWWRedirectWithConfig returns a www redirect middleware with custom configuration.
+func WWWRedirectWithConfig(config RedirectConfig) fursy.HandlerFunc {
+	return redirectWithConfig(config, func(c *fursy.Context) (string, bool) {
+		host := c.Host()
+		if strings.HasPrefix(host, "www.") || !isDomainHost(host) {
+			return "", false
+		}
+		return c.Scheme() + "://www." + host, true
+	})
+}
+
+// NonWWWRedirect returns a middleware that redirects requests from the "www."
+// subdomain to the bare domain (www.example.com → example.com).
+//
+// Example:
+//
+//	router.Use(middleware.NonWWWRedirect())
+func NonWWWRedirect() fursy.HandlerFunc {
+	return NonWWWRedirectWithConfig(RedirectConfig{})
+}
+
+// NonWWWRedirectWithConfig returns a non-www redirect middleware with custom configuration.
+func NonWWWRedirectWithConfig(config RedirectConfig) fursy.HandlerFunc {
+	return redirectWithConfig(config, func(c *fursy.Context) (string, bool) {
+		host, ok := strings.CutPrefix(c.Host(), "www.")
+		if !ok {
+			return "", false
+		}
+		return c.Scheme() + "://" + host, true
+	})
+}
+
+// redirectWithConfig builds a redirect middleware. target returns the new
+// scheme and host, and whether the request must be redirected.
+func redirectWithConfig(config RedirectConfig, target func(c *fursy.Context) (string, bool)) fursy.HandlerFunc {
+	// Set defaults.
+	if config.Code == 0 {
+		config.Code = http.StatusMovedPermanently
+	}
+	if config.ExemptPaths == nil {
+		config.ExemptPaths = []string{ACMEChallengePath}
+	}
+
+	// Validate config.
+	if config.Code < 300 || config.Code > 308 {
+		panic("fursy/middleware: invalid redirect code")
+	}
+
+	return func(c *fursy.Context) error {
+		// Skip if Skipper returns true.
+		if config.Skipper.ShouldSkip(c) {
+			return c.Next()
+		}
+
+		for _, prefix := range config.ExemptPaths {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				return c.Next()
+			}
+		}
+
+		base, ok := target(c)
+		if !ok {
+			return c.Next()
+		}
+
+		return c.Redirect(config.Code, base+c.Request.URL.RequestURI())
+	}
+}
+
+// hostWithoutPort returns host without its port, if any: the port of a
+// plain HTTP listener (e.g. :8080) is not the HTTPS port.
+func hostWithoutPort(host string) string {
+	h, _, err := net.SplitHostPort(host)
+	if err != nil {
+		return host
+	}
+	if strings.Contains(h, ":") {
+		return "[" + h + "]"
+	}
+	return h
+}
+
+// isDomainHost reports whether host (optionally with port) is a domain name
+// rather than an IP address or localhost.
+func isDomainHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.Trim(host, "[]")
+	return host != "" && host != "localhost" && net.ParseIP(host) == nil
+}
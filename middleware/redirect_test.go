@@ -0,0 +1,135 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/coregx/fursy"
+)
+
+// serveRedirect sends a request through a router using mw.
+func serveRedirect(mw fursy.HandlerFunc, target string, headers map[string]string) *httptest.ResponseRecorder {
+	r := fursy.New()
+	r.SetTrustedProxies("10.0.0.0/8")
+	r.Use(mw)
+	r.GET("/*path", func(c *fursy.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if headers["X-Forwarded-Proto"] != "" {
+		req.RemoteAddr = "10.0.0.1:1234"
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// TestHTTPSRedirect tests redirecting HTTP requests to HTTPS.
+func TestHTTPSRedirect(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		headers  map[string]string
+		code     int
+		location string
+	}{
+		{"http", "http://example.com/users?page=2", nil, http.StatusMovedPermanently, "https://example.com/users?page=2"},
+		{"https", "https://example.com/users", nil, http.StatusOK, ""},
+		{"behind TLS proxy", "http://example.com/users", map[string]string{"X-Forwarded-Proto": "https"}, http.StatusOK, ""},
+		{"behind plain proxy", "http://example.com/users", map[string]string{"X-Forwarded-Proto": "http"}, http.StatusMovedPermanently, "https://example.com/users"},
+		{"port", "http://example.com:8080/users", nil, http.StatusMovedPermanently, "https://example.com/users"},
+		{"IPv6 port", "http://[::1]:8080/users", nil, http.StatusMovedPermanently, "https://[::1]/users"},
+		{"ACME challenge", "http://example.com/.well-known/acme-challenge/token", nil, http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveRedirect(HTTPSRedirect(), tt.target, tt.headers)
+			if w.Code != tt.code {
+				t.Errorf("expected status %d, got %d", tt.code, w.Code)
+			}
+			if loc := w.Header().Get("Location"); loc != tt.location {
+				t.Errorf("expected Location %q, got %q", tt.location, loc)
+			}
+		})
+	}
+}
+
+// TestHTTPSRedirect_Config tests custom code and exempt paths.
+func TestHTTPSRedirect_Config(t *testing.T) {
+	mw := HTTPSRedirectWithConfig(RedirectConfig{
+		Code:        http.StatusPermanentRedirect,
+		ExemptPaths: []string{"/health"},
+	})
+
+	if w := serveRedirect(mw, "http://example.com/login", nil); w.Code != http.StatusPermanentRedirect {
+		t.Errorf("expected status 308, got %d", w.Code)
+	}
+	if w := serveRedirect(mw, "http://example.com/health", nil); w.Code != http.StatusOK {
+		t.Errorf("expected exempt path to pass, got %d", w.Code)
+	}
+	if w := serveRedirect(mw, "http://example.com/.well-known/acme-challenge/x", nil); w.Code != http.StatusPermanentRedirect {
+		t.Errorf("expected custom ExemptPaths to replace the default, got %d", w.Code)
+	}
+}
+
+// TestWWWRedirect tests redirecting to the www subdomain.
+func TestWWWRedirect(t *testing.T) {
+	tests := []struct {
+		target   string
+		code     int
+		location string
+	}{
+		{"http://example.com/a?b=c", http.StatusMovedPermanently, "http://www.example.com/a?b=c"},
+		{"https://example.com:8443/a", http.StatusMovedPermanently, "https://www.example.com:8443/a"},
+		{"http://www.example.com/a", http.StatusOK, ""},
+		{"http://127.0.0.1:8080/a", http.StatusOK, ""},
+		{"http://localhost/a", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			w := serveRedirect(WWWRedirect(), tt.target, nil)
+			if w.Code != tt.code {
+				t.Errorf("expected status %d, got %d", tt.code, w.Code)
+			}
+			if loc := w.Header().Get("Location"); loc != tt.location {
+				t.Errorf("expected Location %q, got %q", tt.location, loc)
+			}
+		})
+	}
+}
+
+// TestNonWWWRedirect tests redirecting to the bare domain.
+func TestNonWWWRedirect(t *testing.T) {
+	w := serveRedirect(NonWWWRedirect(), "https://www.example.com/a?b=c", nil)
+	if w.Code != http.StatusMovedPermanently {
+		t.Fatalf("expected status 301, got %d", w.Code)
+	}
+	if loc := w.Header().Get("Location"); loc != "https://example.com/a?b=c" {
+		t.Errorf("expected Location %q, got %q", "https://example.com/a?b=c", loc)
+	}
+
+	if w := serveRedirect(NonWWWRedirect(), "https://example.com/a", nil); w.Code != http.StatusOK {
+		t.Errorf("expected bare domain to pass, got %d", w.Code)
+	}
+}
+
+// TestRedirect_InvalidCode tests that an invalid code panics.
+func TestRedirect_InvalidCode(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	HTTPSRedirectWithConfig(RedirectConfig{Code: http.StatusOK})
+}
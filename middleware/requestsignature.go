@@ -0,0 +1,731 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package middleware provides request signature verification middleware.
+package middleware
+
+import (
+	"bytes"
+	"container/heap"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// SignatureKeyIDContextKey is the key used to store the key ID of a verified
+// request signature in the context.
+const SignatureKeyIDContextKey = "signature_key_id"
+
+// Default values for the RequestSignature middleware.
+const (
+	// DefaultSignatureMaxAge is the default maximum age of a request signature.
+	DefaultSignatureMaxAge = 5 * time.Minute
+
+	// DefaultSignatureMaxBodySize is the default maximum body size read
+	// to verify a request signature (1 MB).
+	DefaultSignatureMaxBodySize = 1 << 20
+
+	// DefaultNonceCacheSize is the default capacity of NewMemoryNonceCache.
+	DefaultNonceCacheSize = 100000
+)
+
+// Request signature errors.
+var (
+	ErrRequestSignatureMissing  = errors.New("missing request signature")
+	ErrRequestSignatureInvalid  = errors.New("invalid request signature")
+	ErrRequestSignatureExpired  = errors.New("request signature has expired")
+	ErrRequestSignatureReplayed = errors.New("request signature has already been used")
+)
+
+// SignatureScheme is a request signature format.
+type SignatureScheme int
+
+const (
+	// SignatureHTTPMessage is RFC 9421 HTTP Message Signatures
+	// (Signature-Input and Signature headers).
+	SignatureHTTPMessage SignatureScheme = iota
+
+	// SignatureHMAC is a hex HMAC-SHA256 of "<timestamp>.<body>", sent in
+	// the signature header with the Unix timestamp in the timestamp header.
+	SignatureHMAC
+)
+
+// SignatureKeyResolver returns the verification key of keyID.
+//
+// Supported keys: []byte (hmac-sha256), ed25519.PublicKey (ed25519),
+// *ecdsa.PublicKey (ecdsa-p256-sha256, ecdsa-p384-sha384) and
+// *rsa.PublicKey (rsa-pss-sha512, or rsa-v1_5-sha256 if the signature
+// says so). SignatureHMAC only supports []byte keys.
+type SignatureKeyResolver func(keyID string) (interface{}, error)
+
+// NonceCache records the nonces of verified signatures to reject replays.
+// Implement it with a shared store (e.g., Redis SET NX) when running several instances.
+type NonceCache interface {
+	// Use records nonce until expiresAt. It returns false if nonce was
+	// already recorded and has not expired.
+	Use(nonce string, expiresAt time.Time) (bool, error)
+}
+
+// RequestSignatureConfig defines the configuration for the RequestSignature middleware.
+type RequestSignatureConfig struct {
+	// Keys resolves the key ID of a signature to its verification key.
+	// Required.
+	Keys SignatureKeyResolver
+
+	// Scheme is the signature format.
+	// Default: SignatureHTTPMessage
+	Scheme SignatureScheme
+
+	// Components are the components an HTTP message signature must cover.
+	// Requests with a body must also cover "content-digest" (RFC 9530),
+	// which is checked against the body.
+	// Default: "@method", "@authority", "@path", "@query"
+	Components []string
+
+	// Label selects the signature to verify when a request carries several.
+	// Default: "" (the first signature)
+	Label string
+
+	// Tag is the required application tag of HTTP message signatures.
+	// Default: "" (not checked)
+	Tag string
+
+	// AllowUncoveredBody accepts HTTP message signatures that do not
+	// cover the body with content-digest.
+	// Default: false
+	AllowUncoveredBody bool
+
+	// MaxAge is the maximum age of a signature (its created parameter or
+	// timestamp). Negative value disables the check.
+	// Default: 5 minutes
+	MaxAge time.Duration
+
+	// NonceCache rejects signatures that were already used (replays).
+	// The nonce parameter is used, or the signature itself if there is none.
+	// Default: in-memory cache of DefaultNonceCacheSize entries
+	NonceCache NonceCache
+
+	// SignatureHeader, TimestampHeader and KeyIDHeader are the headers of
+	// SignatureHMAC. The key ID header is optional in requests.
+	// Default: "X-Signature", "X-Timestamp" and "X-Key-Id"
+	SignatureHeader string
+	TimestampHeader string
+	KeyIDHeader     string
+
+	// MaxBodySize is the maximum body size in bytes.
+	// Default: 1 MB
+	MaxBodySize int64
+
+	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
+	// Default: nil (middleware always executes)
+	Skipper fursy.Skipper
+
+	// ErrorHandler is called when verification fails.
+	// Default: 401 Unauthorized problem
+	ErrorHandler func(c *fursy.Context, err error) error
+}
+
+// RequestSignature returns a middleware that verifies RFC 9421 HTTP Message
+// Signatures, e.g. of partner or webhook traffic, before the handler runs.
+//
+// The middleware:
+//   - Verifies the signature with the key of its keyid parameter
+//   - Requires the configured components to be covered
+//   - Checks content-digest against the body (restored for the handler)
+//   - Rejects expired signatures and replays (see NonceCache)
+//   - Stores the key ID under SignatureKeyIDContextKey
+//
+// Example:
+//
+//	partners := map[string]interface{}{
+//	    "acme": acmePublicKey, // ed25519.PublicKey
+//	}
+//	api := router.Group("/partner", middleware.RequestSignature(func(keyID string) (interface{}, error) {
+//	    if key, ok := partners[keyID]; ok {
+//	        return key, nil
+//	    }
+//	    return nil, errors.New("unknown key")
+//	}))
+func RequestSignature(keys SignatureKeyResolver) fursy.HandlerFunc {
+	return RequestSignatureWithConfig(RequestSignatureConfig{
+		Keys: keys,
+	})
+}
+
+// RequestSignatureWithConfig returns a middleware with custom configuration.
+//
+// Example (HMAC of body and timestamp):
+//
+//	secret := []byte(os.Getenv("PARTNER_SECRET"))
+//	router.Use(middleware.RequestSignatureWithConfig(middleware.RequestSignatureConfig{
+//	    Scheme: middleware.SignatureHMAC,
+//	    Keys: func(string) (interface{}, error) {
+//	        return secret, nil
+//	    },
+//	}))
+//
+//nolint:gocognit,gocyclo,cyclop // Sequential verification steps
+func RequestSignatureWithConfig(config RequestSignatureConfig) fursy.HandlerFunc {
+	// Validate config.
+	if config.Keys == nil {
+		panic("fursy/middleware: RequestSignature key resolver cannot be nil")
+	}
+
+	// Set defaults.
+	if config.Components == nil {
+		config.Components = []string{"@method", "@authority", "@path", "@query"}
+	}
+	if config.MaxAge == 0 {
+		config.MaxAge = DefaultSignatureMaxAge
+	}
+	if config.NonceCache == nil {
+		config.NonceCache = NewMemoryNonceCache(DefaultNonceCacheSize)
+	}
+	if config.SignatureHeader == "" {
+		config.SignatureHeader = "X-Signature"
+	}
+	if config.TimestampHeader == "" {
+		config.TimestampHeader = "X-Timestamp"
+	}
+	if config.KeyIDHeader == "" {
+		config.KeyIDHeader = "X-Key-Id"
+	}
+	if config.MaxBodySize <= 0 {
+		config.MaxBodySize = DefaultSignatureMaxBodySize
+	}
+	if config.ErrorHandler == nil {
+		config.ErrorHandler = defaultRequestSignatureErrorHandler
+	}
+
+	return func(c *fursy.Context) error {
+		// Skip if Skipper returns true.
+		if config.Skipper.ShouldSkip(c) {
+			return c.Next()
+		}
+
+		var body []byte
+		if c.Request.Body != nil && c.Request.Body != http.NoBody {
+			var err error
+			body, err = io.ReadAll(io.LimitReader(c.Request.Body, config.MaxBodySize+1))
+			if err != nil {
+				return err
+			}
+			if int64(len(body)) > config.MaxBodySize {
+				return c.Problem(fursy.NewProblem(http.StatusRequestEntityTooLarge, "Content Too Large",
+					"request body exceeds the allowed size"))
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		var v signatureVerification
+		var err error
+		if config.Scheme == SignatureHMAC {
+			v, err = verifyHMACSignature(c, &config, body)
+		} else {
+			v, err = verifyMessageSignature(c, &config, body)
+		}
+		if err != nil {
+			return config.ErrorHandler(c, err)
+		}
+
+		// Reject replays.
+		fresh, err := config.NonceCache.Use(v.keyID+"\x00"+v.nonce, v.expiresAt)
+		if err != nil {
+			return config.ErrorHandler(c, fmt.Errorf("%w: %w", ErrRequestSignatureInvalid, err))
+		}
+		if !fresh {
+			return config.ErrorHandler(c, ErrRequestSignatureReplayed)
+		}
+
+		c.Set(SignatureKeyIDContextKey, v.keyID)
+		return c.Next()
+	}
+}
+
+// signatureVerification is the result of a successful signature check.
+type signatureVerification struct {
+	keyID     string
+	nonce     string
+	expiresAt time.Time
+}
+
+// verifyHMACSignature checks a SignatureHMAC signature.
+func verifyHMACSignature(c *fursy.Context, config *RequestSignatureConfig, body []byte) (signatureVerification, error) {
+	sig := c.Request.Header.Get(config.SignatureHeader)
+	ts := c.Request.Header.Get(config.TimestampHeader)
+	if sig == "" || ts == "" {
+		return signatureVerification{}, ErrRequestSignatureMissing
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return signatureVerification{}, fmt.Errorf("%w: invalid timestamp", ErrRequestSignatureInvalid)
+	}
+	created := time.Unix(unix, 0)
+
+	keyID := c.Request.Header.Get(config.KeyIDHeader)
+	key, err := config.Keys(keyID)
+	if err != nil {
+		return signatureVerification{}, fmt.Errorf("%w: %w", ErrRequestSignatureInvalid, err)
+	}
+	secret, ok := key.([]byte)
+	if !ok {
+		return signatureVerification{}, fmt.Errorf("%w: HMAC requires a []byte key", ErrRequestSignatureInvalid)
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(ts + "."))
+	mac.Write(body)
+	got, err := hex.DecodeString(strings.TrimPrefix(sig, "sha256="))
+	if err != nil || !hmac.Equal(got, mac.Sum(nil)) {
+		return signatureVerification{}, ErrRequestSignatureInvalid
+	}
+
+	expiresAt, err := signatureExpiry(config.MaxAge, created, time.Time{})
+	if err != nil {
+		return signatureVerification{}, err
+	}
+	return signatureVerification{keyID: keyID, nonce: sig, expiresAt: expiresAt}, nil
+}
+
+// verifyMessageSignature checks an RFC 9421 HTTP message signature.
+func verifyMessageSignature(c *fursy.Context, config *RequestSignatureConfig, body []byte) (signatureVerification, error) {
+	inputs := c.Request.Header.Get("Signature-Input")
+	signatures := c.Request.Header.Get("Signature")
+	if inputs == "" || signatures == "" {
+		return signatureVerification{}, ErrRequestSignatureMissing
+	}
+
+	// Select the signature.
+	inputMembers, err := fursy.ParseSFDictionary(strings.Join(c.Request.Header.Values("Signature-Input"), ", "))
+	if err != nil || len(inputMembers) == 0 {
+		return signatureVerification{}, fmt.Errorf("%w: malformed Signature-Input", ErrRequestSignatureInvalid)
+	}
+	label := config.Label
+	if label == "" {
+		label = inputMembers[0].Key
+	}
+	input, ok := inputMembers.Get(label)
+	if !ok {
+		return signatureVerification{}, ErrRequestSignatureMissing
+	}
+	sigMembers, err := fursy.ParseSFDictionary(strings.Join(c.Request.Header.Values("Signature"), ", "))
+	if err != nil {
+		return signatureVerification{}, fmt.Errorf("%w: malformed Signature", ErrRequestSignatureInvalid)
+	}
+	sigMember, ok := sigMembers.Get(label)
+	if !ok {
+		return signatureVerification{}, ErrRequestSignatureMissing
+	}
+	sig, ok := sigMember.Value.([]byte)
+	if !ok {
+		return signatureVerification{}, fmt.Errorf("%w: malformed Signature", ErrRequestSignatureInvalid)
+	}
+
+	params, err := parseSignatureParams(input)
+	if err != nil {
+		return signatureVerification{}, fmt.Errorf("%w: %w", ErrRequestSignatureInvalid, err)
+	}
+
+	// Check coverage.
+	covered := make([]string, 0, len(params.components))
+	for _, comp := range params.components {
+		covered = append(covered, comp.name)
+	}
+	for _, name := range config.Components {
+		if !slices.Contains(covered, name) {
+			return signatureVerification{}, fmt.Errorf("%w: %s is not covered", ErrRequestSignatureInvalid, name)
+		}
+	}
+	if len(body) > 0 {
+		if slices.Contains(covered, "content-digest") {
+			if err := checkContentDigest(c.Request.Header.Get("Content-Digest"), body); err != nil {
+				return signatureVerification{}, err
+			}
+		} else if !config.AllowUncoveredBody {
+			return signatureVerification{}, fmt.Errorf("%w: content-digest is not covered", ErrRequestSignatureInvalid)
+		}
+	}
+	if config.Tag != "" && params.tag != config.Tag {
+		return signatureVerification{}, fmt.Errorf("%w: unexpected tag", ErrRequestSignatureInvalid)
+	}
+
+	// Check freshness before the (expensive) signature verification.
+	if config.MaxAge >= 0 && params.created.IsZero() {
+		return signatureVerification{}, fmt.Errorf("%w: created parameter is required", ErrRequestSignatureInvalid)
+	}
+	expiresAt, err := signatureExpiry(config.MaxAge, params.created, params.expires)
+	if err != nil {
+		return signatureVerification{}, err
+	}
+
+	base, err := signatureBase(c.Request, c.Scheme(), c.Host(), params)
+	if err != nil {
+		return signatureVerification{}, fmt.Errorf("%w: %w", ErrRequestSignatureInvalid, err)
+	}
+
+	key, err := config.Keys(params.keyID)
+	if err != nil {
+		return signatureVerification{}, fmt.Errorf("%w: %w", ErrRequestSignatureInvalid, err)
+	}
+	if err := verifySignatureBytes(key, params.alg, []byte(base), sig); err != nil {
+		return signatureVerification{}, err
+	}
+
+	nonce := params.nonce
+	if nonce == "" {
+		nonce = base64.StdEncoding.EncodeToString(sig)
+	}
+	return signatureVerification{keyID: params.keyID, nonce: nonce, expiresAt: expiresAt}, nil
+}
+
+// signatureExpiry checks the age of a signature and returns when its
+// nonce can be forgotten.
+func signatureExpiry(maxAge time.Duration, created, expires time.Time) (time.Time, error) {
+	now := time.Now()
+	if !expires.IsZero() && now.After(expires) {
+		return time.Time{}, ErrRequestSignatureExpired
+	}
+
+	expiresAt := expires
+	if maxAge >= 0 && !created.IsZero() {
+		if age := now.Sub(created); age > maxAge || age < -maxAge {
+			return time.Time{}, ErrRequestSignatureExpired
+		}
+		if limit := created.Add(maxAge); expiresAt.IsZero() || limit.Before(expiresAt) {
+			expiresAt = limit
+		}
+	}
+	if expiresAt.IsZero() {
+		expiresAt = now.Add(DefaultSignatureMaxAge)
+	}
+	return expiresAt, nil
+}
+
+// signatureComponent is a covered component.
+type signatureComponent struct {
+	name  string
+	id    string // serialized component identifier, with its parameters
+	query string // name parameter of @query-param
+}
+
+// signatureParams are the parsed parameters of a Signature-Input member.
+type signatureParams struct {
+	components []signatureComponent
+	raw        string // canonical serialization for @signature-params
+	created    time.Time
+	expires    time.Time
+	nonce      string
+	alg        string
+	keyID      string
+	tag        string
+}
+
+// parseSignatureParams parses a Signature-Input member, an inner list
+// such as ("@method" "@path");created=1618884473;keyid="key".
+func parseSignatureParams(input fursy.SFMember) (signatureParams, error) {
+	var p signatureParams
+	if input.InnerList == nil {
+		return p, errors.New("signature input is not an inner list")
+	}
+
+	for _, item := range input.InnerList {
+		name, ok := item.Value.(string)
+		if !ok {
+			return p, errors.New("component identifier is not a string")
+		}
+		comp := signatureComponent{name: name, id: item.String()}
+		for _, param := range item.Params {
+			query, ok := param.Value.(string)
+			if param.Key != "name" || name != "@query-param" || !ok {
+				return p, fmt.Errorf("unsupported component parameter %q", param.Key)
+			}
+			comp.query = query
+		}
+		p.components = append(p.components, comp)
+	}
+
+	for _, param := range input.Params {
+		switch param.Key {
+		case "created", "expires":
+			n, ok := param.Value.(int64)
+			if !ok {
+				return p, fmt.Errorf("%s parameter is not an integer", param.Key)
+			}
+			if param.Key == "created" {
+				p.created = time.Unix(n, 0)
+			} else {
+				p.expires = time.Unix(n, 0)
+			}
+		case "nonce", "alg", "keyid", "tag":
+			value, ok := param.Value.(string)
+			if !ok {
+				return p, fmt.Errorf("%s parameter is not a string", param.Key)
+			}
+			switch param.Key {
+			case "nonce":
+				p.nonce = value
+			case "alg":
+				p.alg = value
+			case "keyid":
+				p.keyID = value
+			default:
+				p.tag = value
+			}
+		}
+	}
+	p.raw = input.String()
+	return p, nil
+}
+
+// signatureBase builds the RFC 9421 signature base of req, received with
+// the given scheme and host.
+func signatureBase(req *http.Request, scheme, host string, p signatureParams) (string, error) {
+	var b strings.Builder
+	for _, comp := range p.components {
+		value, err := componentValue(req, scheme, host, comp)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(comp.id)
+		b.WriteString(": ")
+		b.WriteString(value)
+		b.WriteByte('\n')
+	}
+	b.WriteString(`"@signature-params": `)
+	b.WriteString(p.raw)
+	return b.String(), nil
+}
+
+// componentValue returns the value of a covered component.
+func componentValue(req *http.Request, scheme, host string, comp signatureComponent) (string, error) {
+	switch comp.name {
+	case "@method":
+		return req.Method, nil
+	case "@target-uri":
+		return scheme + "://" + host + req.URL.RequestURI(), nil
+	case "@authority":
+		return strings.ToLower(host), nil
+	case "@scheme":
+		return scheme, nil
+	case "@request-target":
+		return req.URL.RequestURI(), nil
+	case "@path":
+		if path := req.URL.EscapedPath(); path != "" {
+			return path, nil
+		}
+		return "/", nil
+	case "@query":
+		return "?" + req.URL.RawQuery, nil
+	case "@query-param":
+		values := req.URL.Query()[comp.query]
+		if len(values) != 1 {
+			return "", fmt.Errorf("query parameter %q must occur once", comp.query)
+		}
+		return url.QueryEscape(values[0]), nil
+	}
+	if strings.HasPrefix(comp.name, "@") {
+		return "", fmt.Errorf("unsupported component %q", comp.name)
+	}
+
+	// Header fields.
+	values := req.Header.Values(comp.name)
+	if len(values) == 0 {
+		switch comp.name {
+		case "host":
+			return req.Host, nil
+		case "content-length":
+			if req.ContentLength >= 0 {
+				return strconv.FormatInt(req.ContentLength, 10), nil
+			}
+		}
+		return "", fmt.Errorf("header %q is missing", comp.name)
+	}
+	for i, v := range values {
+		values[i] = strings.TrimSpace(v)
+	}
+	return strings.Join(values, ", "), nil
+}
+
+// verifySignatureBytes verifies sig over base with key.
+func verifySignatureBytes(key interface{}, alg string, base, sig []byte) error {
+	var ok bool
+	switch k := key.(type) {
+	case []byte:
+		if alg != "" && alg != "hmac-sha256" {
+			break
+		}
+		mac := hmac.New(sha256.New, k)
+		mac.Write(base)
+		ok = hmac.Equal(sig, mac.Sum(nil))
+	case ed25519.PublicKey:
+		if alg != "" && alg != "ed25519" {
+			break
+		}
+		ok = ed25519.Verify(k, base, sig)
+	case *ecdsa.PublicKey:
+		var h hash.Hash
+		switch k.Curve.Params().BitSize {
+		case 256:
+			if alg == "" || alg == "ecdsa-p256-sha256" {
+				h = sha256.New()
+			}
+		case 384:
+			if alg == "" || alg == "ecdsa-p384-sha384" {
+				h = sha512.New384()
+			}
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if h == nil || len(sig) != 2*size {
+			break
+		}
+		h.Write(base)
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		ok = ecdsa.Verify(k, h.Sum(nil), r, s)
+	case *rsa.PublicKey:
+		switch alg {
+		case "", "rsa-pss-sha512":
+			digest := sha512.Sum512(base)
+			ok = rsa.VerifyPSS(k, crypto.SHA512, digest[:], sig, &rsa.PSSOptions{SaltLength: 64}) == nil
+		case "rsa-v1_5-sha256":
+			digest := sha256.Sum256(base)
+			ok = rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig) == nil
+		}
+	default:
+		return fmt.Errorf("%w: unsupported key type %T", ErrRequestSignatureInvalid, key)
+	}
+	if !ok {
+		return ErrRequestSignatureInvalid
+	}
+	return nil
+}
+
+// checkContentDigest checks a Content-Digest header (RFC 9530) against body.
+// Every supported digest must match, and at least one must be present.
+func checkContentDigest(header string, body []byte) error {
+	members, err := fursy.ParseSFDictionary(header)
+	if err != nil {
+		return fmt.Errorf("%w: malformed Content-Digest", ErrRequestSignatureInvalid)
+	}
+
+	checked := false
+	for _, m := range members {
+		var sum []byte
+		switch m.Key {
+		case "sha-256":
+			s := sha256.Sum256(body)
+			sum = s[:]
+		case "sha-512":
+			s := sha512.Sum512(body)
+			sum = s[:]
+		default:
+			continue
+		}
+		digest, ok := m.Value.([]byte)
+		if !ok || !hmac.Equal(digest, sum) {
+			return fmt.Errorf("%w: content digest mismatch", ErrRequestSignatureInvalid)
+		}
+		checked = true
+	}
+	if !checked {
+		return fmt.Errorf("%w: no supported content digest", ErrRequestSignatureInvalid)
+	}
+	return nil
+}
+
+// defaultRequestSignatureErrorHandler sends a 401 Unauthorized problem.
+// The verification details are not disclosed to the client.
+func defaultRequestSignatureErrorHandler(c *fursy.Context, err error) error {
+	detail := ErrRequestSignatureInvalid.Error()
+	for _, known := range []error{ErrRequestSignatureMissing, ErrRequestSignatureExpired, ErrRequestSignatureReplayed} {
+		if errors.Is(err, known) {
+			detail = known.Error()
+		}
+	}
+	return c.Problem(fursy.Unauthorized(detail))
+}
+
+// memoryNonceCache is an in-memory NonceCache. Nonces are also kept in
+// a min-heap by expiry, so expired nonces are evicted oldest first
+// without scanning the cache.
+type memoryNonceCache struct {
+	mu     sync.Mutex
+	nonces map[string]struct{}
+	expiry nonceHeap
+	max    int
+}
+
+// NewMemoryNonceCache returns an in-memory NonceCache holding up to size
+// unexpired nonces. When full, new signatures are rejected until nonces
+// expire, so size it for the expected request rate times the max age.
+// It is safe for concurrent use.
+func NewMemoryNonceCache(size int) NonceCache {
+	if size <= 0 {
+		size = DefaultNonceCacheSize
+	}
+	return &memoryNonceCache{nonces: make(map[string]struct{}), max: size}
+}
+
+// Use implements NonceCache.
+func (m *memoryNonceCache) Use(nonce string, expiresAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for len(m.expiry) > 0 && !now.Before(m.expiry[0].expiresAt) {
+		delete(m.nonces, heap.Pop(&m.expiry).(nonceEntry).nonce)
+	}
+	if _, ok := m.nonces[nonce]; ok {
+		return false, nil
+	}
+	if len(m.nonces) >= m.max {
+		return false, errors.New("nonce cache is full")
+	}
+	m.nonces[nonce] = struct{}{}
+	heap.Push(&m.expiry, nonceEntry{nonce: nonce, expiresAt: expiresAt})
+	return true, nil
+}
+
+// nonceEntry is a nonce of memoryNonceCache with its expiry.
+type nonceEntry struct {
+	nonce     string
+	expiresAt time.Time
+}
+
+// nonceHeap orders nonces by expiry (container/heap).
+type nonceHeap []nonceEntry
+
+func (h nonceHeap) Len() int           { return len(h) }
+func (h nonceHeap) Less(i, j int) bool { return h[i].expiresAt.Before(h[j].expiresAt) }
+func (h nonceHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *nonceHeap) Push(x any)        { *h = append(*h, x.(nonceEntry)) }
+
+func (h *nonceHeap) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return entry
+}
@@ -0,0 +1,317 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// TestSignatureBase tests the signature base of the RFC 9421 example request.
+func TestSignatureBase(t *testing.T) {
+	req := httptest.NewRequest("POST", "http://example.com/foo?param=Value&Pet=dog", strings.NewReader(`{"hello": "world"}`))
+	req.Header.Set("Date", "Tue, 20 Apr 2021 02:07:55 GMT")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Digest", "sha-512=:WZDPaVn/7XgHaAy8pmojAkGWoRx2UFChF41A2svX+TaPm+AbwAgBWnrIiYllu7BNNyealdVLvRwEmTHWXvJwew==:")
+
+	params := mustParseSignatureParams(t, `("@method" "@authority" "@path" "content-digest" "content-length" "content-type");created=1618884473;keyid="test-key-rsa-pss"`)
+	base, err := signatureBase(req, "https", "example.com", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `"@method": POST
+"@authority": example.com
+"@path": /foo
+"content-digest": sha-512=:WZDPaVn/7XgHaAy8pmojAkGWoRx2UFChF41A2svX+TaPm+AbwAgBWnrIiYllu7BNNyealdVLvRwEmTHWXvJwew==:
+"content-length": 18
+"content-type": application/json
+"@signature-params": ("@method" "@authority" "@path" "content-digest" "content-length" "content-type");created=1618884473;keyid="test-key-rsa-pss"`
+	if base != want {
+		t.Errorf("signature base:\n%s\nwant:\n%s", base, want)
+	}
+
+	if err := checkContentDigest(req.Header.Get("Content-Digest"), []byte(`{"hello": "world"}`)); err != nil {
+		t.Errorf("checkContentDigest: %v", err)
+	}
+	if err := checkContentDigest(req.Header.Get("Content-Digest"), []byte(`{}`)); err == nil {
+		t.Error("checkContentDigest: expected mismatch")
+	}
+}
+
+// signRequest signs req with an RFC 9421 signature covering components.
+func signRequest(t *testing.T, req *http.Request, components, params string, sign func([]byte) []byte) {
+	t.Helper()
+	input := "(" + components + ")" + params
+	p := mustParseSignatureParams(t, input)
+	base, err := signatureBase(req, "http", req.Host, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Signature-Input", "sig1="+input)
+	req.Header.Set("Signature", "sig1=:"+base64.StdEncoding.EncodeToString(sign([]byte(base)))+":")
+}
+
+// mustParseSignatureParams parses the Signature-Input member input.
+func mustParseSignatureParams(t *testing.T, input string) signatureParams {
+	t.Helper()
+	list, err := fursy.ParseSFList(input)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("ParseSFList(%q) = %v, %v", input, list, err)
+	}
+	p, err := parseSignatureParams(list[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func contentDigest(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return "sha-256=:" + base64.StdEncoding.EncodeToString(sum[:]) + ":"
+}
+
+// TestRequestSignature tests RFC 9421 signature verification.
+func TestRequestSignature(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	hmacKey := []byte("shared-secret")
+	keys := map[string]interface{}{"ed": pub, "ec": &ecKey.PublicKey, "mac": hmacKey}
+
+	edSign := func(b []byte) []byte { return ed25519.Sign(priv, b) }
+	ecSign := func(b []byte) []byte {
+		sum := sha256.Sum256(b)
+		r, s, _ := ecdsa.Sign(rand.Reader, ecKey, sum[:])
+		sig := make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+		return sig
+	}
+	macSign := func(b []byte) []byte {
+		mac := hmac.New(sha256.New, hmacKey)
+		mac.Write(b)
+		return mac.Sum(nil)
+	}
+
+	r := fursy.New()
+	r.Use(RequestSignature(func(keyID string) (interface{}, error) {
+		if key, ok := keys[keyID]; ok {
+			return key, nil
+		}
+		return nil, errors.New("unknown key")
+	}))
+	r.POST("/orders", func(c *fursy.Context) error {
+		body, _ := io.ReadAll(c.Request.Body)
+		return c.String(200, c.GetString(SignatureKeyIDContextKey)+" "+string(body))
+	})
+
+	const body = `{"id":1}`
+	const all = `"@method" "@authority" "@path" "@query" "content-digest"`
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	old := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+
+	newReq := func(digest string) *http.Request {
+		req := httptest.NewRequest("POST", "http://api.example.com/orders?x=1", strings.NewReader(body))
+		req.Header.Set("Content-Digest", digest)
+		return req
+	}
+
+	tests := []struct {
+		name   string
+		req    func() *http.Request
+		status int
+		body   string
+	}{
+		{"ed25519", func() *http.Request {
+			req := newReq(contentDigest(body))
+			signRequest(t, req, all, `;created=`+now+`;keyid="ed";alg="ed25519"`, edSign)
+			return req
+		}, 200, "ed " + body},
+		{"ecdsa", func() *http.Request {
+			req := newReq(contentDigest(body))
+			signRequest(t, req, all, `;created=`+now+`;keyid="ec"`, ecSign)
+			return req
+		}, 200, "ec " + body},
+		{"hmac", func() *http.Request {
+			req := newReq(contentDigest(body))
+			signRequest(t, req, all, `;created=`+now+`;keyid="mac"`, macSign)
+			return req
+		}, 200, "mac " + body},
+		{"missing", func() *http.Request {
+			return newReq(contentDigest(body))
+		}, 401, "missing request signature"},
+		{"wrong key", func() *http.Request {
+			req := newReq(contentDigest(body))
+			signRequest(t, req, all, `;created=`+now+`;keyid="mac"`, edSign)
+			return req
+		}, 401, "invalid request signature"},
+		{"wrong alg", func() *http.Request {
+			req := newReq(contentDigest(body))
+			signRequest(t, req, all, `;created=`+now+`;keyid="ed";alg="hmac-sha256"`, edSign)
+			return req
+		}, 401, "invalid request signature"},
+		{"tampered body", func() *http.Request {
+			req := newReq(contentDigest(`{"id":2}`))
+			signRequest(t, req, all, `;created=`+now+`;keyid="ed"`, edSign)
+			return req
+		}, 401, "invalid request signature"},
+		{"body not covered", func() *http.Request {
+			req := newReq(contentDigest(body))
+			signRequest(t, req, `"@method" "@authority" "@path" "@query"`, `;created=`+now+`;keyid="ed"`, edSign)
+			return req
+		}, 401, "invalid request signature"},
+		{"path not covered", func() *http.Request {
+			req := newReq(contentDigest(body))
+			signRequest(t, req, `"@method" "@authority" "content-digest"`, `;created=`+now+`;keyid="ed"`, edSign)
+			return req
+		}, 401, "invalid request signature"},
+		{"expired", func() *http.Request {
+			req := newReq(contentDigest(body))
+			signRequest(t, req, all, `;created=`+old+`;keyid="ed"`, edSign)
+			return req
+		}, 401, "request signature has expired"},
+		{"no created", func() *http.Request {
+			req := newReq(contentDigest(body))
+			signRequest(t, req, all, `;keyid="ed"`, edSign)
+			return req
+		}, 401, "invalid request signature"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, tt.req())
+			if w.Code != tt.status || !strings.Contains(w.Body.String(), tt.body) {
+				t.Errorf("got %d %s, want %d %q", w.Code, w.Body.String(), tt.status, tt.body)
+			}
+		})
+	}
+}
+
+// TestRequestSignature_Replay tests nonce based replay protection.
+func TestRequestSignature_Replay(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+
+	r := fursy.New()
+	r.Use(RequestSignature(func(string) (interface{}, error) { return pub, nil }))
+	r.GET("/items", func(c *fursy.Context) error {
+		return c.String(200, "OK")
+	})
+
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	for _, nonce := range []string{"", `;nonce="n-1"`} {
+		req := httptest.NewRequest("GET", "http://api.example.com/items", http.NoBody)
+		signRequest(t, req, `"@method" "@authority" "@path" "@query"`, `;created=`+now+`;keyid="k"`+nonce,
+			func(b []byte) []byte { return ed25519.Sign(priv, b) })
+
+		for i, want := range []int{200, 401} {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req.Clone(req.Context()))
+			if w.Code != want {
+				t.Errorf("nonce %q, request %d: got %d, want %d", nonce, i+1, w.Code, want)
+			}
+		}
+	}
+}
+
+// TestRequestSignature_HMAC tests the HMAC of body and timestamp scheme.
+func TestRequestSignature_HMAC(t *testing.T) {
+	secret := []byte("partner-secret")
+	r := fursy.New()
+	r.Use(RequestSignatureWithConfig(RequestSignatureConfig{
+		Scheme: SignatureHMAC,
+		Keys: func(keyID string) (interface{}, error) {
+			if keyID != "partner" {
+				return nil, errors.New("unknown key")
+			}
+			return secret, nil
+		},
+	}))
+	r.POST("/events", func(c *fursy.Context) error {
+		return c.String(200, "OK")
+	})
+
+	sign := func(ts, body string) string {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(ts + "." + body))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	old := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+
+	tests := []struct {
+		name, ts, sig, keyID string
+		status               int
+	}{
+		{"valid", now, sign(now, "payload"), "partner", 200},
+		{"replayed", now, sign(now, "payload"), "partner", 401},
+		{"prefixed", now, "sha256=" + sign(now, "payload2"), "partner", 401},
+		{"wrong body", now, sign(now, "other"), "partner", 401},
+		{"expired", old, sign(old, "payload"), "partner", 401},
+		{"unknown key", now, sign(now, "payload"), "other", 401},
+		{"missing", "", "", "partner", 401},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/events", strings.NewReader("payload"))
+			req.Header.Set("X-Timestamp", tt.ts)
+			req.Header.Set("X-Signature", tt.sig)
+			req.Header.Set("X-Key-Id", tt.keyID)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.status {
+				t.Errorf("got %d %s, want %d", w.Code, w.Body.String(), tt.status)
+			}
+		})
+	}
+}
+
+// TestMemoryNonceCache tests nonce expiry and capacity.
+func TestMemoryNonceCache(t *testing.T) {
+	cache := NewMemoryNonceCache(2)
+	future := time.Now().Add(time.Minute)
+
+	if ok, _ := cache.Use("a", future); !ok {
+		t.Error("first use of a rejected")
+	}
+	if ok, _ := cache.Use("a", future); ok {
+		t.Error("second use of a accepted")
+	}
+	if ok, _ := cache.Use("b", time.Now().Add(-time.Second)); !ok {
+		t.Error("first use of b rejected")
+	}
+	// b has expired, so c fits.
+	if ok, err := cache.Use("c", future); !ok || err != nil {
+		t.Errorf("use of c: %v, %v", ok, err)
+	}
+	if _, err := cache.Use("d", future); err == nil {
+		t.Error("expected error for full cache")
+	}
+}
+
+// TestRequestSignature_NilKeysPanics tests config validation.
+func TestRequestSignature_NilKeysPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for nil key resolver")
+		}
+	}()
+	RequestSignature(nil)
+}
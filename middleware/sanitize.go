@@ -0,0 +1,362 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package middleware provides request sanitization middleware.
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/coregx/fursy"
+)
+
+// DefaultSanitizeMaxBodySize is the default maximum body size checked by Sanitize (1 MB).
+const DefaultSanitizeMaxBodySize = 1 << 20
+
+// Sanitize errors.
+var (
+	// ErrNullByte is returned when an input contains a null byte.
+	ErrNullByte = errors.New("input contains null byte")
+
+	// ErrInvalidUTF8 is returned when an input is not valid UTF-8.
+	ErrInvalidUTF8 = errors.New("input is not valid UTF-8")
+)
+
+// SanitizeConfig defines the configuration for the Sanitize middleware.
+type SanitizeConfig struct {
+	// SkipHeaders disables checking and cleaning request headers.
+	// Default: false
+	SkipHeaders bool
+
+	// SkipBody disables checking text request bodies.
+	// Default: false
+	SkipBody bool
+
+	// MaxBodySize is the maximum body size in bytes that is checked.
+	// Larger bodies are passed through unchecked.
+	// Default: 1 MB
+	MaxBodySize int64
+
+	// HTMLFields lists query parameters, form fields and top-level JSON
+	// string fields whose values are passed through HTMLSanitizer
+	// (e.g., user-generated rich text).
+	// Default: nil
+	HTMLFields []string
+
+	// HTMLSanitizer cleans HTML in HTMLFields, e.g. bluemonday's
+	// UGCPolicy().Sanitize. Required if HTMLFields is set.
+	// Default: nil
+	HTMLSanitizer func(html string) string
+
+	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
+	// Default: nil (middleware always executes)
+	Skipper fursy.Skipper
+
+	// ErrorHandler is called when an input is rejected.
+	// err wraps ErrNullByte or ErrInvalidUTF8 and names the input.
+	// Default: 400 Bad Request problem
+	ErrorHandler func(c *fursy.Context, err error) error
+}
+
+// Sanitize returns a middleware that normalizes and validates request inputs.
+//
+// The middleware:
+//   - Rejects path parameters, query parameters and headers containing
+//     null bytes or invalid UTF-8 with 400 Bad Request
+//   - Strips other control characters (except tab) from them
+//   - Rejects text bodies (JSON, XML, forms, text/*) containing null bytes
+//     or invalid UTF-8
+//
+// Example:
+//
+//	router.Use(middleware.Sanitize())
+func Sanitize() fursy.HandlerFunc {
+	return SanitizeWithConfig(SanitizeConfig{})
+}
+
+// SanitizeWithConfig returns a middleware with custom configuration.
+//
+// Example (sanitize rich text fields with bluemonday):
+//
+//	policy := bluemonday.UGCPolicy()
+//	router.Use(middleware.SanitizeWithConfig(middleware.SanitizeConfig{
+//	    HTMLFields:    []string{"bio", "comment"},
+//	    HTMLSanitizer: policy.Sanitize,
+//	}))
+func SanitizeWithConfig(config SanitizeConfig) fursy.HandlerFunc {
+	// Validate config.
+	if len(config.HTMLFields) > 0 && config.HTMLSanitizer == nil {
+		panic("fursy/middleware: Sanitize HTMLFields requires HTMLSanitizer")
+	}
+
+	// Set defaults.
+	if config.MaxBodySize <= 0 {
+		config.MaxBodySize = DefaultSanitizeMaxBodySize
+	}
+	if config.ErrorHandler == nil {
+		config.ErrorHandler = defaultSanitizeErrorHandler
+	}
+
+	return func(c *fursy.Context) error {
+		// Skip if Skipper returns true.
+		if config.Skipper.ShouldSkip(c) {
+			return c.Next()
+		}
+
+		if err := sanitizeParams(c); err != nil {
+			return config.ErrorHandler(c, err)
+		}
+		if err := sanitizeQuery(c, config); err != nil {
+			return config.ErrorHandler(c, err)
+		}
+		if !config.SkipHeaders {
+			if err := sanitizeHeaders(c); err != nil {
+				return config.ErrorHandler(c, err)
+			}
+		}
+		if !config.SkipBody {
+			if err := sanitizeBody(c, config); err != nil {
+				return config.ErrorHandler(c, err)
+			}
+		}
+
+		return c.Next()
+	}
+}
+
+// defaultSanitizeErrorHandler sends a 400 Bad Request problem.
+func defaultSanitizeErrorHandler(c *fursy.Context, err error) error {
+	return c.Problem(fursy.BadRequest(err.Error()))
+}
+
+// cleanInput validates s and strips control characters (except tab).
+// It returns s unchanged if it is already clean.
+func cleanInput(s string) (string, error) {
+	if !utf8.ValidString(s) {
+		return "", ErrInvalidUTF8
+	}
+	if strings.IndexByte(s, 0) >= 0 {
+		return "", ErrNullByte
+	}
+	if strings.IndexFunc(s, isStrippedControl) < 0 {
+		return s, nil
+	}
+	return strings.Map(func(r rune) rune {
+		if isStrippedControl(r) {
+			return -1
+		}
+		return r
+	}, s), nil
+}
+
+// isStrippedControl reports whether r is a control character removed by cleanInput.
+func isStrippedControl(r rune) bool {
+	return r != '\t' && unicode.IsControl(r)
+}
+
+// sanitizeParams cleans path parameters.
+func sanitizeParams(c *fursy.Context) error {
+	for _, p := range c.Params() {
+		clean, err := cleanInput(p.Value)
+		if err != nil {
+			return fmt.Errorf("%w: path parameter %q", err, p.Key)
+		}
+		if clean != p.Value {
+			c.SetParam(p.Key, clean)
+		}
+	}
+	return nil
+}
+
+// sanitizeQuery cleans query parameters and sanitizes HTML fields.
+func sanitizeQuery(c *fursy.Context, config SanitizeConfig) error {
+	if c.Request.URL.RawQuery == "" {
+		return nil
+	}
+
+	query := c.Request.URL.Query()
+	changed, err := sanitizeValues(query, "query parameter", config)
+	if err != nil {
+		return err
+	}
+	if changed {
+		c.SetQuery(query)
+	}
+	return nil
+}
+
+// sanitizeValues cleans values in place and reports whether any changed.
+// Errors name the offending key, described by kind.
+func sanitizeValues(values url.Values, kind string, config SanitizeConfig) (bool, error) {
+	changed := false
+	for key, vals := range values {
+		for i, v := range vals {
+			clean, err := cleanInput(v)
+			if err != nil {
+				return false, fmt.Errorf("%w: %s %q", err, kind, key)
+			}
+			if slices.Contains(config.HTMLFields, key) {
+				clean = config.HTMLSanitizer(clean)
+			}
+			if clean != v {
+				vals[i] = clean
+				changed = true
+			}
+		}
+	}
+	return changed, nil
+}
+
+// sanitizeHeaders cleans request header values.
+func sanitizeHeaders(c *fursy.Context) error {
+	for name, vals := range c.Request.Header {
+		for i, v := range vals {
+			clean, err := cleanInput(v)
+			if err != nil {
+				return fmt.Errorf("%w: header %q", err, name)
+			}
+			vals[i] = clean
+		}
+	}
+	return nil
+}
+
+// sanitizeBody validates text bodies and sanitizes HTML fields in form
+// and JSON bodies. The body is restored for the handler.
+func sanitizeBody(c *fursy.Context, config SanitizeConfig) error {
+	req := c.Request
+	if req.Body == nil || req.ContentLength == 0 || req.ContentLength > config.MaxBodySize {
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if !isTextMediaType(mediaType) {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, config.MaxBodySize+1))
+	if err != nil {
+		return err
+	}
+	if int64(len(body)) > config.MaxBodySize {
+		// Too large to check: pass through unchanged.
+		req.Body = readCloser{io.MultiReader(bytes.NewReader(body), req.Body), req.Body}
+		return nil
+	}
+
+	if !utf8.Valid(body) {
+		return fmt.Errorf("%w: request body", ErrInvalidUTF8)
+	}
+	if bytes.IndexByte(body, 0) >= 0 {
+		return fmt.Errorf("%w: request body", ErrNullByte)
+	}
+
+	if len(config.HTMLFields) > 0 {
+		switch mediaType {
+		case "application/x-www-form-urlencoded":
+			body, err = sanitizeFormBody(body, config)
+		case "application/json":
+			body, err = sanitizeJSONBody(body, config)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	return nil
+}
+
+// sanitizeFormBody sanitizes HTML fields of a URL-encoded form.
+func sanitizeFormBody(body []byte, config SanitizeConfig) ([]byte, error) {
+	form, parseErr := url.ParseQuery(string(body))
+	if parseErr != nil {
+		// Let the handler report malformed forms.
+		return body, nil
+	}
+
+	changed, err := sanitizeValues(form, "form field", config)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return body, nil
+	}
+	return []byte(form.Encode()), nil
+}
+
+// sanitizeJSONBody sanitizes HTML in top-level string fields of a JSON object.
+func sanitizeJSONBody(body []byte, config SanitizeConfig) ([]byte, error) {
+	var object map[string]json.RawMessage
+	if json.Unmarshal(body, &object) != nil {
+		// Not an object (or malformed): let the handler decide.
+		return body, nil
+	}
+
+	changed := false
+	for _, field := range config.HTMLFields {
+		raw, ok := object[field]
+		if !ok {
+			continue
+		}
+		var value string
+		if json.Unmarshal(raw, &value) != nil {
+			continue // Not a string.
+		}
+		clean := config.HTMLSanitizer(value)
+		if clean == value {
+			continue
+		}
+		encoded, err := marshalJSONNoEscape(clean)
+		if err != nil {
+			return nil, err
+		}
+		object[field] = encoded
+		changed = true
+	}
+
+	if !changed {
+		return body, nil
+	}
+	return marshalJSONNoEscape(object)
+}
+
+// marshalJSONNoEscape encodes v as JSON without escaping HTML characters,
+// so sanitized markup is passed to the handler as-is.
+func marshalJSONNoEscape(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// isTextMediaType reports whether bodies of mediaType are text.
+func isTextMediaType(mediaType string) bool {
+	switch {
+	case strings.HasPrefix(mediaType, "text/"):
+		return true
+	case mediaType == "application/json", strings.HasSuffix(mediaType, "+json"):
+		return true
+	case mediaType == "application/xml", strings.HasSuffix(mediaType, "+xml"):
+		return true
+	case mediaType == "application/x-www-form-urlencoded":
+		return true
+	}
+	return false
+}
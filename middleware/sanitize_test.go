@@ -0,0 +1,183 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/coregx/fursy"
+)
+
+// echoInputs returns a handler echoing the sanitized inputs.
+func echoInputs(c *fursy.Context) error {
+	body, _ := io.ReadAll(c.Request.Body)
+	return c.JSON(http.StatusOK, map[string]string{
+		"name":   c.Param("name"),
+		"q":      c.Query("q"),
+		"header": c.GetHeader("X-Note"),
+		"body":   string(body),
+	})
+}
+
+func TestSanitize_StripsControlCharacters(t *testing.T) {
+	r := fursy.New()
+	r.Use(Sanitize())
+	r.GET("/users/:name", echoInputs)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/jo%07hn?q=a%1Bb%09c", http.NoBody)
+	req.Header.Set("X-Note", "hi\x7fthere")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var got map[string]string
+	_ = json.Unmarshal(w.Body.Bytes(), &got)
+	if got["name"] != "john" || got["q"] != "ab\tc" || got["header"] != "hithere" {
+		t.Errorf("unexpected sanitized inputs %v", got)
+	}
+}
+
+func TestSanitize_Rejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		header  string
+		ctype   string
+		body    string
+		wantErr string
+	}{
+		{"null byte in path", "/users/jo%00hn", "", "", "", "path parameter"},
+		{"invalid UTF-8 in query", "/users/john?q=%ff", "", "", "", "query parameter"},
+		{"null byte in query", "/users/john?q=a%00", "", "", "", "null byte"},
+		{"invalid UTF-8 header", "/users/john", "\xff", "", "", "header"},
+		{"null byte in JSON body", "/users/john", "", "application/json", "{\"a\":\"\x00\"}", "request body"},
+		{"invalid UTF-8 in text body", "/users/john", "", "text/plain", "\xc3\x28", "not valid UTF-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := fursy.New()
+			r.Use(Sanitize())
+			r.POST("/users/:name", echoInputs)
+
+			req := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(tt.body))
+			if tt.header != "" {
+				req.Header["X-Note"] = []string{tt.header}
+			}
+			if tt.ctype != "" {
+				req.Header.Set("Content-Type", tt.ctype)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d", w.Code)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantErr) {
+				t.Errorf("expected error mentioning %q, got %s", tt.wantErr, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestSanitize_BinaryBodyNotChecked(t *testing.T) {
+	r := fursy.New()
+	r.Use(Sanitize())
+	r.POST("/upload", func(c *fursy.Context) error {
+		body, _ := io.ReadAll(c.Request.Body)
+		return c.String(http.StatusOK, strconv.Itoa(len(body)))
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("\x00\xff\x01"))
+	req.Header.Set("Content-Type", "application/octet-stream")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || w.Body.String() != "3" {
+		t.Errorf("expected binary body to pass unchanged, got %d %q", w.Code, w.Body.String())
+	}
+}
+
+func TestSanitize_HTMLFields(t *testing.T) {
+	stripTags := func(s string) string {
+		return strings.NewReplacer("<script>", "", "</script>", "").Replace(s)
+	}
+
+	r := fursy.New()
+	r.Use(SanitizeWithConfig(SanitizeConfig{
+		HTMLFields:    []string{"bio"},
+		HTMLSanitizer: stripTags,
+	}))
+	r.POST("/profile", func(c *fursy.Context) error {
+		body, _ := io.ReadAll(c.Request.Body)
+		return c.String(http.StatusOK, c.Query("bio")+"|"+string(body))
+	})
+
+	tests := []struct {
+		name  string
+		query string
+		ctype string
+		body  string
+		want  string
+	}{
+		{"query", "?bio=<script>x</script>", "", "", "x|"},
+		{"form", "", "application/x-www-form-urlencoded", "bio=%3Cscript%3Ey%3C%2Fscript%3E&name=%3Cb%3E", "|bio=y&name=%3Cb%3E"},
+		{"json", "", "application/json", `{"bio":"<script>z</script>","age":30,"name":"<b>"}`, `|{"age":30,"bio":"z","name":"<b>"}`},
+		{"json without field", "", "application/json", `{"name":"<b>"}`, `|{"name":"<b>"}`},
+		{"json array", "", "application/json", `["<script>"]`, `|["<script>"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/profile"+tt.query, strings.NewReader(tt.body))
+			if tt.ctype != "" {
+				req.Header.Set("Content-Type", tt.ctype)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Body.String() != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestSanitize_ErrorHandler(t *testing.T) {
+	var got error
+	r := fursy.New()
+	r.Use(SanitizeWithConfig(SanitizeConfig{
+		ErrorHandler: func(c *fursy.Context, err error) error {
+			got = err
+			return c.NoContent(http.StatusUnprocessableEntity)
+		},
+	}))
+	r.GET("/", echoInputs)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?q=%00", http.NoBody))
+
+	if w.Code != http.StatusUnprocessableEntity || !errors.Is(got, ErrNullByte) {
+		t.Errorf("expected custom handler with ErrNullByte, got %d %v", w.Code, got)
+	}
+}
+
+func TestSanitize_InvalidConfig(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	SanitizeWithConfig(SanitizeConfig{HTMLFields: []string{"bio"}})
+}
@@ -6,8 +6,12 @@
 package middleware
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/coregx/fursy"
 )
@@ -24,11 +28,16 @@ const (
 	ReferrerPolicyStrictOrigin = "strict-origin-when-cross-origin"
 )
 
+// CSPNonceKey is the context key storing the per-request CSP nonce
+// (see SecureConfig.CSPNonce).
+const CSPNonceKey = "csp_nonce"
+
 // SecureConfig defines the configuration for the Secure middleware.
 type SecureConfig struct {
 	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
 	// Default: nil (middleware always executes)
-	Skipper func(c *fursy.Context) bool
+	Skipper fursy.Skipper
 
 	// XSSProtection provides protection against cross-site scripting attack (XSS)
 	// by setting the `X-XSS-Protection` header.
@@ -82,6 +91,20 @@ type SecureConfig struct {
 	// Default: false
 	CSPReportOnly bool
 
+	// CSPNonce generates a random nonce for every request, stores it in the
+	// context under CSPNonceKey and adds 'nonce-<value>' to the CSP
+	// directives in CSPNonceDirectives. Templates put the nonce on inline
+	// <script> and <style> tags, so a strict CSP works without 'unsafe-inline'.
+	// Requires ContentSecurityPolicy.
+	// Default: false
+	CSPNonce bool
+
+	// CSPNonceDirectives lists the directives that receive the nonce.
+	// Directives missing from the policy are skipped; if none is present,
+	// the nonce is added to default-src.
+	// Default: []string{"script-src", "style-src"}
+	CSPNonceDirectives []string
+
 	// ReferrerPolicy sets the `Referrer-Policy` header providing control over
 	// what referrer information, sent in the Referer header, should be included.
 	// Values: no-referrer, no-referrer-when-downgrade, same-origin,
@@ -160,6 +183,18 @@ type SecureConfig struct {
 //	    ContentSecurityPolicy: "default-src 'self'; script-src 'self' 'unsafe-inline'",
 //	}))
 //
+// Example (nonce-based CSP for server-rendered HTML):
+//
+//	router.Use(middleware.SecureWithConfig(middleware.SecureConfig{
+//	    ContentSecurityPolicy: "default-src 'self'; script-src 'self'; style-src 'self'",
+//	    CSPNonce:              true,
+//	}))
+//
+//	router.GET("/", func(c *fursy.Context) error {
+//	    // <script nonce="{{.Nonce}}">...</script>
+//	    return page.Execute(c.Response, map[string]any{"Nonce": middleware.CSPNonce(c)})
+//	})
+//
 // Example (strict security):
 //
 //	router.Use(middleware.SecureWithConfig(middleware.SecureConfig{
@@ -196,9 +231,17 @@ func SecureWithConfig(config SecureConfig) fursy.HandlerFunc {
 	// NOTE: XSSProtection defaults to empty (not set) per OWASP 2025 recommendation.
 	// Modern browsers use CSP instead, and X-XSS-Protection can introduce vulnerabilities.
 
+	// Validate config.
+	if config.CSPNonce && config.ContentSecurityPolicy == "" {
+		panic("fursy/middleware: Secure CSPNonce requires ContentSecurityPolicy")
+	}
+	if config.CSPNonceDirectives == nil {
+		config.CSPNonceDirectives = []string{"script-src", "style-src"}
+	}
+
 	return func(c *fursy.Context) error {
 		// Skip if Skipper returns true.
-		if config.Skipper != nil && config.Skipper(c) {
+		if config.Skipper.ShouldSkip(c) {
 			return c.Next()
 		}
 
@@ -233,10 +276,17 @@ func SecureWithConfig(config SecureConfig) fursy.HandlerFunc {
 		// Content-Security-Policy (CSP)
 		// Application-specific, only set if configured.
 		if config.ContentSecurityPolicy != "" {
+			policy := config.ContentSecurityPolicy
+			if config.CSPNonce {
+				nonce := generateCSPNonce()
+				c.Set(CSPNonceKey, nonce)
+				policy = addCSPNonce(policy, nonce, config.CSPNonceDirectives)
+			}
+
 			if config.CSPReportOnly {
-				c.SetHeader("Content-Security-Policy-Report-Only", config.ContentSecurityPolicy)
+				c.SetHeader("Content-Security-Policy-Report-Only", policy)
 			} else {
-				c.SetHeader("Content-Security-Policy", config.ContentSecurityPolicy)
+				c.SetHeader("Content-Security-Policy", policy)
 			}
 		}
 
@@ -278,6 +328,49 @@ func SecureWithConfig(config SecureConfig) fursy.HandlerFunc {
 	}
 }
 
+// CSPNonce returns the CSP nonce of the request, or "" if
+// SecureConfig.CSPNonce is not enabled.
+//
+// Example:
+//
+//	<script nonce="{{.Nonce}}">initApp()</script>
+func CSPNonce(c *fursy.Context) string {
+	return c.GetString(CSPNonceKey)
+}
+
+// generateCSPNonce returns a random base64-encoded 128-bit nonce.
+func generateCSPNonce() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b) // crypto/rand.Read never returns an error.
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// addCSPNonce adds 'nonce-<nonce>' to the given directives of policy.
+func addCSPNonce(policy, nonce string, directives []string) string {
+	source := "'nonce-" + nonce + "'"
+
+	parts := strings.Split(policy, ";")
+	added := false
+	for i, part := range parts {
+		name, _, _ := strings.Cut(strings.TrimSpace(part), " ")
+		if slices.Contains(directives, strings.ToLower(name)) {
+			parts[i] = strings.TrimRight(part, " ") + " " + source
+			added = true
+		}
+	}
+
+	if !added {
+		for i, part := range parts {
+			name, _, _ := strings.Cut(strings.TrimSpace(part), " ")
+			if strings.EqualFold(name, "default-src") {
+				parts[i] = strings.TrimRight(part, " ") + " " + source
+			}
+		}
+	}
+
+	return strings.Join(parts, ";")
+}
+
 // SecureDefaults returns a SecureConfig with OWASP recommended defaults.
 //
 // Includes:
@@ -216,6 +216,75 @@ func TestSecure_CSP(t *testing.T) {
 	}
 }
 
+// TestSecure_CSPNonce tests per-request CSP nonces.
+func TestSecure_CSPNonce(t *testing.T) {
+	router := fursy.New()
+	router.Use(SecureWithConfig(SecureConfig{
+		ContentSecurityPolicy: "default-src 'self'; script-src 'self'; style-src 'self'; img-src *",
+		CSPNonce:              true,
+	}))
+	router.GET("/test", func(c *fursy.Context) error {
+		return c.String(http.StatusOK, CSPNonce(c))
+	})
+
+	serve := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", http.NoBody))
+		return rec
+	}
+
+	first, second := serve(), serve()
+
+	nonce := first.Body.String()
+	if len(nonce) != 24 {
+		t.Fatalf("Expected 24-character base64 nonce, got %q", nonce)
+	}
+	if second.Body.String() == nonce {
+		t.Error("Expected a different nonce per request")
+	}
+
+	expected := "default-src 'self'; script-src 'self' 'nonce-" + nonce + "'; style-src 'self' 'nonce-" + nonce + "'; img-src *"
+	if v := first.Header().Get("Content-Security-Policy"); v != expected {
+		t.Errorf("Expected CSP %q, got %q", expected, v)
+	}
+}
+
+// TestSecure_CSPNonceDefaultSrc tests the default-src fallback.
+func TestSecure_CSPNonceDefaultSrc(t *testing.T) {
+	tests := []struct {
+		name       string
+		csp        string
+		directives []string
+		expected   string
+	}{
+		{"default-src fallback", "default-src 'self'", nil, "default-src 'self' 'nonce-N'"},
+		{"custom directives", "script-src 'self'; style-src 'self'", []string{"style-src"}, "script-src 'self'; style-src 'self' 'nonce-N'"},
+		{"case insensitive", "Script-Src 'self'", nil, "Script-Src 'self' 'nonce-N'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			directives := tt.directives
+			if directives == nil {
+				directives = []string{"script-src", "style-src"}
+			}
+			if got := addCSPNonce(tt.csp, "N", directives); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestSecure_CSPNonceWithoutPolicy tests that CSPNonce requires a policy.
+func TestSecure_CSPNonceWithoutPolicy(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic")
+		}
+	}()
+	SecureWithConfig(SecureConfig{CSPNonce: true})
+}
+
 // TestSecure_CrossOriginHeaders tests Cross-Origin-* headers.
 func TestSecure_CrossOriginHeaders(t *testing.T) {
 	router := fursy.New()
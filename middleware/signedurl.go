@@ -0,0 +1,106 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package middleware provides signed URL validation middleware.
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/coregx/fursy"
+)
+
+// SignedURLConfig defines the configuration for the SignedURL middleware.
+type SignedURLConfig struct {
+	// Secret is the HMAC key used to sign URLs (see fursy.SignedURL).
+	// Either Secret or SecretSource is required.
+	Secret []byte
+
+	// SecretSource provides the HMAC keys (see fursy.SignedURLFrom).
+	// Links signed with any of its secrets (current or previous) are accepted,
+	// so keys can be rotated without breaking issued links.
+	// Takes precedence over Secret.
+	SecretSource fursy.SecretSource
+
+	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
+	// Default: nil (middleware always executes)
+	Skipper fursy.Skipper
+
+	// ErrorHandler is called when the signature is invalid or expired.
+	// err is fursy.ErrSignatureInvalid, fursy.ErrSignatureExpired or
+	// the error of SecretSource.
+	// Default: 403 Forbidden problem (410 Gone for expired links)
+	ErrorHandler func(c *fursy.Context, err error) error
+}
+
+// SignedURL returns a middleware that only allows requests with a valid URL signature.
+//
+// Use it to protect static files or download routes behind time-limited links
+// created with fursy.SignedURL. Requests with a missing or wrong signature get
+// 403 Forbidden, expired links get 410 Gone.
+//
+// Example:
+//
+//	secret := []byte(os.Getenv("URL_SIGNING_KEY"))
+//
+//	downloads := router.Group("/downloads", middleware.SignedURL(secret))
+//	downloads.GET("/*file", serveDownload)
+//
+//	// In another handler:
+//	link, _ := fursy.SignedURL(secret, "/downloads/report.pdf", 15*time.Minute)
+func SignedURL(secret []byte) fursy.HandlerFunc {
+	return SignedURLWithConfig(SignedURLConfig{
+		Secret: secret,
+	})
+}
+
+// SignedURLWithConfig returns a middleware with custom configuration.
+//
+// Example:
+//
+//	router.Use(middleware.SignedURLWithConfig(middleware.SignedURLConfig{
+//	    SecretSource: fursy.FileSecret("/run/secrets/url-signing"),
+//	    Skipper:      fursy.SkipPaths("/static/public/*").Or(fursy.SkipPaths("/health")),
+//	}))
+func SignedURLWithConfig(config SignedURLConfig) fursy.HandlerFunc {
+	// Validate config.
+	if len(config.Secret) == 0 && config.SecretSource == nil {
+		panic("fursy/middleware: SignedURL secret cannot be empty")
+	}
+
+	// Set defaults.
+	if config.SecretSource == nil {
+		config.SecretSource = fursy.StaticSecrets(config.Secret)
+	}
+	if config.ErrorHandler == nil {
+		config.ErrorHandler = defaultSignedURLErrorHandler
+	}
+
+	return func(c *fursy.Context) error {
+		// Skip if Skipper returns true.
+		if config.Skipper.ShouldSkip(c) {
+			return c.Next()
+		}
+
+		if err := fursy.VerifySignedURLFrom(config.SecretSource, c.Request.URL); err != nil {
+			return config.ErrorHandler(c, err)
+		}
+
+		return c.Next()
+	}
+}
+
+// defaultSignedURLErrorHandler sends 410 Gone for expired links, 500 Internal
+// Server Error if the secrets cannot be loaded and 403 Forbidden otherwise.
+func defaultSignedURLErrorHandler(c *fursy.Context, err error) error {
+	switch {
+	case errors.Is(err, fursy.ErrSignatureExpired):
+		return c.Problem(fursy.NewProblem(http.StatusGone, "Gone", "link has expired"))
+	case !errors.Is(err, fursy.ErrSignatureInvalid):
+		return c.Problem(fursy.InternalServerError("URL signing key unavailable"))
+	}
+	return c.Problem(fursy.Forbidden("invalid or missing URL signature"))
+}
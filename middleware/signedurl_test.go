@@ -0,0 +1,116 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// TestSignedURL tests the SignedURL middleware.
+func TestSignedURL(t *testing.T) {
+	secret := []byte("secret")
+
+	r := fursy.New()
+	downloads := r.Group("/downloads", SignedURL(secret))
+	downloads.GET("/*file", func(c *fursy.Context) error {
+		return c.String(http.StatusOK, c.Param("file"))
+	})
+
+	valid, _ := fursy.SignedURL(secret, "/downloads/report.pdf", time.Minute)
+	expired, _ := fursy.SignedURL(secret, "/downloads/report.pdf", -time.Minute)
+
+	tests := []struct {
+		name   string
+		target string
+		want   int
+	}{
+		{"valid", valid, http.StatusOK},
+		{"unsigned", "/downloads/report.pdf", http.StatusForbidden},
+		{"expired", expired, http.StatusGone},
+		{"tampered", valid + "&extra=1", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, http.NoBody))
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
+
+// TestSignedURL_Skipper tests skipping signature validation.
+func TestSignedURL_Skipper(t *testing.T) {
+	r := fursy.New()
+	r.Use(SignedURLWithConfig(SignedURLConfig{
+		Secret:  []byte("secret"),
+		Skipper: fursy.SkipPaths("/public/*"),
+	}))
+	r.GET("/public/*file", func(c *fursy.Context) error {
+		return c.String(http.StatusOK, "public")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/public/logo.png", http.NoBody))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+}
+
+// TestSignedURL_EmptySecret tests that an empty secret panics.
+func TestSignedURL_EmptySecret(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for empty secret")
+		}
+	}()
+	SignedURL(nil)
+}
+
+// TestSignedURL_SecretSource tests accepting links signed with a previous key.
+func TestSignedURL_SecretSource(t *testing.T) {
+	r := fursy.New()
+	r.Use(SignedURLWithConfig(SignedURLConfig{
+		SecretSource: fursy.StaticSecrets([]byte("new"), []byte("old")),
+	}))
+	r.GET("/*file", func(c *fursy.Context) error {
+		return c.String(http.StatusOK, c.Param("file"))
+	})
+
+	oldLink, _ := fursy.SignedURL([]byte("old"), "/report.pdf", time.Minute)
+	otherLink, _ := fursy.SignedURL([]byte("other"), "/report.pdf", time.Minute)
+
+	for target, want := range map[string]int{oldLink: http.StatusOK, otherLink: http.StatusForbidden} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, http.NoBody))
+		if w.Code != want {
+			t.Errorf("%s: expected status %d, got %d", target, want, w.Code)
+		}
+	}
+}
+
+// TestSignedURL_SecretSourceError tests the response when secrets cannot be loaded.
+func TestSignedURL_SecretSourceError(t *testing.T) {
+	r := fursy.New()
+	r.Use(SignedURLWithConfig(SignedURLConfig{
+		SecretSource: fursy.EnvSecret("FURSY_TEST_UNSET_SECRET"),
+	}))
+	r.GET("/*file", func(c *fursy.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/report.pdf?expires=1&signature=x", http.NoBody))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", w.Code)
+	}
+}
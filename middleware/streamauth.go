@@ -0,0 +1,146 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package middleware provides authentication middleware for streaming endpoints.
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/coregx/fursy"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// DefaultStreamTokenLookup is the default token lookup of StreamAuth.
+//
+// EventSource and browser WebSocket clients cannot set an Authorization
+// header, so the token is also accepted from the "access_token" query
+// parameter or cookie.
+const DefaultStreamTokenLookup = "header:Authorization,query:access_token,cookie:access_token"
+
+// JWTExpiryContextKey is the key used to store the token expiry (time.Time) in the context.
+const JWTExpiryContextKey = "jwt_expiry"
+
+// streamAuthCancelKey stores the cancel function of the expiry deadline.
+const streamAuthCancelKey = "stream_auth_cancel"
+
+// ErrJWTNoExpiry is returned by StreamAuth when RequireExpiry is set and the token has no exp claim.
+var ErrJWTNoExpiry = errors.New("jwt token has no expiration")
+
+// StreamAuthConfig defines the configuration for the StreamAuth middleware.
+type StreamAuthConfig struct {
+	// JWT configures token validation (see JWTConfig).
+	// JWT.SigningKey is required.
+	// Default JWT.TokenLookup: DefaultStreamTokenLookup
+	JWT JWTConfig
+
+	// RequireExpiry rejects tokens without an exp claim, so that no
+	// stream can stay open indefinitely.
+	// Default: false
+	RequireExpiry bool
+}
+
+// StreamAuth returns a JWT middleware for SSE and WebSocket endpoints.
+//
+// In addition to the JWT middleware, it:
+//   - Accepts the token from the Authorization header, the "access_token"
+//     query parameter or the "access_token" cookie (EventSource can't set headers)
+//   - Sets the request context deadline to the token expiry, so streams bound
+//     to the request context are closed automatically when the token expires
+//   - Stores the expiry in the context (JWTExpiryContextKey)
+//
+// Claims are stored under JWTContextKey as with the JWT middleware.
+//
+// Tokens in query strings may end up in access logs; use short-lived tokens.
+//
+// Example:
+//
+//	streams := router.Group("/stream", middleware.StreamAuth(secret))
+//	streams.GET("/events", func(c *fursy.Context) error {
+//	    return stream.SSEUpgrade(c, func(conn *sse.Conn) error {
+//	        hub.Register(conn)
+//	        defer hub.Unregister(conn)
+//	        <-conn.Done() // Also done when the token expires.
+//	        return nil
+//	    })
+//	})
+//
+//	// Browser:
+//	// new EventSource("/stream/events?access_token=" + token)
+func StreamAuth(signingKey interface{}) fursy.HandlerFunc {
+	return StreamAuthWithConfig(StreamAuthConfig{
+		JWT: JWTConfig{SigningKey: signingKey},
+	})
+}
+
+// StreamAuthWithConfig returns a middleware with custom configuration.
+//
+// Example:
+//
+//	router.Use(middleware.StreamAuthWithConfig(middleware.StreamAuthConfig{
+//	    JWT: middleware.JWTConfig{
+//	        SigningKey:  secret,
+//	        TokenLookup: "query:token,cookie:session",
+//	    },
+//	    RequireExpiry: true,
+//	}))
+func StreamAuthWithConfig(config StreamAuthConfig) fursy.HandlerFunc {
+	// Set defaults.
+	if config.JWT.TokenLookup == "" {
+		config.JWT.TokenLookup = DefaultStreamTokenLookup
+	}
+	if config.JWT.ErrorHandler == nil {
+		config.JWT.ErrorHandler = defaultJWTErrorHandler
+	}
+
+	successHandler := config.JWT.SuccessHandler
+	errorHandler := config.JWT.ErrorHandler
+	config.JWT.SuccessHandler = func(c *fursy.Context, claims jwt.Claims) error {
+		exp, err := claims.GetExpirationTime()
+		if err != nil {
+			return errorHandler(c, err)
+		}
+
+		if exp == nil {
+			if config.RequireExpiry {
+				return errorHandler(c, ErrJWTNoExpiry)
+			}
+		} else {
+			// Bind the request lifetime to the token lifetime.
+			ctx, cancel := context.WithDeadline(c.Request.Context(), exp.Time)
+			c.Request = c.Request.WithContext(ctx)
+			c.Set(JWTExpiryContextKey, exp.Time)
+			c.Set(streamAuthCancelKey, cancel)
+		}
+
+		if successHandler != nil {
+			return successHandler(c, claims)
+		}
+		return nil
+	}
+
+	jwtMiddleware := JWTWithConfig(config.JWT)
+
+	return func(c *fursy.Context) error {
+		err := jwtMiddleware(c)
+		if cancel, ok := c.Get(streamAuthCancelKey).(context.CancelFunc); ok {
+			cancel()
+		}
+		return err
+	}
+}
+
+// TokenExpiry returns the expiry of the token validated by StreamAuth.
+//
+// Example:
+//
+//	if exp, ok := middleware.TokenExpiry(c); ok {
+//	    c.SetHeader("X-Token-Expires", exp.Format(time.RFC3339))
+//	}
+func TokenExpiry(c *fursy.Context) (time.Time, bool) {
+	exp, ok := c.Get(JWTExpiryContextKey).(time.Time)
+	return exp, ok
+}
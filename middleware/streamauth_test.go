@@ -0,0 +1,161 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TestStreamAuth_TokenSources tests token extraction from header, query and cookie.
+func TestStreamAuth_TokenSources(t *testing.T) {
+	secret := []byte("secret")
+	token := generateTestToken(jwt.MapClaims{
+		"sub": "user-1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}, secret, "HS256")
+
+	r := fursy.New()
+	r.Use(StreamAuth(secret))
+	r.GET("/events", func(c *fursy.Context) error {
+		sub, _ := c.Get(JWTContextKey).(jwt.MapClaims).GetSubject()
+		return c.String(http.StatusOK, sub)
+	})
+
+	tests := []struct {
+		name  string
+		setup func(req *http.Request)
+		want  int
+	}{
+		{"header", func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+token) }, http.StatusOK},
+		{"query", func(req *http.Request) { req.URL.RawQuery = "access_token=" + token }, http.StatusOK},
+		{"cookie", func(req *http.Request) { req.AddCookie(&http.Cookie{Name: "access_token", Value: token}) }, http.StatusOK},
+		{"missing", func(*http.Request) {}, http.StatusUnauthorized},
+		{"invalid", func(req *http.Request) { req.URL.RawQuery = "access_token=invalid" }, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/events", nil)
+			tt.setup(req)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("expected status %d, got %d", tt.want, w.Code)
+			}
+			if tt.want == http.StatusOK && w.Body.String() != "user-1" {
+				t.Errorf("expected claims in context, got %q", w.Body.String())
+			}
+		})
+	}
+}
+
+// TestStreamAuth_ExpiryDeadline tests that the request context ends when the token expires.
+func TestStreamAuth_ExpiryDeadline(t *testing.T) {
+	secret := []byte("secret")
+	exp := time.Now().Add(time.Second).Truncate(time.Second).Add(time.Second)
+	token := generateTestToken(jwt.MapClaims{"exp": exp.Unix()}, secret, "HS256")
+
+	var (
+		deadline   time.Time
+		expiry     time.Time
+		ctxErr     error
+		streamDone = make(chan struct{})
+	)
+
+	r := fursy.New()
+	r.Use(StreamAuth(secret))
+	r.GET("/events", func(c *fursy.Context) error {
+		deadline, _ = c.Request.Context().Deadline()
+		expiry, _ = TokenExpiry(c)
+
+		// Simulate a long-lived stream bound to the request context.
+		select {
+		case <-c.Request.Context().Done():
+			ctxErr = c.Request.Context().Err()
+		case <-time.After(5 * time.Second):
+		}
+		close(streamDone)
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/events?access_token="+token, nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	<-streamDone
+
+	if !deadline.Equal(exp) || !expiry.Equal(exp) {
+		t.Errorf("expected deadline and expiry %v, got %v and %v", exp, deadline, expiry)
+	}
+	if !errors.Is(ctxErr, context.DeadlineExceeded) {
+		t.Errorf("expected stream to end with DeadlineExceeded, got %v", ctxErr)
+	}
+}
+
+// TestStreamAuth_RequireExpiry tests rejection of tokens without exp.
+func TestStreamAuth_RequireExpiry(t *testing.T) {
+	secret := []byte("secret")
+	token := generateTestToken(jwt.MapClaims{"sub": "user-1"}, secret, "HS256")
+
+	for _, require := range []bool{false, true} {
+		var gotErr error
+		r := fursy.New()
+		r.Use(StreamAuthWithConfig(StreamAuthConfig{
+			JWT: JWTConfig{
+				SigningKey: secret,
+				ErrorHandler: func(c *fursy.Context, err error) error {
+					gotErr = err
+					return c.NoContent(http.StatusUnauthorized)
+				},
+			},
+			RequireExpiry: require,
+		}))
+		r.GET("/events", func(c *fursy.Context) error {
+			if _, ok := c.Request.Context().Deadline(); ok {
+				t.Error("expected no deadline for a token without exp")
+			}
+			return c.NoContent(http.StatusNoContent)
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events?access_token="+token, nil))
+
+		if require {
+			if w.Code != http.StatusUnauthorized || !errors.Is(gotErr, ErrJWTNoExpiry) {
+				t.Errorf("expected ErrJWTNoExpiry, got %d (%v)", w.Code, gotErr)
+			}
+		} else if w.Code != http.StatusNoContent {
+			t.Errorf("expected status 204, got %d", w.Code)
+		}
+	}
+}
+
+// TestJWT_MultipleTokenLookup tests comma-separated token sources.
+func TestJWT_MultipleTokenLookup(t *testing.T) {
+	secret := []byte("secret")
+	token := generateTestToken(jwt.MapClaims{"exp": time.Now().Add(time.Hour).Unix()}, secret, "HS256")
+
+	r := fursy.New()
+	r.Use(JWTWithConfig(JWTConfig{
+		SigningKey:  secret,
+		TokenLookup: "header:Authorization, query:token",
+	}))
+	r.GET("/", func(c *fursy.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?token="+token, nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected query fallback to succeed, got %d", w.Code)
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MiddlewareRun records the execution of a middleware: of a named
+// middleware (see Named), or of every handler in the chain when
+// Router.SetMiddlewareTiming is enabled.
+type MiddlewareRun struct {
+	// Name is the middleware name (see MiddlewareName).
+	Name string
+
+	// Start is when the middleware was called.
+	Start time.Time
+
+	// Duration is how long the middleware took, including the handlers
+	// it called with Context.Next. It is zero while the middleware runs.
+	Duration time.Duration
+
+	// Self is Duration minus the Duration of the recorded runs nested in
+	// it: the time spent in the middleware itself. It is zero while the
+	// middleware runs.
+	Self time.Duration
+}
+
+// namedMiddleware is a middleware with a name (see Named).
+type namedMiddleware struct {
+	name string
+	next HandlerFunc
+}
+
+// serve runs the middleware and records the run in the context.
+// Called with namedProbe, it returns the middleware instead (see unwrapNamed).
+func (m *namedMiddleware) serve(c *Context) error {
+	if c == namedProbe {
+		return namedProbeResult{m}
+	}
+	run := c.beginRun(m.name)
+	err := m.next(c)
+	c.endRun(run)
+	return err
+}
+
+// namedServe is the code pointer shared by all middleware returned by Named.
+var namedServe = reflect.ValueOf(HandlerFunc((&namedMiddleware{}).serve)).Pointer()
+
+// namedProbe is the context passed to the middleware returned by Named
+// to unwrap them. It is never used to serve a request.
+var namedProbe = new(Context)
+
+// namedProbeResult is returned by namedMiddleware.serve for namedProbe.
+type namedProbeResult struct {
+	m *namedMiddleware
+}
+
+// Error implements error.
+func (namedProbeResult) Error() string {
+	return "fursy: named middleware probe"
+}
+
+// unwrapNamed returns the namedMiddleware of h if h was returned by Named.
+// Only the serve method of namedMiddleware is called: other middleware
+// are recognized by their code pointer and never run.
+func unwrapNamed(h HandlerFunc) (*namedMiddleware, bool) {
+	if reflect.ValueOf(h).Pointer() != namedServe {
+		return nil, false
+	}
+	res, ok := h(namedProbe).(namedProbeResult)
+	return res.m, ok
+}
+
+// Named gives mw a name.
+//
+// The name is reported by MiddlewareName, RouteInfo.Middleware and
+// Router.LogSummary, and every run of mw is recorded in the request
+// context (see Context.MiddlewareRuns), where tracing plugins pick it up.
+//
+// Panics if mw is nil.
+//
+// Example:
+//
+//	router.Use(fursy.Named("cors", middleware.CORS()))
+//	api := router.Group("/api", fursy.Named("auth", middleware.JWT(secret)))
+func Named(name string, mw HandlerFunc) HandlerFunc {
+	if mw == nil {
+		panic("fursy: middleware cannot be nil")
+	}
+	return (&namedMiddleware{name: name, next: mw}).serve
+}
+
+// MiddlewareName returns the name of mw: the name given with Named, or a
+// name derived from the function that created mw (e.g.
+// "middleware.CORSWithConfig" for the closure it returns).
+func MiddlewareName(mw HandlerFunc) string {
+	if mw == nil {
+		return ""
+	}
+
+	if m, ok := unwrapNamed(mw); ok {
+		return m.name
+	}
+
+	fn := runtime.FuncForPC(reflect.ValueOf(mw).Pointer())
+	if fn == nil {
+		return "unknown"
+	}
+
+	// Method values end in "-fm": "main.(*auth).Check-fm" -> "main.(*auth).Check".
+	name := strings.TrimSuffix(fn.Name(), "-fm")
+	// Drop the import path: "github.com/coregx/fursy/middleware.Logger" -> "middleware.Logger".
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	// Drop closure suffixes: "middleware.CORSWithConfig.func1" -> "middleware.CORSWithConfig".
+	for {
+		i := strings.LastIndex(name, ".")
+		if i < 0 || !isClosureSuffix(name[i+1:]) {
+			break
+		}
+		name = name[:i]
+	}
+	return name
+}
+
+// isClosureSuffix reports whether s is a compiler-generated closure name
+// component ("func1", "1", "gowrap2").
+func isClosureSuffix(s string) bool {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "func"), "gowrap")
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// middlewareNames returns the names of handlers.
+func middlewareNames(handlers []HandlerFunc) []string {
+	names := make([]string, len(handlers))
+	for i, h := range handlers {
+		names[i] = MiddlewareName(h)
+	}
+	return names
+}
+
+// SetMiddlewareTiming enables recording a MiddlewareRun for every handler
+// in the chain (router middleware, group middleware and the route
+// handler), named by MiddlewareName. The Logger middleware and the
+// OpenTelemetry plugin report the runs, to pinpoint which layer adds
+// latency.
+//
+// Timing adds a clock read and a name lookup per handler, so enable it
+// for debugging or in staging rather than permanently.
+//
+// Default: disabled (only middleware wrapped with Named is recorded).
+//
+// Example:
+//
+//	router.SetMiddlewareTiming(os.Getenv("FURSY_TIMING") == "1")
+func (r *Router) SetMiddlewareTiming(enabled bool) *Router {
+	r.middlewareTiming = enabled
+	return r
+}
+
+// timedCall calls h and records its run, unless h records itself (Named)
+// or is the internal group chain wrapper.
+func (c *Context) timedCall(h HandlerFunc) error {
+	if pc := reflect.ValueOf(h).Pointer(); pc == namedServe || pc == groupChainServe {
+		return h(c)
+	}
+
+	run := c.beginRun(MiddlewareName(h))
+	err := h(c)
+	c.endRun(run)
+	return err
+}
+
+// beginRun records the start of a middleware run and returns its index.
+func (c *Context) beginRun(name string) int {
+	i := len(c.middlewareRuns)
+	c.middlewareRuns = append(c.middlewareRuns, MiddlewareRun{Name: name, Start: time.Now()})
+	c.openRuns = append(c.openRuns, i)
+	return i
+}
+
+// endRun records the end of the middleware run i.
+func (c *Context) endRun(i int) {
+	// Pop i (and runs left open by a recovered panic).
+	for len(c.openRuns) > 0 {
+		top := c.openRuns[len(c.openRuns)-1]
+		c.openRuns = c.openRuns[:len(c.openRuns)-1]
+		if top == i {
+			break
+		}
+	}
+
+	// A completed run always has a non-zero Duration.
+	d := max(time.Since(c.middlewareRuns[i].Start), time.Nanosecond)
+	c.middlewareRuns[i].Duration = d
+	c.middlewareRuns[i].Self += d
+
+	// Exclude this run from the self time of the enclosing run.
+	if n := len(c.openRuns); n > 0 {
+		c.middlewareRuns[c.openRuns[n-1]].Self -= d
+	}
+}
+
+// MiddlewareRuns returns the recorded middleware runs (see Named and
+// Router.SetMiddlewareTiming) in the order they were called. Runs that
+// are still in progress (e.g. when called from a handler) have a zero
+// Duration and Self.
+//
+// Example (debug endpoint):
+//
+//	router.GET("/debug/middleware", func(c *fursy.Context) error {
+//	    return c.JSON(200, c.MiddlewareRuns())
+//	})
+func (c *Context) MiddlewareRuns() []MiddlewareRun {
+	runs := slices.Clone(c.middlewareRuns)
+	for i := range runs {
+		if runs[i].Duration == 0 {
+			runs[i].Self = 0
+		}
+	}
+	return runs
+}
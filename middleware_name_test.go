@@ -0,0 +1,176 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestMiddlewareName tests names of named and anonymous middleware.
+func TestMiddlewareName(t *testing.T) {
+	anonymous := func(c *Context) error { return c.Next() }
+
+	tests := []struct {
+		name string
+		mw   HandlerFunc
+		want string
+	}{
+		{"named", Named("cors", anonymous), "cors"},
+		{"named twice", Named("outer", Named("inner", anonymous)), "outer"},
+		{"constructor closure", summaryMiddleware(), "fursy.summaryMiddleware"},
+		{"method value", summaryAuth{}.Check, "fursy.summaryAuth.Check"},
+		{"nil", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MiddlewareName(tt.mw); got != tt.want {
+				t.Errorf("MiddlewareName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNamed_Runs tests that runs of named middleware are recorded.
+func TestNamed_Runs(t *testing.T) {
+	slow := func(c *Context) error {
+		time.Sleep(5 * time.Millisecond)
+		return c.Next()
+	}
+
+	var runs []MiddlewareRun
+	r := New()
+	r.Use(Named("outer", slow), func(c *Context) error { return c.Next() })
+	api := r.Group("/api", Named("inner", slow))
+	api.GET("/users", func(c *Context) error {
+		runs = c.MiddlewareRuns()
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/users", http.NoBody))
+
+	if len(runs) != 2 || runs[0].Name != "outer" || runs[1].Name != "inner" {
+		t.Fatalf("expected runs [outer inner], got %+v", runs)
+	}
+	if runs[0].Duration != 0 {
+		t.Errorf("run in progress should have zero duration, got %v", runs[0].Duration)
+	}
+
+	// Durations are set once the middleware returns.
+	var done []MiddlewareRun
+	outer := New()
+	outer.Use(func(c *Context) error {
+		err := c.Next()
+		done = c.MiddlewareRuns()
+		return err
+	}, Named("slow", slow))
+	outer.GET("/", func(c *Context) error { return nil })
+	outer.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	if len(done) != 1 || done[0].Duration < 5*time.Millisecond {
+		t.Errorf("expected completed run of at least 5ms, got %+v", done)
+	}
+}
+
+// TestRouter_Routes_Middleware tests the middleware chain in route listings.
+func TestRouter_Routes_Middleware(t *testing.T) {
+	noop := func(c *Context) error { return c.Next() }
+
+	r := New()
+	r.GET("/health", func(c *Context) error { return nil })
+	api := r.Group("/api", Named("auth", noop))
+	api.GET("/users", func(c *Context) error { return nil })
+	r.Use(Named("logger", noop)) // Global middleware applies to routes registered before Use.
+
+	routes := r.Routes()
+	if len(routes) != 2 {
+		t.Fatalf("expected 2 routes, got %d", len(routes))
+	}
+	if !slices.Equal(routes[0].Middleware, []string{"logger"}) {
+		t.Errorf("GET /health middleware = %v, want [logger]", routes[0].Middleware)
+	}
+	if routes[1].Path != "/api/users" || !slices.Equal(routes[1].Middleware, []string{"logger", "auth"}) {
+		t.Errorf("GET /api/users middleware = %v, want [logger auth]", routes[1].Middleware)
+	}
+}
+
+// TestRouter_SetMiddlewareTiming tests timing of every handler in the chain.
+func TestRouter_SetMiddlewareTiming(t *testing.T) {
+	sleep := func(d time.Duration) HandlerFunc {
+		return func(c *Context) error {
+			time.Sleep(d)
+			return c.Next()
+		}
+	}
+
+	var runs []MiddlewareRun
+	r := New()
+	r.SetMiddlewareTiming(true)
+	r.Use(func(c *Context) error {
+		err := c.Next()
+		runs = c.MiddlewareRuns()
+		return err
+	})
+	r.Use(Named("outer", sleep(10*time.Millisecond)))
+	api := r.Group("/api", Named("inner", sleep(5*time.Millisecond)))
+	api.GET("/users", func(c *Context) error { return c.NoContent(http.StatusNoContent) })
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/users", http.NoBody))
+
+	// The first middleware is still running when it reads the runs; the group
+	// wrapper is not recorded.
+	names := make([]string, len(runs))
+	for i, run := range runs {
+		names[i] = run.Name
+	}
+	if len(runs) != 4 || names[1] != "outer" || names[2] != "inner" {
+		t.Fatalf("unexpected runs %v", names)
+	}
+	if runs[0].Duration != 0 || runs[0].Self != 0 {
+		t.Errorf("running middleware should have zero durations, got %+v", runs[0])
+	}
+
+	outer, inner := runs[1], runs[2]
+	if outer.Duration < 15*time.Millisecond || inner.Duration < 5*time.Millisecond {
+		t.Errorf("unexpected durations: outer %v, inner %v", outer.Duration, inner.Duration)
+	}
+	if outer.Self < 10*time.Millisecond || outer.Self >= outer.Duration-inner.Duration+time.Millisecond {
+		t.Errorf("outer self time %v should exclude inner (%v of %v)", outer.Self, inner.Duration, outer.Duration)
+	}
+}
+
+// TestRouter_SetMiddlewareTiming_Panic tests that timing survives recovered panics.
+func TestRouter_SetMiddlewareTiming_Panic(t *testing.T) {
+	var runs []MiddlewareRun
+	r := New()
+	r.SetMiddlewareTiming(true)
+	r.Use(func(c *Context) error {
+		err := c.Next()
+		runs = c.MiddlewareRuns()
+		return err
+	})
+	r.Use(Named("recover", func(c *Context) (err error) {
+		defer func() {
+			if recover() != nil {
+				err = c.NoContent(http.StatusInternalServerError)
+			}
+		}()
+		return c.Next()
+	}))
+	r.GET("/", func(c *Context) error { panic("boom") })
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	if len(runs) != 3 || runs[1].Name != "recover" || runs[1].Duration == 0 {
+		t.Fatalf("expected completed recover run, got %+v", runs)
+	}
+	if runs[2].Duration != 0 {
+		t.Errorf("panicking handler should not be completed, got %+v", runs[2])
+	}
+}
@@ -6,15 +6,23 @@ package fursy
 
 // MIME type constants for common content types.
 const (
-	MIMEApplicationJSON  = "application/json"
-	MIMETextHTML         = "text/html"
-	MIMEApplicationXML   = "application/xml"
-	MIMETextXML          = "text/xml"
-	MIMETextPlain        = "text/plain"
-	MIMETextMarkdown     = "text/markdown" // Added for AI agents and documentation
-	MIMEApplicationForm  = "application/x-www-form-urlencoded"
-	MIMEMultipartForm    = "multipart/form-data"
-	MIMEApplicationXYAML = "application/x-yaml"
-	MIMEApplicationYAML  = "application/yaml"
-	MIMEApplicationTOML  = "application/toml"
+	MIMEApplicationJSON    = "application/json"
+	MIMETextHTML           = "text/html"
+	MIMEApplicationXML     = "application/xml"
+	MIMETextXML            = "text/xml"
+	MIMETextPlain          = "text/plain"
+	MIMETextMarkdown       = "text/markdown" // Added for AI agents and documentation
+	MIMEApplicationForm    = "application/x-www-form-urlencoded"
+	MIMEMultipartForm      = "multipart/form-data"
+	MIMEMultipartMixed     = "multipart/mixed"
+	MIMEMultipartReplace   = "multipart/x-mixed-replace"
+	MIMEApplicationXYAML   = "application/x-yaml"
+	MIMEApplicationYAML    = "application/yaml"
+	MIMEApplicationTOML    = "application/toml"
+	MIMEApplicationMsgPack = "application/msgpack"
+	MIMEApplicationCBOR    = "application/cbor"
+
+	// Patch document media types (see Context.ApplyPatch).
+	MIMEApplicationMergePatch = "application/merge-patch+json" // RFC 7386
+	MIMEApplicationJSONPatch  = "application/json-patch+json"  // RFC 6902
 )
@@ -0,0 +1,91 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+// Module packages a reusable feature (authentication, admin, billing, ...)
+// as one unit: its routes, the middleware of its routes and its cleanup.
+// Register it with Router.Register or RouteGroup.Register.
+//
+// A module mounted under a path implements interface{ Prefix() string }.
+type Module interface {
+	// Routes registers the routes of the module on g.
+	Routes(g *RouteGroup)
+
+	// Middleware returns the middleware of the routes of the module,
+	// run after the router (and parent group) middleware.
+	Middleware() []HandlerFunc
+
+	// Shutdown releases the resources of the module (workers,
+	// connections). It is called by Router.Shutdown.
+	Shutdown()
+}
+
+// Register registers the routes of m in a group with the middleware of m,
+// under the prefix of m if it implements interface{ Prefix() string },
+// and calls m.Shutdown on Router.Shutdown (see OnShutdown). Modules are
+// shut down in reverse registration order, so a module registered after
+// the modules it depends on is shut down first.
+//
+// Example:
+//
+//	type BillingModule struct {
+//	    db     *sql.DB
+//	    worker *InvoiceWorker
+//	}
+//
+//	func (m *BillingModule) Prefix() string { return "/billing" }
+//
+//	func (m *BillingModule) Routes(g *fursy.RouteGroup) {
+//	    g.GET("/invoices", m.listInvoices)
+//	    g.POST("/invoices/:id/pay", m.payInvoice)
+//	}
+//
+//	func (m *BillingModule) Middleware() []fursy.HandlerFunc {
+//	    return []fursy.HandlerFunc{middleware.JWT(secret)}
+//	}
+//
+//	func (m *BillingModule) Shutdown() { m.worker.Stop() }
+//
+//	router.Register(&BillingModule{db: db, worker: worker})
+func (r *Router) Register(m Module) *RouteGroup {
+	if m == nil {
+		panic("fursy: module cannot be nil")
+	}
+	g := r.Group(modulePrefix(m), m.Middleware()...)
+	registerModule(g, m)
+	return g
+}
+
+// Register registers the routes of m under the group prefix plus the
+// prefix of m, with the group middleware followed by the middleware of m
+// (see Router.Register).
+//
+// Example:
+//
+//	v1 := router.Group("/api/v1", auth)
+//	v1.Register(&BillingModule{db: db}) // /api/v1/billing/...
+func (g *RouteGroup) Register(m Module) *RouteGroup {
+	if m == nil {
+		panic("fursy: module cannot be nil")
+	}
+	child := g.Group(modulePrefix(m))
+	child.Use(m.Middleware()...)
+	registerModule(child, m)
+	return child
+}
+
+// modulePrefix returns the prefix of m ("" if m has none).
+func modulePrefix(m Module) string {
+	if p, ok := m.(interface{ Prefix() string }); ok {
+		return p.Prefix()
+	}
+	return ""
+}
+
+// registerModule registers the routes of m on g and its shutdown.
+func registerModule(g *RouteGroup, m Module) {
+	m.Routes(g)
+	g.router.OnShutdown(m.Shutdown)
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// testModule is a module recording its shutdown.
+type testModule struct {
+	name     string
+	shutdown *[]string
+}
+
+func (m *testModule) Routes(g *RouteGroup) {
+	g.GET("/items", func(c *Context) error {
+		return c.Text(m.name + ":" + c.GetHeader("X-Module") + ":" + c.Response.Header().Get("X-Module"))
+	})
+}
+
+func (m *testModule) Middleware() []HandlerFunc {
+	return []HandlerFunc{func(c *Context) error {
+		c.SetHeader("X-Module", m.name)
+		return c.Next()
+	}}
+}
+
+func (m *testModule) Shutdown() {
+	*m.shutdown = append(*m.shutdown, m.name)
+}
+
+// prefixedModule is a module mounted under a prefix.
+type prefixedModule struct {
+	testModule
+}
+
+func (m *prefixedModule) Prefix() string {
+	return "/" + m.name
+}
+
+// TestRouter_Register tests registering modules.
+func TestRouter_Register(t *testing.T) {
+	var shutdown []string
+	r := New()
+	r.Register(&testModule{name: "root", shutdown: &shutdown})
+	api := r.Group("/api", func(c *Context) error {
+		c.Request.Header.Set("X-Module", "api")
+		return c.Next()
+	})
+	g := api.Register(&prefixedModule{testModule{name: "billing", shutdown: &shutdown}})
+	if g.prefix != "/api/billing" {
+		t.Errorf("group prefix = %q, want /api/billing", g.prefix)
+	}
+
+	tests := []struct {
+		path, want string
+	}{
+		{"/items", "root::root"},
+		{"/api/billing/items", "billing:api:billing"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+		if w.Body.String() != tt.want {
+			t.Errorf("%s: body = %q, want %q", tt.path, w.Body.String(), tt.want)
+		}
+	}
+
+	if err := r.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(shutdown) != 2 || shutdown[0] != "billing" || shutdown[1] != "root" {
+		t.Errorf("shutdown order = %v, want [billing root]", shutdown)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Register(nil) should panic")
+		}
+	}()
+	r.Register(nil)
+}
@@ -234,3 +234,93 @@ func TestContext_Negotiate_QWeightingSelection(t *testing.T) {
 }
 
 // Note: contains helper function is defined in validation_test.go
+
+// TestContext_NegotiateLanguage tests Accept-Language negotiation and Vary.
+func TestContext_NegotiateLanguage(t *testing.T) {
+	router := New()
+	router.GET("/test", func(c *Context) error {
+		return c.String(200, c.NegotiateLanguage("en", "de-DE", "fr"))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
+	req.Header.Set("Accept-Language", "de, en;q=0.5")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Body.String() != "de-DE" {
+		t.Errorf("Expected de-DE, got %s", w.Body.String())
+	}
+	if vary := w.Header().Get("Vary"); vary != "Accept-Language" {
+		t.Errorf("Expected Vary: Accept-Language, got %q", vary)
+	}
+}
+
+// TestContext_NegotiateCharsetEncoding tests Accept-Charset and Accept-Encoding negotiation.
+func TestContext_NegotiateCharsetEncoding(t *testing.T) {
+	router := New()
+	router.GET("/test", func(c *Context) error {
+		charset := c.NegotiateCharset("utf-8", "iso-8859-1")
+		encoding := c.NegotiateEncoding("br", "gzip", "identity")
+		return c.String(200, charset+" "+encoding)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
+	req.Header.Set("Accept-Charset", "iso-8859-1, utf-8;q=0.5")
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Body.String() != "iso-8859-1 gzip" {
+		t.Errorf("Expected 'iso-8859-1 gzip', got %q", w.Body.String())
+	}
+	if vary := w.Header().Get("Vary"); vary != "Accept-Charset, Accept-Encoding" {
+		t.Errorf("Expected Vary: Accept-Charset, Accept-Encoding, got %q", vary)
+	}
+}
+
+// TestContext_Vary tests that Vary values are merged without duplicates.
+func TestContext_Vary(t *testing.T) {
+	router := New()
+	router.GET("/test", func(c *Context) error {
+		c.Response.Header().Add("Vary", "Origin")
+		c.Vary("accept-encoding", "Origin")
+		c.Vary("Accept-Encoding")
+		return c.Negotiate(200, "ok")
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", http.NoBody))
+
+	if vary := w.Header().Get("Vary"); vary != "Origin, Accept-Encoding, Accept" {
+		t.Errorf("Expected merged Vary header, got %q", vary)
+	}
+}
+
+// TestContext_AddVary_Wildcard tests that "*" replaces and absorbs other names.
+func TestContext_AddVary_Wildcard(t *testing.T) {
+	router := New()
+	router.GET("/test", func(c *Context) error {
+		c.AddVary("Accept-Encoding", "")
+		c.AddVary("*")
+		c.AddVary("Origin")
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", http.NoBody))
+
+	if vary := w.Header().Values("Vary"); len(vary) != 1 || vary[0] != "*" {
+		t.Errorf("Expected Vary: *, got %q", vary)
+	}
+}
+
+// TestAddVaryHeader tests adding Vary names to a plain http.Header.
+func TestAddVaryHeader(t *testing.T) {
+	h := http.Header{}
+	h.Add("Vary", "Accept, Origin")
+	AddVaryHeader(h, "origin", "cookie")
+
+	if vary := h.Get("Vary"); vary != "Accept, Origin, Cookie" {
+		t.Errorf("Expected merged Vary header, got %q", vary)
+	}
+}
@@ -7,9 +7,16 @@ package fursy
 import (
 	"encoding/json/v2"
 	"fmt"
+	"maps"
 	"net/http"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/coregx/fursy/internal/binding"
+	"github.com/coregx/fursy/internal/radix"
 )
 
 // OpenAPI schema type constants.
@@ -46,6 +53,27 @@ type OpenAPI struct {
 
 	// Tags is a list of tags used by the document with additional metadata.
 	Tags []Tag `json:"tags,omitempty"`
+
+	// ExternalDocs references additional external documentation.
+	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty"`
+
+	// Webhooks describes the requests the API sends to its consumers,
+	// by webhook name (OpenAPI 3.1 only).
+	Webhooks map[string]PathItem `json:"webhooks,omitempty"`
+
+	// Extensions are specification extensions (keys must start with "x-").
+	// These will be flattened into the JSON output alongside standard fields.
+	Extensions map[string]any `json:"-"`
+}
+
+// MarshalJSON implements custom JSON marshaling to flatten extensions.
+func (doc OpenAPI) MarshalJSON() ([]byte, error) {
+	type openAPI OpenAPI // Prevent recursion.
+	data, err := json.Marshal(openAPI(doc))
+	if err != nil {
+		return nil, err
+	}
+	return appendExtensions(data, doc.Extensions)
 }
 
 // Info provides metadata about the API.
@@ -70,6 +98,21 @@ type Info struct {
 
 	// TermsOfService is a URL to the Terms of Service for the API.
 	TermsOfService string `json:"termsOfService,omitempty"`
+
+	// Extensions are specification extensions (keys must start with "x-"),
+	// e.g. "x-logo" for doc renderers.
+	// These will be flattened into the JSON output alongside standard fields.
+	Extensions map[string]any `json:"-"`
+}
+
+// MarshalJSON implements custom JSON marshaling to flatten extensions.
+func (info Info) MarshalJSON() ([]byte, error) {
+	type infoObject Info // Prevent recursion.
+	data, err := json.Marshal(infoObject(info))
+	if err != nil {
+		return nil, err
+	}
+	return appendExtensions(data, info.Extensions)
 }
 
 // Contact information for the exposed API.
@@ -116,6 +159,7 @@ type PathItem struct {
 	Patch       *Operation  `json:"patch,omitempty"`
 	Head        *Operation  `json:"head,omitempty"`
 	Options     *Operation  `json:"options,omitempty"`
+	Trace       *Operation  `json:"trace,omitempty"`
 	Parameters  []Parameter `json:"parameters,omitempty"`
 }
 
@@ -147,6 +191,71 @@ type Operation struct {
 
 	// Security is a declaration of which security mechanisms can be used.
 	Security []SecurityRequirement `json:"security,omitempty"`
+
+	// ExternalDocs references additional external documentation.
+	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty"`
+
+	// Extensions are specification extensions (keys must start with "x-").
+	// These will be flattened into the JSON output alongside standard fields.
+	Extensions map[string]any `json:"-"`
+}
+
+// MarshalJSON implements custom JSON marshaling to flatten extensions.
+func (o Operation) MarshalJSON() ([]byte, error) {
+	type operation Operation // Prevent recursion.
+	data, err := json.Marshal(operation(o))
+	if err != nil {
+		return nil, err
+	}
+	return appendExtensions(data, o.Extensions)
+}
+
+// appendExtensions merges the extensions into the JSON object data.
+func appendExtensions(data []byte, extensions map[string]any) ([]byte, error) {
+	if len(extensions) == 0 {
+		return data, nil
+	}
+
+	ext, err := json.Marshal(extensions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Merge {"responses":...} and {"x-...":...} into a single object.
+	data = append(data[:len(data)-1], ',')
+	return append(data, ext[1:]...), nil
+}
+
+// validateExtensions checks that the extension keys start with "x-".
+func validateExtensions(where string, extensions map[string]any) error {
+	for key := range extensions {
+		if !strings.HasPrefix(key, "x-") {
+			return fmt.Errorf("fursy: %s extension %q must start with \"x-\"", where, key)
+		}
+	}
+	return nil
+}
+
+// ExternalDocs references external documentation.
+type ExternalDocs struct {
+	// Description of the target documentation (supports CommonMark).
+	Description string `json:"description,omitempty"`
+
+	// URL of the target documentation (required).
+	URL string `json:"url"`
+}
+
+// CodeSample is a code sample of an operation, documented in the
+// x-codeSamples extension rendered by Redoc and other doc renderers.
+type CodeSample struct {
+	// Lang is the language of the sample (e.g., "Go", "curl", "JavaScript").
+	Lang string `json:"lang"`
+
+	// Label is the tab label of the sample (default: Lang).
+	Label string `json:"label,omitempty"`
+
+	// Source is the sample code.
+	Source string `json:"source"`
 }
 
 // Parameter describes a single operation parameter.
@@ -170,6 +279,14 @@ type Parameter struct {
 
 	// Schema defining the type used for the parameter.
 	Schema *Schema `json:"schema,omitempty"`
+
+	// Style describes how the parameter value is serialized
+	// (e.g., "form", "deepObject").
+	Style string `json:"style,omitempty"`
+
+	// Explode generates separate parameters for each value of arrays
+	// and objects.
+	Explode *bool `json:"explode,omitempty"`
 }
 
 // RequestBody describes a single request body.
@@ -251,13 +368,29 @@ type Schema struct {
 	// Enum restricts values to a specific set.
 	Enum []any `json:"enum,omitempty"`
 
+	// Minimum is the inclusive lower bound of numbers.
+	Minimum *float64 `json:"minimum,omitempty"`
+
+	// Maximum is the inclusive upper bound of numbers.
+	Maximum *float64 `json:"maximum,omitempty"`
+
+	// ExclusiveMinimum is the exclusive lower bound of numbers: a number
+	// (OpenAPI 3.1), or true to make Minimum exclusive (OpenAPI 3.0).
+	ExclusiveMinimum any `json:"exclusiveMinimum,omitempty"`
+
+	// ExclusiveMaximum is the exclusive upper bound of numbers: a number
+	// (OpenAPI 3.1), or true to make Maximum exclusive (OpenAPI 3.0).
+	ExclusiveMaximum any `json:"exclusiveMaximum,omitempty"`
+
 	// Default value.
 	Default any `json:"default,omitempty"`
 
 	// Example value.
 	Example any `json:"example,omitempty"`
 
-	// Nullable indicates if the value can be null.
+	// Nullable indicates if the value can be null. In OpenAPI 3.1
+	// documents, typed nullable schemas are written with a type array
+	// (e.g., "type": ["string", "null"]).
 	Nullable bool `json:"nullable,omitempty"`
 
 	// ReadOnly indicates the property is read-only.
@@ -280,6 +413,25 @@ type Schema struct {
 
 	// AllOf specifies that the value must match all schemas.
 	AllOf []*Schema `json:"allOf,omitempty"`
+
+	// openAPI30 writes the schema for an OpenAPI 3.0 document.
+	openAPI30 bool
+}
+
+// MarshalJSON implements custom JSON marshaling to write typed nullable
+// schemas as JSON Schema type arrays in OpenAPI 3.1 documents.
+func (s Schema) MarshalJSON() ([]byte, error) {
+	type schema Schema // Prevent recursion.
+	if !s.Nullable || s.Type == "" || s.openAPI30 {
+		return json.Marshal(schema(s))
+	}
+
+	typ := []string{s.Type, "null"}
+	s.Type, s.Nullable = "", false
+	return json.Marshal(struct {
+		Type []string `json:"type"`
+		schema
+	}{typ, schema(s)})
 }
 
 // Components holds reusable objects.
@@ -336,8 +488,9 @@ type SecurityRequirement map[string][]string
 
 // Tag represents a tag with metadata.
 type Tag struct {
-	Name        string `json:"name"`
-	Description string `json:"description,omitempty"`
+	Name         string        `json:"name"`
+	Description  string        `json:"description,omitempty"`
+	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty"`
 }
 
 // generateSchema generates a JSON Schema from a Go type using reflection.
@@ -377,8 +530,8 @@ func generateSchema(t reflect.Type) *Schema {
 		for i := 0; i < t.NumField(); i++ {
 			field := t.Field(i)
 
-			// Skip unexported fields.
-			if !field.IsExported() {
+			// Skip unexported fields and fields bound from the URL.
+			if !field.IsExported() || isURLField(field) {
 				continue
 			}
 
@@ -428,10 +581,49 @@ func generateSchema(t reflect.Type) *Schema {
 	return schema
 }
 
+// multipartSchema generates the multipart/form-data schema of a request
+// struct with file fields. Properties are named by form tag and files are
+// binary strings.
+func multipartSchema(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	schema := &Schema{Type: schemaTypeObject, Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || isURLField(field) {
+			continue
+		}
+
+		name := binding.FormName(field)
+		if name == "-" {
+			continue
+		}
+
+		var fieldSchema *Schema
+		switch {
+		case binding.IsFileField(field) && field.Type.Kind() == reflect.Slice:
+			fieldSchema = &Schema{Type: "array", Items: &Schema{Type: schemaTypeString, Format: "binary"}}
+		case binding.IsFileField(field):
+			fieldSchema = &Schema{Type: schemaTypeString, Format: "binary"}
+		default:
+			fieldSchema = generateSchema(field.Type)
+		}
+		schema.Properties[name] = fieldSchema
+
+		if slices.Contains(strings.Split(field.Tag.Get("validate"), ","), "required") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
+
 // GenerateOpenAPI generates an OpenAPI 3.1 document from the router.
 //
 // This method introspects all registered routes and generates a complete
 // OpenAPI 3.1 specification including paths, schemas, and components.
+// Pass WithVersion("3.0") to generate an OpenAPI 3.0.3 document instead.
 //
 // If info is not provided via WithInfo(), the info parameter is used.
 //
@@ -442,17 +634,39 @@ func generateSchema(t reflect.Type) *Schema {
 //	    Version: "1.0.0",
 //	})
 //
+// Example (for tools without OpenAPI 3.1 support):
+//
+//	doc, err := router.GenerateOpenAPI(info, fursy.WithVersion("3.0"))
+//
 //nolint:gocognit,gocyclo,cyclop,gocritic,funlen // OpenAPI generation requires complex route introspection.
-func (r *Router) GenerateOpenAPI(info Info) (*OpenAPI, error) {
+func (r *Router) GenerateOpenAPI(info Info, opts ...OpenAPIOption) (*OpenAPI, error) {
+	var options openAPIOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+
 	// Use router info if set, otherwise use parameter.
 	if r.info != nil {
 		info = *r.info
 	}
 
+	if err := validateExtensions("info", info.Extensions); err != nil {
+		return nil, err
+	}
+	if err := validateExtensions("document", r.openAPIExtensions); err != nil {
+		return nil, err
+	}
+
 	doc := &OpenAPI{
-		OpenAPI: "3.1.0",
-		Info:    info,
-		Paths:   make(map[string]PathItem),
+		OpenAPI:      "3.1.0",
+		Info:         info,
+		Paths:        make(map[string]PathItem),
+		ExternalDocs: r.externalDocs,
+		Webhooks:     r.webhooks,
+		Extensions:   r.openAPIExtensions,
 		Components: &Components{
 			Schemas: make(map[string]*Schema),
 			Responses: map[string]Response{
@@ -506,8 +720,12 @@ func (r *Router) GenerateOpenAPI(info Info) (*OpenAPI, error) {
 		Required: []string{"type", "title", "status"},
 	}
 
+	// Add the problem types declared with DefineProblem.
+	problemResponses(doc.Components)
+
 	// Process all registered routes.
-	for _, route := range r.routes {
+	routes := r.routeList()
+	for _, route := range expandOptionalRoutes(routes) {
 		// Convert FURSY path format to OpenAPI format.
 		// /users/:id -> /users/{id}
 		openAPIPath := convertPathToOpenAPI(route.Path)
@@ -531,26 +749,38 @@ func (r *Router) GenerateOpenAPI(info Info) (*OpenAPI, error) {
 		// Add parameters.
 		if len(route.Parameters) > 0 {
 			for _, param := range route.Parameters {
-				operation.Parameters = append(operation.Parameters, Parameter{
+				// Parameters without a Go type are plain strings.
+				schema := &Schema{Type: schemaTypeString}
+				if param.Type != nil {
+					schema = generateSchema(param.Type)
+				}
+				if param.Style == "deepObject" && param.Type != nil && isStructType(param.Type) {
+					schema = queryObjectSchema(param.Type)
+				}
+				parameter := Parameter{
 					Name:        param.Name,
 					In:          param.In,
 					Description: param.Description,
 					Required:    param.Required,
-					Schema:      generateSchema(param.Type),
-				})
+					Schema:      schema,
+					Style:       param.Style,
+				}
+				if param.Style != "" {
+					parameter.Explode = &param.Explode
+				}
+				operation.Parameters = append(operation.Parameters, parameter)
 			}
 		}
 
 		// Add request body if RequestType is set.
 		if route.RequestType != nil {
-			schema := generateSchema(route.RequestType)
+			content := mediaTypeContent(r.requestMediaTypes(), generateSchema(route.RequestType))
+			if binding.HasFileFields(route.RequestType) {
+				content = mediaTypeContent([]string{MIMEMultipartForm}, multipartSchema(route.RequestType))
+			}
 			operation.RequestBody = &RequestBody{
 				Required: true,
-				Content: map[string]MediaType{
-					"application/json": {
-						Schema: schema,
-					},
-				},
+				Content:  content,
 			}
 		}
 
@@ -558,31 +788,33 @@ func (r *Router) GenerateOpenAPI(info Info) (*OpenAPI, error) {
 		if len(route.Responses) > 0 {
 			for status, resp := range route.Responses {
 				statusStr := fmt.Sprintf("%d", status)
-				operation.Responses[statusStr] = Response{
+				response := Response{
 					Description: resp.Description,
-					Content: map[string]MediaType{
+					Headers:     responseHeaders(&route, status, resp.Headers),
+				}
+				if resp.Type != nil {
+					response.Content = map[string]MediaType{
 						resp.ContentType: {
 							Schema: generateSchema(resp.Type),
 						},
-					},
+					}
 				}
+				operation.Responses[statusStr] = response
 			}
 		} else {
 			// Default responses.
-			if route.ResponseType != nil {
-				operation.Responses["200"] = Response{
-					Description: "Success",
-					Content: map[string]MediaType{
-						"application/json": {
-							Schema: generateSchema(route.ResponseType),
-						},
-					},
-				}
-			} else {
-				operation.Responses["200"] = Response{
-					Description: "Success",
-				}
+			status := route.SuccessStatus
+			if status == 0 {
+				status = http.StatusOK
+			}
+			response := Response{
+				Description: "Success",
+				Headers:     responseHeaders(&route, status, nil),
 			}
+			if route.ResponseType != nil && status != http.StatusNoContent {
+				response.Content = mediaTypeContent(r.responseMediaTypes(), generateSchema(route.ResponseType))
+			}
+			operation.Responses[strconv.Itoa(status)] = response
 		}
 
 		// Add default error responses.
@@ -603,6 +835,23 @@ func (r *Router) GenerateOpenAPI(info Info) (*OpenAPI, error) {
 			},
 		}
 
+		// Document operational route options.
+		addRoutePolicyDocs(operation, &route)
+
+		// Add documentation options.
+		if err := addOperationDocs(operation, &route); err != nil {
+			return nil, err
+		}
+
+		// Routes sharing a method and path with matchers are documented
+		// as a single operation (see Router.HandleMatch).
+		if route.Match != nil {
+			addMatcherDocs(operation, &route)
+			if existing := pathItemOperation(&pathItem, route.Method); existing != nil {
+				operation = mergeOperations(existing, operation)
+			}
+		}
+
 		// Assign operation to correct HTTP method.
 		switch route.Method {
 		case http.MethodGet:
@@ -619,14 +868,95 @@ func (r *Router) GenerateOpenAPI(info Info) (*OpenAPI, error) {
 			pathItem.Head = operation
 		case http.MethodOptions:
 			pathItem.Options = operation
+		case http.MethodTrace:
+			pathItem.Trace = operation
 		}
 
 		doc.Paths[openAPIPath] = pathItem
 	}
 
+	// Document the automatic OPTIONS responses.
+	if r.documentOPTIONS && r.handleOPTIONS {
+		allow := r.allowSets(routes)
+		for _, route := range routes {
+			for _, path := range radix.ExpandOptional(route.Path) {
+				openAPIPath := convertPathToOpenAPI(path)
+				pathItem := doc.Paths[openAPIPath]
+				if pathItem.Options != nil {
+					continue
+				}
+				pathItem.Options = optionsOperation(path, allow[route.Path])
+				doc.Paths[openAPIPath] = pathItem
+			}
+		}
+	}
+
+	if r.curlSamples {
+		addCurlSamples(doc)
+	}
+
+	if options.version == "3.0" {
+		downgradeOpenAPI30(doc)
+	}
 	return doc, nil
 }
 
+// optionsOperation returns the operation documenting the automatic OPTIONS
+// response of path, whose Allow header lists the allowed methods.
+func optionsOperation(path string, allow []string) *Operation {
+	operation := &Operation{
+		Summary: "Allowed methods",
+		Responses: map[string]Response{
+			"204": {
+				Description: "Allowed methods",
+				Headers: map[string]Header{
+					"Allow": {
+						Description: "Methods allowed on the path",
+						Schema:      &Schema{Type: schemaTypeString, Enum: []any{strings.Join(allow, ", ")}},
+					},
+				},
+			},
+		},
+	}
+	for _, name := range routePathParams(path) {
+		operation.Parameters = append(operation.Parameters, Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: schemaTypeString},
+		})
+	}
+	return operation
+}
+
+// expandOptionalRoutes returns the routes with one route per path of the
+// routes with optional parameters, since OpenAPI path parameters are always
+// required: /users/:id/:action? is documented as /users/{id} and
+// /users/{id}/{action}. Only the longest path keeps the operation ID.
+func expandOptionalRoutes(routes []RouteInfo) []RouteInfo {
+	var expanded []RouteInfo
+	for _, route := range routes {
+		paths := radix.ExpandOptional(route.Path)
+		if len(paths) == 1 {
+			expanded = append(expanded, route)
+			continue
+		}
+		for i, path := range paths {
+			variant := route
+			variant.Path = path
+			if i < len(paths)-1 {
+				variant.OperationID = ""
+			}
+			params := routePathParams(path)
+			variant.Parameters = slices.DeleteFunc(slices.Clone(route.Parameters), func(p RouteParameter) bool {
+				return p.In == "path" && !slices.Contains(params, p.Name)
+			})
+			expanded = append(expanded, variant)
+		}
+	}
+	return expanded
+}
+
 // convertPathToOpenAPI converts FURSY path format to OpenAPI format.
 // /users/:id -> /users/{id}
 // /files/*path -> /files/{path}.
@@ -664,9 +994,6 @@ func convertPathToOpenAPI(path string) string {
 	return result.String()
 }
 
-// MarshalJSON is implemented by the default json/v2 marshaler.
-// This comment exists to document that custom marshaling is not needed.
-
 // WriteJSON writes the OpenAPI document as JSON.
 func (doc *OpenAPI) WriteJSON(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
@@ -688,3 +1015,268 @@ func (doc *OpenAPI) WriteJSON(w http.ResponseWriter) error {
 func (doc *OpenAPI) WriteYAML(w http.ResponseWriter) error {
 	return fmt.Errorf("YAML output not yet implemented (requires external dependency)")
 }
+
+// addRoutePolicyDocs documents operational route options (timeouts, body limits,
+// rate limits, authentication, conditional requests, deprecation) as x- extensions
+// and error responses.
+func addRoutePolicyDocs(op *Operation, route *RouteInfo) {
+	problemResponse := func(description string) Response {
+		return Response{
+			Description: description,
+			Content: map[string]MediaType{
+				"application/problem+json": {
+					Schema: &Schema{Ref: "#/components/schemas/Problem"},
+				},
+			},
+		}
+	}
+
+	setExtension := func(key string, value any) {
+		if op.Extensions == nil {
+			op.Extensions = make(map[string]any)
+		}
+		op.Extensions[key] = value
+	}
+
+	if route.RequireAuth {
+		setExtension("x-require-auth", true)
+		op.Responses["401"] = problemResponse("Unauthorized")
+	}
+	if route.Public {
+		op.Security = []SecurityRequirement{{}}
+	}
+	if route.RateLimit != nil && route.RateLimit.Rate > 0 {
+		limit := map[string]any{
+			"rate":  route.RateLimit.Rate,
+			"burst": route.RateLimit.burst(),
+		}
+		if route.RateLimit.Bytes {
+			limit["unit"] = "bytes"
+			op.Responses["413"] = problemResponse("Content Too Large")
+		} else {
+			limit["cost"] = route.RateLimit.cost()
+		}
+		if route.RateLimit.Group != "" {
+			limit["group"] = route.RateLimit.Group
+		}
+		setExtension("x-ratelimit", limit)
+		op.Responses["429"] = problemResponse("Too Many Requests")
+	}
+	if route.Cost != nil && route.Cost.MaxCost > 0 {
+		setExtension("x-max-cost", route.Cost.MaxCost)
+		op.Responses["422"] = problemResponse("Unprocessable Entity")
+	}
+	if route.MaxBodySize > 0 {
+		setExtension("x-max-body-size", route.MaxBodySize)
+		op.Responses["413"] = problemResponse("Content Too Large")
+	}
+	if route.Timeout > 0 {
+		setExtension("x-timeout", route.Timeout.String())
+		op.Responses["503"] = problemResponse("Service Unavailable")
+	}
+	if route.RequireIfMatch {
+		conditionalDocs(op, problemResponse)
+	}
+	if !route.Sunset.IsZero() {
+		setExtension("x-sunset", route.Sunset.UTC().Format(time.RFC3339))
+	}
+	if route.DeprecationLink != "" {
+		setExtension("x-deprecation-link", route.DeprecationLink)
+	}
+}
+
+// addOperationDocs adds the external documentation, code samples and
+// extensions of the route. Route extensions override the extensions of
+// route policies.
+func addOperationDocs(op *Operation, route *RouteInfo) error {
+	if err := validateExtensions(route.Method+" "+route.Path, route.Extensions); err != nil {
+		return err
+	}
+
+	op.ExternalDocs = route.ExternalDocs
+	if len(route.CodeSamples) == 0 && len(route.Extensions) == 0 {
+		return nil
+	}
+	if op.Extensions == nil {
+		op.Extensions = make(map[string]any)
+	}
+	if len(route.CodeSamples) > 0 {
+		op.Extensions["x-codeSamples"] = route.CodeSamples
+	}
+	maps.Copy(op.Extensions, route.Extensions)
+	return nil
+}
+
+// addMatcherDocs documents the matcher of a route: content type matchers
+// as request body media types (with a 415 response), header matchers as
+// a header parameter.
+func addMatcherDocs(op *Operation, route *RouteInfo) {
+	m := route.Match
+	switch {
+	case m.contentTypes != nil:
+		var schema *Schema
+		if route.RequestType != nil {
+			schema = generateSchema(route.RequestType)
+		}
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  mediaTypeContent(m.contentTypes, schema),
+		}
+		op.Responses["415"] = Response{
+			Description: "Unsupported Media Type",
+			Content: map[string]MediaType{
+				"application/problem+json": {
+					Schema: &Schema{Ref: "#/components/schemas/Problem"},
+				},
+			},
+		}
+	case m.header != "":
+		schema := &Schema{Type: schemaTypeString}
+		for _, v := range m.values {
+			schema.Enum = append(schema.Enum, v)
+		}
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:     m.header,
+			In:       "header",
+			Required: true,
+			Schema:   schema,
+		})
+	}
+}
+
+// mergeOperations merges the operation of a matched route into the
+// operation of a previous route with the same method and path.
+// Metadata of the previous route takes precedence; request body media
+// types, parameters and responses are combined.
+func mergeOperations(dst, src *Operation) *Operation {
+	if dst.Summary == "" {
+		dst.Summary = src.Summary
+	}
+	if dst.Description == "" {
+		dst.Description = src.Description
+	}
+	if dst.OperationID == "" {
+		dst.OperationID = src.OperationID
+	}
+	for _, tag := range src.Tags {
+		if !slices.Contains(dst.Tags, tag) {
+			dst.Tags = append(dst.Tags, tag)
+		}
+	}
+	dst.Deprecated = dst.Deprecated && src.Deprecated
+	if dst.ExternalDocs == nil {
+		dst.ExternalDocs = src.ExternalDocs
+	}
+
+	switch {
+	case dst.RequestBody == nil:
+		dst.RequestBody = src.RequestBody
+	case src.RequestBody != nil:
+		for mt, content := range src.RequestBody.Content {
+			if _, ok := dst.RequestBody.Content[mt]; !ok {
+				dst.RequestBody.Content[mt] = content
+			}
+		}
+	}
+
+	// A parameter is only required if every route requires it.
+	for i := range dst.Parameters {
+		p := &dst.Parameters[i]
+		j := slices.IndexFunc(src.Parameters, func(q Parameter) bool { return q.Name == p.Name && q.In == p.In })
+		if j < 0 {
+			p.Required = false
+			continue
+		}
+		p.Required = p.Required && src.Parameters[j].Required
+		if p.Schema != nil && len(p.Schema.Enum) > 0 && src.Parameters[j].Schema != nil {
+			if enum := src.Parameters[j].Schema.Enum; len(enum) > 0 {
+				for _, v := range enum {
+					if !slices.Contains(p.Schema.Enum, v) {
+						p.Schema.Enum = append(p.Schema.Enum, v)
+					}
+				}
+			} else {
+				p.Schema.Enum = nil
+			}
+		}
+	}
+	for _, q := range src.Parameters {
+		if !slices.ContainsFunc(dst.Parameters, func(p Parameter) bool { return p.Name == q.Name && p.In == q.In }) {
+			q.Required = q.Required && q.In == "path"
+			dst.Parameters = append(dst.Parameters, q)
+		}
+	}
+
+	for status, resp := range src.Responses {
+		if _, ok := dst.Responses[status]; !ok {
+			dst.Responses[status] = resp
+		}
+	}
+	for key, value := range src.Extensions {
+		if _, ok := dst.Extensions[key]; !ok {
+			if dst.Extensions == nil {
+				dst.Extensions = make(map[string]any)
+			}
+			dst.Extensions[key] = value
+		}
+	}
+	return dst
+}
+
+// pathItemOperation returns the operation of pathItem for method, or nil.
+func pathItemOperation(pathItem *PathItem, method string) *Operation {
+	switch method {
+	case http.MethodGet:
+		return pathItem.Get
+	case http.MethodPost:
+		return pathItem.Post
+	case http.MethodPut:
+		return pathItem.Put
+	case http.MethodDelete:
+		return pathItem.Delete
+	case http.MethodPatch:
+		return pathItem.Patch
+	case http.MethodHead:
+		return pathItem.Head
+	case http.MethodOptions:
+		return pathItem.Options
+	case http.MethodTrace:
+		return pathItem.Trace
+	}
+	return nil
+}
+
+// responseHeaders documents the headers of a response with status:
+// the route's ResponseHeaders (for 2xx responses) and headers.
+func responseHeaders(route *RouteInfo, status int, headers []RouteHeader) map[string]Header {
+	if status >= 200 && status < 300 {
+		headers = append(slices.Clip(route.ResponseHeaders), headers...)
+	}
+	if len(headers) == 0 {
+		return nil
+	}
+
+	docs := make(map[string]Header, len(headers))
+	for _, h := range headers {
+		// Headers without a Go type are plain strings.
+		schema := &Schema{Type: schemaTypeString}
+		if h.Type != nil {
+			schema = generateSchema(h.Type)
+		}
+		docs[h.Name] = Header{
+			Description: h.Description,
+			Required:    h.Required,
+			Schema:      schema,
+		}
+	}
+	return docs
+}
+
+// mediaTypeContent returns a content map with the same schema for each media type.
+func mediaTypeContent(mediaTypes []string, schema *Schema) map[string]MediaType {
+	content := make(map[string]MediaType, len(mediaTypes))
+	for _, mt := range mediaTypes {
+		content[mt] = MediaType{Schema: schema}
+	}
+	return content
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Environment variables of the authentication placeholders of curl examples.
+var curlAuthVariables = map[string]string{
+	collectionVarToken:    "$TOKEN",
+	collectionVarUsername: "$USERNAME",
+	collectionVarPassword: "$PASSWORD",
+	collectionVarAPIKey:   "$API_KEY",
+}
+
+// CurlExample is a ready-to-run curl command of an operation.
+type CurlExample struct {
+	// Method is the HTTP method of the operation.
+	Method string
+
+	// Path is the OpenAPI path of the operation (e.g., "/users/{id}").
+	Path string
+
+	// Summary is the summary of the operation.
+	Summary string
+
+	// Command is the curl command.
+	Command string
+}
+
+// CurlExamples returns a ready-to-run curl command per operation of the
+// router, sorted by path (see OpenAPI.CurlExamples).
+//
+// Example:
+//
+//	examples, _ := router.CurlExamples()
+//	for _, ex := range examples {
+//	    slog.Info(ex.Method+" "+ex.Path, "curl", ex.Command)
+//	}
+func (r *Router) CurlExamples() ([]CurlExample, error) {
+	doc, err := r.GenerateOpenAPI(r.openAPIInfo())
+	if err != nil {
+		return nil, err
+	}
+	return doc.CurlExamples(), nil
+}
+
+// WithCurlSamples enables documenting a curl command per operation in the
+// x-codeSamples extension of the OpenAPI document, rendered as a code
+// sample tab by Redoc and other doc renderers. Operations that declare
+// a curl code sample (RouteOptions.CodeSamples) keep theirs.
+//
+// Example:
+//
+//	router.WithCurlSamples(true)
+//	router.ServeOpenAPI("/openapi.json")
+func (r *Router) WithCurlSamples(enabled bool) *Router {
+	r.curlSamples = enabled
+	return r
+}
+
+// CurlExamples returns a ready-to-run curl command per operation, sorted
+// by path.
+//
+// Commands use the URL of the first server (http://localhost:8080 if
+// none), required query parameters and headers, declared examples of
+// parameters ({name} placeholders for path parameters without one), an
+// example body built from the request body schema, and authentication
+// placeholders read from environment variables: $TOKEN for bearer tokens,
+// $USERNAME and $PASSWORD for basic authentication, and $API_KEY for
+// API keys.
+//
+// Example:
+//
+//	// curl -X POST 'http://localhost:8080/users' \
+//	//   -H "Authorization: Bearer $TOKEN" \
+//	//   -H 'Content-Type: application/json' \
+//	//   -d '{
+//	//   "name": "string"
+//	// }'
+func (doc *OpenAPI) CurlExamples() []CurlExample {
+	baseURL := doc.collectionVariables(nil)[0][1]
+
+	requests := doc.collectionRequests()
+	examples := make([]CurlExample, 0, len(requests))
+	for _, req := range requests {
+		examples = append(examples, CurlExample{
+			Method:  req.method,
+			Path:    req.openAPIPath,
+			Summary: req.name,
+			Command: req.curl(baseURL),
+		})
+	}
+	return examples
+}
+
+// addCurlSamples documents the curl command of each operation in its
+// x-codeSamples extension.
+func addCurlSamples(doc *OpenAPI) {
+	for _, example := range doc.CurlExamples() {
+		item := doc.Paths[example.Path]
+		op := pathItemOperation(&item, example.Method)
+		if op == nil {
+			continue
+		}
+		if op.Extensions == nil {
+			op.Extensions = make(map[string]any)
+		}
+		samples, ok := op.Extensions["x-codeSamples"].([]CodeSample)
+		if _, exists := op.Extensions["x-codeSamples"]; exists && !ok {
+			continue // Custom x-codeSamples extension.
+		}
+		if slices.ContainsFunc(samples, func(s CodeSample) bool { return strings.EqualFold(s.Lang, "curl") }) {
+			continue
+		}
+		op.Extensions["x-codeSamples"] = append(slices.Clip(samples), CodeSample{Lang: "curl", Source: example.Command})
+	}
+}
+
+// curl returns the curl command of the request.
+func (r *collectionRequest) curl(baseURL string) string {
+	path := r.path
+	for _, p := range r.pathParams {
+		value := p.value
+		if value == "" {
+			value = "{" + p.name + "}"
+		}
+		path = strings.Replace(path, ":"+p.name, value, 1)
+	}
+	url := baseURL + path
+	sep := "?"
+	for _, q := range r.query {
+		if !q.disabled {
+			url += sep + q.name + "=" + q.value
+			sep = "&"
+		}
+	}
+
+	var args []string
+	switch r.method {
+	case http.MethodGet:
+		args = append(args, "curl "+shellQuote(url))
+	case http.MethodHead:
+		args = append(args, "curl -I "+shellQuote(url))
+	default:
+		args = append(args, "curl -X "+r.method+" "+shellQuote(url))
+	}
+
+	if r.auth != nil {
+		switch r.auth.kind {
+		case "basic":
+			args = append(args, `-u "`+curlAuthVariables[collectionVarUsername]+":"+curlAuthVariables[collectionVarPassword]+`"`)
+		case "apikey":
+			if r.auth.in == "header" {
+				args = append(args, `-H "`+r.auth.name+": "+curlAuthVariables[collectionVarAPIKey]+`"`)
+			} else {
+				args[0] = strings.TrimSuffix(args[0], "'") + sep + r.auth.name + `='"` + curlAuthVariables[collectionVarAPIKey] + `"`
+			}
+		default:
+			args = append(args, `-H "Authorization: Bearer `+curlAuthVariables[collectionVarToken]+`"`)
+		}
+	}
+	for _, h := range r.headers {
+		if !h.disabled {
+			args = append(args, "-H "+shellQuote(h.name+": "+h.value))
+		}
+	}
+
+	switch r.bodyMode {
+	case "json", "raw":
+		args = append(args, "-H "+shellQuote("Content-Type: "+r.contentType), "-d "+shellQuote(r.bodyRaw))
+	case "urlencoded":
+		for _, f := range r.bodyFields {
+			args = append(args, "--data-urlencode "+shellQuote(f.name+"="+f.value))
+		}
+	case "formdata":
+		for _, f := range r.bodyFields {
+			if f.file {
+				args = append(args, "-F "+shellQuote(f.name+"=@"+f.name))
+			} else {
+				args = append(args, "-F "+shellQuote(f.name+"="+f.value))
+			}
+		}
+	}
+
+	return strings.Join(args, " \\\n  ")
+}
+
+// shellQuote quotes s for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
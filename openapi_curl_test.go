@@ -0,0 +1,104 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRouter_CurlExamples(t *testing.T) {
+	examples, err := collectionRouter().CurlExamples()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(examples) != 2 {
+		t.Fatalf("expected 2 examples, got %+v", examples)
+	}
+
+	create, get := examples[0], examples[1]
+	if create.Method != http.MethodPost || create.Path != "/users" {
+		t.Errorf("unexpected example: %+v", create)
+	}
+	wantCreate := "curl -X POST 'https://staging.example.com/v1/users' \\\n" +
+		"  -H 'Content-Type: application/json' \\\n" +
+		"  -d '{\n  \"email\": \"string\",\n  \"name\": \"string\"\n}'"
+	if create.Command != wantCreate {
+		t.Errorf("unexpected command:\n%s\nwant:\n%s", create.Command, wantCreate)
+	}
+
+	if get.Path != "/users/{id}" || get.Summary != "Get user" {
+		t.Errorf("unexpected example: %+v", get)
+	}
+	wantGet := "curl 'https://staging.example.com/v1/users/{id}' \\\n" +
+		"  -H \"Authorization: Bearer $TOKEN\" \\\n" +
+		"  -H 'X-Request-ID: '"
+	if get.Command != wantGet {
+		t.Errorf("unexpected command:\n%s\nwant:\n%s", get.Command, wantGet)
+	}
+}
+
+func TestOpenAPI_CurlExamplesAuth(t *testing.T) {
+	doc := &OpenAPI{
+		Paths: map[string]PathItem{
+			"/reports/{year}": {
+				Get: &Operation{
+					Security: []SecurityRequirement{{"key": {}}},
+					Parameters: []Parameter{
+						{Name: "year", In: "path", Required: true, Schema: &Schema{Type: "integer", Example: 2025}},
+						{Name: "format", In: "query", Required: true, Schema: &Schema{Type: "string", Enum: []any{"csv", "pdf"}}},
+					},
+				},
+				Post: &Operation{
+					Security: []SecurityRequirement{{"basic": {}}},
+					RequestBody: &RequestBody{Content: map[string]MediaType{
+						MIMEApplicationForm: {Example: map[string]any{"title": "it's done"}},
+					}},
+				},
+			},
+		},
+		Components: &Components{SecuritySchemes: map[string]SecurityScheme{
+			"key":   {Type: "apiKey", Name: "api_key", In: "query"},
+			"basic": {Type: "http", Scheme: "basic"},
+		}},
+	}
+
+	examples := doc.CurlExamples()
+	if len(examples) != 2 {
+		t.Fatalf("expected 2 examples, got %+v", examples)
+	}
+	if want := `curl 'http://localhost:8080/reports/2025?format=csv&api_key='"$API_KEY"`; examples[0].Command != want {
+		t.Errorf("unexpected command:\n%s\nwant:\n%s", examples[0].Command, want)
+	}
+	want := "curl -X POST 'http://localhost:8080/reports/{year}' \\\n" +
+		"  -u \"$USERNAME:$PASSWORD\" \\\n" +
+		"  --data-urlencode 'title=it'\\''s done'"
+	if examples[1].Command != want {
+		t.Errorf("unexpected command:\n%s\nwant:\n%s", examples[1].Command, want)
+	}
+}
+
+func TestOpenAPI_CurlSamples(t *testing.T) {
+	r := collectionRouter().WithCurlSamples(true)
+	r.HandleWithOptions(http.MethodDelete, "/users/:id", func(c *Context) error { return nil }, &RouteOptions{
+		CodeSamples: []CodeSample{{Lang: "curl", Source: "curl -X DELETE https://api.example.com/users/42"}},
+	})
+
+	doc, err := r.GenerateOpenAPI(Info{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	samples, ok := doc.Paths["/users/{id}"].Get.Extensions["x-codeSamples"].([]CodeSample)
+	if !ok || len(samples) != 1 || samples[0].Lang != "curl" || !strings.HasPrefix(samples[0].Source, "curl 'https://staging.example.com/v1/users/{id}'") {
+		t.Errorf("unexpected code samples: %+v", doc.Paths["/users/{id}"].Get.Extensions)
+	}
+
+	samples, ok = doc.Paths["/users/{id}"].Delete.Extensions["x-codeSamples"].([]CodeSample)
+	if !ok || len(samples) != 1 || samples[0].Source != "curl -X DELETE https://api.example.com/users/42" {
+		t.Errorf("expected the declared curl sample to be kept, got %+v", samples)
+	}
+}
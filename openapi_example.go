@@ -0,0 +1,156 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// maxExampleDepth bounds the nesting of generated examples.
+const maxExampleDepth = 8
+
+// schemaExample returns an example value of the schema, for the request
+// examples of collections and code samples.
+//
+// The example, default or first enum value of the schema is used when set;
+// otherwise a placeholder value is built from the type and format.
+// References to components are resolved; read-only properties are left out.
+func (doc *OpenAPI) schemaExample(s *Schema) any {
+	return doc.exampleValue(s, nil)
+}
+
+// exampleValue builds the example of s; refs holds the references being
+// expanded, to stop on recursive schemas.
+//
+//nolint:gocognit,gocyclo,cyclop // Example generation follows the schema keywords.
+func (doc *OpenAPI) exampleValue(s *Schema, refs []string) any {
+	if s == nil || len(refs) > maxExampleDepth {
+		return nil
+	}
+	switch {
+	case s.Example != nil:
+		return s.Example
+	case s.Default != nil:
+		return s.Default
+	case len(s.Enum) > 0:
+		return s.Enum[0]
+	}
+
+	if s.Ref != "" {
+		if slices.Contains(refs, s.Ref) {
+			return nil
+		}
+		return doc.exampleValue(doc.resolveSchemaRef(s.Ref), append(slices.Clip(refs), s.Ref))
+	}
+	if len(s.AllOf) > 0 {
+		merged := make(map[string]any)
+		for _, sub := range s.AllOf {
+			if obj, ok := doc.exampleValue(sub, refs).(map[string]any); ok {
+				maps.Copy(merged, obj)
+			}
+		}
+		return merged
+	}
+	for _, alternatives := range [][]*Schema{s.OneOf, s.AnyOf} {
+		for _, alt := range alternatives {
+			if alt != nil && alt.Type != "null" {
+				return doc.exampleValue(alt, refs)
+			}
+		}
+	}
+
+	switch s.Type {
+	case schemaTypeObject, "":
+		if s.Type == "" && len(s.Properties) == 0 {
+			return nil
+		}
+		obj := make(map[string]any, len(s.Properties))
+		for name, prop := range s.Properties {
+			if prop == nil || prop.ReadOnly {
+				continue
+			}
+			obj[name] = doc.exampleValue(prop, refs)
+		}
+		return obj
+	case "array":
+		if s.Items == nil {
+			return []any{}
+		}
+		return []any{doc.exampleValue(s.Items, refs)}
+	case schemaTypeInteger:
+		if s.Minimum != nil {
+			return int64(*s.Minimum)
+		}
+		return 0
+	case "number":
+		if s.Minimum != nil {
+			return *s.Minimum
+		}
+		return 0.0
+	case "boolean":
+		return false
+	case "null":
+		return nil
+	default:
+		return stringExample(s.Format)
+	}
+}
+
+// resolveSchemaRef returns the component schema of a local reference
+// ("#/components/schemas/Name"), or nil.
+func (doc *OpenAPI) resolveSchemaRef(ref string) *Schema {
+	name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+	if !ok || doc.Components == nil {
+		return nil
+	}
+	return doc.Components.Schemas[name]
+}
+
+// stringExample returns a placeholder string for a string format.
+func stringExample(format string) string {
+	switch format {
+	case "date-time":
+		return "2025-01-01T00:00:00Z"
+	case "date":
+		return "2025-01-01"
+	case "time":
+		return "00:00:00"
+	case "email":
+		return "user@example.com"
+	case "uuid":
+		return "00000000-0000-0000-0000-000000000000"
+	case "uri", "url":
+		return "https://example.com"
+	case "ipv4":
+		return "192.0.2.1"
+	case "ipv6":
+		return "2001:db8::1"
+	case "binary":
+		return ""
+	default:
+		return schemaTypeString
+	}
+}
+
+// exampleString formats an example value as a parameter value.
+func exampleString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []any:
+		values := make([]string, len(v))
+		for i, item := range v {
+			values[i] = exampleString(item)
+		}
+		return strings.Join(values, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -6,6 +6,7 @@ package fursy
 
 import (
 	"encoding/json/v2"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -193,6 +194,24 @@ func TestOpenAPI_HandleWithOptions(t *testing.T) {
 	}
 }
 
+// TestOpenAPI_PublicRoute tests documenting anonymous access.
+func TestOpenAPI_PublicRoute(t *testing.T) {
+	router := New()
+	router.HandleWithOptions(http.MethodGet, "/health", func(_ *Context) error {
+		return nil
+	}, &RouteOptions{Public: true})
+
+	doc, err := router.GenerateOpenAPI(Info{Title: "Test", Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI failed: %v", err)
+	}
+
+	op := doc.Paths["/health"].Get
+	if len(op.Security) != 1 || len(op.Security[0]) != 0 {
+		t.Errorf("Expected security [{}], got %v", op.Security)
+	}
+}
+
 func TestOpenAPI_PathConversion(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -631,3 +650,383 @@ func TestRouter_ServeOpenAPI_DefaultInfo(t *testing.T) {
 		t.Errorf("Expected default version '1.0.0', got %s", doc.Info.Version)
 	}
 }
+
+// TestOpenAPI_GenericRouteTypes tests that type-safe routes document their body types.
+func TestOpenAPI_GenericRouteTypes(t *testing.T) {
+	type createUser struct {
+		Name string `json:"name"`
+	}
+	type user struct {
+		ID int `json:"id"`
+	}
+
+	router := New()
+	POST[createUser, user](router, "/users", func(c *Box[createUser, user]) error {
+		return nil
+	})
+	GET[Empty, user](router, "/users/:id", func(c *Box[Empty, user]) error {
+		return nil
+	})
+
+	doc, err := router.GenerateOpenAPI(Info{Title: "Test", Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI failed: %v", err)
+	}
+
+	post := doc.Paths["/users"].Post
+	if post.RequestBody == nil || post.RequestBody.Content["application/json"].Schema.Properties["name"] == nil {
+		t.Errorf("expected request body schema for POST /users, got %+v", post.RequestBody)
+	}
+	if post.Responses["200"].Content["application/json"].Schema == nil {
+		t.Error("expected response schema for POST /users")
+	}
+
+	get := doc.Paths["/users/{id}"].Get
+	if get.RequestBody != nil {
+		t.Error("expected no request body for Empty request type")
+	}
+	if get.Responses["200"].Content["application/json"].Schema.Properties["id"] == nil {
+		t.Error("expected response schema for GET /users/{id}")
+	}
+}
+
+// TestOpenAPI_GenericRouteURLFields tests that path and query tagged fields
+// of request types are documented as parameters, not as the request body.
+func TestOpenAPI_GenericRouteURLFields(t *testing.T) {
+	type getUser struct {
+		ID     int      `path:"id"`
+		Fields []string `query:"fields"`
+		Locale string   `query:"locale" validate:"required"`
+	}
+	type updateUser struct {
+		ID   int    `path:"id"`
+		Name string `json:"name"`
+	}
+	type user struct {
+		ID int `json:"id"`
+	}
+
+	router := New()
+	GET[getUser, user](router, "/users/:id", func(c *Box[getUser, user]) error {
+		return nil
+	})
+	PUT[updateUser, user](router, "/users/:id", func(c *Box[updateUser, user]) error {
+		return nil
+	})
+
+	doc, err := router.GenerateOpenAPI(Info{Title: "Test", Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI failed: %v", err)
+	}
+
+	get := doc.Paths["/users/{id}"].Get
+	if get.RequestBody != nil {
+		t.Error("expected no request body for request type with only URL fields")
+	}
+	if len(get.Parameters) != 3 {
+		t.Fatalf("expected 3 parameters, got %+v", get.Parameters)
+	}
+	want := []struct {
+		name, in, typ string
+		required      bool
+	}{
+		{"id", "path", "integer", true},
+		{"fields", "query", "array", false},
+		{"locale", "query", "string", true},
+	}
+	for i, w := range want {
+		p := get.Parameters[i]
+		if p.Name != w.name || p.In != w.in || p.Schema.Type != w.typ || p.Required != w.required {
+			t.Errorf("parameter %d: expected %+v, got %+v (schema %+v)", i, w, p, p.Schema)
+		}
+	}
+
+	put := doc.Paths["/users/{id}"].Put
+	if put.RequestBody == nil {
+		t.Fatal("expected request body for PUT /users/{id}")
+	}
+	schema := put.RequestBody.Content["application/json"].Schema
+	if schema.Properties["name"] == nil {
+		t.Errorf("expected name in request body schema, got %+v", schema.Properties)
+	}
+	if _, ok := schema.Properties["ID"]; ok {
+		t.Error("expected path field to be excluded from request body schema")
+	}
+	if len(put.Parameters) != 1 || put.Parameters[0].Name != "id" {
+		t.Errorf("expected id path parameter, got %+v", put.Parameters)
+	}
+}
+
+// TestOpenAPI_GenericRouteFiles tests that request types with file fields
+// are documented as multipart/form-data.
+func TestOpenAPI_GenericRouteFiles(t *testing.T) {
+	type uploadDocuments struct {
+		ID     int                     `path:"id"`
+		Title  string                  `form:"title" validate:"required"`
+		Cover  *multipart.FileHeader   `form:"cover" validate:"required"`
+		Extras []*multipart.FileHeader `form:"extras"`
+	}
+
+	router := New()
+	POST[uploadDocuments, Empty](router, "/users/:id/documents", func(c *Box[uploadDocuments, Empty]) error {
+		return nil
+	})
+
+	doc, err := router.GenerateOpenAPI(Info{Title: "Test", Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI failed: %v", err)
+	}
+
+	post := doc.Paths["/users/{id}/documents"].Post
+	if post.RequestBody == nil {
+		t.Fatal("expected request body")
+	}
+	if _, ok := post.RequestBody.Content["application/json"]; ok {
+		t.Error("expected only multipart/form-data content")
+	}
+	schema := post.RequestBody.Content["multipart/form-data"].Schema
+	if schema == nil {
+		t.Fatal("expected multipart/form-data schema")
+	}
+
+	if s := schema.Properties["cover"]; s == nil || s.Type != "string" || s.Format != "binary" {
+		t.Errorf("expected binary cover, got %+v", s)
+	}
+	if s := schema.Properties["extras"]; s == nil || s.Type != "array" || s.Items.Format != "binary" {
+		t.Errorf("expected array of binary extras, got %+v", s)
+	}
+	if s := schema.Properties["title"]; s == nil || s.Type != "string" {
+		t.Errorf("expected string title, got %+v", s)
+	}
+	if _, ok := schema.Properties["id"]; ok {
+		t.Error("expected path field to be excluded")
+	}
+	if len(schema.Required) != 2 || schema.Required[0] != "title" || schema.Required[1] != "cover" {
+		t.Errorf("expected title and cover required, got %v", schema.Required)
+	}
+}
+
+// TestOpenAPI_ResponseHeaders tests documenting declared response headers.
+func TestOpenAPI_ResponseHeaders(t *testing.T) {
+	router := New()
+	router.HandleWithOptions(http.MethodPost, "/users", func(_ *Context) error {
+		return nil
+	}, &RouteOptions{
+		ResponseHeaders: []RouteHeader{
+			{Name: "X-RateLimit-Remaining", Description: "Requests left", Type: reflect.TypeFor[int]()},
+		},
+		Responses: map[int]RouteResponse{
+			201: {Description: "Created", Headers: []RouteHeader{{Name: "Location", Required: true}}},
+			409: {Description: "Conflict", Type: reflect.TypeFor[Problem](), ContentType: "application/problem+json"},
+		},
+	})
+	router.HandleWithOptions(http.MethodGet, "/users", func(_ *Context) error {
+		return nil
+	}, &RouteOptions{
+		ResponseHeaders: []RouteHeader{{Name: "X-Total-Count", Type: reflect.TypeFor[int]()}},
+	})
+
+	doc, err := router.GenerateOpenAPI(Info{Title: "Test", Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI failed: %v", err)
+	}
+
+	created := doc.Paths["/users"].Post.Responses["201"]
+	if created.Content != nil {
+		t.Errorf("expected no content for 201 without type, got %+v", created.Content)
+	}
+	if h, ok := created.Headers["Location"]; !ok || !h.Required || h.Schema.Type != "string" {
+		t.Errorf("expected required string Location header, got %+v", created.Headers)
+	}
+	if h, ok := created.Headers["X-RateLimit-Remaining"]; !ok || h.Schema.Type != "integer" || h.Description != "Requests left" {
+		t.Errorf("expected integer X-RateLimit-Remaining header, got %+v", created.Headers)
+	}
+
+	if conflict := doc.Paths["/users"].Post.Responses["409"]; conflict.Headers != nil {
+		t.Errorf("expected no headers on error response, got %+v", conflict.Headers)
+	}
+
+	ok := doc.Paths["/users"].Get.Responses["200"]
+	if _, exists := ok.Headers["X-Total-Count"]; !exists {
+		t.Errorf("expected X-Total-Count on default 200 response, got %+v", ok.Headers)
+	}
+}
+
+func TestOpenAPI_OptionalAndMidPathParams(t *testing.T) {
+	router := New()
+	router.HandleWithOptions(http.MethodGet, "/users/:id/:action?", func(c *Context) error { return nil }, &RouteOptions{
+		OperationID: "userAction",
+		Parameters: []RouteParameter{
+			{Name: "id", In: "path", Required: true},
+			{Name: "action", In: "path", Required: true},
+		},
+	})
+	router.GET("/registry/*image/manifests/:ref", func(c *Context) error { return nil })
+
+	doc, err := router.GenerateOpenAPI(Info{Title: "Test", Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI failed: %v", err)
+	}
+
+	short, full := doc.Paths["/users/{id}"].Get, doc.Paths["/users/{id}/{action}"].Get
+	if short == nil || full == nil {
+		t.Fatalf("expected both optional parameter paths, got %v", doc.Paths)
+	}
+	if len(short.Parameters) != 1 || short.OperationID != "" {
+		t.Errorf("short path: expected only id and no operation ID, got %+v", short)
+	}
+	if len(full.Parameters) != 2 || full.OperationID != "userAction" {
+		t.Errorf("full path: expected id, action and operation ID, got %+v", full)
+	}
+
+	if _, ok := doc.Paths["/registry/{image}/manifests/{ref}"]; !ok {
+		t.Errorf("expected mid-path catch-all path, got %v", doc.Paths)
+	}
+}
+
+// TestOpenAPI_OPTIONSDocs tests documenting automatic OPTIONS responses.
+func TestOpenAPI_OPTIONSDocs(t *testing.T) {
+	r := New()
+	r.GET("/users/:id", func(c *Context) error { return nil })
+	r.PUT("/users/:id", func(c *Context) error { return nil })
+	r.GET("/health", func(c *Context) error { return nil })
+	r.OPTIONS("/health", func(c *Context) error { return nil })
+
+	doc, err := r.GenerateOpenAPI(Info{Title: "Test", Version: "1.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Paths["/users/{id}"].Options != nil {
+		t.Fatal("OPTIONS documented without WithOPTIONSDocs")
+	}
+
+	doc, err = r.WithOPTIONSDocs(true).GenerateOpenAPI(Info{Title: "Test", Version: "1.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := doc.Paths["/users/{id}"].Options
+	if op == nil {
+		t.Fatal("expected OPTIONS operation for /users/{id}")
+	}
+	allow := op.Responses["204"].Headers["Allow"].Schema
+	if allow == nil || len(allow.Enum) != 1 || allow.Enum[0] != "GET, OPTIONS, PUT" {
+		t.Errorf("Allow schema = %+v", allow)
+	}
+	if len(op.Parameters) != 1 || op.Parameters[0].Name != "id" || !op.Parameters[0].Required {
+		t.Errorf("parameters = %+v", op.Parameters)
+	}
+	// Explicit OPTIONS routes keep their own operation.
+	if health := doc.Paths["/health"].Options; health == nil || health.Summary == "Allowed methods" {
+		t.Errorf("explicit OPTIONS operation replaced: %+v", health)
+	}
+}
+
+// TestOpenAPI_QueryStyles tests the style and explode of query parameters.
+func TestOpenAPI_QueryStyles(t *testing.T) {
+	type pageQuery struct {
+		Size int `query:"size"`
+	}
+	type search struct {
+		Tags   []string          `query:"tag"`
+		IDs    []int             `query:"ids,comma"`
+		Filter map[string]string `query:"filter"`
+		Page   pageQuery         `query:"page"`
+		Q      string            `query:"q"`
+	}
+
+	router := New()
+	GET[search, Empty](router, "/search", func(c *Box[search, Empty]) error {
+		return nil
+	})
+
+	doc, err := router.GenerateOpenAPI(Info{Title: "Test", Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI failed: %v", err)
+	}
+
+	want := map[string]struct {
+		style   string
+		explode bool
+	}{
+		"tag":    {"form", true},
+		"ids":    {"form", false},
+		"filter": {"deepObject", true},
+		"page":   {"deepObject", true},
+		"q":      {"", false},
+	}
+	params := doc.Paths["/search"].Get.Parameters
+	if len(params) != len(want) {
+		t.Fatalf("expected %d parameters, got %+v", len(want), params)
+	}
+	for _, p := range params {
+		w := want[p.Name]
+		if p.Style != w.style || (p.Explode == nil) != (w.style == "") || (p.Explode != nil && *p.Explode != w.explode) {
+			t.Errorf("%s: style %q explode %v, want %+v", p.Name, p.Style, p.Explode, w)
+		}
+		if p.Name == "page" && p.Schema.Properties["size"] == nil {
+			t.Errorf("page schema = %+v", p.Schema)
+		}
+	}
+}
+
+// TestOpenAPI_Extensions tests code samples, external docs and extensions.
+func TestOpenAPI_Extensions(t *testing.T) {
+	r := New()
+	r.WithInfo(Info{
+		Title:      "Test",
+		Version:    "1.0.0",
+		Extensions: map[string]any{"x-logo": map[string]any{"url": "https://example.com/logo.png"}},
+	})
+	r.WithExternalDocs(ExternalDocs{URL: "https://docs.example.com"})
+	r.WithOpenAPIExtension("x-tagGroups", []any{map[string]any{"name": "Users"}})
+	r.HandleWithOptions(http.MethodGet, "/users", func(c *Context) error { return nil }, &RouteOptions{
+		ExternalDocs: &ExternalDocs{Description: "Guide", URL: "https://docs.example.com/users"},
+		CodeSamples:  []CodeSample{{Lang: "curl", Source: "curl https://api.example.com/users"}},
+		Extensions:   map[string]any{"x-kong-plugin-cors": map[string]any{"enabled": true}},
+	})
+
+	doc, err := r.GenerateOpenAPI(Info{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result struct {
+		Info         map[string]any                       `json:"info"`
+		ExternalDocs map[string]any                       `json:"externalDocs"`
+		TagGroups    []any                                `json:"x-tagGroups"`
+		Paths        map[string]map[string]map[string]any `json:"paths"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Info["title"] != "Test" || result.Info["x-logo"] == nil {
+		t.Errorf("info = %v", result.Info)
+	}
+	if result.ExternalDocs["url"] != "https://docs.example.com" || len(result.TagGroups) != 1 {
+		t.Errorf("document externalDocs = %v, x-tagGroups = %v", result.ExternalDocs, result.TagGroups)
+	}
+
+	op := result.Paths["/users"]["get"]
+	if docs, _ := op["externalDocs"].(map[string]any); docs["url"] != "https://docs.example.com/users" {
+		t.Errorf("operation externalDocs = %v", op["externalDocs"])
+	}
+	samples, _ := op["x-codeSamples"].([]any)
+	if len(samples) != 1 || samples[0].(map[string]any)["lang"] != "curl" {
+		t.Errorf("x-codeSamples = %v", op["x-codeSamples"])
+	}
+	if op["x-kong-plugin-cors"] == nil {
+		t.Errorf("missing operation extension: %v", op)
+	}
+
+	// Extension keys must start with "x-".
+	r.HandleWithOptions(http.MethodPost, "/users", func(c *Context) error { return nil }, &RouteOptions{
+		Extensions: map[string]any{"kong": true},
+	})
+	if _, err := r.GenerateOpenAPI(Info{}); err == nil {
+		t.Error("expected error for invalid extension key")
+	}
+}
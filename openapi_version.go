@@ -0,0 +1,237 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"fmt"
+	"slices"
+)
+
+// OpenAPIOption configures the generation of an OpenAPI document
+// (see Router.GenerateOpenAPI and Router.ServeOpenAPI).
+type OpenAPIOption func(*openAPIOptions)
+
+// openAPIOptions holds the OpenAPI generation options.
+type openAPIOptions struct {
+	version string
+}
+
+// validate checks the options.
+func (o *openAPIOptions) validate() error {
+	switch o.version {
+	case "", "3.1", "3.0":
+		return nil
+	default:
+		return fmt.Errorf("fursy: unsupported OpenAPI version %q (want \"3.1\" or \"3.0\")", o.version)
+	}
+}
+
+// WithVersion selects the OpenAPI version of the document: "3.1"
+// (default) or "3.0" for tools that do not support OpenAPI 3.1 yet.
+//
+// The 3.0 document is OpenAPI 3.0.3: nullable types use the nullable
+// keyword, numeric exclusiveMinimum and exclusiveMaximum become boolean
+// flags of minimum and maximum, null schemas of anyOf and oneOf make the
+// schema nullable, and the 3.1-only webhooks, info summary and license
+// identifier are left out.
+//
+// Example:
+//
+//	doc, err := router.GenerateOpenAPI(info, fursy.WithVersion("3.0"))
+func WithVersion(version string) OpenAPIOption {
+	return func(o *openAPIOptions) {
+		o.version = version
+	}
+}
+
+// downgradeOpenAPI30 converts a generated OpenAPI 3.1 document to OpenAPI 3.0.3.
+// Schemas are copied, as they may be shared with other documents.
+func downgradeOpenAPI30(doc *OpenAPI) {
+	doc.OpenAPI = "3.0.3"
+	doc.Webhooks = nil
+
+	// Info.summary and License.identifier are new in 3.1.
+	if doc.Info.Description == "" {
+		doc.Info.Description = doc.Info.Summary
+	}
+	doc.Info.Summary = ""
+	if doc.Info.License != nil {
+		license := *doc.Info.License
+		license.Identifier = ""
+		doc.Info.License = &license
+	}
+
+	for path, item := range doc.Paths {
+		for _, op := range []*Operation{item.Get, item.Post, item.Put, item.Delete, item.Patch, item.Head, item.Options, item.Trace} {
+			if op != nil {
+				downgradeOperation30(op)
+			}
+		}
+		item.Parameters = downgradeParameters30(item.Parameters)
+		doc.Paths[path] = item
+	}
+
+	if c := doc.Components; c != nil {
+		for name, schema := range c.Schemas {
+			c.Schemas[name] = downgradeSchema30(schema)
+		}
+		for name, resp := range c.Responses {
+			c.Responses[name] = downgradeResponse30(resp)
+		}
+		for name, param := range c.Parameters {
+			c.Parameters[name] = downgradeParameters30([]Parameter{param})[0]
+		}
+		for name, body := range c.RequestBodies {
+			body.Content = downgradeContent30(body.Content)
+			c.RequestBodies[name] = body
+		}
+		c.Headers = downgradeHeaders30(c.Headers)
+	}
+}
+
+// downgradeOperation30 converts the schemas of an operation to OpenAPI 3.0.
+func downgradeOperation30(op *Operation) {
+	op.Parameters = downgradeParameters30(op.Parameters)
+	if op.RequestBody != nil {
+		body := *op.RequestBody
+		body.Content = downgradeContent30(body.Content)
+		op.RequestBody = &body
+	}
+	if op.Responses != nil {
+		responses := make(map[string]Response, len(op.Responses))
+		for status, resp := range op.Responses {
+			responses[status] = downgradeResponse30(resp)
+		}
+		op.Responses = responses
+	}
+}
+
+// downgradeParameters30 returns a copy of params with OpenAPI 3.0 schemas.
+func downgradeParameters30(params []Parameter) []Parameter {
+	if params == nil {
+		return nil
+	}
+	params = slices.Clone(params)
+	for i := range params {
+		params[i].Schema = downgradeSchema30(params[i].Schema)
+	}
+	return params
+}
+
+// downgradeResponse30 returns a copy of resp with OpenAPI 3.0 schemas.
+func downgradeResponse30(resp Response) Response {
+	resp.Content = downgradeContent30(resp.Content)
+	resp.Headers = downgradeHeaders30(resp.Headers)
+	return resp
+}
+
+// downgradeContent30 returns a copy of content with OpenAPI 3.0 schemas.
+func downgradeContent30(content map[string]MediaType) map[string]MediaType {
+	if content == nil {
+		return nil
+	}
+	converted := make(map[string]MediaType, len(content))
+	for mt, media := range content {
+		media.Schema = downgradeSchema30(media.Schema)
+		converted[mt] = media
+	}
+	return converted
+}
+
+// downgradeHeaders30 returns a copy of headers with OpenAPI 3.0 schemas.
+func downgradeHeaders30(headers map[string]Header) map[string]Header {
+	if headers == nil {
+		return nil
+	}
+	converted := make(map[string]Header, len(headers))
+	for name, header := range headers {
+		header.Schema = downgradeSchema30(header.Schema)
+		converted[name] = header
+	}
+	return converted
+}
+
+// downgradeSchema30 returns an OpenAPI 3.0 copy of the OpenAPI 3.1 schema s.
+func downgradeSchema30(s *Schema) *Schema {
+	if s == nil {
+		return nil
+	}
+	schema := *s
+	schema.openAPI30 = true
+
+	// Numeric exclusive bounds become boolean flags of the bounds.
+	if bound, ok := schemaNumber(schema.ExclusiveMinimum); ok {
+		schema.Minimum, schema.ExclusiveMinimum = &bound, true
+	}
+	if bound, ok := schemaNumber(schema.ExclusiveMaximum); ok {
+		schema.Maximum, schema.ExclusiveMaximum = &bound, true
+	}
+
+	// The null type does not exist: null alternatives make the schema nullable.
+	if schema.Type == "null" {
+		schema.Type, schema.Nullable = "", true
+	}
+	schema.AnyOf = downgradeAlternatives30(&schema, schema.AnyOf)
+	schema.OneOf = downgradeAlternatives30(&schema, schema.OneOf)
+	schema.AllOf = downgradeSchemas30(schema.AllOf)
+
+	schema.Items = downgradeSchema30(schema.Items)
+	if schema.Properties != nil {
+		properties := make(map[string]*Schema, len(schema.Properties))
+		for name, property := range schema.Properties {
+			properties[name] = downgradeSchema30(property)
+		}
+		schema.Properties = properties
+	}
+	if additional, ok := schema.AdditionalProperties.(*Schema); ok {
+		schema.AdditionalProperties = downgradeSchema30(additional)
+	}
+	return &schema
+}
+
+// downgradeAlternatives30 converts the anyOf or oneOf alternatives of
+// schema, removing null alternatives and making schema nullable instead.
+func downgradeAlternatives30(schema *Schema, alternatives []*Schema) []*Schema {
+	converted := make([]*Schema, 0, len(alternatives))
+	for _, alt := range alternatives {
+		if alt != nil && alt.Type == "null" && alt.Ref == "" {
+			schema.Nullable = true
+			continue
+		}
+		converted = append(converted, downgradeSchema30(alt))
+	}
+	if len(converted) == 0 {
+		return nil
+	}
+	return converted
+}
+
+// downgradeSchemas30 returns OpenAPI 3.0 copies of schemas.
+func downgradeSchemas30(schemas []*Schema) []*Schema {
+	if schemas == nil {
+		return nil
+	}
+	converted := make([]*Schema, len(schemas))
+	for i, s := range schemas {
+		converted[i] = downgradeSchema30(s)
+	}
+	return converted
+}
+
+// schemaNumber returns the value of a numeric schema keyword.
+func schemaNumber(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"encoding/json/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// versionedRouter returns a router using OpenAPI 3.1 features.
+func versionedRouter() *Router {
+	r := New()
+	r.WithInfo(Info{
+		Title:   "Test",
+		Version: "1.0.0",
+		Summary: "Test API",
+		License: &License{Name: "MIT", Identifier: "MIT"},
+	})
+	r.WithWebhook("order.created", PathItem{
+		Post: &Operation{Responses: map[string]Response{"200": {Description: "OK"}}},
+	})
+	r.HandleWithOptions(http.MethodGet, "/items", func(c *Context) error { return nil }, &RouteOptions{
+		Parameters: []RouteParameter{{Name: "limit", In: "query"}},
+		Responses: map[int]RouteResponse{
+			http.StatusOK: {Description: "OK", ContentType: MIMEApplicationJSON},
+		},
+	})
+	return r
+}
+
+// itemSchema is a schema using OpenAPI 3.1 keywords.
+func itemSchema() *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"name":  {Type: "string", Nullable: true},
+			"price": {Type: "number", ExclusiveMinimum: 0},
+			"owner": {OneOf: []*Schema{{Ref: "#/components/schemas/User"}, {Type: "null"}}},
+		},
+	}
+}
+
+func TestOpenAPI_Version31(t *testing.T) {
+	doc, err := versionedRouter().GenerateOpenAPI(Info{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc.Components.Schemas["Item"] = itemSchema()
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result struct {
+		OpenAPI    string         `json:"openapi"`
+		Webhooks   map[string]any `json:"webhooks"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]any `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.OpenAPI != "3.1.0" || result.Webhooks["order.created"] == nil {
+		t.Errorf("openapi = %q, webhooks = %v", result.OpenAPI, result.Webhooks)
+	}
+	name := result.Components.Schemas["Item"].Properties["name"]
+	if typ, _ := name["type"].([]any); len(typ) != 2 || typ[0] != "string" || typ[1] != "null" || name["nullable"] == true {
+		t.Errorf("nullable name = %v", name)
+	}
+	if price := result.Components.Schemas["Item"].Properties["price"]; price["exclusiveMinimum"] != float64(0) {
+		t.Errorf("price = %v", price)
+	}
+}
+
+func TestOpenAPI_Version30(t *testing.T) {
+	r := versionedRouter()
+	r.WithWebhook("order.created", PathItem{})
+
+	doc, err := r.GenerateOpenAPI(Info{}, WithVersion("3.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.OpenAPI != "3.0.3" || doc.Webhooks != nil {
+		t.Errorf("openapi = %q, webhooks = %v", doc.OpenAPI, doc.Webhooks)
+	}
+	if doc.Info.Summary != "" || doc.Info.Description != "Test API" || doc.Info.License.Identifier != "" {
+		t.Errorf("info = %+v", doc.Info)
+	}
+	if r.info.License.Identifier != "MIT" {
+		t.Error("router info modified")
+	}
+
+	item := downgradeSchema30(itemSchema())
+	data, err := json.Marshal(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result struct {
+		Properties map[string]map[string]any `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	if name := result.Properties["name"]; name["type"] != "string" || name["nullable"] != true {
+		t.Errorf("nullable name = %v", name)
+	}
+	if price := result.Properties["price"]; price["minimum"] != float64(0) || price["exclusiveMinimum"] != true {
+		t.Errorf("price = %v", price)
+	}
+	owner := result.Properties["owner"]
+	if oneOf, _ := owner["oneOf"].([]any); len(oneOf) != 1 || owner["nullable"] != true {
+		t.Errorf("owner = %v", owner)
+	}
+}
+
+func TestOpenAPI_UnsupportedVersion(t *testing.T) {
+	if _, err := New().GenerateOpenAPI(Info{}, WithVersion("2.0")); err == nil {
+		t.Error("expected error for unsupported version")
+	}
+}
+
+func TestServeOpenAPI_Version(t *testing.T) {
+	r := versionedRouter()
+	r.ServeOpenAPI("/openapi-3.0.json", WithVersion("3.0"))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi-3.0.json", http.NoBody))
+
+	var result map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result["openapi"] != "3.0.3" || result["webhooks"] != nil {
+		t.Errorf("openapi = %v, webhooks = %v", result["openapi"], result["webhooks"])
+	}
+}
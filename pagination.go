@@ -0,0 +1,375 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"math"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Default pagination limits used when PaginationConfig fields are zero.
+const (
+	defaultPageLimit = 20
+	defaultMaxLimit  = 100
+)
+
+// PageMeta describes the position of a page within a collection.
+//
+// It is embedded in Page[T], so its fields are flattened into the JSON
+// response next to the items.
+type PageMeta struct {
+	// Total is the total number of items in the collection.
+	// Use -1 when the total is unknown (e.g., cursor-based pagination).
+	Total int `json:"total"`
+
+	// Limit is the maximum number of items in the page.
+	Limit int `json:"limit,omitempty"`
+
+	// Offset is the number of items skipped (offset-based pagination).
+	Offset int `json:"offset,omitempty"`
+
+	// NextCursor is an opaque cursor for the next page (cursor-based pagination).
+	// Empty if this is the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+
+	// PrevCursor is an opaque cursor for the previous page (cursor-based pagination).
+	// Empty if this is the first page.
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// Page is a standard response envelope for paginated collections.
+//
+// Example output:
+//
+//	{
+//	  "items": [{"id": 1}, {"id": 2}],
+//	  "total": 42,
+//	  "limit": 2,
+//	  "offset": 0
+//	}
+//
+// Example:
+//
+//	fursy.GET[fursy.Empty, fursy.Page[User]](router, "/users", func(c *fursy.Box[fursy.Empty, fursy.Page[User]]) error {
+//	    p, err := c.Pagination()
+//	    if err != nil {
+//	        return c.Problem(fursy.ValidationProblem(err.(fursy.ValidationErrors)))
+//	    }
+//	    users, total := db.ListUsers(p.Limit, p.Offset)
+//	    c.SetPageLinks(p.Meta(total, ""))
+//	    return c.OK(fursy.NewPage(users, p.Meta(total, "")))
+//	})
+type Page[T any] struct {
+	// Items contains the items of the current page.
+	// Always encoded as an array (never null).
+	Items []T `json:"items"`
+
+	PageMeta
+}
+
+// NewPage creates a Page from items and pagination metadata.
+// A nil items slice is replaced with an empty slice so it encodes as [].
+func NewPage[T any](items []T, meta PageMeta) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return Page[T]{Items: items, PageMeta: meta}
+}
+
+// PaginationConfig configures query parameter parsing for Context.Pagination.
+type PaginationConfig struct {
+	// DefaultLimit is used when the "limit" query parameter is absent.
+	// Default: 20
+	DefaultLimit int
+
+	// MaxLimit is the maximum accepted value of the "limit" query parameter.
+	// Default: 100
+	MaxLimit int
+}
+
+// Pagination holds pagination parameters parsed from the query string.
+//
+// Offset-based (?limit=20&offset=40), page-based (?limit=20&page=3) and
+// cursor-based (?limit=20&cursor=abc) pagination are supported. Offset,
+// Page and Cursor are mutually exclusive.
+type Pagination struct {
+	// Limit is the requested page size.
+	Limit int
+
+	// Offset is the number of items to skip.
+	// For page-based requests it is computed as (Page-1)*Limit.
+	Offset int
+
+	// Page is the requested 1-based page number (0 if not page-based).
+	Page int
+
+	// Cursor is the opaque cursor from a previous page.
+	Cursor string
+}
+
+// Meta builds PageMeta for a response page from the parsed parameters.
+//
+// Example:
+//
+//	p, _ := c.Pagination()
+//	users, total := db.ListUsers(p.Limit, p.Offset)
+//	return c.OKPage(users, p.Meta(total, ""))
+func (p Pagination) Meta(total int, nextCursor string) PageMeta {
+	return PageMeta{
+		Total:      total,
+		Limit:      p.Limit,
+		Offset:     p.Offset,
+		NextCursor: nextCursor,
+	}
+}
+
+// Pagination parses and validates the "limit", "offset", "page" and "cursor" query parameters.
+//
+// Validation rules:
+//   - limit must be an integer between 1 and MaxLimit
+//   - offset must be a non-negative integer
+//   - page must be a positive integer
+//   - offset, page and cursor cannot be combined
+//
+// Returns ValidationErrors if any parameter is invalid, which can be
+// sent directly using ValidationProblem.
+//
+// Example:
+//
+//	// Request: /users?limit=10&offset=20
+//	p, err := c.Pagination()
+//	if err != nil {
+//	    return c.Problem(fursy.ValidationProblem(err.(fursy.ValidationErrors)))
+//	}
+//	// p.Limit == 10, p.Offset == 20
+//
+// With custom limits:
+//
+//	p, err := c.Pagination(fursy.PaginationConfig{DefaultLimit: 50, MaxLimit: 500})
+func (c *Context) Pagination(config ...PaginationConfig) (Pagination, error) {
+	var cfg PaginationConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	if cfg.DefaultLimit <= 0 {
+		cfg.DefaultLimit = defaultPageLimit
+	}
+	if cfg.MaxLimit <= 0 {
+		cfg.MaxLimit = defaultMaxLimit
+	}
+	if cfg.DefaultLimit > cfg.MaxLimit {
+		cfg.DefaultLimit = cfg.MaxLimit
+	}
+
+	p := Pagination{
+		Limit:  cfg.DefaultLimit,
+		Cursor: c.Query("cursor"),
+	}
+
+	var errs ValidationErrors
+
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		switch {
+		case err != nil:
+			errs.Add("limit", "integer", "limit must be an integer")
+		case limit < 1 || limit > cfg.MaxLimit:
+			errs.Add("limit", "range", "limit must be between 1 and "+strconv.Itoa(cfg.MaxLimit))
+		default:
+			p.Limit = limit
+		}
+	}
+
+	if raw := c.Query("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		switch {
+		case err != nil:
+			errs.Add("offset", "integer", "offset must be an integer")
+		case offset < 0:
+			errs.Add("offset", "min", "offset must not be negative")
+		default:
+			p.Offset = offset
+		}
+
+		if p.Cursor != "" {
+			errs.Add("cursor", "excluded_with", "cursor cannot be combined with offset")
+		}
+	}
+
+	if raw := c.Query("page"); raw != "" {
+		page, err := strconv.Atoi(raw)
+		switch {
+		case err != nil:
+			errs.Add("page", "integer", "page must be an integer")
+		case page < 1:
+			errs.Add("page", "min", "page must be at least 1")
+		case page-1 > math.MaxInt/p.Limit:
+			errs.Add("page", "max", "page is too large")
+		default:
+			p.Page = page
+			p.Offset = (page - 1) * p.Limit
+		}
+
+		switch {
+		case c.Query("offset") != "":
+			errs.Add("page", "excluded_with", "page cannot be combined with offset")
+		case p.Cursor != "":
+			errs.Add("cursor", "excluded_with", "cursor cannot be combined with page")
+		}
+	}
+
+	if !errs.IsEmpty() {
+		return Pagination{}, errs
+	}
+
+	return p, nil
+}
+
+// SetPageLinks sets the RFC 8288 Link header for a paginated response.
+//
+// Links are built from the current request URL, preserving all other
+// query parameters:
+//   - rel="next" when NextCursor is set, or when Offset+Limit < Total
+//   - rel="prev" when PrevCursor is set, or when Offset > 0
+//   - rel="first" for offset-based pagination
+//
+// Example header:
+//
+//	Link: </users?limit=10&offset=20>; rel="next", </users?limit=10&offset=0>; rel="prev", </users?limit=10&offset=0>; rel="first"
+func (c *Context) SetPageLinks(meta PageMeta) {
+	if link := buildPageLinks(c.Request.URL, meta); link != "" {
+		c.SetHeader("Link", link)
+	}
+}
+
+// OKPage sends a 200 OK JSON response with a pagination envelope and Link header.
+//
+// The items argument should be a slice; a nil slice is encoded as [].
+//
+// Example:
+//
+//	router.GET("/users", func(c *fursy.Context) error {
+//	    p, err := c.Pagination()
+//	    if err != nil {
+//	        return c.Problem(fursy.ValidationProblem(err.(fursy.ValidationErrors)))
+//	    }
+//	    users, total := db.ListUsers(p.Limit, p.Offset)
+//	    return c.OKPage(users, p.Meta(total, ""))
+//	})
+func (c *Context) OKPage(items any, meta PageMeta) error {
+	if items == nil {
+		items = []any{}
+	} else if v := reflect.ValueOf(items); v.Kind() == reflect.Slice && v.IsNil() {
+		items = []any{}
+	}
+
+	c.SetPageLinks(meta)
+
+	return c.JSON(http.StatusOK, struct {
+		Items any `json:"items"`
+		PageMeta
+	}{Items: items, PageMeta: meta})
+}
+
+// PageLink is a single pagination link (e.g., rel="next").
+type PageLink struct {
+	// Rel is the link relation: "next", "prev" or "first".
+	Rel string
+
+	// Href is the link target (path and query of the current request).
+	Href string
+}
+
+// PageLinks returns the pagination links for the given page, based on the
+// current request URL (all other query parameters are preserved).
+//
+// This is the data behind SetPageLinks, for response formats that embed
+// links in the body (e.g., HAL or JSON:API).
+//
+// Example:
+//
+//	for _, l := range c.PageLinks(meta) {
+//	    links[l.Rel] = l.Href
+//	}
+func (c *Context) PageLinks(meta PageMeta) []PageLink {
+	return pageLinks(c.Request.URL, meta)
+}
+
+// buildPageLinks builds the Link header value for the given page.
+func buildPageLinks(u *url.URL, meta PageMeta) string {
+	links := pageLinks(u, meta)
+	values := make([]string, len(links))
+	for i, l := range links {
+		values[i] = "<" + l.Href + `>; rel="` + l.Rel + `"`
+	}
+	return strings.Join(values, ", ")
+}
+
+// pageLinks builds the pagination links for the given page.
+func pageLinks(u *url.URL, meta PageMeta) []PageLink {
+	var links []PageLink
+
+	link := func(rel string, set func(q url.Values)) {
+		q := u.Query()
+		q.Del("page") // Links always use offset or cursor.
+		set(q)
+		ref := url.URL{Path: u.Path, RawQuery: q.Encode()}
+		links = append(links, PageLink{Rel: rel, Href: ref.String()})
+	}
+
+	limit := strconv.Itoa(meta.Limit)
+
+	// Cursor-based pagination.
+	if meta.NextCursor != "" || meta.PrevCursor != "" {
+		if meta.NextCursor != "" {
+			link("next", func(q url.Values) {
+				q.Del("offset")
+				q.Set("cursor", meta.NextCursor)
+				if meta.Limit > 0 {
+					q.Set("limit", limit)
+				}
+			})
+		}
+		if meta.PrevCursor != "" {
+			link("prev", func(q url.Values) {
+				q.Del("offset")
+				q.Set("cursor", meta.PrevCursor)
+				if meta.Limit > 0 {
+					q.Set("limit", limit)
+				}
+			})
+		}
+		return links
+	}
+
+	// Offset-based pagination requires a page size.
+	if meta.Limit <= 0 {
+		return nil
+	}
+
+	if meta.Total >= 0 && meta.Offset+meta.Limit < meta.Total {
+		link("next", func(q url.Values) {
+			q.Del("cursor")
+			q.Set("limit", limit)
+			q.Set("offset", strconv.Itoa(meta.Offset+meta.Limit))
+		})
+	}
+	if meta.Offset > 0 {
+		link("prev", func(q url.Values) {
+			q.Set("limit", limit)
+			q.Set("offset", strconv.Itoa(max(meta.Offset-meta.Limit, 0)))
+		})
+	}
+	link("first", func(q url.Values) {
+		q.Set("limit", limit)
+		q.Set("offset", "0")
+	})
+
+	return links
+}
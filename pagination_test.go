@@ -0,0 +1,209 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestPagination_Defaults tests default limit and offset.
+func TestPagination_Defaults(t *testing.T) {
+	c := newContext()
+	c.Request = httptest.NewRequest(http.MethodGet, "/users", http.NoBody)
+
+	p, err := c.Pagination()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Limit != 20 || p.Offset != 0 || p.Cursor != "" {
+		t.Errorf("unexpected pagination: %+v", p)
+	}
+}
+
+// TestPagination_Parse tests parsing of limit, offset and cursor.
+func TestPagination_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    Pagination
+		wantErr string
+	}{
+		{"offset", "?limit=10&offset=30", Pagination{Limit: 10, Offset: 30}, ""},
+		{"cursor", "?limit=5&cursor=abc", Pagination{Limit: 5, Cursor: "abc"}, ""},
+		{"limit too large", "?limit=1000", Pagination{}, "limit"},
+		{"limit zero", "?limit=0", Pagination{}, "limit"},
+		{"limit not integer", "?limit=ten", Pagination{}, "limit"},
+		{"negative offset", "?offset=-1", Pagination{}, "offset"},
+		{"offset with cursor", "?offset=10&cursor=abc", Pagination{}, "cursor"},
+		{"page", "?limit=10&page=3", Pagination{Limit: 10, Offset: 20, Page: 3}, ""},
+		{"page zero", "?page=0", Pagination{}, "page"},
+		{"page overflow", "?page=9223372036854775807&limit=10", Pagination{}, "page"},
+		{"page not integer", "?page=two", Pagination{}, "page"},
+		{"page with offset", "?page=2&offset=10", Pagination{}, "page"},
+		{"page with cursor", "?page=2&cursor=abc", Pagination{}, "cursor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newContext()
+			c.Request = httptest.NewRequest(http.MethodGet, "/users"+tt.query, http.NoBody)
+
+			p, err := c.Pagination()
+			if tt.wantErr != "" {
+				verrs, ok := err.(ValidationErrors)
+				if !ok {
+					t.Fatalf("expected ValidationErrors, got %v", err)
+				}
+				if _, ok := verrs.Fields()[tt.wantErr]; !ok {
+					t.Errorf("expected error for field %q, got %v", tt.wantErr, verrs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if p != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, p)
+			}
+		})
+	}
+}
+
+// TestPagination_CustomConfig tests custom default and max limits.
+func TestPagination_CustomConfig(t *testing.T) {
+	c := newContext()
+	c.Request = httptest.NewRequest(http.MethodGet, "/users?limit=300", http.NoBody)
+
+	p, err := c.Pagination(PaginationConfig{DefaultLimit: 50, MaxLimit: 500})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Limit != 300 {
+		t.Errorf("expected limit 300, got %d", p.Limit)
+	}
+}
+
+// TestContext_OKPage tests the pagination envelope and Link header.
+func TestContext_OKPage(t *testing.T) {
+	r := New()
+	r.GET("/users", func(c *Context) error {
+		p, err := c.Pagination()
+		if err != nil {
+			return err
+		}
+		return c.OKPage([]string{"a", "b"}, p.Meta(10, ""))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users?limit=2&offset=2&sort=name", http.NoBody)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var body struct {
+		Items  []string `json:"items"`
+		Total  int      `json:"total"`
+		Limit  int      `json:"limit"`
+		Offset int      `json:"offset"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(body.Items) != 2 || body.Total != 10 || body.Limit != 2 || body.Offset != 2 {
+		t.Errorf("unexpected body: %s", w.Body.String())
+	}
+
+	link := w.Header().Get("Link")
+	for _, want := range []string{
+		`</users?limit=2&offset=4&sort=name>; rel="next"`,
+		`</users?limit=2&offset=0&sort=name>; rel="prev"`,
+		`</users?limit=2&offset=0&sort=name>; rel="first"`,
+	} {
+		if !strings.Contains(link, want) {
+			t.Errorf("expected Link to contain %q, got %q", want, link)
+		}
+	}
+}
+
+// TestContext_OKPage_NilItems tests that nil items encode as an empty array.
+func TestContext_OKPage_NilItems(t *testing.T) {
+	r := New()
+	r.GET("/users", func(c *Context) error {
+		var items []string
+		return c.OKPage(items, PageMeta{Total: 0, Limit: 20})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users", http.NoBody)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if !strings.Contains(w.Body.String(), `"items":[]`) {
+		t.Errorf("expected empty items array, got %s", w.Body.String())
+	}
+	if link := w.Header().Get("Link"); strings.Contains(link, `rel="next"`) {
+		t.Errorf("expected no next link, got %q", link)
+	}
+}
+
+// TestPage_CursorLinks tests Link generation for cursor-based pagination.
+func TestPage_CursorLinks(t *testing.T) {
+	r := New()
+	GET[Empty, Page[int]](r, "/items", func(c *Box[Empty, Page[int]]) error {
+		meta := PageMeta{Total: -1, Limit: 3, NextCursor: "next123"}
+		c.SetPageLinks(meta)
+		return c.OK(NewPage([]int{1, 2, 3}, meta))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items?limit=3&cursor=abc", http.NoBody)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if link := w.Header().Get("Link"); link != `</items?cursor=next123&limit=3>; rel="next"` {
+		t.Errorf("unexpected Link header: %q", link)
+	}
+	if !strings.Contains(w.Body.String(), `"next_cursor":"next123"`) {
+		t.Errorf("expected next_cursor in body, got %s", w.Body.String())
+	}
+}
+
+// TestContext_PageLinks tests pagination links for body-embedded formats.
+func TestContext_PageLinks(t *testing.T) {
+	c := newContext()
+	c.Request = httptest.NewRequest(http.MethodGet, "/users?limit=10&offset=10", http.NoBody)
+
+	links := c.PageLinks(PageMeta{Total: 25, Limit: 10, Offset: 10})
+	want := []PageLink{
+		{Rel: "next", Href: "/users?limit=10&offset=20"},
+		{Rel: "prev", Href: "/users?limit=10&offset=0"},
+		{Rel: "first", Href: "/users?limit=10&offset=0"},
+	}
+	if len(links) != len(want) {
+		t.Fatalf("expected %d links, got %v", len(want), links)
+	}
+	for i := range want {
+		if links[i] != want[i] {
+			t.Errorf("link %d: expected %+v, got %+v", i, want[i], links[i])
+		}
+	}
+}
+
+// TestContext_PageLinks_PageBased tests that links for page-based requests use offsets.
+func TestContext_PageLinks_PageBased(t *testing.T) {
+	c := newContext()
+	c.Request = httptest.NewRequest(http.MethodGet, "/users?limit=10&page=2", http.NoBody)
+
+	links := c.PageLinks(PageMeta{Total: 25, Limit: 10, Offset: 10})
+	for _, l := range links {
+		if strings.Contains(l.Href, "page=") {
+			t.Errorf("expected page parameter to be replaced, got %s", l.Href)
+		}
+	}
+}
@@ -0,0 +1,677 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrUnsupportedPatchType is returned by Context.ApplyPatch for request
+// bodies that are neither a JSON Merge Patch nor a JSON Patch.
+var ErrUnsupportedPatchType = errors.New("fursy: unsupported patch media type")
+
+// PatchOperation is an operation of a JSON Patch document (RFC 6902).
+type PatchOperation struct {
+	// Op is the operation: "add", "remove", "replace", "move", "copy" or "test".
+	Op string `json:"op"`
+
+	// Path is the JSON Pointer to the target location (e.g., "/address/city").
+	Path string `json:"path"`
+
+	// From is the JSON Pointer to the source location of "move" and "copy".
+	From string `json:"from,omitempty"`
+
+	// Value is the value of "add", "replace" and "test".
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// ApplyMergePatch applies a JSON Merge Patch (RFC 7386) to dst, a pointer
+// to a JSON-encodable value: members of the patch replace those of dst,
+// nested objects are merged recursively and null members are removed
+// (reset to their zero value).
+//
+// The patched document is validated against the type of dst: members that
+// do not match a field and values of the wrong type are returned as
+// ValidationErrors, and dst is left unchanged.
+//
+// Example:
+//
+//	user := User{Name: "Alice", Email: "alice@example.com"}
+//	err := fursy.ApplyMergePatch(&user, []byte(`{"email":"alice@example.org"}`))
+//	// user.Name == "Alice", user.Email == "alice@example.org"
+func ApplyMergePatch(dst any, patch []byte) error {
+	doc, err := patchDocument(dst)
+	if err != nil {
+		return err
+	}
+
+	var p any
+	if err := decodeJSONNumber(patch, &p); err != nil {
+		return ValidationErrors{{Tag: "json", Message: "patch must be valid JSON"}}
+	}
+
+	return storePatchDocument(dst, mergePatch(doc, p))
+}
+
+// ApplyJSONPatch applies the operations of a JSON Patch (RFC 6902) to dst,
+// a pointer to a JSON-encodable value.
+//
+// The patch is atomic: if an operation fails (unknown operation, missing
+// path, failed "test") or the patched document does not match the type of
+// dst, a ValidationErrors describing the failure is returned and dst is
+// left unchanged.
+//
+// Example:
+//
+//	err := fursy.ApplyJSONPatch(&user, []fursy.PatchOperation{
+//	    {Op: "test", Path: "/version", Value: json.RawMessage(`3`)},
+//	    {Op: "replace", Path: "/email", Value: json.RawMessage(`"alice@example.org"`)},
+//	    {Op: "add", Path: "/tags/-", Value: json.RawMessage(`"admin"`)},
+//	})
+func ApplyJSONPatch(dst any, ops []PatchOperation) error {
+	doc, err := patchDocument(dst)
+	if err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		doc, err = applyPatchOperation(doc, op)
+		if err != nil {
+			return ValidationErrors{{
+				Field:   op.Path,
+				Tag:     op.Op,
+				Message: err.Error(),
+				Pointer: op.Path,
+			}}
+		}
+	}
+
+	return storePatchDocument(dst, doc)
+}
+
+// ApplyPatch applies the request body to dst as a patch selected by
+// Content-Type:
+//   - application/json-patch+json: JSON Patch (RFC 6902)
+//   - application/merge-patch+json or application/json: JSON Merge Patch (RFC 7386)
+//
+// If a validator is set via Router.SetValidator(), the patched value is
+// validated. Invalid patches and validation failures are returned as
+// ValidationErrors, which can be sent with ValidationProblem; dst is only
+// modified by a valid patch (validation runs on the patched value).
+//
+// Box.Bind does not bind patch media types, so generic PATCH handlers
+// load the resource and call ApplyPatch. Plain application/json bodies are
+// bound to ReqBody by Box.Bind; they can only be applied as a merge patch
+// by non-generic handlers.
+//
+// Example:
+//
+//	fursy.PATCH[fursy.Empty, User](router, "/users/:id", func(c *fursy.Box[fursy.Empty, User]) error {
+//	    user, err := store.Get(c.Param("id"))
+//	    if err != nil {
+//	        return c.Problem(fursy.NotFound("user not found"))
+//	    }
+//	    if err := c.ApplyPatch(&user); err != nil {
+//	        var verrs fursy.ValidationErrors
+//	        if errors.As(err, &verrs) {
+//	            return c.Problem(fursy.ValidationProblem(verrs))
+//	        }
+//	        return c.Problem(fursy.NewProblem(415, "Unsupported Media Type", err.Error()))
+//	    }
+//	    store.Save(user)
+//	    return c.OK(user)
+//	})
+func (c *Context) ApplyPatch(dst any) error {
+	mediaType := MIMEApplicationJSON
+	if ct := c.Request.Header.Get("Content-Type"); ct != "" {
+		mt, _, err := mime.ParseMediaType(ct)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrUnsupportedPatchType, ct)
+		}
+		mediaType = mt
+	}
+
+	if err := checkPatchTarget(dst); err != nil {
+		return err
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return err
+	}
+
+	// Patch a copy, so dst is unchanged if validation fails.
+	patched := reflect.New(reflect.TypeOf(dst).Elem())
+	patched.Elem().Set(reflect.ValueOf(dst).Elem())
+
+	switch mediaType {
+	case MIMEApplicationJSONPatch:
+		var ops []PatchOperation
+		if err := json.Unmarshal(body, &ops); err != nil {
+			return ValidationErrors{{Tag: "json", Message: "patch must be a JSON array of operations"}}
+		}
+		err = ApplyJSONPatch(patched.Interface(), ops)
+	case MIMEApplicationMergePatch, MIMEApplicationJSON:
+		err = ApplyMergePatch(patched.Interface(), body)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedPatchType, mediaType)
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.router != nil && c.router.validator != nil {
+		if err := c.router.validator.Validate(patched.Interface()); err != nil {
+			return err
+		}
+	}
+
+	reflect.ValueOf(dst).Elem().Set(patched.Elem())
+	return nil
+}
+
+// isPatchMediaType reports whether contentType is a patch media type
+// applied by Context.ApplyPatch rather than bound by Box.Bind.
+func isPatchMediaType(contentType string) bool {
+	mt, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mt == MIMEApplicationJSONPatch || mt == MIMEApplicationMergePatch)
+}
+
+// checkPatchTarget returns an error if dst is not a non-nil pointer.
+func checkPatchTarget(dst any) error {
+	if v := reflect.ValueOf(dst); v.Kind() != reflect.Pointer || v.IsNil() {
+		return errors.New("fursy: patch target must be a non-nil pointer")
+	}
+	return nil
+}
+
+// patchDocument encodes dst as a generic JSON document.
+func patchDocument(dst any) (any, error) {
+	if err := checkPatchTarget(dst); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(dst)
+	if err != nil {
+		return nil, err
+	}
+	var doc any
+	if err := decodeJSONNumber(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// storePatchDocument decodes the patched document and stores it in dst
+// if it matches the type of dst.
+func storePatchDocument(dst any, doc any) error {
+	if err := checkPatchTarget(dst); err != nil {
+		return err
+	}
+	t := reflect.TypeOf(dst).Elem()
+
+	var errs ValidationErrors
+	checkPatchFields(t, doc, "", &errs)
+	if !errs.IsEmpty() {
+		return errs
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	// Decode onto a copy of dst with its JSON fields reset, so removed
+	// members are zeroed and fields not encoded in JSON are kept.
+	v := reflect.New(t)
+	v.Elem().Set(reflect.ValueOf(dst).Elem())
+	zeroJSONFields(v.Elem())
+	if err := json.Unmarshal(data, v.Interface()); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return ValidationErrors{{
+				Field:   typeErr.Field,
+				Tag:     "type",
+				Message: "must be of type " + typeErr.Type.String(),
+				Pointer: FieldPointer(typeErr.Field),
+			}}
+		}
+		return err
+	}
+
+	reflect.ValueOf(dst).Elem().Set(v.Elem())
+	return nil
+}
+
+// zeroJSONFields resets the fields of v that are encoded in JSON, keeping
+// unexported fields and fields tagged json:"-".
+func zeroJSONFields(v reflect.Value) {
+	if v.Kind() != reflect.Struct || reflect.PointerTo(v.Type()).Implements(jsonUnmarshalerType) {
+		v.SetZero()
+		return
+	}
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		fv := v.Field(i)
+		if tag, _, _ := strings.Cut(field.Tag.Get("json"), ","); tag == "-" || !fv.CanSet() {
+			continue
+		}
+		if fv.Kind() == reflect.Struct {
+			zeroJSONFields(fv)
+			continue
+		}
+		fv.SetZero()
+	}
+}
+
+// checkPatchFields reports object members of doc that do not match a
+// field of t, so patches cannot silently target unknown fields.
+func checkPatchFields(t reflect.Type, doc any, pointer string, errs *ValidationErrors) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch node := doc.(type) {
+	case map[string]any:
+		switch t.Kind() {
+		case reflect.Struct:
+			if t.Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+				return
+			}
+			for key, value := range node {
+				field, ok := jsonField(t, key)
+				if !ok {
+					name := key
+					if pointer != "" {
+						name = strings.ReplaceAll(pointer[1:], "/", ".") + "." + key
+					}
+					errs.AddError(ValidationError{
+						Field:   name,
+						Tag:     "unknown",
+						Message: "is not a field of the resource",
+						Pointer: pointer + "/" + EscapePointerToken(key),
+					})
+					continue
+				}
+				checkPatchFields(field.Type, value, pointer+"/"+EscapePointerToken(key), errs)
+			}
+		case reflect.Map:
+			for key, value := range node {
+				checkPatchFields(t.Elem(), value, pointer+"/"+EscapePointerToken(key), errs)
+			}
+		}
+	case []any:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, value := range node {
+				checkPatchFields(t.Elem(), value, pointer+"/"+strconv.Itoa(i), errs)
+			}
+		}
+	}
+}
+
+// jsonUnmarshalerType is the type of json.Unmarshaler.
+var jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+// jsonField returns the field of struct t encoded as the JSON member name,
+// matched like encoding/json (exact name first, then case-insensitive).
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	var fold reflect.StructField
+	found := false
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || len(field.Index) > 1 && !isPromotedJSONField(t, field) {
+			continue
+		}
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous && tag == "" && isStructType(field.Type) {
+			continue
+		}
+		if tag == "" {
+			tag = field.Name
+		}
+		if tag == name {
+			return field, true
+		}
+		if !found && strings.EqualFold(tag, name) {
+			fold, found = field, true
+		}
+	}
+	return fold, found
+}
+
+// isStructType reports whether t is a struct or a pointer to a struct.
+func isStructType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// isPromotedJSONField reports whether a field of an embedded struct is
+// promoted in the JSON encoding of t (the embedded struct has no json name).
+func isPromotedJSONField(t reflect.Type, field reflect.StructField) bool {
+	for i := range len(field.Index) - 1 {
+		embedded := t.FieldByIndex(field.Index[:i+1])
+		if tag, _, _ := strings.Cut(embedded.Tag.Get("json"), ","); tag != "" || !embedded.Anonymous {
+			return false
+		}
+	}
+	return true
+}
+
+// mergePatch applies the merge patch p to doc (RFC 7386, Section 2).
+func mergePatch(doc, p any) any {
+	patch, ok := p.(map[string]any)
+	if !ok {
+		return p
+	}
+	target, ok := doc.(map[string]any)
+	if !ok {
+		target = make(map[string]any, len(patch))
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+		target[key] = mergePatch(target[key], value)
+	}
+	return target
+}
+
+// applyPatchOperation applies a JSON Patch operation to doc (RFC 6902, Section 4).
+func applyPatchOperation(doc any, op PatchOperation) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if len(op.Value) == 0 {
+			return nil, errors.New("missing value")
+		}
+		var value any
+		if err := decodeJSONNumber(op.Value, &value); err != nil {
+			return nil, errors.New("value must be valid JSON")
+		}
+		switch op.Op {
+		case "add":
+			return pointerSet(doc, path, value, false)
+		case "replace":
+			return pointerSet(doc, path, value, true)
+		}
+		current, err := pointerGet(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !jsonEqual(current, value) {
+			return nil, errors.New("test failed: value does not match")
+		}
+		return doc, nil
+	case "remove":
+		doc, _, err = pointerRemove(doc, path)
+		return doc, err
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		if op.Op == "move" {
+			if op.Path != op.From && strings.HasPrefix(op.Path+"/", op.From+"/") {
+				return nil, errors.New("cannot move a value into one of its children")
+			}
+			var value any
+			doc, value, err = pointerRemove(doc, from)
+			if err != nil {
+				return nil, fmt.Errorf("from: %w", err)
+			}
+			return pointerSet(doc, path, value, false)
+		}
+		value, err := pointerGet(doc, from)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		return pointerSet(doc, path, deepCopyJSON(value), false)
+	default:
+		return nil, fmt.Errorf("unknown operation %q", op.Op)
+	}
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into unescaped reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+// arrayIndex parses an array index token; n is the array length and
+// allowEnd accepts the index n (insertion at the end).
+func arrayIndex(token string, n int, allowEnd bool) (int, error) {
+	if token == "-" && allowEnd {
+		return n, nil
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || token != strconv.Itoa(i) {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	if i > n || i == n && !allowEnd {
+		return 0, fmt.Errorf("array index %d out of range", i)
+	}
+	return i, nil
+}
+
+// pointerGet returns the value at path in doc.
+func pointerGet(doc any, path []string) (any, error) {
+	for _, token := range path {
+		switch node := doc.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("path %q does not exist", token)
+			}
+			doc = value
+		case []any:
+			i, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[i]
+		default:
+			return nil, fmt.Errorf("path %q does not exist", token)
+		}
+	}
+	return doc, nil
+}
+
+// pointerSet adds (or, if replace, replaces) the value at path in doc
+// and returns the updated document.
+func pointerSet(doc any, path []string, value any, replace bool) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	token, rest := path[0], path[1:]
+
+	switch node := doc.(type) {
+	case map[string]any:
+		child, exists := node[token]
+		if len(rest) == 0 {
+			if replace && !exists {
+				return nil, fmt.Errorf("path %q does not exist", token)
+			}
+			node[token] = value
+			return node, nil
+		}
+		if !exists {
+			return nil, fmt.Errorf("path %q does not exist", token)
+		}
+		child, err := pointerSet(child, rest, value, replace)
+		if err != nil {
+			return nil, err
+		}
+		node[token] = child
+		return node, nil
+	case []any:
+		last := len(rest) == 0
+		i, err := arrayIndex(token, len(node), last && !replace)
+		if err != nil {
+			return nil, err
+		}
+		if !last {
+			child, err := pointerSet(node[i], rest, value, replace)
+			if err != nil {
+				return nil, err
+			}
+			node[i] = child
+			return node, nil
+		}
+		if replace {
+			node[i] = value
+			return node, nil
+		}
+		node = append(node, nil)
+		copy(node[i+1:], node[i:])
+		node[i] = value
+		return node, nil
+	default:
+		return nil, fmt.Errorf("path %q does not exist", token)
+	}
+}
+
+// pointerRemove removes the value at path in doc and returns the updated
+// document and the removed value.
+func pointerRemove(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, errors.New("cannot remove the whole document")
+	}
+	token, rest := path[0], path[1:]
+
+	switch node := doc.(type) {
+	case map[string]any:
+		child, exists := node[token]
+		if !exists {
+			return nil, nil, fmt.Errorf("path %q does not exist", token)
+		}
+		if len(rest) == 0 {
+			delete(node, token)
+			return node, child, nil
+		}
+		child, removed, err := pointerRemove(child, rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		node[token] = child
+		return node, removed, nil
+	case []any:
+		i, err := arrayIndex(token, len(node), false)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(rest) == 0 {
+			removed := node[i]
+			return append(node[:i], node[i+1:]...), removed, nil
+		}
+		child, removed, err := pointerRemove(node[i], rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		node[i] = child
+		return node, removed, nil
+	default:
+		return nil, nil, fmt.Errorf("path %q does not exist", token)
+	}
+}
+
+// jsonEqual reports whether two generic JSON values are equal,
+// comparing numbers by value.
+func jsonEqual(a, b any) bool {
+	switch a := a.(type) {
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		fa, errA := a.Float64()
+		fb, errB := b.Float64()
+		return errA == nil && errB == nil && fa == fb
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for key, value := range a {
+			other, ok := b[key]
+			if !ok || !jsonEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !jsonEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+// deepCopyJSON copies a generic JSON value.
+func deepCopyJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for key, value := range v {
+			m[key] = deepCopyJSON(value)
+		}
+		return m
+	case []any:
+		s := make([]any, len(v))
+		for i, value := range v {
+			s[i] = deepCopyJSON(value)
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+// decodeJSONNumber decodes data into v, keeping numbers as json.Number
+// so that large integers survive the round trip.
+func decodeJSONNumber(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("unexpected data after JSON value")
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// patchAddress and patchUser are patch targets.
+type patchAddress struct {
+	City    string `json:"city"`
+	ZipCode string `json:"zip_code,omitempty"`
+}
+
+type patchUser struct {
+	ID       int               `json:"id"`
+	Name     string            `json:"name"`
+	Email    string            `json:"email,omitempty"`
+	Tags     []string          `json:"tags"`
+	Address  *patchAddress     `json:"address,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Password string            `json:"-"`
+}
+
+func newPatchUser() patchUser {
+	return patchUser{
+		ID:       1,
+		Name:     "Alice",
+		Email:    "alice@example.com",
+		Tags:     []string{"a", "b"},
+		Address:  &patchAddress{City: "Paris", ZipCode: "75001"},
+		Password: "secret",
+	}
+}
+
+// TestApplyMergePatch tests JSON Merge Patch (RFC 7386).
+func TestApplyMergePatch(t *testing.T) {
+	user := newPatchUser()
+	err := ApplyMergePatch(&user, []byte(`{"name":"Alicia","email":null,"address":{"zip_code":null},"labels":{"team":"core"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := patchUser{
+		ID:       1,
+		Name:     "Alicia",
+		Tags:     []string{"a", "b"},
+		Address:  &patchAddress{City: "Paris"},
+		Labels:   map[string]string{"team": "core"},
+		Password: "secret",
+	}
+	if !reflect.DeepEqual(user, want) {
+		t.Errorf("patched = %+v, want %+v", user, want)
+	}
+}
+
+// TestApplyMergePatch_Invalid tests patches that do not match the target type.
+func TestApplyMergePatch_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		patch   string
+		pointer string
+		tag     string
+	}{
+		{"unknown field", `{"nickname":"Al"}`, "/nickname", "unknown"},
+		{"unknown nested field", `{"address":{"country":"FR"}}`, "/address/country", "unknown"},
+		{"wrong type", `{"name":42}`, "/name", "type"},
+		{"invalid JSON", `{"name":`, "", "json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := newPatchUser()
+			err := ApplyMergePatch(&user, []byte(tt.patch))
+
+			var verrs ValidationErrors
+			if !errors.As(err, &verrs) || len(verrs) != 1 {
+				t.Fatalf("err = %v, want one ValidationError", err)
+			}
+			if verrs[0].Pointer != tt.pointer || verrs[0].Tag != tt.tag {
+				t.Errorf("error = %+v, want pointer %q tag %q", verrs[0], tt.pointer, tt.tag)
+			}
+			if !reflect.DeepEqual(user, newPatchUser()) {
+				t.Errorf("target modified by a failed patch: %+v", user)
+			}
+		})
+	}
+}
+
+// TestApplyJSONPatch tests JSON Patch (RFC 6902) operations.
+func TestApplyJSONPatch(t *testing.T) {
+	user := newPatchUser()
+	err := ApplyJSONPatch(&user, []PatchOperation{
+		{Op: "test", Path: "/id", Value: json.RawMessage(`1.0`)},
+		{Op: "replace", Path: "/name", Value: json.RawMessage(`"Alicia"`)},
+		{Op: "add", Path: "/tags/-", Value: json.RawMessage(`"c"`)},
+		{Op: "add", Path: "/tags/0", Value: json.RawMessage(`"z"`)},
+		{Op: "remove", Path: "/tags/1"},
+		{Op: "copy", From: "/address/city", Path: "/labels"},
+		{Op: "remove", Path: "/labels"},
+		{Op: "add", Path: "/labels", Value: json.RawMessage(`{"a/b":"x"}`)},
+		{Op: "move", From: "/labels/a~1b", Path: "/labels/moved"},
+		{Op: "remove", Path: "/email"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := patchUser{
+		ID:       1,
+		Name:     "Alicia",
+		Tags:     []string{"z", "b", "c"},
+		Address:  &patchAddress{City: "Paris", ZipCode: "75001"},
+		Labels:   map[string]string{"moved": "x"},
+		Password: "secret",
+	}
+	if !reflect.DeepEqual(user, want) {
+		t.Errorf("patched = %+v, want %+v", user, want)
+	}
+}
+
+// TestApplyJSONPatch_Errors tests failing JSON Patch operations.
+func TestApplyJSONPatch_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  []PatchOperation
+		tag  string
+	}{
+		{"failed test", []PatchOperation{{Op: "test", Path: "/name", Value: json.RawMessage(`"Bob"`)}}, "test"},
+		{"missing path", []PatchOperation{{Op: "replace", Path: "/address/country", Value: json.RawMessage(`"FR"`)}}, "replace"},
+		{"index out of range", []PatchOperation{{Op: "add", Path: "/tags/5", Value: json.RawMessage(`"x"`)}}, "add"},
+		{"leading zero index", []PatchOperation{{Op: "remove", Path: "/tags/01"}}, "remove"},
+		{"move into child", []PatchOperation{{Op: "move", From: "/address", Path: "/address/city"}}, "move"},
+		{"unknown op", []PatchOperation{{Op: "merge", Path: "/name"}}, "merge"},
+		{"unknown field", []PatchOperation{{Op: "add", Path: "/nickname", Value: json.RawMessage(`"Al"`)}}, "unknown"},
+		{"atomic", []PatchOperation{
+			{Op: "replace", Path: "/name", Value: json.RawMessage(`"Bob"`)},
+			{Op: "remove", Path: "/missing"},
+		}, "remove"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := newPatchUser()
+			err := ApplyJSONPatch(&user, tt.ops)
+
+			var verrs ValidationErrors
+			if !errors.As(err, &verrs) || verrs[0].Tag != tt.tag {
+				t.Fatalf("err = %v, want ValidationErrors with tag %q", err, tt.tag)
+			}
+			if !reflect.DeepEqual(user, newPatchUser()) {
+				t.Errorf("target modified by a failed patch: %+v", user)
+			}
+		})
+	}
+}
+
+// TestContext_ApplyPatch_InvalidTarget tests that non-pointer targets are rejected.
+func TestContext_ApplyPatch_InvalidTarget(t *testing.T) {
+	var nilUser *patchUser
+	for _, dst := range []any{nil, newPatchUser(), nilUser} {
+		req := httptest.NewRequest(http.MethodPatch, "/users/7", strings.NewReader(`{"name":"Alicia"}`))
+		req.Header.Set("Content-Type", MIMEApplicationMergePatch)
+		c := newContext()
+		c.Request = req
+
+		if err := c.ApplyPatch(dst); err == nil {
+			t.Errorf("ApplyPatch(%T) succeeded, want error", dst)
+		}
+	}
+}
+
+// TestBox_ApplyPatch tests PATCH handlers with generic boxes.
+func TestBox_ApplyPatch(t *testing.T) {
+	type patchPath struct {
+		ID int `path:"id"`
+	}
+
+	r := New()
+	validator := &mockValidator{}
+	r.SetValidator(validator)
+	patch := func(c *Context, id int) error {
+		user := newPatchUser()
+		user.ID = id
+		if err := c.ApplyPatch(&user); err != nil {
+			var verrs ValidationErrors
+			if errors.As(err, &verrs) {
+				return c.Problem(ValidationProblem(verrs))
+			}
+			return c.Problem(NewProblem(http.StatusUnsupportedMediaType, "Unsupported Media Type", err.Error()))
+		}
+		return c.JSON(http.StatusOK, user)
+	}
+	PATCH[patchPath, patchUser](r, "/users/:id", func(c *Box[patchPath, patchUser]) error {
+		return patch(c.Context, c.ReqBody.ID)
+	})
+	r.PATCH("/plain/:id", func(c *Context) error {
+		return patch(c, 7)
+	})
+
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		body        string
+		fail        bool
+		status      int
+		want        string
+	}{
+		{"merge patch", "/users/7", MIMEApplicationMergePatch, `{"name":"Alicia"}`, false, http.StatusOK, `"name":"Alicia"`},
+		{"json patch", "/users/7", MIMEApplicationJSONPatch, `[{"op":"add","path":"/tags/-","value":"c"}]`, false, http.StatusOK, `"tags":["a","b","c"]`},
+		{"json as merge patch", "/plain/7", MIMEApplicationJSON, `{"name":"Alicia"}`, false, http.StatusOK, `"id":7`},
+		{"invalid patch", "/users/7", MIMEApplicationJSONPatch, `[{"op":"test","path":"/name","value":"Bob"}]`, false, http.StatusUnprocessableEntity, `"code":"test"`},
+		{"validation", "/users/7", MIMEApplicationMergePatch, `{"name":""}`, true, http.StatusUnprocessableEntity, `"errors"`},
+		{"unsupported", "/plain/7", "text/plain", `name=Alicia`, false, http.StatusUnsupportedMediaType, "unsupported patch media type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator.shouldFail = tt.fail
+			req := httptest.NewRequest(http.MethodPatch, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.status, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.want) {
+				t.Errorf("body = %s, want %s", w.Body.String(), tt.want)
+			}
+		})
+	}
+}
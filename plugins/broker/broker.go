@@ -0,0 +1,122 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package broker provides message broker integration for fursy HTTP router.
+//
+// This package provides:
+//   - Generic Publisher and Consumer interfaces
+//   - Middleware to share a publisher across handlers
+//   - Consumer groups started and stopped with the Router lifecycle
+//   - OpenTelemetry trace propagation through message headers
+//   - An in-memory broker for tests and single-process deployments
+//
+// NATS and Kafka implementations live in the broker/nats and broker/kafka
+// modules, so applications only depend on the client they use.
+//
+// Example:
+//
+//	import (
+//	    "github.com/coregx/fursy"
+//	    "github.com/coregx/fursy/plugins/broker"
+//	    brokernats "github.com/coregx/fursy/plugins/broker/nats"
+//	    "github.com/nats-io/nats.go"
+//	)
+//
+//	nc, _ := nats.Connect(nats.DefaultURL)
+//	b := brokernats.New(nc)
+//
+//	router := fursy.New()
+//	router.Use(broker.Middleware(broker.TracedPublisher(b, broker.TracingConfig{})))
+//
+//	router.POST("/orders", func(c *fursy.Context) error {
+//	    // ... create order ...
+//	    err := broker.MustGetPublisher(c).Publish(c.Request.Context(), &broker.Message{
+//	        Topic: "orders.created",
+//	        Value: body,
+//	    })
+//	    if err != nil {
+//	        return err
+//	    }
+//	    return c.Created(order)
+//	})
+//
+//	broker.StartConsumers(router, broker.ConsumersConfig{
+//	    Consumer: b,
+//	    Subscriptions: []broker.Subscription{
+//	        {Topic: "orders.created", Group: "billing", Handler: billOrder},
+//	    },
+//	    Tracing: &broker.TracingConfig{},
+//	})
+package broker
+
+import (
+	"context"
+	"maps"
+)
+
+// Message is a message published to or consumed from a broker.
+type Message struct {
+	// Topic is the topic (Kafka) or subject (NATS) of the message.
+	Topic string
+
+	// Key is the partitioning key. Messages with the same key keep their
+	// order on brokers that partition topics (Kafka); others ignore it.
+	Key []byte
+
+	// Value is the message body.
+	Value []byte
+
+	// Headers are the message headers.
+	// Trace context is propagated here (see TracedPublisher).
+	Headers map[string]string
+}
+
+// Header returns the value of a header, or "" if it is not set.
+func (m *Message) Header(key string) string {
+	return m.Headers[key]
+}
+
+// SetHeader sets a header, allocating Headers if needed.
+func (m *Message) SetHeader(key, value string) {
+	if m.Headers == nil {
+		m.Headers = make(map[string]string)
+	}
+	m.Headers[key] = value
+}
+
+// clone returns a copy of the message with its own headers.
+func (m *Message) clone() *Message {
+	c := *m
+	c.Headers = maps.Clone(m.Headers)
+	return &c
+}
+
+// Publisher publishes messages.
+//
+// Implementations must be safe for concurrent use.
+type Publisher interface {
+	// Publish sends a message to msg.Topic.
+	Publish(ctx context.Context, msg *Message) error
+
+	// Close flushes pending messages and releases the connection.
+	Close() error
+}
+
+// Handler processes a consumed message.
+type Handler func(ctx context.Context, msg *Message) error
+
+// Consumer consumes messages as a member of a consumer group.
+//
+// Implementations must be safe for concurrent use.
+type Consumer interface {
+	// Consume delivers the messages of topic to handler until ctx is
+	// canceled. Consumers of the same group share the messages: each
+	// message is handled by one member of the group.
+	//
+	// Consume returns nil when ctx is canceled, or the first connection
+	// or handler error. Whether the message of a failed handler is
+	// delivered again depends on the broker (Kafka does not commit it,
+	// NATS core does not redeliver).
+	Consume(ctx context.Context, topic, group string, handler Handler) error
+}
@@ -0,0 +1,208 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package broker_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy"
+	"github.com/coregx/fursy/plugins/broker"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// waitFor polls cond until it holds or the test times out.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	mem := broker.NewMemory(0)
+	router := fursy.New()
+	router.Use(broker.Middleware(mem))
+	router.GET("/", func(c *fursy.Context) error {
+		if broker.MustGetPublisher(c) != mem {
+			t.Error("unexpected publisher")
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	router = fursy.New()
+	router.GET("/", func(c *fursy.Context) error {
+		if _, err := broker.GetPublisherOrError(c); err == nil {
+			t.Error("expected error without middleware")
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+}
+
+func TestStartConsumers(t *testing.T) {
+	mem := broker.NewMemory(0)
+	router := fursy.New()
+
+	var (
+		mu      sync.Mutex
+		handled = map[string][]string{}
+	)
+	record := func(group string) broker.Handler {
+		return func(_ context.Context, msg *broker.Message) error {
+			mu.Lock()
+			handled[group] = append(handled[group], string(msg.Value))
+			mu.Unlock()
+			return nil
+		}
+	}
+
+	broker.StartConsumers(router, broker.ConsumersConfig{
+		Consumer: mem,
+		Subscriptions: []broker.Subscription{
+			{Topic: "orders", Group: "billing", Handler: record("billing"), Concurrency: 2},
+			{Topic: "orders", Group: "mailer", Handler: record("mailer")},
+		},
+	})
+
+	// Wait for the consumer groups to subscribe.
+	time.Sleep(20 * time.Millisecond)
+	for _, v := range []string{"a", "b", "c"} {
+		if err := mem.Publish(context.Background(), &broker.Message{Topic: "orders", Value: []byte(v)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(handled["billing"]) == 3 && len(handled["mailer"]) == 3
+	})
+
+	if err := router.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStartConsumers_Restart(t *testing.T) {
+	mem := broker.NewMemory(0)
+	var calls, errs atomic.Int32
+
+	consumers := broker.StartConsumers(nil, broker.ConsumersConfig{
+		Consumer: mem,
+		Subscriptions: []broker.Subscription{{
+			Topic: "jobs",
+			Group: "workers",
+			Handler: func(context.Context, *broker.Message) error {
+				if calls.Add(1) == 1 {
+					return errors.New("transient")
+				}
+				return nil
+			},
+		}},
+		RetryDelay:   time.Millisecond,
+		ErrorHandler: func(broker.Subscription, error) { errs.Add(1) },
+	})
+	defer consumers.Stop()
+
+	time.Sleep(20 * time.Millisecond)
+	for range 2 {
+		_ = mem.Publish(context.Background(), &broker.Message{Topic: "jobs"})
+	}
+
+	waitFor(t, func() bool { return calls.Load() == 2 })
+	if errs.Load() != 1 {
+		t.Errorf("errors = %d, want 1", errs.Load())
+	}
+
+	consumers.Stop()
+	consumers.Stop() // No-op.
+}
+
+func TestStartConsumers_InvalidConfig(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for a subscription without group")
+		}
+	}()
+	broker.StartConsumers(nil, broker.ConsumersConfig{
+		Consumer:      broker.NewMemory(0),
+		Subscriptions: []broker.Subscription{{Topic: "t", Handler: func(context.Context, *broker.Message) error { return nil }}},
+	})
+}
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracing := broker.TracingConfig{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+		Propagators:    propagation.TraceContext{},
+		System:         "memory",
+	}
+
+	mem := broker.NewMemory(0)
+	pub := broker.TracedPublisher(mem, tracing)
+
+	var consumerTrace trace.TraceID
+	done := make(chan struct{})
+	consumers := broker.StartConsumers(nil, broker.ConsumersConfig{
+		Consumer: mem,
+		Subscriptions: []broker.Subscription{{
+			Topic: "orders",
+			Group: "billing",
+			Handler: func(ctx context.Context, _ *broker.Message) error {
+				consumerTrace = trace.SpanContextFromContext(ctx).TraceID()
+				close(done)
+				return nil
+			},
+		}},
+		Tracing: &tracing,
+	})
+	defer consumers.Stop()
+
+	time.Sleep(20 * time.Millisecond)
+	msg := &broker.Message{Topic: "orders"}
+	if err := pub.Publish(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Headers != nil {
+		t.Error("TracedPublisher modified the caller's message")
+	}
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("message not consumed")
+	}
+	consumers.Stop()
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	producer, consumer := spans["publish orders"], spans["process orders"]
+	if producer == nil || consumer == nil {
+		t.Fatalf("spans = %v, want publish and process spans", spans)
+	}
+	if producer.SpanKind() != trace.SpanKindProducer || consumer.SpanKind() != trace.SpanKindConsumer {
+		t.Errorf("span kinds = %v, %v", producer.SpanKind(), consumer.SpanKind())
+	}
+	if consumer.Parent().SpanID() != producer.SpanContext().SpanID() || consumerTrace != producer.SpanContext().TraceID() {
+		t.Error("consumer span does not continue the producer trace")
+	}
+}
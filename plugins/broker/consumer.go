@@ -0,0 +1,153 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package broker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// DefaultRetryDelay is the default delay before a failed consumer is restarted.
+const DefaultRetryDelay = time.Second
+
+// Subscription binds a handler to a topic within a consumer group.
+type Subscription struct {
+	// Topic is the consumed topic (required).
+	Topic string
+
+	// Group is the consumer group (required). Instances of the application
+	// using the same group share the messages of the topic.
+	Group string
+
+	// Handler processes the messages (required).
+	Handler Handler
+
+	// Concurrency is the number of consumers started for the subscription.
+	// Default: 1
+	Concurrency int
+}
+
+// ConsumersConfig defines the configuration for StartConsumers.
+type ConsumersConfig struct {
+	// Consumer is the broker consuming the messages (required).
+	Consumer Consumer
+
+	// Subscriptions lists the consumed topics (required).
+	Subscriptions []Subscription
+
+	// RetryDelay is the delay before restarting a consumer that returned
+	// an error (connection or handler error).
+	// Default: 1 second
+	RetryDelay time.Duration
+
+	// ErrorHandler is called with the errors returned by consumers.
+	// Default: nil (errors are ignored; consumers are restarted)
+	ErrorHandler func(sub Subscription, err error)
+
+	// Tracing enables OpenTelemetry spans for handled messages, continuing
+	// the trace of the publisher (see TracedHandler).
+	// Default: nil (no tracing)
+	Tracing *TracingConfig
+}
+
+// Consumers runs the consumer groups started by StartConsumers.
+type Consumers struct {
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	once   sync.Once
+}
+
+// StartConsumers starts the subscriptions in the background and stops them
+// when the router shuts down (Router.Shutdown or ListenAndServeWithShutdown).
+// Pass a nil router to manage the lifecycle with Consumers.Stop.
+//
+// Consumers that return an error are restarted after RetryDelay.
+//
+// Example:
+//
+//	broker.StartConsumers(router, broker.ConsumersConfig{
+//	    Consumer: b,
+//	    Subscriptions: []broker.Subscription{
+//	        {Topic: "orders.created", Group: "billing", Handler: billOrder, Concurrency: 4},
+//	        {Topic: "orders.created", Group: "mailer", Handler: sendConfirmation},
+//	    },
+//	    ErrorHandler: func(sub broker.Subscription, err error) {
+//	        log.Printf("consumer %s/%s: %v", sub.Topic, sub.Group, err)
+//	    },
+//	})
+//
+//	router.ListenAndServeWithShutdown(":8080")
+func StartConsumers(r *fursy.Router, config ConsumersConfig) *Consumers {
+	// Validate config.
+	if config.Consumer == nil {
+		panic("broker: consumer is required")
+	}
+	if len(config.Subscriptions) == 0 {
+		panic("broker: at least one subscription is required")
+	}
+	for _, sub := range config.Subscriptions {
+		if sub.Topic == "" || sub.Group == "" || sub.Handler == nil {
+			panic("broker: subscription topic, group and handler are required")
+		}
+	}
+
+	// Set defaults.
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = DefaultRetryDelay
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cs := &Consumers{cancel: cancel}
+	for _, sub := range config.Subscriptions {
+		handler := sub.Handler
+		if config.Tracing != nil {
+			handler = TracedHandler(sub, *config.Tracing)
+		}
+		for range max(sub.Concurrency, 1) {
+			cs.wg.Add(1)
+			go cs.run(ctx, config, sub, handler)
+		}
+	}
+
+	if r != nil {
+		r.OnShutdown(cs.Stop)
+	}
+	return cs
+}
+
+// Stop cancels the consumers and waits for in-progress handlers to return.
+// It is safe to call Stop more than once.
+func (cs *Consumers) Stop() {
+	cs.once.Do(func() {
+		cs.cancel()
+		cs.wg.Wait()
+	})
+}
+
+// run consumes a subscription, restarting the consumer on errors.
+func (cs *Consumers) run(ctx context.Context, config ConsumersConfig, sub Subscription, handler Handler) {
+	defer cs.wg.Done()
+
+	for {
+		err := config.Consumer.Consume(ctx, sub.Topic, sub.Group, handler)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil && config.ErrorHandler != nil {
+			config.ErrorHandler(sub, err)
+		}
+
+		timer := time.NewTimer(config.RetryDelay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,26 @@
+module github.com/coregx/fursy/plugins/broker/kafka
+
+go 1.25.0
+
+require (
+	github.com/coregx/fursy/plugins/broker v0.1.0
+	github.com/segmentio/kafka-go v0.4.47
+)
+
+require (
+	github.com/coregx/fursy v0.2.0 // indirect
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/klauspost/compress v1.15.9 // indirect
+	github.com/pierrec/lz4/v4 v4.1.15 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	go.opentelemetry.io/otel v1.38.0 // indirect
+	go.opentelemetry.io/otel/metric v1.38.0 // indirect
+	go.opentelemetry.io/otel/trace v1.38.0 // indirect
+)
+
+// Use local modules during development.
+replace (
+	github.com/coregx/fursy => ../../..
+	github.com/coregx/fursy/plugins/broker => ..
+)
@@ -0,0 +1,125 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package kafka provides a Kafka implementation of the broker plugin interfaces.
+//
+// Messages are partitioned by key. Consumer offsets are committed after the
+// handler succeeds: if a handler fails, Consume returns without committing
+// and the message is delivered again when the consumer restarts
+// (at-least-once).
+//
+// Example:
+//
+//	b := kafka.New(kafka.Config{Brokers: []string{"localhost:9092"}})
+//	defer b.Close()
+//
+//	router.Use(broker.Middleware(b))
+//	broker.StartConsumers(router, broker.ConsumersConfig{
+//	    Consumer:      b,
+//	    Subscriptions: subscriptions,
+//	})
+package kafka
+
+import (
+	"context"
+
+	"github.com/coregx/fursy/plugins/broker"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// Config defines the configuration for a Kafka Broker.
+type Config struct {
+	// Brokers lists the bootstrap broker addresses (required).
+	Brokers []string
+
+	// Writer is the writer used to publish messages. Its Topic must be
+	// empty (the topic of each message is used).
+	// Default: writer for Brokers with hash partitioning by key
+	Writer *kafkago.Writer
+
+	// Reader customizes the reader of Consume. Brokers, GroupID and Topic
+	// are set by Consume.
+	// Default: zero ReaderConfig (kafka-go defaults)
+	Reader kafkago.ReaderConfig
+}
+
+// Broker publishes and consumes Kafka messages.
+// It implements broker.Publisher and broker.Consumer.
+type Broker struct {
+	config Config
+}
+
+// New creates a Broker.
+func New(config Config) *Broker {
+	// Validate config.
+	if len(config.Brokers) == 0 {
+		panic("kafka: at least one broker address is required")
+	}
+
+	// Set defaults.
+	if config.Writer == nil {
+		config.Writer = &kafkago.Writer{
+			Addr:     kafkago.TCP(config.Brokers...),
+			Balancer: &kafkago.Hash{},
+		}
+	}
+
+	return &Broker{config: config}
+}
+
+// Publish implements broker.Publisher.
+func (b *Broker) Publish(ctx context.Context, msg *broker.Message) error {
+	m := kafkago.Message{
+		Topic: msg.Topic,
+		Key:   msg.Key,
+		Value: msg.Value,
+	}
+	for k, v := range msg.Headers {
+		m.Headers = append(m.Headers, kafkago.Header{Key: k, Value: []byte(v)})
+	}
+	return b.config.Writer.WriteMessages(ctx, m)
+}
+
+// Consume implements broker.Consumer as a member of the consumer group.
+// Offsets are committed after each successful handler call.
+func (b *Broker) Consume(ctx context.Context, topic, group string, handler broker.Handler) error {
+	config := b.config.Reader
+	config.Brokers = b.config.Brokers
+	config.GroupID = group
+	config.Topic = topic
+
+	r := kafkago.NewReader(config)
+	defer r.Close()
+
+	for {
+		m, err := r.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		msg := &broker.Message{Topic: m.Topic, Key: m.Key, Value: m.Value}
+		for _, h := range m.Headers {
+			msg.SetHeader(h.Key, string(h.Value))
+		}
+		if err := handler(ctx, msg); err != nil {
+			return err
+		}
+
+		if err := r.CommitMessages(ctx, m); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// Close implements broker.Publisher. It flushes pending messages and
+// closes the writer.
+func (b *Broker) Close() error {
+	return b.config.Writer.Close()
+}
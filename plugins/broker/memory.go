@@ -0,0 +1,106 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package broker
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned when publishing to a closed Memory broker.
+var ErrClosed = errors.New("broker: broker is closed")
+
+// DefaultMemoryBuffer is the default number of messages buffered per consumer group.
+const DefaultMemoryBuffer = 1024
+
+// Memory is an in-process Publisher and Consumer.
+//
+// Each consumer group of a topic receives every message published after the
+// group started consuming; members of a group share its messages. Messages
+// are not persisted and a message whose handler fails is dropped.
+// Publish blocks while a group's buffer is full.
+//
+// Memory is useful for tests and single-process deployments.
+// It is safe for concurrent use.
+type Memory struct {
+	mu     sync.Mutex
+	groups map[string]map[string]chan *Message // topic -> group -> queue
+	buffer int
+	closed bool
+}
+
+// NewMemory creates an in-memory broker buffering up to buffer messages per
+// consumer group (DefaultMemoryBuffer if zero or negative).
+func NewMemory(buffer int) *Memory {
+	if buffer <= 0 {
+		buffer = DefaultMemoryBuffer
+	}
+	return &Memory{groups: make(map[string]map[string]chan *Message), buffer: buffer}
+}
+
+// Publish implements Publisher.
+func (m *Memory) Publish(ctx context.Context, msg *Message) error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return ErrClosed
+	}
+	queues := make([]chan *Message, 0, len(m.groups[msg.Topic]))
+	for _, q := range m.groups[msg.Topic] {
+		queues = append(queues, q)
+	}
+	m.mu.Unlock()
+
+	for _, q := range queues {
+		select {
+		case q <- msg.clone():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Consume implements Consumer.
+func (m *Memory) Consume(ctx context.Context, topic, group string, handler Handler) error {
+	q := m.queue(topic, group)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg := <-q:
+			if err := handler(ctx, msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Close implements Publisher. Later Publish calls return ErrClosed.
+func (m *Memory) Close() error {
+	m.mu.Lock()
+	m.closed = true
+	m.mu.Unlock()
+	return nil
+}
+
+// queue returns the queue of a consumer group, creating it if needed.
+func (m *Memory) queue(topic, group string) chan *Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	groups, ok := m.groups[topic]
+	if !ok {
+		groups = make(map[string]chan *Message)
+		m.groups[topic] = groups
+	}
+	q, ok := groups[group]
+	if !ok {
+		q = make(chan *Message, m.buffer)
+		groups[group] = q
+	}
+	return q
+}
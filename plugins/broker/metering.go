@@ -0,0 +1,58 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/coregx/fursy/metering"
+)
+
+// MeteringSink returns a metering.Sink publishing each batch of usage
+// aggregates to topic as a single message holding a JSON array, so a
+// batch is published atomically. A billing service consumes the topic
+// to store or rate the usage.
+//
+// Example:
+//
+//	meter := metering.New(metering.Config{
+//	    Sink:       broker.MeteringSink(b, "billing.usage"),
+//	    TenantFunc: func(c *fursy.Context) string { return c.GetString("tenant") },
+//	})
+//
+//	// In the billing service:
+//	broker.StartConsumers(router, broker.ConsumersConfig{
+//	    Consumer: b,
+//	    Subscriptions: []broker.Subscription{{
+//	        Topic: "billing.usage",
+//	        Group: "billing",
+//	        Handler: func(ctx context.Context, msg *broker.Message) error {
+//	            var batch []metering.Usage
+//	            if err := json.Unmarshal(msg.Value, &batch); err != nil {
+//	                return nil // Drop malformed batches.
+//	            }
+//	            return store.Add(ctx, batch)
+//	        },
+//	    }},
+//	})
+func MeteringSink(pub Publisher, topic string) metering.Sink {
+	// Validate config.
+	if pub == nil {
+		panic("broker: metering publisher is required")
+	}
+	if topic == "" {
+		panic("broker: metering topic is required")
+	}
+
+	return metering.SinkFunc(func(ctx context.Context, batch []metering.Usage) error {
+		value, err := json.Marshal(batch)
+		if err != nil {
+			return fmt.Errorf("broker: encode usage: %w", err)
+		}
+		return pub.Publish(ctx, &Message{Topic: topic, Value: value})
+	})
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package broker_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/coregx/fursy/metering"
+	"github.com/coregx/fursy/plugins/broker"
+)
+
+func TestMeteringSink(t *testing.T) {
+	mem := broker.NewMemory(0)
+	received := make(chan []metering.Usage, 16)
+	cs := broker.StartConsumers(nil, broker.ConsumersConfig{
+		Consumer: mem,
+		Subscriptions: []broker.Subscription{{
+			Topic: "billing.usage",
+			Group: "billing",
+			Handler: func(_ context.Context, msg *broker.Message) error {
+				var batch []metering.Usage
+				if err := json.Unmarshal(msg.Value, &batch); err != nil {
+					t.Errorf("decode batch: %v", err)
+				}
+				received <- batch
+				return nil
+			},
+		}},
+	})
+	defer cs.Stop()
+
+	sink := broker.MeteringSink(mem, "billing.usage")
+	batch := []metering.Usage{{Tenant: "acme", Route: "/items", Requests: 3, BytesOut: 120}}
+
+	// Publish until the consumer group is subscribed.
+	waitFor(t, func() bool {
+		if err := sink.Write(context.Background(), batch); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		return len(received) > 0
+	})
+	got := <-received
+	if len(got) != 1 || got[0].Tenant != "acme" || got[0].Requests != 3 || got[0].BytesOut != 120 {
+		t.Errorf("received %+v", got)
+	}
+}
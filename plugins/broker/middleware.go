@@ -0,0 +1,73 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package broker
+
+import (
+	"context"
+
+	"github.com/coregx/fursy"
+)
+
+// contextKey is a private type for storing the publisher in context.
+type contextKey int
+
+const publisherKey contextKey = iota
+
+// Middleware creates a middleware that stores the publisher in the request context.
+//
+// Example:
+//
+//	router.Use(broker.Middleware(publisher))
+//
+//	router.POST("/orders", func(c *fursy.Context) error {
+//	    pub := broker.MustGetPublisher(c)
+//	    // Publish events...
+//	    return nil
+//	})
+func Middleware(pub Publisher) fursy.HandlerFunc {
+	return func(c *fursy.Context) error {
+		ctx := context.WithValue(c.Request.Context(), publisherKey, pub)
+		c.Request = c.Request.WithContext(ctx)
+		return c.Next()
+	}
+}
+
+// GetPublisher retrieves the publisher from the context.
+//
+// Returns (nil, false) if the broker middleware is not configured.
+func GetPublisher(c *fursy.Context) (Publisher, bool) {
+	pub, ok := c.Request.Context().Value(publisherKey).(Publisher)
+	return pub, ok
+}
+
+// MustGetPublisher retrieves the publisher from the context or panics.
+//
+// Use this in handlers where publisher absence indicates a programming error
+// (i.e., middleware misconfiguration), not a runtime error.
+func MustGetPublisher(c *fursy.Context) Publisher {
+	pub, ok := GetPublisher(c)
+	if !ok {
+		panic("broker: middleware not configured - ensure broker.Middleware(publisher) is used")
+	}
+	return pub
+}
+
+// GetPublisherOrError retrieves the publisher from the context or returns an RFC 9457 error.
+//
+// Returns InternalServerError (500) if the broker middleware is not configured.
+//
+// Example:
+//
+//	pub, err := broker.GetPublisherOrError(c)
+//	if err != nil {
+//	    return c.Problem(err.(fursy.Problem))
+//	}
+func GetPublisherOrError(c *fursy.Context) (Publisher, error) {
+	pub, ok := GetPublisher(c)
+	if !ok {
+		return nil, fursy.InternalServerError("Message broker not configured")
+	}
+	return pub, nil
+}
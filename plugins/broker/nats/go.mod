@@ -0,0 +1,29 @@
+module github.com/coregx/fursy/plugins/broker/nats
+
+go 1.25.0
+
+require (
+	github.com/coregx/fursy/plugins/broker v0.1.0
+	github.com/nats-io/nats.go v1.37.0
+)
+
+require (
+	github.com/coregx/fursy v0.2.0 // indirect
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/klauspost/compress v1.17.2 // indirect
+	github.com/nats-io/nkeys v0.4.7 // indirect
+	github.com/nats-io/nuid v1.0.1 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	go.opentelemetry.io/otel v1.38.0 // indirect
+	go.opentelemetry.io/otel/metric v1.38.0 // indirect
+	go.opentelemetry.io/otel/trace v1.38.0 // indirect
+	golang.org/x/crypto v0.18.0 // indirect
+	golang.org/x/sys v0.35.0 // indirect
+)
+
+// Use local modules during development.
+replace (
+	github.com/coregx/fursy => ../../..
+	github.com/coregx/fursy/plugins/broker => ..
+)
@@ -0,0 +1,96 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package nats provides a NATS implementation of the broker plugin interfaces.
+//
+// Topics are NATS subjects and consumer groups are queue groups. NATS core
+// does not persist messages: messages published while no member of a group
+// is subscribed, and messages whose handler fails, are not redelivered.
+//
+// Example:
+//
+//	nc, err := nats.Connect(nats.DefaultURL)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	b := brokernats.New(nc)
+//
+//	router.Use(broker.Middleware(b))
+//	broker.StartConsumers(router, broker.ConsumersConfig{
+//	    Consumer:      b,
+//	    Subscriptions: subscriptions,
+//	})
+package nats
+
+import (
+	"context"
+
+	"github.com/coregx/fursy/plugins/broker"
+	natsgo "github.com/nats-io/nats.go"
+)
+
+// Broker publishes and consumes messages over a NATS connection.
+// It implements broker.Publisher and broker.Consumer.
+type Broker struct {
+	conn *natsgo.Conn
+}
+
+// New creates a Broker using conn.
+func New(conn *natsgo.Conn) *Broker {
+	return &Broker{conn: conn}
+}
+
+// Conn returns the underlying NATS connection.
+func (b *Broker) Conn() *natsgo.Conn {
+	return b.conn
+}
+
+// Publish implements broker.Publisher. The message key is ignored.
+func (b *Broker) Publish(ctx context.Context, msg *broker.Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m := natsgo.NewMsg(msg.Topic)
+	m.Data = msg.Value
+	for k, v := range msg.Headers {
+		m.Header.Set(k, v)
+	}
+	return b.conn.PublishMsg(m)
+}
+
+// Consume implements broker.Consumer using a queue subscription.
+// It returns the first handler error after draining the subscription.
+func (b *Broker) Consume(ctx context.Context, topic, group string, handler broker.Handler) error {
+	errc := make(chan error, 1)
+	sub, err := b.conn.QueueSubscribe(topic, group, func(m *natsgo.Msg) {
+		msg := &broker.Message{Topic: m.Subject, Value: m.Data}
+		for k := range m.Header {
+			msg.SetHeader(k, m.Header.Get(k))
+		}
+		if err := handler(ctx, msg); err != nil {
+			select {
+			case errc <- err:
+			default:
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		_ = sub.Drain()
+		return nil
+	case err := <-errc:
+		_ = sub.Drain()
+		return err
+	}
+}
+
+// Close implements broker.Publisher. It drains the connection, flushing
+// pending messages before closing it.
+func (b *Broker) Close() error {
+	return b.conn.Drain()
+}
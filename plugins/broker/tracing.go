@@ -0,0 +1,135 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package broker
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ScopeName is the instrumentation scope name.
+	ScopeName = "github.com/coregx/fursy/plugins/broker"
+
+	// Version is the instrumentation version.
+	Version = "0.1.0"
+)
+
+// TracingConfig holds the OpenTelemetry configuration of publishers and handlers.
+type TracingConfig struct {
+	// TracerProvider provides the tracer for creating spans.
+	// If not set, the global TracerProvider is used.
+	TracerProvider trace.TracerProvider
+
+	// Propagators injects and extracts the trace context in message headers.
+	// If not set, the global TextMapPropagator is used.
+	Propagators propagation.TextMapPropagator
+
+	// System is the messaging system recorded in the messaging.system
+	// attribute (e.g., "kafka", "nats").
+	// Default: empty (attribute omitted)
+	System string
+}
+
+// tracer returns the tracer and propagators of the config, with defaults.
+func (config TracingConfig) tracer() (trace.Tracer, propagation.TextMapPropagator) {
+	tp := config.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	prop := config.Propagators
+	if prop == nil {
+		prop = otel.GetTextMapPropagator()
+	}
+	return tp.Tracer(ScopeName, trace.WithInstrumentationVersion(Version)), prop
+}
+
+// attributes returns the messaging attributes of a span.
+func (config TracingConfig) attributes(operation, topic string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("messaging.operation.name", operation),
+		attribute.String("messaging.destination.name", topic),
+	}
+	if config.System != "" {
+		attrs = append(attrs, attribute.String("messaging.system", config.System))
+	}
+	return attrs
+}
+
+// tracedPublisher is the Publisher returned by TracedPublisher.
+type tracedPublisher struct {
+	Publisher
+	config TracingConfig
+	tracer trace.Tracer
+	prop   propagation.TextMapPropagator
+}
+
+// TracedPublisher wraps a publisher to create a producer span for each
+// message and inject its trace context into the message headers, so
+// handlers wrapped with TracedHandler continue the trace.
+//
+// The caller's message is not modified.
+//
+// Example:
+//
+//	pub := broker.TracedPublisher(b, broker.TracingConfig{System: "kafka"})
+//	router.Use(broker.Middleware(pub))
+func TracedPublisher(pub Publisher, config TracingConfig) Publisher {
+	tracer, prop := config.tracer()
+	return &tracedPublisher{Publisher: pub, config: config, tracer: tracer, prop: prop}
+}
+
+// Publish implements Publisher.
+func (p *tracedPublisher) Publish(ctx context.Context, msg *Message) error {
+	ctx, span := p.tracer.Start(ctx, "publish "+msg.Topic,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(p.config.attributes("publish", msg.Topic)...),
+	)
+	defer span.End()
+
+	msg = msg.clone()
+	if msg.Headers == nil {
+		msg.Headers = make(map[string]string)
+	}
+	p.prop.Inject(ctx, propagation.MapCarrier(msg.Headers))
+
+	err := p.Publisher.Publish(ctx, msg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// TracedHandler wraps the handler of a subscription to extract the trace
+// context from the message headers and handle the message in a consumer
+// span. StartConsumers applies it when ConsumersConfig.Tracing is set.
+func TracedHandler(sub Subscription, config TracingConfig) Handler {
+	tracer, prop := config.tracer()
+	attrs := append(config.attributes("process", sub.Topic),
+		attribute.String("messaging.consumer.group.name", sub.Group))
+	handler := sub.Handler
+
+	return func(ctx context.Context, msg *Message) error {
+		ctx = prop.Extract(ctx, propagation.MapCarrier(msg.Headers))
+		ctx, span := tracer.Start(ctx, "process "+sub.Topic,
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(attrs...),
+		)
+		defer span.End()
+
+		err := handler(ctx, msg)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return err
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package cbor provides CBOR (application/cbor, RFC 8949) support for fursy.
+//
+// Register adds a binder and a renderer for application/cbor to the router:
+//   - Box.Bind decodes CBOR request bodies
+//   - Context.Negotiate renders CBOR for "Accept: application/cbor"
+//   - The generated OpenAPI document lists application/cbor content
+//
+// Struct fields are mapped using `cbor` tags, falling back to `json` tags,
+// so the same request and response types work for JSON and CBOR clients.
+//
+// Example:
+//
+//	router := fursy.New()
+//	cbor.Register(router)
+//
+//	fursy.GET[fursy.Empty, User](router, "/users/:id", func(c *fursy.Box[fursy.Empty, User]) error {
+//	    user := findUser(c.Param("id"))
+//	    c.ResBody = &user
+//	    return c.NegotiateRes() // JSON or CBOR
+//	})
+package cbor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/coregx/fursy"
+	"github.com/fxamacker/cbor/v2"
+)
+
+// ErrEmptyBody is returned by Bind when the request body is empty.
+var ErrEmptyBody = errors.New("cbor: request body is empty")
+
+// Register registers the CBOR binder and renderer on the router.
+//
+// Example:
+//
+//	router := cbor.Register(fursy.New())
+func Register(r *fursy.Router) *fursy.Router {
+	return r.
+		RegisterBinder(fursy.MIMEApplicationCBOR, Bind).
+		RegisterRenderer(fursy.MIMEApplicationCBOR, Render)
+}
+
+// Bind decodes a CBOR request body into v.
+func Bind(c *fursy.Context, v any) error {
+	if c.Request.Body == nil || c.Request.ContentLength == 0 {
+		return ErrEmptyBody
+	}
+
+	if err := cbor.NewDecoder(c.Request.Body).Decode(v); err != nil {
+		return fmt.Errorf("cbor decode error: %w", err)
+	}
+
+	return nil
+}
+
+// Render sends data as a CBOR response with the given status code.
+func Render(c *fursy.Context, status int, data any) error {
+	body, err := cbor.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("cbor encode error: %w", err)
+	}
+
+	return c.Blob(status, fursy.MIMEApplicationCBOR, body)
+}
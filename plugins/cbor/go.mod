@@ -0,0 +1,13 @@
+module github.com/coregx/fursy/plugins/cbor
+
+go 1.25.0
+
+require (
+	github.com/coregx/fursy v0.1.0
+	github.com/fxamacker/cbor/v2 v2.7.0
+)
+
+require github.com/x448/float16 v0.8.4 // indirect
+
+// Use local fursy module during development.
+replace github.com/coregx/fursy => ../..
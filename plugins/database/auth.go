@@ -0,0 +1,43 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/coregx/fursy"
+)
+
+// PasswordHashLookup returns a function that loads the password hash of a
+// user with query, for use as middleware.UserProvider with
+// middleware.BasicAuthHashed.
+//
+// The query receives the username as its only argument and must select
+// a single column. Unknown users yield fursy.ErrUnauthorized.
+// The DB is taken from the context (see Middleware).
+//
+// Example:
+//
+//	router.Use(database.Middleware(db))
+//	router.Use(middleware.BasicAuth(middleware.BasicAuthHashed(
+//	    database.PasswordHashLookup("SELECT password_hash FROM users WHERE username = $1"),
+//	    middleware.VerifyPassword,
+//	)))
+func PasswordHashLookup(query string) func(c *fursy.Context, username string) (string, error) {
+	return func(c *fursy.Context, username string) (string, error) {
+		db, err := GetDBOrError(c)
+		if err != nil {
+			return "", err
+		}
+
+		var hash string
+		err = db.QueryRow(c.Request.Context(), query, username).Scan(&hash)
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fursy.ErrUnauthorized
+		}
+		return hash, err
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/coregx/fursy"
+	"github.com/coregx/fursy/plugins/database"
+)
+
+// TestPasswordHashLookup tests loading password hashes from the database.
+func TestPasswordHashLookup(t *testing.T) {
+	sqlDB := setupDB(t)
+	defer sqlDB.Close()
+
+	db := database.NewDB(sqlDB)
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, "CREATE TABLE users (username TEXT PRIMARY KEY, password_hash TEXT)"); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	if _, err := db.Exec(ctx, "INSERT INTO users VALUES (?, ?)", "admin", "hash"); err != nil {
+		t.Fatalf("failed to insert user: %v", err)
+	}
+
+	lookup := database.PasswordHashLookup("SELECT password_hash FROM users WHERE username = ?")
+
+	router := fursy.New()
+	router.Use(database.Middleware(db))
+	router.GET("/:user", func(c *fursy.Context) error {
+		hash, err := lookup(c, c.Param("user"))
+		if errors.Is(err, fursy.ErrUnauthorized) {
+			return c.String(http.StatusUnauthorized, "unknown")
+		}
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, hash)
+	})
+
+	tests := []struct {
+		user string
+		code int
+		body string
+	}{
+		{"admin", http.StatusOK, "hash"},
+		{"nobody", http.StatusUnauthorized, "unknown"},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/"+tt.user, http.NoBody))
+		if w.Code != tt.code || w.Body.String() != tt.body {
+			t.Errorf("%s: expected %d %q, got %d %q", tt.user, tt.code, tt.body, w.Code, w.Body.String())
+		}
+	}
+}
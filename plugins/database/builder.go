@@ -0,0 +1,338 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/coregx/fursy"
+)
+
+// querier is implemented by DB and Tx.
+type querier interface {
+	Query(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRow(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// SelectBuilder builds a SELECT statement for list endpoints.
+//
+// It is injection-safe: values are always bound as arguments, and table,
+// column and sort names must be plain identifiers (e.g., "users" or
+// "u.created_at") or come from the column map. Conditions are written
+// with "?" placeholders, rewritten to the builder's placeholder style.
+//
+// Builder errors (invalid identifiers, argument count mismatches) are
+// reported by Build, Rows and Count.
+type SelectBuilder struct {
+	q           querier
+	table       string
+	columns     []string
+	where       []string
+	args        []any
+	orderBy     []string
+	limit       int
+	offset      int
+	placeholder Placeholder
+	columnMap   map[string]string
+	err         error
+}
+
+// Select starts a SELECT statement on table, returning columns
+// (all columns if none are given).
+//
+// Example:
+//
+//	sql, args, err := database.Select("users", "id", "name").
+//	    Where("status = ?", "active").
+//	    Placeholder(database.Dollar).
+//	    Build()
+//	// SELECT id, name FROM users WHERE status = $1
+func Select(table string, columns ...string) *SelectBuilder {
+	b := &SelectBuilder{placeholder: Question}
+	if !isIdentifier(table) {
+		b.setErr(fmt.Errorf("database: invalid table name %q", table))
+	}
+	b.table = table
+	return b.Columns(columns...)
+}
+
+// Select starts a SELECT statement on table that can be run with Rows and Count.
+//
+// Example:
+//
+//	router.GET("/users", func(c *fursy.Context) error {
+//	    q, err := c.ListQuery(fursy.QueryConfig{
+//	        SortFields:   []string{"name", "created_at"},
+//	        FilterFields: []string{"status"},
+//	    })
+//	    if err != nil {
+//	        return c.Problem(fursy.ValidationProblem(err.(fursy.ValidationErrors)))
+//	    }
+//
+//	    sel := database.MustGetDB(c).Select("users", "id", "name").
+//	        Where("deleted_at IS NULL").
+//	        Query(q)
+//	    total, err := sel.Count(ctx)
+//	    // ...
+//	    rows, err := sel.Rows(ctx)
+//	    // ... scan rows ...
+//	    return c.OKPage(users, q.Meta(total, ""))
+//	})
+func (d *DB) Select(table string, columns ...string) *SelectBuilder {
+	b := Select(table, columns...)
+	b.q = d
+	return b
+}
+
+// Select starts a SELECT statement on table run in the transaction.
+func (t *Tx) Select(table string, columns ...string) *SelectBuilder {
+	b := Select(table, columns...)
+	b.q = t
+	return b
+}
+
+// Columns adds result columns. Names must be plain identifiers or keys
+// of the column map.
+func (b *SelectBuilder) Columns(columns ...string) *SelectBuilder {
+	for _, col := range columns {
+		b.columns = append(b.columns, b.column(col))
+	}
+	return b
+}
+
+// ColumnMap maps field names (of sort and filter criteria, and columns) to
+// SQL column expressions (e.g., "author" → "u.name"). Set it before the
+// methods that use field names.
+func (b *SelectBuilder) ColumnMap(columns map[string]string) *SelectBuilder {
+	b.columnMap = columns
+	return b
+}
+
+// Placeholder sets the bind parameter style.
+// Default: Question
+func (b *SelectBuilder) Placeholder(p Placeholder) *SelectBuilder {
+	if p != nil {
+		b.placeholder = p
+	}
+	return b
+}
+
+// Where adds a condition, combined with AND. The condition uses one "?"
+// placeholder per argument; values are never interpolated. Question marks
+// inside quotes are not placeholders, and "??" is a literal "?" (e.g., the
+// PostgreSQL JSONB operator "tags ??| ?" with the Dollar style).
+//
+// Example:
+//
+//	b.Where("status = ?", status).Where("created_at >= ?", since)
+func (b *SelectBuilder) Where(condition string, args ...any) *SelectBuilder {
+	if n := countPlaceholders(condition); n != len(args) {
+		b.setErr(fmt.Errorf("database: condition %q has %d placeholders but %d arguments", condition, n, len(args)))
+		return b
+	}
+	b.where = append(b.where, "("+condition+")")
+	b.args = append(b.args, args...)
+	return b
+}
+
+// OrderBy adds sort criteria, typically Query.Sort from fursy.Context.ListQuery.
+func (b *SelectBuilder) OrderBy(sort []fursy.SortField) *SelectBuilder {
+	for _, s := range sort {
+		dir := " ASC"
+		if s.Desc {
+			dir = " DESC"
+		}
+		b.orderBy = append(b.orderBy, b.column(s.Field)+dir)
+	}
+	return b
+}
+
+// Paginate sets LIMIT and OFFSET from the pagination parameters.
+func (b *SelectBuilder) Paginate(p fursy.Pagination) *SelectBuilder {
+	b.limit = p.Limit
+	b.offset = p.Offset
+	return b
+}
+
+// Query applies the filters, sort and pagination of a list query
+// (see BuildListSQL for the filter operators).
+func (b *SelectBuilder) Query(q fursy.Query) *SelectBuilder {
+	for _, f := range q.Filters {
+		if _, mapped := b.columnMap[f.Field]; !mapped && !isIdentifier(f.Field) {
+			b.setErr(fmt.Errorf("database: invalid column name %q", f.Field))
+			return b
+		}
+	}
+	list := BuildListSQL(fursy.Query{Filters: q.Filters}, ListSQLOptions{Columns: b.columnMap})
+	if list.Where != "" {
+		b.Where(list.Where, list.Args...)
+	}
+	return b.OrderBy(q.Sort).Paginate(q.Pagination)
+}
+
+// Build returns the SQL statement and its arguments.
+func (b *SelectBuilder) Build() (string, []any, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+
+	columns := "*"
+	if len(b.columns) > 0 {
+		columns = strings.Join(b.columns, ", ")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("SELECT " + columns + " FROM " + b.table)
+	args := b.writeWhere(&sb)
+	if len(b.orderBy) > 0 {
+		sb.WriteString(" ORDER BY " + strings.Join(b.orderBy, ", "))
+	}
+	if b.limit > 0 {
+		args = append(args, b.limit)
+		sb.WriteString(" LIMIT ?")
+	}
+	if b.offset > 0 {
+		args = append(args, b.offset)
+		sb.WriteString(" OFFSET ?")
+	}
+
+	return rewritePlaceholders(sb.String(), b.placeholder), args, nil
+}
+
+// BuildCount returns the "SELECT COUNT(*)" statement counting all matching
+// rows (ignoring sort and pagination) and its arguments.
+func (b *SelectBuilder) BuildCount() (string, []any, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("SELECT COUNT(*) FROM " + b.table)
+	args := b.writeWhere(&sb)
+	return rewritePlaceholders(sb.String(), b.placeholder), args, nil
+}
+
+// Rows runs the statement. The builder must come from DB.Select or Tx.Select.
+func (b *SelectBuilder) Rows(ctx context.Context) (*sql.Rows, error) {
+	if b.q == nil {
+		return nil, errors.New("database: builder is not bound to a DB or Tx")
+	}
+	query, args, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	return b.q.Query(ctx, query, args...)
+}
+
+// Count returns the number of matching rows, e.g. the total of a page.
+// The builder must come from DB.Select or Tx.Select.
+func (b *SelectBuilder) Count(ctx context.Context) (int, error) {
+	if b.q == nil {
+		return 0, errors.New("database: builder is not bound to a DB or Tx")
+	}
+	query, args, err := b.BuildCount()
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	err = b.q.QueryRow(ctx, query, args...).Scan(&n)
+	return n, err
+}
+
+// writeWhere writes the WHERE clause and returns a copy of its arguments.
+func (b *SelectBuilder) writeWhere(sb *strings.Builder) []any {
+	if len(b.where) > 0 {
+		sb.WriteString(" WHERE " + strings.Join(b.where, " AND "))
+	}
+	return append([]any(nil), b.args...)
+}
+
+// column returns the SQL expression of a field, recording an error for
+// names that are neither mapped nor plain identifiers.
+func (b *SelectBuilder) column(field string) string {
+	if col, ok := b.columnMap[field]; ok {
+		return col
+	}
+	if field != "*" && !isIdentifier(field) {
+		b.setErr(fmt.Errorf("database: invalid column name %q", field))
+	}
+	return field
+}
+
+// setErr records the first builder error.
+func (b *SelectBuilder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// isIdentifier reports whether s is a plain or qualified SQL identifier
+// ("name", "u.name").
+func isIdentifier(s string) bool {
+	parts := strings.Split(s, ".")
+	if len(parts) > 2 {
+		return false
+	}
+	for _, part := range parts {
+		if part == "" {
+			return false
+		}
+		for i, r := range part {
+			letter := r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+			if !letter && (i == 0 || r < '0' || r > '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// countPlaceholders counts the "?" placeholders of a condition.
+func countPlaceholders(condition string) int {
+	_, n := scanPlaceholders(condition, Question)
+	return n
+}
+
+// rewritePlaceholders replaces the "?" placeholders of query with the
+// placeholder style p.
+func rewritePlaceholders(query string, p Placeholder) string {
+	s, _ := scanPlaceholders(query, p)
+	return s
+}
+
+// scanPlaceholders rewrites the "?" placeholders of query to the style p
+// and counts them. Question marks inside quoted literals and identifiers
+// are kept, and "??" is written as a literal "?" so that PostgreSQL JSONB
+// operators (?, ?| and ?&) can be used with the Dollar style.
+func scanPlaceholders(query string, p Placeholder) (string, int) {
+	var sb strings.Builder
+	n := 0
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+			sb.WriteByte(c)
+		case c == '\'' || c == '"':
+			quote = c
+			sb.WriteByte(c)
+		case c == '?' && i+1 < len(query) && query[i+1] == '?':
+			i++
+			sb.WriteByte(c)
+		case c == '?':
+			n++
+			sb.WriteString(p(n))
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String(), n
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/coregx/fursy"
+	"github.com/coregx/fursy/plugins/database"
+)
+
+func TestSelect_Query(t *testing.T) {
+	q := parseQuery(t, "/users?limit=10&offset=20&sort=-created_at,name&filter[status]=active&filter[age][gte]=18")
+
+	query, args, err := database.Select("users", "id", "name").
+		ColumnMap(map[string]string{"created_at": "u.created_at"}).
+		Where("deleted_at IS NULL").
+		Where("org_id = ?", 7).
+		Query(q).
+		Placeholder(database.Dollar).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "SELECT id, name FROM users WHERE (deleted_at IS NULL) AND (org_id = $1) AND (age >= $2 AND status = $3)" +
+		" ORDER BY u.created_at DESC, name ASC LIMIT $4 OFFSET $5"
+	if query != want {
+		t.Errorf("query = %q, want %q", query, want)
+	}
+	wantArgs := []any{7, "18", "active", 10, 20}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v, want %v", args, wantArgs)
+	}
+
+	count, countArgs, err := database.Select("users").Where("org_id = ?", 7).Query(q).BuildCount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != "SELECT COUNT(*) FROM users WHERE (org_id = ?) AND (age >= ? AND status = ?)" {
+		t.Errorf("count query = %q", count)
+	}
+	if !reflect.DeepEqual(countArgs, []any{7, "18", "active"}) {
+		t.Errorf("count args = %v", countArgs)
+	}
+}
+
+func TestSelect_Literals(t *testing.T) {
+	query, args, err := database.Select("notes").
+		Where("body <> '?' AND id = ?", 1).
+		Placeholder(database.Dollar).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "SELECT * FROM notes WHERE (body <> '?' AND id = $1)" || len(args) != 1 {
+		t.Errorf("query = %q, args = %v", query, args)
+	}
+
+	query, args, err = database.Select("docs").
+		Where(`"is?" = ? AND data ?? 'a' AND tags ??| ?`, true, []string{"b"}).
+		Placeholder(database.Dollar).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != `SELECT * FROM docs WHERE ("is?" = $1 AND data ? 'a' AND tags ?| $2)` || len(args) != 2 {
+		t.Errorf("query = %q, args = %v", query, args)
+	}
+}
+
+func TestSelect_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *database.SelectBuilder
+	}{
+		{"table", database.Select("users; DROP TABLE users")},
+		{"column", database.Select("users", "name, password")},
+		{"sort", database.Select("users").OrderBy([]fursy.SortField{{Field: "name DESC, (SELECT 1)"}})},
+		{"filter", database.Select("users").Query(fursy.Query{Filters: []fursy.Filter{{Field: "1=1 OR a", Op: fursy.FilterEq, Values: []string{"x"}}}})},
+		{"args", database.Select("users").Where("a = ? AND b = ?", 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := tt.builder.Build(); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+
+	if _, err := database.Select("users").Rows(context.Background()); err == nil {
+		t.Error("expected error for an unbound builder")
+	}
+}
+
+func TestDB_Select(t *testing.T) {
+	sqlDB := setupDB(t)
+	defer sqlDB.Close()
+	db := database.NewDB(sqlDB)
+	ctx := context.Background()
+
+	if _, err := db.Exec(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, status TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	for _, u := range [][2]string{{"alice", "active"}, {"bob", "active"}, {"carol", "banned"}, {"dave", "active"}} {
+		if _, err := db.Exec(ctx, "INSERT INTO users (name, status) VALUES (?, ?)", u[0], u[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	q := parseQuery(t, "/users?limit=2&offset=1&sort=-name&filter[status]=active")
+	sel := db.Select("users", "name").Query(q)
+
+	total, err := sel.Count(ctx)
+	if err != nil || total != 3 {
+		t.Fatalf("Count = %d, %v, want 3", total, err)
+	}
+
+	rows, err := sel.Rows(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, name)
+	}
+	if !reflect.DeepEqual(names, []string{"bob", "alice"}) {
+		t.Errorf("names = %v, want [bob alice]", names)
+	}
+}
@@ -58,7 +58,8 @@ const (
 // It provides a thin wrapper around database/sql that integrates
 // with fursy's context and middleware system.
 type DB struct {
-	db *sql.DB
+	db     *sql.DB
+	outbox *Outbox
 }
 
 // NewDB creates a new DB wrapper around a *sql.DB connection.
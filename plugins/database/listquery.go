@@ -0,0 +1,193 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/coregx/fursy"
+)
+
+// Placeholder returns the bind parameter placeholder for the n-th (1-based) argument.
+type Placeholder func(n int) string
+
+// Placeholder styles of common drivers.
+var (
+	// Question is the "?" placeholder style (MySQL, SQLite).
+	Question Placeholder = func(int) string { return "?" }
+
+	// Dollar is the "$1" placeholder style (PostgreSQL).
+	Dollar Placeholder = func(n int) string { return "$" + strconv.Itoa(n) }
+)
+
+// ListSQLOptions configures the translation of a fursy.Query to SQL.
+type ListSQLOptions struct {
+	// Columns maps query field names to SQL column expressions
+	// (e.g., "created_at" → "u.created_at"). Fields not in the map are used as-is.
+	// Field names are already restricted by fursy.QueryConfig.
+	Columns map[string]string
+
+	// Placeholder is the bind parameter style.
+	// Default: Question
+	Placeholder Placeholder
+
+	// ArgOffset is the number of arguments already bound in the statement,
+	// for numbered placeholders (e.g., 1 when the query already uses $1).
+	// Default: 0
+	ArgOffset int
+}
+
+// ListSQL holds SQL fragments built from a fursy.Query.
+//
+// Values are always bound as arguments; only column names from the
+// allowlisted fields are interpolated into the SQL.
+type ListSQL struct {
+	// Where is the filter condition without the WHERE keyword (empty if no filters).
+	Where string
+
+	// OrderBy is the sort expression without the ORDER BY keyword (empty if unsorted).
+	OrderBy string
+
+	// Args contains the filter arguments referenced by Where.
+	Args []any
+
+	// Limit and Offset are the pagination values.
+	Limit  int
+	Offset int
+
+	placeholder Placeholder
+	argOffset   int
+}
+
+// likeEscaper escapes the LIKE wildcards (and the escape character) of a
+// value with "!". Unlike a backslash, "!" needs no escaping in the ESCAPE
+// clause on any dialect (MySQL treats backslashes in literals as escapes).
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// BuildListSQL translates the filters, sort and pagination of q to SQL fragments.
+//
+// Filter operators map to: eq "=", ne "<>", gt ">", gte ">=", lt "<",
+// lte "<=", in "IN (...)", like "LIKE ... ESCAPE '!'" (the value matches
+// as a substring: "%" and "_" in it are escaped, then it is wrapped in "%").
+//
+// Example:
+//
+//	q, err := c.ListQuery(fursy.QueryConfig{
+//	    SortFields:   []string{"created_at", "name"},
+//	    FilterFields: []string{"status"},
+//	})
+//	if err != nil {
+//	    return c.Problem(fursy.ValidationProblem(err.(fursy.ValidationErrors)))
+//	}
+//
+//	list := database.BuildListSQL(q, database.ListSQLOptions{Placeholder: database.Dollar})
+//	clause, args := list.Clause()
+//	rows, err := db.Query(ctx, "SELECT id, name FROM users"+clause, args...)
+func BuildListSQL(q fursy.Query, opts ListSQLOptions) ListSQL {
+	if opts.Placeholder == nil {
+		opts.Placeholder = Question
+	}
+
+	list := ListSQL{
+		Limit:       q.Limit,
+		Offset:      q.Offset,
+		placeholder: opts.Placeholder,
+		argOffset:   opts.ArgOffset,
+	}
+
+	column := func(field string) string {
+		if col, ok := opts.Columns[field]; ok {
+			return col
+		}
+		return field
+	}
+	bind := func(v any) string {
+		list.Args = append(list.Args, v)
+		return opts.Placeholder(opts.ArgOffset + len(list.Args))
+	}
+
+	conditions := make([]string, 0, len(q.Filters))
+	for _, f := range q.Filters {
+		col := column(f.Field)
+		switch f.Op {
+		case fursy.FilterIn:
+			binds := make([]string, len(f.Values))
+			for i, v := range f.Values {
+				binds[i] = bind(v)
+			}
+			conditions = append(conditions, col+" IN ("+strings.Join(binds, ", ")+")")
+		case fursy.FilterLike:
+			conditions = append(conditions, col+" LIKE "+bind("%"+likeEscaper.Replace(f.Value())+"%")+" ESCAPE '!'")
+		default:
+			conditions = append(conditions, col+" "+sqlOperator(f.Op)+" "+bind(f.Value()))
+		}
+	}
+	list.Where = strings.Join(conditions, " AND ")
+
+	orders := make([]string, len(q.Sort))
+	for i, s := range q.Sort {
+		dir := " ASC"
+		if s.Desc {
+			dir = " DESC"
+		}
+		orders[i] = column(s.Field) + dir
+	}
+	list.OrderBy = strings.Join(orders, ", ")
+
+	return list
+}
+
+// Clause returns the " WHERE ... ORDER BY ... LIMIT ... OFFSET ..." suffix
+// and its arguments (filter arguments followed by limit and offset).
+// Parts without content are omitted.
+func (l ListSQL) Clause() (string, []any) {
+	placeholder := l.placeholder
+	if placeholder == nil {
+		placeholder = Question
+	}
+
+	var b strings.Builder
+	args := append([]any(nil), l.Args...)
+
+	if l.Where != "" {
+		b.WriteString(" WHERE ")
+		b.WriteString(l.Where)
+	}
+	if l.OrderBy != "" {
+		b.WriteString(" ORDER BY ")
+		b.WriteString(l.OrderBy)
+	}
+	if l.Limit > 0 {
+		args = append(args, l.Limit)
+		b.WriteString(" LIMIT ")
+		b.WriteString(placeholder(l.argOffset + len(args)))
+	}
+	if l.Offset > 0 {
+		args = append(args, l.Offset)
+		b.WriteString(" OFFSET ")
+		b.WriteString(placeholder(l.argOffset + len(args)))
+	}
+
+	return b.String(), args
+}
+
+// sqlOperator maps a comparison filter operator to SQL.
+func sqlOperator(op string) string {
+	switch op {
+	case fursy.FilterNe:
+		return "<>"
+	case fursy.FilterGt:
+		return ">"
+	case fursy.FilterGte:
+		return ">="
+	case fursy.FilterLt:
+		return "<"
+	case fursy.FilterLte:
+		return "<="
+	default:
+		return "="
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/coregx/fursy"
+	"github.com/coregx/fursy/plugins/database"
+)
+
+// parseQuery parses a list query from a request URL.
+func parseQuery(t *testing.T, target string) fursy.Query {
+	t.Helper()
+
+	var q fursy.Query
+	router := fursy.New()
+	router.GET("/users", func(c *fursy.Context) error {
+		var err error
+		q, err = c.ListQuery(fursy.QueryConfig{
+			SortFields:   []string{"created_at", "name"},
+			FilterFields: []string{"status", "age", "role", "name"},
+		})
+		return err
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, http.NoBody))
+	if w.Code != http.StatusOK {
+		t.Fatalf("failed to parse query %q: status %d", target, w.Code)
+	}
+	return q
+}
+
+func TestBuildListSQL(t *testing.T) {
+	q := parseQuery(t, "/users?limit=10&offset=20&sort=-created_at,name"+
+		"&filter[status]=active&filter[age][gte]=18&filter[role][in]=admin,owner&filter[name][like]=jo")
+
+	list := database.BuildListSQL(q, database.ListSQLOptions{
+		Columns: map[string]string{"created_at": "u.created_at"},
+	})
+
+	wantWhere := "age >= ? AND name LIKE ? ESCAPE '!' AND role IN (?, ?) AND status = ?"
+	if list.Where != wantWhere {
+		t.Errorf("Where = %q, want %q", list.Where, wantWhere)
+	}
+	if list.OrderBy != "u.created_at DESC, name ASC" {
+		t.Errorf("OrderBy = %q", list.OrderBy)
+	}
+	wantArgs := []any{"18", "%jo%", "admin", "owner", "active"}
+	if !reflect.DeepEqual(list.Args, wantArgs) {
+		t.Errorf("Args = %v, want %v", list.Args, wantArgs)
+	}
+
+	clause, args := list.Clause()
+	wantClause := " WHERE " + wantWhere + " ORDER BY u.created_at DESC, name ASC LIMIT ? OFFSET ?"
+	if clause != wantClause {
+		t.Errorf("Clause = %q, want %q", clause, wantClause)
+	}
+	if len(args) != 7 || args[5] != 10 || args[6] != 20 {
+		t.Errorf("unexpected clause args %v", args)
+	}
+}
+
+func TestBuildListSQL_LikeEscapesWildcards(t *testing.T) {
+	q := parseQuery(t, "/users?filter[name][like]=50%25_off!%5C")
+
+	list := database.BuildListSQL(q, database.ListSQLOptions{})
+	if want := []any{`%50!%!_off!!\%`}; !reflect.DeepEqual(list.Args, want) {
+		t.Errorf("Args = %v, want %v", list.Args, want)
+	}
+
+	sqlDB := setupDB(t)
+	defer sqlDB.Close()
+	for _, stmt := range []string{
+		"CREATE TABLE users (name TEXT)",
+		`INSERT INTO users VALUES ('50%_off!\'), ('50% off!\'), ('500 off!\')`,
+	} {
+		if _, err := sqlDB.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	clause, args := list.Clause()
+	var count int
+	if err := sqlDB.QueryRow("SELECT COUNT(*) FROM users"+clause, args...).Scan(&count); err != nil || count != 1 {
+		t.Errorf("matching rows = %d, %v, want 1", count, err)
+	}
+}
+
+// TestBuildListSQL_LikeMySQL tests that LIKE clauses contain no backslash,
+// which MySQL treats as an escape in string literals by default.
+func TestBuildListSQL_LikeMySQL(t *testing.T) {
+	q := parseQuery(t, "/users?filter[name][like]=a%5Cb")
+
+	list := database.BuildListSQL(q, database.ListSQLOptions{Placeholder: database.Question})
+	if want := "name LIKE ? ESCAPE '!'"; list.Where != want {
+		t.Errorf("Where = %q, want %q", list.Where, want)
+	}
+	if clause, _ := list.Clause(); strings.Contains(clause, `\`) {
+		t.Errorf("clause %q contains a backslash", clause)
+	}
+}
+
+func TestBuildListSQL_Dollar(t *testing.T) {
+	q := parseQuery(t, "/users?page=3&limit=5&filter[status][ne]=deleted")
+
+	list := database.BuildListSQL(q, database.ListSQLOptions{
+		Placeholder: database.Dollar,
+		ArgOffset:   1, // $1 is already used by the statement.
+	})
+
+	clause, args := list.Clause()
+	if want := " WHERE status <> $2 LIMIT $3 OFFSET $4"; clause != want {
+		t.Errorf("Clause = %q, want %q", clause, want)
+	}
+	if !reflect.DeepEqual(args, []any{"deleted", 5, 10}) {
+		t.Errorf("unexpected args %v", args)
+	}
+}
+
+func TestBuildListSQL_Empty(t *testing.T) {
+	list := database.BuildListSQL(fursy.Query{}, database.ListSQLOptions{})
+
+	clause, args := list.Clause()
+	if clause != "" || len(args) != 0 {
+		t.Errorf("expected empty clause, got %q %v", clause, args)
+	}
+}
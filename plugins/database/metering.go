@@ -0,0 +1,84 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coregx/fursy/metering"
+)
+
+// DefaultMeteringTable is the default table of MeteringSink.
+const DefaultMeteringTable = "usage"
+
+// MeteringSinkConfig defines the configuration for MeteringSink.
+type MeteringSinkConfig struct {
+	// Table is the usage table name.
+	// Default: "usage"
+	Table string
+
+	// Placeholder is the bind parameter style of the driver.
+	// Default: Question
+	Placeholder Placeholder
+}
+
+// MeteringSink returns a metering.Sink inserting each batch of usage
+// aggregates into a table, in a single transaction.
+//
+// The table must have the following columns (PostgreSQL shown):
+//
+//	CREATE TABLE usage (
+//	    tenant       TEXT NOT NULL,
+//	    route        TEXT NOT NULL,
+//	    requests     BIGINT NOT NULL,
+//	    errors       BIGINT NOT NULL,
+//	    bytes_in     BIGINT NOT NULL,
+//	    bytes_out    BIGINT NOT NULL,
+//	    duration_ms  BIGINT NOT NULL,
+//	    period_start TIMESTAMP NOT NULL,
+//	    period_end   TIMESTAMP NOT NULL
+//	);
+//	CREATE INDEX usage_tenant ON usage (tenant, period_start);
+//
+// Example:
+//
+//	meter := metering.New(metering.Config{
+//	    Sink:       database.MeteringSink(db, database.MeteringSinkConfig{Placeholder: database.Dollar}),
+//	    TenantFunc: func(c *fursy.Context) string { return c.GetString("tenant") },
+//	})
+//
+//	// Monthly invoice:
+//	//   SELECT tenant, SUM(requests), SUM(bytes_out) FROM usage
+//	//   WHERE period_start >= $1 AND period_start < $2 GROUP BY tenant
+func MeteringSink(db *DB, config MeteringSinkConfig) metering.Sink {
+	// Set defaults.
+	if config.Table == "" {
+		config.Table = DefaultMeteringTable
+	}
+	if !isIdentifier(config.Table) {
+		panic(fmt.Sprintf("database: invalid metering table name %q", config.Table))
+	}
+	if config.Placeholder == nil {
+		config.Placeholder = Question
+	}
+
+	insert := rewritePlaceholders(fmt.Sprintf(
+		"INSERT INTO %s (tenant, route, requests, errors, bytes_in, bytes_out, duration_ms, period_start, period_end) "+
+			"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)", config.Table), config.Placeholder)
+
+	return metering.SinkFunc(func(ctx context.Context, batch []metering.Usage) error {
+		return WithTx(ctx, db, func(tx *Tx) error {
+			for _, u := range batch {
+				_, err := tx.Exec(ctx, insert, u.Tenant, u.Route, u.Requests, u.Errors,
+					u.BytesIn, u.BytesOut, u.Duration.Milliseconds(), u.Start.UTC(), u.End.UTC())
+				if err != nil {
+					return fmt.Errorf("database: insert usage: %w", err)
+				}
+			}
+			return nil
+		})
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy/metering"
+	"github.com/coregx/fursy/plugins/database"
+)
+
+const usageSchema = `CREATE TABLE usage (
+	tenant       TEXT NOT NULL,
+	route        TEXT NOT NULL,
+	requests     INTEGER NOT NULL,
+	errors       INTEGER NOT NULL,
+	bytes_in     INTEGER NOT NULL,
+	bytes_out    INTEGER NOT NULL,
+	duration_ms  INTEGER NOT NULL,
+	period_start TIMESTAMP NOT NULL,
+	period_end   TIMESTAMP NOT NULL
+)`
+
+func TestMeteringSink(t *testing.T) {
+	sqlDB := setupDB(t)
+	sqlDB.SetMaxOpenConns(1) // A single in-memory database.
+	defer sqlDB.Close()
+
+	ctx := context.Background()
+	db := database.NewDB(sqlDB)
+	if _, err := db.Exec(ctx, usageSchema); err != nil {
+		t.Fatal(err)
+	}
+
+	sink := database.MeteringSink(db, database.MeteringSinkConfig{})
+	now := time.Now()
+	batch := []metering.Usage{
+		{Tenant: "acme", Route: "/items", Requests: 3, BytesOut: 120, Duration: 15 * time.Millisecond, Start: now, End: now},
+		{Tenant: "globex", Route: "/items", Requests: 1, Errors: 1, BytesIn: 42, Start: now, End: now},
+	}
+	if err := sink.Write(ctx, batch); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	var requests, errs, bytesIn, bytesOut, durationMS int64
+	err := db.QueryRow(ctx, "SELECT SUM(requests), SUM(errors), SUM(bytes_in), SUM(bytes_out), SUM(duration_ms) FROM usage").
+		Scan(&requests, &errs, &bytesIn, &bytesOut, &durationMS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 4 || errs != 1 || bytesIn != 42 || bytesOut != 120 || durationMS != 15 {
+		t.Errorf("totals = %d %d %d %d %d", requests, errs, bytesIn, bytesOut, durationMS)
+	}
+
+	// A failed batch is rolled back as a whole.
+	bad := database.MeteringSink(db, database.MeteringSinkConfig{Table: "missing"})
+	if err := bad.Write(ctx, batch); err == nil {
+		t.Error("expected error for missing table")
+	}
+}
+
+func TestMeteringSink_InvalidTable(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	database.MeteringSink(nil, database.MeteringSinkConfig{Table: "usage; DROP TABLE users"})
+}
@@ -0,0 +1,402 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/coregx/fursy/webhooks"
+)
+
+// Default values for the Outbox.
+const (
+	// DefaultOutboxTable is the default outbox table name.
+	DefaultOutboxTable = "outbox"
+
+	// DefaultOutboxInterval is the default polling interval of the relay.
+	DefaultOutboxInterval = time.Second
+
+	// DefaultOutboxBatchSize is the default number of events relayed per query.
+	DefaultOutboxBatchSize = 100
+
+	// DefaultOutboxMaxAttempts is the default number of publish attempts per event.
+	DefaultOutboxMaxAttempts = 10
+)
+
+// ErrNoOutbox is returned by Tx.Publish when no Outbox is configured for the DB.
+var ErrNoOutbox = errors.New("database: no outbox configured - use database.NewOutbox(db, config)")
+
+// OutboxEvent is an event stored in the outbox table.
+type OutboxEvent struct {
+	// ID is the outbox row ID (increasing in publish order).
+	ID int64
+
+	// Name is the event name (e.g., "order.created").
+	Name string
+
+	// Payload is the JSON-encoded event payload.
+	Payload json.RawMessage
+
+	// Attempts is the number of failed publish attempts so far.
+	Attempts int
+
+	// CreatedAt is the time the event was stored.
+	CreatedAt time.Time
+}
+
+// OutboxPublisher delivers an event to the outside world (a webhook
+// dispatcher, a message broker, ...). Returning an error leaves the event
+// in the outbox to be retried by a later relay pass.
+type OutboxPublisher func(ctx context.Context, event OutboxEvent) error
+
+// OutboxConfig defines the configuration for an Outbox.
+type OutboxConfig struct {
+	// Publisher delivers relayed events (required).
+	Publisher OutboxPublisher
+
+	// Table is the outbox table name.
+	// Default: "outbox"
+	Table string
+
+	// Placeholder is the bind parameter style of the driver.
+	// Default: Question
+	Placeholder Placeholder
+
+	// Interval is the polling interval of the relay.
+	// Default: 1 second
+	Interval time.Duration
+
+	// BatchSize is the maximum number of events loaded per query.
+	// Default: 100
+	BatchSize int
+
+	// MaxAttempts is the number of failed publish attempts after which an
+	// event is no longer relayed. It stays in the table with its last error
+	// for inspection.
+	// Default: 10
+	MaxAttempts int
+
+	// ErrorHandler is called for relay errors (failed queries and failed
+	// publish attempts).
+	// Default: nil (errors are only recorded in the last_error column)
+	ErrorHandler func(err error)
+}
+
+// Outbox implements the transactional outbox pattern.
+//
+// Events published with Tx.Publish are stored in the outbox table in the
+// same transaction as the business data, so they are recorded if and only
+// if the transaction commits. A background relay loads pending events in
+// ID order and passes them to the Publisher, marking them as published on
+// success.
+//
+// Delivery is at-least-once: an event is published again if the process
+// stops between publishing and marking it, or if several relays run on the
+// same table. Consumers should deduplicate by event ID. An event that fails
+// is retried on later passes, so events may be delivered out of order.
+//
+// The outbox table must have the following columns (PostgreSQL shown):
+//
+//	CREATE TABLE outbox (
+//	    id           BIGSERIAL PRIMARY KEY,
+//	    event        TEXT NOT NULL,
+//	    payload      TEXT NOT NULL,
+//	    attempts     INTEGER NOT NULL DEFAULT 0,
+//	    last_error   TEXT,
+//	    created_at   TIMESTAMP NOT NULL,
+//	    published_at TIMESTAMP
+//	);
+//	CREATE INDEX outbox_pending ON outbox (id) WHERE published_at IS NULL;
+//
+// Published rows are kept; delete them periodically (e.g., rows with
+// published_at older than a week).
+type Outbox struct {
+	db     *DB
+	config OutboxConfig
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewOutbox creates an Outbox for db. Transactions started by db can then
+// publish events with Tx.Publish.
+//
+// Call NewOutbox before serving requests.
+//
+// Example:
+//
+//	hooks := webhooks.New(webhooks.Config{})
+//	outbox := database.NewOutbox(db, database.OutboxConfig{
+//	    Publisher:   database.WebhookPublisher(hooks),
+//	    Placeholder: database.Dollar,
+//	})
+//	outbox.Start()
+//	router.OnShutdown(func() {
+//	    outbox.Stop()
+//	    _ = hooks.Shutdown(context.Background())
+//	})
+//
+//	router.POST("/orders", func(c *fursy.Context) error {
+//	    ctx := c.Request.Context()
+//	    return database.WithTx(ctx, db, func(tx *database.Tx) error {
+//	        if _, err := tx.Exec(ctx, "INSERT INTO orders ...", ...); err != nil {
+//	            return err
+//	        }
+//	        return tx.Publish(ctx, "order.created", order)
+//	    })
+//	})
+func NewOutbox(db *DB, config OutboxConfig) *Outbox {
+	// Validate config.
+	if config.Publisher == nil {
+		panic("database: outbox publisher is required")
+	}
+
+	// Set defaults.
+	if config.Table == "" {
+		config.Table = DefaultOutboxTable
+	}
+	if !isIdentifier(config.Table) {
+		panic(fmt.Sprintf("database: invalid outbox table name %q", config.Table))
+	}
+	if config.Placeholder == nil {
+		config.Placeholder = Question
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultOutboxInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultOutboxBatchSize
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultOutboxMaxAttempts
+	}
+
+	o := &Outbox{db: db, config: config}
+	db.outbox = o
+	return o
+}
+
+// Publish stores an event in the outbox within the transaction.
+// The payload is encoded as JSON.
+func (o *Outbox) Publish(ctx context.Context, tx *Tx, event string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("database: encode outbox payload: %w", err)
+	}
+	_, err = tx.Exec(ctx, o.query("INSERT INTO %s (event, payload, attempts, created_at) VALUES (?, ?, 0, ?)"),
+		event, string(body), time.Now().UTC())
+	return err
+}
+
+// Publish stores an event in the outbox of the DB within the transaction.
+// It is relayed only if the transaction commits.
+// Returns ErrNoOutbox if NewOutbox was not called for the DB.
+//
+// Example:
+//
+//	_, err := tx.Exec(ctx, "UPDATE orders SET status = $1 WHERE id = $2", "paid", id)
+//	if err != nil {
+//	    return err
+//	}
+//	return tx.Publish(ctx, "order.paid", map[string]any{"id": id})
+func (t *Tx) Publish(ctx context.Context, event string, payload any) error {
+	if t.db == nil || t.db.outbox == nil {
+		return ErrNoOutbox
+	}
+	return t.db.outbox.Publish(ctx, t, event, payload)
+}
+
+// Start starts the background relay. It is a no-op if the relay is running.
+func (o *Outbox) Start() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	o.cancel = cancel
+	o.done = make(chan struct{})
+	go o.run(ctx, o.done)
+}
+
+// Stop stops the background relay and waits for the current pass to end.
+// The context of an in-progress publish is canceled; the event stays pending.
+//
+// Stop has the signature of a Router.OnShutdown callback.
+func (o *Outbox) Stop() {
+	o.mu.Lock()
+	cancel, done := o.cancel, o.done
+	o.cancel, o.done = nil, nil
+	o.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// Relay runs a single relay pass over at most BatchSize pending events and
+// returns the number of published events. Failed publish attempts are
+// recorded and reported to the ErrorHandler, not returned.
+//
+// See Drain to relay all pending events from a scheduled job.
+func (o *Outbox) Relay(ctx context.Context) (int, error) {
+	n, _, _, err := o.relay(ctx, 0)
+	return n, err
+}
+
+// Drain relays all pending events, batch by batch, and returns the number
+// of published events. Events that fail are retried by the next Drain, not
+// by this one.
+//
+// The background relay (see Start) drains the outbox at every Interval.
+// Without Start, schedule Drain as a job instead, e.g. with a cron
+// scheduler or a job queue worker:
+//
+//	c := cron.New()
+//	c.AddFunc("@every 1m", func() {
+//	    if _, err := outbox.Drain(ctx); err != nil {
+//	        log.Printf("outbox: %v", err)
+//	    }
+//	})
+func (o *Outbox) Drain(ctx context.Context) (int, error) {
+	var (
+		total int
+		after int64
+	)
+	for {
+		n, loaded, last, err := o.relay(ctx, after)
+		total += n
+		if err != nil || loaded < o.config.BatchSize {
+			return total, err
+		}
+		after = last
+	}
+}
+
+// relay runs a relay pass over at most BatchSize pending events with an ID
+// greater than after. It returns the number of published and loaded events
+// and the ID of the last loaded event.
+func (o *Outbox) relay(ctx context.Context, after int64) (published, loaded int, last int64, err error) {
+	events, err := o.pending(ctx, after)
+	if err != nil {
+		return 0, 0, after, err
+	}
+	if len(events) > 0 {
+		last = events[len(events)-1].ID
+	}
+
+	n := 0
+	for _, event := range events {
+		if pubErr := o.config.Publisher(ctx, event); pubErr != nil {
+			if ctx.Err() != nil {
+				return n, len(events), last, ctx.Err()
+			}
+			o.handleError(fmt.Errorf("database: publish outbox event %d (%s): %w", event.ID, event.Name, pubErr))
+			_, err := o.db.Exec(ctx, o.query("UPDATE %s SET attempts = attempts + 1, last_error = ? WHERE id = ?"),
+				pubErr.Error(), event.ID)
+			if err != nil {
+				return n, len(events), last, err
+			}
+			continue
+		}
+
+		_, err := o.db.Exec(ctx, o.query("UPDATE %s SET published_at = ? WHERE id = ?"), time.Now().UTC(), event.ID)
+		if err != nil {
+			return n, len(events), last, err
+		}
+		n++
+	}
+	return n, len(events), last, nil
+}
+
+// run is the relay loop.
+func (o *Outbox) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(o.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		o.drain(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain drains the outbox and reports relay errors.
+func (o *Outbox) drain(ctx context.Context) {
+	if _, err := o.Drain(ctx); err != nil && ctx.Err() == nil {
+		o.handleError(fmt.Errorf("database: outbox relay: %w", err))
+	}
+}
+
+// pending loads the next batch of unpublished events with an ID greater
+// than after.
+func (o *Outbox) pending(ctx context.Context, after int64) ([]OutboxEvent, error) {
+	rows, err := o.db.Query(ctx,
+		o.query("SELECT id, event, payload, attempts, created_at FROM %s "+
+			"WHERE published_at IS NULL AND attempts < ? AND id > ? ORDER BY id LIMIT ?"),
+		o.config.MaxAttempts, after, o.config.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []OutboxEvent
+	for rows.Next() {
+		var (
+			event   OutboxEvent
+			payload string
+		)
+		if err := rows.Scan(&event.ID, &event.Name, &payload, &event.Attempts, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+		event.Payload = json.RawMessage(payload)
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// query formats a statement for the outbox table and placeholder style.
+func (o *Outbox) query(format string) string {
+	return rewritePlaceholders(fmt.Sprintf(format, o.config.Table), o.config.Placeholder)
+}
+
+// handleError reports a relay error to the ErrorHandler.
+func (o *Outbox) handleError(err error) {
+	if o.config.ErrorHandler != nil {
+		o.config.ErrorHandler(err)
+	}
+}
+
+// WebhookPublisher returns an OutboxPublisher delivering events to the
+// endpoints of a webhook dispatcher subscribed to them.
+//
+// Deliveries are enqueued on the dispatcher (see Dispatcher.SendJSON) and
+// run in the background with its retries, so a slow endpoint does not hold
+// up the relay until the dispatcher's MaxConcurrent deliveries are in
+// progress. The event is marked as published once enqueued: stop the
+// outbox, then shut the dispatcher down gracefully so that deliveries in
+// progress complete. Deliveries that finally fail are recorded as dead
+// letters (to be retried with Dispatcher.Redeliver). The event stays
+// pending if the dispatcher is shut down.
+func WebhookPublisher(d *webhooks.Dispatcher) OutboxPublisher {
+	return func(_ context.Context, event OutboxEvent) error {
+		_, err := d.SendJSON(event.Name, event.Payload)
+		return err
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy/plugins/database"
+	"github.com/coregx/fursy/webhooks"
+)
+
+const outboxSchema = `CREATE TABLE outbox (
+	id           INTEGER PRIMARY KEY AUTOINCREMENT,
+	event        TEXT NOT NULL,
+	payload      TEXT NOT NULL,
+	attempts     INTEGER NOT NULL DEFAULT 0,
+	last_error   TEXT,
+	created_at   TIMESTAMP NOT NULL,
+	published_at TIMESTAMP
+)`
+
+// recordingPublisher records published events and fails for selected names.
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []database.OutboxEvent
+	fail   map[string]bool
+}
+
+func (p *recordingPublisher) publish(_ context.Context, event database.OutboxEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.fail[event.Name] {
+		return errors.New("broker unavailable")
+	}
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *recordingPublisher) names() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	names := make([]string, 0, len(p.events))
+	for _, e := range p.events {
+		names = append(names, e.Name)
+	}
+	return names
+}
+
+func setupOutbox(t *testing.T, config database.OutboxConfig) (*database.DB, *database.Outbox) {
+	t.Helper()
+	sqlDB := setupDB(t)
+	sqlDB.SetMaxOpenConns(1) // A single in-memory database.
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db := database.NewDB(sqlDB)
+	if _, err := db.Exec(context.Background(), outboxSchema); err != nil {
+		t.Fatal(err)
+	}
+	return db, database.NewOutbox(db, config)
+}
+
+func TestOutbox_Relay(t *testing.T) {
+	pub := &recordingPublisher{fail: map[string]bool{"order.failed": true}}
+	var handled []error
+	db, outbox := setupOutbox(t, database.OutboxConfig{
+		Publisher:    pub.publish,
+		MaxAttempts:  2,
+		ErrorHandler: func(err error) { handled = append(handled, err) },
+	})
+	ctx := context.Background()
+
+	err := database.WithTx(ctx, db, func(tx *database.Tx) error {
+		if err := tx.Publish(ctx, "order.created", map[string]int{"id": 1}); err != nil {
+			return err
+		}
+		return tx.Publish(ctx, "order.failed", map[string]int{"id": 2})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Events of rolled back transactions are not stored.
+	_ = database.WithTx(ctx, db, func(tx *database.Tx) error {
+		if err := tx.Publish(ctx, "order.rolled_back", nil); err != nil {
+			return err
+		}
+		return errors.New("rollback")
+	})
+
+	n, err := outbox.Relay(ctx)
+	if err != nil || n != 1 {
+		t.Fatalf("Relay = %d, %v, want 1", n, err)
+	}
+	if got := pub.names(); len(got) != 1 || got[0] != "order.created" {
+		t.Fatalf("published = %v, want [order.created]", got)
+	}
+	if string(pub.events[0].Payload) != `{"id":1}` || pub.events[0].CreatedAt.IsZero() {
+		t.Errorf("event = %+v", pub.events[0])
+	}
+	if len(handled) != 1 {
+		t.Errorf("handled errors = %v, want 1", handled)
+	}
+
+	// The failed event is retried up to MaxAttempts; published events are not.
+	if n, err = outbox.Relay(ctx); err != nil || n != 0 {
+		t.Fatalf("second Relay = %d, %v, want 0", n, err)
+	}
+	if n, err = outbox.Relay(ctx); err != nil || n != 0 {
+		t.Fatalf("third Relay = %d, %v, want 0", n, err)
+	}
+	if len(handled) != 2 {
+		t.Errorf("handled errors = %d, want 2 (MaxAttempts)", len(handled))
+	}
+
+	var attempts int
+	var lastError string
+	err = db.QueryRow(ctx, "SELECT attempts, last_error FROM outbox WHERE event = ?", "order.failed").Scan(&attempts, &lastError)
+	if err != nil || attempts != 2 || lastError != "broker unavailable" {
+		t.Errorf("failed event: attempts = %d, last_error = %q, err = %v", attempts, lastError, err)
+	}
+}
+
+func TestOutbox_Drain(t *testing.T) {
+	pub := &recordingPublisher{fail: map[string]bool{"event.0": true}}
+	db, outbox := setupOutbox(t, database.OutboxConfig{
+		Publisher: pub.publish,
+		BatchSize: 2,
+	})
+	ctx := context.Background()
+
+	err := database.WithTx(ctx, db, func(tx *database.Tx) error {
+		for i := range 5 {
+			if err := tx.Publish(ctx, fmt.Sprintf("event.%d", i), i); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A failed event does not stop the drain, and is not retried by it.
+	n, err := outbox.Drain(ctx)
+	if err != nil || n != 4 {
+		t.Fatalf("Drain = %d, %v, want 4", n, err)
+	}
+	var attempts int
+	if err := db.QueryRow(ctx, "SELECT attempts FROM outbox WHERE event = ?", "event.0").Scan(&attempts); err != nil || attempts != 1 {
+		t.Errorf("failed event attempts = %d, %v, want 1", attempts, err)
+	}
+}
+
+func TestWebhookPublisher_Enqueues(t *testing.T) {
+	release := make(chan struct{})
+	received := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		received <- r.Header.Get(webhooks.EventHeader)
+	}))
+	defer server.Close()
+
+	hooks := webhooks.New(webhooks.Config{})
+	if err := hooks.Register(webhooks.Endpoint{ID: "acme", URL: server.URL, Secret: []byte("secret")}); err != nil {
+		t.Fatal(err)
+	}
+	publish := database.WebhookPublisher(hooks)
+
+	// The publisher returns while the endpoint is still busy.
+	if err := publish(context.Background(), database.OutboxEvent{ID: 1, Name: "order.created", Payload: []byte(`{}`)}); err != nil {
+		t.Fatal(err)
+	}
+	close(release)
+	select {
+	case <-received:
+	case <-time.After(2 * time.Second):
+		t.Fatal("event was not delivered")
+	}
+
+	_ = hooks.Shutdown(context.Background())
+	if err := publish(context.Background(), database.OutboxEvent{ID: 2, Name: "order.created", Payload: []byte(`{}`)}); !errors.Is(err, webhooks.ErrDispatcherClosed) {
+		t.Errorf("err = %v, want ErrDispatcherClosed", err)
+	}
+}
+
+func TestOutbox_StartStop(t *testing.T) {
+	pub := &recordingPublisher{}
+	db, outbox := setupOutbox(t, database.OutboxConfig{
+		Publisher: pub.publish,
+		Interval:  10 * time.Millisecond,
+	})
+	ctx := context.Background()
+
+	outbox.Start()
+	outbox.Start() // No-op.
+
+	err := database.WithTx(ctx, db, func(tx *database.Tx) error {
+		return tx.Publish(ctx, "user.created", "alice")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(pub.names()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	outbox.Stop()
+	outbox.Stop() // No-op.
+
+	if got := pub.names(); len(got) != 1 || got[0] != "user.created" {
+		t.Errorf("published = %v, want [user.created]", got)
+	}
+}
+
+func TestTx_PublishWithoutOutbox(t *testing.T) {
+	sqlDB := setupDB(t)
+	defer sqlDB.Close()
+	db := database.NewDB(sqlDB)
+	ctx := context.Background()
+
+	err := database.WithTx(ctx, db, func(tx *database.Tx) error {
+		return tx.Publish(ctx, "user.created", nil)
+	})
+	if !errors.Is(err, database.ErrNoOutbox) {
+		t.Errorf("err = %v, want ErrNoOutbox", err)
+	}
+}
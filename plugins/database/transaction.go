@@ -18,6 +18,7 @@ import (
 // all succeed (commit) or all fail (rollback).
 type Tx struct {
 	tx *sql.Tx
+	db *DB
 }
 
 // BeginTx starts a new database transaction.
@@ -41,7 +42,7 @@ func (d *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Tx{tx: tx}, nil
+	return &Tx{tx: tx, db: d}, nil
 }
 
 // Commit commits the transaction.
@@ -0,0 +1,71 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+
+	"github.com/coregx/fursy"
+)
+
+// ErrVersionConflict is returned by CheckVersion when a versioned UPDATE or
+// DELETE affected no rows: the row was modified (or deleted) concurrently.
+var ErrVersionConflict = errors.New("database: version conflict")
+
+// VersionETag returns the strong ETag of a row with an integer version
+// column, incremented on every update, for use with
+// fursy.Context.RequireIfMatch and the ETag response header.
+//
+// Example:
+//
+//	c.SetTypedHeader(database.VersionETag(user.Version))
+func VersionETag(version int64) fursy.ETag {
+	return fursy.ETag{Value: strconv.FormatInt(version, 10)}
+}
+
+// CheckVersion checks the result of an optimistic UPDATE or DELETE guarded
+// by the version column ("... WHERE id = ? AND version = ?"): it returns
+// ErrVersionConflict if no row was affected.
+//
+// Example:
+//
+//	router.PUT("/users/:id", func(c *fursy.Context) error {
+//	    db := database.MustGetDB(c)
+//	    ctx := c.Request.Context()
+//
+//	    var user User
+//	    // ... load the user with its version ...
+//	    if ok, err := c.RequireIfMatch(database.VersionETag(user.Version)); !ok {
+//	        return err
+//	    }
+//
+//	    err := database.CheckVersion(db.Exec(ctx,
+//	        "UPDATE users SET name = ?, version = version + 1 WHERE id = ? AND version = ?",
+//	        name, user.ID, user.Version))
+//	    if errors.Is(err, database.ErrVersionConflict) {
+//	        return c.Problem(fursy.PreconditionFailed("user has been modified"))
+//	    }
+//	    if err != nil {
+//	        return err
+//	    }
+//
+//	    c.SetTypedHeader(database.VersionETag(user.Version + 1))
+//	    return c.NoContent(204)
+//	})
+func CheckVersion(result sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
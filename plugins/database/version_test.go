@@ -0,0 +1,51 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/coregx/fursy/plugins/database"
+)
+
+// TestVersionETag tests ETags derived from version columns.
+func TestVersionETag(t *testing.T) {
+	if got := database.VersionETag(42).String(); got != `"42"` {
+		t.Errorf("VersionETag(42) = %s, want \"42\"", got)
+	}
+}
+
+// TestCheckVersion tests optimistic updates guarded by a version column.
+func TestCheckVersion(t *testing.T) {
+	db := database.NewDB(setupDB(t))
+	defer db.Close()
+	ctx := context.Background()
+
+	if _, err := db.Exec(ctx, "CREATE TABLE docs (id INTEGER PRIMARY KEY, body TEXT, version INTEGER)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(ctx, "INSERT INTO docs (id, body, version) VALUES (1, 'a', 1)"); err != nil {
+		t.Fatal(err)
+	}
+
+	update := func(version int64) error {
+		return database.CheckVersion(db.Exec(ctx,
+			"UPDATE docs SET body = 'b', version = version + 1 WHERE id = 1 AND version = ?", version))
+	}
+
+	if err := update(1); err != nil {
+		t.Fatalf("update with current version: %v", err)
+	}
+	if err := update(1); !errors.Is(err, database.ErrVersionConflict) {
+		t.Errorf("update with stale version: got %v, want ErrVersionConflict", err)
+	}
+
+	queryErr := database.CheckVersion(db.Exec(ctx, "UPDATE missing SET x = 1"))
+	if queryErr == nil || errors.Is(queryErr, database.ErrVersionConflict) {
+		t.Errorf("query error: got %v, want the driver error", queryErr)
+	}
+}
@@ -0,0 +1,8 @@
+module github.com/coregx/fursy/plugins/hypermedia
+
+go 1.25.0
+
+require github.com/coregx/fursy v0.1.0
+
+// Use local fursy module during development.
+replace github.com/coregx/fursy => ../..
@@ -0,0 +1,193 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package hypermedia
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/coregx/fursy"
+)
+
+// HALLink is a HAL link object.
+type HALLink struct {
+	// Href is the link target (URI or URI template).
+	Href string `json:"href"`
+
+	// Templated is true when Href is a URI template (RFC 6570).
+	Templated bool `json:"templated,omitempty"`
+
+	// Title is a human-readable label for the link.
+	Title string `json:"title,omitempty"`
+}
+
+// HALResource is a HAL resource: the JSON fields of Data plus _links and _embedded.
+//
+// Example:
+//
+//	res := hypermedia.NewHAL(user, "/users/1").
+//	    Link("orders", "/users/1/orders").
+//	    Embed("manager", hypermedia.NewHAL(manager, "/users/7"))
+//
+//	return hypermedia.HAL(c, 200, res)
+//	// {"id":1,"name":"Alice","_links":{"self":{"href":"/users/1"},...},"_embedded":{...}}
+type HALResource struct {
+	// Data is the resource state. It must encode to a JSON object (or be nil).
+	Data any
+
+	// Links contains the resource links, keyed by relation.
+	Links map[string]HALLink
+
+	// Embedded contains embedded resources, keyed by relation.
+	// Values are *HALResource or []*HALResource.
+	Embedded map[string]any
+}
+
+// NewHAL creates a HAL resource for data with a "self" link.
+// The self link is omitted if self is empty.
+func NewHAL(data any, self string) *HALResource {
+	res := &HALResource{Data: data}
+	if self != "" {
+		res.Link("self", self)
+	}
+	return res
+}
+
+// Link adds a link with the given relation and returns the resource for chaining.
+func (r *HALResource) Link(rel, href string) *HALResource {
+	if r.Links == nil {
+		r.Links = make(map[string]HALLink)
+	}
+	r.Links[rel] = HALLink{Href: href}
+	return r
+}
+
+// Embed adds embedded resources with the given relation and returns the
+// resource for chaining. A single resource is embedded as an object,
+// multiple resources (or none) as an array.
+func (r *HALResource) Embed(rel string, resources ...*HALResource) *HALResource {
+	if r.Embedded == nil {
+		r.Embedded = make(map[string]any)
+	}
+	if len(resources) == 1 {
+		r.Embedded[rel] = resources[0]
+	} else {
+		r.Embedded[rel] = append([]*HALResource{}, resources...)
+	}
+	return r
+}
+
+// MarshalJSON implements json.Marshaler.
+// The fields of Data are merged with the reserved _links and _embedded properties.
+func (r *HALResource) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]json.RawMessage)
+
+	if r.Data != nil {
+		data, err := json.Marshal(r.Data)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(data, []byte("null")) {
+			if err := json.Unmarshal(data, &fields); err != nil {
+				return nil, fmt.Errorf("hypermedia: HAL data must encode to a JSON object: %w", err)
+			}
+		}
+	}
+
+	if len(r.Links) > 0 {
+		links, err := json.Marshal(r.Links)
+		if err != nil {
+			return nil, err
+		}
+		fields["_links"] = links
+	}
+	if len(r.Embedded) > 0 {
+		embedded, err := json.Marshal(r.Embedded)
+		if err != nil {
+			return nil, err
+		}
+		fields["_embedded"] = embedded
+	}
+
+	return json.Marshal(fields)
+}
+
+// HAL sends a HAL resource with the given status code.
+//
+// Example:
+//
+//	router.GET("/users/:id", func(c *fursy.Context) error {
+//	    user := getUser(c.Param("id"))
+//	    return hypermedia.HAL(c, 200, hypermedia.NewHAL(user, c.Request.URL.Path))
+//	})
+func HAL(c *fursy.Context, status int, res *HALResource) error {
+	c.Response.Header().Set("Content-Type", MIMEHAL)
+	c.Response.WriteHeader(status)
+	return json.NewEncoder(c.Response).Encode(res)
+}
+
+// HALPage sends a 200 OK HAL collection with items embedded under rel,
+// pagination links ("self", "first", "prev", "next") and the total count.
+//
+// Items that are not already *HALResource are wrapped without links.
+// Links are built by Context.PageLinks from the current request URL, and the
+// RFC 8288 Link header is set as well.
+//
+// Example:
+//
+//	router.GET("/users", func(c *fursy.Context) error {
+//	    p, err := c.Pagination()
+//	    if err != nil {
+//	        return c.Problem(fursy.ValidationProblem(err.(fursy.ValidationErrors)))
+//	    }
+//	    users, total := db.ListUsers(p.Limit, p.Offset)
+//	    return hypermedia.HALPage(c, "users", users, p.Meta(total, ""))
+//	})
+func HALPage(c *fursy.Context, rel string, items any, meta fursy.PageMeta) error {
+	resources, err := halResources(items)
+	if err != nil {
+		return err
+	}
+
+	var data any
+	if meta.Total >= 0 {
+		data = map[string]int{"total": meta.Total}
+	}
+
+	page := NewHAL(data, c.Request.URL.RequestURI())
+	for _, l := range c.PageLinks(meta) {
+		page.Link(l.Rel, l.Href)
+	}
+	page.Embedded = map[string]any{rel: resources}
+
+	c.SetPageLinks(meta)
+	return HAL(c, http.StatusOK, page)
+}
+
+// halResources converts a slice into HAL resources.
+func halResources(items any) ([]*HALResource, error) {
+	if items == nil {
+		return []*HALResource{}, nil
+	}
+
+	rv := reflect.ValueOf(items)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("hypermedia: HALPage items must be a slice, got %T", items)
+	}
+
+	resources := make([]*HALResource, 0, rv.Len())
+	for i := range rv.Len() {
+		item := rv.Index(i).Interface()
+		if res, ok := item.(*HALResource); ok {
+			resources = append(resources, res)
+		} else {
+			resources = append(resources, &HALResource{Data: item})
+		}
+	}
+	return resources, nil
+}
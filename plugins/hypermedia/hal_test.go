@@ -0,0 +1,147 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package hypermedia
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/coregx/fursy"
+)
+
+type person struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// TestHALResource_MarshalJSON tests merging data fields with _links and _embedded.
+func TestHALResource_MarshalJSON(t *testing.T) {
+	res := NewHAL(person{ID: 1, Name: "Alice"}, "/people/1").
+		Link("friends", "/people/1/friends").
+		Embed("manager", NewHAL(person{ID: 7, Name: "Bob"}, "/people/7"))
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	want := `{"_embedded":{"manager":{"_links":{"self":{"href":"/people/7"}},"id":7,"name":"Bob"}},` +
+		`"_links":{"friends":{"href":"/people/1/friends"},"self":{"href":"/people/1"}},"id":1,"name":"Alice"}`
+	if string(data) != want {
+		t.Errorf("expected %s, got %s", want, data)
+	}
+}
+
+// TestHALResource_Embed tests that multiple embedded resources encode as an array.
+func TestHALResource_Embed(t *testing.T) {
+	res := NewHAL(nil, "").Embed("items", NewHAL(person{ID: 1}, ""), NewHAL(person{ID: 2}, ""))
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != `{"_embedded":{"items":[{"id":1,"name":""},{"id":2,"name":""}]}}` {
+		t.Errorf("unexpected HAL document: %s", data)
+	}
+}
+
+// TestHALResource_NonObject tests that data must encode to a JSON object.
+func TestHALResource_NonObject(t *testing.T) {
+	if _, err := json.Marshal(NewHAL([]int{1, 2}, "/numbers")); err == nil {
+		t.Error("expected error for non-object data")
+	}
+}
+
+// TestHAL tests sending a HAL resource.
+func TestHAL(t *testing.T) {
+	r := fursy.New()
+	r.GET("/people/:id", func(c *fursy.Context) error {
+		return HAL(c, http.StatusOK, NewHAL(person{ID: 1, Name: "Alice"}, c.Request.URL.Path))
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/people/1", nil))
+
+	if ct := w.Header().Get("Content-Type"); ct != MIMEHAL {
+		t.Errorf("expected Content-Type %q, got %q", MIMEHAL, ct)
+	}
+
+	var doc struct {
+		Name  string             `json:"name"`
+		Links map[string]HALLink `json:"_links"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if doc.Name != "Alice" || doc.Links["self"].Href != "/people/1" {
+		t.Errorf("unexpected document: %s", w.Body.String())
+	}
+}
+
+// TestHALPage tests collections with embedded items, pagination links and total.
+func TestHALPage(t *testing.T) {
+	r := fursy.New()
+	r.GET("/people", func(c *fursy.Context) error {
+		p, err := c.Pagination()
+		if err != nil {
+			return err
+		}
+		items := []any{
+			NewHAL(person{ID: 1, Name: "Alice"}, "/people/1"),
+			person{ID: 2, Name: "Bob"},
+		}
+		return HALPage(c, "people", items, p.Meta(5, ""))
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/people?limit=2", nil))
+
+	var doc struct {
+		Total    int                `json:"total"`
+		Links    map[string]HALLink `json:"_links"`
+		Embedded struct {
+			People []struct {
+				ID    int                `json:"id"`
+				Links map[string]HALLink `json:"_links"`
+			} `json:"people"`
+		} `json:"_embedded"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if doc.Total != 5 {
+		t.Errorf("expected total 5, got %d", doc.Total)
+	}
+	if doc.Links["self"].Href != "/people?limit=2" || doc.Links["next"].Href != "/people?limit=2&offset=2" {
+		t.Errorf("unexpected links: %+v", doc.Links)
+	}
+	if _, ok := doc.Links["prev"]; ok {
+		t.Error("expected no prev link on the first page")
+	}
+	if len(doc.Embedded.People) != 2 || doc.Embedded.People[0].Links["self"].Href != "/people/1" || doc.Embedded.People[1].ID != 2 {
+		t.Errorf("unexpected embedded items: %s", w.Body.String())
+	}
+	if w.Header().Get("Link") == "" {
+		t.Error("expected Link header")
+	}
+}
+
+// TestHALPage_NotSlice tests that non-slice items are rejected.
+func TestHALPage_NotSlice(t *testing.T) {
+	r := fursy.New()
+	var err error
+	r.GET("/people", func(c *fursy.Context) error {
+		err = HALPage(c, "people", person{}, fursy.PageMeta{})
+		return nil
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/people", nil))
+	if err == nil {
+		t.Error("expected error for non-slice items")
+	}
+}
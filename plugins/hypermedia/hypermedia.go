@@ -0,0 +1,87 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package hypermedia provides JSON:API and HAL response helpers for fursy.
+//
+// Both formats are built from regular Go structs:
+//   - JSON:API (application/vnd.api+json): resource objects with
+//     attributes and relationships, error objects mapped from fursy.Problem
+//   - HAL (application/hal+json): struct fields plus _links and _embedded
+//
+// Paginated collections include first/prev/next links built by
+// fursy.Context.PageLinks from the current request URL, so links stay
+// consistent with the RFC 8288 Link header set by Context.SetPageLinks.
+//
+// Example:
+//
+//	type Article struct {
+//	    ID     int    `jsonapi:"primary,articles"`
+//	    Title  string `json:"title"`
+//	    Author *User  `jsonapi:"relation,author"`
+//	}
+//
+//	router.GET("/articles", func(c *fursy.Context) error {
+//	    p, err := c.Pagination()
+//	    if err != nil {
+//	        return hypermedia.JSONAPIError(c, fursy.ValidationProblem(err.(fursy.ValidationErrors)))
+//	    }
+//	    articles, total := listArticles(p.Limit, p.Offset)
+//	    return hypermedia.JSONAPIPage(c, articles, p.Meta(total, ""))
+//	})
+package hypermedia
+
+import (
+	"github.com/coregx/fursy"
+)
+
+// Media types.
+const (
+	// MIMEJSONAPI is the JSON:API media type.
+	MIMEJSONAPI = "application/vnd.api+json"
+
+	// MIMEHAL is the HAL media type.
+	MIMEHAL = "application/hal+json"
+)
+
+// Register registers JSON:API and HAL renderers on the router, so
+// Context.Negotiate and Box.NegotiateRes serve both formats on request.
+//
+// Values that are not already a *Document or *HALResource are converted
+// with MarshalDocument (JSON:API) or NewHAL with a self link to the
+// current request (HAL).
+//
+// Example:
+//
+//	router := hypermedia.Register(fursy.New())
+//
+//	router.GET("/users/:id", func(c *fursy.Context) error {
+//	    return c.Negotiate(200, getUser(c.Param("id"))) // JSON, JSON:API or HAL
+//	})
+func Register(r *fursy.Router) *fursy.Router {
+	return r.
+		RegisterRenderer(MIMEJSONAPI, renderJSONAPI).
+		RegisterRenderer(MIMEHAL, renderHAL)
+}
+
+// renderJSONAPI renders data as a JSON:API document.
+func renderJSONAPI(c *fursy.Context, status int, data any) error {
+	if doc, ok := data.(*Document); ok {
+		return writeJSONAPI(c, status, doc)
+	}
+
+	doc, err := MarshalDocument(data)
+	if err != nil {
+		return err
+	}
+	return writeJSONAPI(c, status, doc)
+}
+
+// renderHAL renders data as a HAL resource.
+func renderHAL(c *fursy.Context, status int, data any) error {
+	res, ok := data.(*HALResource)
+	if !ok {
+		res = NewHAL(data, c.Request.URL.RequestURI())
+	}
+	return HAL(c, status, res)
+}
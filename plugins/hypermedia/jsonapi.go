@@ -0,0 +1,445 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package hypermedia
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/coregx/fursy"
+)
+
+// JSON:API errors.
+var (
+	// ErrNoPrimary is returned when a struct has no `jsonapi:"primary,<type>"` field.
+	ErrNoPrimary = errors.New("hypermedia: struct has no jsonapi primary field")
+
+	// ErrNotStruct is returned when a value is not a struct or pointer to struct.
+	ErrNotStruct = errors.New("hypermedia: value is not a struct")
+)
+
+// jsonapiTag is the struct tag used to mark primary keys and relationships.
+//
+// Supported values:
+//   - `jsonapi:"primary,<type>"` - the resource ID; <type> is the resource type
+//   - `jsonapi:"relation,<name>"` - a relationship (struct, pointer or slice)
+//   - `jsonapi:"-"` - the field is ignored
+//
+// All other exported fields are attributes, named by their `json` tag.
+const jsonapiTag = "jsonapi"
+
+// Document is a JSON:API top-level document.
+type Document struct {
+	// Data is the primary data: *Resource, []*Resource or nil.
+	Data any `json:"data,omitempty"`
+
+	// Errors contains error objects. Mutually exclusive with Data.
+	Errors []Error `json:"errors,omitempty"`
+
+	// Meta contains non-standard meta-information (e.g., "total").
+	Meta map[string]any `json:"meta,omitempty"`
+
+	// Links contains top-level links (e.g., "self", "next").
+	Links map[string]string `json:"links,omitempty"`
+}
+
+// Resource is a JSON:API resource object.
+type Resource struct {
+	// Type is the resource type (e.g., "articles").
+	Type string `json:"type"`
+
+	// ID is the resource identifier.
+	ID string `json:"id"`
+
+	// Attributes contains the resource fields.
+	Attributes map[string]any `json:"attributes,omitempty"`
+
+	// Relationships contains references to related resources.
+	Relationships map[string]Relationship `json:"relationships,omitempty"`
+
+	// Links contains resource links (e.g., "self").
+	Links map[string]string `json:"links,omitempty"`
+}
+
+// ResourceIdentifier identifies a related resource.
+type ResourceIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// Relationship is a JSON:API relationship object.
+type Relationship struct {
+	// Data is the resource linkage: *ResourceIdentifier (to-one, nil if empty)
+	// or []ResourceIdentifier (to-many).
+	Data any `json:"data"`
+
+	// Links contains relationship links (e.g., "related").
+	Links map[string]string `json:"links,omitempty"`
+}
+
+// Error is a JSON:API error object.
+type Error struct {
+	// Status is the HTTP status code as a string.
+	Status string `json:"status,omitempty"`
+
+	// Code is an application-specific error code.
+	Code string `json:"code,omitempty"`
+
+	// Title is a short summary of the problem.
+	Title string `json:"title,omitempty"`
+
+	// Detail is an explanation specific to this occurrence.
+	Detail string `json:"detail,omitempty"`
+
+	// Source points to the part of the request that caused the error.
+	Source *ErrorSource `json:"source,omitempty"`
+
+	// Meta contains non-standard meta-information.
+	Meta map[string]any `json:"meta,omitempty"`
+}
+
+// ErrorSource points to the cause of an error.
+type ErrorSource struct {
+	// Pointer is a JSON Pointer to the request document field (e.g., "/data/attributes/email").
+	Pointer string `json:"pointer,omitempty"`
+
+	// Parameter is the query parameter that caused the error.
+	Parameter string `json:"parameter,omitempty"`
+}
+
+// MarshalResource converts a struct (or pointer to struct) into a JSON:API resource.
+//
+// Example:
+//
+//	type User struct {
+//	    ID    int    `jsonapi:"primary,users"`
+//	    Name  string `json:"name"`
+//	    Email string `json:"email,omitempty"`
+//	}
+//
+//	res, err := hypermedia.MarshalResource(User{ID: 1, Name: "Alice"})
+//	// {"type":"users","id":"1","attributes":{"name":"Alice"}}
+func MarshalResource(v any) (*Resource, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, ErrNotStruct
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, ErrNotStruct
+	}
+
+	res := &Resource{}
+	hasPrimary := false
+	if err := marshalFields(rv, res, &hasPrimary); err != nil {
+		return nil, err
+	}
+	if !hasPrimary {
+		return nil, fmt.Errorf("%w: %s", ErrNoPrimary, rv.Type())
+	}
+
+	return res, nil
+}
+
+// MarshalDocument converts a struct or a slice of structs into a JSON:API document.
+// A nil value produces a document with null data.
+func MarshalDocument(v any) (*Document, error) {
+	if v == nil {
+		return &Document{}, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		res, err := MarshalResource(v)
+		if err != nil {
+			return nil, err
+		}
+		return &Document{Data: res}, nil
+	}
+
+	resources := make([]*Resource, 0, rv.Len())
+	for i := range rv.Len() {
+		res, err := MarshalResource(rv.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, res)
+	}
+	return &Document{Data: resources}, nil
+}
+
+// marshalFields fills res from the fields of the struct value rv.
+// Embedded structs without a json name are flattened.
+func marshalFields(rv reflect.Value, res *Resource, hasPrimary *bool) error {
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+		value := rv.Field(i)
+
+		tag := field.Tag.Get(jsonapiTag)
+		if tag == "-" {
+			continue
+		}
+
+		if kind, name, ok := strings.Cut(tag, ","); ok {
+			switch kind {
+			case "primary":
+				res.Type = name
+				res.ID = formatID(value)
+				*hasPrimary = true
+			case "relation":
+				rel, err := marshalRelationship(value)
+				if err != nil {
+					return fmt.Errorf("hypermedia: relation %q: %w", name, err)
+				}
+				if res.Relationships == nil {
+					res.Relationships = make(map[string]Relationship)
+				}
+				res.Relationships[name] = rel
+			default:
+				return fmt.Errorf("hypermedia: unknown jsonapi tag %q on %s.%s", tag, rt, field.Name)
+			}
+			continue
+		}
+
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			embedded := value
+			if embedded.Kind() == reflect.Pointer {
+				if embedded.IsNil() {
+					continue
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if err := marshalFields(embedded, res, hasPrimary); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name, omitEmpty, skip := jsonFieldName(field)
+		if skip || (omitEmpty && value.IsZero()) {
+			continue
+		}
+		if res.Attributes == nil {
+			res.Attributes = make(map[string]any)
+		}
+		res.Attributes[name] = value.Interface()
+	}
+
+	return nil
+}
+
+// marshalRelationship converts a relationship field into resource linkage.
+func marshalRelationship(value reflect.Value) (Relationship, error) {
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		ids := make([]ResourceIdentifier, 0, value.Len())
+		for i := range value.Len() {
+			res, err := MarshalResource(value.Index(i).Interface())
+			if err != nil {
+				return Relationship{}, err
+			}
+			ids = append(ids, ResourceIdentifier{Type: res.Type, ID: res.ID})
+		}
+		return Relationship{Data: ids}, nil
+	}
+
+	if value.Kind() == reflect.Pointer && value.IsNil() {
+		return Relationship{Data: nil}, nil
+	}
+
+	res, err := MarshalResource(value.Interface())
+	if err != nil {
+		return Relationship{}, err
+	}
+	return Relationship{Data: &ResourceIdentifier{Type: res.Type, ID: res.ID}}, nil
+}
+
+// jsonFieldName returns the attribute name from the field's json tag.
+func jsonFieldName(field reflect.StructField) (name string, omitEmpty, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, slices.Contains(strings.Split(opts, ","), "omitempty"), false
+}
+
+// formatID formats a primary key value as a string.
+func formatID(value reflect.Value) string {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+	if s, ok := value.Interface().(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+// JSONAPI sends v as a JSON:API document with the given status code.
+// v may be a struct, a slice of structs or a *Document.
+//
+// Example:
+//
+//	router.GET("/articles/:id", func(c *fursy.Context) error {
+//	    article, err := getArticle(c.Param("id"))
+//	    if err != nil {
+//	        return hypermedia.JSONAPIError(c, fursy.NotFound("article not found"))
+//	    }
+//	    return hypermedia.JSONAPI(c, 200, article)
+//	})
+func JSONAPI(c *fursy.Context, status int, v any) error {
+	return renderJSONAPI(c, status, v)
+}
+
+// JSONAPIPage sends a 200 OK JSON:API collection document with pagination
+// links ("first", "prev", "next") and the total in meta.
+//
+// Links are built by Context.PageLinks from the current request URL, and the
+// RFC 8288 Link header is set as well.
+//
+// Example:
+//
+//	router.GET("/articles", func(c *fursy.Context) error {
+//	    p, err := c.Pagination()
+//	    if err != nil {
+//	        return hypermedia.JSONAPIError(c, fursy.ValidationProblem(err.(fursy.ValidationErrors)))
+//	    }
+//	    articles, total := db.ListArticles(p.Limit, p.Offset)
+//	    return hypermedia.JSONAPIPage(c, articles, p.Meta(total, ""))
+//	})
+func JSONAPIPage(c *fursy.Context, items any, meta fursy.PageMeta) error {
+	doc, err := MarshalDocument(items)
+	if err != nil {
+		return err
+	}
+	if doc.Data == nil {
+		doc.Data = []*Resource{}
+	}
+
+	doc.Links = map[string]string{"self": c.Request.URL.RequestURI()}
+	for _, l := range c.PageLinks(meta) {
+		doc.Links[l.Rel] = l.Href
+	}
+	if meta.Total >= 0 {
+		doc.Meta = map[string]any{"total": meta.Total}
+	}
+
+	c.SetPageLinks(meta)
+	return writeJSONAPI(c, http.StatusOK, doc)
+}
+
+// JSONAPIError sends a fursy.Problem as a JSON:API error document.
+//
+// The problem is converted with ErrorsFromProblem, so validation problems
+// produce one error object per invalid field.
+//
+// Example:
+//
+//	if errs := validate(input); !errs.IsEmpty() {
+//	    return hypermedia.JSONAPIError(c, fursy.ValidationProblem(errs))
+//	}
+func JSONAPIError(c *fursy.Context, p fursy.Problem) error {
+	return writeJSONAPI(c, p.Status, &Document{Errors: ErrorsFromProblem(p)})
+}
+
+// ErrorsFromProblem maps a fursy.Problem to JSON:API error objects.
+//
+// Validation problems (see fursy.ValidationProblem) produce one error per
+// violation with its rule as code and a source pointer under
+// "/data/attributes"; a map of field names to messages in the "errors"
+// extension is also accepted. Other problems
+// produce a single error; extensions are copied into meta.
+func ErrorsFromProblem(p fursy.Problem) []Error {
+	status := ""
+	if p.Status != 0 {
+		status = strconv.Itoa(p.Status)
+	}
+
+	if violations, ok := p.Extensions["errors"].([]fursy.Violation); ok && len(violations) > 0 {
+		violations = slices.Clone(violations)
+		slices.SortStableFunc(violations, func(a, b fursy.Violation) int {
+			return strings.Compare(a.Pointer, b.Pointer)
+		})
+
+		errs := make([]Error, 0, len(violations))
+		for _, v := range violations {
+			e := Error{
+				Status: status,
+				Code:   v.Code,
+				Title:  p.Title,
+				Detail: v.Detail,
+				Source: &ErrorSource{Pointer: "/data/attributes" + v.Pointer},
+			}
+			if len(v.Params) > 0 {
+				e.Meta = v.Params
+			}
+			errs = append(errs, e)
+		}
+		return errs
+	}
+
+	if fields, ok := p.Extensions["errors"].(map[string]string); ok && len(fields) > 0 {
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		errs := make([]Error, 0, len(names))
+		for _, name := range names {
+			errs = append(errs, Error{
+				Status: status,
+				Title:  p.Title,
+				Detail: fields[name],
+				Source: &ErrorSource{Pointer: "/data/attributes/" + name},
+			})
+		}
+		return errs
+	}
+
+	e := Error{
+		Status: status,
+		Title:  p.Title,
+		Detail: p.Detail,
+	}
+	if p.Type != "" && p.Type != "about:blank" {
+		e.Code = p.Type
+	}
+	if len(p.Extensions) > 0 {
+		e.Meta = make(map[string]any, len(p.Extensions))
+		for k, v := range p.Extensions {
+			e.Meta[k] = v
+		}
+	}
+	return []Error{e}
+}
+
+// writeJSONAPI encodes doc with the JSON:API media type.
+func writeJSONAPI(c *fursy.Context, status int, doc *Document) error {
+	c.Response.Header().Set("Content-Type", MIMEJSONAPI)
+	c.Response.WriteHeader(status)
+	return json.NewEncoder(c.Response).Encode(doc)
+}
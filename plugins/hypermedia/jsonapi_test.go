@@ -0,0 +1,269 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package hypermedia
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/coregx/fursy"
+)
+
+type author struct {
+	ID   int    `jsonapi:"primary,people"`
+	Name string `json:"name"`
+}
+
+type tag struct {
+	Name string `jsonapi:"primary,tags"`
+}
+
+type article struct {
+	ID       int     `jsonapi:"primary,articles"`
+	Title    string  `json:"title"`
+	Body     string  `json:"body,omitempty"`
+	Secret   string  `json:"-"`
+	Author   *author `jsonapi:"relation,author"`
+	Tags     []tag   `jsonapi:"relation,tags"`
+	internal string
+}
+
+// TestMarshalResource tests attributes, relationships and ignored fields.
+func TestMarshalResource(t *testing.T) {
+	res, err := MarshalResource(&article{
+		ID:       1,
+		Title:    "Hello",
+		Secret:   "hidden",
+		Author:   &author{ID: 9, Name: "Alice"},
+		Tags:     []tag{{Name: "go"}, {Name: "http"}},
+		internal: "x",
+	})
+	if err != nil {
+		t.Fatalf("MarshalResource failed: %v", err)
+	}
+
+	if res.Type != "articles" || res.ID != "1" {
+		t.Errorf("expected articles/1, got %s/%s", res.Type, res.ID)
+	}
+	if len(res.Attributes) != 1 || res.Attributes["title"] != "Hello" {
+		t.Errorf("expected only title attribute, got %v", res.Attributes)
+	}
+
+	a, ok := res.Relationships["author"].Data.(*ResourceIdentifier)
+	if !ok || *a != (ResourceIdentifier{Type: "people", ID: "9"}) {
+		t.Errorf("unexpected author linkage: %#v", res.Relationships["author"].Data)
+	}
+	tags, ok := res.Relationships["tags"].Data.([]ResourceIdentifier)
+	if !ok || len(tags) != 2 || tags[1] != (ResourceIdentifier{Type: "tags", ID: "http"}) {
+		t.Errorf("unexpected tags linkage: %#v", res.Relationships["tags"].Data)
+	}
+}
+
+// TestMarshalResource_NilRelation tests that an empty to-one relationship encodes as null.
+func TestMarshalResource_NilRelation(t *testing.T) {
+	res, err := MarshalResource(article{ID: 2, Title: "Draft"})
+	if err != nil {
+		t.Fatalf("MarshalResource failed: %v", err)
+	}
+
+	data, _ := json.Marshal(res.Relationships["author"])
+	if string(data) != `{"data":null}` {
+		t.Errorf("expected null linkage, got %s", data)
+	}
+	data, _ = json.Marshal(res.Relationships["tags"])
+	if string(data) != `{"data":[]}` {
+		t.Errorf("expected empty to-many linkage, got %s", data)
+	}
+}
+
+// TestMarshalResource_Errors tests invalid inputs.
+func TestMarshalResource_Errors(t *testing.T) {
+	if _, err := MarshalResource(struct{ Name string }{"x"}); !errors.Is(err, ErrNoPrimary) {
+		t.Errorf("expected ErrNoPrimary, got %v", err)
+	}
+	if _, err := MarshalResource(42); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("expected ErrNotStruct, got %v", err)
+	}
+	if _, err := MarshalResource((*article)(nil)); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("expected ErrNotStruct for nil pointer, got %v", err)
+	}
+}
+
+// TestJSONAPI tests rendering a single resource document.
+func TestJSONAPI(t *testing.T) {
+	r := fursy.New()
+	r.GET("/articles/:id", func(c *fursy.Context) error {
+		return JSONAPI(c, http.StatusOK, article{ID: 1, Title: "Hello"})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/articles/1", nil))
+
+	if ct := w.Header().Get("Content-Type"); ct != MIMEJSONAPI {
+		t.Errorf("expected Content-Type %q, got %q", MIMEJSONAPI, ct)
+	}
+
+	var doc struct {
+		Data struct {
+			Type       string         `json:"type"`
+			ID         string         `json:"id"`
+			Attributes map[string]any `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if doc.Data.Type != "articles" || doc.Data.ID != "1" || doc.Data.Attributes["title"] != "Hello" {
+		t.Errorf("unexpected document: %s", w.Body.String())
+	}
+}
+
+// TestJSONAPIPage tests collection documents with pagination links and meta.
+func TestJSONAPIPage(t *testing.T) {
+	r := fursy.New()
+	r.GET("/articles", func(c *fursy.Context) error {
+		p, err := c.Pagination()
+		if err != nil {
+			return err
+		}
+		return JSONAPIPage(c, []article{{ID: 3, Title: "C"}, {ID: 4, Title: "D"}}, p.Meta(10, ""))
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/articles?limit=2&offset=2", nil))
+
+	var doc struct {
+		Data  []Resource        `json:"data"`
+		Links map[string]string `json:"links"`
+		Meta  map[string]int    `json:"meta"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if len(doc.Data) != 2 || doc.Data[0].ID != "3" {
+		t.Errorf("unexpected data: %+v", doc.Data)
+	}
+	if doc.Meta["total"] != 10 {
+		t.Errorf("expected total 10, got %v", doc.Meta)
+	}
+	for rel, href := range map[string]string{
+		"self":  "/articles?limit=2&offset=2",
+		"next":  "/articles?limit=2&offset=4",
+		"prev":  "/articles?limit=2&offset=0",
+		"first": "/articles?limit=2&offset=0",
+	} {
+		if doc.Links[rel] != href {
+			t.Errorf("expected %s link %q, got %q", rel, href, doc.Links[rel])
+		}
+	}
+	if w.Header().Get("Link") == "" {
+		t.Error("expected Link header")
+	}
+}
+
+// TestJSONAPIPage_Empty tests that an empty collection encodes data as [].
+func TestJSONAPIPage_Empty(t *testing.T) {
+	r := fursy.New()
+	r.GET("/articles", func(c *fursy.Context) error {
+		return JSONAPIPage(c, []article(nil), fursy.PageMeta{Total: 0, Limit: 10})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/articles", nil))
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if string(doc["data"]) != "[]" {
+		t.Errorf("expected empty data array, got %s", doc["data"])
+	}
+}
+
+// TestErrorsFromProblem_Validation tests per-field error objects with source pointers.
+func TestErrorsFromProblem_Validation(t *testing.T) {
+	errs := ErrorsFromProblem(fursy.ValidationProblem(fursy.ValidationErrors{
+		{Field: "title", Tag: "required", Message: "is required"},
+		{Field: "body", Tag: "min", Message: "is too short", Params: map[string]any{"limit": 10}},
+	}))
+
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %d", len(errs))
+	}
+	if errs[0].Source.Pointer != "/data/attributes/body" || errs[0].Detail != "is too short" ||
+		errs[0].Code != "min" || errs[0].Meta["limit"] != 10 {
+		t.Errorf("unexpected first error: %+v", errs[0])
+	}
+	if errs[1].Status != "422" || errs[1].Source.Pointer != "/data/attributes/title" {
+		t.Errorf("unexpected second error: %+v", errs[1])
+	}
+}
+
+// TestJSONAPIError tests sending a problem as a JSON:API error document.
+func TestJSONAPIError(t *testing.T) {
+	r := fursy.New()
+	r.GET("/articles/:id", func(c *fursy.Context) error {
+		p := fursy.NotFound("article not found")
+		p.Extensions = map[string]any{"id": c.Param("id")}
+		return JSONAPIError(c, p)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/articles/7", nil))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+
+	var doc struct {
+		Data   any     `json:"data"`
+		Errors []Error `json:"errors"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if doc.Data != nil || len(doc.Errors) != 1 {
+		t.Fatalf("unexpected document: %s", w.Body.String())
+	}
+	e := doc.Errors[0]
+	if e.Status != "404" || e.Detail != "article not found" || e.Meta["id"] != "7" {
+		t.Errorf("unexpected error object: %+v", e)
+	}
+}
+
+// TestRegister_Negotiate tests JSON:API and HAL rendering via content negotiation.
+func TestRegister_Negotiate(t *testing.T) {
+	r := Register(fursy.New())
+	r.GET("/articles/:id", func(c *fursy.Context) error {
+		return c.Negotiate(http.StatusOK, article{ID: 1, Title: "Hello"})
+	})
+
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{MIMEJSONAPI, `"type":"articles"`},
+		{MIMEHAL, `"_links":{"self":{"href":"/articles/1"}}`},
+		{fursy.MIMEApplicationJSON, `"title":"Hello"`},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/articles/1", nil)
+		req.Header.Set("Accept", tt.accept)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if !json.Valid(w.Body.Bytes()) {
+			t.Fatalf("%s: invalid JSON: %s", tt.accept, w.Body.String())
+		}
+		if !strings.Contains(w.Body.String(), tt.want) {
+			t.Errorf("%s: expected body to contain %s, got %s", tt.accept, tt.want, w.Body.String())
+		}
+	}
+}
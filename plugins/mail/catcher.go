@@ -0,0 +1,69 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package mail
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// Catcher is a development Sender that logs messages instead of sending them.
+//
+// Caught messages are also kept in memory, which makes Catcher useful in tests.
+type Catcher struct {
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	messages []Message
+}
+
+// NewCatcher creates a Catcher logging to logger (slog.Default() if nil).
+//
+// Example:
+//
+//	catcher := mail.NewCatcher(nil)
+//	mailer := mail.New(mail.Config{Sender: catcher, HTML: templates})
+//	// ...
+//	msgs := catcher.Messages()
+func NewCatcher(logger *slog.Logger) *Catcher {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Catcher{logger: logger}
+}
+
+// Send logs and records the message.
+func (c *Catcher) Send(ctx context.Context, msg *Message) error {
+	c.logger.InfoContext(ctx, "mail: caught message",
+		"from", msg.From,
+		"to", msg.To,
+		"cc", msg.Cc,
+		"bcc", msg.Bcc,
+		"subject", msg.Subject,
+		"text", msg.Text,
+		"html_bytes", len(msg.HTML),
+		"attachments", len(msg.Attachments),
+	)
+
+	c.mu.Lock()
+	c.messages = append(c.messages, *msg)
+	c.mu.Unlock()
+	return nil
+}
+
+// Messages returns the caught messages in send order.
+func (c *Catcher) Messages() []Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Message(nil), c.messages...)
+}
+
+// Reset discards the caught messages.
+func (c *Catcher) Reset() {
+	c.mu.Lock()
+	c.messages = nil
+	c.mu.Unlock()
+}
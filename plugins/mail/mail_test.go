@@ -0,0 +1,336 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package mail_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	htmltemplate "html/template"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	netmail "net/mail"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/coregx/fursy"
+	"github.com/coregx/fursy/plugins/mail"
+)
+
+func TestMessage_Bytes(t *testing.T) {
+	msg := &mail.Message{
+		From:    "Shop <shop@example.com>",
+		To:      []string{"Jürgen <a@example.com>", "b@example.com"},
+		Bcc:     []string{"hidden@example.com"},
+		Subject: "Grüße",
+		Text:    "Hello",
+		HTML:    "<p>Hello</p>",
+		Headers: map[string]string{"x-campaign": "spring"},
+		Attachments: []mail.Attachment{
+			{Filename: "invoice.pdf", Data: []byte("%PDF")},
+		},
+	}
+	data, err := msg.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := netmail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := parsed.Header
+	if subject, _ := new(mime.WordDecoder).DecodeHeader(h.Get("Subject")); subject != "Grüße" {
+		t.Errorf("Subject = %q", subject)
+	}
+	if to, _ := h.AddressList("To"); len(to) != 2 || to[0].Name != "Jürgen" {
+		t.Errorf("To = %v", to)
+	}
+	if h.Get("Bcc") != "" || strings.Contains(string(data), "hidden@") {
+		t.Error("Bcc must not appear in the message")
+	}
+	if h.Get("X-Campaign") != "spring" || !strings.HasSuffix(h.Get("Message-Id"), "@example.com>") {
+		t.Errorf("headers = %v", h)
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	if mediaType != "multipart/mixed" {
+		t.Fatalf("Content-Type = %q", mediaType)
+	}
+	r := multipart.NewReader(parsed.Body, params["boundary"])
+	body, _ := r.NextPart()
+	if ct := body.Header.Get("Content-Type"); !strings.HasPrefix(ct, "multipart/alternative") {
+		t.Errorf("body Content-Type = %q", ct)
+	}
+	attachment, _ := r.NextPart()
+	if attachment.FileName() != "invoice.pdf" || attachment.Header.Get("Content-Type") != "application/pdf" {
+		t.Errorf("attachment headers = %v", attachment.Header)
+	}
+}
+
+func TestMessage_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  mail.Message
+	}{
+		{"no from", mail.Message{To: []string{"a@example.com"}}},
+		{"no recipients", mail.Message{From: "a@example.com"}},
+		{"bad recipient", mail.Message{From: "a@example.com", To: []string{"a@example.com\r\nBcc: x@example.com"}}},
+		{"subject injection", mail.Message{From: "a@example.com", To: []string{"b@example.com"}, Subject: "Hi\r\nBcc: x@example.com"}},
+		{"header injection", mail.Message{From: "a@example.com", To: []string{"b@example.com"}, Headers: map[string]string{"X-A": "1\nBcc: x@example.com"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.msg.Bytes(); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+// discardLogger returns a logger discarding all output.
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestMailer_SendTemplate(t *testing.T) {
+	pages := htmltemplate.Must(htmltemplate.New("").Parse(`
+{{define "welcome.subject"}}Welcome, {{.Name}} & co{{end}}
+{{define "welcome.html"}}<p>Hello {{.Name}}</p>{{end}}
+{{define "only-text.txt"}}plain{{end}}`))
+	texts := texttemplate.Must(texttemplate.New("").Parse(`{{define "welcome.txt"}}Hello {{.Name}}{{end}}`))
+
+	catcher := mail.NewCatcher(discardLogger())
+	mailer := mail.New(mail.Config{Sender: catcher, From: "shop@example.com", HTML: pages, Text: texts})
+
+	data := map[string]string{"Name": "<Bob>"}
+	if err := mailer.SendTemplate(context.Background(), &mail.Message{To: []string{"bob@example.com"}}, "welcome", data); err != nil {
+		t.Fatal(err)
+	}
+	msgs := catcher.Messages()
+	if len(msgs) != 1 {
+		t.Fatalf("caught %d messages", len(msgs))
+	}
+	got := msgs[0]
+	if got.From != "shop@example.com" || got.Subject != "Welcome, <Bob> & co" || got.Text != "Hello <Bob>" || got.HTML != "<p>Hello &lt;Bob&gt;</p>" {
+		t.Errorf("message = %+v", got)
+	}
+
+	if err := mailer.SendTemplate(context.Background(), &mail.Message{To: []string{"bob@example.com"}}, "missing", nil); err == nil {
+		t.Error("expected missing template error")
+	}
+}
+
+func TestMailer_Enqueue(t *testing.T) {
+	var calls atomic.Int32
+	sender := mail.SenderFunc(func(context.Context, *mail.Message) error {
+		if calls.Add(1) < 3 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+
+	var failed atomic.Int32
+	mailer := mail.New(mail.Config{
+		Sender:       sender,
+		From:         "shop@example.com",
+		MaxAttempts:  3,
+		RetryDelay:   time.Millisecond,
+		ErrorHandler: func(*mail.Message, error) { failed.Add(1) },
+	})
+
+	if err := mailer.Enqueue(&mail.Message{To: []string{"bob@example.com"}, Text: "hi"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := mailer.Enqueue(&mail.Message{Text: "no recipients"}); err == nil {
+		t.Error("expected invalid message error")
+	}
+
+	if err := mailer.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if calls.Load() != 3 || failed.Load() != 0 {
+		t.Errorf("calls = %d, failed = %d", calls.Load(), failed.Load())
+	}
+	if err := mailer.Enqueue(&mail.Message{To: []string{"bob@example.com"}}); !errors.Is(err, mail.ErrMailerClosed) {
+		t.Errorf("Enqueue after Shutdown: err = %v", err)
+	}
+}
+
+func TestMailer_ShutdownCancelsRetries(t *testing.T) {
+	var failed atomic.Int32
+	mailer := mail.New(mail.Config{
+		Sender:       mail.SenderFunc(func(context.Context, *mail.Message) error { return errors.New("down") }),
+		From:         "shop@example.com",
+		MaxAttempts:  5,
+		RetryDelay:   time.Hour,
+		ErrorHandler: func(*mail.Message, error) { failed.Add(1) },
+	})
+	_ = mailer.Enqueue(&mail.Message{To: []string{"bob@example.com"}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := mailer.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown err = %v", err)
+	}
+	if failed.Load() != 1 {
+		t.Errorf("failed = %d, want 1", failed.Load())
+	}
+}
+
+func TestMailer_Catch(t *testing.T) {
+	var logs strings.Builder
+	mailer := mail.New(mail.Config{
+		Sender: mail.SenderFunc(func(context.Context, *mail.Message) error { return errors.New("must not send") }),
+		From:   "shop@example.com",
+		Catch:  true,
+		Logger: slog.New(slog.NewTextHandler(&logs, nil)),
+	})
+	if err := mailer.Send(context.Background(), &mail.Message{To: []string{"bob@example.com"}, Subject: "Hi"}); err != nil {
+		t.Fatal(err)
+	}
+	if catcher, ok := mailer.Sender().(*mail.Catcher); !ok || len(catcher.Messages()) != 1 {
+		t.Error("message not caught")
+	}
+	if !strings.Contains(logs.String(), "subject=Hi") {
+		t.Errorf("logs = %q", logs.String())
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	mailer := mail.New(mail.Config{Catch: true, Logger: discardLogger()})
+	router := fursy.New()
+	router.Use(mail.Middleware(mailer))
+	router.GET("/", func(c *fursy.Context) error {
+		if mail.MustGetMailer(c) != mailer {
+			t.Error("wrong mailer")
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("status = %d", w.Code)
+	}
+}
+
+// fakeSMTP is a minimal SMTP server recording one transaction per connection.
+type fakeSMTP struct {
+	mu   sync.Mutex
+	cmds []string
+	data string
+}
+
+func newFakeSMTP(t *testing.T) (*fakeSMTP, string, int) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	f := &fakeSMTP{}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	addr := ln.Addr().(*net.TCPAddr)
+	return f, addr.IP.String(), addr.Port
+}
+
+func (f *fakeSMTP) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(s string) { _, _ = io.WriteString(conn, s+"\r\n") }
+
+	reply("220 fake ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.TrimRight(line, "\r\n")
+		f.mu.Lock()
+		f.cmds = append(f.cmds, cmd)
+		f.mu.Unlock()
+
+		switch verb := strings.ToUpper(strings.Fields(cmd)[0]); verb {
+		case "EHLO":
+			reply("250-fake")
+			reply("250 8BITMIME")
+		case "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil || l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			f.mu.Lock()
+			f.data = data.String()
+			f.mu.Unlock()
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func TestSMTP_Send(t *testing.T) {
+	f, host, port := newFakeSMTP(t)
+	sender := mail.NewSMTP(mail.SMTPConfig{Host: host, Port: port})
+
+	msg := &mail.Message{
+		From:    "shop@example.com",
+		To:      []string{"a@example.com"},
+		Bcc:     []string{"b@example.com"},
+		Subject: "Order",
+		Text:    "Thanks",
+	}
+	if err := sender.Send(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	commands := strings.Join(f.cmds, "\n")
+	for _, want := range []string{"MAIL FROM:<shop@example.com>", "RCPT TO:<a@example.com>", "RCPT TO:<b@example.com>", "QUIT"} {
+		if !strings.Contains(commands, want) {
+			t.Errorf("commands missing %q:\n%s", want, commands)
+		}
+	}
+	if !strings.Contains(f.data, "Subject: Order") || !strings.Contains(f.data, "Thanks") {
+		t.Errorf("data = %q", f.data)
+	}
+}
+
+func TestSMTP_RequireTLS(t *testing.T) {
+	_, host, port := newFakeSMTP(t)
+	sender := mail.NewSMTP(mail.SMTPConfig{Host: host, Port: port, RequireTLS: true})
+	err := sender.Send(context.Background(), &mail.Message{From: "a@example.com", To: []string{"b@example.com"}})
+	if err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Errorf("err = %v, want STARTTLS error", err)
+	}
+}
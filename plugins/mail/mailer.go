@@ -0,0 +1,263 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package mail
+
+import (
+	"context"
+	"errors"
+	htmltemplate "html/template"
+	"log/slog"
+	"sync"
+	texttemplate "text/template"
+	"time"
+)
+
+// Default values for the Mailer.
+const (
+	// DefaultMaxAttempts is the default number of attempts of a background delivery.
+	DefaultMaxAttempts = 3
+
+	// DefaultRetryDelay is the delay before the first retry; it doubles per attempt.
+	DefaultRetryDelay = 5 * time.Second
+)
+
+// ErrMailerClosed is returned by Enqueue after Shutdown.
+var ErrMailerClosed = errors.New("mail: mailer is shut down")
+
+// Sender delivers messages.
+//
+// SMTP implements Sender. HTTP API providers (SendGrid, Postmark, SES, ...)
+// are integrated by implementing Send with their client.
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// SenderFunc adapts a function to the Sender interface.
+type SenderFunc func(ctx context.Context, msg *Message) error
+
+// Send calls f(ctx, msg).
+func (f SenderFunc) Send(ctx context.Context, msg *Message) error {
+	return f(ctx, msg)
+}
+
+// Config defines the config for the Mailer.
+type Config struct {
+	// Sender delivers messages.
+	// Required unless Catch is set.
+	Sender Sender
+
+	// From is the default sender address of messages without From.
+	From string
+
+	// HTML holds message templates, typically the template set also used
+	// to render HTML pages (see fursy.Router.HTMLTemplates).
+	// See SubjectSuffix for template naming.
+	HTML *htmltemplate.Template
+
+	// Text optionally holds plain text templates for subjects and text bodies.
+	// They take precedence over templates of the same name in HTML.
+	Text *texttemplate.Template
+
+	// Catch logs messages instead of sending them (development mode).
+	// Sender is replaced by a Catcher.
+	Catch bool
+
+	// Logger is used by the Catch mode and to report failed background deliveries.
+	// Default: slog.Default()
+	Logger *slog.Logger
+
+	// MaxAttempts is the number of attempts of a background delivery.
+	// Default: 3
+	MaxAttempts int
+
+	// RetryDelay is the delay before the first retry of a background
+	// delivery; it doubles with each attempt.
+	// Default: 5s
+	RetryDelay time.Duration
+
+	// ErrorHandler is called when a background delivery finally fails.
+	// Default: logs the error with Logger
+	ErrorHandler func(msg *Message, err error)
+}
+
+// Mailer renders and sends messages, synchronously or in the background.
+//
+// Mailer is safe for concurrent use.
+type Mailer struct {
+	config   Config
+	renderer renderer
+
+	mu     sync.RWMutex
+	closed bool
+
+	wg     sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// New creates a Mailer.
+//
+// Example:
+//
+//	mailer := mail.New(mail.Config{
+//	    Sender: mail.NewSMTP(mail.SMTPConfig{Host: "smtp.example.com"}),
+//	    From:   "no-reply@example.com",
+//	    HTML:   templates,
+//	})
+func New(config Config) *Mailer {
+	// Set defaults.
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+	if config.Catch {
+		config.Sender = NewCatcher(config.Logger)
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = DefaultRetryDelay
+	}
+	if config.ErrorHandler == nil {
+		logger := config.Logger
+		config.ErrorHandler = func(msg *Message, err error) {
+			logger.Error("mail: delivery failed", "to", msg.To, "subject", msg.Subject, "error", err)
+		}
+	}
+
+	// Validate config.
+	if config.Sender == nil {
+		panic("mail: sender is required")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Mailer{
+		config:   config,
+		renderer: renderer{html: config.HTML, text: config.Text},
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Sender returns the sender of the mailer (a *Catcher in Catch mode).
+func (m *Mailer) Sender() Sender {
+	return m.config.Sender
+}
+
+// Render renders the message template name with data into msg, setting its
+// Subject, Text and HTML fields.
+func (m *Mailer) Render(msg *Message, name string, data any) error {
+	return m.renderer.render(msg, name, data)
+}
+
+// Send sends the message synchronously.
+func (m *Mailer) Send(ctx context.Context, msg *Message) error {
+	if msg.From == "" {
+		msg.From = m.config.From
+	}
+	if _, _, err := msg.Envelope(); err != nil {
+		return err
+	}
+	return m.config.Sender.Send(ctx, msg)
+}
+
+// SendTemplate renders the message template name with data into msg and
+// sends it synchronously.
+func (m *Mailer) SendTemplate(ctx context.Context, msg *Message, name string, data any) error {
+	if err := m.Render(msg, name, data); err != nil {
+		return err
+	}
+	return m.Send(ctx, msg)
+}
+
+// Enqueue sends the message in the background, retrying failed attempts
+// with backoff. Invalid messages are rejected immediately.
+//
+// The message must not be modified after Enqueue.
+func (m *Mailer) Enqueue(msg *Message) error {
+	if msg.From == "" {
+		msg.From = m.config.From
+	}
+	if _, _, err := msg.Envelope(); err != nil {
+		return err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.closed {
+		return ErrMailerClosed
+	}
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		if err := m.deliver(m.ctx, msg); err != nil {
+			m.config.ErrorHandler(msg, err)
+		}
+	}()
+	return nil
+}
+
+// EnqueueTemplate renders the message template name with data into msg and
+// sends it in the background. Rendering errors are returned immediately.
+func (m *Mailer) EnqueueTemplate(msg *Message, name string, data any) error {
+	if err := m.Render(msg, name, data); err != nil {
+		return err
+	}
+	return m.Enqueue(msg)
+}
+
+// Shutdown stops accepting new messages and waits for background deliveries.
+// If ctx is done first, pending retries are canceled and ctx.Err() is returned.
+//
+// Example:
+//
+//	router.OnShutdown(func() {
+//	    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	    defer cancel()
+//	    _ = mailer.Shutdown(ctx)
+//	})
+func (m *Mailer) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	m.closed = true
+	m.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		m.cancel()
+		return nil
+	case <-ctx.Done():
+		m.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// deliver sends the message with retries.
+func (m *Mailer) deliver(ctx context.Context, msg *Message) error {
+	delay := m.config.RetryDelay
+	for attempt := 1; ; attempt++ {
+		err := m.config.Sender.Send(ctx, msg)
+		if err == nil || attempt >= m.config.MaxAttempts {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		}
+		delay *= 2
+	}
+}
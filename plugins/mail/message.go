@@ -0,0 +1,296 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package mail provides email sending for fursy HTTP router.
+//
+// This package provides:
+//   - A Sender interface, implemented by the SMTP sender and API providers
+//   - Message rendering from html/template (and text/template) templates,
+//     typically the same template set used for HTML pages
+//   - Background delivery with retries, drained on router shutdown
+//   - A development "catch" mode logging messages instead of sending them
+//
+// Example:
+//
+//	pages := template.Must(template.ParseGlob("templates/*.html"))
+//
+//	mailer := mail.New(mail.Config{
+//	    Sender: mail.NewSMTP(mail.SMTPConfig{
+//	        Host:     "smtp.example.com",
+//	        Username: "apikey",
+//	        Password: os.Getenv("SMTP_PASSWORD"),
+//	    }),
+//	    From:  "Example <no-reply@example.com>",
+//	    HTML:  pages,
+//	    Catch: os.Getenv("ENV") == "dev",
+//	})
+//	router.OnShutdown(func() {
+//	    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	    defer cancel()
+//	    _ = mailer.Shutdown(ctx)
+//	})
+//
+//	router.POST("/signup", func(c *fursy.Context) error {
+//	    // ... create user ...
+//	    err := mailer.EnqueueTemplate(&mail.Message{To: []string{user.Email}}, "welcome", user)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    return c.Created(user)
+//	})
+package mail
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"net/textproto"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Message is an email message.
+type Message struct {
+	// From is the sender address (e.g., "Example <no-reply@example.com>").
+	// Default: Config.From of the Mailer
+	From string
+
+	// To, Cc and Bcc list the recipient addresses.
+	// Bcc recipients are not included in the message headers.
+	To  []string
+	Cc  []string
+	Bcc []string
+
+	// ReplyTo is the Reply-To address.
+	ReplyTo string
+
+	// Subject is the message subject.
+	Subject string
+
+	// Text is the plain text body.
+	Text string
+
+	// HTML is the HTML body. Messages with both bodies are sent as
+	// multipart/alternative.
+	HTML string
+
+	// Headers holds additional headers (e.g., "List-Unsubscribe").
+	Headers map[string]string
+
+	// Attachments are attached files.
+	Attachments []Attachment
+}
+
+// Attachment is a file attached to a message.
+type Attachment struct {
+	// Filename is the file name shown to the recipient.
+	Filename string
+
+	// ContentType is the media type.
+	// Default: derived from the Filename extension, or application/octet-stream
+	ContentType string
+
+	// Data is the file content.
+	Data []byte
+}
+
+// Envelope returns the SMTP envelope sender and recipients (To, Cc and Bcc)
+// of the message.
+func (m *Message) Envelope() (string, []string, error) {
+	from, err := netmail.ParseAddress(m.From)
+	if err != nil {
+		return "", nil, fmt.Errorf("mail: invalid From address %q: %w", m.From, err)
+	}
+
+	var recipients []string
+	for _, list := range [][]string{m.To, m.Cc, m.Bcc} {
+		for _, addr := range list {
+			a, err := netmail.ParseAddress(addr)
+			if err != nil {
+				return "", nil, fmt.Errorf("mail: invalid recipient address %q: %w", addr, err)
+			}
+			recipients = append(recipients, a.Address)
+		}
+	}
+	if len(recipients) == 0 {
+		return "", nil, errors.New("mail: message has no recipients")
+	}
+	return from.Address, recipients, nil
+}
+
+// Bytes returns the message in RFC 5322 format with MIME bodies.
+func (m *Message) Bytes() ([]byte, error) {
+	if _, _, err := m.Envelope(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writeHeader := func(name, value string) {
+		buf.WriteString(name + ": " + value + "\r\n")
+	}
+
+	from, _ := netmail.ParseAddress(m.From)
+	writeHeader("Date", time.Now().Format(time.RFC1123Z))
+	writeHeader("From", from.String())
+	for name, list := range map[string][]string{"To": m.To, "Cc": m.Cc} {
+		if len(list) > 0 {
+			writeHeader(name, formatAddresses(list))
+		}
+	}
+	if m.ReplyTo != "" {
+		replyTo, err := netmail.ParseAddress(m.ReplyTo)
+		if err != nil {
+			return nil, fmt.Errorf("mail: invalid Reply-To address %q: %w", m.ReplyTo, err)
+		}
+		writeHeader("Reply-To", replyTo.String())
+	}
+	if strings.ContainsAny(m.Subject, "\r\n") {
+		return nil, errors.New("mail: subject contains a line break")
+	}
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	writeHeader("Message-ID", messageID(from.Address))
+	writeHeader("MIME-Version", "1.0")
+
+	names := make([]string, 0, len(m.Headers))
+	for name := range m.Headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		value := m.Headers[name]
+		if strings.ContainsAny(name, "\r\n: ") || strings.ContainsAny(value, "\r\n") {
+			return nil, fmt.Errorf("mail: invalid header %q", name)
+		}
+		writeHeader(textproto.CanonicalMIMEHeaderKey(name), mime.QEncoding.Encode("utf-8", value))
+	}
+
+	body := m.body()
+	for _, name := range []string{"Content-Type", "Content-Transfer-Encoding"} {
+		if value := body.header.Get(name); value != "" {
+			writeHeader(name, value)
+		}
+	}
+	buf.WriteString("\r\n")
+	buf.Write(body.body)
+	return buf.Bytes(), nil
+}
+
+// entity is a MIME entity: its headers and encoded body.
+type entity struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+// body returns the MIME body of the message.
+func (m *Message) body() entity {
+	var parts []entity
+	if m.Text != "" || m.HTML == "" {
+		parts = append(parts, textEntity("text/plain", m.Text))
+	}
+	if m.HTML != "" {
+		parts = append(parts, textEntity("text/html", m.HTML))
+	}
+
+	body := parts[0]
+	if len(parts) > 1 {
+		body = multipartEntity("alternative", parts)
+	}
+	if len(m.Attachments) == 0 {
+		return body
+	}
+
+	mixed := []entity{body}
+	for _, a := range m.Attachments {
+		mixed = append(mixed, attachmentEntity(a))
+	}
+	return multipartEntity("mixed", mixed)
+}
+
+// textEntity returns a quoted-printable UTF-8 text entity.
+func textEntity(mediaType, s string) entity {
+	var b bytes.Buffer
+	w := quotedprintable.NewWriter(&b)
+	_, _ = w.Write([]byte(s))
+	_ = w.Close()
+	return entity{
+		header: textproto.MIMEHeader{
+			"Content-Type":              {mediaType + "; charset=utf-8"},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		},
+		body: b.Bytes(),
+	}
+}
+
+// multipartEntity returns a multipart entity of the given subtype.
+func multipartEntity(subtype string, parts []entity) entity {
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+	for _, p := range parts {
+		pw, _ := w.CreatePart(p.header)
+		_, _ = pw.Write(p.body)
+	}
+	_ = w.Close()
+	return entity{
+		header: textproto.MIMEHeader{"Content-Type": {"multipart/" + subtype + "; boundary=" + w.Boundary()}},
+		body:   b.Bytes(),
+	}
+}
+
+// attachmentEntity returns a base64 attachment entity.
+func attachmentEntity(a Attachment) entity {
+	contentType := a.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(a.Filename))
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(a.Data)
+	var b bytes.Buffer
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded)
+
+	return entity{
+		header: textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename})},
+		},
+		body: b.Bytes(),
+	}
+}
+
+// formatAddresses formats an address list header (addresses are validated by Envelope).
+func formatAddresses(list []string) string {
+	formatted := make([]string, 0, len(list))
+	for _, addr := range list {
+		a, _ := netmail.ParseAddress(addr)
+		formatted = append(formatted, a.String())
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// messageID returns a unique Message-ID in the domain of the sender.
+func messageID(from string) string {
+	domain := "localhost"
+	if i := strings.LastIndexByte(from, '@'); i >= 0 {
+		domain = from[i+1:]
+	}
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">"
+}
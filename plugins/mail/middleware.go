@@ -0,0 +1,55 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package mail
+
+import (
+	"context"
+
+	"github.com/coregx/fursy"
+)
+
+// contextKey is a private type for storing the mailer in context.
+type contextKey int
+
+const mailerKey contextKey = iota
+
+// Middleware creates a middleware that stores the mailer in the request context.
+//
+// Example:
+//
+//	router.Use(mail.Middleware(mailer))
+//
+//	router.POST("/password-reset", func(c *fursy.Context) error {
+//	    mailer := mail.MustGetMailer(c)
+//	    // Send messages...
+//	    return c.NoContent(204)
+//	})
+func Middleware(m *Mailer) fursy.HandlerFunc {
+	return func(c *fursy.Context) error {
+		ctx := context.WithValue(c.Request.Context(), mailerKey, m)
+		c.Request = c.Request.WithContext(ctx)
+		return c.Next()
+	}
+}
+
+// GetMailer retrieves the mailer from the context.
+//
+// Returns (nil, false) if the mail middleware is not configured.
+func GetMailer(c *fursy.Context) (*Mailer, bool) {
+	m, ok := c.Request.Context().Value(mailerKey).(*Mailer)
+	return m, ok
+}
+
+// MustGetMailer retrieves the mailer from the context or panics.
+//
+// Use this in handlers where mailer absence indicates a programming error
+// (i.e., middleware misconfiguration), not a runtime error.
+func MustGetMailer(c *fursy.Context) *Mailer {
+	m, ok := GetMailer(c)
+	if !ok {
+		panic("mail: middleware not configured - ensure mail.Middleware(mailer) is used")
+	}
+	return m
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// Default SMTP settings.
+const (
+	// DefaultSMTPPort is the submission port (STARTTLS).
+	DefaultSMTPPort = 587
+
+	// DefaultSMTPTimeout bounds a single delivery.
+	DefaultSMTPTimeout = 30 * time.Second
+)
+
+// SMTPConfig defines the config for the SMTP sender.
+type SMTPConfig struct {
+	// Host is the SMTP server host name.
+	// Required.
+	Host string
+
+	// Port is the SMTP server port.
+	// Default: 587
+	Port int
+
+	// Username and Password enable PLAIN authentication.
+	// Credentials are only sent over TLS (or to localhost).
+	Username string
+	Password string
+
+	// ImplicitTLS connects over TLS (port 465) instead of upgrading
+	// the connection with STARTTLS.
+	ImplicitTLS bool
+
+	// RequireTLS fails delivery when the server does not support STARTTLS.
+	RequireTLS bool
+
+	// TLSConfig customizes TLS (e.g., custom root CAs).
+	// Default: &tls.Config{ServerName: Host}
+	TLSConfig *tls.Config
+
+	// LocalName is the host name sent in EHLO.
+	// Default: "localhost"
+	LocalName string
+
+	// Timeout bounds a single delivery, unless the context deadline is earlier.
+	// Default: 30s
+	Timeout time.Duration
+}
+
+// SMTP sends messages through an SMTP server.
+//
+// A new connection is used for every message.
+type SMTP struct {
+	config SMTPConfig
+}
+
+// NewSMTP creates an SMTP sender.
+//
+// Example:
+//
+//	sender := mail.NewSMTP(mail.SMTPConfig{
+//	    Host:     "smtp.example.com",
+//	    Username: "apikey",
+//	    Password: os.Getenv("SMTP_PASSWORD"),
+//	})
+func NewSMTP(config SMTPConfig) *SMTP {
+	// Validate config.
+	if config.Host == "" {
+		panic("mail: SMTP host is required")
+	}
+
+	// Set defaults.
+	if config.Port == 0 {
+		config.Port = DefaultSMTPPort
+	}
+	if config.TLSConfig == nil {
+		config.TLSConfig = &tls.Config{ServerName: config.Host, MinVersion: tls.VersionTLS12}
+	}
+	if config.Timeout == 0 {
+		config.Timeout = DefaultSMTPTimeout
+	}
+
+	return &SMTP{config: config}
+}
+
+// Send delivers the message to the SMTP server.
+func (s *SMTP) Send(ctx context.Context, msg *Message) error {
+	from, recipients, err := msg.Envelope()
+	if err != nil {
+		return err
+	}
+	data, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("mail: connect to %s: %w", s.config.Host, err)
+	}
+
+	deadline := time.Now().Add(s.config.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("mail: %w", err)
+	}
+	defer client.Close()
+
+	if err := s.deliver(client, from, recipients, data); err != nil {
+		return fmt.Errorf("mail: %w", err)
+	}
+	return nil
+}
+
+// dial connects to the server, over TLS if ImplicitTLS is set.
+func (s *SMTP) dial(ctx context.Context) (net.Conn, error) {
+	addr := net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port))
+	dialer := &net.Dialer{Timeout: s.config.Timeout}
+	if s.config.ImplicitTLS {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: s.config.TLSConfig}
+		return tlsDialer.DialContext(ctx, "tcp", addr)
+	}
+	return dialer.DialContext(ctx, "tcp", addr)
+}
+
+// deliver runs the SMTP transaction.
+func (s *SMTP) deliver(client *smtp.Client, from string, recipients []string, data []byte) error {
+	if s.config.LocalName != "" {
+		if err := client.Hello(s.config.LocalName); err != nil {
+			return err
+		}
+	}
+
+	if !s.config.ImplicitTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(s.config.TLSConfig); err != nil {
+				return err
+			}
+		} else if s.config.RequireTLS {
+			return errors.New("server does not support STARTTLS")
+		}
+	}
+
+	if s.config.Username != "" {
+		auth := smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range recipients {
+		if err := client.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+)
+
+// Template name suffixes.
+//
+// A message template "welcome" consists of up to three named templates:
+//
+//	{{define "welcome.subject"}}Welcome, {{.Name}}!{{end}}
+//	{{define "welcome.html"}}<p>Hello {{.Name}}, ...</p>{{end}}
+//	{{define "welcome.txt"}}Hello {{.Name}}, ...{{end}}
+const (
+	SubjectSuffix = ".subject"
+	HTMLSuffix    = ".html"
+	TextSuffix    = ".txt"
+)
+
+// renderer renders message templates.
+type renderer struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+// render renders the templates of name into msg.
+//
+// The subject and text body are looked up in the text set first, then in the
+// HTML set (with HTML escaping undone). The HTML body comes from the HTML set.
+// At least one body template is required.
+func (r renderer) render(msg *Message, name string, data any) error {
+	subject, ok, err := r.renderText(name+SubjectSuffix, data)
+	if err != nil {
+		return err
+	}
+	if ok {
+		msg.Subject = strings.TrimSpace(subject)
+	}
+
+	text, hasText, err := r.renderText(name+TextSuffix, data)
+	if err != nil {
+		return err
+	}
+	if hasText {
+		msg.Text = text
+	}
+
+	var hasHTML bool
+	if t := r.lookupHTML(name + HTMLSuffix); t != nil {
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, data); err != nil {
+			return fmt.Errorf("mail: render template %q: %w", name+HTMLSuffix, err)
+		}
+		msg.HTML = buf.String()
+		hasHTML = true
+	}
+
+	if !hasText && !hasHTML {
+		return fmt.Errorf("mail: template %q not found (define %q or %q)", name, name+HTMLSuffix, name+TextSuffix)
+	}
+	return nil
+}
+
+// renderText renders a plain text template, reporting whether it exists.
+func (r renderer) renderText(name string, data any) (string, bool, error) {
+	var buf bytes.Buffer
+	if r.text != nil {
+		if t := r.text.Lookup(name); t != nil {
+			if err := t.Execute(&buf, data); err != nil {
+				return "", false, fmt.Errorf("mail: render template %q: %w", name, err)
+			}
+			return buf.String(), true, nil
+		}
+	}
+	if t := r.lookupHTML(name); t != nil {
+		if err := t.Execute(&buf, data); err != nil {
+			return "", false, fmt.Errorf("mail: render template %q: %w", name, err)
+		}
+		return html.UnescapeString(buf.String()), true, nil
+	}
+	return "", false, nil
+}
+
+// lookupHTML returns the named HTML template or nil.
+func (r renderer) lookupHTML(name string) *htmltemplate.Template {
+	if r.html == nil {
+		return nil
+	}
+	return r.html.Lookup(name)
+}
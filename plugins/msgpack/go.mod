@@ -0,0 +1,13 @@
+module github.com/coregx/fursy/plugins/msgpack
+
+go 1.25.0
+
+require (
+	github.com/coregx/fursy v0.1.0
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+)
+
+require github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+
+// Use local fursy module during development.
+replace github.com/coregx/fursy => ../..
@@ -0,0 +1,78 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package msgpack provides MessagePack (application/msgpack) support for fursy.
+//
+// Register adds a binder and a renderer for application/msgpack to the router:
+//   - Box.Bind decodes MessagePack request bodies
+//   - Context.Negotiate renders MessagePack for "Accept: application/msgpack"
+//   - The generated OpenAPI document lists application/msgpack content
+//
+// Struct fields are mapped using their `json` tags, so the same request and
+// response types work for JSON and MessagePack clients.
+//
+// Example:
+//
+//	router := fursy.New()
+//	msgpack.Register(router)
+//
+//	fursy.POST[CreateUser, User](router, "/users", func(c *fursy.Box[CreateUser, User]) error {
+//	    user := createUser(c.ReqBody)
+//	    return c.Status(201).NegotiateRes() // JSON or MessagePack
+//	})
+package msgpack
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/coregx/fursy"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// ErrEmptyBody is returned by Bind when the request body is empty.
+var ErrEmptyBody = errors.New("msgpack: request body is empty")
+
+// structTag is the struct tag used for field names.
+const structTag = "json"
+
+// Register registers the MessagePack binder and renderer on the router.
+//
+// Example:
+//
+//	router := msgpack.Register(fursy.New())
+func Register(r *fursy.Router) *fursy.Router {
+	return r.
+		RegisterBinder(fursy.MIMEApplicationMsgPack, Bind).
+		RegisterRenderer(fursy.MIMEApplicationMsgPack, Render)
+}
+
+// Bind decodes a MessagePack request body into v.
+func Bind(c *fursy.Context, v any) error {
+	if c.Request.Body == nil || c.Request.ContentLength == 0 {
+		return ErrEmptyBody
+	}
+
+	dec := msgpack.NewDecoder(c.Request.Body)
+	dec.SetCustomStructTag(structTag)
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("msgpack decode error: %w", err)
+	}
+
+	return nil
+}
+
+// Render sends data as a MessagePack response with the given status code.
+func Render(c *fursy.Context, status int, data any) error {
+	var buf bytes.Buffer
+
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag(structTag)
+	if err := enc.Encode(data); err != nil {
+		return fmt.Errorf("msgpack encode error: %w", err)
+	}
+
+	return c.Blob(status, fursy.MIMEApplicationMsgPack, buf.Bytes())
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package msgpack
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/coregx/fursy"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type user struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func encode(v any) []byte {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	_ = enc.Encode(v)
+	return buf.Bytes()
+}
+
+func decode(data []byte, v any) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	return dec.Decode(v)
+}
+
+// TestRegister_RoundTrip tests binding and negotiated rendering of MessagePack.
+func TestRegister_RoundTrip(t *testing.T) {
+	r := Register(fursy.New())
+	fursy.POST[user, user](r, "/users", func(c *fursy.Box[user, user]) error {
+		created := user{ID: 1, Name: c.ReqBody.Name}
+		c.ResBody = &created
+		return c.Status(http.StatusCreated).NegotiateRes()
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/users", bytes.NewReader(encode(user{Name: "Alice"})))
+	req.Header.Set("Content-Type", fursy.MIMEApplicationMsgPack)
+	req.Header.Set("Accept", fursy.MIMEApplicationMsgPack)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != fursy.MIMEApplicationMsgPack {
+		t.Errorf("expected Content-Type %q, got %q", fursy.MIMEApplicationMsgPack, ct)
+	}
+
+	var got user
+	if err := decode(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if got != (user{ID: 1, Name: "Alice"}) {
+		t.Errorf("unexpected response: %+v", got)
+	}
+}
+
+// TestRegister_JSONStillDefault tests that JSON clients are unaffected.
+func TestRegister_JSONStillDefault(t *testing.T) {
+	r := Register(fursy.New())
+	r.GET("/users/1", func(c *fursy.Context) error {
+		return c.Negotiate(http.StatusOK, user{ID: 1, Name: "Alice"})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/1", http.NoBody))
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/json; charset=utf-8" {
+		t.Errorf("expected JSON by default, got %q", ct)
+	}
+}
+
+// TestBind_EmptyBody tests that an empty body is rejected.
+func TestBind_EmptyBody(t *testing.T) {
+	c := &fursy.Context{Request: httptest.NewRequest(http.MethodPost, "/", http.NoBody)}
+	if err := Bind(c, &user{}); err != ErrEmptyBody {
+		t.Errorf("expected ErrEmptyBody, got %v", err)
+	}
+}
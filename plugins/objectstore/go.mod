@@ -0,0 +1,8 @@
+module github.com/coregx/fursy/plugins/objectstore
+
+go 1.25.0
+
+require github.com/coregx/fursy v0.2.0
+
+// Use local fursy module during development.
+replace github.com/coregx/fursy => ../..
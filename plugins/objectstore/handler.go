@@ -0,0 +1,314 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package objectstore
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// Default values for the handler helpers.
+const (
+	// DefaultUploadField is the default multipart field of uploaded files.
+	DefaultUploadField = "file"
+
+	// DefaultMaxUploadSize is the default maximum size of an uploaded file.
+	DefaultMaxUploadSize = 32 << 20
+
+	// DefaultDownloadExpiry is the default lifetime of download URLs.
+	DefaultDownloadExpiry = 15 * time.Minute
+)
+
+// errFileTooLarge is returned by the size-limited upload reader.
+var errFileTooLarge = errors.New("objectstore: file too large")
+
+// UploadConfig defines the configuration for Upload and UploadHandler.
+type UploadConfig struct {
+	// Field is the multipart form field of the files.
+	// Default: "file"
+	Field string
+
+	// MaxSize is the maximum size of a file in bytes.
+	// Default: 32 MiB
+	MaxSize int64
+
+	// MaxFiles is the maximum number of files per request.
+	// Default: 1
+	MaxFiles int
+
+	// AllowedTypes lists the accepted media types of the files (from the
+	// part Content-Type header). Supports wildcards like "image/*".
+	// Default: nil (all types)
+	AllowedTypes []string
+
+	// Key returns the object key of an uploaded file.
+	// Default: random hex name keeping the file extension (e.g., "3f2a...9c.png")
+	Key func(c *fursy.Context, filename string) (string, error)
+}
+
+// Upload streams the files of a multipart/form-data request to the store,
+// without buffering them in memory or on disk, and returns the stored objects.
+//
+// Other form fields are skipped. Client errors are returned as fursy.Problem
+// (400 for malformed requests or missing files, 413 for files larger than
+// MaxSize, 415 for disallowed types); objects stored before an error are
+// deleted.
+//
+// Example:
+//
+//	router.POST("/avatars", func(c *fursy.Context) error {
+//	    objects, err := objectstore.Upload(c, store, objectstore.UploadConfig{
+//	        MaxSize:      5 << 20,
+//	        AllowedTypes: []string{"image/png", "image/jpeg"},
+//	    })
+//	    if err != nil {
+//	        var p fursy.Problem
+//	        if errors.As(err, &p) {
+//	            return c.Problem(p)
+//	        }
+//	        return err
+//	    }
+//	    return c.Created(objects[0])
+//	})
+func Upload(c *fursy.Context, store Store, config UploadConfig) ([]Object, error) {
+	// Set defaults.
+	if config.Field == "" {
+		config.Field = DefaultUploadField
+	}
+	if config.MaxSize <= 0 {
+		config.MaxSize = DefaultMaxUploadSize
+	}
+	if config.MaxFiles <= 0 {
+		config.MaxFiles = 1
+	}
+	if config.Key == nil {
+		config.Key = randomKey
+	}
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, fursy.BadRequest("Expected a multipart/form-data request")
+	}
+
+	ctx := c.Request.Context()
+	var objects []Object
+	fail := func(err error) ([]Object, error) {
+		for _, obj := range objects {
+			_ = store.Delete(ctx, obj.Key)
+		}
+		return nil, err
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fail(fursy.BadRequest("Malformed multipart body"))
+		}
+		if part.FormName() != config.Field || part.FileName() == "" {
+			_ = part.Close()
+			continue
+		}
+		if len(objects) == config.MaxFiles {
+			return fail(fursy.BadRequest(fmt.Sprintf("At most %d file(s) may be uploaded", config.MaxFiles)))
+		}
+
+		contentType := part.Header.Get("Content-Type")
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		if !typeAllowed(config.AllowedTypes, contentType) {
+			return fail(fursy.NewProblem(http.StatusUnsupportedMediaType, "Unsupported Media Type",
+				fmt.Sprintf("File type %q is not allowed", contentType)))
+		}
+
+		filename := path.Base(strings.ReplaceAll(part.FileName(), "\\", "/"))
+		key, err := config.Key(c, filename)
+		if err != nil {
+			return fail(err)
+		}
+
+		obj, err := store.Put(ctx, key, &limitedReader{r: part, n: config.MaxSize}, PutOptions{
+			ContentType: contentType,
+			Size:        -1,
+		})
+		if errors.Is(err, errFileTooLarge) {
+			_ = store.Delete(ctx, key)
+			return fail(fursy.NewProblem(http.StatusRequestEntityTooLarge, "Content Too Large",
+				fmt.Sprintf("File exceeds the maximum size of %d bytes", config.MaxSize)))
+		}
+		if err != nil {
+			return fail(err)
+		}
+		obj.Filename = filename
+		objects = append(objects, obj)
+	}
+
+	if len(objects) == 0 {
+		return nil, fursy.BadRequest(fmt.Sprintf("Missing file field %q", config.Field))
+	}
+	return objects, nil
+}
+
+// UploadHandler returns a handler storing uploaded files with Upload and
+// responding 201 Created with the stored object (or the list of objects
+// if MaxFiles is greater than 1).
+func UploadHandler(store Store, config UploadConfig) fursy.HandlerFunc {
+	return func(c *fursy.Context) error {
+		objects, err := Upload(c, store, config)
+		if err != nil {
+			var p fursy.Problem
+			if errors.As(err, &p) {
+				return c.Problem(p)
+			}
+			return err
+		}
+		if config.MaxFiles > 1 {
+			return c.Created(objects)
+		}
+		return c.Created(objects[0])
+	}
+}
+
+// DownloadConfig defines the configuration for DownloadHandler.
+type DownloadConfig struct {
+	// Param is the route parameter holding the object key.
+	// Default: "key"
+	Param string
+
+	// Expiry is the lifetime of the signed URL.
+	// Default: 15 minutes
+	Expiry time.Duration
+
+	// Authorize checks access to the object before redirecting.
+	// Returning an error aborts the request with it.
+	// Default: nil (all objects are accessible)
+	Authorize func(c *fursy.Context, key string) error
+}
+
+// Redirect redirects the client to a signed download URL of the object
+// (307 Temporary Redirect), so the file is served by the store instead of
+// the application.
+//
+// Example:
+//
+//	router.GET("/invoices/:id/pdf", func(c *fursy.Context) error {
+//	    // ... check access ...
+//	    return objectstore.Redirect(c, store, "invoices/"+c.Param("id")+".pdf", 5*time.Minute)
+//	})
+func Redirect(c *fursy.Context, store Store, key string, expiry time.Duration) error {
+	u, err := store.SignedURL(c.Request.Context(), http.MethodGet, key, expiry)
+	if err != nil {
+		return err
+	}
+	c.SetHeader("Cache-Control", "no-store")
+	return c.Redirect(http.StatusTemporaryRedirect, u)
+}
+
+// DownloadHandler returns a handler redirecting to signed download URLs of
+// the object named by a route parameter (a catch-all parameter allows keys
+// with slashes).
+//
+// Example:
+//
+//	router.GET("/files/*key", objectstore.DownloadHandler(store, objectstore.DownloadConfig{
+//	    Authorize: func(c *fursy.Context, key string) error {
+//	        if !strings.HasPrefix(key, currentUser(c).ID+"/") {
+//	            return fursy.Forbidden("Access denied")
+//	        }
+//	        return nil
+//	    },
+//	}))
+func DownloadHandler(store Store, config DownloadConfig) fursy.HandlerFunc {
+	// Set defaults.
+	if config.Param == "" {
+		config.Param = "key"
+	}
+	if config.Expiry <= 0 {
+		config.Expiry = DefaultDownloadExpiry
+	}
+
+	return func(c *fursy.Context) error {
+		key := strings.TrimPrefix(c.Param(config.Param), "/")
+		if key == "" {
+			return c.Problem(fursy.NotFound("Object not found"))
+		}
+		if config.Authorize != nil {
+			if err := config.Authorize(c, key); err != nil {
+				var p fursy.Problem
+				if errors.As(err, &p) {
+					return c.Problem(p)
+				}
+				return err
+			}
+		}
+		return Redirect(c, store, key, config.Expiry)
+	}
+}
+
+// limitedReader reads at most n bytes and fails with errFileTooLarge on more.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read implements io.Reader.
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, errFileTooLarge
+	}
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return n, errFileTooLarge
+	}
+	return n, err
+}
+
+// typeAllowed reports whether contentType matches one of the allowed types.
+func typeAllowed(allowed []string, contentType string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return slices.ContainsFunc(allowed, func(pattern string) bool {
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+			return strings.HasPrefix(mediaType, prefix+"/")
+		}
+		return strings.EqualFold(pattern, mediaType)
+	})
+}
+
+// randomKey returns a random key keeping the extension of filename.
+func randomKey(_ *fursy.Context, filename string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	ext := strings.ToLower(path.Ext(filename))
+	if len(ext) > 16 || strings.ContainsAny(ext, " %?#") {
+		ext = ""
+	}
+	return hex.EncodeToString(b) + ext, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package objectstore_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/coregx/fursy"
+	"github.com/coregx/fursy/plugins/objectstore"
+)
+
+// uploadFile is a file of a multipart test request.
+type uploadFile struct {
+	field, name, contentType, content string
+}
+
+func multipartRequest(t *testing.T, files ...uploadFile) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	_ = w.WriteField("description", "ignored")
+	for _, f := range files {
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Disposition", `form-data; name="`+f.field+`"; filename="`+f.name+`"`)
+		h.Set("Content-Type", f.contentType)
+		part, err := w.CreatePart(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = part.Write([]byte(f.content))
+	}
+	_ = w.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/files", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func TestUploadHandler(t *testing.T) {
+	f, store := newFakeS3(t)
+	router := fursy.New()
+	router.POST("/files", objectstore.UploadHandler(store, objectstore.UploadConfig{
+		MaxSize:      10,
+		AllowedTypes: []string{"image/*", "text/plain"},
+		Key: func(_ *fursy.Context, filename string) (string, error) {
+			return "uploads/" + filename, nil
+		},
+	}))
+
+	tests := []struct {
+		name   string
+		req    *http.Request
+		status int
+		want   string
+	}{
+		{"ok", multipartRequest(t, uploadFile{"file", `C:\tmp\a.png`, "image/png", "png"}), http.StatusCreated, `"key":"uploads/a.png"`},
+		{"too large", multipartRequest(t, uploadFile{"file", "b.txt", "text/plain", "01234567890"}), http.StatusRequestEntityTooLarge, "maximum size"},
+		{"type", multipartRequest(t, uploadFile{"file", "c.pdf", "application/pdf", "pdf"}), http.StatusUnsupportedMediaType, "not allowed"},
+		{"missing", multipartRequest(t, uploadFile{"other", "d.txt", "text/plain", "d"}), http.StatusBadRequest, `Missing file field`},
+		{"too many", multipartRequest(t,
+			uploadFile{"file", "e.txt", "text/plain", "e"},
+			uploadFile{"file", "f.txt", "text/plain", "f"},
+		), http.StatusBadRequest, "At most 1"},
+		{"not multipart", httptest.NewRequest(http.MethodPost, "/files", strings.NewReader("{}")), http.StatusBadRequest, "multipart"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, tt.req)
+			if w.Code != tt.status || !strings.Contains(w.Body.String(), tt.want) {
+				t.Errorf("got %d %s, want %d %s", w.Code, w.Body.String(), tt.status, tt.want)
+			}
+		})
+	}
+
+	if data, _ := f.object("uploads/a.png"); string(data) != "png" {
+		t.Errorf("stored = %q, want png", data)
+	}
+	for _, key := range []string{"uploads/b.txt", "uploads/e.txt"} {
+		if _, ok := f.object(key); ok {
+			t.Errorf("%s kept after a failed upload", key)
+		}
+	}
+}
+
+func TestDownloadHandler(t *testing.T) {
+	_, store := newFakeS3(t)
+	router := fursy.New()
+	router.GET("/files/*key", objectstore.DownloadHandler(store, objectstore.DownloadConfig{
+		Authorize: func(_ *fursy.Context, key string) error {
+			if strings.HasPrefix(key, "private/") {
+				return fursy.Forbidden("Access denied")
+			}
+			return nil
+		},
+	}))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/files/docs/a.txt", http.NoBody))
+	loc := w.Header().Get("Location")
+	if w.Code != http.StatusTemporaryRedirect || !strings.Contains(loc, "/bucket/docs/a.txt?") || !strings.Contains(loc, "X-Amz-Expires=900") {
+		t.Errorf("got %d Location %q", w.Code, loc)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/files/private/b.txt", http.NoBody))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("private object: got %d, want 403", w.Code)
+	}
+}
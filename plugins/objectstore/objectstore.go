@@ -0,0 +1,89 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package objectstore provides object storage integration for fursy HTTP router.
+//
+// This package provides:
+//   - A minimal Store interface (Put, Get, Delete, SignedURL)
+//   - An S3-compatible implementation (AWS S3, MinIO, R2, ...) without SDK dependencies
+//   - Upload helpers streaming multipart file parts directly to the store
+//   - Download helpers redirecting to pre-signed URLs
+//
+// Example:
+//
+//	store, err := objectstore.NewS3(objectstore.S3Config{
+//	    Endpoint:        "https://s3.eu-west-1.amazonaws.com",
+//	    Region:          "eu-west-1",
+//	    Bucket:          "uploads",
+//	    AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+//	    SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	router := fursy.New()
+//	router.POST("/files", objectstore.UploadHandler(store, objectstore.UploadConfig{
+//	    MaxSize: 50 << 20,
+//	}))
+//	router.GET("/files/*key", objectstore.DownloadHandler(store, objectstore.DownloadConfig{}))
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrNotFound is returned by Store.Get when the object does not exist.
+var ErrNotFound = errors.New("objectstore: object not found")
+
+// Object describes a stored object.
+type Object struct {
+	// Key is the object key.
+	Key string `json:"key"`
+
+	// ContentType is the media type of the object.
+	ContentType string `json:"content_type,omitempty"`
+
+	// Size is the object size in bytes.
+	Size int64 `json:"size"`
+
+	// Filename is the client file name of an uploaded object.
+	Filename string `json:"filename,omitempty"`
+
+	// ETag is the entity tag returned by the store.
+	ETag string `json:"etag,omitempty"`
+}
+
+// PutOptions holds the options of Store.Put.
+type PutOptions struct {
+	// ContentType is the media type of the object.
+	// Default: "application/octet-stream"
+	ContentType string
+
+	// Size is the object size in bytes, or -1 if unknown (e.g., a streamed
+	// upload). Stores may buffer objects of unknown size in parts.
+	Size int64
+}
+
+// Store is a minimal object storage interface.
+//
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Put stores the content of r under key, replacing any existing object.
+	Put(ctx context.Context, key string, r io.Reader, opts PutOptions) (Object, error)
+
+	// Get returns the content of an object. The caller must close it.
+	// Returns ErrNotFound if the object does not exist.
+	Get(ctx context.Context, key string) (io.ReadCloser, Object, error)
+
+	// Delete removes an object. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// SignedURL returns a URL granting method (GET or PUT) access to an
+	// object for the expiry duration, without credentials.
+	SignedURL(ctx context.Context, method, key string, expiry time.Duration) (string, error)
+}
@@ -0,0 +1,381 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default values for S3.
+const (
+	// DefaultRegion is the default S3 region.
+	DefaultRegion = "us-east-1"
+
+	// DefaultPartSize is the default part size of multipart uploads.
+	// It is also the minimum part size accepted by S3.
+	DefaultPartSize = 5 << 20
+
+	// maxErrorBody is the maximum number of error response bytes read.
+	maxErrorBody = 4 << 10
+)
+
+// S3Config defines the configuration for an S3 store.
+type S3Config struct {
+	// Endpoint is the base URL of the S3 service (required),
+	// e.g. "https://s3.eu-west-1.amazonaws.com" or "http://localhost:9000".
+	Endpoint string
+
+	// Region is the region used for request signing.
+	// Default: "us-east-1"
+	Region string
+
+	// Bucket is the bucket name (required).
+	Bucket string
+
+	// AccessKeyID is the access key (required).
+	AccessKeyID string
+
+	// SecretAccessKey is the secret key (required).
+	SecretAccessKey string
+
+	// SessionToken is the session token of temporary credentials.
+	// Default: "" (long-term credentials)
+	SessionToken string
+
+	// PathStyle addresses the bucket in the path (endpoint/bucket/key)
+	// instead of the host name (bucket.endpoint/key). Most S3-compatible
+	// services (e.g., MinIO) require path-style addressing.
+	// Default: false
+	PathStyle bool
+
+	// Client is the HTTP client used for requests.
+	// Default: http.DefaultClient
+	Client *http.Client
+
+	// PartSize is the part size of multipart uploads of objects with
+	// unknown size. Objects smaller than one part are uploaded with a
+	// single request.
+	// Default: 5 MiB (the S3 minimum)
+	PartSize int
+}
+
+// S3 is a Store backed by an S3-compatible service.
+//
+// Requests are signed with AWS Signature Version 4; payloads are not signed
+// (UNSIGNED-PAYLOAD), so objects are streamed without being read twice.
+// Objects of unknown size are uploaded with multipart uploads, buffering
+// one part at a time.
+type S3 struct {
+	config S3Config
+	base   *url.URL
+	signer *signer
+	now    func() time.Time
+}
+
+// S3Error is an error response of the S3 service.
+type S3Error struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+
+	// Code is the S3 error code (e.g., "AccessDenied").
+	Code string `xml:"Code"`
+
+	// Message is the error message.
+	Message string `xml:"Message"`
+}
+
+// Error implements the error interface.
+func (e *S3Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("objectstore: S3 request failed with status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("objectstore: S3 request failed with status %d: %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// NewS3 creates an S3 store.
+//
+// Example:
+//
+//	// MinIO
+//	store, err := objectstore.NewS3(objectstore.S3Config{
+//	    Endpoint:        "http://localhost:9000",
+//	    Bucket:          "uploads",
+//	    AccessKeyID:     "minioadmin",
+//	    SecretAccessKey: "minioadmin",
+//	    PathStyle:       true,
+//	})
+func NewS3(config S3Config) (*S3, error) {
+	// Validate config.
+	if config.Bucket == "" || config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, errors.New("objectstore: bucket and credentials are required")
+	}
+	base, err := url.Parse(config.Endpoint)
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return nil, fmt.Errorf("objectstore: invalid endpoint %q", config.Endpoint)
+	}
+
+	// Set defaults.
+	if config.Region == "" {
+		config.Region = DefaultRegion
+	}
+	if config.Client == nil {
+		config.Client = http.DefaultClient
+	}
+	if config.PartSize < DefaultPartSize {
+		config.PartSize = DefaultPartSize
+	}
+
+	return &S3{
+		config: config,
+		base:   base,
+		signer: &signer{
+			accessKeyID:     config.AccessKeyID,
+			secretAccessKey: config.SecretAccessKey,
+			sessionToken:    config.SessionToken,
+			region:          config.Region,
+		},
+		now: time.Now,
+	}, nil
+}
+
+// Put implements Store.
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, opts PutOptions) (Object, error) {
+	if opts.ContentType == "" {
+		opts.ContentType = "application/octet-stream"
+	}
+	obj := Object{Key: key, ContentType: opts.ContentType, Size: opts.Size}
+
+	if opts.Size >= 0 {
+		etag, err := s.putObject(ctx, key, io.LimitReader(r, opts.Size), opts.Size, opts.ContentType)
+		obj.ETag = etag
+		return obj, err
+	}
+
+	// Unknown size: upload in a single request if the object fits in one part.
+	first := make([]byte, s.config.PartSize)
+	n, err := io.ReadFull(r, first)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		obj.Size = int64(n)
+		obj.ETag, err = s.putObject(ctx, key, bytes.NewReader(first[:n]), int64(n), opts.ContentType)
+		return obj, err
+	}
+	if err != nil {
+		return obj, err
+	}
+
+	size, etag, err := s.multipartUpload(ctx, key, first, r, opts.ContentType)
+	obj.Size, obj.ETag = size, etag
+	return obj, err
+}
+
+// Get implements Store.
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, Object, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil, -1, nil)
+	if err != nil {
+		return nil, Object{}, err
+	}
+
+	size, _ := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64)
+	obj := Object{
+		Key:         key,
+		ContentType: resp.Header.Get("Content-Type"),
+		Size:        size,
+		ETag:        resp.Header.Get("ETag"),
+	}
+	return resp.Body, obj, nil
+}
+
+// Delete implements Store.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, nil, 0, nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// SignedURL implements Store. The expiry is capped at 7 days (the S3 maximum).
+func (s *S3) SignedURL(_ context.Context, method, key string, expiry time.Duration) (string, error) {
+	if method != http.MethodGet && method != http.MethodPut && method != http.MethodHead {
+		return "", fmt.Errorf("objectstore: unsupported signed URL method %q", method)
+	}
+	if expiry <= 0 {
+		return "", errors.New("objectstore: signed URL expiry must be positive")
+	}
+	return s.signer.presign(method, s.objectURL(key, nil), s.now(), min(expiry, maxPresignExpiry)), nil
+}
+
+// putObject uploads an object of known size with a single PUT request.
+func (s *S3) putObject(ctx context.Context, key string, body io.Reader, size int64, contentType string) (string, error) {
+	header := http.Header{"Content-Type": {contentType}}
+	resp, err := s.do(ctx, http.MethodPut, key, nil, body, size, header)
+	if err != nil {
+		return "", err
+	}
+	_ = resp.Body.Close()
+	return resp.Header.Get("ETag"), nil
+}
+
+// completedPart is a part of the CompleteMultipartUpload request.
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// multipartUpload uploads first followed by the rest of r in parts.
+// The upload is aborted on error.
+func (s *S3) multipartUpload(ctx context.Context, key string, first []byte, r io.Reader, contentType string) (int64, string, error) {
+	resp, err := s.do(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil, 0,
+		http.Header{"Content-Type": {contentType}})
+	if err != nil {
+		return 0, "", err
+	}
+	var created struct {
+		UploadID string `xml:"UploadId"`
+	}
+	err = decodeXML(resp, &created)
+	if err != nil {
+		return 0, "", err
+	}
+
+	size, parts, err := s.uploadParts(ctx, key, created.UploadID, first, r)
+	if err != nil {
+		abortCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+		defer cancel()
+		if resp, abortErr := s.do(abortCtx, http.MethodDelete, key, url.Values{"uploadId": {created.UploadID}}, nil, 0, nil); abortErr == nil {
+			_ = resp.Body.Close()
+		}
+		return 0, "", err
+	}
+
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return 0, "", err
+	}
+	resp, err = s.do(ctx, http.MethodPost, key, url.Values{"uploadId": {created.UploadID}},
+		bytes.NewReader(body), int64(len(body)), http.Header{"Content-Type": {"application/xml"}})
+	if err != nil {
+		return 0, "", err
+	}
+
+	// CompleteMultipartUpload may report errors in a 200 response.
+	var completed struct {
+		XMLName xml.Name
+		ETag    string `xml:"ETag"`
+		S3Error
+	}
+	if err := decodeXML(resp, &completed); err != nil {
+		return 0, "", err
+	}
+	if completed.XMLName.Local == "Error" {
+		completed.S3Error.StatusCode = http.StatusOK
+		return 0, "", &completed.S3Error
+	}
+	return size, completed.ETag, nil
+}
+
+// uploadParts uploads first and the rest of r as parts of an upload.
+func (s *S3) uploadParts(ctx context.Context, key, uploadID string, first []byte, r io.Reader) (int64, []completedPart, error) {
+	var (
+		size  int64
+		parts []completedPart
+		buf   = first
+	)
+	for n := len(first); n > 0; {
+		number := len(parts) + 1
+		resp, err := s.do(ctx, http.MethodPut, key,
+			url.Values{"partNumber": {strconv.Itoa(number)}, "uploadId": {uploadID}},
+			bytes.NewReader(buf[:n]), int64(n), nil)
+		if err != nil {
+			return 0, nil, err
+		}
+		_ = resp.Body.Close()
+		parts = append(parts, completedPart{PartNumber: number, ETag: resp.Header.Get("ETag")})
+		size += int64(n)
+
+		n, err = io.ReadFull(r, buf)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, nil, err
+		}
+	}
+	return size, parts, nil
+}
+
+// objectURL returns the URL of an object.
+func (s *S3) objectURL(key string, query url.Values) *url.URL {
+	u := *s.base
+	path := strings.TrimSuffix(u.Path, "/")
+	if s.config.PathStyle {
+		path += "/" + s.config.Bucket
+	} else {
+		u.Host = s.config.Bucket + "." + u.Host
+	}
+	u.Path = path + "/" + strings.TrimPrefix(key, "/")
+	// Send the path with the encoding used for signing.
+	u.RawPath = uriEncode(u.Path, false)
+	u.RawQuery = canonicalQuery(query)
+	return &u
+}
+
+// do sends a signed request for an object and returns the response of a
+// successful (2xx) request. size is the body length (-1 if unknown).
+func (s *S3) do(ctx context.Context, method, key string, query url.Values, body io.Reader, size int64, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(key, query).String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if size >= 0 && body != nil {
+		req.ContentLength = size
+		if size == 0 {
+			req.Body = http.NoBody
+		}
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	s.signer.sign(req, s.now())
+
+	resp, err := s.config.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound && method != http.MethodPost {
+		return nil, ErrNotFound
+	}
+	s3err := &S3Error{StatusCode: resp.StatusCode}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	_ = xml.Unmarshal(data, s3err)
+	return nil, s3err
+}
+
+// decodeXML decodes and closes an XML response body.
+func decodeXML(resp *http.Response, v any) error {
+	defer resp.Body.Close()
+	if err := xml.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("objectstore: decode S3 response: %w", err)
+	}
+	return nil
+}
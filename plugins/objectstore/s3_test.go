@@ -0,0 +1,261 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package objectstore_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy/plugins/objectstore"
+)
+
+// fakeS3 is a minimal path-style S3 server for a single bucket.
+type fakeS3 struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	types    map[string]string
+	uploads  map[string]map[int][]byte
+	nextID   int
+	requests []string
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *objectstore.S3) {
+	t.Helper()
+	f := &fakeS3{
+		objects: make(map[string][]byte),
+		types:   make(map[string]string),
+		uploads: make(map[string]map[int][]byte),
+	}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+
+	store, err := objectstore.NewS3(objectstore.S3Config{
+		Endpoint:        srv.URL,
+		Bucket:          "bucket",
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "SECRET",
+		PathStyle:       true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f, store
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, "<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>")
+		return
+	}
+	key, ok := strings.CutPrefix(r.URL.Path, "/bucket/")
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	q := r.URL.Query()
+	body, _ := io.ReadAll(r.Body)
+
+	op := r.Method
+	switch {
+	case q.Has("uploads"):
+		op = "CreateMultipartUpload"
+		f.nextID++
+		id := fmt.Sprint(f.nextID)
+		f.uploads[id] = make(map[int][]byte)
+		f.types[key] = r.Header.Get("Content-Type")
+		fmt.Fprintf(w, "<InitiateMultipartUploadResult><UploadId>%s</UploadId></InitiateMultipartUploadResult>", id)
+	case q.Has("partNumber"):
+		op = "UploadPart"
+		var n int
+		fmt.Sscan(q.Get("partNumber"), &n)
+		f.uploads[q.Get("uploadId")][n] = body
+		w.Header().Set("ETag", fmt.Sprintf(`"part%d"`, n))
+	case q.Has("uploadId") && r.Method == http.MethodPost:
+		op = "CompleteMultipartUpload"
+		var req struct {
+			Parts []struct {
+				PartNumber int
+				ETag       string
+			} `xml:"Part"`
+		}
+		_ = xml.Unmarshal(body, &req)
+		var data []byte
+		for _, p := range req.Parts {
+			data = append(data, f.uploads[q.Get("uploadId")][p.PartNumber]...)
+		}
+		f.objects[key] = data
+		delete(f.uploads, q.Get("uploadId"))
+		fmt.Fprint(w, `<CompleteMultipartUploadResult><ETag>"multi"</ETag></CompleteMultipartUploadResult>`)
+	case q.Has("uploadId") && r.Method == http.MethodDelete:
+		op = "AbortMultipartUpload"
+		delete(f.uploads, q.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut:
+		if r.ContentLength != int64(len(body)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.objects[key] = body
+		f.types[key] = r.Header.Get("Content-Type")
+		w.Header().Set("ETag", `"single"`)
+	case r.Method == http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "<Error><Code>NoSuchKey</Code></Error>")
+			return
+		}
+		w.Header().Set("Content-Type", f.types[key])
+		_, _ = w.Write(data)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+	f.requests = append(f.requests, op+" "+key)
+}
+
+func (f *fakeS3) log() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.requests...)
+}
+
+func (f *fakeS3) object(key string) ([]byte, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	data, ok := f.objects[key]
+	return data, ok
+}
+
+func TestS3_PutGetDelete(t *testing.T) {
+	f, store := newFakeS3(t)
+	ctx := context.Background()
+
+	obj, err := store.Put(ctx, "docs/a b.txt", strings.NewReader("hello"), objectstore.PutOptions{ContentType: "text/plain", Size: 5})
+	if err != nil || obj.ETag != `"single"` || obj.Size != 5 {
+		t.Fatalf("Put = %+v, %v", obj, err)
+	}
+
+	body, obj, err := store.Get(ctx, "docs/a b.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(body)
+	_ = body.Close()
+	if string(data) != "hello" || obj.ContentType != "text/plain" || obj.Size != 5 {
+		t.Errorf("Get = %q, %+v", data, obj)
+	}
+
+	if err := store.Delete(ctx, "docs/a b.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := store.Get(ctx, "docs/a b.txt"); !errors.Is(err, objectstore.ErrNotFound) {
+		t.Errorf("Get after Delete: err = %v, want ErrNotFound", err)
+	}
+	if _, ok := f.object("docs/a b.txt"); ok {
+		t.Error("object not deleted")
+	}
+}
+
+func TestS3_PutUnknownSize(t *testing.T) {
+	f, store := newFakeS3(t)
+	ctx := context.Background()
+
+	// Smaller than a part: single request.
+	obj, err := store.Put(ctx, "small", strings.NewReader("abc"), objectstore.PutOptions{Size: -1})
+	if err != nil || obj.Size != 3 {
+		t.Fatalf("Put small = %+v, %v", obj, err)
+	}
+
+	// Larger than a part: multipart upload.
+	data := bytes.Repeat([]byte("0123456789"), (objectstore.DefaultPartSize*2+500)/10)
+	obj, err = store.Put(ctx, "large", bytes.NewReader(data), objectstore.PutOptions{Size: -1})
+	if err != nil || obj.Size != int64(len(data)) || obj.ETag != `"multi"` {
+		t.Fatalf("Put large = %+v, %v", obj, err)
+	}
+	if stored, _ := f.object("large"); !bytes.Equal(stored, data) {
+		t.Errorf("stored %d bytes, want %d", len(stored), len(data))
+	}
+
+	want := []string{
+		"PUT small",
+		"CreateMultipartUpload large",
+		"UploadPart large",
+		"UploadPart large",
+		"UploadPart large",
+		"CompleteMultipartUpload large",
+	}
+	if got := f.log(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("requests = %v, want %v", got, want)
+	}
+}
+
+// failingReader returns data followed by an error.
+type failingReader struct{ data io.Reader }
+
+func (r failingReader) Read(p []byte) (int, error) {
+	n, err := r.data.Read(p)
+	if errors.Is(err, io.EOF) {
+		return n, errors.New("connection reset")
+	}
+	return n, err
+}
+
+func TestS3_PutAbortsMultipartUpload(t *testing.T) {
+	f, store := newFakeS3(t)
+	data := bytes.NewReader(make([]byte, objectstore.DefaultPartSize+1))
+	if _, err := store.Put(context.Background(), "broken", failingReader{data}, objectstore.PutOptions{Size: -1}); err == nil {
+		t.Fatal("expected error")
+	}
+	if log := f.log(); log[len(log)-1] != "AbortMultipartUpload broken" {
+		t.Errorf("upload not aborted: %v", log)
+	}
+}
+
+func TestS3_Errors(t *testing.T) {
+	_, store := newFakeS3(t)
+	bad, err := objectstore.NewS3(objectstore.S3Config{Endpoint: "ftp://example.com", Bucket: "b", AccessKeyID: "a", SecretAccessKey: "s"})
+	if err == nil || bad != nil {
+		t.Error("expected invalid endpoint error")
+	}
+
+	if _, err := store.SignedURL(context.Background(), http.MethodPost, "k", time.Minute); err == nil {
+		t.Error("expected unsupported method error")
+	}
+}
+
+func TestS3_SignedURL(t *testing.T) {
+	_, store := newFakeS3(t)
+	signed, err := store.SignedURL(context.Background(), http.MethodGet, "docs/a b.txt", 30*24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.EscapedPath() != "/bucket/docs/a%20b.txt" {
+		t.Errorf("path = %q", u.EscapedPath())
+	}
+	q := u.Query()
+	if q.Get("X-Amz-Expires") != "604800" || len(q.Get("X-Amz-Signature")) != 64 {
+		t.Errorf("query = %v", q)
+	}
+}
@@ -23,8 +23,8 @@ type MetricsConfig struct {
 
 	// Skipper defines a function to skip middleware for certain requests.
 	// If it returns true, the request will not be measured.
-	// Example: skip /health and /metrics endpoints.
-	Skipper func(*fursy.Context) bool
+	// Example: fursy.SkipPaths("/health", "/metrics").
+	Skipper fursy.Skipper
 
 	// ServerName is the logical server name to use in metric attributes.
 	// Maps to server.address semantic convention.
@@ -69,7 +69,7 @@ type metricsInstruments struct {
 	activeRequests  metric.Int64UpDownCounter
 	measureInflight bool
 	serverName      string
-	skipper         func(*fursy.Context) bool
+	skipper         fursy.Skipper
 }
 
 // Metrics returns a FURSY middleware that records HTTP metrics using OpenTelemetry.
@@ -85,6 +85,7 @@ type metricsInstruments struct {
 // Attributes recorded:
 //   - http.request.method - HTTP method (GET, POST, etc.)
 //   - http.response.status_code - HTTP status code
+//   - http.route - Route pattern (e.g., "/users/:id")
 //   - server.address - Server name (from config)
 //
 // Example:
@@ -95,9 +96,7 @@ type metricsInstruments struct {
 //	// With custom configuration:
 //	router.Use(opentelemetry.MetricsWithConfig(opentelemetry.MetricsConfig{
 //	    ServerName: "api.example.com",
-//	    Skipper: func(c *fursy.Context) bool {
-//	        return c.Request.URL.Path == "/health"
-//	    },
+//	    Skipper: fursy.SkipPaths("/health", "/metrics"),
 //	    RecordInFlightRequests: true,
 //	}))
 func Metrics(serverName string) fursy.HandlerFunc {
@@ -185,7 +184,7 @@ func MetricsWithConfig(config MetricsConfig) fursy.HandlerFunc {
 
 	return func(c *fursy.Context) error {
 		// Skip if Skipper returns true.
-		if instruments.skipper != nil && instruments.skipper(c) {
+		if instruments.skipper.ShouldSkip(c) {
 			return c.Next()
 		}
 
@@ -246,14 +245,27 @@ func httpMetricAttributes(c *fursy.Context, serverName string, statusCode int) [
 		semconv.HTTPResponseStatusCode(statusCode),
 	}
 
+	// Route pattern (http.route).
+	if route := c.Route(); route != "" {
+		attrs = append(attrs, semconv.HTTPRoute(route))
+	}
+
 	// Server name (server.address).
 	if serverName != "" {
 		attrs = append(attrs, semconv.ServerAddress(serverName))
 	}
 
+	// Split route variant (see fursy.SplitHandler).
+	if variant := c.Variant(); variant != "" {
+		attrs = append(attrs, attribute.String(variantAttribute, variant))
+	}
+
 	return attrs
 }
 
+// variantAttribute is the attribute key of the split route variant.
+const variantAttribute = "fursy.variant"
+
 // serverAddressAttribute returns server.address attribute if serverName is set.
 func serverAddressAttribute(serverName string) []attribute.KeyValue {
 	if serverName == "" {
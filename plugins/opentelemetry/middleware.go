@@ -37,8 +37,8 @@ type Config struct {
 
 	// Skipper defines a function to skip middleware for certain requests.
 	// If it returns true, the request will not be traced.
-	// Example: skip /health and /metrics endpoints.
-	Skipper func(*fursy.Context) bool
+	// Example: fursy.SkipPaths("/health", "/metrics").
+	Skipper fursy.Skipper
 
 	// SpanNameFormatter allows customizing the span name.
 	// Default format is "{method} {route}" (e.g., "GET /users/:id").
@@ -85,9 +85,7 @@ type Config struct {
 //
 //	// With custom configuration:
 //	router.Use(opentelemetry.MiddlewareWithConfig(opentelemetry.Config{
-//	    Skipper: func(c *fursy.Context) bool {
-//	        return c.Request.URL.Path == "/health"
-//	    },
+//	    Skipper: fursy.SkipPaths("/health", "/metrics"),
 //	    WithClientIP: true,
 //	    WithUserAgent: true,
 //	}))
@@ -121,7 +119,7 @@ func MiddlewareWithConfig(config Config) fursy.HandlerFunc {
 
 	return func(c *fursy.Context) error {
 		// Skip if Skipper returns true.
-		if config.Skipper != nil && config.Skipper(c) {
+		if config.Skipper.ShouldSkip(c) {
 			return c.Next()
 		}
 
@@ -161,6 +159,15 @@ func MiddlewareWithConfig(config Config) fursy.HandlerFunc {
 		status := wrapper.statusCode
 		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
 
+		// Tag the split route variant (see fursy.SplitHandler).
+		if variant := c.Variant(); variant != "" {
+			span.SetAttributes(attribute.String(variantAttribute, variant))
+		}
+
+		// Record which middleware ran and how long each took
+		// (see fursy.Named and Router.SetMiddlewareTiming).
+		addMiddlewareEvents(span, c.MiddlewareRuns())
+
 		// Set span status based on HTTP status code.
 		if status >= 400 {
 			span.SetStatus(codes.Error, http.StatusText(status))
@@ -185,14 +192,12 @@ func MiddlewareWithConfig(config Config) fursy.HandlerFunc {
 func defaultSpanNameFormatter(c *fursy.Context) string {
 	method := c.Request.Method
 
-	// Try to get route pattern from context.
-	// In FURSY, the route pattern isn't currently stored, so we use the path.
-	// Future improvement: store matched route pattern in context.
-	route := c.Request.URL.Path
-
-	// For known paths, use them directly.
-	// This creates better span names like "GET /users/:id" vs "GET /users/123".
-	// TODO: Store route pattern in context during routing.
+	// Use the route pattern for span names like "GET /users/:id"
+	// rather than "GET /users/123".
+	route := c.Route()
+	if route == "" {
+		route = c.Request.URL.Path
+	}
 
 	return fmt.Sprintf("%s %s", method, route)
 }
@@ -208,6 +213,11 @@ func httpServerAttributes(c *fursy.Context, config Config) []attribute.KeyValue
 		semconv.NetworkProtocolVersion(httpVersion(req)),
 	}
 
+	// Route pattern (http.route).
+	if route := c.Route(); route != "" {
+		attrs = append(attrs, semconv.HTTPRoute(route))
+	}
+
 	// Server name (server.address).
 	if config.ServerName != "" {
 		attrs = append(attrs, semconv.ServerAddress(config.ServerName))
@@ -349,3 +359,31 @@ func (w *responseWriter) Write(b []byte) (int, error) {
 func (w *responseWriter) Unwrap() http.ResponseWriter {
 	return w.ResponseWriter
 }
+
+// middlewareEvent is the span event name of a named middleware run.
+const middlewareEvent = "middleware"
+
+// Attribute keys of middleware span events.
+const (
+	middlewareNameAttribute     = "fursy.middleware.name"
+	middlewareDurationAttribute = "fursy.middleware.duration_ms"
+	middlewareSelfAttribute     = "fursy.middleware.self_ms"
+)
+
+// addMiddlewareEvents adds a span event for each completed middleware run,
+// timestamped when the middleware was called.
+func addMiddlewareEvents(span trace.Span, runs []fursy.MiddlewareRun) {
+	for _, run := range runs {
+		if run.Duration == 0 {
+			continue // Still running (e.g., this middleware).
+		}
+		span.AddEvent(middlewareEvent,
+			trace.WithTimestamp(run.Start),
+			trace.WithAttributes(
+				attribute.String(middlewareNameAttribute, run.Name),
+				attribute.Float64(middlewareDurationAttribute, float64(run.Duration.Nanoseconds())/1e6),
+				attribute.Float64(middlewareSelfAttribute, float64(run.Self.Nanoseconds())/1e6),
+			),
+		)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package opentelemetry
+
+import (
+	"context"
+
+	"github.com/coregx/fursy"
+	"github.com/coregx/fursy/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Attribute keys of middleware metrics.
+const (
+	circuitBreakerNameAttribute = "fursy.circuit_breaker.name"
+	circuitBreakerFromAttribute = "fursy.circuit_breaker.from"
+	circuitBreakerToAttribute   = "fursy.circuit_breaker.to"
+	jwtReasonAttribute          = "fursy.jwt.reason"
+	corsAllowedAttribute        = "fursy.cors.allowed"
+)
+
+// middlewareMetrics implements middleware.Metrics with OpenTelemetry instruments.
+type middlewareMetrics struct {
+	rateLimitRejections metric.Int64Counter
+	cbTransitions       metric.Int64Counter
+	cbState             metric.Int64Gauge
+	cbShortCircuits     metric.Int64Counter
+	jwtFailures         metric.Int64Counter
+	corsPreflights      metric.Int64Counter
+}
+
+// MiddlewareMetrics returns a middleware.Metrics that records events from
+// the built-in fursy middleware with OpenTelemetry.
+// If provider is nil, the global MeterProvider is used.
+//
+// The following metrics are recorded:
+//
+//   - fursy.ratelimit.rejections (Counter) - Requests rejected by RateLimit
+//   - fursy.circuit_breaker.transitions (Counter) - State changes, by name, from and to state
+//   - fursy.circuit_breaker.state (Gauge) - Current state by name (0 closed, 1 open, 2 half-open)
+//   - fursy.circuit_breaker.short_circuits (Counter) - Requests rejected by an open breaker, by name
+//   - fursy.jwt.failures (Counter) - JWT validation failures, by reason
+//   - fursy.cors.preflights (Counter) - CORS preflight requests, by allowed
+//
+// Use the OpenTelemetry Prometheus exporter to scrape them with Prometheus.
+//
+// Example:
+//
+//	middleware.SetMetrics(opentelemetry.MiddlewareMetrics(nil))
+//
+//	router.Use(middleware.RateLimit(10, 20))
+//	router.Use(middleware.CircuitBreakerWithName("payments", middleware.CircuitBreakerConfig{}))
+func MiddlewareMetrics(provider metric.MeterProvider) middleware.Metrics {
+	if provider == nil {
+		provider = otel.GetMeterProvider()
+	}
+
+	meter := provider.Meter(
+		ScopeName,
+		metric.WithInstrumentationVersion(Version),
+	)
+
+	m := &middlewareMetrics{}
+	var err error
+
+	m.rateLimitRejections, err = meter.Int64Counter(
+		"fursy.ratelimit.rejections",
+		metric.WithDescription("Number of requests rejected by the rate limiter"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		panic(err) // Should never happen unless meter is misconfigured.
+	}
+
+	m.cbTransitions, err = meter.Int64Counter(
+		"fursy.circuit_breaker.transitions",
+		metric.WithDescription("Number of circuit breaker state transitions"),
+		metric.WithUnit("{transition}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	m.cbState, err = meter.Int64Gauge(
+		"fursy.circuit_breaker.state",
+		metric.WithDescription("Current circuit breaker state (0 closed, 1 open, 2 half-open)"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	m.cbShortCircuits, err = meter.Int64Counter(
+		"fursy.circuit_breaker.short_circuits",
+		metric.WithDescription("Number of requests rejected by an open circuit breaker"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	m.jwtFailures, err = meter.Int64Counter(
+		"fursy.jwt.failures",
+		metric.WithDescription("Number of JWT validation failures"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	m.corsPreflights, err = meter.Int64Counter(
+		"fursy.cors.preflights",
+		metric.WithDescription("Number of CORS preflight requests"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+// RateLimitRejected implements middleware.Metrics.
+func (m *middlewareMetrics) RateLimitRejected(c *fursy.Context) {
+	m.rateLimitRejections.Add(c.Request.Context(), 1)
+}
+
+// CircuitBreakerStateChanged implements middleware.Metrics.
+func (m *middlewareMetrics) CircuitBreakerStateChanged(name string, from, to middleware.State) {
+	ctx := context.Background()
+	m.cbTransitions.Add(ctx, 1, metric.WithAttributes(
+		attribute.String(circuitBreakerNameAttribute, name),
+		attribute.String(circuitBreakerFromAttribute, from.String()),
+		attribute.String(circuitBreakerToAttribute, to.String()),
+	))
+	m.cbState.Record(ctx, int64(to), metric.WithAttributes(
+		attribute.String(circuitBreakerNameAttribute, name),
+	))
+}
+
+// CircuitBreakerShortCircuited implements middleware.Metrics.
+func (m *middlewareMetrics) CircuitBreakerShortCircuited(c *fursy.Context, name string) {
+	m.cbShortCircuits.Add(c.Request.Context(), 1, metric.WithAttributes(
+		attribute.String(circuitBreakerNameAttribute, name),
+	))
+}
+
+// JWTValidationFailed implements middleware.Metrics.
+func (m *middlewareMetrics) JWTValidationFailed(c *fursy.Context, reason string) {
+	m.jwtFailures.Add(c.Request.Context(), 1, metric.WithAttributes(
+		attribute.String(jwtReasonAttribute, reason),
+	))
+}
+
+// CORSPreflight implements middleware.Metrics.
+func (m *middlewareMetrics) CORSPreflight(c *fursy.Context, allowed bool) {
+	m.corsPreflights.Add(c.Request.Context(), 1, metric.WithAttributes(
+		attribute.Bool(corsAllowedAttribute, allowed),
+	))
+}
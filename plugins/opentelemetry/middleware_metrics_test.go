@@ -0,0 +1,96 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package opentelemetry
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy"
+	"github.com/coregx/fursy/middleware"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// collectSums returns the total of each Int64 counter by metric name.
+func collectSums(t *testing.T, reader *sdkmetric.ManualReader) map[string]int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	sums := make(map[string]int64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					sums[m.Name] += dp.Value
+				}
+			case metricdata.Gauge[int64]:
+				for _, dp := range data.DataPoints {
+					sums[m.Name] = dp.Value
+				}
+			}
+		}
+	}
+	return sums
+}
+
+func TestMiddlewareMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer func() { _ = mp.Shutdown(ctx) }()
+
+	middleware.SetMetrics(MiddlewareMetrics(mp))
+	defer middleware.SetMetrics(nil)
+
+	router := fursy.New()
+	router.Use(middleware.CORSWithConfig(middleware.CORSConfig{AllowOrigins: "https://example.com"}))
+	api := router.Group("/api", middleware.CircuitBreakerWithName("payments", middleware.CircuitBreakerConfig{
+		ConsecutiveFailures: 1,
+		Timeout:             time.Hour,
+	}))
+	api.GET("/pay", func(c *fursy.Context) error {
+		return c.String(500, "failed")
+	})
+	limited := router.Group("/limited", middleware.RateLimit(0.001, 1))
+	limited.GET("", func(c *fursy.Context) error {
+		return c.String(200, "OK")
+	})
+	secure := router.Group("/secure", middleware.JWT([]byte("secret")))
+	secure.GET("", func(c *fursy.Context) error {
+		return c.String(200, "OK")
+	})
+	router.OPTIONS("/cors", func(c *fursy.Context) error {
+		return c.NoContent(204)
+	})
+
+	for _, path := range []string{"/api/pay", "/api/pay", "/limited", "/limited", "/secure"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+	preflight := httptest.NewRequest("OPTIONS", "/cors", nil)
+	preflight.Header.Set("Origin", "https://example.com")
+	preflight.Header.Set("Access-Control-Request-Method", "GET")
+	router.ServeHTTP(httptest.NewRecorder(), preflight)
+
+	sums := collectSums(t, reader)
+	want := map[string]int64{
+		"fursy.ratelimit.rejections":           1,
+		"fursy.circuit_breaker.transitions":    1,
+		"fursy.circuit_breaker.state":          int64(middleware.StateOpen),
+		"fursy.circuit_breaker.short_circuits": 1,
+		"fursy.jwt.failures":                   1,
+		"fursy.cors.preflights":                1,
+	}
+	for name, value := range want {
+		if sums[name] != value {
+			t.Errorf("%s = %d, want %d", name, sums[name], value)
+		}
+	}
+}
@@ -63,8 +63,8 @@ func TestMiddleware_BasicTracing(t *testing.T) {
 	span := spans[0]
 
 	// Check span name.
-	if span.Name != "GET /users/123" {
-		t.Errorf("expected span name 'GET /users/123', got '%s'", span.Name)
+	if span.Name != "GET /users/:id" {
+		t.Errorf("expected span name 'GET /users/:id', got '%s'", span.Name)
 	}
 
 	// Check span kind.
@@ -76,6 +76,7 @@ func TestMiddleware_BasicTracing(t *testing.T) {
 	attrs := span.Attributes
 	hasMethod := false
 	hasPath := false
+	hasRoute := false
 	hasStatusCode := false
 
 	for _, attr := range attrs {
@@ -85,6 +86,9 @@ func TestMiddleware_BasicTracing(t *testing.T) {
 		if attr.Key == "url.path" && attr.Value.AsString() == "/users/123" {
 			hasPath = true
 		}
+		if attr.Key == semconv.HTTPRouteKey && attr.Value.AsString() == "/users/:id" {
+			hasRoute = true
+		}
 		if attr.Key == semconv.HTTPResponseStatusCodeKey && attr.Value.AsInt64() == 200 {
 			hasStatusCode = true
 		}
@@ -96,6 +100,9 @@ func TestMiddleware_BasicTracing(t *testing.T) {
 	if !hasPath {
 		t.Error("span missing url.path attribute")
 	}
+	if !hasRoute {
+		t.Error("span missing http.route attribute")
+	}
 	if !hasStatusCode {
 		t.Error("span missing http.response.status_code attribute")
 	}
@@ -645,3 +652,74 @@ func TestParsePort(t *testing.T) {
 		})
 	}
 }
+
+func TestMiddleware_MiddlewareEvents(t *testing.T) {
+	tp, exporter := setupTestTracer()
+	defer func() { _ = tp.Shutdown(context.Background()) }()
+
+	router := fursy.New()
+	router.Use(Middleware("test-service"))
+	router.Use(fursy.Named("auth", func(c *fursy.Context) error { return c.Next() }))
+	router.GET("/users", func(c *fursy.Context) error {
+		return c.String(200, "OK")
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users", nil))
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+
+	events := spans[0].Events
+	if len(events) != 1 || events[0].Name != middlewareEvent {
+		t.Fatalf("expected 1 middleware event, got %+v", events)
+	}
+
+	var name string
+	for _, attr := range events[0].Attributes {
+		if attr.Key == middlewareNameAttribute {
+			name = attr.Value.AsString()
+		}
+	}
+	if name != "auth" {
+		t.Errorf("expected middleware name %q, got %q", "auth", name)
+	}
+}
+
+func TestMiddleware_MiddlewareTiming(t *testing.T) {
+	tp, exporter := setupTestTracer()
+	defer func() { _ = tp.Shutdown(context.Background()) }()
+
+	router := fursy.New()
+	router.SetMiddlewareTiming(true)
+	router.Use(Middleware("test-service"))
+	api := router.Group("/api", fursy.Named("auth", func(c *fursy.Context) error { return c.Next() }))
+	api.GET("/users", func(c *fursy.Context) error {
+		return c.String(200, "OK")
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/users", nil))
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+
+	// The running tracing middleware itself is not reported.
+	events := spans[0].Events
+	if len(events) != 2 {
+		t.Fatalf("expected events for auth and handler, got %+v", events)
+	}
+	for _, event := range events {
+		hasSelf := false
+		for _, attr := range event.Attributes {
+			if attr.Key == middlewareSelfAttribute {
+				hasSelf = true
+			}
+		}
+		if !hasSelf {
+			t.Errorf("event %+v should have %s", event, middlewareSelfAttribute)
+		}
+	}
+}
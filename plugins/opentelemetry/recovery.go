@@ -0,0 +1,44 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package opentelemetry
+
+import (
+	"github.com/coregx/fursy"
+	"github.com/coregx/fursy/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RecordPanic marks the active span as errored and records the panic as an exception event.
+//
+// It is designed to be used as middleware.RecoveryConfig.OnPanic. The tracing
+// middleware must run before the recovery middleware so the span is still active.
+//
+// Example:
+//
+//	router := fursy.New()
+//	router.Use(opentelemetry.Middleware("my-service"))
+//	router.Use(middleware.RecoveryWithConfig(middleware.RecoveryConfig{
+//	    ProblemResponse: true,
+//	    OnPanic:         opentelemetry.RecordPanic,
+//	}))
+func RecordPanic(c *fursy.Context, info middleware.PanicInfo) {
+	span := trace.SpanFromContext(c.Request.Context())
+	if !span.IsRecording() {
+		return
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("incident.id", info.IncidentID),
+	}
+	if info.Stack != "" {
+		attrs = append(attrs, semconv.ExceptionStacktrace(info.Stack))
+	}
+
+	span.RecordError(info.Err, trace.WithAttributes(attrs...))
+	span.SetStatus(codes.Error, "panic: "+info.Err.Error())
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package stream
+
+import (
+	"context"
+	"sync"
+
+	"github.com/coregx/fursy"
+	"github.com/coregx/fursy/middleware"
+	"github.com/coregx/stream/sse"
+	"github.com/coregx/stream/websocket"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// connClaims maps open connections to the claims of the request that opened them.
+var connClaims sync.Map
+
+// Claims returns the JWT claims attached to a connection opened with
+// AuthSSEUpgrade or AuthWebSocketUpgrade.
+//
+// Use it in hub code to filter or route messages per user.
+//
+// Example:
+//
+//	claims, ok := stream.Claims(conn)
+//	if ok {
+//	    userID, _ := claims.GetSubject()
+//	}
+func Claims(conn any) (jwt.Claims, bool) {
+	claims, ok := connClaims.Load(conn)
+	if !ok {
+		return nil, false
+	}
+	return claims.(jwt.Claims), true
+}
+
+// AuthSSEUpgrade upgrades an authenticated request to SSE.
+//
+// The claims stored by middleware.JWT or middleware.StreamAuth are attached
+// to the connection (see Claims) for its lifetime. The connection is closed
+// when the request context ends, which with middleware.StreamAuth happens
+// when the token expires. Unauthenticated requests get 401 Unauthorized.
+//
+// Example:
+//
+//	streams := router.Group("/stream", middleware.StreamAuth(secret))
+//	streams.GET("/events", func(c *fursy.Context) error {
+//	    return stream.AuthSSEUpgrade(c, func(conn *sse.Conn) error {
+//	        hub.Register(conn)
+//	        defer hub.Unregister(conn)
+//	        <-conn.Done()
+//	        return nil
+//	    })
+//	})
+func AuthSSEUpgrade(c *fursy.Context, handler func(conn *sse.Conn) error) error {
+	claims, ok := c.Get(middleware.JWTContextKey).(jwt.Claims)
+	if !ok {
+		return c.Problem(fursy.Unauthorized("authentication required"))
+	}
+
+	return SSEUpgrade(c, func(conn *sse.Conn) error {
+		connClaims.Store(conn, claims)
+		defer connClaims.Delete(conn)
+
+		stop := context.AfterFunc(c.Request.Context(), func() {
+			_ = conn.Close()
+		})
+		defer stop()
+
+		return handler(conn)
+	})
+}
+
+// AuthWebSocketUpgrade upgrades an authenticated request to WebSocket.
+//
+// The claims stored by middleware.JWT or middleware.StreamAuth are attached
+// to the connection (see Claims) for its lifetime. The connection is closed
+// when the request context ends, which with middleware.StreamAuth happens
+// when the token expires. Unauthenticated requests get 401 Unauthorized.
+//
+// Example:
+//
+//	router.GET("/ws", func(c *fursy.Context) error {
+//	    return stream.AuthWebSocketUpgrade(c, func(conn *websocket.Conn) error {
+//	        hub.Register(conn)
+//	        defer hub.Unregister(conn)
+//	        for {
+//	            if _, _, err := conn.Read(); err != nil {
+//	                return err // Also returned when the token expires.
+//	            }
+//	        }
+//	    }, nil)
+//	})
+func AuthWebSocketUpgrade(c *fursy.Context, handler func(conn *websocket.Conn) error, opts *websocket.UpgradeOptions) error {
+	claims, ok := c.Get(middleware.JWTContextKey).(jwt.Claims)
+	if !ok {
+		return c.Problem(fursy.Unauthorized("authentication required"))
+	}
+
+	return WebSocketUpgrade(c, func(conn *websocket.Conn) error {
+		connClaims.Store(conn, claims)
+		defer connClaims.Delete(conn)
+
+		// websocket.Upgrade is not bound to the request context.
+		stop := context.AfterFunc(c.Request.Context(), func() {
+			_ = conn.Close()
+		})
+		defer stop()
+
+		return handler(conn)
+	}, opts)
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package stream
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+
+	"github.com/coregx/fursy"
+	"github.com/coregx/fursy/plugins/broker"
+	"github.com/coregx/stream/sse"
+	"github.com/coregx/stream/websocket"
+)
+
+// BridgeConfig defines the configuration of a Bridge.
+type BridgeConfig struct {
+	// Publisher publishes the broadcasts (required).
+	Publisher broker.Publisher
+
+	// Consumer delivers the broadcasts of every replica (required).
+	Consumer broker.Consumer
+
+	// Topic is the topic (NATS subject, Redis channel, ...) of the
+	// broadcasts (required). Use one topic per hub.
+	Topic string
+
+	// Group is the consumer group of this replica. Each replica must use
+	// its own group to receive every broadcast.
+	// Default: a group unique to the process ("stream-<hostname>-<random>")
+	Group string
+
+	// ErrorHandler is called with the errors of received broadcasts
+	// (decoding or delivery errors) and of the consumer.
+	// Default: nil (errors are ignored; the consumer is restarted)
+	ErrorHandler func(err error)
+}
+
+// Bridge relays hub broadcasts through a message broker, so events
+// broadcast on one replica reach the clients connected to every replica.
+//
+// Broadcast publishes the event instead of delivering it locally; every
+// replica, including the publishing one, consumes it and delivers it to its
+// local hub. Any broker.Publisher and broker.Consumer can carry the events:
+// NATS (plugins/broker/nats), Kafka, or a Redis Pub/Sub implementation of
+// the broker interfaces.
+type Bridge[T any] struct {
+	publisher broker.Publisher
+	topic     string
+	consumers *broker.Consumers
+}
+
+// NewBridge starts consuming the broadcasts of config.Topic, delivering
+// each event to deliver, and returns the bridge publishing them.
+// The consumer is stopped when the router shuts down; pass a nil router
+// to stop it with Bridge.Stop. Events are encoded as JSON.
+//
+// Use SSEBridge, WebSocketBridge and FanoutBridge to deliver to a hub.
+//
+// Example:
+//
+//	bridge := stream.NewBridge(router, stream.BridgeConfig{
+//	    Publisher: b,
+//	    Consumer:  b,
+//	    Topic:     "notifications",
+//	}, func(n Notification) error {
+//	    return hub.Broadcast(n)
+//	})
+func NewBridge[T any](r *fursy.Router, config BridgeConfig, deliver func(T) error) *Bridge[T] {
+	// Validate config.
+	if config.Publisher == nil || config.Consumer == nil {
+		panic("stream: bridge publisher and consumer are required")
+	}
+	if config.Topic == "" {
+		panic("stream: bridge topic is required")
+	}
+	if deliver == nil {
+		panic("stream: bridge deliver function is required")
+	}
+
+	// Set defaults.
+	if config.Group == "" {
+		config.Group = replicaGroup()
+	}
+
+	handleError := config.ErrorHandler
+	if handleError == nil {
+		handleError = func(error) {}
+	}
+
+	b := &Bridge[T]{publisher: config.Publisher, topic: config.Topic}
+	b.consumers = broker.StartConsumers(r, broker.ConsumersConfig{
+		Consumer: config.Consumer,
+		Subscriptions: []broker.Subscription{{
+			Topic: config.Topic,
+			Group: config.Group,
+			Handler: func(_ context.Context, msg *broker.Message) error {
+				var event T
+				if err := json.Unmarshal(msg.Value, &event); err != nil {
+					// A malformed event must not stop the consumer.
+					handleError(err)
+					return nil
+				}
+				if err := deliver(event); err != nil {
+					handleError(err)
+				}
+				return nil
+			},
+		}},
+		ErrorHandler: func(_ broker.Subscription, err error) {
+			handleError(err)
+		},
+	})
+	return b
+}
+
+// Broadcast publishes event to the hubs of every replica.
+//
+// Example:
+//
+//	router.POST("/notify", func(c *fursy.Context) error {
+//	    if err := bridge.Broadcast(c.Request.Context(), notification); err != nil {
+//	        return err
+//	    }
+//	    return c.NoContent(202)
+//	})
+func (b *Bridge[T]) Broadcast(ctx context.Context, event T) error {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return b.publisher.Publish(ctx, &broker.Message{Topic: b.topic, Value: value})
+}
+
+// Stop stops consuming broadcasts. It does not close the publisher.
+func (b *Bridge[T]) Stop() {
+	b.consumers.Stop()
+}
+
+// SSEBridge returns a bridge delivering the broadcasts of every replica
+// to hub (see NewBridge).
+//
+// Example:
+//
+//	hub := sse.NewHub[Notification]()
+//	go hub.Run()
+//
+//	bridge := stream.SSEBridge(router, hub, stream.BridgeConfig{
+//	    Publisher: b,
+//	    Consumer:  b,
+//	    Topic:     "notifications",
+//	})
+//
+//	// On any replica:
+//	_ = bridge.Broadcast(ctx, Notification{Type: "info", Message: "deployed"})
+func SSEBridge[T any](r *fursy.Router, hub *sse.Hub[T], config BridgeConfig) *Bridge[T] {
+	return NewBridge(r, config, hub.Broadcast)
+}
+
+// WebSocketBridge returns a bridge delivering the broadcasts of every
+// replica to hub (see NewBridge).
+func WebSocketBridge(r *fursy.Router, hub *websocket.Hub, config BridgeConfig) *Bridge[[]byte] {
+	return NewBridge(r, config, func(data []byte) error {
+		hub.Broadcast(data)
+		return nil
+	})
+}
+
+// FanoutBridge returns a bridge delivering the broadcasts of every replica
+// to fanout (see NewBridge).
+func FanoutBridge[T any](r *fursy.Router, fanout *Fanout[T], config BridgeConfig) *Bridge[T] {
+	return NewBridge(r, config, func(event T) error {
+		fanout.Broadcast(event)
+		return nil
+	})
+}
+
+// replicaGroup returns a consumer group unique to the process.
+func replicaGroup() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "replica"
+	}
+	var suffix [4]byte
+	_, _ = rand.Read(suffix[:])
+	return "stream-" + host + "-" + hex.EncodeToString(suffix[:])
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package stream_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy/plugins/broker"
+	"github.com/coregx/fursy/plugins/stream"
+)
+
+type bridgeEvent struct {
+	Message string `json:"message"`
+}
+
+// replica collects the events delivered to the hub of a replica.
+type replica struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *replica) deliver(e bridgeEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e.Message)
+	return nil
+}
+
+func (r *replica) received() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.events)
+}
+
+func TestBridge_Broadcast(t *testing.T) {
+	b := broker.NewMemory(0)
+	config := stream.BridgeConfig{Publisher: b, Consumer: b, Topic: "notifications"}
+
+	var first, second replica
+	bridge1 := stream.NewBridge(nil, config, first.deliver)
+	defer bridge1.Stop()
+	bridge2 := stream.NewBridge(nil, config, second.deliver)
+	defer bridge2.Stop()
+
+	// Wait for both consumers to subscribe.
+	waitFor(t, func() bool {
+		_ = bridge1.Broadcast(context.Background(), bridgeEvent{Message: "ping"})
+		time.Sleep(10 * time.Millisecond)
+		return len(first.received()) > 0 && len(second.received()) > 0
+	})
+
+	if err := bridge2.Broadcast(context.Background(), bridgeEvent{Message: "deployed"}); err != nil {
+		t.Fatalf("Broadcast: %v", err)
+	}
+	for _, r := range []*replica{&first, &second} {
+		waitFor(t, func() bool { return slices.Contains(r.received(), "deployed") })
+	}
+}
+
+func TestBridge_ErrorHandler(t *testing.T) {
+	b := broker.NewMemory(0)
+	errs := make(chan error, 4)
+	errDeliver := errors.New("hub closed")
+
+	bridge := stream.NewBridge(nil, stream.BridgeConfig{
+		Publisher:    b,
+		Consumer:     b,
+		Topic:        "events",
+		ErrorHandler: func(err error) { errs <- err },
+	}, func(bridgeEvent) error { return errDeliver })
+	defer bridge.Stop()
+
+	// Malformed events are reported without stopping the consumer.
+	for deadline := time.Now().Add(time.Second); ; {
+		_ = b.Publish(context.Background(), &broker.Message{Topic: "events", Value: []byte("not json")})
+		select {
+		case err := <-errs:
+			if errors.Is(err, errDeliver) {
+				t.Fatalf("malformed event delivered: %v", err)
+			}
+		case <-time.After(10 * time.Millisecond):
+			if time.Now().After(deadline) {
+				t.Fatal("expected decoding error")
+			}
+			continue
+		}
+		break
+	}
+
+	if err := bridge.Broadcast(context.Background(), bridgeEvent{Message: "x"}); err != nil {
+		t.Fatalf("Broadcast: %v", err)
+	}
+	select {
+	case err := <-errs:
+		if !errors.Is(err, errDeliver) {
+			t.Errorf("err = %v, want %v", err, errDeliver)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected delivery error")
+	}
+}
+
+func TestFanoutBridge(t *testing.T) {
+	b := broker.NewMemory(0)
+	conn := newBlockingConn()
+	close(conn.release)
+
+	fanout := stream.NewFanout[int]()
+	q := stream.NewQueue(conn.send, conn.close, stream.QueueConfig{})
+	defer q.Close()
+	fanout.Add(q)
+
+	bridge := stream.FanoutBridge(nil, fanout, stream.BridgeConfig{Publisher: b, Consumer: b, Topic: "counts"})
+	defer bridge.Stop()
+
+	waitFor(t, func() bool {
+		_ = bridge.Broadcast(context.Background(), 7)
+		time.Sleep(10 * time.Millisecond)
+		return len(conn.messages()) > 0
+	})
+	if got := conn.messages(); got[0] != 7 {
+		t.Errorf("received %v", got)
+	}
+}
+
+func TestNewBridge_InvalidConfig(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	stream.NewBridge(nil, stream.BridgeConfig{}, func(bridgeEvent) error { return nil })
+}
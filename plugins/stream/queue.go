@@ -0,0 +1,431 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package stream
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/coregx/fursy"
+	"github.com/coregx/stream/sse"
+	"github.com/coregx/stream/websocket"
+)
+
+// DefaultQueueSize is the default number of messages buffered per connection.
+const DefaultQueueSize = 64
+
+// SlowClientPolicy defines what a Queue does with a message sent while it
+// is full, i.e. when the client reads slower than messages are produced.
+type SlowClientPolicy int
+
+const (
+	// DropNewest drops the message being sent.
+	DropNewest SlowClientPolicy = iota
+
+	// DropOldest drops the oldest queued message to make room.
+	DropOldest
+
+	// Disconnect closes the connection of the client.
+	Disconnect
+)
+
+// Queue errors.
+var (
+	// ErrQueueFull is returned by Queue.Send when the message is dropped.
+	ErrQueueFull = errors.New("stream: send queue full")
+
+	// ErrQueueClosed is returned by Queue.Send after the queue is closed,
+	// including when it disconnected a slow client.
+	ErrQueueClosed = errors.New("stream: send queue closed")
+)
+
+// QueueConfig defines the send queue of a connection.
+type QueueConfig struct {
+	// Size is the number of messages buffered for the connection.
+	// Default: DefaultQueueSize (64)
+	Size int
+
+	// Policy is applied to messages sent while the queue is full.
+	// Default: DropNewest
+	Policy SlowClientPolicy
+
+	// Metrics collects the counters of the queue. Share one QueueMetrics
+	// between the connections of a hub to monitor it.
+	// Default: nil (not collected)
+	Metrics *QueueMetrics
+}
+
+// QueueMetrics counts the messages of send queues. It is safe for
+// concurrent use; the zero value is ready to use.
+type QueueMetrics struct {
+	connections  atomic.Int64
+	queued       atomic.Int64
+	sent         atomic.Int64
+	dropped      atomic.Int64
+	failed       atomic.Int64
+	disconnected atomic.Int64
+}
+
+// QueueStats is a snapshot of QueueMetrics.
+type QueueStats struct {
+	// Connections is the number of open queues.
+	Connections int64
+
+	// Queued is the number of messages waiting to be sent.
+	Queued int64
+
+	// Sent is the number of messages written to connections.
+	Sent int64
+
+	// Dropped is the number of messages dropped by DropNewest and DropOldest.
+	Dropped int64
+
+	// Failed is the number of messages whose write failed.
+	Failed int64
+
+	// Disconnected is the number of slow clients disconnected.
+	Disconnected int64
+}
+
+// Stats returns a snapshot of the counters.
+//
+// Example:
+//
+//	router.GET("/debug/stream", func(c *fursy.Context) error {
+//	    return c.JSON(200, metrics.Stats())
+//	})
+func (m *QueueMetrics) Stats() QueueStats {
+	return QueueStats{
+		Connections:  m.connections.Load(),
+		Queued:       m.queued.Load(),
+		Sent:         m.sent.Load(),
+		Dropped:      m.dropped.Load(),
+		Failed:       m.failed.Load(),
+		Disconnected: m.disconnected.Load(),
+	}
+}
+
+// Queue is a bounded send queue of a connection. Send never blocks: a
+// goroutine writes the queued messages to the connection, and the policy
+// decides what happens when the client cannot keep up, so a stalled client
+// holds at most Size messages and never delays the others.
+type Queue[T any] struct {
+	messages   chan T
+	send       func(T) error
+	disconnect func() error
+	policy     SlowClientPolicy
+	metrics    *QueueMetrics
+
+	mu     sync.Mutex // Serializes Send with Close.
+	closed bool
+	done   chan struct{}
+}
+
+// NewQueue returns a queue writing messages with send, and starts its
+// writer goroutine. disconnect closes the connection; it is called when the
+// Disconnect policy applies or a write fails.
+//
+// Use SSEQueue and WebSocketQueue for the connections of the stream plugin.
+//
+// Example:
+//
+//	q := stream.NewQueue(conn.SendJSON, conn.Close, stream.QueueConfig{
+//	    Size:   16,
+//	    Policy: stream.DropOldest,
+//	})
+//	defer q.Close()
+func NewQueue[T any](send func(T) error, disconnect func() error, config QueueConfig) *Queue[T] {
+	// Validate config.
+	if config.Size < 0 {
+		panic("stream: queue size must not be negative")
+	}
+
+	// Set defaults.
+	if config.Size == 0 {
+		config.Size = DefaultQueueSize
+	}
+	if config.Metrics == nil {
+		config.Metrics = new(QueueMetrics)
+	}
+
+	q := &Queue[T]{
+		messages:   make(chan T, config.Size),
+		send:       send,
+		disconnect: disconnect,
+		policy:     config.Policy,
+		metrics:    config.Metrics,
+		done:       make(chan struct{}),
+	}
+	q.metrics.connections.Add(1)
+	go q.run()
+	return q
+}
+
+// Send queues msg without blocking. It returns ErrQueueFull if msg (or,
+// with DropOldest, an older message) was dropped, and ErrQueueClosed if
+// the queue is closed.
+func (q *Queue[T]) Send(msg T) error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return ErrQueueClosed
+	}
+
+	err := q.enqueue(msg)
+	if errors.Is(err, ErrQueueClosed) {
+		q.closeLocked()
+	}
+	q.mu.Unlock()
+
+	if errors.Is(err, ErrQueueClosed) {
+		q.metrics.disconnected.Add(1)
+		_ = q.disconnect()
+	}
+	return err
+}
+
+// enqueue queues msg, applying the policy if the queue is full.
+// q.mu must be held, so only the writer goroutine takes messages meanwhile.
+func (q *Queue[T]) enqueue(msg T) error {
+	select {
+	case q.messages <- msg:
+		q.metrics.queued.Add(1)
+		return nil
+	default:
+	}
+
+	switch q.policy {
+	case DropOldest:
+		var err error
+		select {
+		case <-q.messages:
+			q.metrics.queued.Add(-1)
+			q.metrics.dropped.Add(1)
+			err = ErrQueueFull
+		default:
+			// The writer took a message meanwhile.
+		}
+		q.messages <- msg
+		q.metrics.queued.Add(1)
+		return err
+	case Disconnect:
+		return ErrQueueClosed
+	default:
+		q.metrics.dropped.Add(1)
+		return ErrQueueFull
+	}
+}
+
+// Len returns the number of queued messages.
+func (q *Queue[T]) Len() int {
+	return len(q.messages)
+}
+
+// Cap returns the size of the queue.
+func (q *Queue[T]) Cap() int {
+	return cap(q.messages)
+}
+
+// Done returns a channel closed when the queue is closed.
+func (q *Queue[T]) Done() <-chan struct{} {
+	return q.done
+}
+
+// Close closes the queue. Queued messages are discarded.
+// Close does not close the connection.
+func (q *Queue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closeLocked()
+}
+
+// closeLocked closes the queue; q.mu must be held.
+func (q *Queue[T]) closeLocked() {
+	if q.closed {
+		return
+	}
+	q.closed = true
+	close(q.done)
+	q.metrics.connections.Add(-1)
+
+	// Discard the queued messages.
+	for {
+		select {
+		case <-q.messages:
+			q.metrics.queued.Add(-1)
+		default:
+			return
+		}
+	}
+}
+
+// run writes the queued messages until the queue is closed or a write fails.
+func (q *Queue[T]) run() {
+	for {
+		select {
+		case <-q.done:
+			return
+		case msg := <-q.messages:
+			q.metrics.queued.Add(-1)
+			if err := q.send(msg); err != nil {
+				q.metrics.failed.Add(1)
+				q.Close()
+				_ = q.disconnect()
+				return
+			}
+			q.metrics.sent.Add(1)
+		}
+	}
+}
+
+// queueConfigKey stores the QueueConfig of SSEHubWithConfig and
+// WebSocketHubWithConfig in the request context.
+type queueConfigKey struct{}
+
+// SSEHubWithConfig is SSEHub with the send queue configuration used by
+// SSEQueue for the connections of the hub.
+//
+// Example:
+//
+//	metrics := new(stream.QueueMetrics)
+//	router.Use(stream.SSEHubWithConfig(hub, stream.QueueConfig{
+//	    Size:    32,
+//	    Policy:  stream.Disconnect,
+//	    Metrics: metrics,
+//	}))
+func SSEHubWithConfig[T any](hub *sse.Hub[T], config QueueConfig) fursy.HandlerFunc {
+	return withQueueConfig(SSEHub(hub), config)
+}
+
+// WebSocketHubWithConfig is WebSocketHub with the send queue configuration
+// used by WebSocketQueue for the connections of the hub.
+func WebSocketHubWithConfig(hub *websocket.Hub, config QueueConfig) fursy.HandlerFunc {
+	return withQueueConfig(WebSocketHub(hub), config)
+}
+
+// withQueueConfig stores config in the request context, then runs hubMiddleware.
+func withQueueConfig(hubMiddleware fursy.HandlerFunc, config QueueConfig) fursy.HandlerFunc {
+	// Validate config.
+	if config.Size < 0 {
+		panic("stream: queue size must not be negative")
+	}
+
+	return func(c *fursy.Context) error {
+		ctx := context.WithValue(c.Request.Context(), queueConfigKey{}, config)
+		c.Request = c.Request.WithContext(ctx)
+		return hubMiddleware(c)
+	}
+}
+
+// queueConfig returns the QueueConfig of the hub middleware of c, if any.
+func queueConfig(c *fursy.Context) QueueConfig {
+	config, _ := c.Request.Context().Value(queueConfigKey{}).(QueueConfig)
+	return config
+}
+
+// SSEQueue returns a send queue writing JSON events to conn, configured by
+// SSEHubWithConfig (defaults otherwise). The queue is closed when conn is.
+//
+// Example:
+//
+//	return c.SSE(func(conn *sse.Conn) error {
+//	    q := stream.SSEQueue[Notification](c, conn)
+//	    defer q.Close()
+//	    fanout.Add(q)
+//	    defer fanout.Remove(q)
+//	    <-conn.Done()
+//	    return nil
+//	})
+func SSEQueue[T any](c *fursy.Context, conn *sse.Conn) *Queue[T] {
+	q := NewQueue(func(msg T) error { return conn.SendJSON(msg) }, conn.Close, queueConfig(c))
+	go func() {
+		select {
+		case <-conn.Done():
+			q.Close()
+		case <-q.Done():
+		}
+	}()
+	return q
+}
+
+// WebSocketQueue returns a send queue writing JSON messages to conn,
+// configured by WebSocketHubWithConfig (defaults otherwise).
+// Close the queue when the connection ends.
+//
+// Example:
+//
+//	return stream.WebSocketUpgrade(c, func(conn *websocket.Conn) error {
+//	    q := stream.WebSocketQueue[ChatMessage](c, conn)
+//	    defer q.Close()
+//	    fanout.Add(q)
+//	    defer fanout.Remove(q)
+//	    for {
+//	        if _, _, err := conn.Read(); err != nil {
+//	            return nil
+//	        }
+//	    }
+//	}, nil)
+func WebSocketQueue[T any](c *fursy.Context, conn *websocket.Conn) *Queue[T] {
+	return NewQueue(func(msg T) error { return conn.WriteJSON(msg) }, conn.Close, queueConfig(c))
+}
+
+// Fanout broadcasts messages to a set of send queues. Unlike writing to
+// every connection in turn, a Broadcast never waits for a slow client: each
+// queue applies its own SlowClientPolicy.
+//
+// Example:
+//
+//	fanout := stream.NewFanout[Notification]()
+//	fanout.Broadcast(Notification{Type: "info", Message: "deployed"})
+type Fanout[T any] struct {
+	mu     sync.RWMutex
+	queues map[*Queue[T]]struct{}
+}
+
+// NewFanout returns an empty Fanout.
+func NewFanout[T any]() *Fanout[T] {
+	return &Fanout[T]{queues: make(map[*Queue[T]]struct{})}
+}
+
+// Add adds q to the fanout.
+func (f *Fanout[T]) Add(q *Queue[T]) {
+	f.mu.Lock()
+	f.queues[q] = struct{}{}
+	f.mu.Unlock()
+}
+
+// Remove removes q from the fanout.
+func (f *Fanout[T]) Remove(q *Queue[T]) {
+	f.mu.Lock()
+	delete(f.queues, q)
+	f.mu.Unlock()
+}
+
+// Len returns the number of queues.
+func (f *Fanout[T]) Len() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return len(f.queues)
+}
+
+// Broadcast sends msg to every queue without blocking.
+// Closed queues are removed.
+func (f *Fanout[T]) Broadcast(msg T) {
+	var closed []*Queue[T]
+
+	f.mu.RLock()
+	for q := range f.queues {
+		if err := q.Send(msg); errors.Is(err, ErrQueueClosed) {
+			closed = append(closed, q)
+		}
+	}
+	f.mu.RUnlock()
+
+	for _, q := range closed {
+		f.Remove(q)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package stream_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy/plugins/stream"
+)
+
+// blockingConn is a connection whose writes block until released.
+type blockingConn struct {
+	mu      sync.Mutex
+	sent    []int
+	release chan struct{}
+	closed  chan struct{}
+	once    sync.Once
+}
+
+func newBlockingConn() *blockingConn {
+	return &blockingConn{release: make(chan struct{}), closed: make(chan struct{})}
+}
+
+func (b *blockingConn) send(msg int) error {
+	select {
+	case <-b.release:
+	case <-b.closed:
+		return errors.New("closed")
+	}
+	b.mu.Lock()
+	b.sent = append(b.sent, msg)
+	b.mu.Unlock()
+	return nil
+}
+
+func (b *blockingConn) close() error {
+	b.once.Do(func() { close(b.closed) })
+	return nil
+}
+
+func (b *blockingConn) messages() []int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]int(nil), b.sent...)
+}
+
+// fillQueue sends messages until the writer is blocked on the first one
+// and the queue is full.
+func fillQueue(t *testing.T, q *stream.Queue[int]) {
+	t.Helper()
+	if err := q.Send(0); err != nil {
+		t.Fatalf("Send(0) = %v", err)
+	}
+	for deadline := time.Now().Add(time.Second); q.Len() > 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("writer did not take the first message")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	for i := 1; i <= q.Cap(); i++ {
+		if err := q.Send(i); err != nil {
+			t.Fatalf("Send(%d) = %v", i, err)
+		}
+	}
+}
+
+// waitFor waits until cond is true.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); !cond(); {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestQueue_DropNewest(t *testing.T) {
+	conn := newBlockingConn()
+	metrics := new(stream.QueueMetrics)
+	q := stream.NewQueue(conn.send, conn.close, stream.QueueConfig{Size: 2, Metrics: metrics})
+	defer q.Close()
+
+	fillQueue(t, q)
+	if err := q.Send(3); !errors.Is(err, stream.ErrQueueFull) {
+		t.Errorf("Send on full queue = %v, want ErrQueueFull", err)
+	}
+
+	close(conn.release)
+	waitFor(t, func() bool { return len(conn.messages()) == 3 })
+	if got := conn.messages(); got[0] != 0 || got[1] != 1 || got[2] != 2 {
+		t.Errorf("sent = %v, want [0 1 2]", got)
+	}
+	waitFor(t, func() bool { return metrics.Stats().Sent == 3 })
+	if stats := metrics.Stats(); stats.Dropped != 1 || stats.Queued != 0 || stats.Connections != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestQueue_DropOldest(t *testing.T) {
+	conn := newBlockingConn()
+	q := stream.NewQueue(conn.send, conn.close, stream.QueueConfig{Size: 2, Policy: stream.DropOldest})
+	defer q.Close()
+
+	fillQueue(t, q)
+	if err := q.Send(3); !errors.Is(err, stream.ErrQueueFull) {
+		t.Errorf("Send on full queue = %v, want ErrQueueFull", err)
+	}
+
+	close(conn.release)
+	waitFor(t, func() bool { return len(conn.messages()) == 3 })
+	if got := conn.messages(); got[0] != 0 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("sent = %v, want [0 2 3]", got)
+	}
+}
+
+func TestQueue_Disconnect(t *testing.T) {
+	conn := newBlockingConn()
+	metrics := new(stream.QueueMetrics)
+	q := stream.NewQueue(conn.send, conn.close, stream.QueueConfig{Size: 1, Policy: stream.Disconnect, Metrics: metrics})
+
+	fillQueue(t, q)
+	if err := q.Send(2); !errors.Is(err, stream.ErrQueueClosed) {
+		t.Errorf("Send on full queue = %v, want ErrQueueClosed", err)
+	}
+
+	select {
+	case <-conn.closed:
+	case <-time.After(time.Second):
+		t.Fatal("expected slow client to be disconnected")
+	}
+	<-q.Done()
+	if err := q.Send(3); !errors.Is(err, stream.ErrQueueClosed) {
+		t.Errorf("Send after disconnect = %v, want ErrQueueClosed", err)
+	}
+	waitFor(t, func() bool { return metrics.Stats().Failed == 1 })
+	if stats := metrics.Stats(); stats.Disconnected != 1 || stats.Connections != 0 || stats.Queued != 0 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestFanout_Broadcast(t *testing.T) {
+	fast := newBlockingConn()
+	close(fast.release)
+	slow := newBlockingConn()
+
+	fanout := stream.NewFanout[int]()
+	fastQueue := stream.NewQueue(fast.send, fast.close, stream.QueueConfig{Size: 4})
+	slowQueue := stream.NewQueue(slow.send, slow.close, stream.QueueConfig{Size: 1, Policy: stream.Disconnect})
+	defer fastQueue.Close()
+	fanout.Add(fastQueue)
+	fanout.Add(slowQueue)
+
+	for i := range 4 {
+		fanout.Broadcast(i)
+		// Let the fast client keep up.
+		waitFor(t, func() bool { return len(fast.messages()) == i+1 })
+	}
+
+	if got := fast.messages(); len(got) != 4 {
+		t.Errorf("fast client received %v", got)
+	}
+	if fanout.Len() != 1 {
+		t.Errorf("Len() = %d, want the slow client removed", fanout.Len())
+	}
+}
+
+func TestNewQueue_InvalidSize(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	stream.NewQueue(func(int) error { return nil }, func() error { return nil }, stream.QueueConfig{Size: -1})
+}
@@ -12,6 +12,8 @@
 //   - WebSocketHub middleware for sharing WebSocket hubs
 //   - Context helper methods: c.SSE() and c.WebSocket()
 //   - Type-safe hub retrieval with generics
+//   - Bounded per-connection send queues with slow-client policies
+//   - Bridge relaying hub broadcasts across replicas through a message broker
 //
 // Example SSE usage:
 //
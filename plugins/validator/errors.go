@@ -6,6 +6,8 @@ package validator
 
 import (
 	"fmt"
+	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/coregx/fursy"
@@ -20,7 +22,10 @@ const (
 )
 
 // convertErrors converts validator.ValidationErrors to fursy.ValidationErrors.
-func (v *Validator) convertErrors(errs validator.ValidationErrors) fursy.ValidationErrors {
+//
+// typ is the type of the validated struct, used to build the JSON Pointer
+// of each field from its json tags; it is nil for Var.
+func (v *Validator) convertErrors(errs validator.ValidationErrors, typ reflect.Type) fursy.ValidationErrors {
 	var result fursy.ValidationErrors
 
 	for _, err := range errs {
@@ -29,12 +34,108 @@ func (v *Validator) convertErrors(errs validator.ValidationErrors) fursy.Validat
 			Tag:     err.Tag(),
 			Value:   err.Value(),
 			Message: v.formatMessage(err),
+			Pointer: jsonPointer(typ, err.StructNamespace()),
+			Params:  ruleParams(err),
 		})
 	}
 
 	return result
 }
 
+// ruleParams returns the parameters of the failed rule:
+// {"limit": n} for size and comparison rules, {"values": [...]} for oneof,
+// and {"param": p} for other rules with a parameter.
+func ruleParams(err validator.FieldError) map[string]any {
+	param := err.Param()
+	if param == "" {
+		return nil
+	}
+
+	switch err.Tag() {
+	case "min", "max", "len", "gt", "gte", "lt", "lte", "eq", "ne":
+		if n, parseErr := strconv.ParseFloat(param, 64); parseErr == nil {
+			return map[string]any{"limit": n}
+		}
+		return map[string]any{"limit": param}
+	case "oneof":
+		return map[string]any{"values": strings.Fields(param)}
+	default:
+		return map[string]any{"param": param}
+	}
+}
+
+// jsonPointer converts a struct namespace of Go field names
+// (e.g., "User.Address.ZipCode" or "Order.Items[0].SKU") to the JSON Pointer
+// of the field in the encoded struct ("/address/zip_code", "/items/0/sku"),
+// following json tags and embedded structs.
+//
+// Returns "" if typ is nil or the namespace cannot be resolved.
+func jsonPointer(typ reflect.Type, namespace string) string {
+	if typ == nil {
+		return ""
+	}
+
+	segments := strings.Split(namespace, ".")
+	if len(segments) < 2 {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, segment := range segments[1:] { // Skip the struct type name.
+		name, keys, _ := strings.Cut(segment, "[")
+
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct {
+			return ""
+		}
+		field, ok := typ.FieldByName(name)
+		if !ok {
+			return ""
+		}
+		typ = field.Type
+
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Anonymous && jsonName == "" && isStruct(field.Type) {
+			// Embedded struct fields are promoted in JSON.
+			continue
+		}
+		if jsonName == "" || jsonName == "-" {
+			jsonName = field.Name
+		}
+		sb.WriteByte('/')
+		sb.WriteString(fursy.EscapePointerToken(jsonName))
+
+		// Indexes and map keys: "Items[0]", "Matrix[1][2]", "Labels[env]".
+		for keys != "" {
+			key, rest, _ := strings.Cut(keys, "]")
+			sb.WriteByte('/')
+			sb.WriteString(fursy.EscapePointerToken(key))
+			keys = strings.TrimPrefix(rest, "[")
+
+			for typ.Kind() == reflect.Pointer {
+				typ = typ.Elem()
+			}
+			switch typ.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				typ = typ.Elem()
+			default:
+				return ""
+			}
+		}
+	}
+	return sb.String()
+}
+
+// isStruct reports whether t is a struct or a pointer to a struct.
+func isStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
 // formatMessage creates a human-readable error message for a validation error.
 func (v *Validator) formatMessage(err validator.FieldError) string {
 	// Check if custom message exists for this tag.
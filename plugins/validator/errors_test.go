@@ -8,6 +8,8 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/coregx/fursy"
 )
 
 // Test structs for comprehensive validation tag coverage.
@@ -203,3 +205,70 @@ func TestInterpolateMessage(t *testing.T) {
 		}
 	}
 }
+
+// TestErrorPointersAndParams tests JSON Pointers and rule params of errors.
+func TestErrorPointersAndParams(t *testing.T) {
+	v := New()
+
+	type Address struct {
+		ZipCode string `json:"zip_code" validate:"len=5"`
+	}
+	type Item struct {
+		SKU string `json:"sku" validate:"required"`
+	}
+	type Audit struct {
+		Source string `validate:"oneof=web api"`
+	}
+	type Order struct {
+		Audit
+		Password string   `json:"password,omitempty" validate:"min=8"`
+		Address  *Address `json:"address"`
+		Items    []Item   `json:"items" validate:"dive"`
+		Note     string   `validate:"contains=ok"`
+	}
+
+	err := v.Validate(&Order{
+		Audit:    Audit{Source: "fax"},
+		Password: "short",
+		Address:  &Address{ZipCode: "123"},
+		Items:    []Item{{SKU: "a"}, {}},
+		Note:     "no",
+	})
+	errs, ok := err.(fursy.ValidationErrors)
+	if !ok {
+		t.Fatalf("expected fursy.ValidationErrors, got %T", err)
+	}
+
+	want := map[string]struct {
+		pointer string
+		params  map[string]any
+	}{
+		"Source":   {"/Source", map[string]any{"values": []string{"web", "api"}}},
+		"Password": {"/password", map[string]any{"limit": float64(8)}},
+		"ZipCode":  {"/address/zip_code", map[string]any{"limit": float64(5)}},
+		"SKU":      {"/items/1/sku", nil},
+		"Note":     {"/Note", map[string]any{"param": "ok"}},
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors, want %d: %v", len(errs), len(want), errs)
+	}
+	for _, e := range errs {
+		w, ok := want[e.Field]
+		if !ok {
+			t.Errorf("unexpected error for %s", e.Field)
+			continue
+		}
+		if e.Pointer != w.pointer {
+			t.Errorf("%s: Pointer = %q, want %q", e.Field, e.Pointer, w.pointer)
+		}
+		if !reflect.DeepEqual(e.Params, w.params) {
+			t.Errorf("%s: Params = %v, want %v", e.Field, e.Params, w.params)
+		}
+	}
+
+	// Var has no struct to resolve pointers against.
+	err = v.Var("x", "min=3")
+	if errs, ok := err.(fursy.ValidationErrors); !ok || errs[0].Pointer != "" {
+		t.Errorf("Var: got %v, want error without pointer", err)
+	}
+}
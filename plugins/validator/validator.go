@@ -81,7 +81,7 @@ func (v *Validator) Validate(data any) error {
 	// Convert validator.ValidationErrors to fursy.ValidationErrors.
 	var validationErrs validator.ValidationErrors
 	if errors.As(err, &validationErrs) {
-		return v.convertErrors(validationErrs)
+		return v.convertErrors(validationErrs, reflect.TypeOf(data))
 	}
 
 	// Return other errors as-is (e.g., invalid type).
@@ -168,7 +168,7 @@ func (v *Validator) Var(field any, tag string) error {
 	// Convert validator.ValidationErrors to fursy.ValidationErrors.
 	var validationErrs validator.ValidationErrors
 	if errors.As(err, &validationErrs) {
-		return v.convertErrors(validationErrs)
+		return v.convertErrors(validationErrs, nil)
 	}
 
 	return err
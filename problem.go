@@ -186,12 +186,22 @@ func Conflict(detail string) Problem {
 	return NewProblem(409, "Conflict", detail)
 }
 
+// PreconditionFailed creates a 412 Precondition Failed problem.
+func PreconditionFailed(detail string) Problem {
+	return NewProblem(412, "Precondition Failed", detail)
+}
+
 // UnprocessableEntity creates a 422 Unprocessable Entity problem.
 // This is commonly used for validation errors.
 func UnprocessableEntity(detail string) Problem {
 	return NewProblem(422, "Unprocessable Entity", detail)
 }
 
+// PreconditionRequired creates a 428 Precondition Required problem (RFC 6585).
+func PreconditionRequired(detail string) Problem {
+	return NewProblem(428, "Precondition Required", detail)
+}
+
 // TooManyRequests creates a 429 Too Many Requests problem.
 func TooManyRequests(detail string) Problem {
 	return NewProblem(429, "Too Many Requests", detail)
@@ -210,7 +220,8 @@ func ServiceUnavailable(detail string) Problem {
 // ValidationProblem creates a 422 Unprocessable Entity problem from ValidationErrors.
 //
 // The validation errors are included as an extension field "errors" containing
-// a map of field names to error messages.
+// one Violation per error, with the JSON Pointer to the field, the rule code
+// and its params, so that clients can map errors to inputs programmatically.
 //
 // Example output:
 //
@@ -219,10 +230,12 @@ func ServiceUnavailable(detail string) Problem {
 //	  "title": "Validation Failed",
 //	  "status": 422,
 //	  "detail": "One or more fields failed validation",
-//	  "errors": {
-//	    "email": "must be a valid email address",
-//	    "age": "must be at least 18"
-//	  }
+//	  "errors": [
+//	    {"detail": "must be a valid email address", "pointer": "/email",
+//	     "field": "email", "code": "email"},
+//	    {"detail": "must be at least 18", "pointer": "/age",
+//	     "field": "age", "code": "min", "params": {"limit": 18}}
+//	  ]
 //	}
 func ValidationProblem(errs ValidationErrors) Problem {
 	if errs.IsEmpty() {
@@ -240,7 +253,7 @@ func ValidationProblem(errs ValidationErrors) Problem {
 		Status: 422,
 		Detail: detail,
 		Extensions: map[string]any{
-			"errors": errs.Fields(),
+			"errors": errs.Violations(),
 		},
 	}
 }
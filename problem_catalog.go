@@ -0,0 +1,191 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ProblemDefinition is an error type of the API, declared once with
+// DefineProblem and sent with Context.ProblemType.
+type ProblemDefinition struct {
+	// Code is the stable, machine-readable error code (e.g., "user-not-found").
+	// It is sent in the "code" extension member.
+	Code string `json:"code"`
+
+	// Status is the HTTP status code.
+	Status int `json:"status"`
+
+	// Title is the short, human-readable summary of the problem type.
+	Title string `json:"title"`
+
+	// Type is the problem type URI: the documentation URL given to
+	// DefineProblem, or "urn:problem-type:<code>".
+	Type string `json:"type"`
+}
+
+// New returns a Problem of this type with the given detail.
+func (d ProblemDefinition) New(detail string) Problem {
+	return Problem{
+		Type:       d.Type,
+		Title:      d.Title,
+		Status:     d.Status,
+		Detail:     detail,
+		Extensions: map[string]any{"code": d.Code},
+	}
+}
+
+// problemCatalog holds the problem types declared with DefineProblem.
+var problemCatalog = struct {
+	sync.RWMutex
+	defs map[string]ProblemDefinition
+}{defs: make(map[string]ProblemDefinition)}
+
+// DefineProblem declares an error type of the API, typically in a package
+// level var or init. The catalog of declared types is documented in the
+// OpenAPI components and can be served with Router.ServeProblemCatalog.
+//
+// docURL is the documentation URL of the problem type, used as the problem
+// type URI; if empty, "urn:problem-type:<code>" is used.
+//
+// Panics if code is empty or already defined, or status is not a 4xx or 5xx code.
+//
+// Example:
+//
+//	var ErrUserNotFound = fursy.DefineProblem("user-not-found", 404, "User not found",
+//	    "https://docs.example.com/errors/user-not-found")
+//
+//	router.GET("/users/:id", func(c *fursy.Context) error {
+//	    user, ok := users[c.Param("id")]
+//	    if !ok {
+//	        return c.ProblemType("user-not-found", "no user with ID "+c.Param("id"))
+//	    }
+//	    return c.JSON(200, user)
+//	})
+func DefineProblem(code string, status int, title, docURL string) ProblemDefinition {
+	if code == "" {
+		panic("fursy: problem code cannot be empty")
+	}
+	if status < 400 || status > 599 {
+		panic(fmt.Sprintf("fursy: problem %q: status %d is not an error status", code, status))
+	}
+
+	def := ProblemDefinition{Code: code, Status: status, Title: title, Type: docURL}
+	if def.Type == "" {
+		def.Type = "urn:problem-type:" + code
+	}
+
+	problemCatalog.Lock()
+	defer problemCatalog.Unlock()
+	if _, exists := problemCatalog.defs[code]; exists {
+		panic(fmt.Sprintf("fursy: problem %q is already defined", code))
+	}
+	problemCatalog.defs[code] = def
+	return def
+}
+
+// LookupProblem returns the problem type declared with code.
+func LookupProblem(code string) (ProblemDefinition, bool) {
+	problemCatalog.RLock()
+	defer problemCatalog.RUnlock()
+	def, ok := problemCatalog.defs[code]
+	return def, ok
+}
+
+// Problems returns the declared problem types, sorted by code.
+func Problems() []ProblemDefinition {
+	problemCatalog.RLock()
+	defs := make([]ProblemDefinition, 0, len(problemCatalog.defs))
+	for _, def := range problemCatalog.defs {
+		defs = append(defs, def)
+	}
+	problemCatalog.RUnlock()
+
+	slices.SortFunc(defs, func(a, b ProblemDefinition) int {
+		return strings.Compare(a.Code, b.Code)
+	})
+	return defs
+}
+
+// ProblemType sends the problem type declared with code (see DefineProblem)
+// with the given detail.
+//
+// An undeclared code is a programming error: it is logged and answered
+// with 500 Internal Server Error.
+//
+// Example:
+//
+//	return c.ProblemType("user-not-found", "no user with ID 42")
+func (c *Context) ProblemType(code, detail string) error {
+	def, ok := LookupProblem(code)
+	if !ok {
+		logger := slog.Default()
+		if c.router != nil {
+			logger = c.router.log()
+		}
+		logger.Error("fursy: undefined problem type", slog.String("code", code))
+		return c.Problem(InternalServerError("undefined problem type"))
+	}
+	return c.Problem(def.New(detail))
+}
+
+// ServeProblemCatalog registers routes documenting the declared problem
+// types as JSON: path lists all of them and path/:code describes one,
+// e.g. so that docURL can point to the API itself.
+//
+// Example:
+//
+//	router.ServeProblemCatalog("/errors")
+//
+//	// GET /errors                -> [{"code":"user-not-found","status":404,...}]
+//	// GET /errors/user-not-found -> {"code":"user-not-found","status":404,...}
+func (r *Router) ServeProblemCatalog(path string) {
+	r.GET(path, func(c *Context) error {
+		return c.JSON(http.StatusOK, Problems())
+	})
+	r.GET(strings.TrimSuffix(path, "/")+"/:code", func(c *Context) error {
+		def, ok := LookupProblem(c.Param("code"))
+		if !ok {
+			return c.Problem(NotFound("unknown problem type " + c.Param("code")))
+		}
+		return c.JSON(http.StatusOK, def)
+	})
+}
+
+// problemResponses documents the declared problem types as reusable
+// responses, named by code.
+func problemResponses(components *Components) {
+	for _, def := range Problems() {
+		if _, exists := components.Responses[def.Code]; exists {
+			continue
+		}
+		components.Responses[def.Code] = Response{
+			Description: def.Title,
+			Content: map[string]MediaType{
+				"application/problem+json": {
+					Schema: &Schema{
+						AllOf: []*Schema{
+							{Ref: "#/components/schemas/Problem"},
+							{
+								Type: "object",
+								Properties: map[string]*Schema{
+									"type":   {Type: "string", Enum: []any{def.Type}},
+									"status": {Type: "integer", Enum: []any{def.Status}},
+									"code":   {Type: "string", Enum: []any{def.Code}},
+								},
+							},
+						},
+					},
+					Example: def.New(""),
+				},
+			},
+		}
+	}
+}
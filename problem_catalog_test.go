@@ -0,0 +1,160 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Problem types of the tests, declared once as in applications.
+var (
+	testProblemNotFound = DefineProblem("test-user-not-found", http.StatusNotFound, "User not found",
+		"https://docs.example.com/errors/user-not-found")
+	testProblemQuota = DefineProblem("test-quota-exceeded", http.StatusTooManyRequests, "Quota exceeded", "")
+)
+
+// TestDefineProblem tests declaring problem types.
+func TestDefineProblem(t *testing.T) {
+	if testProblemNotFound.Type != "https://docs.example.com/errors/user-not-found" {
+		t.Errorf("Type = %q", testProblemNotFound.Type)
+	}
+	if testProblemQuota.Type != "urn:problem-type:test-quota-exceeded" {
+		t.Errorf("Type = %q, want URN", testProblemQuota.Type)
+	}
+
+	if def, ok := LookupProblem("test-quota-exceeded"); !ok || def != testProblemQuota {
+		t.Errorf("LookupProblem = %+v, %v", def, ok)
+	}
+	if _, ok := LookupProblem("test-undefined"); ok {
+		t.Error("LookupProblem found an undefined code")
+	}
+
+	var codes []string
+	for _, def := range Problems() {
+		if strings.HasPrefix(def.Code, "test-") {
+			codes = append(codes, def.Code)
+		}
+	}
+	if strings.Join(codes, ",") != "test-quota-exceeded,test-user-not-found" {
+		t.Errorf("Problems codes = %v, want sorted test codes", codes)
+	}
+
+	for name, define := range map[string]func(){
+		"empty code":   func() { DefineProblem("", 400, "Bad", "") },
+		"duplicate":    func() { DefineProblem("test-user-not-found", 404, "Again", "") },
+		"success":      func() { DefineProblem("test-ok", 200, "OK", "") },
+		"bad status":   func() { DefineProblem("test-bad", 99, "Bad", "") },
+		"status > 5xx": func() { DefineProblem("test-big", 600, "Bad", "") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			define()
+		})
+	}
+}
+
+// TestContext_ProblemType tests sending declared problem types.
+func TestContext_ProblemType(t *testing.T) {
+	r := New()
+	r.GET("/users/:id", func(c *Context) error {
+		return c.ProblemType("test-user-not-found", "no user with ID "+c.Param("id"))
+	})
+	r.GET("/undefined", func(c *Context) error {
+		return c.ProblemType("test-undefined", "oops")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/42", http.NoBody))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", w.Code)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"type":   "https://docs.example.com/errors/user-not-found",
+		"title":  "User not found",
+		"status": float64(404),
+		"detail": "no user with ID 42",
+		"code":   "test-user-not-found",
+	}
+	for k, v := range want {
+		if body[k] != v {
+			t.Errorf("%s = %v, want %v", k, body[k], v)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/undefined", http.NoBody))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("undefined code: status = %d, want 500", w.Code)
+	}
+}
+
+// TestRouter_ServeProblemCatalog tests the problem catalog endpoints.
+func TestRouter_ServeProblemCatalog(t *testing.T) {
+	r := New()
+	r.ServeProblemCatalog("/errors")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/errors", http.NoBody))
+	var defs []ProblemDefinition
+	if err := json.Unmarshal(w.Body.Bytes(), &defs); err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, def := range defs {
+		found = found || def == testProblemNotFound
+	}
+	if !found {
+		t.Errorf("catalog %s does not list test-user-not-found", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/errors/test-quota-exceeded", http.NoBody))
+	var def ProblemDefinition
+	if err := json.Unmarshal(w.Body.Bytes(), &def); err != nil || def != testProblemQuota {
+		t.Errorf("GET /errors/test-quota-exceeded = %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/errors/test-undefined", http.NoBody))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unknown code: status = %d, want 404", w.Code)
+	}
+}
+
+// TestOpenAPI_ProblemCatalog tests the problem types in OpenAPI components.
+func TestOpenAPI_ProblemCatalog(t *testing.T) {
+	r := New()
+	doc, err := r.GenerateOpenAPI(Info{Title: "Test", Version: "1.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, ok := doc.Components.Responses["test-user-not-found"]
+	if !ok {
+		t.Fatal("missing components.responses[test-user-not-found]")
+	}
+	if resp.Description != "User not found" {
+		t.Errorf("Description = %q", resp.Description)
+	}
+	schema := resp.Content["application/problem+json"].Schema
+	if len(schema.AllOf) != 2 || schema.AllOf[0].Ref != "#/components/schemas/Problem" {
+		t.Fatalf("schema = %+v, want allOf Problem", schema)
+	}
+	if enum := schema.AllOf[1].Properties["status"].Enum; len(enum) != 1 || enum[0] != http.StatusNotFound {
+		t.Errorf("status enum = %v, want [404]", enum)
+	}
+}
@@ -293,19 +293,23 @@ func TestValidationProblem(t *testing.T) {
 	}
 
 	// Check extensions contain errors field.
-	errorsField, ok := p.Extensions["errors"].(map[string]string)
+	errorsField, ok := p.Extensions["errors"].([]Violation)
 	if !ok {
-		t.Fatalf("Extensions[errors] should be map[string]string, got %T", p.Extensions["errors"])
+		t.Fatalf("Extensions[errors] should be []Violation, got %T", p.Extensions["errors"])
 	}
 
-	if len(errorsField) != 2 {
-		t.Errorf("errors length = %d, want 2", len(errorsField))
+	want := []Violation{
+		{Detail: "email is required", Pointer: "/email", Field: "email", Code: "required"},
+		{Detail: "age must be at least 18", Pointer: "/age", Field: "age", Code: "min"},
 	}
-	if errorsField["email"] != "email is required" {
-		t.Errorf("errors[email] = %q, want %q", errorsField["email"], "email is required")
+	if len(errorsField) != len(want) {
+		t.Fatalf("errors length = %d, want %d", len(errorsField), len(want))
 	}
-	if errorsField["age"] != "age must be at least 18" {
-		t.Errorf("errors[age] = %q, want %q", errorsField["age"], "age must be at least 18")
+	for i := range want {
+		if errorsField[i].Detail != want[i].Detail || errorsField[i].Pointer != want[i].Pointer ||
+			errorsField[i].Field != want[i].Field || errorsField[i].Code != want[i].Code {
+			t.Errorf("errors[%d] = %+v, want %+v", i, errorsField[i], want[i])
+		}
 	}
 }
 
@@ -432,16 +436,18 @@ func TestContext_Problem_ValidationErrors(t *testing.T) {
 	}
 
 	// Check errors field.
-	errorsField, ok := result["errors"].(map[string]any)
-	if !ok {
-		t.Fatalf("errors should be map[string]any, got %T", result["errors"])
+	errorsField, ok := result["errors"].([]any)
+	if !ok || len(errorsField) != 2 {
+		t.Fatalf("errors should be an array of 2 entries, got %v", result["errors"])
 	}
 
-	if errorsField["email"] != "must be a valid email" {
-		t.Errorf("errors[email] = %v, want must be a valid email", errorsField["email"])
+	email, _ := errorsField[0].(map[string]any)
+	if email["detail"] != "must be a valid email" || email["pointer"] != "/email" || email["code"] != "email" {
+		t.Errorf("errors[0] = %v, want email entry", email)
 	}
-	if errorsField["age"] != "must be at least 18" {
-		t.Errorf("errors[age] = %v, want must be at least 18", errorsField["age"])
+	age, _ := errorsField[1].(map[string]any)
+	if age["detail"] != "must be at least 18" || age["pointer"] != "/age" || age["code"] != "min" {
+		t.Errorf("errors[1] = %v, want age entry", age)
 	}
 }
 
@@ -0,0 +1,102 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package proxy
+
+import (
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Backend is a backend instance of a proxy.
+type Backend struct {
+	// URL is the base URL of the instance.
+	URL *url.URL
+
+	active atomic.Int64
+
+	mu             sync.Mutex
+	failures       int
+	unhealthyUntil time.Time
+}
+
+// ActiveRequests returns the number of requests in progress on the backend.
+func (b *Backend) ActiveRequests() int64 {
+	return b.active.Load()
+}
+
+// Healthy reports whether the backend is not marked unhealthy.
+func (b *Backend) Healthy() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return !time.Now().Before(b.unhealthyUntil)
+}
+
+// fail records a failed request, marking the backend unhealthy for
+// timeout after maxFails consecutive failures.
+func (b *Backend) fail(maxFails int, timeout time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.failures >= maxFails {
+		b.failures = 0
+		b.unhealthyUntil = time.Now().Add(timeout)
+	}
+}
+
+// succeed records a successful request.
+func (b *Backend) succeed() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+}
+
+// Balancer picks the backend of a request.
+//
+// Pick is called with at least one backend and must be safe for concurrent use.
+type Balancer interface {
+	Pick(backends []*Backend) *Backend
+}
+
+// RoundRobin returns a balancer cycling through the backends.
+func RoundRobin() Balancer {
+	return &roundRobin{}
+}
+
+// roundRobin picks backends in turn.
+type roundRobin struct {
+	next atomic.Uint64
+}
+
+// Pick returns the next backend.
+func (rr *roundRobin) Pick(backends []*Backend) *Backend {
+	n := rr.next.Add(1) - 1
+	return backends[n%uint64(len(backends))]
+}
+
+// LeastConnections returns a balancer picking the backend with the fewest
+// requests in progress. Ties are broken in turn.
+func LeastConnections() Balancer {
+	return &leastConnections{}
+}
+
+// leastConnections picks the least loaded backend.
+type leastConnections struct {
+	next atomic.Uint64
+}
+
+// Pick returns the backend with the fewest active requests.
+func (lc *leastConnections) Pick(backends []*Backend) *Backend {
+	start := int(lc.next.Add(1) % uint64(len(backends)))
+	best := backends[start]
+	for i := 1; i < len(backends); i++ {
+		b := backends[(start+i)%len(backends)]
+		if b.ActiveRequests() < best.ActiveRequests() {
+			best = b
+		}
+	}
+	return best
+}
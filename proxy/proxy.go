@@ -0,0 +1,285 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package proxy provides a load-balancing reverse proxy handler.
+//
+// The proxy discovers backend instances through a Resolver (static list,
+// DNS SRV, or a service registry such as Consul or Kubernetes endpoints),
+// balances requests across healthy instances (round-robin or least
+// connections) and takes failing instances out of rotation, so fursy can
+// front multiple backend instances without an external load balancer.
+//
+// Example:
+//
+//	users := proxy.New(proxy.Config{
+//	    Resolver: proxy.DNSSRV(proxy.DNSSRVConfig{Service: "http", Proto: "tcp", Name: "users.internal"}),
+//	    Balancer: proxy.LeastConnections(),
+//	})
+//	router.Any("/users/*path", users.Handler())
+package proxy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// Default values for the Proxy.
+const (
+	// DefaultRefreshInterval is the default interval between resolver lookups.
+	DefaultRefreshInterval = 10 * time.Second
+
+	// DefaultMaxFails is the default number of consecutive failures marking
+	// a backend unhealthy.
+	DefaultMaxFails = 3
+
+	// DefaultFailTimeout is the default time a backend stays unhealthy.
+	DefaultFailTimeout = 10 * time.Second
+)
+
+// ErrNoBackends is returned by the resolver lookup when no backend is available.
+var ErrNoBackends = errors.New("proxy: no backends available")
+
+// Config defines the config for the Proxy.
+type Config struct {
+	// Resolver discovers the backend instances.
+	// Required.
+	Resolver Resolver
+
+	// Balancer picks the backend of each request among healthy backends.
+	// Default: RoundRobin()
+	Balancer Balancer
+
+	// RefreshInterval is the interval between resolver lookups.
+	// Lookups run in the background; failed lookups keep the current backends.
+	// Default: 10s
+	RefreshInterval time.Duration
+
+	// MaxFails is the number of consecutive failures (connection errors and
+	// 502, 503 and 504 responses) marking a backend unhealthy.
+	// Default: 3
+	MaxFails int
+
+	// FailTimeout is the time a backend stays unhealthy. When all backends
+	// are unhealthy, requests are balanced across all of them.
+	// Default: 10s
+	FailTimeout time.Duration
+
+	// StripPrefix is removed from the request path before proxying.
+	// The remaining path is appended to the backend URL path.
+	StripPrefix string
+
+	// Transport performs the proxied requests.
+	// Default: http.DefaultTransport
+	Transport http.RoundTripper
+}
+
+// Proxy is a load-balancing reverse proxy.
+//
+// Proxy is safe for concurrent use.
+type Proxy struct {
+	config  Config
+	reverse *httputil.ReverseProxy
+
+	mu         sync.RWMutex
+	backends   []*Backend
+	resolvedAt time.Time
+	refreshing atomic.Bool
+}
+
+// New creates a Proxy.
+//
+// Example:
+//
+//	api := proxy.New(proxy.Config{
+//	    Resolver:    proxy.Static("http://10.0.0.1:8080", "http://10.0.0.2:8080"),
+//	    StripPrefix: "/api",
+//	})
+//	router.Any("/api/*path", api.Handler())
+func New(config Config) *Proxy {
+	// Validate config.
+	if config.Resolver == nil {
+		panic("proxy: resolver is required")
+	}
+
+	// Set defaults.
+	if config.Balancer == nil {
+		config.Balancer = RoundRobin()
+	}
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = DefaultRefreshInterval
+	}
+	if config.MaxFails <= 0 {
+		config.MaxFails = DefaultMaxFails
+	}
+	if config.FailTimeout <= 0 {
+		config.FailTimeout = DefaultFailTimeout
+	}
+	if config.Transport == nil {
+		config.Transport = http.DefaultTransport
+	}
+
+	p := &Proxy{config: config}
+	p.reverse = &httputil.ReverseProxy{
+		Rewrite:        p.rewrite,
+		Transport:      config.Transport,
+		ModifyResponse: p.modifyResponse,
+		ErrorHandler:   p.errorHandler,
+	}
+	return p
+}
+
+// Handler returns the handler proxying requests to the backends.
+func (p *Proxy) Handler() fursy.HandlerFunc {
+	return func(c *fursy.Context) error {
+		backends, err := p.resolve(c.Request.Context())
+		if err != nil {
+			return c.Problem(fursy.ServiceUnavailable("No backend available"))
+		}
+		b := p.config.Balancer.Pick(healthy(backends))
+
+		b.active.Add(1)
+		defer b.active.Add(-1)
+
+		st := &requestState{backend: b, c: c}
+		req := c.Request.WithContext(context.WithValue(c.Request.Context(), stateKey{}, st))
+		p.reverse.ServeHTTP(c.Response, req)
+		return nil
+	}
+}
+
+// Backends returns the current backends (resolving them if needed).
+func (p *Proxy) Backends(ctx context.Context) ([]*Backend, error) {
+	return p.resolve(ctx)
+}
+
+// stateKey is the request context key of the proxied request state.
+type stateKey struct{}
+
+// requestState is the state of a proxied request.
+type requestState struct {
+	backend *Backend
+	c       *fursy.Context
+}
+
+// rewrite routes the outbound request to the picked backend.
+func (p *Proxy) rewrite(pr *httputil.ProxyRequest) {
+	st := pr.In.Context().Value(stateKey{}).(*requestState)
+	if p.config.StripPrefix != "" {
+		pr.Out.URL.Path = ensureSlash(strings.TrimPrefix(pr.Out.URL.Path, p.config.StripPrefix))
+		pr.Out.URL.RawPath = ""
+	}
+	pr.SetURL(st.backend.URL)
+	pr.SetXForwarded()
+}
+
+// modifyResponse records the backend health from the response status.
+func (p *Proxy) modifyResponse(resp *http.Response) error {
+	st := resp.Request.Context().Value(stateKey{}).(*requestState)
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		st.backend.fail(p.config.MaxFails, p.config.FailTimeout)
+	default:
+		st.backend.succeed()
+	}
+	return nil
+}
+
+// errorHandler records the failure and answers 502 Bad Gateway.
+func (p *Proxy) errorHandler(_ http.ResponseWriter, r *http.Request, err error) {
+	st := r.Context().Value(stateKey{}).(*requestState)
+	if errors.Is(err, context.Canceled) {
+		// The client went away: not a backend failure.
+		st.c.Response.WriteHeader(http.StatusBadGateway)
+		return
+	}
+	st.backend.fail(p.config.MaxFails, p.config.FailTimeout)
+	_ = st.c.Problem(fursy.NewProblem(http.StatusBadGateway, "Bad Gateway", "Upstream request failed"))
+}
+
+// resolve returns the backends, refreshing them in the background when stale.
+func (p *Proxy) resolve(ctx context.Context) ([]*Backend, error) {
+	p.mu.RLock()
+	backends, resolvedAt := p.backends, p.resolvedAt
+	p.mu.RUnlock()
+
+	if backends == nil {
+		if err := p.refresh(ctx); err != nil {
+			return nil, err
+		}
+		p.mu.RLock()
+		backends = p.backends
+		p.mu.RUnlock()
+		return backends, nil
+	}
+
+	if time.Since(resolvedAt) > p.config.RefreshInterval && p.refreshing.CompareAndSwap(false, true) {
+		go func() {
+			defer p.refreshing.Store(false)
+			ctx, cancel := context.WithTimeout(context.Background(), p.config.RefreshInterval)
+			defer cancel()
+			_ = p.refresh(ctx)
+		}()
+	}
+	return backends, nil
+}
+
+// refresh looks up the backends, keeping the state of known backends.
+func (p *Proxy) refresh(ctx context.Context) error {
+	urls, err := p.config.Resolver.Resolve(ctx)
+	if err != nil {
+		return err
+	}
+	if len(urls) == 0 {
+		return ErrNoBackends
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	known := make(map[string]*Backend, len(p.backends))
+	for _, b := range p.backends {
+		known[b.URL.String()] = b
+	}
+	backends := make([]*Backend, 0, len(urls))
+	for _, u := range urls {
+		b, ok := known[u.String()]
+		if !ok {
+			b = &Backend{URL: u}
+		}
+		backends = append(backends, b)
+	}
+	p.backends = backends
+	p.resolvedAt = time.Now()
+	return nil
+}
+
+// healthy returns the healthy backends, or all backends if none is healthy.
+func healthy(backends []*Backend) []*Backend {
+	var up []*Backend
+	for _, b := range backends {
+		if b.Healthy() {
+			up = append(up, b)
+		}
+	}
+	if len(up) == 0 {
+		return backends
+	}
+	return up
+}
+
+// ensureSlash returns path with a leading slash.
+func ensureSlash(path string) string {
+	if !strings.HasPrefix(path, "/") {
+		return "/" + path
+	}
+	return path
+}
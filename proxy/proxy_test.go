@@ -0,0 +1,203 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package proxy_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy"
+	"github.com/coregx/fursy/proxy"
+)
+
+// newBackend starts a backend answering with its name and the request path.
+func newBackend(t *testing.T, name string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s %s %s", name, r.URL.Path, r.Header.Get("X-Forwarded-Host"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func serve(router *fursy.Router, path string) (int, string) {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+	return w.Code, w.Body.String()
+}
+
+func TestProxy_RoundRobin(t *testing.T) {
+	a, b := newBackend(t, "a"), newBackend(t, "b")
+	p := proxy.New(proxy.Config{
+		Resolver:    proxy.Static(a.URL, b.URL+"/v1"),
+		StripPrefix: "/api",
+	})
+	router := fursy.New()
+	router.Any("/api/*path", p.Handler())
+
+	var got []string
+	for range 4 {
+		status, body := serve(router, "/api/users")
+		if status != http.StatusOK {
+			t.Fatalf("status = %d", status)
+		}
+		got = append(got, body)
+	}
+	want := []string{"a /users example.com", "b /v1/users example.com", "a /users example.com", "b /v1/users example.com"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("responses = %q, want %q", got, want)
+	}
+}
+
+func TestProxy_UnhealthyBackend(t *testing.T) {
+	good := newBackend(t, "good")
+	bad := httptest.NewServer(http.NotFoundHandler())
+	badURL := bad.URL
+	bad.Close() // Connections are refused.
+
+	p := proxy.New(proxy.Config{
+		Resolver:    proxy.Static(badURL, good.URL),
+		MaxFails:    1,
+		FailTimeout: time.Minute,
+	})
+	router := fursy.New()
+	router.GET("/*path", p.Handler())
+
+	status, body := serve(router, "/x")
+	if status != http.StatusBadGateway || !strings.Contains(body, "Bad Gateway") {
+		t.Errorf("first request: %d %q, want 502 problem", status, body)
+	}
+	for range 3 {
+		if status, body := serve(router, "/x"); status != http.StatusOK || !strings.HasPrefix(body, "good") {
+			t.Errorf("after failure: %d %q, want good backend", status, body)
+		}
+	}
+
+	backends, _ := p.Backends(context.Background())
+	if backends[0].Healthy() || !backends[1].Healthy() {
+		t.Error("bad backend not marked unhealthy")
+	}
+}
+
+func TestProxy_NoBackends(t *testing.T) {
+	p := proxy.New(proxy.Config{
+		Resolver: proxy.ResolverFunc(func(context.Context) ([]*url.URL, error) {
+			return nil, errors.New("registry down")
+		}),
+	})
+	router := fursy.New()
+	router.GET("/*path", p.Handler())
+
+	if status, _ := serve(router, "/x"); status != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", status)
+	}
+}
+
+func TestProxy_Refresh(t *testing.T) {
+	a, b := newBackend(t, "a"), newBackend(t, "b")
+	var current atomic.Value
+	current.Store(a.URL)
+	resolver := proxy.ResolverFunc(func(context.Context) ([]*url.URL, error) {
+		u, _ := url.Parse(current.Load().(string))
+		return []*url.URL{u}, nil
+	})
+
+	p := proxy.New(proxy.Config{Resolver: resolver, RefreshInterval: 10 * time.Millisecond})
+	router := fursy.New()
+	router.GET("/*path", p.Handler())
+
+	if _, body := serve(router, "/x"); !strings.HasPrefix(body, "a") {
+		t.Fatalf("body = %q", body)
+	}
+	current.Store(b.URL)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		time.Sleep(15 * time.Millisecond)
+		if _, body := serve(router, "/x"); strings.HasPrefix(body, "b") {
+			return
+		}
+	}
+	t.Error("backends not refreshed")
+}
+
+func TestLeastConnections(t *testing.T) {
+	u1, _ := url.Parse("http://one")
+	u2, _ := url.Parse("http://two")
+	backends := []*proxy.Backend{{URL: u1}, {URL: u2}}
+
+	release := make(chan struct{})
+	busy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-release
+	}))
+	defer busy.Close()
+	idle := newBackend(t, "idle")
+
+	p := proxy.New(proxy.Config{Resolver: proxy.Static(busy.URL, idle.URL), Balancer: proxy.LeastConnections()})
+	router := fursy.New()
+	router.GET("/*path", p.Handler())
+
+	// Send requests until one is held by the busy backend.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if _, body := serve(router, "/x"); body == "" {
+				return // Held request released with an empty body.
+			}
+		}
+	}()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		bs, _ := p.Backends(context.Background())
+		if bs[0].ActiveRequests() == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("busy backend never received a request")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	for range 5 {
+		if _, body := serve(router, "/x"); !strings.HasPrefix(body, "idle") {
+			t.Errorf("body = %q, want idle backend", body)
+		}
+	}
+	close(release)
+	<-done
+
+	// Ties are broken in turn.
+	lc := proxy.LeastConnections()
+	if lc.Pick(backends) == lc.Pick(backends) {
+		t.Error("ties not rotated")
+	}
+}
+
+func TestStatic_InvalidURL(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	proxy.Static("localhost:8080")
+}
+
+func TestDNSSRV_RequiresName(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	proxy.DNSSRV(proxy.DNSSRVConfig{})
+}
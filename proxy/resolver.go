@@ -0,0 +1,119 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Resolver discovers the backend instances of a service.
+//
+// Service registries (Consul, Kubernetes endpoints, ...) are integrated by
+// implementing Resolve with their client, e.g. with ResolverFunc:
+//
+//	consul := proxy.ResolverFunc(func(ctx context.Context) ([]*url.URL, error) {
+//	    entries, _, err := consulClient.Health().Service("users", "", true, nil)
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    urls := make([]*url.URL, 0, len(entries))
+//	    for _, e := range entries {
+//	        urls = append(urls, &url.URL{Scheme: "http", Host: net.JoinHostPort(e.Service.Address, strconv.Itoa(e.Service.Port))})
+//	    }
+//	    return urls, nil
+//	})
+type Resolver interface {
+	Resolve(ctx context.Context) ([]*url.URL, error)
+}
+
+// ResolverFunc adapts a function to the Resolver interface.
+type ResolverFunc func(ctx context.Context) ([]*url.URL, error)
+
+// Resolve calls f(ctx).
+func (f ResolverFunc) Resolve(ctx context.Context) ([]*url.URL, error) {
+	return f(ctx)
+}
+
+// Static returns a resolver for a fixed list of backend URLs.
+//
+// Panics if a URL is invalid.
+//
+// Example:
+//
+//	proxy.Static("http://10.0.0.1:8080", "http://10.0.0.2:8080")
+func Static(targets ...string) Resolver {
+	urls := make([]*url.URL, 0, len(targets))
+	for _, target := range targets {
+		u, err := url.Parse(target)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			panic("proxy: invalid backend URL: " + target)
+		}
+		urls = append(urls, u)
+	}
+	return ResolverFunc(func(context.Context) ([]*url.URL, error) {
+		return urls, nil
+	})
+}
+
+// DNSSRVConfig defines the config for the DNS SRV resolver.
+type DNSSRVConfig struct {
+	// Service, Proto and Name form the SRV query _service._proto.name
+	// (e.g., "http", "tcp", "users.example.internal").
+	// Leave Service and Proto empty to query Name directly.
+	// Name is required.
+	Service string
+	Proto   string
+	Name    string
+
+	// Scheme is the scheme of the backend URLs.
+	// Default: "http"
+	Scheme string
+
+	// Resolver performs the DNS lookups.
+	// Default: net.DefaultResolver
+	Resolver *net.Resolver
+}
+
+// DNSSRV returns a resolver looking up backends in DNS SRV records
+// (e.g., Kubernetes headless services or Consul DNS).
+//
+// Example:
+//
+//	proxy.DNSSRV(proxy.DNSSRVConfig{Service: "http", Proto: "tcp", Name: "users.default.svc.cluster.local"})
+func DNSSRV(config DNSSRVConfig) Resolver {
+	// Validate config.
+	if config.Name == "" {
+		panic("proxy: DNS SRV name is required")
+	}
+
+	// Set defaults.
+	if config.Scheme == "" {
+		config.Scheme = "http"
+	}
+	if config.Resolver == nil {
+		config.Resolver = net.DefaultResolver
+	}
+
+	return ResolverFunc(func(ctx context.Context) ([]*url.URL, error) {
+		_, records, err := config.Resolver.LookupSRV(ctx, config.Service, config.Proto, config.Name)
+		if err != nil {
+			return nil, fmt.Errorf("proxy: lookup SRV %s: %w", config.Name, err)
+		}
+		urls := make([]*url.URL, 0, len(records))
+		for _, srv := range records {
+			host := strings.TrimSuffix(srv.Target, ".")
+			urls = append(urls, &url.URL{
+				Scheme: config.Scheme,
+				Host:   net.JoinHostPort(host, strconv.Itoa(int(srv.Port))),
+			})
+		}
+		return urls, nil
+	})
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// CostEstimator estimates the cost of a request in abstract units, such
+// as the number of resources it loads.
+type CostEstimator func(c *Context) int
+
+// CostPolicy rejects requests whose estimated cost exceeds MaxCost with
+// 422 Unprocessable Entity, before the handler runs, so abusive clients
+// cannot build expensive calls from cheap parameters (large pages with
+// many included relations).
+//
+// It is set via RouteOptions.Cost and enforced by the router.
+//
+// Example:
+//
+//	// GET /orders?limit=100&include=items,customer costs 300.
+//	router.HandleWithOptions(http.MethodGet, "/orders", listOrders, &fursy.RouteOptions{
+//	    Cost: &fursy.CostPolicy{MaxCost: 500},
+//	})
+type CostPolicy struct {
+	// MaxCost is the maximum cost of a request (0 = unlimited).
+	MaxCost int
+
+	// Estimate estimates the cost of a request.
+	// Default: ListCost
+	Estimate CostEstimator
+}
+
+// ListCost estimates the cost of a list request as the page size (the
+// limit query parameter, default 20) multiplied by 1 plus the number of
+// relations in the include query parameter (e.g., include=items,customer).
+func ListCost(c *Context) int {
+	return queryCost(c.Request.URL.Query(), defaultPageLimit)
+}
+
+// queryCost returns limit × (1 + includes) for the query q. defaultLimit
+// is the page size when the limit parameter is absent or invalid.
+func queryCost(q url.Values, defaultLimit int) int {
+	limit, err := strconv.Atoi(q.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultLimit
+	}
+
+	relations := 0
+	for _, include := range q["include"] {
+		for rel := range strings.SplitSeq(include, ",") {
+			if strings.TrimSpace(rel) != "" {
+				relations++
+			}
+		}
+	}
+	return limit * (1 + relations)
+}
+
+// costPolicy rejects requests whose estimated cost exceeds p.MaxCost.
+func costPolicy(next HandlerFunc, p *CostPolicy) HandlerFunc {
+	estimate := p.Estimate
+	if estimate == nil {
+		estimate = ListCost
+	}
+
+	return func(c *Context) error {
+		if cost := estimate(c); cost > p.MaxCost {
+			return c.Problem(costProblem(cost, p.MaxCost))
+		}
+		return next(c)
+	}
+}
+
+// costProblem returns the 422 problem of a request exceeding maxCost.
+func costProblem(cost, maxCost int) Problem {
+	return UnprocessableEntity(fmt.Sprintf("request cost %d exceeds the maximum of %d", cost, maxCost)).
+		WithExtension("cost", cost).
+		WithExtension("max_cost", maxCost)
+}
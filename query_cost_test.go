@@ -0,0 +1,58 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestCostPolicy tests rejecting list requests exceeding the cost limit.
+func TestCostPolicy(t *testing.T) {
+	r := New()
+	r.HandleWithOptions(http.MethodGet, "/orders", func(c *Context) error {
+		return c.OK([]string{})
+	}, &RouteOptions{Cost: &CostPolicy{MaxCost: 100}})
+	r.HandleWithOptions(http.MethodGet, "/reports", func(c *Context) error {
+		return c.OK([]string{})
+	}, &RouteOptions{Cost: &CostPolicy{MaxCost: 10, Estimate: func(c *Context) int {
+		return len(c.Request.URL.Query()["metric"]) * 5
+	}}})
+
+	tests := []struct {
+		path     string
+		wantCode int
+	}{
+		{"/orders", http.StatusOK},
+		{"/orders?limit=50&include=items", http.StatusOK},
+		{"/orders?limit=50&include=items,customer", http.StatusUnprocessableEntity},
+		{"/orders?include=a&include=b,c,d,e", http.StatusUnprocessableEntity},
+		{"/reports?metric=a&metric=b", http.StatusOK},
+		{"/reports?metric=a&metric=b&metric=c", http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+		if w.Code != tt.wantCode {
+			t.Errorf("%s: status = %d, want %d", tt.path, w.Code, tt.wantCode)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders?limit=100&include=items", http.NoBody))
+	var p map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p["cost"] != float64(200) || p["max_cost"] != float64(100) {
+		t.Errorf("problem = %v, want cost and max_cost extensions", p)
+	}
+
+	if r.Routes()[0].Cost == nil {
+		t.Error("RouteInfo.Cost should be set")
+	}
+}
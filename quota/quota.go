@@ -0,0 +1,359 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package quota provides long-window usage quotas per API key or tenant.
+//
+// Unlike rate limits, which smooth traffic over seconds, quotas cap the
+// usage of a key over a day or a month (e.g., 10,000 requests per day on
+// the free plan). The Manager counts requests in a shared Store, warns
+// clients approaching their quota, rejects requests over it, and reports
+// quota events to application code and webhook subscribers.
+//
+// Example:
+//
+//	quotas := quota.New(quota.Config{
+//	    KeyFunc: func(c *fursy.Context) string { return c.Request.Header.Get("X-API-Key") },
+//	    Limit:   10000,
+//	    Period:  quota.Daily,
+//	    Store:   redisStore, // any quota.Store shared by the replicas
+//	})
+//	api := router.Group("/api", quotas.Middleware())
+//	api.GET("/usage", quotas.UsageHandler())
+package quota
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/coregx/fursy"
+	"github.com/coregx/fursy/webhooks"
+)
+
+// DefaultSoftLimit is the default fraction of the quota above which
+// responses carry a warning.
+const DefaultSoftLimit = 0.8
+
+// Period is the window of a quota.
+type Period int
+
+const (
+	// Daily quotas reset at midnight (in Config.Location).
+	Daily Period = iota
+
+	// Monthly quotas reset on the first day of the month at midnight.
+	Monthly
+)
+
+// String returns the name of the period ("day" or "month").
+func (p Period) String() string {
+	if p == Monthly {
+		return "month"
+	}
+	return "day"
+}
+
+// window returns the start of the window containing t and the time it resets.
+func (p Period) window(t time.Time) (start, reset time.Time) {
+	year, month, day := t.Date()
+	if p == Monthly {
+		start = time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+		return start, start.AddDate(0, 1, 0)
+	}
+	start = time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	return start, start.AddDate(0, 0, 1)
+}
+
+// EventType is the type of a quota event.
+type EventType string
+
+// Quota events. They are also the event names of webhook deliveries.
+const (
+	// EventWarning is reported when a key crosses the soft limit.
+	EventWarning EventType = "quota.warning"
+
+	// EventExceeded is reported when a key exceeds its quota.
+	EventExceeded EventType = "quota.exceeded"
+)
+
+// Event is a quota event. Each event is reported once per key and window.
+type Event struct {
+	// Type is the event type.
+	Type EventType `json:"type"`
+
+	// Usage is the usage of the key when the event occurred.
+	Usage Usage `json:"usage"`
+}
+
+// Usage is the usage of a key in the current window.
+type Usage struct {
+	// Key is the API key or tenant.
+	Key string `json:"key"`
+
+	// Period is the quota period ("day" or "month").
+	Period string `json:"period"`
+
+	// Limit is the quota of the key (0 if the key has no quota).
+	Limit int64 `json:"limit"`
+
+	// Used is the usage counted in the window, including rejected requests.
+	Used int64 `json:"used"`
+
+	// Remaining is the usage left in the window.
+	Remaining int64 `json:"remaining"`
+
+	// Reset is the time the window resets.
+	Reset time.Time `json:"reset"`
+}
+
+// Config defines the configuration of a Manager.
+type Config struct {
+	// KeyFunc extracts the API key or tenant from the request.
+	// Requests with an empty key are not counted.
+	// Required.
+	KeyFunc func(c *fursy.Context) string
+
+	// Limit is the quota of every key per period.
+	// Required unless LimitFunc is set.
+	Limit int64
+
+	// LimitFunc returns the quota of a key, e.g. from the plan of the
+	// tenant. A quota of 0 or less means the key has no quota.
+	// Default: nil (Limit for every key)
+	LimitFunc func(key string) int64
+
+	// Period is the quota window.
+	// Default: Daily
+	Period Period
+
+	// Location is the time zone in which windows start.
+	// Default: time.UTC
+	Location *time.Location
+
+	// Store stores the usage counters.
+	// Default: NewMemoryStore() (not shared between replicas)
+	Store Store
+
+	// Prefix is prepended to the store keys.
+	// Default: "quota:"
+	Prefix string
+
+	// CostFunc returns the usage a request consumes.
+	// Default: nil (every request costs 1)
+	CostFunc func(c *fursy.Context) int64
+
+	// SoftLimit is the fraction of the quota above which responses carry
+	// an X-Quota-Warning header and EventWarning is reported.
+	// Default: 0.8
+	SoftLimit float64
+
+	// OnEvent is called synchronously for every quota event.
+	// Default: nil
+	OnEvent func(event Event)
+
+	// Webhooks delivers the quota events to the subscribed endpoints,
+	// with the Event as payload.
+	// Default: nil (no webhooks)
+	Webhooks *webhooks.Dispatcher
+
+	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
+	// Default: nil (middleware always executes)
+	Skipper fursy.Skipper
+
+	// ErrorHandler is called when a request exceeds the quota.
+	// Default: 429 Too Many Requests problem with Retry-After
+	ErrorHandler func(c *fursy.Context, usage Usage) error
+}
+
+// Manager enforces and reports quotas.
+//
+// Manager is safe for concurrent use.
+type Manager struct {
+	config Config
+}
+
+// New creates a Manager.
+//
+// Example:
+//
+//	quotas := quota.New(quota.Config{
+//	    KeyFunc:   func(c *fursy.Context) string { return c.GetString("tenant") },
+//	    LimitFunc: func(tenant string) int64 { return plans.MonthlyRequests(tenant) },
+//	    Period:    quota.Monthly,
+//	    Webhooks:  hooks, // delivers "quota.warning" and "quota.exceeded"
+//	})
+func New(config Config) *Manager {
+	// Validate config.
+	if config.KeyFunc == nil {
+		panic("quota: key function is required")
+	}
+	if config.Limit <= 0 && config.LimitFunc == nil {
+		panic("quota: limit or limit function is required")
+	}
+	if config.SoftLimit < 0 || config.SoftLimit > 1 {
+		panic("quota: soft limit must be between 0 and 1")
+	}
+
+	// Set defaults.
+	if config.LimitFunc == nil {
+		limit := config.Limit
+		config.LimitFunc = func(string) int64 { return limit }
+	}
+	if config.Location == nil {
+		config.Location = time.UTC
+	}
+	if config.Store == nil {
+		config.Store = NewMemoryStore()
+	}
+	if config.Prefix == "" {
+		config.Prefix = "quota:"
+	}
+	if config.SoftLimit == 0 {
+		config.SoftLimit = DefaultSoftLimit
+	}
+	if config.ErrorHandler == nil {
+		config.ErrorHandler = defaultErrorHandler
+	}
+
+	return &Manager{config: config}
+}
+
+// Middleware returns the middleware counting requests against the quota
+// of their key.
+//
+// Responses carry X-Quota-Limit, X-Quota-Remaining and X-Quota-Reset
+// headers, plus X-Quota-Warning above the soft limit. Requests over the
+// quota are rejected by the ErrorHandler. Store errors are returned to
+// the router's error handler.
+func (m *Manager) Middleware() fursy.HandlerFunc {
+	return func(c *fursy.Context) error {
+		// Skip if Skipper returns true.
+		if m.config.Skipper.ShouldSkip(c) {
+			return c.Next()
+		}
+
+		key := m.config.KeyFunc(c)
+		if key == "" {
+			return c.Next()
+		}
+		limit := m.config.LimitFunc(key)
+		if limit <= 0 {
+			return c.Next()
+		}
+
+		cost := int64(1)
+		if m.config.CostFunc != nil {
+			cost = m.config.CostFunc(c)
+		}
+
+		storeKey, reset := m.window(key, time.Now())
+		used, err := m.config.Store.Incr(c.Request.Context(), storeKey, cost, reset)
+		if err != nil {
+			return fmt.Errorf("quota: %w", err)
+		}
+		usage := m.usage(key, limit, used, reset)
+		before := used - cost
+
+		c.SetHeader("X-Quota-Limit", strconv.FormatInt(limit, 10))
+		c.SetHeader("X-Quota-Remaining", strconv.FormatInt(usage.Remaining, 10))
+		c.SetHeader("X-Quota-Reset", strconv.FormatInt(reset.Unix(), 10))
+
+		if used > limit {
+			if before <= limit {
+				m.report(EventExceeded, usage)
+			}
+			return m.config.ErrorHandler(c, usage)
+		}
+
+		soft := int64(float64(limit) * m.config.SoftLimit)
+		if used >= soft {
+			if before < soft {
+				m.report(EventWarning, usage)
+			}
+			c.SetHeader("X-Quota-Warning", fmt.Sprintf("%d of %d requests per %s used", used, limit, m.config.Period))
+		}
+		return c.Next()
+	}
+}
+
+// Usage returns the usage of key in the current window.
+//
+// Example:
+//
+//	admin.GET("/tenants/:id/usage", func(c *fursy.Context) error {
+//	    usage, err := quotas.Usage(c.Request.Context(), c.Param("id"))
+//	    if err != nil {
+//	        return err
+//	    }
+//	    return c.JSON(200, usage)
+//	})
+func (m *Manager) Usage(ctx context.Context, key string) (Usage, error) {
+	storeKey, reset := m.window(key, time.Now())
+	used, err := m.config.Store.Get(ctx, storeKey)
+	if err != nil {
+		return Usage{}, fmt.Errorf("quota: %w", err)
+	}
+	return m.usage(key, m.config.LimitFunc(key), used, reset), nil
+}
+
+// UsageHandler returns a handler reporting the usage of the requesting
+// key as JSON. Requests without a key are rejected with 401 Unauthorized.
+func (m *Manager) UsageHandler() fursy.HandlerFunc {
+	return func(c *fursy.Context) error {
+		key := m.config.KeyFunc(c)
+		if key == "" {
+			return c.Problem(fursy.Unauthorized("An API key is required"))
+		}
+		usage, err := m.Usage(c.Request.Context(), key)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, usage)
+	}
+}
+
+// window returns the store key of key for the window containing now,
+// and the time the window resets.
+func (m *Manager) window(key string, now time.Time) (string, time.Time) {
+	start, reset := m.config.Period.window(now.In(m.config.Location))
+	layout := time.DateOnly
+	if m.config.Period == Monthly {
+		layout = "2006-01"
+	}
+	return m.config.Prefix + key + ":" + start.Format(layout), reset
+}
+
+// usage returns the Usage of key.
+func (m *Manager) usage(key string, limit, used int64, reset time.Time) Usage {
+	return Usage{
+		Key:       key,
+		Period:    m.config.Period.String(),
+		Limit:     max(limit, 0),
+		Used:      used,
+		Remaining: max(limit-used, 0),
+		Reset:     reset,
+	}
+}
+
+// report reports a quota event to OnEvent and the webhook subscribers.
+func (m *Manager) report(typ EventType, usage Usage) {
+	event := Event{Type: typ, Usage: usage}
+	if m.config.OnEvent != nil {
+		m.config.OnEvent(event)
+	}
+	if m.config.Webhooks != nil {
+		// Send only fails once the dispatcher is shut down.
+		_, _ = m.config.Webhooks.Send(string(typ), event)
+	}
+}
+
+// defaultErrorHandler rejects the request with 429 Too Many Requests.
+func defaultErrorHandler(c *fursy.Context, usage Usage) error {
+	retryAfter := max(int(time.Until(usage.Reset).Seconds())+1, 1)
+	c.SetHeader("Retry-After", strconv.Itoa(retryAfter))
+	return c.Problem(fursy.TooManyRequests(
+		fmt.Sprintf("quota of %d requests per %s exceeded", usage.Limit, usage.Period)))
+}
@@ -0,0 +1,194 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package quota
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+func apiKey(c *fursy.Context) string {
+	return c.Request.Header.Get("X-API-Key")
+}
+
+func newQuotaRouter(m *Manager) *fursy.Router {
+	r := fursy.New()
+	r.Use(m.Middleware())
+	r.GET("/items", func(c *fursy.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+	r.GET("/usage", m.UsageHandler())
+	return r
+}
+
+func serveKey(r *fursy.Router, path, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+	if key != "" {
+		req.Header.Set("X-API-Key", key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestManager_Middleware(t *testing.T) {
+	var events []Event
+	m := New(Config{
+		KeyFunc: apiKey,
+		Limit:   5,
+		OnEvent: func(e Event) { events = append(events, e) },
+	})
+	r := newQuotaRouter(m)
+
+	for i := 1; i <= 5; i++ {
+		w := serveKey(r, "/items", "acme")
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("request %d: expected status 204, got %d", i, w.Code)
+		}
+		if got := w.Header().Get("X-Quota-Remaining"); got != strconv.Itoa(5-i) {
+			t.Errorf("request %d: X-Quota-Remaining = %q", i, got)
+		}
+		if warned := w.Header().Get("X-Quota-Warning") != ""; warned != (i >= 4) {
+			t.Errorf("request %d: X-Quota-Warning = %q", i, w.Header().Get("X-Quota-Warning"))
+		}
+	}
+
+	for range 2 {
+		w := serveKey(r, "/items", "acme")
+		if w.Code != http.StatusTooManyRequests {
+			t.Fatalf("expected status 429, got %d", w.Code)
+		}
+		if w.Header().Get("Retry-After") == "" {
+			t.Error("expected Retry-After header")
+		}
+	}
+
+	if len(events) != 2 || events[0].Type != EventWarning || events[1].Type != EventExceeded {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+	if events[0].Usage.Used != 4 || events[1].Usage.Used != 6 {
+		t.Errorf("unexpected event usage: %+v", events)
+	}
+
+	// Other keys and anonymous requests are not affected.
+	if w := serveKey(r, "/items", "globex"); w.Code != http.StatusNoContent {
+		t.Errorf("expected other key to pass, got %d", w.Code)
+	}
+	if w := serveKey(r, "/items", ""); w.Code != http.StatusNoContent || w.Header().Get("X-Quota-Limit") != "" {
+		t.Errorf("expected anonymous request to pass uncounted, got %d", w.Code)
+	}
+}
+
+func TestManager_LimitFunc(t *testing.T) {
+	plans := map[string]int64{"free": 1}
+	m := New(Config{
+		KeyFunc:   apiKey,
+		LimitFunc: func(key string) int64 { return plans[key] },
+		CostFunc:  func(*fursy.Context) int64 { return 1 },
+	})
+	r := newQuotaRouter(m)
+
+	serveKey(r, "/items", "free")
+	if w := serveKey(r, "/items", "free"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429, got %d", w.Code)
+	}
+	for range 3 {
+		if w := serveKey(r, "/items", "enterprise"); w.Code != http.StatusNoContent {
+			t.Errorf("expected key without quota to pass, got %d", w.Code)
+		}
+	}
+}
+
+func TestManager_UsageHandler(t *testing.T) {
+	m := New(Config{KeyFunc: apiKey, Limit: 100, Period: Monthly})
+	r := newQuotaRouter(m)
+
+	serveKey(r, "/items", "acme")
+	serveKey(r, "/items", "acme")
+
+	w := serveKey(r, "/usage", "acme")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var usage Usage
+	if err := json.Unmarshal(w.Body.Bytes(), &usage); err != nil {
+		t.Fatalf("decode usage: %v", err)
+	}
+	// The usage request itself is counted too.
+	if usage.Key != "acme" || usage.Period != "month" || usage.Limit != 100 || usage.Used != 3 || usage.Remaining != 97 {
+		t.Errorf("unexpected usage: %+v", usage)
+	}
+	if now := time.Now(); !usage.Reset.After(now) || usage.Reset.Day() != 1 {
+		t.Errorf("unexpected reset: %v", usage.Reset)
+	}
+
+	if w := serveKey(r, "/usage", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
+	}
+}
+
+func TestPeriod_Window(t *testing.T) {
+	at := time.Date(2025, time.December, 31, 18, 30, 0, 0, time.UTC)
+
+	start, reset := Daily.window(at)
+	if !start.Equal(time.Date(2025, time.December, 31, 0, 0, 0, 0, time.UTC)) ||
+		!reset.Equal(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("daily window = %v, %v", start, reset)
+	}
+
+	start, reset = Monthly.window(at)
+	if !start.Equal(time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC)) ||
+		!reset.Equal(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("monthly window = %v, %v", start, reset)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+
+	if n, _ := s.Incr(ctx, "k", 2, time.Now().Add(time.Hour)); n != 2 {
+		t.Errorf("Incr = %d, want 2", n)
+	}
+	if n, _ := s.Incr(ctx, "k", 3, time.Now().Add(time.Hour)); n != 5 {
+		t.Errorf("Incr = %d, want 5", n)
+	}
+	if n, _ := s.Get(ctx, "k"); n != 5 {
+		t.Errorf("Get = %d, want 5", n)
+	}
+
+	// Expired counters restart at 0.
+	s.Incr(ctx, "old", 7, time.Now().Add(-time.Second))
+	if n, _ := s.Get(ctx, "old"); n != 0 {
+		t.Errorf("Get of expired counter = %d, want 0", n)
+	}
+	if n, _ := s.Incr(ctx, "old", 1, time.Now().Add(time.Hour)); n != 1 {
+		t.Errorf("Incr of expired counter = %d, want 1", n)
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	for name, config := range map[string]Config{
+		"no key":     {Limit: 1},
+		"no limit":   {KeyFunc: apiKey},
+		"soft limit": {KeyFunc: apiKey, Limit: 1, SoftLimit: 2},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			New(config)
+		})
+	}
+}
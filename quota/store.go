@@ -0,0 +1,92 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package quota provides the usage counter stores.
+package quota
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store stores the usage counters of the quota windows.
+//
+// Counters must be shared by every replica, so production deployments
+// implement Store on a shared database. With Redis, Incr maps to
+// INCRBY followed by EXPIREAT (in a MULTI or a Lua script), and Get to GET.
+type Store interface {
+	// Incr adds n to the counter of key and returns the new value.
+	// The counter is created at 0 and expires at expireAt.
+	Incr(ctx context.Context, key string, n int64, expireAt time.Time) (int64, error)
+
+	// Get returns the counter of key (0 if absent or expired).
+	Get(ctx context.Context, key string) (int64, error)
+}
+
+// memoryPruneInterval is the minimum interval between removals of the
+// expired counters of a MemoryStore.
+const memoryPruneInterval = time.Minute
+
+// MemoryStore is an in-memory Store for single-instance deployments and tests.
+// It is safe for concurrent use.
+type MemoryStore struct {
+	mu       sync.Mutex
+	counters map[string]memoryCounter
+	prunedAt time.Time
+}
+
+// memoryCounter is a counter of a MemoryStore.
+type memoryCounter struct {
+	value    int64
+	expireAt time.Time
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{counters: make(map[string]memoryCounter)}
+}
+
+// Incr implements Store.
+func (s *MemoryStore) Incr(_ context.Context, key string, n int64, expireAt time.Time) (int64, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune(now)
+	counter := s.counters[key]
+	if !now.Before(counter.expireAt) {
+		counter = memoryCounter{}
+	}
+	counter.value += n
+	counter.expireAt = expireAt
+	s.counters[key] = counter
+	return counter.value, nil
+}
+
+// Get implements Store.
+func (s *MemoryStore) Get(_ context.Context, key string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counter, ok := s.counters[key]
+	if !ok || !time.Now().Before(counter.expireAt) {
+		return 0, nil
+	}
+	return counter.value, nil
+}
+
+// prune removes the expired counters; s.mu must be held.
+func (s *MemoryStore) prune(now time.Time) {
+	if now.Sub(s.prunedAt) < memoryPruneInterval {
+		return
+	}
+	s.prunedAt = now
+	for key, counter := range s.counters {
+		if !now.Before(counter.expireAt) {
+			delete(s.counters, key)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"mime"
+	"slices"
+)
+
+// Renderer writes data as a response body in a specific media type.
+// Renderers are registered via Router.RegisterRenderer and used by Context.Negotiate.
+type Renderer func(c *Context, status int, data any) error
+
+// RegisterRenderer registers a renderer for a media type used by Context.Negotiate.
+//
+// Registered media types are offered after the built-in formats
+// (JSON, XML, plain text), in registration order. Registering a built-in
+// media type replaces its default rendering.
+//
+// Panics if the media type is invalid or render is nil.
+//
+// Example:
+//
+//	router.RegisterRenderer("text/csv", func(c *fursy.Context, status int, data any) error {
+//	    rows, ok := data.(CSVer)
+//	    if !ok {
+//	        return c.Problem(fursy.NotAcceptable("CSV not available for this resource"))
+//	    }
+//	    c.SetHeader("Content-Type", "text/csv; charset=utf-8")
+//	    c.Response.WriteHeader(status)
+//	    return csv.NewWriter(c.Response).WriteAll(rows.CSV())
+//	})
+//
+//	// Clients sending "Accept: text/csv" now receive CSV from c.Negotiate().
+func (r *Router) RegisterRenderer(mediaType string, render Renderer) *Router {
+	if render == nil {
+		panic("fursy: renderer cannot be nil")
+	}
+	mt, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		panic("fursy: invalid renderer media type: " + mediaType)
+	}
+
+	if r.renderers == nil {
+		r.renderers = make(map[string]Renderer)
+		r.renderOffers = slices.Clone(negotiateOffers)
+	}
+	if !slices.Contains(r.renderOffers, mt) {
+		r.renderOffers = append(r.renderOffers, mt)
+	}
+	r.renderers[mt] = render
+
+	return r
+}
+
+// negotiateOffers returns the media types Negotiate can render for this router.
+func (c *Context) negotiateOffers() []string {
+	if c.router != nil && c.router.renderOffers != nil {
+		return c.router.renderOffers
+	}
+	return negotiateOffers
+}
+
+// customRenderer returns the registered renderer for a media type (if any).
+func (c *Context) customRenderer(mediaType string) Renderer {
+	if c.router == nil {
+		return nil
+	}
+	return c.router.renderers[mediaType]
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestRouter_RegisterRenderer tests custom renderers in Negotiate.
+func TestRouter_RegisterRenderer(t *testing.T) {
+	r := New()
+	r.RegisterRenderer("text/csv; charset=utf-8", func(c *Context, status int, data any) error {
+		c.SetHeader("Content-Type", "text/csv; charset=utf-8")
+		return c.Blob(status, "text/csv; charset=utf-8", []byte(fmt.Sprintf("value\n%v\n", data)))
+	})
+	r.GET("/data", func(c *Context) error {
+		return c.Negotiate(http.StatusOK, 42)
+	})
+
+	tests := []struct {
+		accept      string
+		contentType string
+		body        string
+	}{
+		{"text/csv", "text/csv; charset=utf-8", "value\n42\n"},
+		{"text/csv, application/json;q=0.5", "text/csv; charset=utf-8", "value\n42\n"},
+		{"application/json", "application/json; charset=utf-8", "42\n"},
+		{"*/*", "application/json; charset=utf-8", "42\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/data", http.NoBody)
+			req.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if ct := w.Header().Get("Content-Type"); ct != tt.contentType {
+				t.Errorf("expected Content-Type %q, got %q", tt.contentType, ct)
+			}
+			if w.Body.String() != tt.body {
+				t.Errorf("expected body %q, got %q", tt.body, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestRouter_RegisterRenderer_Override tests replacing a built-in renderer.
+func TestRouter_RegisterRenderer_Override(t *testing.T) {
+	r := New()
+	r.RegisterRenderer(MIMETextPlain, func(c *Context, status int, data any) error {
+		return c.String(status, fmt.Sprintf("value=%v", data))
+	})
+
+	GET[Empty, int](r, "/data", func(c *Box[Empty, int]) error {
+		value := 7
+		c.ResBody = &value
+		if c.ResponseFormat() != MIMETextPlain {
+			t.Errorf("expected text/plain, got %q", c.ResponseFormat())
+		}
+		return c.NegotiateRes()
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/data", http.NoBody)
+	req.Header.Set("Accept", "text/plain")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Body.String() != "value=7" {
+		t.Errorf("expected custom plain text, got %q", w.Body.String())
+	}
+}
+
+// TestRouter_RegisterRenderer_Invalid tests that invalid registrations panic.
+func TestRouter_RegisterRenderer_Invalid(t *testing.T) {
+	render := func(_ *Context, _ int, _ any) error { return nil }
+
+	for name, register := range map[string]func(){
+		"nil renderer":       func() { New().RegisterRenderer("text/csv", nil) },
+		"invalid media type": func() { New().RegisterRenderer("not a media type", render) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			register()
+		})
+	}
+}
@@ -0,0 +1,366 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Store errors mapped to problems by Resource.
+var (
+	// ErrResourceNotFound is returned by a Store when the item does not exist.
+	// Resource answers with 404 Not Found.
+	ErrResourceNotFound = errors.New("fursy: resource not found")
+
+	// ErrResourceConflict is returned by a Store when the item conflicts with
+	// the stored state (e.g., a duplicate unique key).
+	// Resource answers with 409 Conflict.
+	ErrResourceConflict = errors.New("fursy: resource conflict")
+)
+
+// Store is the persistence of the items of a Resource, identified by the
+// string form of their ID.
+//
+// Methods return ErrResourceNotFound and ErrResourceConflict (possibly
+// wrapped) for missing and conflicting items, and ValidationErrors or a
+// Problem for domain errors; other errors are passed to the router's error
+// handler.
+type Store[T any] interface {
+	// List returns a page of items matching q and the total number of matches.
+	List(ctx context.Context, q Query) (items []T, total int, err error)
+
+	// Get returns the item with the given ID.
+	Get(ctx context.Context, id string) (T, error)
+
+	// Create stores a new item and returns it as stored (e.g., with its ID).
+	Create(ctx context.Context, item T) (T, error)
+
+	// Update replaces the item with the given ID and returns it as stored.
+	Update(ctx context.Context, id string, item T) (T, error)
+
+	// Delete removes the item with the given ID.
+	Delete(ctx context.Context, id string) error
+}
+
+// BulkStore is implemented by stores that can create several items in one
+// transaction. Resource then registers POST <path>/bulk.
+type BulkStore[T any] interface {
+	// CreateMany stores all items or none of them (e.g., in one database
+	// transaction) and returns them as stored.
+	CreateMany(ctx context.Context, items []T) ([]T, error)
+}
+
+// ResourceConfig configures the routes registered by Resource.
+type ResourceConfig[T any] struct {
+	// ID returns the ID of an item, used for the Location header of
+	// created items.
+	// Default: nil (no Location header)
+	ID func(item T) string
+
+	// Query configures pagination, sorting and filtering of the list route.
+	// Default: zero QueryConfig (pagination only)
+	Query QueryConfig
+
+	// Tags are the OpenAPI tags of the routes.
+	// Default: the last segment of the path (e.g., "users")
+	Tags []string
+
+	// ReadOnly registers only the list and get routes.
+	// Default: false
+	ReadOnly bool
+
+	// MaxBulk is the maximum number of items of a bulk creation.
+	// Larger requests are rejected with 422 Unprocessable Entity.
+	// Default: 1000
+	MaxBulk int
+}
+
+// defaultMaxBulk is the default ResourceConfig.MaxBulk.
+const defaultMaxBulk = 1000
+
+// Resource registers the standard REST routes of a collection backed by store:
+//
+//	GET    path          list items (pagination, sort and filters, see Context.ListQuery)
+//	POST   path          create an item (201 Created)
+//	GET    path/:id      get an item
+//	PUT    path/:id      replace an item
+//	PATCH  path/:id      patch an item (JSON Merge Patch or JSON Patch, see Context.ApplyPatch)
+//	DELETE path/:id      delete an item (204 No Content)
+//	POST   path/bulk     create items in one transaction, if store implements BulkStore
+//
+// Request bodies are validated with the router's validator (see
+// Router.SetValidator). Errors are sent as problems: 422 for validation
+// errors, 404 for ErrResourceNotFound and 409 for ErrResourceConflict.
+// Routes are documented in OpenAPI with their types, parameters and
+// operation IDs (e.g., "listUsers", "getUser").
+//
+// Example:
+//
+//	type User struct {
+//	    ID    int    `json:"id"`
+//	    Name  string `json:"name" validate:"required"`
+//	    Email string `json:"email" validate:"required,email"`
+//	}
+//
+//	fursy.Resource[User](router, "/users", userStore, fursy.ResourceConfig[User]{
+//	    ID:    func(u User) string { return strconv.Itoa(u.ID) },
+//	    Query: fursy.QueryConfig{SortFields: []string{"name"}, FilterFields: []string{"email"}},
+//	})
+func Resource[T any](r *Router, path string, store Store[T], config ...ResourceConfig[T]) {
+	if store == nil {
+		panic("fursy: resource store cannot be nil")
+	}
+
+	var cfg ResourceConfig[T]
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	// Set defaults.
+	path = strings.TrimSuffix(path, "/")
+	name := resourceName(path)
+	if cfg.Tags == nil {
+		cfg.Tags = []string{name}
+	}
+	if cfg.MaxBulk <= 0 {
+		cfg.MaxBulk = defaultMaxBulk
+	}
+
+	res := &resource[T]{store: store, config: cfg, name: singular(name)}
+	itemPath := path + "/:id"
+	plural, single := upperFirst(name), upperFirst(res.name)
+	itemType, pageType := reflect.TypeFor[T](), reflect.TypeFor[Page[T]]()
+
+	res.route(r, http.MethodGet, path, res.list, "list"+plural, "List "+name, nil, pageType)
+	res.route(r, http.MethodGet, itemPath, res.get, "get"+single, "Get a "+res.name, nil, itemType)
+	if cfg.ReadOnly {
+		return
+	}
+
+	res.route(r, http.MethodPost, path, res.create, "create"+single, "Create a "+res.name, itemType, itemType)
+	res.route(r, http.MethodPut, itemPath, res.update, "update"+single, "Replace a "+res.name, itemType, itemType)
+	res.route(r, http.MethodPatch, itemPath, res.patch, "patch"+single, "Patch a "+res.name, itemType, itemType)
+	res.route(r, http.MethodDelete, itemPath, res.delete, "delete"+single, "Delete a "+res.name, nil, nil)
+
+	if bulk, ok := store.(BulkStore[T]); ok {
+		res.bulk = bulk
+		res.route(r, http.MethodPost, path+"/bulk", res.createMany, "bulkCreate"+plural,
+			"Create "+name+" in one transaction", reflect.TypeFor[[]T](), reflect.TypeFor[[]T]())
+	}
+}
+
+// resource holds the handlers of the routes registered by Resource.
+type resource[T any] struct {
+	store  Store[T]
+	bulk   BulkStore[T]
+	config ResourceConfig[T]
+	name   string // Singular name, for problem details.
+}
+
+// route registers a handler with its OpenAPI metadata.
+func (res *resource[T]) route(r *Router, method, path string, handler HandlerFunc, operationID, summary string, reqType, resType reflect.Type) {
+	r.handleRoute(method, path, handler, nil, func(route *RouteInfo) {
+		route.OperationID = operationID
+		route.Summary = summary
+		route.Tags = res.config.Tags
+		route.RequestType = reqType
+		route.ResponseType = resType
+		switch {
+		case strings.HasSuffix(path, "/:id"):
+			route.Parameters = []RouteParameter{{Name: "id", In: "path", Required: true, Type: reflect.TypeFor[string]()}}
+		case method == http.MethodGet: // The list route.
+			route.Parameters = listParameters(res.config.Query)
+		}
+	})
+}
+
+func (res *resource[T]) list(c *Context) error {
+	q, err := c.ListQuery(res.config.Query)
+	if err != nil {
+		return res.fail(c, err)
+	}
+	items, total, err := res.store.List(c.Request.Context(), q)
+	if err != nil {
+		return res.fail(c, err)
+	}
+	return c.OKPage(items, q.Meta(total, ""))
+}
+
+func (res *resource[T]) get(c *Context) error {
+	item, err := res.store.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		return res.fail(c, err)
+	}
+	return c.OK(item)
+}
+
+func (res *resource[T]) create(c *Context) error {
+	item, err := bindResource[T](c)
+	if err != nil {
+		return res.fail(c, err)
+	}
+	created, err := res.store.Create(c.Request.Context(), *item)
+	if err != nil {
+		return res.fail(c, err)
+	}
+	if res.config.ID != nil {
+		c.SetHeader("Location", strings.TrimSuffix(c.Request.URL.Path, "/")+"/"+res.config.ID(created))
+	}
+	return c.Created(created)
+}
+
+func (res *resource[T]) update(c *Context) error {
+	item, err := bindResource[T](c)
+	if err != nil {
+		return res.fail(c, err)
+	}
+	updated, err := res.store.Update(c.Request.Context(), c.Param("id"), *item)
+	if err != nil {
+		return res.fail(c, err)
+	}
+	return c.OK(updated)
+}
+
+func (res *resource[T]) patch(c *Context) error {
+	ctx, id := c.Request.Context(), c.Param("id")
+	item, err := res.store.Get(ctx, id)
+	if err != nil {
+		return res.fail(c, err)
+	}
+	if err := c.ApplyPatch(&item); err != nil {
+		return res.fail(c, err)
+	}
+	updated, err := res.store.Update(ctx, id, item)
+	if err != nil {
+		return res.fail(c, err)
+	}
+	return c.OK(updated)
+}
+
+func (res *resource[T]) delete(c *Context) error {
+	if err := res.store.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		return res.fail(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (res *resource[T]) createMany(c *Context) error {
+	var items []T
+	if err := json.NewDecoder(c.Request.Body).Decode(&items); err != nil {
+		return c.Problem(BadRequest("request body must be a JSON array"))
+	}
+	if len(items) > res.config.MaxBulk {
+		return c.Problem(UnprocessableEntity("at most " + strconv.Itoa(res.config.MaxBulk) + " items can be created at once"))
+	}
+	if c.router != nil && c.router.validator != nil {
+		var errs ValidationErrors
+		for i := range items {
+			if err := c.router.validator.Validate(&items[i]); err != nil {
+				var verrs ValidationErrors
+				if !errors.As(indexValidationError(i, err), &verrs) {
+					return err
+				}
+				errs = append(errs, verrs...)
+			}
+		}
+		if !errs.IsEmpty() {
+			return c.Problem(ValidationProblem(errs))
+		}
+	}
+
+	created, err := res.bulk.CreateMany(c.Request.Context(), items)
+	if err != nil {
+		return res.fail(c, err)
+	}
+	if created == nil {
+		created = []T{}
+	}
+	return c.Created(created)
+}
+
+// fail sends the problem matching err, or returns err for the router's
+// error handler.
+func (res *resource[T]) fail(c *Context, err error) error {
+	var (
+		verrs   ValidationErrors
+		problem Problem
+	)
+	switch {
+	case errors.As(err, &verrs):
+		return c.Problem(ValidationProblem(verrs))
+	case errors.As(err, &problem):
+		return c.Problem(problem)
+	case errors.Is(err, ErrResourceNotFound):
+		return c.Problem(NotFound(res.name + " " + c.Param("id") + " not found"))
+	case errors.Is(err, ErrResourceConflict):
+		return c.Problem(Conflict(err.Error()))
+	case errors.Is(err, ErrUnsupportedPatchType):
+		return c.Problem(NewProblem(http.StatusUnsupportedMediaType, "Unsupported Media Type", err.Error()))
+	}
+	return err
+}
+
+// bindResource binds and validates the request body as a T.
+func bindResource[T any](c *Context) (*T, error) {
+	box := newBox[T, Empty](c)
+	if err := box.Bind(); err != nil {
+		var verrs ValidationErrors
+		if errors.As(err, &verrs) {
+			return nil, err
+		}
+		return nil, BadRequest(err.Error())
+	}
+	return box.ReqBody, nil
+}
+
+// listParameters documents the query parameters of a list route.
+func listParameters(config QueryConfig) []RouteParameter {
+	intType, stringType := reflect.TypeFor[int](), reflect.TypeFor[string]()
+	params := []RouteParameter{
+		{Name: "limit", In: "query", Description: "Page size", Type: intType},
+		{Name: "offset", In: "query", Description: "Number of items to skip", Type: intType},
+		{Name: "page", In: "query", Description: "1-based page number", Type: intType},
+		{Name: "cursor", In: "query", Description: "Cursor from a previous page", Type: stringType},
+	}
+	if len(config.SortFields) > 0 {
+		params = append(params, RouteParameter{
+			Name:        "sort",
+			In:          "query",
+			Description: "Comma-separated sort fields, prefixed with - for descending order: " + strings.Join(config.SortFields, ", "),
+			Type:        stringType,
+		})
+	}
+	return params
+}
+
+// resourceName returns the last segment of path, without parameters.
+func resourceName(p string) string {
+	name := path.Base(p)
+	if name == "/" || name == "." || strings.ContainsAny(name[:1], ":*") {
+		panic("fursy: resource path must end with a static segment: " + p)
+	}
+	return name
+}
+
+// singular returns a naive singular of an English plural name
+// ("users" → "user", "categories" → "category").
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies") && len(name) > 3:
+		return name[:len(name)-3] + "y"
+	case strings.HasSuffix(name, "ses"), strings.HasSuffix(name, "xes"):
+		return name[:len(name)-2]
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss"):
+		return name[:len(name)-1]
+	}
+	return name
+}
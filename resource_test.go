@@ -0,0 +1,246 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// resourceItem is the item type of the resource tests.
+type resourceItem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// memoryStore is an in-memory Store and BulkStore.
+type memoryStore struct {
+	mu     sync.Mutex
+	items  map[string]resourceItem
+	nextID int
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{items: make(map[string]resourceItem), nextID: 1}
+}
+
+func (s *memoryStore) List(_ context.Context, q Query) ([]resourceItem, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := make([]resourceItem, 0, len(s.items))
+	for _, item := range s.items {
+		items = append(items, item)
+	}
+	slices.SortFunc(items, func(a, b resourceItem) int { return a.ID - b.ID })
+	total := len(items)
+	items = items[min(q.Offset, total):min(q.Offset+q.Limit, total)]
+	return items, total, nil
+}
+
+func (s *memoryStore) Get(_ context.Context, id string) (resourceItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.items[id]
+	if !ok {
+		return resourceItem{}, ErrResourceNotFound
+	}
+	return item, nil
+}
+
+func (s *memoryStore) Create(_ context.Context, item resourceItem) (resourceItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.items {
+		if existing.Name == item.Name {
+			return resourceItem{}, fmt.Errorf("name %q is taken: %w", item.Name, ErrResourceConflict)
+		}
+	}
+	item.ID = s.nextID
+	s.nextID++
+	s.items[strconv.Itoa(item.ID)] = item
+	return item, nil
+}
+
+func (s *memoryStore) Update(_ context.Context, id string, item resourceItem) (resourceItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.items[id]
+	if !ok {
+		return resourceItem{}, ErrResourceNotFound
+	}
+	item.ID = existing.ID
+	s.items[id] = item
+	return item, nil
+}
+
+func (s *memoryStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.items[id]; !ok {
+		return ErrResourceNotFound
+	}
+	delete(s.items, id)
+	return nil
+}
+
+func (s *memoryStore) CreateMany(ctx context.Context, items []resourceItem) ([]resourceItem, error) {
+	created := make([]resourceItem, 0, len(items))
+	for _, item := range items {
+		c, err := s.Create(ctx, item)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, c)
+	}
+	return created, nil
+}
+
+// nameValidator requires a non-empty name.
+type nameValidator struct{}
+
+func (nameValidator) Validate(v any) error {
+	if item, ok := v.(*resourceItem); ok && item.Name == "" {
+		return ValidationErrors{{Field: "name", Tag: "required", Message: "name is required"}}
+	}
+	return nil
+}
+
+func newResourceRouter(store Store[resourceItem], config ...ResourceConfig[resourceItem]) *Router {
+	r := New()
+	r.SetValidator(nameValidator{})
+	Resource[resourceItem](r, "/items", store, config...)
+	return r
+}
+
+func resourceRequest(r *Router, method, target, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// TestResource_CRUD tests the standard routes.
+func TestResource_CRUD(t *testing.T) {
+	r := newResourceRouter(newMemoryStore(), ResourceConfig[resourceItem]{
+		ID: func(item resourceItem) string { return strconv.Itoa(item.ID) },
+	})
+
+	steps := []struct {
+		method, target, contentType, body string
+		status                            int
+		want                              string
+	}{
+		{http.MethodPost, "/items", MIMEApplicationJSON, `{"name":"a"}`, http.StatusCreated, `{"id":1,"name":"a"}`},
+		{http.MethodPost, "/items", MIMEApplicationJSON, `{"name":"b"}`, http.StatusCreated, `{"id":2,"name":"b"}`},
+		{http.MethodPost, "/items", MIMEApplicationJSON, `{"name":"a"}`, http.StatusConflict, `name \"a\" is taken`},
+		{http.MethodPost, "/items", MIMEApplicationJSON, `{"name":""}`, http.StatusUnprocessableEntity, `"code":"required"`},
+		{http.MethodPost, "/items", MIMEApplicationJSON, `{"name":`, http.StatusBadRequest, `"status":400`},
+		{http.MethodGet, "/items/1", "", "", http.StatusOK, `{"id":1,"name":"a"}`},
+		{http.MethodGet, "/items/9", "", "", http.StatusNotFound, `item 9 not found`},
+		{http.MethodGet, "/items?limit=1&offset=1", "", "", http.StatusOK, `"items":[{"id":2,"name":"b"}],"total":2`},
+		{http.MethodPut, "/items/1", MIMEApplicationJSON, `{"name":"c"}`, http.StatusOK, `{"id":1,"name":"c"}`},
+		{http.MethodPatch, "/items/2", MIMEApplicationMergePatch, `{"name":"d"}`, http.StatusOK, `{"id":2,"name":"d"}`},
+		{http.MethodPatch, "/items/2", MIMEApplicationJSONPatch, `[{"op":"test","path":"/name","value":"x"}]`, http.StatusUnprocessableEntity, `"code":"test"`},
+		{http.MethodPatch, "/items/2", MIMEApplicationMergePatch, `{"name":null}`, http.StatusUnprocessableEntity, `name is required`},
+		{http.MethodDelete, "/items/1", "", "", http.StatusNoContent, ""},
+		{http.MethodDelete, "/items/1", "", "", http.StatusNotFound, `item 1 not found`},
+	}
+	for _, s := range steps {
+		w := resourceRequest(r, s.method, s.target, s.contentType, s.body)
+		if w.Code != s.status || !strings.Contains(w.Body.String(), s.want) {
+			t.Errorf("%s %s %s: got %d %s, want %d %s", s.method, s.target, s.body, w.Code, w.Body.String(), s.status, s.want)
+		}
+	}
+
+	w := resourceRequest(r, http.MethodPost, "/items", MIMEApplicationJSON, `{"name":"e"}`)
+	if loc := w.Header().Get("Location"); loc != "/items/3" {
+		t.Errorf("Location = %q, want /items/3", loc)
+	}
+}
+
+// TestResource_Bulk tests transactional bulk creation.
+func TestResource_Bulk(t *testing.T) {
+	r := newResourceRouter(newMemoryStore(), ResourceConfig[resourceItem]{MaxBulk: 3})
+
+	w := resourceRequest(r, http.MethodPost, "/items/bulk", MIMEApplicationJSON, `[{"name":"a"},{"name":"b"}]`)
+	if w.Code != http.StatusCreated || !strings.Contains(w.Body.String(), `[{"id":1,"name":"a"},{"id":2,"name":"b"}]`) {
+		t.Errorf("bulk create: got %d %s", w.Code, w.Body.String())
+	}
+
+	w = resourceRequest(r, http.MethodPost, "/items/bulk", MIMEApplicationJSON, `[{"name":"c"},{"name":""}]`)
+	if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), `"field":"[1].name"`) {
+		t.Errorf("invalid item: got %d %s", w.Code, w.Body.String())
+	}
+
+	w = resourceRequest(r, http.MethodPost, "/items/bulk", MIMEApplicationJSON, `[{"name":"d"},{"name":"e"},{"name":"f"},{"name":"g"}]`)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("too many items: got %d, want 422", w.Code)
+	}
+
+	w = resourceRequest(r, http.MethodGet, "/items", "", "")
+	var page struct {
+		Total int `json:"total"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil || page.Total != 2 {
+		t.Errorf("after failed bulk requests: total = %d (%v), want 2", page.Total, err)
+	}
+}
+
+// crudOnlyStore hides the BulkStore methods of memoryStore.
+type crudOnlyStore struct{ Store[resourceItem] }
+
+// TestResource_Routes tests the registered routes and their OpenAPI metadata.
+func TestResource_Routes(t *testing.T) {
+	r := newResourceRouter(crudOnlyStore{newMemoryStore()})
+
+	var routes []string
+	for _, route := range r.Routes() {
+		routes = append(routes, route.Method+" "+route.Path+" "+route.OperationID)
+	}
+	want := []string{
+		"GET /items listItems",
+		"GET /items/:id getItem",
+		"POST /items createItem",
+		"PUT /items/:id updateItem",
+		"PATCH /items/:id patchItem",
+		"DELETE /items/:id deleteItem",
+	}
+	if !slices.Equal(routes, want) {
+		t.Errorf("routes = %v, want %v", routes, want)
+	}
+
+	doc, err := r.GenerateOpenAPI(Info{Title: "Test", Version: "1.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	get := doc.Paths["/items/{id}"].Get
+	if len(get.Parameters) != 1 || get.Parameters[0].Name != "id" || get.Tags[0] != "items" {
+		t.Errorf("get operation = %+v", get)
+	}
+	if doc.Paths["/items"].Post.RequestBody == nil {
+		t.Error("create operation has no request body")
+	}
+
+	r = New()
+	Resource[resourceItem](r, "/categories", newMemoryStore(), ResourceConfig[resourceItem]{ReadOnly: true})
+	routes = routes[:0]
+	for _, route := range r.Routes() {
+		routes = append(routes, route.Method+" "+route.Path+" "+route.OperationID)
+	}
+	if !slices.Equal(routes, []string{"GET /categories listCategories", "GET /categories/:id getCategory"}) {
+		t.Errorf("read-only routes = %v", routes)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+// DefaultResponseBufferLimit is the default size up to which encoded
+// responses are buffered (see Options.ResponseBufferLimit).
+const DefaultResponseBufferLimit = 64 << 10
+
+// EncodeError is returned by Context.JSON and Context.JSONIndent when the
+// response cannot be encoded (e.g., a MarshalJSON method fails).
+//
+// Responses up to the buffer limit are encoded before the status line is
+// written, so the error handler can still send an error response; the
+// default error handler sends a 500 Internal Server Error problem.
+//
+// Example:
+//
+//	router.SetErrorHandler(func(c *fursy.Context, err error) {
+//	    var encErr *fursy.EncodeError
+//	    if errors.As(err, &encErr) {
+//	        slog.Error("response encoding failed", "route", c.Route(), "error", encErr.Err)
+//	    }
+//	    _ = c.Problem(fursy.InternalServerError(""))
+//	})
+type EncodeError struct {
+	// ContentType is the media type of the response (e.g., "application/json").
+	ContentType string
+
+	// Err is the encoding error.
+	Err error
+}
+
+// Error implements error.
+func (e *EncodeError) Error() string {
+	return "fursy: encode " + e.ContentType + " response: " + e.Err.Error()
+}
+
+// Unwrap returns the encoding error.
+func (e *EncodeError) Unwrap() error {
+	return e.Err
+}
+
+// responseBufferPool reuses the buffers of bufferedBody.
+var responseBufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// bufferedBody buffers a response body up to limit bytes before writing
+// the status line, so encoding errors can still change the response.
+// Larger bodies are streamed once the limit is reached.
+type bufferedBody struct {
+	c           *Context
+	code        int
+	contentType string
+	limit       int
+	buf         *bytes.Buffer
+	flushed     bool
+}
+
+// newBufferedBody returns a buffered body for a response with status code.
+func (c *Context) newBufferedBody(code int, contentType string) *bufferedBody {
+	limit := DefaultResponseBufferLimit
+	if c.router != nil && c.router.responseBufferLimit != 0 {
+		limit = c.router.responseBufferLimit
+	}
+	b := &bufferedBody{c: c, code: code, contentType: contentType, limit: limit}
+	if limit > 0 {
+		b.buf = responseBufferPool.Get().(*bytes.Buffer)
+	}
+	return b
+}
+
+// Write buffers p, or streams it once the body exceeds the limit.
+func (b *bufferedBody) Write(p []byte) (int, error) {
+	if !b.flushed && b.buf != nil && b.buf.Len()+len(p) <= b.limit {
+		return b.buf.Write(p)
+	}
+	if err := b.flush(); err != nil {
+		return 0, err
+	}
+	return b.c.Response.Write(p)
+}
+
+// flush writes the status line and the buffered body.
+func (b *bufferedBody) flush() error {
+	if b.flushed {
+		return nil
+	}
+	b.flushed = true
+	b.c.Response.Header().Set("Content-Type", b.contentType)
+	b.c.Response.WriteHeader(b.code)
+	if b.buf == nil || b.buf.Len() == 0 {
+		return nil
+	}
+	_, err := b.c.Response.Write(b.buf.Bytes())
+	return err
+}
+
+// release returns the buffer to the pool. Large buffers are dropped.
+func (b *bufferedBody) release() {
+	if b.buf == nil {
+		return
+	}
+	if b.buf.Cap() <= 2*b.limit {
+		b.buf.Reset()
+		responseBufferPool.Put(b.buf)
+	}
+	b.buf = nil
+}
+
+// writeJSON encodes obj as the JSON response body, buffered up to the
+// response buffer limit.
+func (c *Context) writeJSON(code int, obj any, indent string) error {
+	if c.shouldCancel() {
+		return ErrClientGone
+	}
+	// Mask fields (see SetUnmasker), then select and localize them (see
+	// RouteOptions.Fields and RouteOptions.Localize) on the generic JSON
+	// document.
+	obj, _ = c.maskResponse(obj)
+	success := code >= 200 && code < 300
+	if success && (c.fields != nil || c.localizer != nil) {
+		doc, err := jsonDocument(obj)
+		if err != nil {
+			return &EncodeError{ContentType: "application/json", Err: err}
+		}
+		if c.fields != nil {
+			doc = c.fields.prune(doc)
+		}
+		if c.localizer != nil {
+			doc = c.localizer.localize(doc)
+		}
+		obj = doc
+	}
+	body := c.newBufferedBody(code, c.contentType("application/json"))
+	defer body.release()
+
+	encoder := json.NewEncoder(body)
+	if indent != "" {
+		encoder.SetIndent("", indent)
+	}
+	if err := encoder.Encode(obj); err != nil {
+		if body.flushed {
+			// The status line is sent: the response is truncated.
+			return err
+		}
+		return &EncodeError{ContentType: "application/json", Err: err}
+	}
+	return body.flush()
+}
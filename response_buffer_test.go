@@ -0,0 +1,100 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// failingJSON fails to encode.
+type failingJSON struct{}
+
+func (failingJSON) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("broken field")
+}
+
+func TestJSON_EncodeError(t *testing.T) {
+	var handled error
+	r := New()
+	r.GET("/broken", func(c *Context) error {
+		return c.JSON(http.StatusOK, map[string]any{"ok": true, "field": failingJSON{}})
+	})
+	r.GET("/handled", func(c *Context) error {
+		return c.JSONIndent(http.StatusOK, []any{failingJSON{}}, "  ")
+	})
+	api := r.Group("/api")
+	api.SetErrorHandler(func(c *Context, err error) {
+		handled = err
+		_ = c.String(http.StatusTeapot, "handled")
+	})
+	api.GET("/broken", func(c *Context) error {
+		return c.JSON(http.StatusOK, failingJSON{})
+	})
+
+	for _, path := range []string{"/broken", "/handled"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("%s: status = %d, want 500", path, w.Code)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/problem+json; charset=utf-8" {
+			t.Errorf("%s: Content-Type = %q, want problem", path, ct)
+		}
+		if strings.Contains(w.Body.String(), "ok") {
+			t.Errorf("%s: partial body leaked: %q", path, w.Body.String())
+		}
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/broken", http.NoBody))
+	var encErr *EncodeError
+	if w.Code != http.StatusTeapot || !errors.As(handled, &encErr) || encErr.ContentType != "application/json" {
+		t.Errorf("error handler got %v (status %d)", handled, w.Code)
+	}
+}
+
+func TestJSON_ResponseBufferLimit(t *testing.T) {
+	large := strings.Repeat("x", 1024)
+
+	tests := []struct {
+		name     string
+		limit    int
+		value    any
+		wantCode int
+	}{
+		{"buffered", 0, large, http.StatusCreated},
+		{"streamed large body", 100, large, http.StatusCreated},
+		{"disabled", -1, large, http.StatusCreated},
+		{"disabled encode error", -1, failingJSON{}, http.StatusInternalServerError}, // Nothing written yet.
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewWithOptions(WithResponseBufferLimit(tt.limit))
+			r.GET("/", func(c *Context) error {
+				code := http.StatusCreated
+				if _, ok := tt.value.(failingJSON); ok {
+					code = http.StatusOK
+				}
+				return c.JSON(code, tt.value)
+			})
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+			if w.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantCode)
+			}
+			if s, ok := tt.value.(string); ok && w.Body.String() != `"`+s+`"`+"\n" {
+				t.Errorf("body length = %d, want %d", w.Body.Len(), len(s)+3)
+			}
+		})
+	}
+}
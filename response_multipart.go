@@ -0,0 +1,98 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+// Multipart streams a multipart response whose parts are written by fn,
+// e.g. JSON metadata followed by a binary payload, or the frames of a
+// multipart/x-mixed-replace (MJPEG) stream.
+//
+// The media type is multipart/mixed, unless a multipart Content-Type is set
+// before the call (e.g., MIMEMultipartReplace); the boundary parameter is
+// added to it. An empty boundary generates a random one. Content-Length is
+// removed since the length is not known in advance.
+//
+// Parts are written to the connection as fn writes them, through the
+// server's buffer: call Context.Flush after a part to send it immediately.
+// The closing boundary is written when fn returns nil; if fn returns an
+// error, it is omitted so clients can detect the truncated response.
+//
+// Example:
+//
+//	return c.Multipart(200, "", func(mw *multipart.Writer) error {
+//	    meta, _ := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"application/json"}})
+//	    if err := json.NewEncoder(meta).Encode(file.Meta); err != nil {
+//	        return err
+//	    }
+//	    data, _ := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {file.ContentType}})
+//	    _, err := io.Copy(data, file.Body)
+//	    return err
+//	})
+//
+// Example (MJPEG stream):
+//
+//	c.SetHeader("Content-Type", fursy.MIMEMultipartReplace)
+//	return c.Multipart(200, "frame", func(mw *multipart.Writer) error {
+//	    for frame := range camera.Frames(c.Request.Context()) {
+//	        part, err := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"image/jpeg"}})
+//	        if err != nil {
+//	            return err
+//	        }
+//	        if _, err := part.Write(frame); err != nil {
+//	            return err
+//	        }
+//	        if err := c.Flush(); err != nil {
+//	            return err
+//	        }
+//	    }
+//	    return nil
+//	})
+func (c *Context) Multipart(code int, boundary string, fn func(mw *multipart.Writer) error) error {
+	if c.shouldCancel() {
+		return ErrClientGone
+	}
+
+	mw := multipart.NewWriter(c.Response)
+	if boundary != "" {
+		if err := mw.SetBoundary(boundary); err != nil {
+			return err
+		}
+	}
+
+	h := c.Response.Header()
+	mediaType := MIMEMultipartMixed
+	if mt, _, err := mime.ParseMediaType(h.Get("Content-Type")); err == nil && strings.HasPrefix(mt, "multipart/") {
+		mediaType = mt
+	}
+	h.Set("Content-Type", mime.FormatMediaType(mediaType, map[string]string{"boundary": mw.Boundary()}))
+	h.Del("Content-Length")
+	c.Response.WriteHeader(code)
+
+	if err := fn(mw); err != nil {
+		return err
+	}
+	return mw.Close()
+}
+
+// Flush sends the buffered response data to the client, if the
+// ResponseWriter supports it (see http.ResponseController).
+//
+// Example:
+//
+//	for _, line := range lines {
+//	    fmt.Fprintln(c.Response, line)
+//	    if err := c.Flush(); err != nil {
+//	        return err
+//	    }
+//	}
+func (c *Context) Flush() error {
+	return http.NewResponseController(c.Response).Flush()
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+)
+
+// TestContext_Multipart tests multipart responses.
+func TestContext_Multipart(t *testing.T) {
+	r := New()
+	r.GET("/file", func(c *Context) error {
+		c.SetHeader("Content-Length", "42")
+		return c.Multipart(http.StatusOK, "", func(mw *multipart.Writer) error {
+			meta, err := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"application/json"}})
+			if err != nil {
+				return err
+			}
+			_, _ = io.WriteString(meta, `{"name":"a.bin"}`)
+			data, err := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"application/octet-stream"}})
+			if err != nil {
+				return err
+			}
+			_, _ = data.Write([]byte{1, 2, 3})
+			return c.Flush()
+		})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/file", http.NoBody))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d", w.Code)
+	}
+	if w.Header().Get("Content-Length") != "" {
+		t.Error("expected Content-Length to be removed")
+	}
+	mediaType, params, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	if err != nil || mediaType != MIMEMultipartMixed || params["boundary"] == "" {
+		t.Fatalf("Content-Type = %q", w.Header().Get("Content-Type"))
+	}
+	if !w.Flushed {
+		t.Error("expected response to be flushed")
+	}
+
+	reader := multipart.NewReader(w.Body, params["boundary"])
+	var parts []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("NextPart: %v", err)
+		}
+		body, _ := io.ReadAll(part)
+		parts = append(parts, part.Header.Get("Content-Type")+" "+string(body))
+	}
+	if len(parts) != 2 || parts[0] != `application/json {"name":"a.bin"}` || parts[1] != "application/octet-stream \x01\x02\x03" {
+		t.Errorf("parts = %q", parts)
+	}
+}
+
+// TestContext_MultipartReplace tests keeping a preset multipart media type and boundary.
+func TestContext_MultipartReplace(t *testing.T) {
+	c, w := newMultipartContext()
+	c.SetHeader("Content-Type", MIMEMultipartReplace)
+
+	errStop := errors.New("camera stopped")
+	err := c.Multipart(http.StatusOK, "frame", func(mw *multipart.Writer) error {
+		part, _ := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"image/jpeg"}})
+		_, _ = part.Write([]byte("jpeg"))
+		return errStop
+	})
+
+	if !errors.Is(err, errStop) {
+		t.Errorf("err = %v, want %v", err, errStop)
+	}
+	if got := w.Header().Get("Content-Type"); got != MIMEMultipartReplace+"; boundary=frame" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	if body := w.Body.String(); body != "--frame\r\nContent-Type: image/jpeg\r\n\r\njpeg" {
+		t.Errorf("body = %q, want no closing boundary", body)
+	}
+
+	// Invalid boundaries are rejected before anything is written.
+	c, w = newMultipartContext()
+	if err := c.Multipart(http.StatusOK, "bad@boundary", func(*multipart.Writer) error { return nil }); err == nil {
+		t.Error("expected invalid boundary error")
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("body = %q, want empty", w.Body.String())
+	}
+}
+
+// newMultipartContext returns a context writing to a recorder.
+func newMultipartContext() (*Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c := newContext()
+	c.init(w, httptest.NewRequest(http.MethodGet, "/stream", http.NoBody), nil, nil)
+	return c, w
+}
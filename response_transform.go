@@ -0,0 +1,282 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// defaultTransformMaxSize is the default TransformConfig.MaxSize.
+const defaultTransformMaxSize = 1 << 20
+
+// BufferedResponse is a response captured by TransformResponse before it
+// is sent to the client.
+type BufferedResponse struct {
+	// Status is the response status code.
+	Status int
+
+	// Header is the response header. Content-Length is set from Body
+	// when the response is sent.
+	Header http.Header
+
+	// Body is the response body.
+	Body []byte
+}
+
+// ResponseTransformer transforms a buffered response in place, e.g. to
+// wrap it in an envelope or rename its fields. If it returns an error,
+// the response is not sent and the error is returned to the error handler.
+type ResponseTransformer func(c *Context, res *BufferedResponse) error
+
+// TransformConfig defines the configuration for TransformResponseWithConfig.
+type TransformConfig struct {
+	// Transform transforms the buffered responses.
+	// Required.
+	Transform ResponseTransformer
+
+	// MaxSize is the maximum size of a transformed body in bytes. Larger
+	// responses are streamed to the client untransformed.
+	// Default: 1 MB
+	MaxSize int
+
+	// ContentTypes are the media types of the transformed responses.
+	// Other responses are streamed untransformed.
+	// Default: application/json and +json media types
+	ContentTypes []string
+
+	// Skipper defines a function to skip the middleware.
+	// Default: nil (middleware always executes)
+	Skipper Skipper
+}
+
+// TransformResponse returns a middleware that buffers JSON responses of
+// the following handlers and transforms them with fn before they are sent.
+//
+// Responses are streamed untransformed (passthrough) when they are larger
+// than 1 MB, flushed by the handler (Server-Sent Events, Context.Flush),
+// already compressed (Content-Encoding set) or not JSON, so streaming
+// endpoints keep working behind the middleware. 204 and 304 responses are
+// sent as is. If the handler returns an error, its buffered response is
+// discarded and the error handler's response is sent untransformed.
+//
+// Example (envelope wrapping):
+//
+//	router.Use(fursy.TransformResponse(func(c *fursy.Context, res *fursy.BufferedResponse) error {
+//	    if res.Status >= 300 {
+//	        return nil
+//	    }
+//	    res.Body = append(append([]byte(`{"data":`), bytes.TrimSpace(res.Body)...), '}')
+//	    return nil
+//	}))
+func TransformResponse(fn ResponseTransformer) HandlerFunc {
+	return TransformResponseWithConfig(TransformConfig{Transform: fn})
+}
+
+// TransformResponseWithConfig returns a TransformResponse middleware with
+// custom configuration.
+//
+// Example (transform CSV responses up to 10 MB):
+//
+//	router.Use(fursy.TransformResponseWithConfig(fursy.TransformConfig{
+//	    Transform:    addCSVHeader,
+//	    MaxSize:      10 << 20,
+//	    ContentTypes: []string{"text/csv"},
+//	}))
+func TransformResponseWithConfig(config TransformConfig) HandlerFunc {
+	// Validate config.
+	if config.Transform == nil {
+		panic("fursy: TransformResponse transform is required")
+	}
+
+	// Set defaults.
+	if config.MaxSize <= 0 {
+		config.MaxSize = defaultTransformMaxSize
+	}
+
+	return func(c *Context) error {
+		if config.Skipper.ShouldSkip(c) {
+			return c.Next()
+		}
+
+		tw := &transformWriter{ResponseWriter: c.Response, config: &config}
+		c.Response = tw
+		err := c.Next()
+		c.Response = tw.ResponseWriter
+		defer tw.release()
+
+		if tw.status == 0 || tw.passthrough {
+			return err
+		}
+		if err != nil {
+			// Nothing was sent yet: drop the buffered response so the
+			// error handler writes the only response.
+			return err
+		}
+
+		res := &BufferedResponse{Status: tw.status, Header: tw.Header()}
+		if tw.buf != nil {
+			res.Body = tw.buf.Bytes()
+		}
+		if terr := config.Transform(c, res); terr != nil {
+			return terr
+		}
+
+		res.Header.Set("Content-Length", strconv.Itoa(len(res.Body)))
+		c.Response.WriteHeader(res.Status)
+		if _, werr := c.Response.Write(res.Body); werr != nil && err == nil {
+			err = werr
+		}
+		return err
+	}
+}
+
+// TransformJSON returns a TransformResponse middleware that decodes
+// successful (2xx) JSON responses, transforms the decoded value with fn
+// and encodes the result, e.g. to convert the case of field names.
+// Numbers are decoded as json.Number, so they are encoded unchanged.
+//
+// Example (envelope wrapping):
+//
+//	router.Use(fursy.TransformJSON(func(c *fursy.Context, v any) (any, error) {
+//	    return map[string]any{"data": v, "request_id": c.GetString("request_id")}, nil
+//	}))
+func TransformJSON(fn func(c *Context, v any) (any, error)) HandlerFunc {
+	return TransformResponse(func(c *Context, res *BufferedResponse) error {
+		if res.Status < 200 || res.Status > 299 || len(res.Body) == 0 {
+			return nil
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(res.Body))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			return &EncodeError{ContentType: "application/json", Err: err}
+		}
+
+		v, err := fn(c, v)
+		if err != nil {
+			return err
+		}
+
+		body, err := json.Marshal(v)
+		if err != nil {
+			return &EncodeError{ContentType: "application/json", Err: err}
+		}
+		res.Body = append(body, '\n')
+		return nil
+	})
+}
+
+// transformWriter buffers the response for TransformResponse, or streams
+// it untransformed once passthrough starts.
+type transformWriter struct {
+	http.ResponseWriter
+	config      *TransformConfig
+	status      int
+	buf         *bytes.Buffer
+	passthrough bool
+}
+
+// WriteHeader records the status code. Informational responses are sent
+// immediately; responses without a body (204, 304) and responses that
+// cannot be transformed start passthrough.
+func (w *transformWriter) WriteHeader(code int) {
+	if code < 200 {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if w.status != 0 {
+		return
+	}
+	w.status = code
+
+	h := w.Header()
+	if code == http.StatusNoContent || code == http.StatusNotModified ||
+		h.Get("Content-Encoding") != "" || !w.transformable(h.Get("Content-Type")) {
+		_ = w.startPassthrough()
+	}
+}
+
+// Write buffers b, or streams it in passthrough or once the body would
+// exceed MaxSize.
+func (w *transformWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.passthrough {
+		if w.buf == nil {
+			w.buf = responseBufferPool.Get().(*bytes.Buffer)
+		}
+		if w.buf.Len()+len(b) <= w.config.MaxSize {
+			return w.buf.Write(b)
+		}
+		if err := w.startPassthrough(); err != nil {
+			return 0, err
+		}
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush starts passthrough and flushes the underlying ResponseWriter.
+func (w *transformWriter) Flush() {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if err := w.startPassthrough(); err != nil {
+		return
+	}
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap returns the underlying ResponseWriter (see http.ResponseController).
+func (w *transformWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// startPassthrough sends the status line and the buffered body; the rest
+// of the response is written directly.
+func (w *transformWriter) startPassthrough() error {
+	if w.passthrough {
+		return nil
+	}
+	w.passthrough = true
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.buf == nil || w.buf.Len() == 0 {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+// transformable reports whether responses of contentType are transformed.
+func (w *transformWriter) transformable(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	if len(w.config.ContentTypes) == 0 {
+		return mediaType == MIMEApplicationJSON || strings.HasSuffix(mediaType, "+json")
+	}
+	return slices.Contains(w.config.ContentTypes, mediaType)
+}
+
+// release returns the buffer to the pool. Large buffers are dropped.
+func (w *transformWriter) release() {
+	if w.buf == nil {
+		return
+	}
+	if w.buf.Cap() <= 2*DefaultResponseBufferLimit {
+		w.buf.Reset()
+		responseBufferPool.Put(w.buf)
+	}
+	w.buf = nil
+}
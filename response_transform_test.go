@@ -0,0 +1,148 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestTransformResponse tests transforming buffered JSON responses.
+func TestTransformResponse(t *testing.T) {
+	r := New()
+	r.Use(TransformResponse(func(c *Context, res *BufferedResponse) error {
+		res.Body = append(append([]byte(`{"data":`), bytes.TrimSpace(res.Body)...), '}')
+		res.Header.Set("X-Transformed", "1")
+		return nil
+	}))
+	r.GET("/user", func(c *Context) error {
+		return c.JSON(http.StatusCreated, map[string]string{"name": "alice"})
+	})
+	r.GET("/text", func(c *Context) error {
+		return c.String(http.StatusOK, "plain")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/user", http.NoBody))
+	if w.Code != http.StatusCreated {
+		t.Errorf("status = %d, want 201", w.Code)
+	}
+	if got := w.Body.String(); got != `{"data":{"name":"alice"}}` {
+		t.Errorf("body = %q", got)
+	}
+	if w.Header().Get("Content-Length") != fmt.Sprint(w.Body.Len()) || w.Header().Get("X-Transformed") != "1" {
+		t.Errorf("headers = %v", w.Header())
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/text", http.NoBody))
+	if w.Body.String() != "plain" || w.Header().Get("X-Transformed") != "" {
+		t.Errorf("non-JSON response should pass through, got %q", w.Body.String())
+	}
+}
+
+// TestTransformResponse_Passthrough tests that large and flushed
+// responses are streamed untransformed.
+func TestTransformResponse_Passthrough(t *testing.T) {
+	transformed := false
+	r := New()
+	r.Use(TransformResponseWithConfig(TransformConfig{
+		Transform: func(c *Context, res *BufferedResponse) error {
+			transformed = true
+			return nil
+		},
+		MaxSize: 16,
+	}))
+	r.GET("/large", func(c *Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"text": strings.Repeat("x", 64)})
+	})
+	r.GET("/stream", func(c *Context) error {
+		c.SetHeader("Content-Type", MIMEApplicationJSON)
+		_, _ = c.Response.Write([]byte(`{"n":1}`))
+		if err := c.Flush(); err != nil {
+			return err
+		}
+		_, err := c.Response.Write([]byte(`{"n":2}`))
+		return err
+	})
+
+	for _, path := range []string{"/large", "/stream"} {
+		transformed = false
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+		if transformed {
+			t.Errorf("%s: response should not be transformed", path)
+		}
+		if w.Code != http.StatusOK || w.Body.Len() == 0 {
+			t.Errorf("%s: status = %d, body = %q", path, w.Code, w.Body.String())
+		}
+	}
+}
+
+// TestTransformResponse_ErrorAndBodiless tests that handler errors and
+// bodiless responses are not sent twice or given a Content-Length.
+func TestTransformResponse_ErrorAndBodiless(t *testing.T) {
+	transformed := false
+	r := New()
+	r.Use(TransformResponse(func(c *Context, res *BufferedResponse) error {
+		transformed = true
+		return nil
+	}))
+	r.GET("/error", func(c *Context) error {
+		_ = c.JSON(http.StatusOK, map[string]string{"name": "alice"})
+		return errors.New("audit failed")
+	})
+	r.GET("/not-modified", func(c *Context) error {
+		c.SetHeader("Content-Type", MIMEApplicationJSON)
+		c.Response.WriteHeader(http.StatusNotModified)
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/error", http.NoBody))
+	if w.Code != http.StatusInternalServerError || strings.Contains(w.Body.String(), "alice") {
+		t.Errorf("error: status = %d, body = %q", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/not-modified", http.NoBody))
+	if w.Code != http.StatusNotModified || w.Header().Get("Content-Length") != "" {
+		t.Errorf("304: status = %d, headers = %v", w.Code, w.Header())
+	}
+	if transformed {
+		t.Error("responses should not be transformed")
+	}
+}
+
+// TestTransformJSON tests transforming decoded JSON values.
+func TestTransformJSON(t *testing.T) {
+	r := New()
+	r.Use(TransformJSON(func(c *Context, v any) (any, error) {
+		if c.Query("fail") != "" {
+			return nil, errors.New("transform failed")
+		}
+		return map[string]any{"data": v}, nil
+	}))
+	r.GET("/items", func(c *Context) error {
+		return c.JSON(http.StatusOK, []map[string]any{{"id": 12345678901234567}})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items", http.NoBody))
+	if got := strings.TrimSpace(w.Body.String()); got != `{"data":[{"id":12345678901234567}]}` {
+		t.Errorf("body = %q", got)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items?fail=1", http.NoBody))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("transform error: status = %d, want 500", w.Code)
+	}
+}
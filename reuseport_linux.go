@@ -0,0 +1,26 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"runtime"
+	"syscall"
+)
+
+// soReusePort is SO_REUSEPORT, which the frozen syscall package does not
+// define on Linux.
+var soReusePort = func() int {
+	switch runtime.GOARCH {
+	case "mips", "mipsle", "mips64", "mips64le":
+		return 0x200
+	default:
+		return 0xf
+	}
+}()
+
+// setReusePort sets SO_REUSEPORT on the socket fd.
+func setReusePort(fd uintptr) error {
+	return syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, soReusePort, 1)
+}
@@ -0,0 +1,17 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package fursy
+
+import (
+	"errors"
+	"runtime"
+)
+
+// setReusePort reports that SO_REUSEPORT is not supported.
+func setReusePort(uintptr) error {
+	return errors.New("fursy: SO_REUSEPORT is not supported on " + runtime.GOOS)
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// DeprecatedRoute reports the usage of a deprecated route.
+type DeprecatedRoute struct {
+	// Method is the HTTP method of the route.
+	Method string
+
+	// Path is the route path (e.g., "/v1/users/:id").
+	Path string
+
+	// Sunset is when the route will be removed (zero if not scheduled).
+	Sunset time.Time
+
+	// Calls is the number of requests served since startup.
+	Calls int64
+
+	// LastCall is the time of the most recent request (zero if never called).
+	LastCall time.Time
+}
+
+// deprecatedRouteStats counts requests to a deprecated route.
+type deprecatedRouteStats struct {
+	method   string
+	path     string
+	sunset   time.Time
+	calls    atomic.Int64
+	lastCall atomic.Int64 // Unix nanoseconds.
+}
+
+// DeprecatedRoutes returns usage counts of routes registered with
+// RouteOptions.Deprecated or RouteOptions.Sunset, so API owners can measure
+// remaining traffic before removing them.
+//
+// Example:
+//
+//	router.GET("/debug/deprecated", func(c *fursy.Context) error {
+//	    return c.JSON(200, router.DeprecatedRoutes())
+//	})
+func (r *Router) DeprecatedRoutes() []DeprecatedRoute {
+	routes := make([]DeprecatedRoute, len(r.deprecatedRoutes))
+	for i, s := range r.deprecatedRoutes {
+		routes[i] = DeprecatedRoute{
+			Method: s.method,
+			Path:   s.path,
+			Sunset: s.sunset,
+			Calls:  s.calls.Load(),
+		}
+		if last := s.lastCall.Load(); last != 0 {
+			routes[i].LastCall = time.Unix(0, last)
+		}
+	}
+	return routes
+}
+
+// deprecationPolicy sets deprecation headers and records usage of a deprecated route.
+//
+// Headers:
+//   - Deprecation: true
+//   - Sunset: <HTTP-date> (RFC 8594), if opts.Sunset is set
+//   - Link: <url>; rel="deprecation", if opts.DeprecationLink is set
+//
+// Calls are logged as warnings on the 1st, 10th, 100th, ... request, so
+// usage stays visible without flooding the logs.
+func (r *Router) deprecationPolicy(next HandlerFunc, method, path string, opts *RouteOptions) HandlerFunc {
+	stats := &deprecatedRouteStats{method: method, path: path, sunset: opts.Sunset}
+	r.deprecatedRoutes = append(r.deprecatedRoutes, stats)
+
+	var sunset string
+	if !opts.Sunset.IsZero() {
+		sunset = opts.Sunset.UTC().Format(http.TimeFormat)
+	}
+
+	return func(c *Context) error {
+		h := c.Response.Header()
+		h.Set("Deprecation", "true")
+		if sunset != "" {
+			h.Set("Sunset", sunset)
+		}
+		if opts.DeprecationLink != "" {
+			h.Add("Link", "<"+opts.DeprecationLink+`>; rel="deprecation"`)
+		}
+
+		calls := stats.calls.Add(1)
+		stats.lastCall.Store(time.Now().UnixNano())
+		if isPowerOfTen(calls) {
+			attrs := []any{"method", method, "path", path, "calls", calls}
+			if sunset != "" {
+				attrs = append(attrs, "sunset", sunset)
+			}
+			r.log().Warn("fursy: deprecated route called", attrs...)
+		}
+
+		return next(c)
+	}
+}
+
+// isPowerOfTen reports whether n is 1, 10, 100, ...
+func isPowerOfTen(n int64) bool {
+	for n >= 10 && n%10 == 0 {
+		n /= 10
+	}
+	return n == 1
+}
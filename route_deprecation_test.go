@@ -0,0 +1,141 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRouter_DeprecatedRoute_Headers tests deprecation response headers.
+func TestRouter_DeprecatedRoute_Headers(t *testing.T) {
+	sunset := time.Date(2030, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	router := New()
+	router.SetLogger(slog.New(slog.DiscardHandler))
+	router.HandleWithOptions(http.MethodGet, "/v1/users", func(c *Context) error {
+		return c.String(http.StatusOK, "ok")
+	}, &RouteOptions{
+		Sunset:          sunset,
+		DeprecationLink: "https://example.com/migrate",
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/users", http.NoBody))
+
+	if got := w.Header().Get("Deprecation"); got != "true" {
+		t.Errorf("Deprecation = %q, want true", got)
+	}
+	if got := w.Header().Get("Sunset"); got != "Sun, 30 Jun 2030 00:00:00 GMT" {
+		t.Errorf("Sunset = %q", got)
+	}
+	if got := w.Header().Get("Link"); got != `<https://example.com/migrate>; rel="deprecation"` {
+		t.Errorf("Link = %q", got)
+	}
+
+	routes := router.Routes()
+	if len(routes) != 1 || !routes[0].Deprecated || !routes[0].Sunset.Equal(sunset) {
+		t.Errorf("expected route listing to report deprecation, got %+v", routes)
+	}
+}
+
+// TestRouter_DeprecatedRoute_Usage tests usage counting and logging.
+func TestRouter_DeprecatedRoute_Usage(t *testing.T) {
+	var buf bytes.Buffer
+
+	router := New()
+	router.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	router.HandleWithOptions(http.MethodGet, "/old", func(c *Context) error {
+		return c.String(http.StatusOK, "ok")
+	}, &RouteOptions{Deprecated: true})
+	router.GET("/new", func(c *Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+
+	for range 12 {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/old", http.NoBody))
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/new", http.NoBody))
+	if w.Header().Get("Deprecation") != "" {
+		t.Error("expected no Deprecation header on active route")
+	}
+
+	usage := router.DeprecatedRoutes()
+	if len(usage) != 1 {
+		t.Fatalf("expected 1 deprecated route, got %d", len(usage))
+	}
+	if usage[0].Method != http.MethodGet || usage[0].Path != "/old" || usage[0].Calls != 12 {
+		t.Errorf("unexpected usage %+v", usage[0])
+	}
+	if usage[0].LastCall.IsZero() {
+		t.Error("expected LastCall to be set")
+	}
+
+	// Logged on the 1st and 10th call.
+	logs := buf.String()
+	if n := strings.Count(logs, "deprecated route called"); n != 2 {
+		t.Errorf("expected 2 log lines, got %d:\n%s", n, logs)
+	}
+	if !strings.Contains(logs, "calls=10") || !strings.Contains(logs, "path=/old") {
+		t.Errorf("expected call count and path in logs, got:\n%s", logs)
+	}
+}
+
+// TestRouter_DeprecatedRoute_OpenAPI tests deprecation in the OpenAPI document.
+func TestRouter_DeprecatedRoute_OpenAPI(t *testing.T) {
+	router := New()
+	router.HandleWithOptions(http.MethodGet, "/v1/users", func(c *Context) error {
+		return c.NoContent(http.StatusNoContent)
+	}, &RouteOptions{
+		Sunset:          time.Date(2030, 6, 30, 0, 0, 0, 0, time.UTC),
+		DeprecationLink: "https://example.com/migrate",
+	})
+
+	doc, err := router.GenerateOpenAPI(Info{Title: "API", Version: "1.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	op := doc.Paths["/v1/users"].Get
+	if !op.Deprecated {
+		t.Error("expected operation to be deprecated")
+	}
+	if got := op.Extensions["x-sunset"]; got != "2030-06-30T00:00:00Z" {
+		t.Errorf("x-sunset = %v", got)
+	}
+	if got := op.Extensions["x-deprecation-link"]; got != "https://example.com/migrate" {
+		t.Errorf("x-deprecation-link = %v", got)
+	}
+}
+
+// TestRouter_Validate_SunsetPassed tests that routes past their sunset are reported.
+func TestRouter_Validate_SunsetPassed(t *testing.T) {
+	router := New()
+	router.HandleWithOptions(http.MethodGet, "/v0/users", func(c *Context) error {
+		return c.NoContent(http.StatusNoContent)
+	}, &RouteOptions{Sunset: time.Now().Add(-24 * time.Hour)})
+
+	err := router.Validate()
+	var cfgErr *RouteConfigError
+	if !errors.As(err, &cfgErr) || !strings.Contains(cfgErr.Message, "sunset") {
+		t.Errorf("expected sunset error, got %v", err)
+	}
+}
+
+// TestIsPowerOfTen tests the log threshold helper.
+func TestIsPowerOfTen(t *testing.T) {
+	for n, want := range map[int64]bool{1: true, 2: false, 10: true, 11: false, 100: true, 110: false, 1000: true, 0: false} {
+		if got := isPowerOfTen(n); got != want {
+			t.Errorf("isPowerOfTen(%d) = %v, want %v", n, got, want)
+		}
+	}
+}
@@ -4,7 +4,12 @@
 
 package fursy
 
-import "reflect"
+import (
+	"net/http"
+	"reflect"
+	"slices"
+	"time"
+)
 
 // RouteInfo stores metadata about a registered route.
 // This information is used for OpenAPI generation, documentation, and introspection.
@@ -15,6 +20,9 @@ type RouteInfo struct {
 	// Path is the route path (e.g., "/users/:id").
 	Path string
 
+	// Name is the name of the route (see Context.RouteName).
+	Name string
+
 	// Summary is a short description of the operation.
 	Summary string
 
@@ -30,17 +38,92 @@ type RouteInfo struct {
 	// Deprecated: indicates if this route is deprecated.
 	Deprecated bool
 
+	// Sunset is when the route will be removed (zero if not scheduled).
+	Sunset time.Time
+
+	// DeprecationLink is the URL of the deprecation notice or migration guide.
+	DeprecationLink string
+
 	// RequestType is the Go type for the request body (if any).
 	RequestType reflect.Type
 
 	// ResponseType is the Go type for the response body (if any).
 	ResponseType reflect.Type
 
+	// SuccessStatus is the success status of a type-safe route
+	// (see Status and SuccessStatuser; 0 = 200 OK).
+	SuccessStatus int
+
 	// Parameters stores metadata about path/query/header parameters.
 	Parameters []RouteParameter
 
 	// Responses stores metadata about possible responses.
 	Responses map[int]RouteResponse
+
+	// ResponseHeaders are headers sent with every successful response.
+	ResponseHeaders []RouteHeader
+
+	// Timeout is the maximum duration of the handler (0 = no timeout).
+	Timeout time.Duration
+
+	// MaxBodySize is the maximum request body size in bytes (0 = unlimited).
+	MaxBodySize int64
+
+	// MaxMultipartMemory is the memory limit for parsing multipart forms
+	// (0 = router limit).
+	MaxMultipartMemory int64
+
+	// RateLimit is the per-route rate limit (nil = unlimited).
+	RateLimit *RateLimitPolicy
+
+	// Cost is the per-route request cost limit (nil = unlimited).
+	Cost *CostPolicy
+
+	// RequireAuth indicates the route requires an authenticated request.
+	RequireAuth bool
+
+	// Public indicates the route is documented as not requiring authentication.
+	Public bool
+
+	// RequireIfMatch indicates the route requires an If-Match header.
+	RequireIfMatch bool
+
+	// Fields are the fields clients can select with the fields query
+	// parameter (nil = no field selection).
+	Fields []string
+
+	// Localize lists the localized fields of the responses (nil = none).
+	Localize *Localization
+
+	// Match selects the requests served by the route among routes sharing
+	// its method and path (nil = all requests).
+	Match *Matcher
+
+	// ExternalDocs references external documentation of the operation.
+	ExternalDocs *ExternalDocs
+
+	// CodeSamples are the code samples of the operation.
+	CodeSamples []CodeSample
+
+	// Extensions are the OpenAPI extensions of the operation.
+	Extensions map[string]any
+
+	// Allow lists the methods allowed on the route path, sorted: the
+	// methods of the routes registered with the same path, plus OPTIONS
+	// when automatic OPTIONS responses are enabled. This is the Allow
+	// header of OPTIONS and 405 responses. Set by Router.Routes.
+	Allow []string
+
+	// Middleware lists the names of the middleware that run for the route:
+	// router middleware first, then group middleware (see Named and
+	// MiddlewareName). Set by Router.Routes.
+	Middleware []string
+
+	// groupMiddleware is the group middleware of the route.
+	groupMiddleware []HandlerFunc
+
+	// bindType is the request type bound by a type-safe route (nil = none).
+	bindType reflect.Type
 }
 
 // RouteParameter stores metadata about a route parameter.
@@ -59,6 +142,13 @@ type RouteParameter struct {
 
 	// Type is the Go type of the parameter.
 	Type reflect.Type
+
+	// Style is the OpenAPI serialization style of the parameter
+	// (e.g., "form", "deepObject"; "" = default of its location).
+	Style string
+
+	// Explode is the OpenAPI explode flag of Style (ignored without Style).
+	Explode bool
 }
 
 // RouteResponse stores metadata about a response.
@@ -71,10 +161,34 @@ type RouteResponse struct {
 
 	// ContentType is the media type (e.g., "application/json").
 	ContentType string
+
+	// Headers are headers sent with this response (e.g., Location for 201),
+	// in addition to RouteOptions.ResponseHeaders.
+	Headers []RouteHeader
+}
+
+// RouteHeader stores metadata about a response header.
+type RouteHeader struct {
+	// Name of the header (e.g., "X-RateLimit-Remaining").
+	Name string
+
+	// Description of the header.
+	Description string
+
+	// Required indicates the header is always sent.
+	Required bool
+
+	// Type is the Go type of the header value (nil = string).
+	Type reflect.Type
 }
 
 // RouteOptions allows configuring route metadata when registering a route.
 type RouteOptions struct {
+	// Name is the name of the route, returned by Context.RouteName to
+	// label logs, metrics and traces.
+	// Default: OperationID
+	Name string
+
 	// Summary is a short description of the operation.
 	Summary string
 
@@ -88,11 +202,173 @@ type RouteOptions struct {
 	OperationID string
 
 	// Deprecated: indicates if this route is deprecated.
+	// Responses carry a "Deprecation: true" header and usage is logged
+	// and counted (see Router.DeprecatedRoutes).
 	Deprecated bool
 
+	// Sunset is when the route will be removed, sent in the Sunset header (RFC 8594).
+	// Setting Sunset implies Deprecated.
+	// Default: zero (no Sunset header)
+	Sunset time.Time
+
+	// DeprecationLink is the URL of the deprecation notice or migration guide,
+	// sent as a Link header with rel="deprecation".
+	// Default: "" (no Link header)
+	DeprecationLink string
+
 	// Parameters stores metadata about path/query/header parameters.
 	Parameters []RouteParameter
 
 	// Responses stores metadata about possible responses.
 	Responses map[int]RouteResponse
+
+	// ResponseHeaders declares headers sent with every successful (2xx)
+	// response, e.g. rate limit headers. They are documented in OpenAPI.
+	// Default: nil
+	ResponseHeaders []RouteHeader
+
+	// Timeout is the maximum duration of the handler.
+	// The request context gets a deadline; if the handler returns an error
+	// after the deadline, a 503 Service Unavailable problem is sent.
+	// Default: 0 (no timeout)
+	Timeout time.Duration
+
+	// MaxBodySize is the maximum request body size in bytes.
+	// Larger requests are rejected with 413 Content Too Large.
+	// Default: 0 (unlimited)
+	MaxBodySize int64
+
+	// MaxMultipartMemory is the memory limit in bytes for parsing multipart
+	// forms of the route, overriding the router's (Options.MaxMultipartMemory).
+	// Larger files are stored in temporary files; forms whose non-file
+	// values exceed the limit are rejected with 413 Content Too Large
+	// (see Context.ParseForm).
+	// Default: 0 (router limit)
+	MaxMultipartMemory int64
+
+	// RateLimit is a per-route rate limit.
+	// Requests over the limit are rejected with 429 Too Many Requests.
+	// Default: nil (unlimited)
+	RateLimit *RateLimitPolicy
+
+	// Cost rejects requests whose estimated cost (by default, page size ×
+	// included relations) exceeds a maximum with 422 Unprocessable Entity.
+	// The maximum and the 422 response are documented in OpenAPI.
+	// Default: nil (unlimited)
+	Cost *CostPolicy
+
+	// RequireAuth rejects unauthenticated requests with 401 Unauthorized.
+	// Authentication is checked after middleware using the router's
+	// AuthChecker (see Router.SetAuthChecker).
+	// Default: false
+	RequireAuth bool
+
+	// Public documents the route as not requiring authentication: its
+	// OpenAPI operation allows anonymous access (security: [{}]), overriding
+	// the API security requirements. Router.Validate reports public routes
+	// run by authentication middleware.
+	// Default: false
+	Public bool
+
+	// RequireIfMatch rejects requests without an If-Match header with
+	// 428 Precondition Required, for routes using Context.RequireIfMatch.
+	// The If-Match header, the ETag response header and the 412 and 428
+	// responses are documented in OpenAPI.
+	// Default: false
+	RequireIfMatch bool
+
+	// Fields enables sparse fieldsets: clients select the fields of JSON
+	// responses with the fields query parameter (e.g., ?fields=id,address.city),
+	// among these dotted paths. Selecting a field selects its subfields;
+	// requests for other fields are rejected with 400 Bad Request, so
+	// fields outside the allowlist cannot be probed, and responses without
+	// the parameter include the allowlisted fields only. The parameter is
+	// documented in OpenAPI. Other formats (XML, custom renderers) are not
+	// pruned: use Mask for fields that must never be sent.
+	// Default: nil (the fields parameter is ignored)
+	Fields []string
+
+	// Localize formats time and number fields of JSON responses for the
+	// locale and time zone of the request (see Localization).
+	// Default: nil (responses are not localized)
+	Localize *Localization
+
+	// Match restricts the route to the requests accepted by the matcher,
+	// so several routes can share a method and path (see Router.HandleMatch).
+	// Content type matchers are documented as request body media types,
+	// header matchers as header parameters.
+	// Default: nil (all requests)
+	Match *Matcher
+
+	// ExternalDocs references external documentation of the operation
+	// (e.g., a guide), documented in OpenAPI.
+	// Default: nil
+	ExternalDocs *ExternalDocs
+
+	// CodeSamples are documented in the x-codeSamples extension of the
+	// operation, rendered by Redoc and other doc renderers.
+	// Default: nil
+	CodeSamples []CodeSample
+
+	// Extensions are OpenAPI specification extensions of the operation,
+	// e.g. gateway settings such as "x-kong-plugin-cors" or
+	// "x-google-backend". Keys must start with "x-"; they override the
+	// extensions documenting the route policies.
+	// Default: nil
+	Extensions map[string]any
+}
+
+// Routes returns metadata of the registered routes (including group
+// routes), in registration order.
+//
+// Example:
+//
+//	for _, route := range router.Routes() {
+//	    if route.Deprecated {
+//	        log.Printf("deprecated: %s %s (sunset %s)", route.Method, route.Path, route.Sunset)
+//	    }
+//	}
+//
+// Example (debug endpoint listing the middleware chain of each route):
+//
+//	router.GET("/debug/routes", func(c *fursy.Context) error {
+//	    routes := map[string][]string{}
+//	    for _, route := range router.Routes() {
+//	        routes[route.Method+" "+route.Path] = route.Middleware
+//	    }
+//	    return c.JSON(200, routes)
+//	})
+//
+// Example (gateway configuration):
+//
+//	for _, route := range router.Routes() {
+//	    gateway.AllowMethods(route.Path, route.Allow)
+//	}
+func (r *Router) Routes() []RouteInfo {
+	routes := slices.Clone(r.routeList())
+	global := middlewareNames(r.middleware)
+	allow := r.allowSets(routes)
+	for i := range routes {
+		routes[i].Middleware = append(slices.Clip(global), middlewareNames(routes[i].groupMiddleware)...)
+		routes[i].Allow = allow[routes[i].Path]
+	}
+	return routes
+}
+
+// allowSets returns the sorted allowed methods of each path of routes.
+func (r *Router) allowSets(routes []RouteInfo) map[string][]string {
+	sets := make(map[string][]string)
+	for _, route := range routes {
+		if !slices.Contains(sets[route.Path], route.Method) {
+			sets[route.Path] = append(sets[route.Path], route.Method)
+		}
+	}
+	for path, methods := range sets {
+		if r.handleOPTIONS && !slices.Contains(methods, http.MethodOptions) {
+			methods = append(methods, http.MethodOptions)
+		}
+		slices.Sort(methods)
+		sets[path] = methods
+	}
+	return sets
 }
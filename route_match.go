@@ -0,0 +1,92 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// anyMethods are the methods registered by Router.Any and RouteGroup.Any.
+var anyMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+	http.MethodConnect,
+	http.MethodTrace,
+}
+
+// Any registers a handler for all standard HTTP methods (GET, HEAD, POST,
+// PUT, PATCH, DELETE, OPTIONS, CONNECT and TRACE) on the path.
+//
+// Each method gets its own route (see Router.Routes), so 405 responses
+// and the OpenAPI document list them like individually registered routes.
+//
+// Example:
+//
+//	router.Any("/webhook", handleWebhook)
+func (r *Router) Any(path string, handler HandlerFunc) {
+	r.Match(anyMethods, path, handler)
+}
+
+// Match registers a handler for each of methods on the path.
+// Methods are upper-cased and duplicates are ignored.
+//
+// Example:
+//
+//	router.Match([]string{"GET", "HEAD"}, "/health", health)
+func (r *Router) Match(methods []string, path string, handler HandlerFunc) {
+	for _, method := range matchMethods(methods) {
+		r.Handle(method, path, handler)
+	}
+}
+
+// Any registers a handler for all standard HTTP methods on the group path
+// (see Router.Any).
+//
+// Example:
+//
+//	legacy := router.Group("/legacy", auth)
+//	legacy.Any("/*path", fursy.WrapH(legacyMux))
+func (g *RouteGroup) Any(path string, handler HandlerFunc) {
+	g.Match(anyMethods, path, handler)
+}
+
+// Match registers a handler for each of methods on the group path
+// (see Router.Match).
+//
+// Example:
+//
+//	api := router.Group("/api")
+//	api.Match([]string{"PUT", "PATCH"}, "/users/:id", updateUser)
+func (g *RouteGroup) Match(methods []string, path string, handler HandlerFunc) {
+	for _, method := range matchMethods(methods) {
+		g.Handle(method, path, handler)
+	}
+}
+
+// matchMethods normalizes the methods of Match.
+func matchMethods(methods []string) []string {
+	if len(methods) == 0 {
+		panic("fursy: Match requires at least one method")
+	}
+
+	normalized := make([]string, 0, len(methods))
+	for _, method := range methods {
+		method = strings.ToUpper(strings.TrimSpace(method))
+		if method == "" {
+			panic("fursy: HTTP method cannot be empty")
+		}
+		if !slices.Contains(normalized, method) {
+			normalized = append(normalized, method)
+		}
+	}
+	return normalized
+}
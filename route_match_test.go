@@ -0,0 +1,127 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestRouter_Any tests registering a handler for all methods.
+func TestRouter_Any(t *testing.T) {
+	r := New()
+	r.Any("/webhook", func(c *Context) error {
+		return c.String(http.StatusOK, c.Request.Method)
+	})
+	api := r.Group("/api")
+	api.Any("/echo", func(c *Context) error {
+		return c.String(http.StatusOK, c.Request.Method)
+	})
+
+	for _, path := range []string{"/webhook", "/api/echo"} {
+		for _, method := range anyMethods {
+			req := httptest.NewRequest(method, path, http.NoBody)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				t.Errorf("%s %s: status = %d, want %d", method, path, w.Code, http.StatusOK)
+			}
+		}
+	}
+
+	if got := len(r.Routes()); got != 2*len(anyMethods) {
+		t.Errorf("expected %d routes, got %d", 2*len(anyMethods), got)
+	}
+}
+
+// TestRouter_Match tests registering a handler for selected methods.
+func TestRouter_Match(t *testing.T) {
+	r := New()
+	r.Match([]string{"get", "HEAD", "GET"}, "/health", func(c *Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+	r.Group("/api").Match([]string{http.MethodPut, http.MethodPatch}, "/users/:id", func(c *Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		method, path string
+		wantCode     int
+		wantAllow    string
+	}{
+		{http.MethodGet, "/health", http.StatusNoContent, ""},
+		{http.MethodHead, "/health", http.StatusNoContent, ""},
+		{http.MethodPost, "/health", http.StatusMethodNotAllowed, "GET, HEAD, OPTIONS"},
+		{http.MethodPatch, "/api/users/1", http.StatusNoContent, ""},
+		{http.MethodDelete, "/api/users/1", http.StatusMethodNotAllowed, "OPTIONS, PATCH, PUT"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != tt.wantCode {
+			t.Errorf("%s %s: status = %d, want %d", tt.method, tt.path, w.Code, tt.wantCode)
+		}
+		if got := w.Header().Get("Allow"); got != tt.wantAllow {
+			t.Errorf("%s %s: Allow = %q, want %q", tt.method, tt.path, got, tt.wantAllow)
+		}
+	}
+
+	var methods []string
+	for _, route := range r.Routes() {
+		methods = append(methods, route.Method+" "+route.Path)
+	}
+	want := []string{"GET /health", "HEAD /health", "PUT /api/users/:id", "PATCH /api/users/:id"}
+	if len(methods) != len(want) {
+		t.Fatalf("routes = %v, want %v", methods, want)
+	}
+	for i := range want {
+		if methods[i] != want[i] {
+			t.Errorf("route %d = %q, want %q", i, methods[i], want[i])
+		}
+	}
+
+	doc, err := r.GenerateOpenAPI(Info{Title: "Test", Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI failed: %v", err)
+	}
+	health := doc.Paths["/health"]
+	if health.Get == nil || health.Head == nil || health.Post != nil {
+		t.Errorf("expected GET and HEAD operations for /health, got %+v", health)
+	}
+}
+
+// TestRouter_Match_Panics tests invalid method lists.
+func TestRouter_Match_Panics(t *testing.T) {
+	for name, methods := range map[string][]string{
+		"no methods":   nil,
+		"empty method": {"GET", " "},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			New().Match(methods, "/", func(c *Context) error { return nil })
+		})
+	}
+}
+
+// TestOpenAPI_Trace tests documenting TRACE routes registered with Any.
+func TestOpenAPI_Trace(t *testing.T) {
+	r := New()
+	r.Any("/echo", func(c *Context) error { return nil })
+
+	doc, err := r.GenerateOpenAPI(Info{Title: "Test", Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI failed: %v", err)
+	}
+	item := doc.Paths["/echo"]
+	if item.Trace == nil || item.Options == nil || item.Get == nil {
+		t.Errorf("expected TRACE, OPTIONS and GET operations, got %+v", item)
+	}
+}
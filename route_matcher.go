@@ -0,0 +1,206 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Matcher selects one of several handlers registered for the same method
+// and path, by request Content-Type, header value or a custom predicate.
+//
+// Create matchers with MatchContentType, MatchHeader and MatchFunc, and
+// register the handlers with Router.HandleMatch (or RouteOptions.Match).
+// Handlers are tried in registration order; the first matching one serves
+// the request.
+type Matcher struct {
+	// contentTypes are the matched request media types.
+	contentTypes []string
+
+	// header and values are the matched request header and its values.
+	header string
+	values []string
+
+	// fn is the custom predicate.
+	fn func(c *Context) bool
+}
+
+// MatchContentType returns a matcher for requests whose Content-Type has
+// one of the media types (parameters such as charset are ignored).
+// The media types are documented as request body content in OpenAPI.
+//
+// Example:
+//
+//	router.HandleMatch(http.MethodPost, "/ingest", fursy.MatchContentType("application/json"), ingestJSON)
+//	router.HandleMatch(http.MethodPost, "/ingest", fursy.MatchContentType("text/csv"), ingestCSV)
+func MatchContentType(mediaTypes ...string) *Matcher {
+	if len(mediaTypes) == 0 {
+		panic("fursy: content type matcher requires at least one media type")
+	}
+	types := make([]string, len(mediaTypes))
+	for i, mediaType := range mediaTypes {
+		mt, _, err := mime.ParseMediaType(mediaType)
+		if err != nil {
+			panic("fursy: invalid matcher media type: " + mediaType)
+		}
+		types[i] = mt
+	}
+	return &Matcher{contentTypes: types}
+}
+
+// MatchHeader returns a matcher for requests whose header name has one of
+// the values. Without values, any non-empty value matches.
+// The header is documented as a parameter in OpenAPI.
+//
+// Example:
+//
+//	router.HandleMatch(http.MethodGet, "/report", fursy.MatchHeader("X-API-Version", "2"), reportV2)
+func MatchHeader(name string, values ...string) *Matcher {
+	if name == "" {
+		panic("fursy: header matcher requires a header name")
+	}
+	return &Matcher{header: http.CanonicalHeaderKey(name), values: values}
+}
+
+// MatchFunc returns a matcher for requests accepted by fn.
+//
+// Example:
+//
+//	router.HandleMatch(http.MethodPost, "/upload", fursy.MatchFunc(func(c *fursy.Context) bool {
+//	    return c.Request.ContentLength > 10<<20
+//	}), uploadLarge)
+func MatchFunc(fn func(c *Context) bool) *Matcher {
+	if fn == nil {
+		panic("fursy: matcher function cannot be nil")
+	}
+	return &Matcher{fn: fn}
+}
+
+// matches reports whether the request matches.
+func (m *Matcher) matches(c *Context) bool {
+	switch {
+	case m.contentTypes != nil:
+		mt, _, err := mime.ParseMediaType(c.Request.Header.Get("Content-Type"))
+		return err == nil && slices.Contains(m.contentTypes, strings.ToLower(mt))
+	case m.header != "":
+		value := c.Request.Header.Get(m.header)
+		if len(m.values) == 0 {
+			return value != ""
+		}
+		return slices.Contains(m.values, value)
+	default:
+		return m.fn(c)
+	}
+}
+
+// HandleMatch registers a handler for method and path that serves only
+// the requests accepted by match. Several handlers can share a method and
+// path, each with its own matcher; all of them must have a matcher.
+//
+// Requests matching none of the handlers get 415 Unsupported Media Type
+// if a content type matcher is registered, 404 Not Found otherwise.
+// In OpenAPI, the handlers are documented as a single operation with the
+// media types of all content type matchers.
+//
+// Example:
+//
+//	router.HandleMatch(http.MethodPost, "/ingest", fursy.MatchContentType("application/json"), ingestJSON)
+//	router.HandleMatch(http.MethodPost, "/ingest", fursy.MatchContentType("text/csv"), ingestCSV)
+func (r *Router) HandleMatch(method, path string, match *Matcher, handler HandlerFunc) {
+	r.HandleWithOptions(method, path, handler, &RouteOptions{Match: match})
+}
+
+// HandleMatch registers a handler in the group that serves only the
+// requests accepted by match. See Router.HandleMatch.
+func (g *RouteGroup) HandleMatch(method, path string, match *Matcher, handler HandlerFunc) {
+	g.router.handleWithGroupMiddleware(g, method, g.prefix+path, g.combineMiddleware(handler), match, nil, nil)
+}
+
+// matchedRoute dispatches the requests of a method and path to the
+// handler of the first matching matcher.
+type matchedRoute struct {
+	entry    *routeEntry
+	matchers []*Matcher
+	handlers []HandlerFunc
+}
+
+// serve runs the handler of the first matching matcher.
+func (mr *matchedRoute) serve(c *Context) error {
+	for _, m := range mr.matchers {
+		switch {
+		case m.contentTypes != nil:
+			c.AddVary("Content-Type")
+		case m.header != "":
+			c.AddVary(m.header)
+		}
+	}
+
+	for i, m := range mr.matchers {
+		if m.matches(c) {
+			return mr.handlers[i](c)
+		}
+	}
+
+	var types []string
+	for _, m := range mr.matchers {
+		types = append(types, m.contentTypes...)
+	}
+	if len(types) > 0 {
+		return c.Problem(NewProblem(http.StatusUnsupportedMediaType, "Unsupported Media Type",
+			"Content-Type must be one of: "+strings.Join(types, ", ")))
+	}
+	return c.Problem(NotFound(""))
+}
+
+// routeEntry is the value stored in the radix tree for a route.
+type routeEntry struct {
+	handler HandlerFunc
+	pattern string
+	name    string
+}
+
+// insertRoute registers the handler of a route (see Router.Remove for the
+// route table). Routes with a matcher share a matchedRoute, named after
+// the first of them with a name, which is copied when a route is added so
+// requests being served see a consistent one. It returns the function
+// that replaces the registered handler (see Router.Override).
+// It must be called with routeMu held.
+func (r *Router) insertRoute(method, path, name string, match *Matcher, handler HandlerFunc) func(HandlerFunc) {
+	if match == nil {
+		entry := &routeEntry{handler: handler, pattern: path, name: name}
+		r.addTableEntry(method, path, entry)
+		return func(h HandlerFunc) { entry.handler = h }
+	}
+
+	key := method + " " + path
+	old := r.matchedRoutes[key]
+	mr := &matchedRoute{entry: &routeEntry{pattern: path, name: name}}
+	mr.entry.handler = mr.serve
+	if old != nil {
+		if old.entry.name != "" {
+			mr.entry.name = old.entry.name
+		}
+		mr.matchers = slices.Clone(old.matchers)
+		mr.handlers = slices.Clone(old.handlers)
+	}
+	mr.matchers = append(mr.matchers, match)
+	mr.handlers = append(mr.handlers, handler)
+
+	if old == nil {
+		r.addTableEntry(method, path, mr.entry)
+	} else {
+		r.replaceTableEntry(method, old.entry, mr.entry)
+	}
+	if r.matchedRoutes == nil {
+		r.matchedRoutes = make(map[string]*matchedRoute)
+	}
+	r.matchedRoutes[key] = mr
+
+	i := len(mr.handlers) - 1
+	return func(h HandlerFunc) { r.matchedRoutes[key].handlers[i] = h }
+}
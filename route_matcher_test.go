@@ -0,0 +1,195 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newIngestRouter() *Router {
+	router := New()
+	router.HandleMatch(http.MethodPost, "/ingest", MatchContentType("application/json"),
+		func(c *Context) error { return c.String(http.StatusOK, "json") })
+	router.HandleMatch(http.MethodPost, "/ingest", MatchContentType("text/csv", "text/tab-separated-values"),
+		func(c *Context) error { return c.String(http.StatusOK, "csv") })
+	return router
+}
+
+func TestHandleMatch_ContentType(t *testing.T) {
+	router := newIngestRouter()
+
+	tests := []struct {
+		contentType string
+		wantStatus  int
+		wantBody    string
+	}{
+		{"application/json", http.StatusOK, "json"},
+		{"application/json; charset=utf-8", http.StatusOK, "json"},
+		{"TEXT/CSV", http.StatusOK, "csv"},
+		{"text/tab-separated-values", http.StatusOK, "csv"},
+		{"application/xml", http.StatusUnsupportedMediaType, ""},
+		{"", http.StatusUnsupportedMediaType, ""},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/ingest", strings.NewReader("x"))
+		if tt.contentType != "" {
+			req.Header.Set("Content-Type", tt.contentType)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("%q: expected status %d, got %d", tt.contentType, tt.wantStatus, w.Code)
+		}
+		if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+			t.Errorf("%q: expected body %q, got %q", tt.contentType, tt.wantBody, w.Body.String())
+		}
+		if got := w.Header().Get("Vary"); got != "Content-Type" {
+			t.Errorf("%q: expected Vary Content-Type, got %q", tt.contentType, got)
+		}
+	}
+}
+
+func TestHandleMatch_HeaderAndFunc(t *testing.T) {
+	router := New()
+	router.HandleMatch(http.MethodGet, "/report", MatchHeader("x-api-version", "2", "3"),
+		func(c *Context) error { return c.String(http.StatusOK, "v2") })
+	router.HandleMatch(http.MethodGet, "/report", MatchFunc(func(c *Context) bool { return c.Query("format") == "pdf" }),
+		func(c *Context) error { return c.String(http.StatusOK, "pdf") })
+
+	tests := []struct {
+		url, version string
+		wantStatus   int
+		wantBody     string
+	}{
+		{"/report", "2", http.StatusOK, "v2"},
+		{"/report", "3", http.StatusOK, "v2"},
+		{"/report?format=pdf", "", http.StatusOK, "pdf"},
+		{"/report?format=pdf", "2", http.StatusOK, "v2"}, // First match wins.
+		{"/report", "1", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.url, http.NoBody)
+		if tt.version != "" {
+			req.Header.Set("X-API-Version", tt.version)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != tt.wantStatus || (tt.wantBody != "" && w.Body.String() != tt.wantBody) {
+			t.Errorf("%s (version %q): got %d %q, want %d %q", tt.url, tt.version, w.Code, w.Body.String(), tt.wantStatus, tt.wantBody)
+		}
+	}
+}
+
+func TestHandleMatch_Group(t *testing.T) {
+	router := New()
+	api := router.Group("/api", func(c *Context) error {
+		c.SetHeader("X-Group", "api")
+		return c.Next()
+	})
+	api.HandleMatch(http.MethodPost, "/ingest", MatchContentType("text/csv"),
+		func(c *Context) error { return c.String(http.StatusOK, "csv") })
+
+	req := httptest.NewRequest(http.MethodPost, "/api/ingest", strings.NewReader("a,b"))
+	req.Header.Set("Content-Type", "text/csv")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Body.String() != "csv" || w.Header().Get("X-Group") != "api" {
+		t.Errorf("got %q with X-Group %q", w.Body.String(), w.Header().Get("X-Group"))
+	}
+}
+
+func TestHandleMatch_ConflictsWithPlainRoute(t *testing.T) {
+	tests := []struct {
+		name     string
+		register func(r *Router)
+	}{
+		{"plain then matched", func(r *Router) {
+			r.POST("/ingest", func(c *Context) error { return nil })
+			r.HandleMatch(http.MethodPost, "/ingest", MatchContentType("text/csv"), func(c *Context) error { return nil })
+		}},
+		{"matched then plain", func(r *Router) {
+			r.HandleMatch(http.MethodPost, "/ingest", MatchContentType("text/csv"), func(c *Context) error { return nil })
+			r.POST("/ingest", func(c *Context) error { return nil })
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			tt.register(New())
+		})
+	}
+}
+
+func TestMatcher_InvalidArguments(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"no media types": func() { MatchContentType() },
+		"bad media type": func() { MatchContentType("not a media type") },
+		"no header name": func() { MatchHeader("") },
+		"nil func":       func() { MatchFunc(nil) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
+
+func TestHandleMatch_OpenAPI(t *testing.T) {
+	router := New()
+	router.HandleWithOptions(http.MethodPost, "/ingest", func(c *Context) error { return nil }, &RouteOptions{
+		Summary: "Ingest records",
+		Match:   MatchContentType("application/json"),
+	})
+	router.HandleMatch(http.MethodPost, "/ingest", MatchContentType("text/csv"), func(c *Context) error { return nil })
+	router.HandleMatch(http.MethodGet, "/report", MatchHeader("X-API-Version", "1"), func(c *Context) error { return nil })
+	router.HandleMatch(http.MethodGet, "/report", MatchHeader("X-API-Version", "2"), func(c *Context) error { return nil })
+
+	doc, err := router.GenerateOpenAPI(Info{Title: "Test", Version: "1.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ingest := doc.Paths["/ingest"].Post
+	if ingest == nil || ingest.Summary != "Ingest records" {
+		t.Fatalf("expected merged ingest operation, got %+v", ingest)
+	}
+	if ingest.RequestBody == nil || len(ingest.RequestBody.Content) != 2 {
+		t.Fatalf("expected 2 request media types, got %+v", ingest.RequestBody)
+	}
+	for _, mt := range []string{"application/json", "text/csv"} {
+		if _, ok := ingest.RequestBody.Content[mt]; !ok {
+			t.Errorf("media type %s not documented", mt)
+		}
+	}
+	if _, ok := ingest.Responses["415"]; !ok {
+		t.Error("415 response not documented")
+	}
+
+	report := doc.Paths["/report"].Get
+	if report == nil || len(report.Parameters) != 1 {
+		t.Fatalf("expected one header parameter, got %+v", report)
+	}
+	param := report.Parameters[0]
+	if param.Name != "X-Api-Version" || param.In != "header" || !param.Required || len(param.Schema.Enum) != 2 {
+		t.Errorf("unexpected header parameter %+v", param)
+	}
+}
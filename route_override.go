@@ -0,0 +1,84 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+// routeSlot holds the handler of a registered route, so that it can be
+// replaced after registration (see Router.Override).
+type routeSlot struct {
+	// handler is the current route handler.
+	handler HandlerFunc
+
+	// set installs a new handler in the route chain. If nil, the chain
+	// calls serve, which reads handler.
+	set func(HandlerFunc)
+}
+
+// serve calls the current route handler.
+func (s *routeSlot) serve(c *Context) error {
+	return s.handler(c)
+}
+
+// replace makes h the route handler.
+func (s *routeSlot) replace(h HandlerFunc) {
+	s.handler = h
+	if s.set != nil {
+		s.set(h)
+	}
+}
+
+// addRouteSlot records the slot of the route registered for method and path.
+func (r *Router) addRouteSlot(method, path string, slot *routeSlot) {
+	if r.routeSlots == nil {
+		r.routeSlots = make(map[string][]*routeSlot)
+	}
+	key := method + " " + path
+	r.routeSlots[key] = append(r.routeSlots[key], slot)
+}
+
+// Override replaces the handler of the route registered for method and
+// path (the full path for group routes). Router and group middleware and
+// the route options (timeout, rate limit, etc.) still apply, so
+// integration tests can stub a single route while exercising the rest
+// of the application unchanged. Routes registered with a matcher (see
+// HandleMatch) are all replaced.
+//
+// Like route registration, Override must be called before serving
+// requests. It panics if no route is registered for method and path.
+//
+// Example:
+//
+//	app := NewApp() // Registers the real routes and middleware.
+//	app.Override(http.MethodPost, "/payments", func(c *fursy.Context) error {
+//	    return c.JSON(http.StatusCreated, map[string]string{"id": "pay_test"})
+//	})
+func (r *Router) Override(method, path string, handler HandlerFunc) {
+	if handler == nil {
+		panic("fursy: handler cannot be nil")
+	}
+	r.Decorate(method, path, func(HandlerFunc) HandlerFunc { return handler })
+}
+
+// Decorate wraps the handler of the route registered for method and path
+// with decorator, e.g. to record or delay its calls in tests. See
+// Override for the middleware and options that still apply.
+//
+// Example:
+//
+//	var calls atomic.Int64
+//	router.Decorate(http.MethodGet, "/users/:id", func(next fursy.HandlerFunc) fursy.HandlerFunc {
+//	    return func(c *fursy.Context) error {
+//	        calls.Add(1)
+//	        return next(c)
+//	    }
+//	})
+func (r *Router) Decorate(method, path string, decorator func(HandlerFunc) HandlerFunc) {
+	slots := r.routeSlots[method+" "+path]
+	if len(slots) == 0 {
+		panic("fursy: no route registered for " + method + " " + path)
+	}
+	for _, slot := range slots {
+		slot.replace(decorator(slot.handler))
+	}
+}
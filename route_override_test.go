@@ -0,0 +1,107 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestRouter_Override tests replacing route handlers while middleware
+// and route options still apply.
+func TestRouter_Override(t *testing.T) {
+	r := New()
+	r.Use(func(c *Context) error {
+		c.SetHeader("X-Router", "1")
+		return c.Next()
+	})
+	api := r.Group("/api")
+	api.Use(func(c *Context) error {
+		c.SetHeader("X-Group", "1")
+		return c.Next()
+	})
+
+	real := func(c *Context) error { return c.String(http.StatusOK, "real") }
+	r.GET("/plain", real)
+	api.GET("/users/:id", real)
+	r.HandleWithOptions(http.MethodGet, "/private", real, &RouteOptions{RequireAuth: true})
+	r.GET("/other", real)
+
+	stub := func(c *Context) error { return c.String(http.StatusOK, "stub "+c.Param("id")) }
+	r.Override(http.MethodGet, "/plain", stub)
+	r.Override(http.MethodGet, "/api/users/:id", stub)
+	r.Override(http.MethodGet, "/private", stub)
+
+	tests := []struct {
+		path      string
+		wantCode  int
+		wantBody  string
+		wantGroup bool
+	}{
+		{"/plain", http.StatusOK, "stub ", false},
+		{"/api/users/7", http.StatusOK, "stub 7", true},
+		{"/private", http.StatusUnauthorized, "", false},
+		{"/other", http.StatusOK, "real", false},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+		if w.Code != tt.wantCode {
+			t.Errorf("%s: status = %d, want %d", tt.path, w.Code, tt.wantCode)
+		}
+		if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+			t.Errorf("%s: body = %q, want %q", tt.path, w.Body.String(), tt.wantBody)
+		}
+		if w.Header().Get("X-Router") != "1" {
+			t.Errorf("%s: router middleware did not run", tt.path)
+		}
+		if got := w.Header().Get("X-Group") == "1"; got != tt.wantGroup {
+			t.Errorf("%s: group middleware ran = %v, want %v", tt.path, got, tt.wantGroup)
+		}
+	}
+
+	r.SetAuthChecker(func(c *Context) bool { return true })
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/private", http.NoBody))
+	if w.Body.String() != "stub " {
+		t.Errorf("/private with auth: body = %q, want stub", w.Body.String())
+	}
+}
+
+// TestRouter_Decorate tests wrapping route handlers.
+func TestRouter_Decorate(t *testing.T) {
+	r := New()
+	r.HandleMatch(http.MethodPost, "/ingest", MatchContentType("application/json"), func(c *Context) error {
+		return c.String(http.StatusOK, "json")
+	})
+	r.HandleMatch(http.MethodPost, "/ingest", MatchContentType("text/csv"), func(c *Context) error {
+		return c.String(http.StatusOK, "csv")
+	})
+
+	calls := 0
+	r.Decorate(http.MethodPost, "/ingest", func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			calls++
+			return next(c)
+		}
+	})
+
+	for _, ct := range []string{"application/json", "text/csv"} {
+		req := httptest.NewRequest(http.MethodPost, "/ingest", http.NoBody)
+		req.Header.Set("Content-Type", ct)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	if calls != 2 {
+		t.Errorf("decorated calls = %d, want 2", calls)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for unknown route")
+		}
+	}()
+	r.Override(http.MethodGet, "/missing", func(c *Context) error { return nil })
+}
@@ -0,0 +1,405 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultRouteRateLimitMaxKeys is the default maximum number of keys tracked per route.
+const defaultRouteRateLimitMaxKeys = 10000
+
+// RateLimitPolicy declares a per-route rate limit (token bucket).
+//
+// It is set via RouteOptions.RateLimit and enforced by the router.
+// Each route has its own limiter store, independent of the
+// middleware.RateLimit middleware.
+//
+// Example:
+//
+//	router.HandleWithOptions(http.MethodPost, "/login", login, &fursy.RouteOptions{
+//	    RateLimit: &fursy.RateLimitPolicy{Rate: 1, Burst: 5},
+//	})
+type RateLimitPolicy struct {
+	// Rate is the number of requests allowed per second.
+	Rate float64
+
+	// Burst is the maximum burst size (bucket capacity).
+	// Default: Rate rounded up (at least 1)
+	Burst int
+
+	// KeyFunc extracts the rate limit key from the request.
+	// Default: client IP (Context.ClientIP)
+	KeyFunc func(c *Context) string
+
+	// MaxKeys is the maximum number of keys to track.
+	// When exceeded, the least recently used key is evicted.
+	// Default: 10000
+	MaxKeys int
+
+	// Cost is the number of tokens a request consumes, so expensive
+	// endpoints (e.g., bulk operations) drain the bucket faster than
+	// cheap ones. It must not exceed Burst (the group's Burst with Group).
+	// Ignored when Bytes is set.
+	// Default: 1
+	Cost float64
+
+	// Group shares the buckets of the routes whose policies have the same
+	// Group, so their costs are drawn from a single budget per key.
+	// The Rate, Burst and MaxKeys of the first route of the group apply.
+	// Default: "" (the route has its own buckets)
+	Group string
+
+	// Bytes expresses Rate and Burst in bytes of request body: a request
+	// consumes its body size instead of Cost. Requests whose
+	// Content-Length exceeds Burst are rejected with 413 Content Too Large.
+	// Bodies of unknown length consume their size as they are read and
+	// may overdraw the bucket, delaying the next requests of the key.
+	// Default: false
+	Bytes bool
+}
+
+// cost returns the configured cost or the default of 1 token.
+func (p *RateLimitPolicy) cost() float64 {
+	if p.Cost > 0 {
+		return p.Cost
+	}
+	return 1
+}
+
+// burst returns the configured burst or a default derived from Rate.
+func (p *RateLimitPolicy) burst() int {
+	if p.Burst > 0 {
+		return p.Burst
+	}
+	if b := int(p.Rate); float64(b) < p.Rate {
+		return b + 1
+	} else if b > 0 {
+		return b
+	}
+	return 1
+}
+
+// AuthChecker reports whether the current request is authenticated.
+// It is used to enforce RouteOptions.RequireAuth.
+type AuthChecker func(c *Context) bool
+
+// defaultAuthChecker reports whether an authentication middleware stored an identity.
+// It checks the keys used by middleware.JWT ("jwt") and middleware.BasicAuth ("User").
+func defaultAuthChecker(c *Context) bool {
+	return c.Get("jwt") != nil || c.Get("User") != nil
+}
+
+// SetAuthChecker sets the function used to enforce RouteOptions.RequireAuth.
+//
+// Default: the request is authenticated if middleware.JWT or middleware.BasicAuth
+// stored an identity in the context.
+//
+// Example:
+//
+//	router.SetAuthChecker(func(c *fursy.Context) bool {
+//	    return c.GetString("session_user") != ""
+//	})
+func (r *Router) SetAuthChecker(fn AuthChecker) *Router {
+	r.authChecker = fn
+	return r
+}
+
+// applyRoutePolicies wraps the handler with the operational settings from opts.
+//
+// Execution order: RequireAuth → RateLimit → Cost → RequireIfMatch → Fields →
+// Localize → MaxBodySize → Timeout → MaxMultipartMemory → handler.
+// Policies run after router and group middleware, so authentication
+// middleware has already populated the context.
+//
+// groups stores the rate limit buckets shared by RateLimitPolicy.Group.
+func applyRoutePolicies(handler HandlerFunc, opts *RouteOptions, groups map[string]*routeLimiterStore) HandlerFunc {
+	if opts == nil {
+		return handler
+	}
+
+	if opts.MaxMultipartMemory > 0 {
+		handler = multipartMemoryPolicy(handler, opts.MaxMultipartMemory)
+	}
+	if opts.Timeout > 0 {
+		handler = timeoutPolicy(handler, opts.Timeout)
+	}
+	if opts.MaxBodySize > 0 {
+		handler = maxBodySizePolicy(handler, opts.MaxBodySize)
+	}
+	if opts.Localize != nil {
+		handler = localizePolicy(handler, opts.Localize)
+	}
+	if len(opts.Fields) > 0 {
+		handler = fieldsPolicy(handler, opts.Fields)
+	}
+	if opts.RequireIfMatch {
+		handler = requireIfMatchPolicy(handler)
+	}
+	if opts.Cost != nil && opts.Cost.MaxCost > 0 {
+		handler = costPolicy(handler, opts.Cost)
+	}
+	if opts.RateLimit != nil && opts.RateLimit.Rate > 0 {
+		handler = rateLimitPolicy(handler, opts.RateLimit, groups)
+	}
+	if opts.RequireAuth {
+		handler = requireAuthPolicy(handler)
+	}
+
+	return handler
+}
+
+// hasPolicies reports whether opts declares a policy that wraps the
+// route handler (see applyRoutePolicies and Router.deprecationPolicy).
+func (opts *RouteOptions) hasPolicies() bool {
+	return opts != nil && (opts.MaxMultipartMemory > 0 || opts.Timeout > 0 || opts.MaxBodySize > 0 ||
+		opts.RequireIfMatch || len(opts.Fields) > 0 || opts.Localize != nil || (opts.Cost != nil && opts.Cost.MaxCost > 0) || (opts.RateLimit != nil && opts.RateLimit.Rate > 0) || opts.RequireAuth ||
+		opts.Deprecated || !opts.Sunset.IsZero())
+}
+
+// timeoutPolicy sets a deadline on the request context.
+// If the handler fails after the deadline before writing a response,
+// a 503 Service Unavailable problem is sent.
+func timeoutPolicy(next HandlerFunc, timeout time.Duration) HandlerFunc {
+	return func(c *Context) error {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		tw := &timeoutResponseWriter{ResponseWriter: c.Response}
+		c.Response = tw
+
+		err := next(c)
+		c.Response = tw.ResponseWriter
+		if err != nil && !tw.written && (errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded)) {
+			return c.Problem(ServiceUnavailable("request timed out"))
+		}
+		return err
+	}
+}
+
+// timeoutResponseWriter records whether the handler of a route with a
+// timeout started the response.
+type timeoutResponseWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+// WriteHeader records a final status code and calls the underlying WriteHeader.
+func (w *timeoutResponseWriter) WriteHeader(code int) {
+	if code >= 200 {
+		w.written = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write records the response as started and calls the underlying Write.
+func (w *timeoutResponseWriter) Write(b []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush flushes the underlying ResponseWriter, if supported.
+func (w *timeoutResponseWriter) Flush() {
+	w.written = true
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap returns the underlying ResponseWriter (see http.ResponseController).
+func (w *timeoutResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// maxBodySizePolicy limits the request body size.
+// Requests with a larger Content-Length are rejected with 413 Content Too Large.
+func maxBodySizePolicy(next HandlerFunc, limit int64) HandlerFunc {
+	return func(c *Context) error {
+		if c.Request.ContentLength > limit {
+			return c.Problem(NewProblem(http.StatusRequestEntityTooLarge, "Content Too Large",
+				fmt.Sprintf("request body exceeds %d bytes", limit)))
+		}
+		if c.Request.Body != nil && c.Request.Body != http.NoBody {
+			c.Request.Body = http.MaxBytesReader(c.Response, c.Request.Body, limit)
+		}
+		return next(c)
+	}
+}
+
+// multipartMemoryPolicy sets the multipart memory limit of the route.
+func multipartMemoryPolicy(next HandlerFunc, limit int64) HandlerFunc {
+	return func(c *Context) error {
+		c.multipartMemory = limit
+		return next(c)
+	}
+}
+
+// requireAuthPolicy rejects unauthenticated requests with 401 Unauthorized.
+func requireAuthPolicy(next HandlerFunc) HandlerFunc {
+	return func(c *Context) error {
+		check := defaultAuthChecker
+		if c.router != nil && c.router.authChecker != nil {
+			check = c.router.authChecker
+		}
+		if !check(c) {
+			return c.Problem(Unauthorized("authentication required"))
+		}
+		return next(c)
+	}
+}
+
+// rateLimitPolicy enforces a per-route token bucket rate limit.
+// Requests over the limit are rejected with 429 Too Many Requests and a Retry-After header.
+func rateLimitPolicy(next HandlerFunc, policy *RateLimitPolicy, groups map[string]*routeLimiterStore) HandlerFunc {
+	cost := policy.cost()
+	keyFunc := policy.KeyFunc
+	if keyFunc == nil {
+		keyFunc = func(c *Context) string {
+			return c.ClientIP()
+		}
+	}
+
+	store := groups[policy.Group]
+	if store == nil {
+		maxKeys := policy.MaxKeys
+		if maxKeys <= 0 {
+			maxKeys = defaultRouteRateLimitMaxKeys
+		}
+		store = &routeLimiterStore{
+			buckets: make(map[string]*list.Element),
+			rate:    policy.Rate,
+			burst:   float64(policy.burst()),
+			maxKeys: maxKeys,
+		}
+		if policy.Group != "" {
+			groups[policy.Group] = store
+		}
+	}
+	// The burst of the group's first route applies to the whole group.
+	if !policy.Bytes && cost > store.burst {
+		panic(fmt.Sprintf("fursy: rate limit cost %g exceeds burst %g", cost, store.burst))
+	}
+
+	return func(c *Context) error {
+		key := keyFunc(c)
+		n := cost
+		if policy.Bytes {
+			if c.Request.ContentLength > int64(store.burst) {
+				return c.Problem(NewProblem(http.StatusRequestEntityTooLarge, "Content Too Large",
+					fmt.Sprintf("request body exceeds %d bytes", int64(store.burst))))
+			}
+			n = float64(max(c.Request.ContentLength, 0))
+		}
+
+		if ok, retryAfter := store.allow(key, n, time.Now()); !ok {
+			c.SetHeader("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+			return c.Problem(TooManyRequests("rate limit exceeded"))
+		}
+
+		// Bodies of unknown length are charged as they are read.
+		if policy.Bytes && c.Request.ContentLength < 0 && c.Request.Body != nil && c.Request.Body != http.NoBody {
+			c.Request.Body = &meteredBody{ReadCloser: c.Request.Body, store: store, key: key}
+		}
+		return next(c)
+	}
+}
+
+// meteredBody charges the bytes read from a request body to a token bucket.
+type meteredBody struct {
+	io.ReadCloser
+	store *routeLimiterStore
+	key   string
+}
+
+// Read implements io.Reader.
+func (b *meteredBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.store.charge(b.key, float64(n), time.Now())
+	}
+	return n, err
+}
+
+// routeLimiterStore stores per-key token buckets for a single route
+// (or the routes of a RateLimitPolicy.Group).
+// It uses the standard library only, keeping core routing dependency-free.
+// Eviction of the least recently used key is O(1).
+type routeLimiterStore struct {
+	mu      sync.Mutex
+	buckets map[string]*list.Element
+	lru     list.List // *tokenBucket, most recently used first.
+	rate    float64   // Tokens per second.
+	burst   float64   // Bucket capacity.
+	maxKeys int
+}
+
+// tokenBucket is the state of a single key's token bucket.
+type tokenBucket struct {
+	key        string
+	tokens     float64
+	lastAccess time.Time
+}
+
+// allow consumes n tokens for key.
+// If not enough tokens are available, it returns false and the time until they are.
+func (s *routeLimiterStore) allow(key string, n float64, now time.Time) (bool, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.bucket(key, now)
+	if b.tokens >= n {
+		b.tokens -= n
+		return true, 0
+	}
+	return false, time.Duration((n - b.tokens) / s.rate * float64(time.Second))
+}
+
+// charge consumes n tokens for key, overdrawing the bucket if needed.
+func (s *routeLimiterStore) charge(key string, n float64, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.bucket(key, now).tokens -= n
+}
+
+// bucket returns the refilled bucket of key; s.mu must be held.
+func (s *routeLimiterStore) bucket(key string, now time.Time) *tokenBucket {
+	var b *tokenBucket
+	if e, ok := s.buckets[key]; ok {
+		s.lru.MoveToFront(e)
+		b = e.Value.(*tokenBucket)
+	} else {
+		// Evict the least recently used key.
+		if len(s.buckets) >= s.maxKeys {
+			oldest := s.lru.Back()
+			s.lru.Remove(oldest)
+			delete(s.buckets, oldest.Value.(*tokenBucket).key)
+		}
+		b = &tokenBucket{key: key, tokens: s.burst, lastAccess: now}
+		s.buckets[key] = s.lru.PushFront(b)
+	}
+
+	// Refill based on elapsed time.
+	b.tokens = min(s.burst, b.tokens+now.Sub(b.lastAccess).Seconds()*s.rate)
+	b.lastAccess = now
+	return b
+}
+
+// remoteIP returns the client IP from the request's RemoteAddr.
+func remoteIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
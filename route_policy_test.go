@@ -0,0 +1,345 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"container/list"
+	"encoding/json/v2"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRouteOptions_Timeout tests that the handler context gets a deadline.
+func TestRouteOptions_Timeout(t *testing.T) {
+	r := New()
+	r.HandleWithOptions(http.MethodGet, "/slow", func(c *Context) error {
+		if _, ok := c.Request.Context().Deadline(); !ok {
+			t.Error("expected request context deadline")
+		}
+		<-c.Request.Context().Done()
+		return c.Request.Context().Err()
+	}, &RouteOptions{Timeout: 10 * time.Millisecond})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", http.NoBody))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/problem+json") {
+		t.Errorf("expected problem+json, got %q", ct)
+	}
+}
+
+// TestRouteOptions_TimeoutAfterWrite tests that no 503 is sent once the
+// handler started the response.
+func TestRouteOptions_TimeoutAfterWrite(t *testing.T) {
+	r := New()
+	r.HandleWithOptions(http.MethodGet, "/stream", func(c *Context) error {
+		_ = c.String(http.StatusOK, "partial")
+		<-c.Request.Context().Done()
+		return c.Request.Context().Err()
+	}, &RouteOptions{Timeout: 10 * time.Millisecond})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stream", http.NoBody))
+
+	if w.Code != http.StatusOK || strings.Contains(w.Body.String(), "timed out") {
+		t.Errorf("status = %d, body = %q", w.Code, w.Body.String())
+	}
+}
+
+// TestRouteOptions_MaxBodySize tests request body limits.
+func TestRouteOptions_MaxBodySize(t *testing.T) {
+	r := New()
+	r.HandleWithOptions(http.MethodPost, "/upload", func(c *Context) error {
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			return c.Problem(NewProblem(http.StatusRequestEntityTooLarge, "Content Too Large", err.Error()))
+		}
+		return c.NoContent(http.StatusNoContent)
+	}, &RouteOptions{MaxBodySize: 8})
+
+	t.Run("within limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("small")))
+		if w.Code != http.StatusNoContent {
+			t.Errorf("expected status 204, got %d", w.Code)
+		}
+	})
+
+	t.Run("content length too large", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("much too large")))
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("expected status 413, got %d", w.Code)
+		}
+	})
+
+	t.Run("streamed body too large", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("much too large"))
+		req.ContentLength = -1
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("expected status 413, got %d", w.Code)
+		}
+	})
+}
+
+// TestRouteOptions_RateLimit tests per-route rate limiting.
+func TestRouteOptions_RateLimit(t *testing.T) {
+	r := New()
+	r.HandleWithOptions(http.MethodPost, "/login", func(c *Context) error {
+		return c.NoContent(http.StatusNoContent)
+	}, &RouteOptions{RateLimit: &RateLimitPolicy{Rate: 0.01, Burst: 2}})
+	r.GET("/other", func(c *Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	codes := make([]int, 0, 3)
+	for range 3 {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/login", http.NoBody))
+		codes = append(codes, w.Code)
+		if w.Code == http.StatusTooManyRequests && w.Header().Get("Retry-After") == "" {
+			t.Error("expected Retry-After header")
+		}
+	}
+	if codes[0] != http.StatusNoContent || codes[1] != http.StatusNoContent || codes[2] != http.StatusTooManyRequests {
+		t.Errorf("unexpected status codes: %v", codes)
+	}
+
+	// Other clients and routes are not affected.
+	req := httptest.NewRequest(http.MethodPost, "/login", http.NoBody)
+	req.RemoteAddr = "10.0.0.1:1234"
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected other client to pass, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/other", http.NoBody))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected other route to pass, got %d", w.Code)
+	}
+}
+
+// TestRouteOptions_RateLimitCost tests request costs and shared groups.
+func TestRouteOptions_RateLimitCost(t *testing.T) {
+	r := New()
+	ok := func(c *Context) error {
+		return c.NoContent(http.StatusNoContent)
+	}
+	r.HandleWithOptions(http.MethodPost, "/bulk", ok,
+		&RouteOptions{RateLimit: &RateLimitPolicy{Rate: 0.01, Burst: 12, Cost: 10, Group: "api"}})
+	r.HandleWithOptions(http.MethodGet, "/items", ok,
+		&RouteOptions{RateLimit: &RateLimitPolicy{Rate: 0.01, Burst: 12, Group: "api"}})
+
+	serve := func(method, path string) int {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, path, http.NoBody))
+		return w.Code
+	}
+
+	if code := serve(http.MethodPost, "/bulk"); code != http.StatusNoContent {
+		t.Fatalf("expected first bulk request to pass, got %d", code)
+	}
+	// 2 tokens left: not enough for a bulk request, enough for 2 reads.
+	if code := serve(http.MethodPost, "/bulk"); code != http.StatusTooManyRequests {
+		t.Errorf("expected second bulk request to be limited, got %d", code)
+	}
+	for i := range 2 {
+		if code := serve(http.MethodGet, "/items"); code != http.StatusNoContent {
+			t.Errorf("read %d: expected status 204, got %d", i, code)
+		}
+	}
+	if code := serve(http.MethodGet, "/items"); code != http.StatusTooManyRequests {
+		t.Errorf("expected shared budget to be exhausted, got %d", code)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for cost exceeding burst")
+		}
+	}()
+	r.HandleWithOptions(http.MethodPost, "/huge", ok,
+		&RouteOptions{RateLimit: &RateLimitPolicy{Rate: 1, Burst: 5, Cost: 6}})
+}
+
+// TestRouteOptions_RateLimitGroupBurst tests that costs are checked
+// against the burst of the group.
+func TestRouteOptions_RateLimitGroupBurst(t *testing.T) {
+	r := New()
+	ok := func(c *Context) error {
+		return c.NoContent(http.StatusNoContent)
+	}
+	r.HandleWithOptions(http.MethodGet, "/items", ok,
+		&RouteOptions{RateLimit: &RateLimitPolicy{Rate: 1, Burst: 5, Group: "api"}})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for cost exceeding the group burst")
+		}
+	}()
+	r.HandleWithOptions(http.MethodPost, "/bulk", ok,
+		&RouteOptions{RateLimit: &RateLimitPolicy{Rate: 1, Burst: 20, Cost: 10, Group: "api"}})
+}
+
+// TestRouteLimiterStore_Evict tests that the least recently used key is evicted.
+func TestRouteLimiterStore_Evict(t *testing.T) {
+	s := &routeLimiterStore{buckets: make(map[string]*list.Element), rate: 1, burst: 1, maxKeys: 2}
+	now := time.Now()
+	for _, key := range []string{"a", "b", "a", "c"} {
+		s.allow(key, 0, now)
+	}
+	if _, ok := s.buckets["b"]; ok || len(s.buckets) != 2 || s.lru.Len() != 2 {
+		t.Errorf("buckets = %v, want a and c", s.buckets)
+	}
+}
+
+// TestRouteOptions_RateLimitBytes tests limits in bytes of request body.
+func TestRouteOptions_RateLimitBytes(t *testing.T) {
+	r := New()
+	r.HandleWithOptions(http.MethodPost, "/upload", func(c *Context) error {
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			return err
+		}
+		return c.NoContent(http.StatusNoContent)
+	}, &RouteOptions{RateLimit: &RateLimitPolicy{Rate: 0.01, Burst: 10, Bytes: true}})
+
+	serve := func(body string, contentLength int64) int {
+		req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(body))
+		req.ContentLength = contentLength
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := serve("this is too large", 17); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status 413, got %d", code)
+	}
+	if code := serve("123456", 6); code != http.StatusNoContent {
+		t.Errorf("expected status 204, got %d", code)
+	}
+	if code := serve("123456", 6); code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429, got %d", code)
+	}
+
+	// Bodies of unknown length are charged as read.
+	if code := serve("12345678", -1); code != http.StatusNoContent {
+		t.Errorf("expected streamed body to pass, got %d", code)
+	}
+	if code := serve("1", 1); code != http.StatusTooManyRequests {
+		t.Errorf("expected overdrawn bucket to limit, got %d", code)
+	}
+}
+
+// TestRouteOptions_RequireAuth tests authentication enforcement.
+func TestRouteOptions_RequireAuth(t *testing.T) {
+	auth := func(c *Context) error {
+		if c.Request.Header.Get("Authorization") != "" {
+			c.Set("jwt", map[string]any{"sub": "1"})
+		}
+		return c.Next()
+	}
+
+	t.Run("default checker", func(t *testing.T) {
+		r := New()
+		r.Use(auth)
+		r.HandleWithOptions(http.MethodGet, "/me", func(c *Context) error {
+			return c.String(http.StatusOK, "me")
+		}, &RouteOptions{RequireAuth: true})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/me", http.NoBody))
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("expected status 401, got %d", w.Code)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/me", http.NoBody)
+		req.Header.Set("Authorization", "Bearer token")
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("custom checker", func(t *testing.T) {
+		r := New()
+		r.SetAuthChecker(func(c *Context) bool {
+			return c.Request.Header.Get("X-API-Key") == "secret"
+		})
+		r.HandleWithOptions(http.MethodGet, "/me", func(c *Context) error {
+			return c.String(http.StatusOK, "me")
+		}, &RouteOptions{RequireAuth: true})
+
+		req := httptest.NewRequest(http.MethodGet, "/me", http.NoBody)
+		req.Header.Set("X-API-Key", "secret")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", w.Code)
+		}
+	})
+}
+
+// TestRouteOptions_OpenAPIExtensions tests that route policies are documented.
+func TestRouteOptions_OpenAPIExtensions(t *testing.T) {
+	r := New()
+	r.HandleWithOptions(http.MethodPost, "/uploads", func(c *Context) error {
+		return c.NoContent(http.StatusNoContent)
+	}, &RouteOptions{
+		Summary:     "Upload",
+		Timeout:     30 * time.Second,
+		MaxBodySize: 1 << 20,
+		RateLimit:   &RateLimitPolicy{Rate: 5},
+		RequireAuth: true,
+	})
+
+	doc, err := r.GenerateOpenAPI(Info{Title: "Test", Version: "1.0"})
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI failed: %v", err)
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+
+	var result struct {
+		Paths map[string]map[string]map[string]any `json:"paths"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+
+	op := result.Paths["/uploads"]["post"]
+	if op["x-timeout"] != "30s" {
+		t.Errorf("expected x-timeout 30s, got %v", op["x-timeout"])
+	}
+	if op["x-max-body-size"] != float64(1<<20) {
+		t.Errorf("expected x-max-body-size, got %v", op["x-max-body-size"])
+	}
+	if op["x-require-auth"] != true {
+		t.Errorf("expected x-require-auth, got %v", op["x-require-auth"])
+	}
+	rl, ok := op["x-ratelimit"].(map[string]any)
+	if !ok || rl["rate"] != float64(5) || rl["burst"] != float64(5) || rl["cost"] != float64(1) {
+		t.Errorf("unexpected x-ratelimit: %v", op["x-ratelimit"])
+	}
+
+	responses, _ := op["responses"].(map[string]any)
+	for _, code := range []string{"401", "413", "429", "503"} {
+		if _, ok := responses[code]; !ok {
+			t.Errorf("expected %s response", code)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+)
+
+// VariantContextKey is the context key storing the variant selected by a split route.
+const VariantContextKey = "variant"
+
+// Default variant names of a split route.
+const (
+	// VariantControl is the default name of the existing (control) handler.
+	VariantControl = "control"
+
+	// VariantCanary is the default name of the new (canary) handler.
+	VariantCanary = "canary"
+)
+
+// SplitPolicy decides which of two handlers serves a request.
+//
+// Rules are checked in order: Header, Cookie, Percentage. The header and
+// cookie values must equal a variant name to select it, so testers can
+// force either variant (e.g., "X-Variant: canary" or "X-Variant: control").
+//
+// Example (5% of users, sticky per user, forceable via header):
+//
+//	policy := fursy.SplitPolicy{
+//	    Percentage: 5,
+//	    Header:     "X-Variant",
+//	    KeyFunc: func(c *fursy.Context) string {
+//	        return c.GetString("user_id")
+//	    },
+//	}
+type SplitPolicy struct {
+	// Percentage is the share of requests served by the canary (0-100).
+	// Default: 0 (only header and cookie select the canary)
+	Percentage float64
+
+	// Header is the request header that selects a variant by name.
+	// Responses get "Vary: <Header>" so caches keep the variants apart.
+	// Default: "" (not used)
+	Header string
+
+	// Cookie is the cookie that selects a variant by name.
+	// Default: "" (not used)
+	Cookie string
+
+	// KeyFunc returns a stable key (e.g., user ID or session ID) used for
+	// percentage assignment, so the same key always gets the same variant.
+	// An empty key falls back to random assignment.
+	// Default: nil (random assignment per request)
+	KeyFunc func(c *Context) string
+
+	// ControlName is the name of the control variant.
+	// Default: "control"
+	ControlName string
+
+	// CanaryName is the name of the canary variant.
+	// Default: "canary"
+	CanaryName string
+
+	// ResponseHeader, if set, sends the selected variant name in this
+	// response header (e.g., "X-Variant").
+	// Default: "" (not sent)
+	ResponseHeader string
+}
+
+// pick returns true if the request should be served by the canary.
+func (p *SplitPolicy) pick(c *Context) bool {
+	if p.Header != "" {
+		switch c.GetHeader(p.Header) {
+		case p.CanaryName:
+			return true
+		case p.ControlName:
+			return false
+		}
+	}
+
+	if p.Cookie != "" {
+		if cookie, err := c.Request.Cookie(p.Cookie); err == nil {
+			switch cookie.Value {
+			case p.CanaryName:
+				return true
+			case p.ControlName:
+				return false
+			}
+		}
+	}
+
+	switch {
+	case p.Percentage <= 0:
+		return false
+	case p.Percentage >= 100:
+		return true
+	}
+
+	if p.KeyFunc != nil {
+		if key := p.KeyFunc(c); key != "" {
+			h := fnv.New32a()
+			_, _ = h.Write([]byte(key))
+			return float64(h.Sum32()%10000) < p.Percentage*100
+		}
+	}
+
+	return rand.Float64()*100 < p.Percentage
+}
+
+// SplitHandler returns a handler that serves each request with either
+// control or canary, as decided by policy.
+//
+// The selected variant name is stored in the context (see Context.Variant),
+// where middleware.Logger and the opentelemetry plugin pick it up, so
+// logs, metrics and traces can be compared per variant.
+//
+// Example:
+//
+//	router.GET("/checkout", fursy.SplitHandler(checkoutV1, checkoutV2, fursy.SplitPolicy{
+//	    Percentage: 10,
+//	}))
+func SplitHandler(control, canary HandlerFunc, policy SplitPolicy) HandlerFunc {
+	// Validate config.
+	if control == nil || canary == nil {
+		panic("fursy: split route requires control and canary handlers")
+	}
+
+	// Set defaults.
+	if policy.ControlName == "" {
+		policy.ControlName = VariantControl
+	}
+	if policy.CanaryName == "" {
+		policy.CanaryName = VariantCanary
+	}
+
+	return func(c *Context) error {
+		handler, name := control, policy.ControlName
+		if policy.pick(c) {
+			handler, name = canary, policy.CanaryName
+		}
+
+		c.Set(VariantContextKey, name)
+		if policy.Header != "" {
+			c.AddVary(policy.Header)
+		}
+		if policy.ResponseHeader != "" {
+			c.SetHeader(policy.ResponseHeader, name)
+		}
+
+		return handler(c)
+	}
+}
+
+// Split registers a route served by two handlers, split by policy.
+// It is a shorthand for Handle with SplitHandler.
+//
+// Example (incremental rollout):
+//
+//	router.Split(http.MethodGet, "/search", searchV1, searchV2, fursy.SplitPolicy{
+//	    Percentage: 25,
+//	    Cookie:     "variant",
+//	})
+func (r *Router) Split(method, path string, control, canary HandlerFunc, policy SplitPolicy) {
+	r.Handle(method, path, SplitHandler(control, canary, policy))
+}
+
+// Split registers a route in the group served by two handlers, split by policy.
+// See Router.Split.
+func (g *RouteGroup) Split(method, path string, control, canary HandlerFunc, policy SplitPolicy) {
+	g.Handle(method, path, SplitHandler(control, canary, policy))
+}
+
+// Variant returns the variant selected by a split route
+// (see SplitHandler), or "" if the route is not split.
+//
+// Example:
+//
+//	metrics.Checkouts.WithLabelValues(c.Variant()).Inc()
+func (c *Context) Variant() string {
+	return c.GetString(VariantContextKey)
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func newSplitRouter(policy SplitPolicy) *Router {
+	router := New()
+	router.Split(http.MethodGet, "/checkout",
+		func(c *Context) error { return c.String(http.StatusOK, "v1:"+c.Variant()) },
+		func(c *Context) error { return c.String(http.StatusOK, "v2:"+c.Variant()) },
+		policy,
+	)
+	return router
+}
+
+func serveSplit(router *Router, req *http.Request) string {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Body.String()
+}
+
+func TestSplit_Percentage(t *testing.T) {
+	tests := []struct {
+		percentage float64
+		want       string
+	}{
+		{0, "v1:control"},
+		{100, "v2:canary"},
+	}
+
+	for _, tt := range tests {
+		router := newSplitRouter(SplitPolicy{Percentage: tt.percentage})
+		for range 20 {
+			if got := serveSplit(router, httptest.NewRequest(http.MethodGet, "/checkout", http.NoBody)); got != tt.want {
+				t.Fatalf("percentage %v: expected %q, got %q", tt.percentage, tt.want, got)
+			}
+		}
+	}
+}
+
+func TestSplit_PercentageDistribution(t *testing.T) {
+	router := newSplitRouter(SplitPolicy{Percentage: 30})
+
+	canary := 0
+	for range 2000 {
+		if serveSplit(router, httptest.NewRequest(http.MethodGet, "/checkout", http.NoBody)) == "v2:canary" {
+			canary++
+		}
+	}
+	if canary < 450 || canary > 750 {
+		t.Errorf("expected ~600 canary requests, got %d", canary)
+	}
+}
+
+func TestSplit_Sticky(t *testing.T) {
+	router := newSplitRouter(SplitPolicy{
+		Percentage: 50,
+		KeyFunc:    func(c *Context) string { return c.Query("user") },
+	})
+
+	variants := map[string]bool{}
+	for i := range 50 {
+		target := "/checkout?user=" + strconv.Itoa(i)
+		first := serveSplit(router, httptest.NewRequest(http.MethodGet, target, http.NoBody))
+		for range 5 {
+			if got := serveSplit(router, httptest.NewRequest(http.MethodGet, target, http.NoBody)); got != first {
+				t.Fatalf("user %d: variant changed from %q to %q", i, first, got)
+			}
+		}
+		variants[first] = true
+	}
+	if len(variants) != 2 {
+		t.Errorf("expected both variants across users, got %v", variants)
+	}
+}
+
+func TestSplit_HeaderAndCookie(t *testing.T) {
+	router := newSplitRouter(SplitPolicy{
+		Percentage:     100,
+		Header:         "X-Variant",
+		Cookie:         "variant",
+		ResponseHeader: "X-Variant",
+	})
+
+	// Header forces control despite 100%.
+	req := httptest.NewRequest(http.MethodGet, "/checkout", http.NoBody)
+	req.Header.Set("X-Variant", "control")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Body.String() != "v1:control" {
+		t.Errorf("expected header to force control, got %q", w.Body.String())
+	}
+	if w.Header().Get("X-Variant") != "control" {
+		t.Errorf("expected X-Variant response header, got %q", w.Header().Get("X-Variant"))
+	}
+	if w.Header().Get("Vary") != "X-Variant" {
+		t.Errorf("expected Vary: X-Variant, got %q", w.Header().Get("Vary"))
+	}
+
+	// Cookie forces control.
+	req = httptest.NewRequest(http.MethodGet, "/checkout", http.NoBody)
+	req.AddCookie(&http.Cookie{Name: "variant", Value: "control"})
+	if got := serveSplit(router, req); got != "v1:control" {
+		t.Errorf("expected cookie to force control, got %q", got)
+	}
+
+	// Header takes precedence over cookie.
+	req = httptest.NewRequest(http.MethodGet, "/checkout", http.NoBody)
+	req.Header.Set("X-Variant", "canary")
+	req.AddCookie(&http.Cookie{Name: "variant", Value: "control"})
+	if got := serveSplit(router, req); got != "v2:canary" {
+		t.Errorf("expected header to take precedence, got %q", got)
+	}
+
+	// Unknown values fall through to the percentage.
+	req = httptest.NewRequest(http.MethodGet, "/checkout", http.NoBody)
+	req.Header.Set("X-Variant", "other")
+	if got := serveSplit(router, req); got != "v2:canary" {
+		t.Errorf("expected percentage for unknown value, got %q", got)
+	}
+}
+
+func TestSplit_CustomNamesAndGroup(t *testing.T) {
+	router := New()
+	api := router.Group("/api")
+	api.Split(http.MethodGet, "/search",
+		func(c *Context) error { return c.String(http.StatusOK, c.Variant()) },
+		func(c *Context) error { return c.String(http.StatusOK, c.Variant()) },
+		SplitPolicy{Header: "X-Search", ControlName: "v1", CanaryName: "v2"},
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/search", http.NoBody)
+	req.Header.Set("X-Search", "v2")
+	if got := serveSplit(router, req); got != "v2" {
+		t.Errorf("expected v2, got %q", got)
+	}
+	if got := serveSplit(router, httptest.NewRequest(http.MethodGet, "/api/search", http.NoBody)); got != "v1" {
+		t.Errorf("expected v1, got %q", got)
+	}
+}
+
+func TestSplitHandler_NilHandlerPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	SplitHandler(nil, func(*Context) error { return nil }, SplitPolicy{})
+}
+
+func TestContext_VariantUnset(t *testing.T) {
+	c := newContext()
+	if got := c.Variant(); got != "" {
+		t.Errorf("expected empty variant, got %q", got)
+	}
+}
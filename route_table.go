@@ -0,0 +1,174 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/coregx/fursy/internal/radix"
+)
+
+// routeTable is an immutable snapshot of the registered routes. The
+// routing trees of the snapshot are built on first use, so registering
+// many routes before serving builds them once.
+//
+// Registration and removal publish a new snapshot (copy-on-write), so
+// ServeHTTP reads the routes without locking while they change.
+type routeTable struct {
+	// entries lists the routes of each HTTP method in registration order.
+	entries map[string][]tableEntry
+
+	once  sync.Once
+	trees map[string]*radix.Tree
+}
+
+// tableEntry is a route of a routeTable.
+type tableEntry struct {
+	path  string
+	entry *routeEntry
+}
+
+// routeTrees returns the routing trees of the snapshot, one per HTTP method.
+func (t *routeTable) routeTrees() map[string]*radix.Tree {
+	t.once.Do(func() {
+		t.trees = make(map[string]*radix.Tree, len(t.entries))
+		for method, entries := range t.entries {
+			tree := radix.New()
+			for _, e := range entries {
+				// Conflicts were rejected by the staging trees on registration.
+				_ = tree.Insert(e.path, e.entry)
+			}
+			t.trees[method] = tree
+		}
+	})
+	return t.trees
+}
+
+// routeTrees returns the routing trees of the registered routes.
+func (r *Router) routeTrees() map[string]*radix.Tree {
+	return r.table.Load().routeTrees()
+}
+
+// addTableEntry registers entry for method and path, panicking if the
+// path is invalid or conflicts with a registered route.
+// It must be called with routeMu held.
+func (r *Router) addTableEntry(method, path string, entry *routeEntry) {
+	stage := r.stageTrees[method]
+	if stage == nil {
+		stage = radix.New()
+		r.stageTrees[method] = stage
+	}
+	if err := stage.Insert(path, entry); err != nil {
+		panic("fursy: " + method + " " + path + ": " + err.Error())
+	}
+
+	r.tableEntries[method] = append(r.tableEntries[method], tableEntry{path: path, entry: entry})
+	r.publishTable()
+}
+
+// replaceTableEntry replaces the entry old of method with entry.
+// It must be called with routeMu held.
+func (r *Router) replaceTableEntry(method string, old, entry *routeEntry) {
+	// Copy the entries: published snapshots share them.
+	entries := slices.Clone(r.tableEntries[method])
+	for i := range entries {
+		if entries[i].entry == old {
+			entries[i].entry = entry
+		}
+	}
+	r.tableEntries[method] = entries
+	r.publishTable()
+}
+
+// publishTable publishes a snapshot of the registered routes.
+// It must be called with routeMu held.
+func (r *Router) publishTable() {
+	r.table.Store(&routeTable{entries: maps.Clone(r.tableEntries)})
+}
+
+// Remove unregisters the routes registered for method and path (the full
+// path for group routes, e.g. "/api/users/:id"), including all the routes
+// of HandleMatch on them. It reports whether a route was removed.
+//
+// Requests for the path then get 404 Not Found (or 405 Method Not
+// Allowed if other methods remain), and the route is no longer listed by
+// Routes or documented in OpenAPI. The path can be registered again, so
+// plugins and admin endpoints can toggle routes without a restart.
+//
+// Remove and route registration are safe to call while the router serves
+// requests (see Handle for the guarantees).
+//
+// Example:
+//
+//	router.POST("/admin/features/:name/off", func(c *fursy.Context) error {
+//	    if !router.Remove(http.MethodGet, "/features/"+c.Param("name")) {
+//	        return c.Problem(fursy.NotFound("feature is not enabled"))
+//	    }
+//	    return c.NoContent(http.StatusNoContent)
+//	})
+func (r *Router) Remove(method, path string) bool {
+	r.routeMu.Lock()
+	defer r.routeMu.Unlock()
+
+	entries := r.tableEntries[method]
+	kept := make([]tableEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.path != path {
+			kept = append(kept, e)
+		}
+	}
+	if len(kept) == len(entries) {
+		return false
+	}
+
+	if len(kept) == 0 {
+		delete(r.tableEntries, method)
+		delete(r.stageTrees, method)
+	} else {
+		stage := radix.New()
+		for _, e := range kept {
+			_ = stage.Insert(e.path, e.entry)
+		}
+		r.tableEntries[method] = kept
+		r.stageTrees[method] = stage
+	}
+	r.publishTable()
+
+	key := method + " " + path
+	delete(r.matchedRoutes, key)
+	delete(r.routeSlots, key)
+	r.routes = slices.DeleteFunc(r.routes, func(route RouteInfo) bool {
+		return route.Method == method && route.Path == path
+	})
+	r.routeSnapshot.Store(nil)
+	return true
+}
+
+// addRouteInfo records the metadata of a registered route.
+// It must be called with routeMu held.
+func (r *Router) addRouteInfo(info RouteInfo) {
+	r.routes = append(r.routes, info)
+	r.routeSnapshot.Store(nil)
+}
+
+// routeList returns a snapshot of the metadata of the registered routes.
+// Snapshots are never modified, so they can be read while routes are
+// registered or removed.
+func (r *Router) routeList() []RouteInfo {
+	if routes := r.routeSnapshot.Load(); routes != nil {
+		return *routes
+	}
+
+	r.routeMu.Lock()
+	defer r.routeMu.Unlock()
+	if routes := r.routeSnapshot.Load(); routes != nil {
+		return *routes
+	}
+	routes := slices.Clone(r.routes)
+	r.routeSnapshot.Store(&routes)
+	return routes
+}
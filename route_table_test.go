@@ -0,0 +1,202 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestRouter_Remove tests unregistering routes.
+func TestRouter_Remove(t *testing.T) {
+	r := New()
+	ok := func(c *Context) error { return c.Text(c.Request.Method) }
+	r.GET("/users/:id", ok)
+	r.DELETE("/users/:id", ok)
+	r.GET("/users/:id/posts", ok)
+	api := r.Group("/api")
+	api.GET("/status", ok)
+	r.HandleMatch(http.MethodPost, "/upload", MatchContentType("application/json"), ok)
+	r.HandleMatch(http.MethodPost, "/upload", MatchContentType("text/csv"), ok)
+
+	serve := func(method, path string) int {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, path, http.NoBody))
+		return w.Code
+	}
+
+	if !r.Remove(http.MethodGet, "/users/:id") {
+		t.Fatal("Remove should report the removed route")
+	}
+	if r.Remove(http.MethodGet, "/users/:id") {
+		t.Error("Remove of a removed route should report false")
+	}
+	if code := serve(http.MethodGet, "/users/1"); code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /users/1 = %d, want 405", code)
+	}
+	if code := serve(http.MethodDelete, "/users/1"); code != http.StatusOK {
+		t.Errorf("DELETE /users/1 = %d, want 200", code)
+	}
+	if code := serve(http.MethodGet, "/users/1/posts"); code != http.StatusOK {
+		t.Errorf("GET /users/1/posts = %d, want 200", code)
+	}
+
+	if !r.Remove(http.MethodGet, "/api/status") || serve(http.MethodGet, "/api/status") != http.StatusNotFound {
+		t.Error("group route should be removed")
+	}
+	if !r.Remove(http.MethodPost, "/upload") || serve(http.MethodPost, "/upload") != http.StatusNotFound {
+		t.Error("matched routes should be removed")
+	}
+
+	for _, route := range r.Routes() {
+		if route.Path == "/api/status" || route.Path == "/upload" || (route.Method == http.MethodGet && route.Path == "/users/:id") {
+			t.Errorf("removed route %s %s still listed", route.Method, route.Path)
+		}
+	}
+
+	// The path can be registered again.
+	r.GET("/users/:id", func(c *Context) error { return c.Text("v2") })
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/1", http.NoBody))
+	if w.Body.String() != "v2" {
+		t.Errorf("re-registered route body = %q, want v2", w.Body.String())
+	}
+
+	// Overriding a removed route panics like an unregistered one.
+	r.Remove(http.MethodDelete, "/users/:id")
+	defer func() {
+		if recover() == nil {
+			t.Error("Override of a removed route should panic")
+		}
+	}()
+	r.Override(http.MethodDelete, "/users/:id", ok)
+}
+
+// TestRouter_DynamicRoutes tests registering and removing routes while
+// serving requests (run with -race).
+func TestRouter_DynamicRoutes(t *testing.T) {
+	r := New()
+	r.GET("/static", func(c *Context) error { return c.NoContent(http.StatusNoContent) })
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range 100 {
+			path := "/dynamic/" + strconv.Itoa(i)
+			r.GET(path, func(c *Context) error { return c.NoContent(http.StatusNoContent) })
+			r.HandleMatch(http.MethodPost, "/matched", MatchHeader("X-Version", strconv.Itoa(i)), func(c *Context) error {
+				return c.NoContent(http.StatusNoContent)
+			})
+			if i%2 == 0 {
+				r.Remove(http.MethodGet, path)
+			}
+		}
+	}()
+
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 200 {
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/static", http.NoBody))
+				if w.Code != http.StatusNoContent {
+					t.Errorf("GET /static = %d during route changes", w.Code)
+					return
+				}
+				r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/matched", http.NoBody))
+				_ = r.Routes()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, want := range map[int]int{1: http.StatusNoContent, 2: http.StatusNotFound} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/dynamic/"+strconv.Itoa(i), http.NoBody))
+		if w.Code != want {
+			t.Errorf("GET /dynamic/%d = %d, want %d", i, w.Code, want)
+		}
+	}
+}
+
+// TestRouter_RemoveWhileGeneratingOpenAPI tests removing routes while
+// handlers generate the OpenAPI document (run with -race).
+func TestRouter_RemoveWhileGeneratingOpenAPI(t *testing.T) {
+	r := New()
+	r.GET("/openapi.json", func(c *Context) error {
+		doc, err := r.GenerateOpenAPI(Info{Title: "Test", Version: "1.0"})
+		if err != nil {
+			return err
+		}
+		return c.OK(doc)
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range 100 {
+			path := "/items/" + strconv.Itoa(i)
+			GET[Empty, string](r, path, func(c *Box[Empty, string]) error { return c.OK("item") })
+			if i > 0 {
+				r.Remove(http.MethodGet, "/items/"+strconv.Itoa(i-1))
+			}
+		}
+	}()
+
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", http.NoBody))
+				if w.Code != http.StatusOK {
+					t.Errorf("GET /openapi.json = %d during route changes", w.Code)
+					return
+				}
+				_ = r.Stats()
+				_ = r.Validate()
+			}
+		}()
+	}
+	wg.Wait()
+
+	doc, err := r.GenerateOpenAPI(Info{Title: "Test", Version: "1.0"})
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI failed: %v", err)
+	}
+	if len(doc.Paths) != 2 {
+		t.Errorf("paths = %d, want 2 (/openapi.json and the last item)", len(doc.Paths))
+	}
+}
+
+// TestRouter_ConcurrentRegistrationMetadata tests that the metadata of
+// routes registered concurrently is recorded on the right route.
+func TestRouter_ConcurrentRegistrationMetadata(t *testing.T) {
+	r := New()
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Resource[resourceItem](r, "/things"+strconv.Itoa(i), newMemoryStore(), ResourceConfig[resourceItem]{ReadOnly: true})
+		}()
+	}
+	wg.Wait()
+
+	for _, route := range r.Routes() {
+		if segment := strings.Split(route.Path, "/")[1]; len(route.Tags) != 1 || route.Tags[0] != segment {
+			t.Errorf("%s %s: tags = %v, want [%s]", route.Method, route.Path, route.Tags, segment)
+		}
+	}
+}
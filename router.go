@@ -51,9 +51,18 @@ package fursy
 import (
 	"context"
 	"errors"
+	"html/template"
+	"log/slog"
+	"net"
 	"net/http"
+	"net/netip"
+	"net/url"
 	"os/signal"
+	"reflect"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -81,8 +90,17 @@ import (
 //
 // Router implements http.Handler and can be used directly with http.ListenAndServe.
 type Router struct {
-	// trees stores one radix tree per HTTP method for efficient routing.
-	trees map[string]*radix.Tree
+	// table is the published snapshot of the registered routes, from
+	// which one radix tree per HTTP method is built for efficient routing.
+	table atomic.Pointer[routeTable]
+
+	// routeMu serializes route registration and removal.
+	routeMu sync.Mutex
+
+	// stageTrees validate registered paths (syntax, conflicts), and
+	// tableEntries lists the registered routes, by HTTP method.
+	stageTrees   map[string]*radix.Tree
+	tableEntries map[string][]tableEntry
 
 	// pool reuses Context instances across requests for zero allocations.
 	pool sync.Pool
@@ -102,25 +120,183 @@ type Router struct {
 	// handleOPTIONS enables automatic handling of OPTIONS requests.
 	handleOPTIONS bool
 
-	// routes stores metadata about all registered routes for OpenAPI generation.
+	// trailingSlash is the trailing slash policy (see Options.TrailingSlash).
+	trailingSlash TrailingSlashPolicy
+
+	// cleanPath is the path clean policy (see Options.CleanPath).
+	cleanPath PathCleanPolicy
+
+	// paramDecoding is the path parameter decoding policy (see Options.ParamDecoding).
+	paramDecoding ParamDecoding
+
+	// profileLabels sets pprof labels around handlers (see Options.ProfileLabels).
+	profileLabels bool
+
+	// responseBufferLimit is the response buffer size (see Options.ResponseBufferLimit).
+	// Zero uses DefaultResponseBufferLimit, negative values disable buffering.
+	responseBufferLimit int
+
+	// maxMultipartMemory is the memory limit for parsing multipart forms.
+	maxMultipartMemory int64
+
+	// charset is the charset of text responses.
+	charset string
+
+	// cancelOnDisconnect stops the handler chain and skips response
+	// encoding when the client disconnects (see Options.CancelOnDisconnect).
+	cancelOnDisconnect bool
+
+	// timeLayouts are the layouts accepted by Context.QueryTime
+	// (see Options.TimeLayouts).
+	timeLayouts []string
+
+	// poolRequestBodies reuses the request bodies of generic handlers
+	// (see Options.PoolRequestBodies).
+	poolRequestBodies bool
+
+	// errorHandler handles errors returned by the handler chain.
+	// Set using Router.SetErrorHandler(). Groups can override it.
+	errorHandler ErrorHandler
+
+	// notFoundHandler handles requests that match no route.
+	// Set using Router.SetNotFoundHandler(). Groups can override it.
+	notFoundHandler HandlerFunc
+
+	// notFoundGroups stores groups with a custom NotFound handler.
+	// The group with the longest matching prefix handles the 404.
+	notFoundGroups []*RouteGroup
+
+	// authChecker enforces RouteOptions.RequireAuth.
+	// Set using Router.SetAuthChecker(). Default: defaultAuthChecker.
+	authChecker AuthChecker
+
+	// unmasker decides which callers see masked response fields unmasked.
+	// Set using Router.SetUnmasker(). Default: nil (always masked).
+	unmasker Unmasker
+
+	// trustedProxies lists proxies whose forwarding headers are trusted by Context.ClientIP.
+	// Set using Router.SetTrustedProxies().
+	trustedProxies []netip.Prefix
+
+	// binders stores custom request body binders by media type for Box.Bind.
+	// Set using Router.RegisterBinder().
+	binders map[string]Binder
+
+	// binderTypes lists registered binder media types in registration order.
+	binderTypes []string
+
+	// renderers stores custom renderers by media type for Context.Negotiate.
+	// Set using Router.RegisterRenderer().
+	renderers map[string]Renderer
+
+	// renderOffers lists media types offered by Context.Negotiate
+	// (built-in formats followed by registered renderers). Nil uses the defaults.
+	renderOffers []string
+
+	// htmlTemplates renders Context.HTML responses and error pages.
+	// Set using Router.SetHTMLTemplates().
+	htmlTemplates *template.Template
+
+	// errorPages maps status codes to error page templates.
+	// Set using Router.SetErrorPages().
+	errorPages map[int]string
+
+	// routes stores metadata about all registered routes for OpenAPI
+	// generation. It is guarded by routeMu; readers use routeList.
 	routes []RouteInfo
 
+	// routeSnapshot is the published copy of routes (nil after a change).
+	routeSnapshot atomic.Pointer[[]RouteInfo]
+
+	// matchedRoutes stores the routes registered with a matcher by
+	// "METHOD path" (see Router.HandleMatch).
+	matchedRoutes map[string]*matchedRoute
+
+	// routeSlots stores the handlers of the registered routes by
+	// "METHOD path" (see Router.Override).
+	routeSlots map[string][]*routeSlot
+
 	// info stores API metadata for OpenAPI generation.
 	info *Info
 
 	// servers stores server information for OpenAPI generation.
 	servers []Server
 
+	// externalDocs and openAPIExtensions are the document-level
+	// external documentation and extensions of the OpenAPI document.
+	externalDocs      *ExternalDocs
+	openAPIExtensions map[string]any
+
+	// webhooks stores the webhooks of the OpenAPI document.
+	webhooks map[string]PathItem
+
+	// curlSamples documents curl commands in x-codeSamples (see WithCurlSamples).
+	curlSamples bool
+
+	// openAPIPath is the path registered with ServeOpenAPI ("" if none).
+	openAPIPath string
+
+	// documentOPTIONS documents the automatic OPTIONS responses in the
+	// OpenAPI document. Set using Router.WithOPTIONSDocs().
+	documentOPTIONS bool
+
+	// listenConfig tunes the listeners of ListenAndServeWithShutdown.
+	// Set using Router.SetListenConfig().
+	listenConfig ListenConfig
+
 	// server stores reference to http.Server for graceful shutdown.
 	// Set by ListenAndServeWithShutdown or manually via SetServer.
 	server *http.Server
 
+	// logger is used for router warnings (e.g., deprecated route usage).
+	// Set using Router.SetLogger(). Nil uses slog.Default().
+	logger *slog.Logger
+
+	// deprecatedRoutes tracks usage of deprecated routes.
+	deprecatedRoutes []*deprecatedRouteStats
+
 	// shutdownCallbacks stores functions to call during graceful shutdown.
 	// Register callbacks using OnShutdown().
 	shutdownCallbacks []func()
 
 	// shutdownMu protects shutdown callbacks from concurrent access.
 	shutdownMu sync.Mutex
+
+	// connOpenHooks and connCloseHooks store connection lifecycle hooks.
+	// Register hooks using OnConnOpen() and OnConnClose().
+	connOpenHooks  []func(net.Conn)
+	connCloseHooks []func(net.Conn)
+
+	// connMu protects connection hooks from concurrent access.
+	connMu sync.RWMutex
+
+	// openConns counts connections accepted by the server set with SetServer.
+	openConns atomic.Int64
+
+	// inFlight counts requests currently being served.
+	inFlight atomic.Int64
+
+	// canceledRequests counts requests whose client disconnected.
+	canceledRequests atomic.Int64
+
+	// stats holds the counters of Router.Stats.
+	stats routerStats
+
+	// rateLimitGroups stores the rate limit buckets shared by the routes
+	// of a RateLimitPolicy.Group.
+	rateLimitGroups map[string]*routeLimiterStore
+
+	// contextKeys maps Context.Set keys mirrored into the request context
+	// to their request context keys. Set using Router.MirrorContextKeys().
+	contextKeys map[string]any
+
+	// middlewareTiming enables recording a MiddlewareRun for every handler
+	// in the chain. Set using Router.SetMiddlewareTiming().
+	middlewareTiming bool
+
+	// hooks stores request lifecycle hooks (nil if none are registered).
+	// Register hooks using OnRequest, OnResponse, OnError and OnPanic.
+	hooks *lifecycleHooks
 }
 
 // New creates a new Router instance with default configuration.
@@ -129,16 +305,24 @@ type Router struct {
 //   - Context pooling enabled for zero allocations
 //   - Method Not Allowed handling enabled
 //   - OPTIONS handling enabled
+//   - Strict trailing slash matching
 //   - Empty routing tables (trees are created on first route registration)
+//
+// Use NewWithOptions to change these behaviors.
 func New() *Router {
 	r := &Router{
-		trees:                  make(map[string]*radix.Tree),
+		stageTrees:             make(map[string]*radix.Tree),
+		tableEntries:           make(map[string][]tableEntry),
 		handleMethodNotAllowed: true,
 		handleOPTIONS:          true,
+		maxMultipartMemory:     DefaultMaxMultipartMemory,
+		charset:                DefaultCharset,
 	}
+	r.table.Store(&routeTable{})
 
 	// Initialize context pool.
 	r.pool.New = func() any {
+		r.stats.poolMisses.Add(1)
 		c := newContext()
 		c.router = r
 		return c
@@ -206,6 +390,66 @@ func (r *Router) SetValidator(v Validator) *Router {
 	return r
 }
 
+// SetErrorHandler sets the global error handler.
+//
+// The error handler is called when the handler chain returns an error.
+// RouteGroups can override it for their subtree using RouteGroup.SetErrorHandler().
+//
+// Default: sends a plain text 500 Internal Server Error response.
+//
+// Example:
+//
+//	router.SetErrorHandler(func(c *fursy.Context, err error) {
+//	    var p fursy.Problem
+//	    if errors.As(err, &p) {
+//	        _ = c.Problem(p)
+//	        return
+//	    }
+//	    _ = c.Problem(fursy.InternalServerError("unexpected error"))
+//	})
+func (r *Router) SetErrorHandler(h ErrorHandler) *Router {
+	r.errorHandler = h
+	return r
+}
+
+// SetNotFoundHandler sets the global handler for requests that match no route.
+//
+// RouteGroups can override it for paths under their prefix using
+// RouteGroup.SetNotFoundHandler().
+//
+// Default: sends a plain text 404 Not Found response.
+//
+// Example:
+//
+//	router.SetNotFoundHandler(func(c *fursy.Context) error {
+//	    return c.Problem(fursy.NotFound("no route for " + c.Request.URL.Path))
+//	})
+func (r *Router) SetNotFoundHandler(h HandlerFunc) *Router {
+	r.notFoundHandler = h
+	return r
+}
+
+// SetLogger sets the logger used for router warnings, such as calls to
+// deprecated routes.
+//
+// Default: slog.Default().
+//
+// Example:
+//
+//	router.SetLogger(slog.New(slog.NewJSONHandler(os.Stderr, nil)))
+func (r *Router) SetLogger(l *slog.Logger) *Router {
+	r.logger = l
+	return r
+}
+
+// log returns the router logger.
+func (r *Router) log() *slog.Logger {
+	if r.logger != nil {
+		return r.logger
+	}
+	return slog.Default()
+}
+
 // WithInfo sets the API metadata for OpenAPI generation.
 //
 // This configures the info section of the generated OpenAPI document.
@@ -237,6 +481,72 @@ func (r *Router) WithServer(server Server) *Router {
 	return r
 }
 
+// WithExternalDocs sets the external documentation of the OpenAPI document.
+//
+// Example:
+//
+//	router.WithExternalDocs(ExternalDocs{
+//	    Description: "Developer guide",
+//	    URL:         "https://docs.example.com",
+//	})
+func (r *Router) WithExternalDocs(docs ExternalDocs) *Router {
+	r.externalDocs = &docs
+	return r
+}
+
+// WithOpenAPIExtension sets a specification extension of the OpenAPI
+// document. The key must start with "x-" (checked by GenerateOpenAPI).
+// Use Info.Extensions for the extensions of the info object and
+// RouteOptions.Extensions for those of operations.
+//
+// Example:
+//
+//	router.WithOpenAPIExtension("x-tagGroups", []map[string]any{
+//	    {"name": "Billing", "tags": []string{"invoices", "payments"}},
+//	})
+func (r *Router) WithOpenAPIExtension(key string, value any) *Router {
+	if r.openAPIExtensions == nil {
+		r.openAPIExtensions = make(map[string]any)
+	}
+	r.openAPIExtensions[key] = value
+	return r
+}
+
+// WithWebhook documents a webhook the API sends to its consumers in the
+// OpenAPI document. Webhooks are only part of OpenAPI 3.1 documents.
+//
+// Example:
+//
+//	router.WithWebhook("order.created", PathItem{
+//	    Post: &Operation{
+//	        Summary:     "Order created",
+//	        RequestBody: &RequestBody{Content: map[string]MediaType{"application/json": {}}},
+//	        Responses:   map[string]Response{"200": {Description: "Delivery accepted"}},
+//	    },
+//	})
+func (r *Router) WithWebhook(name string, item PathItem) *Router {
+	if r.webhooks == nil {
+		r.webhooks = make(map[string]PathItem)
+	}
+	r.webhooks[name] = item
+	return r
+}
+
+// WithOPTIONSDocs enables documenting the automatic OPTIONS responses in
+// the OpenAPI document: each path without an explicit OPTIONS route gets an
+// OPTIONS operation whose 204 response declares the Allow header of the
+// path. This lets gateway configuration tooling read the allowed methods
+// from the spec. Ignored when automatic OPTIONS responses are disabled.
+//
+// Example:
+//
+//	router.WithOPTIONSDocs(true)
+//	router.ServeOpenAPI("/openapi.json")
+func (r *Router) WithOPTIONSDocs(enabled bool) *Router {
+	r.documentOPTIONS = enabled
+	return r
+}
+
 // ServeOpenAPI registers a route that serves the OpenAPI 3.1 specification as JSON.
 //
 // This is a convenience method that automatically generates and serves the OpenAPI
@@ -262,19 +572,14 @@ func (r *Router) WithServer(server Server) *Router {
 //	router.ServeOpenAPI("/openapi.json")
 //
 //	// Now GET /openapi.json returns the OpenAPI 3.1 document
-func (r *Router) ServeOpenAPI(path string) {
+//
+//	// Serve an OpenAPI 3.0 document for older tools
+//	router.ServeOpenAPI("/openapi-3.0.json", fursy.WithVersion("3.0"))
+func (r *Router) ServeOpenAPI(path string, opts ...OpenAPIOption) {
+	r.openAPIPath = path
 	r.GET(path, func(c *Context) error {
-		// Use router info if configured, otherwise use minimal defaults.
-		info := Info{
-			Title:   "API Documentation",
-			Version: "1.0.0",
-		}
-		if r.info != nil {
-			info = *r.info
-		}
-
 		// Generate OpenAPI document.
-		doc, err := r.GenerateOpenAPI(info)
+		doc, err := r.GenerateOpenAPI(r.openAPIInfo(), opts...)
 		if err != nil {
 			return err
 		}
@@ -284,6 +589,17 @@ func (r *Router) ServeOpenAPI(path string) {
 	})
 }
 
+// openAPIInfo returns the info set with WithInfo, or minimal defaults.
+func (r *Router) openAPIInfo() Info {
+	if r.info != nil {
+		return *r.info
+	}
+	return Info{
+		Title:   "API Documentation",
+		Version: "1.0.0",
+	}
+}
+
 // Group creates a new route group with the given path prefix and optional middleware.
 // Groups allow organizing routes hierarchically and applying middleware to specific route sets.
 //
@@ -399,9 +715,26 @@ func (r *Router) OPTIONS(path string, handler HandlerFunc) {
 //   - Static segments: /users
 //   - Named parameters: /users/:id
 //   - Catch-all parameters: /files/*path
+//   - Mid-path catch-alls, matching one or more segments: /registry/*image/manifests/:ref
+//   - Trailing optional parameters: /users/:id/:action? (also matches /users/:id)
+//
+// At each segment, static segments take precedence over parameters, and
+// parameters and catch-alls are mutually exclusive. A mid-path catch-all
+// captures the most segments that still let the rest of the path match.
+// Missing optional parameters are "" in Context.Param.
 //
 // Panics if method or path is empty, or if handler is nil.
 //
+// Concurrency: routes can be registered (and removed with Remove) while
+// the router serves requests, e.g. by plugins or admin endpoints. Each
+// change publishes a new route table atomically: a request is routed
+// either with or without the route, never with a partially updated tree,
+// and ServeHTTP takes no lock. Routes, GenerateOpenAPI and Validate read
+// a snapshot of the route metadata. Registration calls are serialized, but
+// route metadata set after registration (e.g., by the generic handlers)
+// assumes a single goroutine registers routes at a time. Middleware (Use),
+// options and Override/Decorate must still be set before serving.
+//
 // Example:
 //
 //	router.Handle("GET", "/users/:id", func(c *fursy.Box) error {
@@ -423,7 +756,38 @@ func (r *Router) Handle(method, path string, handler HandlerFunc) {
 //	    Description: "Returns a single user",
 //	    Tags:        []string{"users"},
 //	})
+//
+// RouteOptions can also declare operational settings (Timeout, MaxBodySize,
+// RateLimit, Cost, RequireAuth, RequireIfMatch) that the router enforces for this
+// route and documents in the OpenAPI output:
+//
+//	router.HandleWithOptions("POST", "/uploads", upload, &RouteOptions{
+//	    Summary:     "Upload file",
+//	    Timeout:     30 * time.Second,
+//	    MaxBodySize: 10 << 20,
+//	    RateLimit:   &RateLimitPolicy{Rate: 2, Burst: 5},
+//	    RequireAuth: true,
+//	})
+//
+// Response headers are declared with ResponseHeaders (all 2xx responses)
+// or RouteResponse.Headers (one response):
+//
+//	router.HandleWithOptions("POST", "/users", create, &RouteOptions{
+//	    ResponseHeaders: []RouteHeader{
+//	        {Name: "X-RateLimit-Remaining", Type: reflect.TypeFor[int]()},
+//	    },
+//	    Responses: map[int]RouteResponse{
+//	        201: {Description: "Created", Headers: []RouteHeader{{Name: "Location", Required: true}}},
+//	    },
+//	})
 func (r *Router) HandleWithOptions(method, path string, handler HandlerFunc, opts *RouteOptions) {
+	r.handleRoute(method, path, handler, opts, nil)
+}
+
+// handleRoute registers a route with opts. The metadata of the route is
+// completed by update (if not nil) under the registration lock, so that
+// concurrent registrations cannot interleave.
+func (r *Router) handleRoute(method, path string, handler HandlerFunc, opts *RouteOptions, update func(route *RouteInfo)) {
 	if method == "" {
 		panic("fursy: HTTP method cannot be empty")
 	}
@@ -434,22 +798,70 @@ func (r *Router) HandleWithOptions(method, path string, handler HandlerFunc, opt
 		panic("fursy: handler cannot be nil")
 	}
 
-	// Get or create tree for this method.
-	tree := r.trees[method]
-	if tree == nil {
-		tree = radix.New()
-		r.trees[method] = tree
-	}
+	r.routeMu.Lock()
+	defer r.routeMu.Unlock()
+
+	handler, slot := r.wrapRoutePolicies(method, path, handler, opts)
 
 	// Insert route into radix tree.
-	if err := tree.Insert(path, handler); err != nil {
-		panic("fursy: " + err.Error())
+	var match *Matcher
+	if opts != nil {
+		match = opts.Match
+	}
+	name := opts.routeName()
+	set := r.insertRoute(method, path, name, match, handler)
+	if !opts.hasPolicies() {
+		slot.set = set
 	}
+	r.addRouteSlot(method, path, slot)
 
 	// Store route metadata for OpenAPI generation.
+	routeInfo := newRouteInfo(method, path, name, match, opts)
+	if update != nil {
+		update(&routeInfo)
+	}
+
+	r.addRouteInfo(routeInfo)
+}
+
+// wrapRoutePolicies wraps handler with the operational route options of
+// opts. Policies call the handler through the returned slot so that an
+// override still runs inside them.
+// It must be called with routeMu held.
+func (r *Router) wrapRoutePolicies(method, path string, handler HandlerFunc, opts *RouteOptions) (HandlerFunc, *routeSlot) {
+	slot := &routeSlot{handler: handler}
+	if !opts.hasPolicies() {
+		return handler, slot
+	}
+
+	if r.rateLimitGroups == nil {
+		r.rateLimitGroups = make(map[string]*routeLimiterStore)
+	}
+	handler = applyRoutePolicies(slot.serve, opts, r.rateLimitGroups)
+	if opts.Deprecated || !opts.Sunset.IsZero() {
+		handler = r.deprecationPolicy(handler, method, path, opts)
+	}
+	return handler, slot
+}
+
+// routeName returns the route name of opts: Name, or OperationID if unset.
+func (opts *RouteOptions) routeName() string {
+	if opts == nil {
+		return ""
+	}
+	if opts.Name != "" {
+		return opts.Name
+	}
+	return opts.OperationID
+}
+
+// newRouteInfo returns the metadata of a route registered with opts.
+func newRouteInfo(method, path, name string, match *Matcher, opts *RouteOptions) RouteInfo {
 	routeInfo := RouteInfo{
 		Method: method,
 		Path:   path,
+		Name:   name,
+		Match:  match,
 	}
 
 	if opts != nil {
@@ -457,12 +869,30 @@ func (r *Router) HandleWithOptions(method, path string, handler HandlerFunc, opt
 		routeInfo.Description = opts.Description
 		routeInfo.Tags = opts.Tags
 		routeInfo.OperationID = opts.OperationID
-		routeInfo.Deprecated = opts.Deprecated
+		routeInfo.Deprecated = opts.Deprecated || !opts.Sunset.IsZero()
+		routeInfo.Sunset = opts.Sunset
+		routeInfo.DeprecationLink = opts.DeprecationLink
 		routeInfo.Parameters = opts.Parameters
 		routeInfo.Responses = opts.Responses
+		routeInfo.ResponseHeaders = opts.ResponseHeaders
+		routeInfo.Timeout = opts.Timeout
+		routeInfo.MaxBodySize = opts.MaxBodySize
+		routeInfo.MaxMultipartMemory = opts.MaxMultipartMemory
+		routeInfo.RateLimit = opts.RateLimit
+		routeInfo.Cost = opts.Cost
+		routeInfo.RequireAuth = opts.RequireAuth
+		routeInfo.Public = opts.Public
+		routeInfo.RequireIfMatch = opts.RequireIfMatch
+		routeInfo.Fields = opts.Fields
+		routeInfo.Localize = opts.Localize
+		if len(opts.Fields) > 0 {
+			routeInfo.Parameters = append(slices.Clip(routeInfo.Parameters), fieldsParameter(opts.Fields))
+		}
+		routeInfo.ExternalDocs = opts.ExternalDocs
+		routeInfo.CodeSamples = opts.CodeSamples
+		routeInfo.Extensions = opts.Extensions
 	}
-
-	r.routes = append(r.routes, routeInfo)
+	return routeInfo
 }
 
 // handleWithGroupMiddleware registers a route with group middleware.
@@ -470,7 +900,10 @@ func (r *Router) HandleWithOptions(method, path string, handler HandlerFunc, opt
 //
 // The groupHandlers slice contains: group.middleware + handler
 // These will be combined with router.middleware in ServeHTTP.
-func (r *Router) handleWithGroupMiddleware(method, path string, groupHandlers []HandlerFunc) {
+// The route policies of opts wrap the handler, after the group middleware.
+// The metadata of the route is completed by update (if not nil) under the
+// registration lock.
+func (r *Router) handleWithGroupMiddleware(g *RouteGroup, method, path string, groupHandlers []HandlerFunc, match *Matcher, opts *RouteOptions, update func(route *RouteInfo)) {
 	if method == "" {
 		panic("fursy: HTTP method cannot be empty")
 	}
@@ -481,48 +914,91 @@ func (r *Router) handleWithGroupMiddleware(method, path string, groupHandlers []
 		panic("fursy: groupHandlers cannot be empty")
 	}
 
-	// Create a wrapper handler that executes group middleware + handler
-	wrapper := r.createGroupHandlerWrapper(groupHandlers)
+	r.routeMu.Lock()
+	defer r.routeMu.Unlock()
+	r.addGroupRoute(g, method, path, groupHandlers, match, opts, update)
+}
 
-	// Get or create tree for this method.
-	tree := r.trees[method]
-	if tree == nil {
-		tree = radix.New()
-		r.trees[method] = tree
+// addGroupRoute registers a group route (see handleWithGroupMiddleware).
+// It must be called with routeMu held.
+func (r *Router) addGroupRoute(g *RouteGroup, method, path string, groupHandlers []HandlerFunc, match *Matcher, opts *RouteOptions, update func(route *RouteInfo)) {
+	last := len(groupHandlers) - 1
+	handler, slot := r.wrapRoutePolicies(method, path, groupHandlers[last], opts)
+	groupHandlers[last] = handler
+	if !opts.hasPolicies() {
+		slot.set = func(h HandlerFunc) { groupHandlers[last] = h }
 	}
+	if opts != nil && opts.Match != nil {
+		match = opts.Match
+	}
+
+	// Insert route into radix tree with a wrapper that executes group
+	// middleware + handler.
+	name := opts.routeName()
+	r.insertRoute(method, path, name, match, r.createGroupHandlerWrapper(g, groupHandlers))
+	r.addRouteSlot(method, path, slot)
 
-	// Insert route into radix tree with the wrapper.
-	if err := tree.Insert(path, wrapper); err != nil {
-		panic("fursy: " + err.Error())
+	routeInfo := newRouteInfo(method, path, name, match, opts)
+	routeInfo.groupMiddleware = groupHandlers[:last]
+	if update != nil {
+		update(&routeInfo)
 	}
+	r.addRouteInfo(routeInfo)
 }
 
 // createGroupHandlerWrapper creates a handler that executes group middleware + handler.
 // This wrapper will be called as part of the router middleware chain.
 //
 // Execution order in ServeHTTP: router.middleware → wrapper (group.middleware → handler).
-func (r *Router) createGroupHandlerWrapper(groupHandlers []HandlerFunc) HandlerFunc {
-	return func(c *Context) error {
-		// Save current middleware chain state
-		savedHandlers := c.handlers
-		savedIndex := c.index
-		savedAborted := c.aborted
-
-		// Build group middleware chain
-		c.handlers = groupHandlers
-		c.index = -1
-		c.aborted = false
-
-		// Execute group middleware chain
-		err := c.Next()
-
-		// Restore router middleware chain state
-		c.handlers = savedHandlers
-		c.index = savedIndex
-		c.aborted = savedAborted
+//
+// The wrapper also installs the group's error handler (if any) on the context,
+// so errors from this route are handled by the most specific error handler.
+func (r *Router) createGroupHandlerWrapper(g *RouteGroup, groupHandlers []HandlerFunc) HandlerFunc {
+	return (&groupChain{group: g, handlers: groupHandlers}).serve
+}
 
-		return err
+// groupChain executes the group middleware and handler of a group route.
+type groupChain struct {
+	group    *RouteGroup
+	handlers []HandlerFunc
+}
+
+// groupChainServe is the code pointer shared by all group wrappers,
+// used to exclude them from middleware timing (see SetMiddlewareTiming).
+var groupChainServe uintptr
+
+func init() {
+	// Set in init: serve refers to groupChainServe through Context.Next.
+	groupChainServe = reflect.ValueOf(HandlerFunc((&groupChain{}).serve)).Pointer()
+}
+
+// serve runs the group chain.
+func (gc *groupChain) serve(c *Context) error {
+	// Install group error handler (resolved at request time so it can be
+	// set after routes are registered).
+	if h := gc.group.resolveErrorHandler(); h != nil {
+		c.errorHandler = h
 	}
+
+	// Save current middleware chain state
+	savedHandlers := c.handlers
+	savedIndex := c.index
+	savedAborted := c.aborted
+
+	// Build group middleware chain
+	c.handlers = gc.handlers
+	c.index = -1
+	c.aborted = false
+
+	// Execute group middleware chain
+	err := c.Next()
+
+	// Restore router middleware chain state
+	c.handlers = savedHandlers
+	c.index = savedIndex
+	c.aborted = savedAborted
+
+	return err
 }
 
 // ServeHTTP implements http.Handler interface, making Router compatible
@@ -542,36 +1018,91 @@ func (r *Router) createGroupHandlerWrapper(groupHandlers []HandlerFunc) HandlerF
 // Returns 405 Method Not Allowed if the path exists but for a different method
 // (when handleMethodNotAllowed is enabled).
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.inFlight.Add(1)
+	r.stats.requests.Add(1)
+
 	// Get context from pool.
 	c := r.pool.Get().(*Context)
 	defer func() {
 		c.reset()
 		r.pool.Put(c)
+		r.inFlight.Add(-1)
 	}()
 
+	if h := r.hooks; h != nil {
+		start := time.Now()
+		c.init(w, req, r, nil)
+		hw := h.startRequest(c)
+		w = hw
+		defer h.finishRequest(c, hw, start)
+	}
+
 	path := req.URL.Path
+	if r.paramDecoding != ParamDecodePath {
+		path = req.URL.EscapedPath()
+	}
 
-	// Get tree for this HTTP method.
-	tree := r.trees[req.Method]
-	if tree == nil {
-		if r.handleMethodNotAllowed {
-			// Check if path exists in other methods.
-			if r.pathExistsInOtherMethods(path, req.Method) {
+	// Handle paths with duplicate slashes or dot segments.
+	if r.cleanPath != PathCleanNone {
+		if cleaned := cleanPath(path); cleaned != path {
+			switch r.cleanPath {
+			case PathCleanRedirect:
+				c.init(w, req, r, nil)
+				redirectPath(c, cleaned)
+				return
+			case PathCleanReject:
 				c.init(w, req, r, nil)
-				_ = c.String(http.StatusMethodNotAllowed, "Method Not Allowed")
+				_ = c.Problem(BadRequest("Path is not in canonical form"))
 				return
+			default:
+				// Middleware (skippers, auth) must see the routed path.
+				path = cleaned
+				req = withPath(req, cleaned, r.paramDecoding != ParamDecodePath)
 			}
 		}
-		c.init(w, req, r, nil)
-		_ = c.String(http.StatusNotFound, "Not Found")
-		return
 	}
 
-	// Lookup route in radix tree.
-	handler, params, found := tree.Lookup(path)
+	// Get tree for this HTTP method and lookup route in radix tree.
+	var (
+		handler any
+		params  []radix.Param
+		found   bool
+	)
+	if tree := r.routeTrees()[req.Method]; tree != nil {
+		handler, params, found = tree.Lookup(path)
+
+		// Try the path with the trailing slash toggled.
+		if !found && r.trailingSlash != TrailingSlashStrict {
+			if alt, ok := toggleTrailingSlash(path); ok && !strings.HasPrefix(alt, "//") {
+				handler, params, found = tree.Lookup(alt)
+				if found && r.trailingSlash == TrailingSlashRedirect {
+					c.init(w, req, r, nil)
+					redirectPath(c, alt)
+					return
+				}
+			}
+		}
+	}
 	if !found {
 		c.init(w, req, r, nil)
-		_ = c.String(http.StatusNotFound, "Not Found")
+		// Answer OPTIONS requests for paths without an OPTIONS route.
+		if req.Method == http.MethodOptions && r.handleOPTIONS {
+			if allow := r.allowedMethods(path); len(allow) > 0 {
+				r.serveAutoOPTIONS(c, allow)
+				return
+			}
+		}
+		// Check if path exists in other methods.
+		if r.handleMethodNotAllowed && r.pathExistsInOtherMethods(path, req.Method) {
+			c.SetHeader("Allow", strings.Join(r.allowedMethods(path), ", "))
+			if c.hasErrorPages() {
+				_ = c.Problem(MethodNotAllowed(""))
+				return
+			}
+			_ = c.String(http.StatusMethodNotAllowed, "Method Not Allowed")
+			return
+		}
+		r.handleNotFound(c)
 		return
 	}
 
@@ -579,6 +1110,11 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	// Reuse pre-allocated params buffer from context (zero allocation).
 	c.params = c.params[:0] // Reset length, keep capacity.
 	for _, p := range params {
+		if r.paramDecoding == ParamDecodeSegments {
+			if v, err := url.PathUnescape(p.Value); err == nil {
+				p.Value = v
+			}
+		}
 		c.params = append(c.params, Param{Key: p.Key, Value: p.Value})
 	}
 
@@ -587,7 +1123,10 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
 	// Build handler chain: middleware + route handler.
 	// Reuse pre-allocated handlers buffer from context (zero allocation).
-	routeHandler := handler.(HandlerFunc)
+	entry := handler.(*routeEntry)
+	c.route = entry.pattern
+	c.routeName = entry.name
+	routeHandler := entry.handler
 	c.handlers = c.handlers[:0] // Reset length, keep capacity.
 	c.handlers = append(c.handlers, r.middleware...)
 	c.handlers = append(c.handlers, routeHandler)
@@ -595,18 +1134,63 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	c.aborted = false
 
 	// Execute middleware chain.
-	if err := c.Next(); err != nil {
-		// If handler returned an error and hasn't written a response,
-		// send a 500 Internal Server Error.
-		// In the future, this will call custom ErrorHandler.
-		_ = c.String(http.StatusInternalServerError, "Internal Server Error")
+	if r.profileLabels {
+		r.serveWithProfileLabels(c)
+		return
+	}
+	// Errors are delegated to the most specific error handler.
+	r.finishChain(c, c.Next())
+}
+
+// handleError calls the most specific error handler for the request:
+// group error handler → router error handler → default 500 response.
+func (r *Router) handleError(c *Context, err error) {
+	c.notifyError(err)
+
+	switch {
+	case c.errorHandler != nil:
+		c.errorHandler(c, err)
+	case r.errorHandler != nil:
+		r.errorHandler(c, err)
+	default:
+		defaultErrorHandler(c, err)
+	}
+}
+
+// handleNotFound calls the most specific NotFound handler for the request path:
+// group with the longest matching prefix → router NotFound handler → default 404 response.
+func (r *Router) handleNotFound(c *Context) {
+	handler := r.notFoundHandler
+
+	var group *RouteGroup
+	for _, g := range r.notFoundGroups {
+		if g.hasPathPrefix(c.Request.URL.Path) && (group == nil || len(g.prefix) > len(group.prefix)) {
+			group = g
+		}
+	}
+	if group != nil {
+		handler = group.notFoundHandler
+		c.errorHandler = group.resolveErrorHandler()
+	}
+
+	if handler == nil {
+		if c.hasErrorPages() {
+			_ = c.Problem(NotFound(""))
+			return
+		}
+		_ = c.String(http.StatusNotFound, "Not Found")
+		return
+	}
+
+	if err := handler(c); err != nil {
+		r.handleError(c, err)
 	}
 }
 
 // pathExistsInOtherMethods checks if a path exists in other HTTP methods.
 // Used for 405 Method Not Allowed responses.
 func (r *Router) pathExistsInOtherMethods(path, method string) bool {
-	for m, tree := range r.trees {
+	for m, tree := range r.routeTrees() {
 		if m != method {
 			_, _, found := tree.Lookup(path)
 			if found {
@@ -743,7 +1327,14 @@ func (r *Router) Shutdown(ctx context.Context) error {
 //	})
 //
 //	// Now router.Shutdown() will shutdown srv
+//
+// SetServer also installs the router's connection tracking into
+// srv.ConnState (chaining any existing ConnState), which drives
+// OnConnOpen, OnConnClose and OpenConns.
 func (r *Router) SetServer(srv *http.Server) {
+	if srv != nil && srv != r.server {
+		srv.ConnState = r.connStateHook(srv.ConnState)
+	}
 	r.server = srv
 }
 
@@ -752,7 +1343,7 @@ func (r *Router) SetServer(srv *http.Server) {
 // This is a convenience method that:
 //  1. Creates an http.Server with the given address
 //  2. Listens for SIGTERM and SIGINT signals (Kubernetes/Docker compatible)
-//  3. Starts the server in a goroutine
+//  3. Starts the server in a goroutine (see SetListenConfig for listener tuning)
 //  4. Blocks until shutdown signal is received
 //  5. Calls Shutdown() with the specified timeout (default: 30s)
 //
@@ -832,12 +1423,29 @@ func (r *Router) ListenAndServeWithShutdown(addr string, timeout ...time.Duratio
 	// Channel to receive server startup errors.
 	serverErr := make(chan error, 1)
 
-	// Start server in goroutine.
-	go func() {
-		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			serverErr <- err
+	// Start server in goroutine (one per listener with a ListenConfig).
+	if r.listenConfig.isZero() {
+		go func() {
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serverErr <- err
+			}
+		}()
+	} else {
+		listeners, err := r.listenConfig.Listen(ctx, addr)
+		if err != nil {
+			return err
 		}
-	}()
+		for _, ln := range listeners {
+			go func() {
+				if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					select {
+					case serverErr <- err:
+					default:
+					}
+				}
+			}()
+		}
+	}
 
 	// Wait for shutdown signal or server error.
 	select {
@@ -1,8 +1,10 @@
 package fursy
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -330,3 +332,36 @@ func BenchmarkContext_Pooling(b *testing.B) {
 		router.ServeHTTP(w, req)
 	}
 }
+
+// BenchmarkRouter_GenericHandler benchmarks a generic handler binding a JSON body.
+func BenchmarkRouter_GenericHandler(b *testing.B) {
+	benchmarkGenericHandler(b, New())
+}
+
+// BenchmarkRouter_GenericHandler_PooledBodies benchmarks a generic handler
+// with request body pooling.
+func BenchmarkRouter_GenericHandler_PooledBodies(b *testing.B) {
+	benchmarkGenericHandler(b, NewWithOptions(WithRequestBodyPooling(true)))
+}
+
+func benchmarkGenericHandler(b *testing.B, router *Router) {
+	type request struct {
+		Name string `json:"name"`
+	}
+	POST[request, Empty](router, "/users", func(c *Box[request, Empty]) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	body := strings.NewReader(`{"name":"John"}`)
+	req := httptest.NewRequest("POST", "/users", body)
+	req.Header.Set("Content-Type", MIMEApplicationJSON)
+	w := httptest.NewRecorder()
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = body.Seek(0, io.SeekStart)
+		router.ServeHTTP(w, req)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net"
+	"net/http"
+)
+
+// OnConnOpen registers a function to be called when the server accepts a
+// new connection.
+//
+// Hooks run synchronously on the connection's goroutine before the first
+// request is read, so they should be fast. Closing conn in a hook rejects
+// the connection (e.g., to enforce per-IP connection limits).
+//
+// Connection hooks require the router to observe connection state: use
+// ListenAndServeWithShutdown, SetServer, or set http.Server.ConnState to
+// Router.ConnState yourself.
+//
+// OnConnOpen is safe for concurrent use.
+//
+// Example:
+//
+//	var perIP sync.Map // IP -> *atomic.Int64
+//
+//	router.OnConnOpen(func(conn net.Conn) {
+//	    ip, _, _ := net.SplitHostPort(conn.RemoteAddr().String())
+//	    n, _ := perIP.LoadOrStore(ip, new(atomic.Int64))
+//	    if n.(*atomic.Int64).Add(1) > 20 {
+//	        conn.Close()
+//	    }
+//	})
+//	router.OnConnClose(func(conn net.Conn) {
+//	    ip, _, _ := net.SplitHostPort(conn.RemoteAddr().String())
+//	    if n, ok := perIP.Load(ip); ok {
+//	        n.(*atomic.Int64).Add(-1)
+//	    }
+//	})
+func (r *Router) OnConnOpen(f func(conn net.Conn)) {
+	if f == nil {
+		return
+	}
+	r.connMu.Lock()
+	defer r.connMu.Unlock()
+	r.connOpenHooks = append(r.connOpenHooks, f)
+}
+
+// OnConnClose registers a function to be called when a connection accepted
+// by the server is closed.
+//
+// Hijacked connections (e.g., WebSocket) are reported as closed when they
+// are hijacked, because the server no longer tracks them afterwards.
+//
+// See OnConnOpen for how connection state is observed.
+//
+// OnConnClose is safe for concurrent use.
+func (r *Router) OnConnClose(f func(conn net.Conn)) {
+	if f == nil {
+		return
+	}
+	r.connMu.Lock()
+	defer r.connMu.Unlock()
+	r.connCloseHooks = append(r.connCloseHooks, f)
+}
+
+// ConnState tracks connection state and runs connection hooks.
+// Its signature matches http.Server.ConnState.
+//
+// SetServer and ListenAndServeWithShutdown install it automatically.
+// Set it yourself when the router serves through a server that is not
+// passed to SetServer.
+//
+// Example:
+//
+//	srv := &http.Server{
+//	    Addr:      ":8080",
+//	    Handler:   router,
+//	    ConnState: router.ConnState,
+//	}
+func (r *Router) ConnState(conn net.Conn, state http.ConnState) {
+	var hooks []func(net.Conn)
+
+	switch state {
+	case http.StateNew:
+		r.openConns.Add(1)
+		r.connMu.RLock()
+		hooks = r.connOpenHooks
+		r.connMu.RUnlock()
+	case http.StateClosed, http.StateHijacked:
+		r.openConns.Add(-1)
+		r.connMu.RLock()
+		hooks = r.connCloseHooks
+		r.connMu.RUnlock()
+	default:
+		return
+	}
+
+	for _, hook := range hooks {
+		hook(conn)
+	}
+}
+
+// OpenConns returns the number of open connections observed by ConnState.
+//
+// Use it with InFlight to build connection draining dashboards.
+//
+// Example:
+//
+//	router.GET("/debug/conns", func(c *fursy.Context) error {
+//	    return c.JSON(200, map[string]int64{
+//	        "open_conns": router.OpenConns(),
+//	        "in_flight":  router.InFlight(),
+//	    })
+//	})
+func (r *Router) OpenConns() int64 {
+	return r.openConns.Load()
+}
+
+// InFlight returns the number of requests currently being served by the router.
+//
+// Long-lived requests (SSE streams, WebSocket handlers) are counted until
+// their handler returns. During Shutdown, InFlight dropping to zero means
+// all requests have been drained.
+func (r *Router) InFlight() int64 {
+	return r.inFlight.Load()
+}
+
+// connStateHook returns a ConnState function that runs Router.ConnState
+// followed by next (if any).
+func (r *Router) connStateHook(next func(net.Conn, http.ConnState)) func(net.Conn, http.ConnState) {
+	if next == nil {
+		return r.ConnState
+	}
+	return func(conn net.Conn, state http.ConnState) {
+		r.ConnState(conn, state)
+		next(conn, state)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newConnTestServer starts a test server whose http.Server is set with SetServer.
+func newConnTestServer(t *testing.T, router *Router) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewUnstartedServer(router)
+	router.SetServer(ts.Config)
+	ts.Start()
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// waitFor polls cond until it returns true or the timeout expires.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before timeout")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestRouter_ConnHooks tests that open and close hooks run for each connection.
+func TestRouter_ConnHooks(t *testing.T) {
+	router := New()
+	router.GET("/", func(c *Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+
+	var opened, closed atomic.Int32
+	router.OnConnOpen(func(net.Conn) { opened.Add(1) })
+	router.OnConnClose(func(net.Conn) { closed.Add(1) })
+	router.OnConnOpen(nil) // Ignored.
+
+	ts := newConnTestServer(t, router)
+
+	client := &http.Client{Transport: &http.Transport{}}
+	resp, err := client.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	if got := opened.Load(); got != 1 {
+		t.Errorf("opened = %d, want 1", got)
+	}
+	if got := router.OpenConns(); got != 1 {
+		t.Errorf("OpenConns() = %d, want 1", got)
+	}
+
+	client.CloseIdleConnections()
+	waitFor(t, func() bool { return closed.Load() == 1 })
+
+	if got := router.OpenConns(); got != 0 {
+		t.Errorf("OpenConns() = %d, want 0", got)
+	}
+}
+
+// TestRouter_ConnHooks_RejectConnection tests closing a connection from OnConnOpen.
+func TestRouter_ConnHooks_RejectConnection(t *testing.T) {
+	router := New()
+	router.GET("/", func(c *Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+	router.OnConnOpen(func(conn net.Conn) {
+		_ = conn.Close()
+	})
+
+	ts := newConnTestServer(t, router)
+
+	resp, err := ts.Client().Get(ts.URL)
+	if err == nil {
+		_ = resp.Body.Close()
+		t.Fatal("expected request on rejected connection to fail")
+	}
+}
+
+// TestRouter_InFlight tests the in-flight request gauge.
+func TestRouter_InFlight(t *testing.T) {
+	router := New()
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	router.GET("/slow", func(c *Context) error {
+		entered <- struct{}{}
+		<-release
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	ts := newConnTestServer(t, router)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		resp, err := ts.Client().Get(ts.URL + "/slow")
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+
+	<-entered
+	if got := router.InFlight(); got != 1 {
+		t.Errorf("InFlight() = %d, want 1", got)
+	}
+
+	close(release)
+	<-done
+
+	if got := router.InFlight(); got != 0 {
+		t.Errorf("InFlight() = %d, want 0", got)
+	}
+}
+
+// TestRouter_SetServer_ChainsConnState tests that an existing ConnState is preserved.
+func TestRouter_SetServer_ChainsConnState(t *testing.T) {
+	router := New()
+
+	var states atomic.Int32
+	srv := &http.Server{
+		ConnState: func(net.Conn, http.ConnState) { states.Add(1) },
+	}
+	router.SetServer(srv)
+	router.SetServer(srv) // Same server: hook is not installed twice.
+
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	srv.ConnState(server, http.StateNew)
+
+	if got := states.Load(); got != 1 {
+		t.Errorf("existing ConnState called %d times, want 1", got)
+	}
+	if got := router.OpenConns(); got != 1 {
+		t.Errorf("OpenConns() = %d, want 1", got)
+	}
+
+	srv.ConnState(server, http.StateHijacked)
+
+	if got := router.OpenConns(); got != 0 {
+		t.Errorf("OpenConns() after hijack = %d, want 0", got)
+	}
+}
@@ -4,7 +4,16 @@
 
 package fursy
 
-import "net/http"
+import (
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/coregx/fursy/internal/binding"
+)
 
 // GET registers a type-safe handler for GET requests to the specified path.
 //
@@ -27,13 +36,14 @@ import "net/http"
 //	    user := db.GetUser(id)
 //	    return c.OK(UserResponse{ID: user.ID, Name: user.Name})
 //	})
-func GET[Req, Res any](r *Router, path string, handler Handler[Req, Res]) {
-	r.Handle(http.MethodGet, path, adaptGenericHandler(handler))
+func GET[Req, Res any](r *Router, path string, handler Handler[Req, Res], opts ...GenericOption) {
+	handleGeneric(r, http.MethodGet, path, handler, opts)
 }
 
 // POST registers a type-safe handler for POST requests to the specified path.
 //
 // The handler will receive a Box[Req, Res] with automatically bound request body.
+// Options such as Status declare the success status of the route.
 //
 // Example:
 //
@@ -52,8 +62,8 @@ func GET[Req, Res any](r *Router, path string, handler Handler[Req, Res]) {
 //	    user := db.CreateUser(req.Name, req.Email)
 //	    return c.Created("/users/"+user.ID, UserResponse{ID: user.ID, Name: user.Name})
 //	})
-func POST[Req, Res any](r *Router, path string, handler Handler[Req, Res]) {
-	r.Handle(http.MethodPost, path, adaptGenericHandler(handler))
+func POST[Req, Res any](r *Router, path string, handler Handler[Req, Res], opts ...GenericOption) {
+	handleGeneric(r, http.MethodPost, path, handler, opts)
 }
 
 // PUT registers a type-safe handler for PUT requests to the specified path.
@@ -73,8 +83,8 @@ func POST[Req, Res any](r *Router, path string, handler Handler[Req, Res]) {
 //	    user := db.UpdateUser(id, req.Name, req.Email)
 //	    return c.OK(UserResponse{ID: user.ID, Name: user.Name})
 //	})
-func PUT[Req, Res any](r *Router, path string, handler Handler[Req, Res]) {
-	r.Handle(http.MethodPut, path, adaptGenericHandler(handler))
+func PUT[Req, Res any](r *Router, path string, handler Handler[Req, Res], opts ...GenericOption) {
+	handleGeneric(r, http.MethodPut, path, handler, opts)
 }
 
 // DELETE registers a type-safe handler for DELETE requests to the specified path.
@@ -88,8 +98,8 @@ func PUT[Req, Res any](r *Router, path string, handler Handler[Req, Res]) {
 //	    db.DeleteUser(id)
 //	    return c.NoContent(204)
 //	})
-func DELETE[Req, Res any](r *Router, path string, handler Handler[Req, Res]) {
-	r.Handle(http.MethodDelete, path, adaptGenericHandler(handler))
+func DELETE[Req, Res any](r *Router, path string, handler Handler[Req, Res], opts ...GenericOption) {
+	handleGeneric(r, http.MethodDelete, path, handler, opts)
 }
 
 // PATCH registers a type-safe handler for PATCH requests to the specified path.
@@ -109,8 +119,8 @@ func DELETE[Req, Res any](r *Router, path string, handler Handler[Req, Res]) {
 //	    user := db.PatchUser(id, req)
 //	    return c.OK(UserResponse{ID: user.ID, Name: user.Name})
 //	})
-func PATCH[Req, Res any](r *Router, path string, handler Handler[Req, Res]) {
-	r.Handle(http.MethodPatch, path, adaptGenericHandler(handler))
+func PATCH[Req, Res any](r *Router, path string, handler Handler[Req, Res], opts ...GenericOption) {
+	handleGeneric(r, http.MethodPatch, path, handler, opts)
 }
 
 // HEAD registers a type-safe handler for HEAD requests to the specified path.
@@ -127,8 +137,8 @@ func PATCH[Req, Res any](r *Router, path string, handler Handler[Req, Res]) {
 //	    }
 //	    return c.NoContent(404)
 //	})
-func HEAD[Req, Res any](r *Router, path string, handler Handler[Req, Res]) {
-	r.Handle(http.MethodHead, path, adaptGenericHandler(handler))
+func HEAD[Req, Res any](r *Router, path string, handler Handler[Req, Res], opts ...GenericOption) {
+	handleGeneric(r, http.MethodHead, path, handler, opts)
 }
 
 // OPTIONS registers a type-safe handler for OPTIONS requests to the specified path.
@@ -143,6 +153,239 @@ func HEAD[Req, Res any](r *Router, path string, handler Handler[Req, Res]) {
 //	    c.SetHeader("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE")
 //	    return c.NoContent(200)
 //	})
-func OPTIONS[Req, Res any](r *Router, path string, handler Handler[Req, Res]) {
-	r.Handle(http.MethodOptions, path, adaptGenericHandler(handler))
+func OPTIONS[Req, Res any](r *Router, path string, handler Handler[Req, Res], opts ...GenericOption) {
+	handleGeneric(r, http.MethodOptions, path, handler, opts)
+}
+
+// GenericOption configures a route registered with a type-safe handler
+// (GET, POST, etc.).
+type GenericOption func(*genericOptions)
+
+// genericOptions holds the options of a type-safe route.
+type genericOptions struct {
+	status int
+	name   string
+	fields []string
+	local  *Localization
+}
+
+// SuccessStatuser is implemented by response types that declare the
+// success status of their routes, e.g. 201 Created for a created resource.
+// The method is called on the zero value of the type.
+//
+// Example:
+//
+//	type UserCreated struct {
+//	    ID int `json:"id"`
+//	}
+//
+//	func (UserCreated) SuccessStatus() int { return http.StatusCreated }
+type SuccessStatuser interface {
+	SuccessStatus() int
+}
+
+// Status declares the success status of a type-safe route, overriding
+// the SuccessStatus method of the response type. It is the status of
+// Box.Send and Box.NegotiateRes (unless set with Box.Status) and of the
+// success response documented in OpenAPI.
+//
+// Default: the SuccessStatus of the response type, or 200 OK.
+//
+// Example:
+//
+//	fursy.POST[CreateUser, User](router, "/users", func(c *fursy.Box[CreateUser, User]) error {
+//	    return c.Location("/users/42").Send(User{ID: 42}) // 201 Created
+//	}, fursy.Status(http.StatusCreated))
+func Status(code int) GenericOption {
+	if code < 200 || code > 299 {
+		panic(fmt.Sprintf("fursy: success status %d is not 2xx", code))
+	}
+	return func(o *genericOptions) {
+		o.status = code
+	}
+}
+
+// RouteName names a type-safe route (see Context.RouteName).
+//
+// Example:
+//
+//	fursy.GET[GetUser, User](router, "/users/:id", getUser, fursy.RouteName("users.get"))
+func RouteName(name string) GenericOption {
+	return func(o *genericOptions) {
+		o.name = name
+	}
+}
+
+// Fields enables sparse fieldsets on a type-safe route: clients select the
+// fields of the response with the fields query parameter among paths
+// (see RouteOptions.Fields).
+//
+// Example:
+//
+//	fursy.GET[GetUser, User](router, "/users/:id", getUser, fursy.Fields("id", "name", "address.city"))
+func Fields(paths ...string) GenericOption {
+	return func(o *genericOptions) {
+		o.fields = paths
+	}
+}
+
+// Localize formats time and number fields of the responses of a
+// type-safe route for the request locale (see RouteOptions.Localize).
+//
+// Example:
+//
+//	fursy.GET[fursy.Empty, Stats](router, "/stats", stats, fursy.Localize(fursy.Localization{
+//	    Times:   []string{"updated_at"},
+//	    Numbers: []string{"revenue", "series.value"},
+//	}))
+func Localize(l Localization) GenericOption {
+	return func(o *genericOptions) {
+		o.local = &l
+	}
+}
+
+// successStatus returns the success status of a route with response type
+// Res: the Status option, the SuccessStatus method of Res, or 0 (200 OK).
+func successStatus[Res any](options genericOptions) int {
+	if options.status != 0 {
+		return options.status
+	}
+
+	var res Res
+	if s, ok := any(res).(SuccessStatuser); ok {
+		status := s.SuccessStatus()
+		if status < 200 || status > 299 {
+			panic(fmt.Sprintf("fursy: success status %d of %T is not 2xx", status, res))
+		}
+		return status
+	}
+	return 0
+}
+
+// handleGeneric registers a type-safe handler and records its request and
+// response types for OpenAPI generation (Empty is not documented).
+// Path and query tagged fields of Req are documented as parameters, and
+// Req is only documented as the request body if it has other fields.
+func handleGeneric[Req, Res any](r *Router, method, path string, handler Handler[Req, Res], opts []GenericOption) {
+	var options genericOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	status := successStatus[Res](options)
+	r.handleRoute(method, path, adaptGenericHandler(handler, status), &RouteOptions{Name: options.name, Fields: options.fields, Localize: options.local}, func(route *RouteInfo) {
+		route.SuccessStatus = status
+		if t := reflect.TypeFor[Req](); t != reflect.TypeFor[Empty]() {
+			route.bindType = t
+			route.Parameters = append(route.Parameters, urlParameters(t)...)
+			if hasBodyFields(t) {
+				route.RequestType = t
+			}
+		}
+		if t := reflect.TypeFor[Res](); t != reflect.TypeFor[Empty]() {
+			route.ResponseType = t
+		}
+	})
+}
+
+// urlParameters returns the path, query and header tagged fields of the
+// struct t as route parameters. Path parameters are always required, query
+// and header parameters if their validate tag contains "required".
+func urlParameters(t reflect.Type) []RouteParameter {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var params []RouteParameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if name, ok := urlFieldName(field, binding.TagPath); ok {
+			params = append(params, RouteParameter{Name: name, In: "path", Required: true, Type: field.Type})
+		} else if name, ok := urlFieldName(field, binding.TagQuery); ok {
+			required := slices.Contains(strings.Split(field.Tag.Get("validate"), ","), "required")
+			param := RouteParameter{Name: name, In: "query", Required: required, Type: field.Type}
+			_, comma := binding.FieldTag(field, binding.TagQuery)
+			param.Style, param.Explode = queryStyle(field.Type, comma)
+			params = append(params, param)
+		} else if name, ok := urlFieldName(field, binding.TagHeader); ok {
+			required := slices.Contains(strings.Split(field.Tag.Get("validate"), ","), "required")
+			params = append(params, RouteParameter{Name: textproto.CanonicalMIMEHeaderKey(name), In: "header", Required: required, Type: field.Type})
+		}
+	}
+	return params
+}
+
+// queryStyle returns the OpenAPI serialization of a query parameter of
+// type t following the binding conventions: repeated parameters for
+// slices (comma-separated lists with the comma tag option) and bracket
+// syntax for maps and structs. Other types use the default ("").
+func queryStyle(t reflect.Type, comma bool) (style string, explode bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return "form", !comma
+	case reflect.Map, reflect.Struct:
+		return "deepObject", true
+	default:
+		return "", false
+	}
+}
+
+// queryObjectSchema returns the schema of a query parameter of struct type
+// t bound with bracket syntax, whose properties are its query tagged fields.
+func queryObjectSchema(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	schema := &Schema{Type: schemaTypeObject, Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if name, ok := urlFieldName(field, binding.TagQuery); ok && field.IsExported() {
+			schema.Properties[name] = generateSchema(field.Type)
+		}
+	}
+	return schema
+}
+
+// hasBodyFields reports whether the struct t has exported fields that are
+// bound from the request body, i.e. not tagged path, query, header or json:"-".
+// Non-struct types are always bound from the body.
+func hasBodyFields(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return true
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || isURLField(field) || field.Tag.Get("json") == "-" {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// isURLField reports whether field is bound from a path, query or header parameter.
+func isURLField(field reflect.StructField) bool {
+	_, isPath := urlFieldName(field, binding.TagPath)
+	_, isQuery := urlFieldName(field, binding.TagQuery)
+	_, isHeader := urlFieldName(field, binding.TagHeader)
+	return isPath || isQuery || isHeader
+}
+
+// urlFieldName returns the parameter name of field for tag, if any.
+func urlFieldName(field reflect.StructField, tag string) (string, bool) {
+	name, _ := binding.FieldTag(field, tag)
+	return name, name != ""
 }
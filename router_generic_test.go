@@ -0,0 +1,102 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type statusItem struct {
+	ID int `json:"id"`
+}
+
+// createdItem declares 201 Created as its success status.
+type createdItem struct {
+	ID int `json:"id"`
+}
+
+func (createdItem) SuccessStatus() int { return http.StatusCreated }
+
+func TestGeneric_StatusOption(t *testing.T) {
+	r := New()
+	POST[Empty, statusItem](r, "/items", func(c *Box[Empty, statusItem]) error {
+		return c.Location("/items/1").Send(statusItem{ID: 1})
+	}, Status(http.StatusCreated))
+	DELETE[Empty, Empty](r, "/items/:id", func(c *Box[Empty, Empty]) error {
+		return c.NegotiateRes()
+	}, Status(http.StatusNoContent))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/items", nil))
+	if w.Code != http.StatusCreated || w.Header().Get("Location") != "/items/1" {
+		t.Errorf("expected 201 with Location, got %d %v", w.Code, w.Header())
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/items/1", nil))
+	if w.Code != http.StatusNoContent || w.Body.Len() != 0 {
+		t.Errorf("expected empty 204, got %d %q", w.Code, w.Body.String())
+	}
+
+	doc, err := r.GenerateOpenAPI(Info{Title: "Test", Version: "1.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	post := doc.Paths["/items"].Post
+	if _, ok := post.Responses["200"]; ok {
+		t.Error("expected no 200 response")
+	}
+	if created, ok := post.Responses["201"]; !ok || created.Content[MIMEApplicationJSON].Schema == nil {
+		t.Errorf("expected documented 201 response, got %+v", post.Responses)
+	}
+	if deleted, ok := doc.Paths["/items/{id}"].Delete.Responses["204"]; !ok || deleted.Content != nil {
+		t.Errorf("expected 204 response without content, got %+v", doc.Paths["/items/{id}"].Delete.Responses)
+	}
+}
+
+func TestGeneric_SuccessStatuser(t *testing.T) {
+	r := New()
+	POST[Empty, createdItem](r, "/items", func(c *Box[Empty, createdItem]) error {
+		return c.Send(createdItem{ID: 1})
+	})
+	PUT[Empty, createdItem](r, "/items/:id", func(c *Box[Empty, createdItem]) error {
+		return c.Send(createdItem{ID: 1})
+	}, Status(http.StatusOK))
+	PATCH[Empty, createdItem](r, "/items/:id", func(c *Box[Empty, createdItem]) error {
+		return c.Status(http.StatusAccepted).Send(createdItem{ID: 1})
+	})
+
+	for _, tt := range []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{http.MethodPost, "/items", http.StatusCreated},
+		{http.MethodPut, "/items/1", http.StatusOK},         // Status option wins.
+		{http.MethodPatch, "/items/1", http.StatusAccepted}, // Box.Status wins.
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+		if w.Code != tt.want || !strings.Contains(w.Body.String(), `"id":1`) {
+			t.Errorf("%s %s: expected %d, got %d %q", tt.method, tt.path, tt.want, w.Code, w.Body.String())
+		}
+	}
+
+	if routes := r.Routes(); routes[0].SuccessStatus != http.StatusCreated {
+		t.Errorf("expected route success status 201, got %d", routes[0].SuccessStatus)
+	}
+}
+
+func TestStatus_Invalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	Status(http.StatusNotFound)
+}
@@ -0,0 +1,188 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ListenConfig tunes the TCP listeners of ListenAndServeWithShutdown for
+// high connection rates. The zero value listens like http.Server.ListenAndServe.
+type ListenConfig struct {
+	// ReusePort sets SO_REUSEPORT on the listening socket, so several
+	// listeners (Acceptors) or processes can bind the same address and the
+	// kernel balances new connections between them.
+	// Supported on Linux and the BSDs (including macOS); Listen fails on
+	// other platforms.
+	// Default: false
+	ReusePort bool
+
+	// Acceptors is the number of listeners, each accepting connections in
+	// its own goroutine. Values > 1 require ReusePort.
+	// Default: 1
+	Acceptors int
+
+	// KeepAlive is the idle time of an accepted connection before TCP
+	// keep-alive probes are sent. Negative disables keep-alive.
+	// Default: 15s (Go default)
+	KeepAlive time.Duration
+
+	// KeepAliveInterval is the time between keep-alive probes.
+	// Default: 15s (Go default)
+	KeepAliveInterval time.Duration
+
+	// KeepAliveCount is the number of unanswered probes before the
+	// connection is dropped.
+	// Default: 9 (Go default)
+	KeepAliveCount int
+
+	// MaxConns limits the number of open connections per listener:
+	// when reached, the listener stops accepting until a connection is
+	// closed, leaving new connections in the kernel backlog (backpressure)
+	// instead of overloading the server.
+	// Default: 0 (unlimited)
+	MaxConns int
+}
+
+// SetListenConfig sets the listener tuning used by ListenAndServeWithShutdown.
+//
+// Example:
+//
+//	router.SetListenConfig(fursy.ListenConfig{
+//	    ReusePort: true,
+//	    Acceptors: runtime.GOMAXPROCS(0),
+//	    KeepAlive: 30 * time.Second,
+//	    MaxConns:  10000,
+//	})
+//	router.ListenAndServeWithShutdown(":8080")
+func (r *Router) SetListenConfig(cfg ListenConfig) *Router {
+	r.listenConfig = cfg
+	return r
+}
+
+// Listen creates the TCP listeners for addr described by cfg, e.g. to
+// serve them with your own http.Server (one Serve call per listener).
+//
+// Example:
+//
+//	cfg := fursy.ListenConfig{ReusePort: true, Acceptors: 4}
+//	listeners, err := cfg.Listen(ctx, ":8080")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, ln := range listeners {
+//	    go srv.Serve(ln)
+//	}
+func (cfg ListenConfig) Listen(ctx context.Context, addr string) ([]net.Listener, error) {
+	acceptors := max(cfg.Acceptors, 1)
+	if acceptors > 1 && !cfg.ReusePort {
+		return nil, errors.New("fursy: ListenConfig.Acceptors > 1 requires ReusePort")
+	}
+	if addr == "" {
+		addr = ":http"
+	}
+
+	var lc net.ListenConfig
+	if cfg.KeepAlive < 0 {
+		lc.KeepAlive = -1
+	} else {
+		lc.KeepAliveConfig = net.KeepAliveConfig{
+			Enable:   true,
+			Idle:     cfg.KeepAlive,
+			Interval: cfg.KeepAliveInterval,
+			Count:    cfg.KeepAliveCount,
+		}
+	}
+	if cfg.ReusePort {
+		lc.Control = func(_, _ string, c syscall.RawConn) error {
+			var sockErr error
+			if err := c.Control(func(fd uintptr) { sockErr = setReusePort(fd) }); err != nil {
+				return err
+			}
+			return sockErr
+		}
+	}
+
+	listeners := make([]net.Listener, 0, acceptors)
+	for range acceptors {
+		ln, err := lc.Listen(ctx, "tcp", addr)
+		if err != nil {
+			for _, l := range listeners {
+				_ = l.Close()
+			}
+			return nil, fmt.Errorf("fursy: listen %s: %w", addr, err)
+		}
+		// Bind the other acceptors to the port chosen for ":0".
+		addr = ln.Addr().String()
+
+		if cfg.MaxConns > 0 {
+			ln = newLimitListener(ln, cfg.MaxConns)
+		}
+		listeners = append(listeners, ln)
+	}
+	return listeners, nil
+}
+
+// isZero reports whether cfg is the zero value (plain ListenAndServe).
+func (cfg ListenConfig) isZero() bool {
+	return cfg == ListenConfig{}
+}
+
+// limitListener limits the number of open connections accepted by a listener.
+type limitListener struct {
+	net.Listener
+	sem  chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+func newLimitListener(ln net.Listener, n int) *limitListener {
+	return &limitListener{
+		Listener: ln,
+		sem:      make(chan struct{}, n),
+		done:     make(chan struct{}),
+	}
+}
+
+// Accept waits for a free connection slot, then accepts a connection.
+func (l *limitListener) Accept() (net.Conn, error) {
+	select {
+	case l.sem <- struct{}{}:
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		<-l.sem
+		return nil, err
+	}
+	return &limitConn{Conn: conn, release: func() { <-l.sem }}, nil
+}
+
+// Close closes the listener, unblocking a pending Accept.
+func (l *limitListener) Close() error {
+	l.once.Do(func() { close(l.done) })
+	return l.Listener.Close()
+}
+
+// limitConn releases its connection slot once when closed.
+type limitConn struct {
+	net.Conn
+	once    sync.Once
+	release func()
+}
+
+func (c *limitConn) Close() error {
+	err := c.Conn.Close()
+	c.once.Do(c.release)
+	return err
+}
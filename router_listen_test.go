@@ -0,0 +1,124 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"context"
+	"net"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestListenConfig_Acceptors tests SO_REUSEPORT listeners sharing an address.
+func TestListenConfig_Acceptors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SO_REUSEPORT is not supported on windows")
+	}
+
+	cfg := ListenConfig{ReusePort: true, Acceptors: 3, KeepAlive: 30 * time.Second}
+	listeners, err := cfg.Listen(context.Background(), "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer func() {
+		for _, ln := range listeners {
+			_ = ln.Close()
+		}
+	}()
+
+	if len(listeners) != 3 {
+		t.Fatalf("got %d listeners, want 3", len(listeners))
+	}
+	for _, ln := range listeners[1:] {
+		if ln.Addr().String() != listeners[0].Addr().String() {
+			t.Errorf("listener addr = %s, want %s", ln.Addr(), listeners[0].Addr())
+		}
+	}
+}
+
+// TestListenConfig_AcceptorsRequireReusePort tests the Acceptors validation.
+func TestListenConfig_AcceptorsRequireReusePort(t *testing.T) {
+	_, err := ListenConfig{Acceptors: 2}.Listen(context.Background(), "127.0.0.1:0")
+	if err == nil || !strings.Contains(err.Error(), "ReusePort") {
+		t.Errorf("Listen error = %v, want ReusePort error", err)
+	}
+
+	router := New().SetListenConfig(ListenConfig{Acceptors: 2})
+	if err := router.ListenAndServeWithShutdown("127.0.0.1:0"); err == nil {
+		t.Error("ListenAndServeWithShutdown: expected error")
+	}
+}
+
+// TestListenConfig_MaxConns tests accept backpressure.
+func TestListenConfig_MaxConns(t *testing.T) {
+	listeners, err := ListenConfig{MaxConns: 1, KeepAlive: -1}.Listen(context.Background(), "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	ln := listeners[0]
+	defer func() { _ = ln.Close() }()
+
+	accepted := make(chan net.Conn, 2)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- conn
+		}
+	}()
+
+	for range 2 {
+		conn, err := net.Dial("tcp", ln.Addr().String())
+		if err != nil {
+			t.Fatalf("Dial: %v", err)
+		}
+		defer func() { _ = conn.Close() }()
+	}
+
+	first := <-accepted
+	select {
+	case <-accepted:
+		t.Fatal("second connection accepted while the first is open")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	_ = first.Close()
+	select {
+	case conn := <-accepted:
+		_ = conn.Close()
+	case <-time.After(2 * time.Second):
+		t.Fatal("second connection not accepted after the first was closed")
+	}
+}
+
+// TestListenConfig_CloseUnblocksAccept tests closing a listener waiting for a slot.
+func TestListenConfig_CloseUnblocksAccept(t *testing.T) {
+	listeners, err := ListenConfig{MaxConns: 1}.Listen(context.Background(), "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	ln := listeners[0].(*limitListener)
+	ln.sem <- struct{}{} // Occupy the only slot.
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := ln.Accept()
+		done <- err
+	}()
+
+	_ = ln.Close()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("Accept: expected error after Close")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Accept not unblocked by Close")
+	}
+}
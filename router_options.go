@@ -0,0 +1,430 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+	"runtime/pprof"
+	"slices"
+	"strings"
+)
+
+// Router defaults, used when the corresponding option is not set.
+const (
+	// DefaultMaxMultipartMemory is the default memory limit for parsing
+	// multipart forms (32 MB). Larger parts are stored in temporary files.
+	DefaultMaxMultipartMemory = 32 << 20
+
+	// DefaultCharset is the default charset of text responses.
+	DefaultCharset = "utf-8"
+)
+
+// TrailingSlashPolicy controls requests whose path differs from a
+// registered route only by a trailing slash (e.g., "/users/" vs "/users").
+type TrailingSlashPolicy int
+
+// Trailing slash policies.
+const (
+	// TrailingSlashStrict treats "/users" and "/users/" as different paths:
+	// a request for an unregistered variant gets 404 Not Found (default).
+	TrailingSlashStrict TrailingSlashPolicy = iota
+
+	// TrailingSlashRedirect redirects to the registered variant
+	// (301 Moved Permanently for GET and HEAD, 308 Permanent Redirect otherwise).
+	TrailingSlashRedirect
+
+	// TrailingSlashMatch serves the registered variant without redirecting.
+	TrailingSlashMatch
+)
+
+// PathCleanPolicy controls requests whose path is not in canonical form:
+// duplicate slashes ("/users//7") or dot segments ("/a/./b", "/a/../b").
+type PathCleanPolicy int
+
+// Path clean policies.
+const (
+	// PathCleanNone routes the path as received (default).
+	PathCleanNone PathCleanPolicy = iota
+
+	// PathCleanMatch routes the cleaned path without redirecting. The
+	// request URL path is replaced by the cleaned path, so middleware
+	// (skippers, authentication) sees the path that was routed.
+	PathCleanMatch
+
+	// PathCleanRedirect redirects to the cleaned path
+	// (301 Moved Permanently for GET and HEAD, 308 Permanent Redirect otherwise).
+	PathCleanRedirect
+
+	// PathCleanReject rejects the request with 400 Bad Request.
+	PathCleanReject
+)
+
+// ParamDecoding controls the percent-decoding of path parameters.
+type ParamDecoding int
+
+// Path parameter decoding policies.
+const (
+	// ParamDecodePath routes the decoded request path (default), so an
+	// encoded slash ("%2F") separates segments like a slash.
+	ParamDecodePath ParamDecoding = iota
+
+	// ParamDecodeSegments routes the encoded request path and decodes each
+	// parameter, so "/files/a%2Fb" matches "/files/:name" with name "a/b".
+	ParamDecodeSegments
+
+	// ParamRaw routes the encoded request path and keeps parameters
+	// encoded ("a%2Fb"), e.g. for proxies forwarding paths unchanged.
+	ParamRaw
+)
+
+// Options configures the behaviors of a Router created with NewWithOptions.
+// The zero value is the configuration of New.
+type Options struct {
+	// DisableMethodNotAllowed responds 404 Not Found instead of
+	// 405 Method Not Allowed when the path exists for other methods.
+	// Default: false (405 with an Allow header)
+	DisableMethodNotAllowed bool
+
+	// DisableAutoOPTIONS disables automatic OPTIONS responses.
+	// By default, an OPTIONS request for a path without an OPTIONS route
+	// runs the global middleware (e.g., CORS) and gets 204 No Content with
+	// an Allow header listing the methods of the path.
+	// Default: false
+	DisableAutoOPTIONS bool
+
+	// TrailingSlash is the trailing slash policy.
+	// Default: TrailingSlashStrict
+	TrailingSlash TrailingSlashPolicy
+
+	// CleanPath is the policy for paths with duplicate slashes or dot
+	// segments. The trailing slash is kept when cleaning.
+	// Default: PathCleanNone
+	CleanPath PathCleanPolicy
+
+	// ParamDecoding is the percent-decoding policy of path parameters.
+	// Default: ParamDecodePath
+	ParamDecoding ParamDecoding
+
+	// ProfileLabels sets the pprof labels "route" (the route pattern) and
+	// "method" while the middleware and handler of a request run, so CPU
+	// and heap profiles (and continuous profilers such as Parca or
+	// Pyroscope) attribute samples to endpoints. Disabled, it costs nothing.
+	// Default: false
+	ProfileLabels bool
+
+	// ResponseBufferLimit is the size in bytes up to which JSON responses
+	// are buffered, so encoding errors become 500 problems instead of
+	// truncated responses. Larger responses are streamed once the limit is
+	// reached. A negative value disables buffering.
+	// Default: DefaultResponseBufferLimit (64 KB)
+	ResponseBufferLimit int
+
+	// CancelOnDisconnect stops serving requests whose client has
+	// disconnected: the remaining handlers of the chain are not called
+	// (Context.Next returns ErrClientGone), JSON, XML, HTML and problem
+	// responses are not encoded (they return ErrClientGone), and no error
+	// response is sent. Handlers doing long work should still check
+	// Context.IsClientGone or the request context.
+	// Canceled requests are counted by Router.CanceledRequests either way.
+	// Default: false
+	CancelOnDisconnect bool
+
+	// MaxMultipartMemory is the memory limit in bytes for parsing multipart
+	// forms in Context.Form, Context.PostForm and Box.Bind.
+	// Default: DefaultMaxMultipartMemory (32 MB)
+	MaxMultipartMemory int64
+
+	// Charset is the charset of text responses (String, JSON, XML, ...).
+	// Default: DefaultCharset ("utf-8")
+	Charset string
+
+	// TimeLayouts are the layouts accepted by Context.QueryTime when the
+	// handler passes none, tried in order, so temporal query parameters
+	// are parsed the same way by every handler.
+	// Default: [time.RFC3339]
+	TimeLayouts []string
+
+	// PoolRequestBodies reuses the request bodies (Box.ReqBody) of generic
+	// handlers across requests, zeroing them in between, to save an
+	// allocation per request.
+	// Only enable it if handlers do not retain ReqBody (or pointers into it)
+	// after returning, e.g. in a goroutine, a cache or a stored struct.
+	// Default: false
+	PoolRequestBodies bool
+}
+
+// Option configures a Router created with NewWithOptions.
+// Options is an Option, as are the With* functions.
+type Option interface {
+	apply(r *Router)
+}
+
+// apply implements Option. It sets every behavior, using defaults for zero fields.
+func (o Options) apply(r *Router) {
+	r.handleMethodNotAllowed = !o.DisableMethodNotAllowed
+	r.handleOPTIONS = !o.DisableAutoOPTIONS
+	r.trailingSlash = o.TrailingSlash
+	r.cleanPath = o.CleanPath
+	r.paramDecoding = o.ParamDecoding
+	r.profileLabels = o.ProfileLabels
+	r.responseBufferLimit = o.ResponseBufferLimit
+	r.cancelOnDisconnect = o.CancelOnDisconnect
+	WithMaxMultipartMemory(o.MaxMultipartMemory).apply(r)
+	WithCharset(o.Charset).apply(r)
+	r.timeLayouts = o.TimeLayouts
+	r.poolRequestBodies = o.PoolRequestBodies
+}
+
+// optionFunc adapts a function to Option.
+type optionFunc func(r *Router)
+
+func (f optionFunc) apply(r *Router) { f(r) }
+
+// WithMethodNotAllowed enables or disables 405 Method Not Allowed responses.
+func WithMethodNotAllowed(enabled bool) Option {
+	return optionFunc(func(r *Router) {
+		r.handleMethodNotAllowed = enabled
+	})
+}
+
+// WithAutoOPTIONS enables or disables automatic OPTIONS responses.
+func WithAutoOPTIONS(enabled bool) Option {
+	return optionFunc(func(r *Router) {
+		r.handleOPTIONS = enabled
+	})
+}
+
+// WithTrailingSlash sets the trailing slash policy.
+func WithTrailingSlash(policy TrailingSlashPolicy) Option {
+	return optionFunc(func(r *Router) {
+		r.trailingSlash = policy
+	})
+}
+
+// WithCleanPath sets the policy for paths with duplicate slashes or dot segments.
+func WithCleanPath(policy PathCleanPolicy) Option {
+	return optionFunc(func(r *Router) {
+		r.cleanPath = policy
+	})
+}
+
+// WithParamDecoding sets the percent-decoding policy of path parameters.
+func WithParamDecoding(policy ParamDecoding) Option {
+	return optionFunc(func(r *Router) {
+		r.paramDecoding = policy
+	})
+}
+
+// WithProfileLabels enables or disables pprof labels per route
+// (see Options.ProfileLabels).
+func WithProfileLabels(enabled bool) Option {
+	return optionFunc(func(r *Router) {
+		r.profileLabels = enabled
+	})
+}
+
+// WithResponseBufferLimit sets the size up to which JSON responses are
+// buffered (see Options.ResponseBufferLimit).
+func WithResponseBufferLimit(n int) Option {
+	return optionFunc(func(r *Router) {
+		r.responseBufferLimit = n
+	})
+}
+
+// WithCancelOnDisconnect enables or disables stopping requests whose
+// client has disconnected (see Options.CancelOnDisconnect).
+func WithCancelOnDisconnect(enabled bool) Option {
+	return optionFunc(func(r *Router) {
+		r.cancelOnDisconnect = enabled
+	})
+}
+
+// WithMaxMultipartMemory sets the memory limit for parsing multipart forms.
+// Values <= 0 use DefaultMaxMultipartMemory.
+func WithMaxMultipartMemory(n int64) Option {
+	return optionFunc(func(r *Router) {
+		if n <= 0 {
+			n = DefaultMaxMultipartMemory
+		}
+		r.maxMultipartMemory = n
+	})
+}
+
+// WithCharset sets the charset of text responses.
+// An empty charset uses DefaultCharset.
+func WithCharset(charset string) Option {
+	return optionFunc(func(r *Router) {
+		if charset == "" {
+			charset = DefaultCharset
+		}
+		r.charset = charset
+	})
+}
+
+// WithTimeLayouts sets the layouts accepted by Context.QueryTime
+// (see Options.TimeLayouts).
+//
+// Example:
+//
+//	router := fursy.NewWithOptions(fursy.WithTimeLayouts(time.RFC3339, time.DateOnly))
+func WithTimeLayouts(layouts ...string) Option {
+	return optionFunc(func(r *Router) {
+		r.timeLayouts = layouts
+	})
+}
+
+// WithRequestBodyPooling enables or disables the reuse of generic handler
+// request bodies (see Options.PoolRequestBodies).
+func WithRequestBodyPooling(enabled bool) Option {
+	return optionFunc(func(r *Router) {
+		r.poolRequestBodies = enabled
+	})
+}
+
+// NewWithOptions creates a new Router configured by opts, applied in order.
+// Without options it is equivalent to New.
+//
+// Example:
+//
+//	router := fursy.NewWithOptions(fursy.Options{
+//	    TrailingSlash:      fursy.TrailingSlashRedirect,
+//	    MaxMultipartMemory: 8 << 20,
+//	})
+//
+// Example (functional options):
+//
+//	router := fursy.NewWithOptions(
+//	    fursy.WithTrailingSlash(fursy.TrailingSlashMatch),
+//	    fursy.WithAutoOPTIONS(false),
+//	)
+func NewWithOptions(opts ...Option) *Router {
+	r := New()
+	for _, opt := range opts {
+		opt.apply(r)
+	}
+	return r
+}
+
+// toggleTrailingSlash returns path with the trailing slash added or removed.
+// It returns false for the root path.
+func toggleTrailingSlash(path string) (string, bool) {
+	if path == "/" || path == "" {
+		return "", false
+	}
+	if strings.HasSuffix(path, "/") {
+		return path[:len(path)-1], true
+	}
+	return path + "/", true
+}
+
+// serveWithProfileLabels runs the handler chain of c with the pprof
+// labels "route" and "method", also set on the request context.
+func (r *Router) serveWithProfileLabels(c *Context) {
+	req := c.Request
+	pprof.Do(req.Context(), pprof.Labels("route", c.route, "method", req.Method), func(ctx context.Context) {
+		c.Request = req.WithContext(ctx)
+		r.finishChain(c, c.Next())
+	})
+}
+
+// cleanPath returns the canonical form of p, without duplicate slashes
+// and dot segments. The trailing slash is kept.
+func cleanPath(p string) string {
+	if p == "" || p[0] != '/' || (!strings.Contains(p, "//") && !strings.Contains(p, "/.")) {
+		return p
+	}
+	cleaned := path.Clean(p)
+	if cleaned != "/" && (strings.HasSuffix(p, "/") || strings.HasSuffix(p, "/.") || strings.HasSuffix(p, "/..")) {
+		cleaned += "/"
+	}
+	return cleaned
+}
+
+// withPath returns a shallow copy of req with the URL path p, escaped if
+// the router routes encoded paths.
+func withPath(req *http.Request, p string, escaped bool) *http.Request {
+	u := *req.URL
+	u.Path, u.RawPath = p, ""
+	if escaped {
+		if decoded, err := url.PathUnescape(p); err == nil {
+			u.Path, u.RawPath = decoded, p
+		}
+	}
+	clone := *req
+	clone.URL = &u
+	return &clone
+}
+
+// redirectPath redirects the request to path, keeping the query.
+// The path is encoded unless the router routes encoded paths.
+func redirectPath(c *Context, path string) {
+	code := http.StatusPermanentRedirect
+	if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+		code = http.StatusMovedPermanently
+	}
+	u := &url.URL{Path: path, RawQuery: c.Request.URL.RawQuery}
+	if c.router.paramDecoding != ParamDecodePath {
+		if decoded, err := url.PathUnescape(path); err == nil {
+			u.Path, u.RawPath = decoded, path
+		}
+	}
+	http.Redirect(c.Response, c.Request, u.String(), code)
+}
+
+// allowedMethods returns the sorted methods with a route matching path,
+// including OPTIONS when automatic OPTIONS responses are enabled.
+func (r *Router) allowedMethods(path string) []string {
+	var methods []string
+	for m, tree := range r.routeTrees() {
+		if _, _, found := tree.Lookup(path); found {
+			methods = append(methods, m)
+		}
+	}
+	if len(methods) > 0 && r.handleOPTIONS && !slices.Contains(methods, http.MethodOptions) {
+		methods = append(methods, http.MethodOptions)
+	}
+	slices.Sort(methods)
+	return methods
+}
+
+// serveAutoOPTIONS answers an OPTIONS request with the allowed methods,
+// running the global middleware first so CORS preflights are handled.
+func (r *Router) serveAutoOPTIONS(c *Context, allow []string) {
+	c.handlers = c.handlers[:0]
+	c.handlers = append(c.handlers, r.middleware...)
+	c.handlers = append(c.handlers, func(c *Context) error {
+		c.SetHeader("Allow", strings.Join(allow, ", "))
+		return c.NoContent(http.StatusNoContent)
+	})
+	c.index = -1
+	c.aborted = false
+
+	if err := c.Next(); err != nil {
+		r.handleError(c, err)
+	}
+}
+
+// contentType returns mediaType with the charset of the router.
+func (c *Context) contentType(mediaType string) string {
+	charset := DefaultCharset
+	if c.router != nil && c.router.charset != "" {
+		charset = c.router.charset
+	}
+	return mediaType + "; charset=" + charset
+}
+
+// maxMultipartMemory returns the multipart memory limit of the route or router.
+func (c *Context) maxMultipartMemory() int64 {
+	if c.multipartMemory > 0 {
+		return c.multipartMemory
+	}
+	if c.router != nil && c.router.maxMultipartMemory > 0 {
+		return c.router.maxMultipartMemory
+	}
+	return DefaultMaxMultipartMemory
+}
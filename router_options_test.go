@@ -0,0 +1,465 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"runtime/pprof"
+	"strings"
+	"testing"
+)
+
+// TestNewWithOptions tests configuring the router with Options and functional options.
+func TestNewWithOptions(t *testing.T) {
+	r := NewWithOptions()
+	if !r.handleMethodNotAllowed || !r.handleOPTIONS || r.trailingSlash != TrailingSlashStrict ||
+		r.maxMultipartMemory != DefaultMaxMultipartMemory || r.charset != DefaultCharset {
+		t.Errorf("expected New defaults, got %+v", r)
+	}
+
+	r = NewWithOptions(Options{
+		DisableMethodNotAllowed: true,
+		DisableAutoOPTIONS:      true,
+		TrailingSlash:           TrailingSlashMatch,
+		MaxMultipartMemory:      1 << 20,
+		Charset:                 "iso-8859-1",
+	})
+	if r.handleMethodNotAllowed || r.handleOPTIONS || r.trailingSlash != TrailingSlashMatch ||
+		r.maxMultipartMemory != 1<<20 || r.charset != "iso-8859-1" {
+		t.Errorf("expected Options to be applied, got %+v", r)
+	}
+
+	// Later options override earlier ones; zero values use defaults.
+	r = NewWithOptions(
+		Options{Charset: "iso-8859-1"},
+		WithCharset(""),
+		WithMaxMultipartMemory(0),
+		WithMethodNotAllowed(false),
+		WithAutoOPTIONS(false),
+		WithTrailingSlash(TrailingSlashRedirect),
+	)
+	if r.charset != DefaultCharset || r.maxMultipartMemory != DefaultMaxMultipartMemory ||
+		r.handleMethodNotAllowed || r.handleOPTIONS || r.trailingSlash != TrailingSlashRedirect {
+		t.Errorf("expected functional options to be applied, got %+v", r)
+	}
+}
+
+// TestRouter_TrailingSlash tests the trailing slash policies.
+func TestRouter_TrailingSlash(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     TrailingSlashPolicy
+		method     string
+		target     string
+		wantCode   int
+		wantTarget string
+	}{
+		{"strict", TrailingSlashStrict, http.MethodGet, "/users/", http.StatusNotFound, ""},
+		{"redirect GET", TrailingSlashRedirect, http.MethodGet, "/users/?page=2", http.StatusMovedPermanently, "/users?page=2"},
+		{"redirect POST", TrailingSlashRedirect, http.MethodPost, "/users/", http.StatusPermanentRedirect, "/users"},
+		{"redirect add slash", TrailingSlashRedirect, http.MethodGet, "/docs", http.StatusMovedPermanently, "/docs/"},
+		{"match", TrailingSlashMatch, http.MethodGet, "/users/", http.StatusOK, ""},
+		{"exact", TrailingSlashRedirect, http.MethodGet, "/users", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewWithOptions(WithTrailingSlash(tt.policy))
+			r.GET("/users", func(c *Context) error { return c.String(http.StatusOK, "users") })
+			r.POST("/users", func(c *Context) error { return c.String(http.StatusOK, "created") })
+			r.GET("/docs/", func(c *Context) error { return c.String(http.StatusOK, "docs") })
+
+			req := httptest.NewRequest(tt.method, tt.target, http.NoBody)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantCode)
+			}
+			if got := w.Header().Get("Location"); got != tt.wantTarget {
+				t.Errorf("Location = %q, want %q", got, tt.wantTarget)
+			}
+		})
+	}
+}
+
+// TestRouter_CleanPath tests the path clean policies.
+func TestRouter_CleanPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     PathCleanPolicy
+		method     string
+		target     string
+		wantCode   int
+		wantBody   string
+		wantTarget string
+	}{
+		{"none", PathCleanNone, http.MethodGet, "/users//7", http.StatusNotFound, "", ""},
+		{"match duplicate slashes", PathCleanMatch, http.MethodGet, "/users//7", http.StatusOK, "user 7", ""},
+		{"match dot segments", PathCleanMatch, http.MethodGet, "/files/../users/./7", http.StatusOK, "user 7", ""},
+		{"match keeps trailing slash", PathCleanMatch, http.MethodGet, "/docs//", http.StatusOK, "docs", ""},
+		{"redirect GET", PathCleanRedirect, http.MethodGet, "/users//7?x=1", http.StatusMovedPermanently, "", "/users/7?x=1"},
+		{"redirect POST", PathCleanRedirect, http.MethodPost, "//users/7", http.StatusPermanentRedirect, "", "/users/7"},
+		{"reject", PathCleanReject, http.MethodGet, "/users/./7", http.StatusBadRequest, "", ""},
+		{"canonical", PathCleanReject, http.MethodGet, "/users/7", http.StatusOK, "user 7", ""},
+		{"dot file", PathCleanReject, http.MethodGet, "/files/.env", http.StatusOK, "file .env", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewWithOptions(WithCleanPath(tt.policy))
+			r.GET("/users/:id", func(c *Context) error { return c.String(http.StatusOK, "user "+c.Param("id")) })
+			r.POST("/users/:id", func(c *Context) error { return c.String(http.StatusOK, "updated") })
+			r.GET("/files/:name", func(c *Context) error { return c.String(http.StatusOK, "file "+c.Param("name")) })
+			r.GET("/docs/", func(c *Context) error { return c.String(http.StatusOK, "docs") })
+
+			req := httptest.NewRequest(tt.method, "/", http.NoBody)
+			req.URL.Path, req.URL.RawQuery, _ = strings.Cut(tt.target, "?")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantCode)
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", w.Body.String(), tt.wantBody)
+			}
+			if got := w.Header().Get("Location"); got != tt.wantTarget {
+				t.Errorf("Location = %q, want %q", got, tt.wantTarget)
+			}
+		})
+	}
+}
+
+// TestRouter_CleanPathSkipper tests that middleware skippers see the
+// cleaned path, so dot segments cannot escape a skipped prefix.
+func TestRouter_CleanPathSkipper(t *testing.T) {
+	for _, decoding := range []ParamDecoding{ParamDecodePath, ParamDecodeSegments} {
+		r := NewWithOptions(Options{CleanPath: PathCleanMatch, ParamDecoding: decoding})
+		skip := SkipPaths("/public/*")
+		r.Use(func(c *Context) error {
+			if !skip.ShouldSkip(c) && c.GetHeader("Authorization") == "" {
+				return c.String(http.StatusUnauthorized, "unauthorized")
+			}
+			return c.Next()
+		})
+		r.GET("/public/*path", func(c *Context) error { return c.String(http.StatusOK, "public") })
+		r.GET("/admin/secret", func(c *Context) error {
+			return c.String(http.StatusOK, "SECRET "+c.Request.URL.Path)
+		})
+
+		for target, want := range map[string]string{
+			"/public/../admin/secret":  "unauthorized",
+			"/public//../admin/secret": "unauthorized",
+			"/public/./docs":           "public",
+		} {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, http.NoBody))
+			if w.Body.String() != want {
+				t.Errorf("decoding %v: GET %s = %d %q, want %q", decoding, target, w.Code, w.Body.String(), want)
+			}
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/admin//secret", http.NoBody)
+		req.Header.Set("Authorization", "Bearer token")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Body.String() != "SECRET /admin/secret" {
+			t.Errorf("decoding %v: body = %q, want the cleaned path", decoding, w.Body.String())
+		}
+	}
+}
+
+// TestRouter_ParamDecoding tests the path parameter decoding policies.
+func TestRouter_ParamDecoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   ParamDecoding
+		target   string
+		wantCode int
+		wantBody string
+	}{
+		{"path", ParamDecodePath, "/files/a%20b", http.StatusOK, "a b"},
+		{"path encoded slash", ParamDecodePath, "/files/a%2Fb", http.StatusNotFound, ""},
+		{"segments", ParamDecodeSegments, "/files/a%2Fb", http.StatusOK, "a/b"},
+		{"segments space", ParamDecodeSegments, "/files/a%20b", http.StatusOK, "a b"},
+		{"raw", ParamRaw, "/files/a%2Fb", http.StatusOK, "a%2Fb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewWithOptions(WithParamDecoding(tt.policy))
+			r.GET("/files/:name", func(c *Context) error { return c.String(http.StatusOK, c.Param("name")) })
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, http.NoBody))
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantCode)
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+
+	// Redirects keep encoded characters.
+	r := NewWithOptions(Options{CleanPath: PathCleanRedirect, ParamDecoding: ParamRaw})
+	r.GET("/files/:name", func(c *Context) error { return nil })
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/files//a%2Fb", http.NoBody))
+	if got := w.Header().Get("Location"); got != "/files/a%2Fb" {
+		t.Errorf("Location = %q, want %q", got, "/files/a%2Fb")
+	}
+}
+
+// TestRouter_ProfileLabels tests pprof labels per route.
+func TestRouter_ProfileLabels(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		r := NewWithOptions(WithProfileLabels(enabled))
+		var route, method string
+		r.PUT("/users/:id", func(c *Context) error {
+			route, _ = pprof.Label(c.Request.Context(), "route")
+			method, _ = pprof.Label(c.Request.Context(), "method")
+			return errors.New("failed")
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/users/7", http.NoBody))
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("enabled=%v: status = %d, want 500", enabled, w.Code)
+		}
+		wantRoute, wantMethod := "", ""
+		if enabled {
+			wantRoute, wantMethod = "/users/:id", http.MethodPut
+		}
+		if route != wantRoute || method != wantMethod {
+			t.Errorf("enabled=%v: labels = route %q, method %q", enabled, route, method)
+		}
+	}
+}
+
+// TestRouter_AutoOPTIONS tests automatic OPTIONS responses.
+func TestRouter_AutoOPTIONS(t *testing.T) {
+	r := New()
+	middlewareRan := false
+	r.Use(func(c *Context) error {
+		middlewareRan = true
+		return c.Next()
+	})
+	r.GET("/users", func(c *Context) error { return nil })
+	r.POST("/users", func(c *Context) error { return nil })
+
+	req := httptest.NewRequest(http.MethodOptions, "/users", http.NoBody)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+	if got := w.Header().Get("Allow"); got != "GET, OPTIONS, POST" {
+		t.Errorf("Allow = %q, want %q", got, "GET, OPTIONS, POST")
+	}
+	if !middlewareRan {
+		t.Error("expected global middleware to run for automatic OPTIONS")
+	}
+
+	// Unknown paths are still 404.
+	req = httptest.NewRequest(http.MethodOptions, "/unknown", http.NoBody)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+
+	// Disabled: 405 with an Allow header.
+	r = NewWithOptions(WithAutoOPTIONS(false))
+	r.GET("/users", func(c *Context) error { return nil })
+	req = httptest.NewRequest(http.MethodOptions, "/users", http.NoBody)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusMethodNotAllowed)
+	}
+	if got := w.Header().Get("Allow"); got != "GET" {
+		t.Errorf("Allow = %q, want %q", got, "GET")
+	}
+}
+
+// TestRouter_Routes_Allow tests the Allow sets exposed by Routes.
+func TestRouter_Routes_Allow(t *testing.T) {
+	r := New()
+	r.GET("/users", func(c *Context) error { return nil })
+	r.POST("/users", func(c *Context) error { return nil })
+	r.DELETE("/users/:id", func(c *Context) error { return nil })
+
+	for _, route := range r.Routes() {
+		want := "GET, OPTIONS, POST"
+		if route.Path == "/users/:id" {
+			want = "DELETE, OPTIONS"
+		}
+		if got := strings.Join(route.Allow, ", "); got != want {
+			t.Errorf("%s %s: Allow = %q, want %q", route.Method, route.Path, got, want)
+		}
+	}
+
+	r = NewWithOptions(WithAutoOPTIONS(false))
+	r.GET("/users", func(c *Context) error { return nil })
+	if got := r.Routes()[0].Allow; len(got) != 1 || got[0] != http.MethodGet {
+		t.Errorf("Allow = %v, want [GET]", got)
+	}
+}
+
+// TestRouter_Charset tests the charset of text responses.
+func TestRouter_Charset(t *testing.T) {
+	r := NewWithOptions(WithCharset("iso-8859-1"))
+	r.GET("/", func(c *Context) error { return c.String(http.StatusOK, "hello") })
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Type"); got != "text/plain; charset=iso-8859-1" {
+		t.Errorf("Content-Type = %q", got)
+	}
+}
+
+// TestRouter_MaxMultipartMemory tests that multipart forms use the router limit.
+func TestRouter_MaxMultipartMemory(t *testing.T) {
+	r := NewWithOptions(WithMaxMultipartMemory(16))
+
+	var inMemory bool
+	r.POST("/upload", func(c *Context) error {
+		_ = c.Form("title")
+		fh := c.Request.MultipartForm.File["file"][0]
+		f, err := fh.Open()
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		inMemory = fileName(f) == ""
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	_ = writer.WriteField("title", "big")
+	part, _ := writer.CreateFormFile("file", "big.bin")
+	_, _ = part.Write(bytes.Repeat([]byte("x"), 1024))
+	_ = writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", &buf)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusNoContent, w.Body.String())
+	}
+	if inMemory {
+		t.Error("expected file larger than MaxMultipartMemory to be stored on disk")
+	}
+}
+
+// TestRoute_MaxMultipartMemory tests that the route limit overrides the router limit.
+func TestRoute_MaxMultipartMemory(t *testing.T) {
+	r := New()
+
+	var inMemory bool
+	r.HandleWithOptions(http.MethodPost, "/upload", func(c *Context) error {
+		if err := c.ParseForm(); err != nil {
+			return err
+		}
+		f, err := c.Request.MultipartForm.File["file"][0].Open()
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		inMemory = fileName(f) == ""
+		return c.NoContent(http.StatusNoContent)
+	}, &RouteOptions{MaxMultipartMemory: 16})
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, _ := writer.CreateFormFile("file", "big.bin")
+	_, _ = part.Write(bytes.Repeat([]byte("x"), 1024))
+	_ = writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", &buf)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusNoContent, w.Body.String())
+	}
+	if inMemory {
+		t.Error("expected file larger than the route MaxMultipartMemory to be stored on disk")
+	}
+}
+
+// TestContext_ParseFormErrors tests that form parse errors are returned as problems.
+func TestContext_ParseFormErrors(t *testing.T) {
+	r := New()
+	var parseErr error
+	var title string
+	r.HandleWithOptions(http.MethodPost, "/upload", func(c *Context) error {
+		title = c.PostForm("title")
+		parseErr = c.ParseForm()
+		return c.NoContent(http.StatusNoContent)
+	}, &RouteOptions{MaxBodySize: 64})
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	_ = writer.WriteField("title", strings.Repeat("x", 128))
+	_ = writer.Close()
+
+	// Chunked body: the size is only known while parsing.
+	req := httptest.NewRequest(http.MethodPost, "/upload", io.NopCloser(&buf))
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	var p Problem
+	if !errors.As(parseErr, &p) || p.Status != http.StatusRequestEntityTooLarge || title != "" {
+		t.Errorf("expected 413 problem, got %v (title %q)", parseErr, title)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("not multipart"))
+	req.Header.Set("Content-Type", "multipart/form-data; boundary=missing")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !errors.As(parseErr, &p) || p.Status != http.StatusBadRequest {
+		t.Errorf("expected 400 problem, got %v", parseErr)
+	}
+}
+
+// fileName returns the name of f if it is stored on disk.
+func fileName(f multipart.File) string {
+	if named, ok := f.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return ""
+}
+
+// TestRouter_DuplicateRoutePanic tests that duplicate routes name the method and path.
+func TestRouter_DuplicateRoutePanic(t *testing.T) {
+	r := New()
+	r.GET("/users/:id", func(c *Context) error { return nil })
+
+	defer func() {
+		msg, _ := recover().(string)
+		if !strings.HasPrefix(msg, "fursy: GET /users/:name: ") {
+			t.Errorf("unexpected panic %q", msg)
+		}
+	}()
+	r.GET("/users/:name", func(c *Context) error { return nil })
+}
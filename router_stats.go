@@ -0,0 +1,106 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// RouterStats is a snapshot of the router counters returned by Router.Stats.
+type RouterStats struct {
+	// Routes is the number of registered routes.
+	Routes int `json:"routes"`
+
+	// Requests is the number of requests received.
+	Requests int64 `json:"requests"`
+
+	// Active is the number of requests currently being served (see InFlight).
+	Active int64 `json:"active"`
+
+	// Status2xx, Status3xx, Status4xx and Status5xx count completed
+	// requests by response status class. They are only counted once
+	// Router.EnableStats is called.
+	Status2xx int64 `json:"status_2xx"`
+	Status3xx int64 `json:"status_3xx"`
+	Status4xx int64 `json:"status_4xx"`
+	Status5xx int64 `json:"status_5xx"`
+
+	// PoolHits and PoolMisses count requests whose Context was reused
+	// from the pool or newly allocated.
+	PoolHits   int64 `json:"pool_hits"`
+	PoolMisses int64 `json:"pool_misses"`
+
+	// Panics is the number of panics recovered by recovery middleware
+	// (see Context.NotifyPanic).
+	Panics int64 `json:"panics"`
+
+	// Canceled is the number of requests whose client disconnected
+	// (see CanceledRequests).
+	Canceled int64 `json:"canceled"`
+
+	// OpenConns is the number of open connections (see OpenConns).
+	OpenConns int64 `json:"open_conns"`
+}
+
+// routerStats holds the router counters behind Router.Stats.
+type routerStats struct {
+	requests   atomic.Int64
+	poolMisses atomic.Int64
+	panics     atomic.Int64
+
+	// status counts completed requests by status class (index 2 to 5),
+	// when enabled by Router.EnableStats.
+	status  [6]atomic.Int64
+	enabled atomic.Bool
+}
+
+// EnableStats counts completed requests by status class in Router.Stats,
+// using an OnResponse hook. The other counters are always collected.
+//
+// Like lifecycle hooks, it must be called before the router serves
+// requests. Calling it again has no effect.
+func (r *Router) EnableStats() *Router {
+	if r.stats.enabled.Swap(true) {
+		return r
+	}
+	return r.OnResponse(func(_ *Context, status int, _ time.Duration) {
+		if class := status / 100; class >= 2 && class <= 5 {
+			r.stats.status[class].Add(1)
+		}
+	})
+}
+
+// Stats returns a snapshot of the router counters: registered routes,
+// requests by status class, active requests, context pool hits and
+// misses, and recovered panics, for health and admin endpoints or tests
+// without a metrics stack. Counters are read independently, so a snapshot
+// taken under load is not exactly consistent.
+//
+// Example:
+//
+//	router.EnableStats()
+//	router.GET("/admin/stats", func(c *fursy.Context) error {
+//	    return c.OK(router.Stats())
+//	})
+func (r *Router) Stats() RouterStats {
+	routes := len(r.routeList())
+	requests := r.stats.requests.Load()
+	misses := r.stats.poolMisses.Load()
+	return RouterStats{
+		Routes:     routes,
+		Requests:   requests,
+		Active:     r.inFlight.Load(),
+		Status2xx:  r.stats.status[2].Load(),
+		Status3xx:  r.stats.status[3].Load(),
+		Status4xx:  r.stats.status[4].Load(),
+		Status5xx:  r.stats.status[5].Load(),
+		PoolHits:   max(requests-misses, 0),
+		PoolMisses: misses,
+		Panics:     r.stats.panics.Load(),
+		Canceled:   r.canceledRequests.Load(),
+		OpenConns:  r.openConns.Load(),
+	}
+}
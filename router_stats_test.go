@@ -0,0 +1,70 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestRouter_Stats tests the router counters snapshot.
+func TestRouter_Stats(t *testing.T) {
+	r := New().EnableStats()
+	r.EnableStats() // No double counting.
+	r.Use(func(c *Context) (err error) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				c.NotifyPanic(rec)
+				err = c.Problem(InternalServerError(""))
+			}
+		}()
+		return c.Next()
+	})
+	r.GET("/ok", func(c *Context) error {
+		return c.OK("ok")
+	})
+	r.GET("/redirect", func(c *Context) error {
+		return c.Redirect(http.StatusFound, "/ok")
+	})
+	r.GET("/panic", func(c *Context) error {
+		panic("boom")
+	})
+
+	var active int64
+	r.GET("/active", func(c *Context) error {
+		active = r.Stats().Active
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	for _, path := range []string{"/ok", "/ok", "/redirect", "/missing", "/panic", "/active"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, http.NoBody))
+	}
+
+	s := r.Stats()
+	if s.Routes != 4 || s.Requests != 6 {
+		t.Errorf("Routes = %d, Requests = %d, want 4 and 6", s.Routes, s.Requests)
+	}
+	if s.Status2xx != 3 || s.Status3xx != 1 || s.Status4xx != 1 || s.Status5xx != 1 {
+		t.Errorf("status classes = %d/%d/%d/%d, want 3/1/1/1", s.Status2xx, s.Status3xx, s.Status4xx, s.Status5xx)
+	}
+	if s.Panics != 1 {
+		t.Errorf("Panics = %d, want 1", s.Panics)
+	}
+	if active != 1 || s.Active != 0 {
+		t.Errorf("Active = %d during a request and %d after, want 1 and 0", active, s.Active)
+	}
+	if s.PoolHits+s.PoolMisses != s.Requests || s.PoolMisses == 0 {
+		t.Errorf("PoolHits = %d, PoolMisses = %d", s.PoolHits, s.PoolMisses)
+	}
+
+	// Without EnableStats, status classes are not counted.
+	r2 := New()
+	r2.GET("/ok", func(c *Context) error { return c.OK("ok") })
+	r2.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ok", http.NoBody))
+	if s := r2.Stats(); s.Requests != 1 || s.Status2xx != 0 {
+		t.Errorf("Requests = %d, Status2xx = %d, want 1 and 0", s.Requests, s.Status2xx)
+	}
+}
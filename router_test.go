@@ -11,7 +11,7 @@ import (
 func TestRouter_New(t *testing.T) {
 	r := New()
 	// Router should never be nil.
-	if r.trees == nil {
+	if r.table.Load() == nil {
 		t.Error("trees map not initialized")
 	}
 	if !r.handleMethodNotAllowed {
@@ -33,7 +33,7 @@ func TestRouter_GET(t *testing.T) {
 
 	r.GET("/test", handler)
 
-	if r.trees[http.MethodGet] == nil {
+	if r.routeTrees()[http.MethodGet] == nil {
 		t.Fatal("GET tree not created")
 	}
 
@@ -210,7 +210,7 @@ func TestRouter_Handle(t *testing.T) {
 	// Valid registration.
 	r.Handle(http.MethodGet, "/test", handler)
 
-	if r.trees[http.MethodGet] == nil {
+	if r.routeTrees()[http.MethodGet] == nil {
 		t.Error("GET tree not created")
 	}
 }
@@ -291,6 +291,26 @@ func TestRouter_ServeHTTP_NotFound(t *testing.T) {
 	}
 }
 
+// TestRouter_ServeHTTP_MethodNotAllowed_OtherRoutes tests 405 when the method has other routes.
+func TestRouter_ServeHTTP_MethodNotAllowed_OtherRoutes(t *testing.T) {
+	r := New()
+	r.GET("/users", func(c *Context) error {
+		return c.String(200, "OK")
+	})
+	r.POST("/login", func(c *Context) error {
+		return c.String(200, "OK")
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/users", http.NoBody)
+
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Status code = %d, want %d", w.Code, http.StatusMethodNotAllowed)
+	}
+}
+
 // TestRouter_ServeHTTP_MethodNotAllowed tests 405 response.
 func TestRouter_ServeHTTP_MethodNotAllowed(t *testing.T) {
 	r := New()
@@ -492,3 +512,107 @@ func TestRouter_MethodNotAllowed_Disabled(t *testing.T) {
 		t.Errorf("Status code = %d, want %d (404)", w.Code, http.StatusNotFound)
 	}
 }
+
+// TestRouter_OptionalAndMidPathParams tests optional parameters and mid-path catch-alls.
+func TestRouter_OptionalAndMidPathParams(t *testing.T) {
+	r := New()
+	r.GET("/users/:id/:action?", func(c *Context) error {
+		return c.String(200, "user "+c.Param("id")+" "+c.Param("action"))
+	})
+	r.GET("/users/:id/posts", func(c *Context) error {
+		return c.String(200, "posts "+c.Param("id"))
+	})
+	r.GET("/registry/*image/manifests/:ref", func(c *Context) error {
+		return c.String(200, "manifest "+c.Param("image")+" "+c.Param("ref"))
+	})
+
+	tests := []struct {
+		path     string
+		wantCode int
+		wantBody string
+	}{
+		{"/users/7", 200, "user 7 "},
+		{"/users/7/edit", 200, "user 7 edit"},
+		{"/users/7/posts", 200, "posts 7"}, // Static segment takes precedence.
+		{"/registry/library/redis/manifests/7.2", 200, "manifest library/redis 7.2"},
+		{"/registry/redis", 404, ""},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+
+		if w.Code != tt.wantCode {
+			t.Errorf("%s: status code = %d, want %d", tt.path, w.Code, tt.wantCode)
+		}
+		if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+			t.Errorf("%s: body = %q, want %q", tt.path, w.Body.String(), tt.wantBody)
+		}
+	}
+
+	if err := r.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+// TestContext_Route tests the matched route pattern.
+func TestContext_Route(t *testing.T) {
+	r := New()
+	var route string
+	r.Use(func(c *Context) error {
+		route = c.Route() // Known before the route handler runs.
+		return c.Next()
+	})
+	r.GET("/users/:id", func(c *Context) error { return nil })
+	r.Group("/api").GET("/files/*path", func(c *Context) error { return nil })
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/users/7", "/users/:id"},
+		{"/api/files/a/b", "/api/files/*path"},
+	}
+	for _, tt := range tests {
+		route = ""
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+		if route != tt.want {
+			t.Errorf("%s: Route() = %q, want %q", tt.path, route, tt.want)
+		}
+	}
+}
+
+// TestContext_RouteName tests the route pattern and name seen by middleware.
+func TestContext_RouteName(t *testing.T) {
+	r := New()
+	var pattern, name string
+	r.Use(func(c *Context) error {
+		pattern, name = c.Route(), c.RouteName()
+		return c.Next()
+	})
+	r.HandleWithOptions(http.MethodGet, "/users/:id", func(c *Context) error { return nil }, &RouteOptions{Name: "users.get"})
+	r.HandleWithOptions(http.MethodGet, "/orders/:id", func(c *Context) error { return nil }, &RouteOptions{OperationID: "getOrder"})
+	GET[Empty, Empty](r, "/items/:id", func(c *Box[Empty, Empty]) error { return nil }, RouteName("items.get"))
+	r.GET("/health", func(c *Context) error { return nil })
+
+	tests := []struct {
+		path        string
+		wantPattern string
+		wantName    string
+	}{
+		{"/users/7", "/users/:id", "users.get"},
+		{"/orders/7", "/orders/:id", "getOrder"},
+		{"/items/7", "/items/:id", "items.get"},
+		{"/health", "/health", ""},
+	}
+	for _, tt := range tests {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+		if pattern != tt.wantPattern || name != tt.wantName {
+			t.Errorf("%s: Route() = %q, RouteName() = %q, want %q, %q", tt.path, pattern, name, tt.wantPattern, tt.wantName)
+		}
+	}
+
+	if routes := r.Routes(); routes[0].Name != "users.get" || routes[2].Name != "items.get" {
+		t.Errorf("RouteInfo names = %q, %q", routes[0].Name, routes[2].Name)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RouteConfigError describes a route misconfiguration found by Router.Validate.
+type RouteConfigError struct {
+	// Method is the HTTP method of the route.
+	Method string
+
+	// Path is the route path (e.g., "/users/:id").
+	Path string
+
+	// Message describes the problem.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *RouteConfigError) Error() string {
+	return e.Method + " " + e.Path + ": " + e.Message
+}
+
+// Validate checks registered routes for common misconfigurations.
+//
+// Call it at startup (or in a test) after all routes are registered.
+// It returns nil if no problems are found, otherwise a joined error
+// (see errors.Join) with one *RouteConfigError per problem, so every
+// misconfiguration is reported at once.
+//
+// Checks:
+//   - Duplicate operation IDs (breaks OpenAPI client generation)
+//   - Documented path parameters that do not appear in the route path
+//   - Path parameters missing from the documented parameters
+//   - Path parameters of type-safe routes not bound by the request type
+//     (the handler never sees them)
+//   - Routes with RequireAuth that can never be authenticated
+//     (no router or group middleware and no AuthChecker)
+//   - Routes documented as public (RouteOptions.Public) that run
+//     authentication middleware, e.g. in a group using middleware.JWT
+//     (see isAuthMiddleware)
+//   - Deprecated routes past their Sunset date
+//   - Routes registered both with and without a trailing slash when the
+//     trailing slash policy is not TrailingSlashStrict (one of them is
+//     never reached through the other's redirect or match)
+//
+// Example:
+//
+//	func TestRoutes(t *testing.T) {
+//	    router := app.NewRouter()
+//	    if err := router.Validate(); err != nil {
+//	        t.Fatal(err)
+//	    }
+//	}
+func (r *Router) Validate() error {
+	var errs []error
+
+	report := func(route *RouteInfo, format string, args ...any) {
+		errs = append(errs, &RouteConfigError{
+			Method:  route.Method,
+			Path:    route.Path,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	operationIDs := make(map[string]*RouteInfo)
+	slashRoutes := make(map[string]*RouteInfo)
+	routes := r.routeList()
+	global := middlewareNames(r.middleware)
+	for i := range routes {
+		route := &routes[i]
+
+		// Routes that differ only by a trailing slash.
+		if r.trailingSlash != TrailingSlashStrict && route.Path != "/" {
+			key := route.Method + " " + strings.TrimSuffix(route.Path, "/")
+			if first, ok := slashRoutes[key]; ok && first.Path != route.Path {
+				report(route, "duplicates %s %s under the trailing slash policy", first.Method, first.Path)
+			} else {
+				slashRoutes[key] = route
+			}
+		}
+
+		// Duplicate operation IDs.
+		if route.OperationID != "" {
+			if first, ok := operationIDs[route.OperationID]; ok {
+				report(route, "duplicate operation ID %q (also used by %s %s)",
+					route.OperationID, first.Method, first.Path)
+			} else {
+				operationIDs[route.OperationID] = route
+			}
+		}
+
+		// Documented path parameters must match the route path.
+		pathParams := routePathParams(route.Path)
+		var documented []string
+		for _, p := range route.Parameters {
+			if p.In != "path" {
+				continue
+			}
+			documented = append(documented, p.Name)
+			if !slices.Contains(pathParams, p.Name) {
+				report(route, "documented path parameter %q is not in the route path", p.Name)
+			}
+		}
+		if route.bindType != nil {
+			// Type-safe routes only see the parameters bound by their request type.
+			var bound []string
+			for _, p := range urlParameters(route.bindType) {
+				if p.In == "path" {
+					bound = append(bound, p.Name)
+				}
+			}
+			for _, name := range pathParams {
+				if !slices.Contains(bound, name) {
+					report(route, "path parameter %q is not bound by request type %s", name, route.bindType)
+				}
+			}
+		} else if len(documented) > 0 {
+			for _, name := range pathParams {
+				if !slices.Contains(documented, name) {
+					report(route, "path parameter %q is not documented", name)
+				}
+			}
+		}
+
+		// RequireAuth needs something that can authenticate the request.
+		if route.RequireAuth && len(r.middleware) == 0 && len(route.groupMiddleware) == 0 && r.authChecker == nil {
+			report(route, "route requires authentication but no middleware or AuthChecker is configured")
+		}
+
+		// Public routes must not run authentication middleware.
+		if route.Public {
+			for _, name := range append(slices.Clip(global), middlewareNames(route.groupMiddleware)...) {
+				if isAuthMiddleware(name) {
+					report(route, "route is documented as public but runs authentication middleware %q", name)
+				}
+			}
+		}
+
+		// Routes past their sunset date should have been removed.
+		if !route.Sunset.IsZero() && route.Sunset.Before(time.Now()) {
+			report(route, "sunset date %s has passed", route.Sunset.UTC().Format(time.DateOnly))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// isAuthMiddleware reports whether the middleware named name authenticates
+// requests, judging by its name: "middleware.JWT", "middleware.BasicAuth"
+// or a name given with Named such as "auth".
+func isAuthMiddleware(name string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(name, "auth") || strings.Contains(name, "jwt")
+}
+
+// routePathParams returns the parameter names in a route path
+// (e.g., "/users/:id/files/*path" → ["id", "path"], "/users/:id?" → ["id"]).
+func routePathParams(path string) []string {
+	var params []string
+	for _, seg := range strings.Split(path, "/") {
+		if len(seg) > 1 && (seg[0] == ':' || seg[0] == '*') {
+			params = append(params, strings.TrimSuffix(seg[1:], "?"))
+		}
+	}
+	return params
+}
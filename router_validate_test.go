@@ -0,0 +1,159 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestRouter_Validate_OK tests that a correct configuration passes.
+func TestRouter_Validate_OK(t *testing.T) {
+	r := New()
+	r.GET("/health", func(c *Context) error { return c.NoContent(http.StatusOK) })
+	r.HandleWithOptions(http.MethodGet, "/users/:id", func(c *Context) error { return nil }, &RouteOptions{
+		OperationID: "getUser",
+		Parameters:  []RouteParameter{{Name: "id", In: "path", Required: true}},
+	})
+	r.HandleWithOptions(http.MethodDelete, "/users/:id", func(c *Context) error { return nil }, &RouteOptions{
+		OperationID: "deleteUser",
+	})
+
+	if err := r.Validate(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+// TestRouter_Validate_Errors tests that all misconfigurations are reported.
+func TestRouter_Validate_Errors(t *testing.T) {
+	handler := func(c *Context) error { return nil }
+
+	r := New()
+	r.HandleWithOptions(http.MethodGet, "/users", handler, &RouteOptions{OperationID: "listUsers"})
+	r.HandleWithOptions(http.MethodGet, "/accounts", handler, &RouteOptions{OperationID: "listUsers"})
+	r.HandleWithOptions(http.MethodGet, "/users/:id/posts/:postID", handler, &RouteOptions{
+		Parameters: []RouteParameter{
+			{Name: "userID", In: "path"},
+			{Name: "postID", In: "path"},
+		},
+	})
+	r.HandleWithOptions(http.MethodGet, "/me", handler, &RouteOptions{RequireAuth: true})
+
+	err := r.Validate()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	wants := []string{
+		`GET /accounts: duplicate operation ID "listUsers" (also used by GET /users)`,
+		`GET /users/:id/posts/:postID: documented path parameter "userID" is not in the route path`,
+		`GET /users/:id/posts/:postID: path parameter "id" is not documented`,
+		`GET /me: route requires authentication`,
+	}
+	for _, want := range wants {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got:\n%v", want, err)
+		}
+	}
+
+	var rerr *RouteConfigError
+	if !errors.As(err, &rerr) || rerr.Method != http.MethodGet || rerr.Path != "/accounts" {
+		t.Errorf("expected *RouteConfigError for GET /accounts, got %v", rerr)
+	}
+}
+
+// TestRouter_Validate_RequireAuthWithChecker tests that an AuthChecker satisfies RequireAuth.
+func TestRouter_Validate_RequireAuthWithChecker(t *testing.T) {
+	r := New()
+	r.SetAuthChecker(func(c *Context) bool { return c.Request.Header.Get("X-API-Key") != "" })
+	r.HandleWithOptions(http.MethodGet, "/me", func(c *Context) error { return nil }, &RouteOptions{RequireAuth: true})
+
+	if err := r.Validate(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+// TestRoutePathParams tests path parameter extraction.
+func TestRoutePathParams(t *testing.T) {
+	got := routePathParams("/users/:id/files/*path")
+	if len(got) != 2 || got[0] != "id" || got[1] != "path" {
+		t.Errorf("unexpected params: %v", got)
+	}
+	if got := routePathParams("/static/*"); len(got) != 0 {
+		t.Errorf("expected no params, got %v", got)
+	}
+}
+
+// TestRouter_Validate_TrailingSlashDuplicates tests reporting routes that
+// differ only by a trailing slash under a non-strict policy.
+func TestRouter_Validate_TrailingSlashDuplicates(t *testing.T) {
+	handler := func(c *Context) error { return nil }
+
+	r := NewWithOptions(WithTrailingSlash(TrailingSlashRedirect))
+	r.GET("/users", handler)
+	r.GET("/users/", handler)
+	r.POST("/users/", handler)
+
+	err := r.Validate()
+	want := "GET /users/: duplicates GET /users under the trailing slash policy"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("expected error containing %q, got %v", want, err)
+	}
+	if strings.Contains(err.Error(), "POST") {
+		t.Errorf("expected no error for POST /users/, got %v", err)
+	}
+
+	strict := New()
+	strict.GET("/users", handler)
+	strict.GET("/users/", handler)
+	if err := strict.Validate(); err != nil {
+		t.Errorf("expected no error with strict trailing slashes, got %v", err)
+	}
+}
+
+// TestRouter_Validate_UnboundPathParams tests reporting path parameters
+// that the request type of a type-safe route does not bind.
+func TestRouter_Validate_UnboundPathParams(t *testing.T) {
+	type getPost struct {
+		PostID int `path:"postID"`
+	}
+	handler := func(c *Box[getPost, Empty]) error { return nil }
+
+	r := New()
+	GET[getPost, Empty](r, "/users/:id/posts/:postID", handler)
+	GET[getPost, Empty](r, "/posts/:postID", handler)
+
+	err := r.Validate()
+	want := `GET /users/:id/posts/:postID: path parameter "id" is not bound by request type fursy.getPost`
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("expected error containing %q, got %v", want, err)
+	}
+	if strings.Contains(err.Error(), "GET /posts/") || strings.Contains(err.Error(), "not documented") {
+		t.Errorf("expected a single report, got %v", err)
+	}
+}
+
+// TestRouter_Validate_Auth tests the authentication checks with group middleware.
+func TestRouter_Validate_Auth(t *testing.T) {
+	handler := func(c *Context) error { return nil }
+	auth := Named("auth", func(c *Context) error { return c.Next() })
+
+	r := New()
+	api := r.Group("/api", auth)
+	api.HandleWithOptions(http.MethodGet, "/me", handler, &RouteOptions{RequireAuth: true})
+	api.HandleWithOptions(http.MethodGet, "/status", handler, &RouteOptions{Public: true})
+	r.HandleWithOptions(http.MethodGet, "/health", handler, &RouteOptions{Public: true})
+
+	err := r.Validate()
+	want := `GET /api/status: route is documented as public but runs authentication middleware "auth"`
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("expected error containing %q, got %v", want, err)
+	}
+	if strings.Contains(err.Error(), "/api/me") || strings.Contains(err.Error(), "/health") {
+		t.Errorf("expected a single report, got %v", err)
+	}
+}
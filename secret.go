@@ -0,0 +1,223 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrSecretEmpty is returned by a SecretSource that has no current secret
+// (e.g., an unset environment variable or an empty file).
+var ErrSecretEmpty = errors.New("secret is empty")
+
+// SecretSource provides the keys used to sign and verify data
+// (JWTs, signed URLs, passwords) without hardcoding them.
+//
+// Secrets returns the current secret first, followed by previous secrets
+// that are still accepted for verification. New signatures always use the
+// current secret, so keys can be rotated without invalidating existing
+// tokens and links: add the new key as current, keep the old one as
+// previous until everything signed with it has expired, then drop it.
+//
+// Built-in sources: StaticSecrets, EnvSecret, FileSecret and RotatingSecret.
+type SecretSource interface {
+	Secrets() ([][]byte, error)
+}
+
+// CurrentSecret returns the current (signing) secret of src.
+//
+// Example:
+//
+//	key, err := fursy.CurrentSecret(secrets)
+//	if err != nil {
+//	    return err
+//	}
+//	signed, err := token.SignedString(key)
+func CurrentSecret(src SecretSource) ([]byte, error) {
+	secrets, err := src.Secrets()
+	if err != nil {
+		return nil, err
+	}
+	if len(secrets) == 0 || len(secrets[0]) == 0 {
+		return nil, ErrSecretEmpty
+	}
+	return secrets[0], nil
+}
+
+// copySecrets returns a copy of secrets without the empty ones, so that
+// an empty key is never accepted and callers cannot modify the secrets of
+// a source.
+func copySecrets(secrets [][]byte) [][]byte {
+	out := make([][]byte, 0, len(secrets))
+	for _, secret := range secrets {
+		if len(secret) > 0 {
+			out = append(out, bytes.Clone(secret))
+		}
+	}
+	return out
+}
+
+// staticSecrets is a SecretSource with fixed secrets.
+type staticSecrets [][]byte
+
+// Secrets implements SecretSource.
+func (s staticSecrets) Secrets() ([][]byte, error) {
+	if len(s) == 0 || len(s[0]) == 0 {
+		return nil, ErrSecretEmpty
+	}
+	return copySecrets(s), nil
+}
+
+// StaticSecrets returns a SecretSource with a fixed current secret and
+// previous secrets still accepted for verification. Empty previous secrets
+// (e.g., read from an unset environment variable) are ignored.
+//
+// Example (rotating from oldKey to newKey):
+//
+//	secrets := fursy.StaticSecrets(newKey, oldKey)
+func StaticSecrets(current []byte, previous ...[]byte) SecretSource {
+	return staticSecrets(append([][]byte{bytes.Clone(current)}, copySecrets(previous)...))
+}
+
+// envSecret is a SecretSource reading environment variables.
+type envSecret []string
+
+// Secrets implements SecretSource.
+func (e envSecret) Secrets() ([][]byte, error) {
+	secrets := make([][]byte, 0, len(e))
+	for i, name := range e {
+		value := os.Getenv(name)
+		switch {
+		case value != "":
+			secrets = append(secrets, []byte(value))
+		case i == 0:
+			return nil, ErrSecretEmpty
+		}
+	}
+	return secrets, nil
+}
+
+// EnvSecret returns a SecretSource reading the current secret from the
+// environment variable name and previous secrets from the variables in
+// previous (unset or empty previous variables are ignored).
+//
+// Variables are read on every call, so changes made with os.Setenv
+// take effect immediately.
+//
+// Example:
+//
+//	secrets := fursy.EnvSecret("JWT_SECRET", "JWT_SECRET_PREVIOUS")
+func EnvSecret(name string, previous ...string) SecretSource {
+	return envSecret(append([]string{name}, previous...))
+}
+
+// fileSecret is a SecretSource reading a file, reloaded when it changes.
+type fileSecret struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	secrets [][]byte
+}
+
+// FileSecret returns a SecretSource reading secrets from the file at path,
+// one per line: the first line is the current secret, the following lines
+// are previous secrets. Empty lines and surrounding whitespace are ignored.
+//
+// The file is re-read when its modification time or size changes, so
+// mounted secrets (e.g., Kubernetes Secrets, Docker secrets) are rotated
+// without a restart.
+//
+// Example:
+//
+//	secrets := fursy.FileSecret("/run/secrets/jwt")
+func FileSecret(path string) SecretSource {
+	return &fileSecret{path: path}
+}
+
+// Secrets implements SecretSource.
+func (f *fileSecret) Secrets() ([][]byte, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.secrets != nil && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return copySecrets(f.secrets), nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var secrets [][]byte
+	for line := range bytes.Lines(data) {
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			secrets = append(secrets, line)
+		}
+	}
+	if len(secrets) == 0 {
+		return nil, ErrSecretEmpty
+	}
+
+	f.secrets, f.modTime, f.size = secrets, info.ModTime(), info.Size()
+	return copySecrets(secrets), nil
+}
+
+// RotatingSecret is a SecretSource rotated at runtime (e.g., by a
+// scheduler or a secrets manager callback). It keeps a limited number
+// of previous secrets. It is safe for concurrent use.
+type RotatingSecret struct {
+	mu       sync.RWMutex
+	secrets  [][]byte
+	previous int
+}
+
+// NewRotatingSecret creates a RotatingSecret with the current secret,
+// keeping up to previous old secrets after rotations.
+//
+// Example:
+//
+//	secrets := fursy.NewRotatingSecret(initialKey, 1)
+//
+//	// Later, e.g. from a secrets manager webhook:
+//	secrets.Rotate(newKey)
+func NewRotatingSecret(current []byte, previous int) *RotatingSecret {
+	return &RotatingSecret{
+		secrets:  [][]byte{current},
+		previous: max(previous, 0),
+	}
+}
+
+// Rotate makes next the current secret. The former current secret
+// becomes the most recent previous secret; the oldest is dropped when
+// more than the configured number of previous secrets are kept.
+func (r *RotatingSecret) Rotate(next []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	secrets := append([][]byte{next}, r.secrets...)
+	r.secrets = secrets[:min(len(secrets), r.previous+1)]
+}
+
+// Secrets implements SecretSource.
+func (r *RotatingSecret) Secrets() ([][]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.secrets[0]) == 0 {
+		return nil, ErrSecretEmpty
+	}
+	return copySecrets(r.secrets), nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// secretStrings converts the secrets of src to strings.
+func secretStrings(t *testing.T, src SecretSource) []string {
+	t.Helper()
+	secrets, err := src.Secrets()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := make([]string, len(secrets))
+	for i, s := range secrets {
+		out[i] = string(s)
+	}
+	return out
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestStaticSecrets(t *testing.T) {
+	src := StaticSecrets([]byte("new"), []byte("old"))
+	if got := secretStrings(t, src); !equalStrings(got, []string{"new", "old"}) {
+		t.Errorf("unexpected secrets %v", got)
+	}
+
+	current, err := CurrentSecret(src)
+	if err != nil || string(current) != "new" {
+		t.Errorf("expected current secret %q, got %q (%v)", "new", current, err)
+	}
+
+	if _, err := CurrentSecret(StaticSecrets(nil)); !errors.Is(err, ErrSecretEmpty) {
+		t.Errorf("expected ErrSecretEmpty, got %v", err)
+	}
+
+	// Empty previous secrets (e.g., unset variables) are never accepted.
+	src = StaticSecrets([]byte("key"), []byte(os.Getenv("FURSY_TEST_UNSET")), nil)
+	if got := secretStrings(t, src); !equalStrings(got, []string{"key"}) {
+		t.Errorf("empty previous secrets should be dropped, got %v", got)
+	}
+
+	// Secrets are copies.
+	secrets, _ := src.Secrets()
+	secrets[0][0] = 'X'
+	if got := secretStrings(t, src); !equalStrings(got, []string{"key"}) {
+		t.Errorf("secrets modified through Secrets: %v", got)
+	}
+}
+
+func TestEnvSecret(t *testing.T) {
+	t.Setenv("FURSY_TEST_SECRET", "current")
+	t.Setenv("FURSY_TEST_SECRET_PREVIOUS", "")
+
+	src := EnvSecret("FURSY_TEST_SECRET", "FURSY_TEST_SECRET_PREVIOUS")
+	if got := secretStrings(t, src); !equalStrings(got, []string{"current"}) {
+		t.Errorf("unexpected secrets %v", got)
+	}
+
+	// Changes are picked up on the next call.
+	t.Setenv("FURSY_TEST_SECRET", "rotated")
+	t.Setenv("FURSY_TEST_SECRET_PREVIOUS", "current")
+	if got := secretStrings(t, src); !equalStrings(got, []string{"rotated", "current"}) {
+		t.Errorf("unexpected secrets after rotation %v", got)
+	}
+
+	t.Setenv("FURSY_TEST_SECRET", "")
+	if _, err := src.Secrets(); !errors.Is(err, ErrSecretEmpty) {
+		t.Errorf("expected ErrSecretEmpty, got %v", err)
+	}
+}
+
+func TestFileSecret(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte("first\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	src := FileSecret(path)
+	if got := secretStrings(t, src); !equalStrings(got, []string{"first"}) {
+		t.Errorf("unexpected secrets %v", got)
+	}
+
+	// Rotation: new secret first, old secret kept on the next line.
+	if err := os.WriteFile(path, []byte("  second \n\nfirst\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, time.Now(), time.Now().Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if got := secretStrings(t, src); !equalStrings(got, []string{"second", "first"}) {
+		t.Errorf("unexpected secrets after rotation %v", got)
+	}
+
+	if err := os.WriteFile(path, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := src.Secrets(); !errors.Is(err, ErrSecretEmpty) {
+		t.Errorf("expected ErrSecretEmpty for empty file, got %v", err)
+	}
+
+	if _, err := FileSecret(filepath.Join(t.TempDir(), "missing")).Secrets(); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestRotatingSecret(t *testing.T) {
+	src := NewRotatingSecret([]byte("k1"), 1)
+
+	src.Rotate([]byte("k2"))
+	if got := secretStrings(t, src); !equalStrings(got, []string{"k2", "k1"}) {
+		t.Errorf("unexpected secrets %v", got)
+	}
+
+	src.Rotate([]byte("k3"))
+	if got := secretStrings(t, src); !equalStrings(got, []string{"k3", "k2"}) {
+		t.Errorf("expected oldest secret to be dropped, got %v", got)
+	}
+}
+
+func TestSignedURLFrom_Rotation(t *testing.T) {
+	old := StaticSecrets([]byte("old"))
+	link, err := SignedURLFrom(old, "/files/a.pdf", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(link)
+
+	// Links signed with the previous key stay valid.
+	if err := VerifySignedURLFrom(StaticSecrets([]byte("new"), []byte("old")), u); err != nil {
+		t.Errorf("expected previous key to be accepted, got %v", err)
+	}
+
+	// Once the previous key is dropped, they are rejected.
+	if err := VerifySignedURLFrom(StaticSecrets([]byte("new")), u); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("expected ErrSignatureInvalid, got %v", err)
+	}
+
+	if _, err := SignedURLFrom(StaticSecrets(nil), "/files/a.pdf", time.Minute); !errors.Is(err, ErrSecretEmpty) {
+		t.Errorf("expected ErrSecretEmpty, got %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Query parameters used by signed URLs.
+const (
+	// SignedURLExpiresParam is the query parameter holding the expiry (Unix seconds).
+	SignedURLExpiresParam = "expires"
+
+	// SignedURLSignatureParam is the query parameter holding the signature.
+	SignedURLSignatureParam = "signature"
+)
+
+// Signed URL errors.
+var (
+	// ErrSignatureInvalid is returned when a signed URL has a missing or wrong signature.
+	ErrSignatureInvalid = errors.New("invalid URL signature")
+
+	// ErrSignatureExpired is returned when a signed URL has expired.
+	ErrSignatureExpired = errors.New("URL signature expired")
+)
+
+// SignedURL returns path with an expiry and HMAC-SHA256 signature appended
+// as query parameters, for time-limited links to private content.
+//
+// The signature covers the path, all existing query parameters and the expiry,
+// so none of them can be changed without invalidating the link.
+// Verify signed URLs with VerifySignedURL or the middleware.SignedURL middleware.
+//
+// Example:
+//
+//	link, err := fursy.SignedURL(secret, "/downloads/report.pdf", 15*time.Minute)
+//	// /downloads/report.pdf?expires=1735689600&signature=...
+func SignedURL(secret []byte, path string, expiry time.Duration) (string, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	query.Del(SignedURLSignatureParam)
+	query.Set(SignedURLExpiresParam, strconv.FormatInt(time.Now().Add(expiry).Unix(), 10))
+
+	u.RawQuery = query.Encode()
+	query.Set(SignedURLSignatureParam, signURL(secret, u.Path, u.RawQuery))
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// VerifySignedURL checks the signature and expiry of a URL created by SignedURL.
+//
+// Returns ErrSignatureInvalid if the signature is missing or does not match,
+// and ErrSignatureExpired if the link has expired.
+//
+// Example:
+//
+//	if err := fursy.VerifySignedURL(secret, c.Request.URL); err != nil {
+//	    return c.Problem(fursy.Forbidden(err.Error()))
+//	}
+func VerifySignedURL(secret []byte, u *url.URL) error {
+	return verifySignedURL([][]byte{secret}, u)
+}
+
+// SignedURLFrom is like SignedURL but signs with the current secret of src.
+//
+// Example:
+//
+//	secrets := fursy.EnvSecret("URL_SIGNING_KEY", "URL_SIGNING_KEY_PREVIOUS")
+//	link, err := fursy.SignedURLFrom(secrets, "/downloads/report.pdf", 15*time.Minute)
+func SignedURLFrom(src SecretSource, path string, expiry time.Duration) (string, error) {
+	secret, err := CurrentSecret(src)
+	if err != nil {
+		return "", err
+	}
+	return SignedURL(secret, path, expiry)
+}
+
+// VerifySignedURLFrom is like VerifySignedURL but accepts signatures made
+// with any secret of src (current or previous), so links stay valid
+// during key rotation.
+//
+// Returns the error of src if its secrets cannot be loaded.
+func VerifySignedURLFrom(src SecretSource, u *url.URL) error {
+	secrets, err := src.Secrets()
+	if err != nil {
+		return err
+	}
+	return verifySignedURL(secrets, u)
+}
+
+// verifySignedURL checks u against each of secrets.
+func verifySignedURL(secrets [][]byte, u *url.URL) error {
+	query := u.Query()
+
+	signature := query.Get(SignedURLSignatureParam)
+	expires, err := strconv.ParseInt(query.Get(SignedURLExpiresParam), 10, 64)
+	if signature == "" || err != nil {
+		return ErrSignatureInvalid
+	}
+
+	query.Del(SignedURLSignatureParam)
+	canonicalQuery := query.Encode()
+
+	valid := false
+	for _, secret := range secrets {
+		if len(secret) == 0 {
+			// Empty secrets of custom sources are never accepted.
+			continue
+		}
+		expected := signURL(secret, u.Path, canonicalQuery)
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return ErrSignatureInvalid
+	}
+
+	if time.Now().Unix() > expires {
+		return ErrSignatureExpired
+	}
+
+	return nil
+}
+
+// signURL computes the base64url-encoded HMAC-SHA256 of path and canonical query.
+func signURL(secret []byte, path, canonicalQuery string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(path))
+	mac.Write([]byte{'?'})
+	mac.Write([]byte(canonicalQuery))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSignedURL_Verify tests signing and verifying URLs.
+func TestSignedURL_Verify(t *testing.T) {
+	secret := []byte("secret")
+
+	link, err := SignedURL(secret, "/downloads/report.pdf?version=2", time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL failed: %v", err)
+	}
+	if !strings.HasPrefix(link, "/downloads/report.pdf?") ||
+		!strings.Contains(link, "expires=") || !strings.Contains(link, "signature=") || !strings.Contains(link, "version=2") {
+		t.Fatalf("unexpected signed URL: %s", link)
+	}
+
+	u, _ := url.Parse(link)
+	if err := VerifySignedURL(secret, u); err != nil {
+		t.Errorf("expected valid signature, got %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(u *url.URL)
+		secret []byte
+	}{
+		{"wrong secret", func(_ *url.URL) {}, []byte("other")},
+		{"changed path", func(u *url.URL) { u.Path = "/downloads/other.pdf" }, secret},
+		{"changed query", func(u *url.URL) {
+			q := u.Query()
+			q.Set("version", "3")
+			u.RawQuery = q.Encode()
+		}, secret},
+		{"missing signature", func(u *url.URL) {
+			q := u.Query()
+			q.Del(SignedURLSignatureParam)
+			u.RawQuery = q.Encode()
+		}, secret},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, _ := url.Parse(link)
+			tt.mutate(u)
+			if err := VerifySignedURL(tt.secret, u); !errors.Is(err, ErrSignatureInvalid) {
+				t.Errorf("expected ErrSignatureInvalid, got %v", err)
+			}
+		})
+	}
+}
+
+// TestSignedURL_Expired tests expired links.
+func TestSignedURL_Expired(t *testing.T) {
+	secret := []byte("secret")
+
+	link, err := SignedURL(secret, "/private/file.txt", -time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL failed: %v", err)
+	}
+
+	u, _ := url.Parse(link)
+	if err := VerifySignedURL(secret, u); !errors.Is(err, ErrSignatureExpired) {
+		t.Errorf("expected ErrSignatureExpired, got %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Skipper decides whether a middleware should be skipped for the current request.
+// Returning true skips the middleware (the chain continues with c.Next()).
+//
+// All FURSY middleware accept a Skipper in their config. Skippers can be
+// written by hand or built from path patterns and method filters:
+//
+//	router.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
+//	    Skipper: fursy.SkipPaths("/health", "/metrics", "/static/*"),
+//	}))
+//
+//	router.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+//	    SigningKey: secret,
+//	    Skipper: fursy.SkipPaths("POST /login", "/public/*").
+//	        Or(fursy.SkipMethods(http.MethodOptions)),
+//	}))
+type Skipper func(c *Context) bool
+
+// ShouldSkip reports whether the middleware should be skipped.
+// A nil Skipper never skips.
+func (s Skipper) ShouldSkip(c *Context) bool {
+	return s != nil && s(c)
+}
+
+// Or returns a Skipper that skips if either s or other skips.
+// Nil skippers are ignored.
+func (s Skipper) Or(other Skipper) Skipper {
+	switch {
+	case s == nil:
+		return other
+	case other == nil:
+		return s
+	}
+	return func(c *Context) bool {
+		return s(c) || other(c)
+	}
+}
+
+// And returns a Skipper that skips only if both s and other skip.
+// Nil skippers are ignored.
+func (s Skipper) And(other Skipper) Skipper {
+	switch {
+	case s == nil:
+		return other
+	case other == nil:
+		return s
+	}
+	return func(c *Context) bool {
+		return s(c) && other(c)
+	}
+}
+
+// SkipPaths returns a Skipper that skips requests whose path matches any of the patterns.
+//
+// Pattern syntax:
+//   - Static paths: "/health"
+//   - Route parameters match one segment: "/users/:id"
+//   - Trailing wildcard matches the rest of the path: "/static/*" or "/files/*path"
+//   - Glob characters within a segment (see path.Match): "/assets/*.css"
+//   - Optional method prefix: "GET /health", "POST /login"
+//
+// Example:
+//
+//	skip := fursy.SkipPaths("/health", "/metrics", "/static/*", "GET /users/:id")
+func SkipPaths(patterns ...string) Skipper {
+	compiled := make([]skipPattern, 0, len(patterns))
+	for _, p := range patterns {
+		compiled = append(compiled, compileSkipPattern(p))
+	}
+
+	return func(c *Context) bool {
+		reqPath := c.Request.URL.Path
+		for i := range compiled {
+			if compiled[i].match(c.Request.Method, reqPath) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SkipMethods returns a Skipper that skips requests with any of the given HTTP methods.
+//
+// Example:
+//
+//	skip := fursy.SkipMethods(http.MethodOptions, http.MethodHead)
+func SkipMethods(methods ...string) Skipper {
+	set := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		set[strings.ToUpper(m)] = true
+	}
+
+	return func(c *Context) bool {
+		return set[c.Request.Method]
+	}
+}
+
+// skipPattern is a compiled SkipPaths pattern.
+type skipPattern struct {
+	method   string   // Empty matches any method.
+	segments []string // Path segments (without leading slash).
+	catchAll bool     // Last segment is a wildcard matching the rest.
+	exact    string   // Set for static patterns (fast path).
+}
+
+// compileSkipPattern parses a pattern like "GET /users/:id" or "/static/*".
+func compileSkipPattern(pattern string) skipPattern {
+	var sp skipPattern
+
+	pattern = strings.TrimSpace(pattern)
+	if method, rest, ok := strings.Cut(pattern, " "); ok && !strings.HasPrefix(pattern, "/") {
+		sp.method = strings.ToUpper(method)
+		pattern = strings.TrimSpace(rest)
+	}
+
+	if !strings.ContainsAny(pattern, ":*?[") {
+		sp.exact = pattern
+		return sp
+	}
+
+	sp.segments = strings.Split(strings.TrimPrefix(pattern, "/"), "/")
+	if last := sp.segments[len(sp.segments)-1]; last == "*" || (strings.HasPrefix(last, "*") && isParamName(last[1:])) {
+		sp.catchAll = true
+		sp.segments = sp.segments[:len(sp.segments)-1]
+	}
+
+	return sp
+}
+
+// match reports whether the pattern matches the method and path.
+func (sp *skipPattern) match(method, reqPath string) bool {
+	if sp.method != "" && sp.method != method {
+		// HEAD requests match GET patterns, mirroring net/http semantics.
+		if sp.method != http.MethodGet || method != http.MethodHead {
+			return false
+		}
+	}
+
+	if sp.segments == nil && !sp.catchAll {
+		return reqPath == sp.exact
+	}
+
+	parts := strings.Split(strings.TrimPrefix(reqPath, "/"), "/")
+	if len(parts) < len(sp.segments) || (!sp.catchAll && len(parts) != len(sp.segments)) {
+		return false
+	}
+
+	for i, seg := range sp.segments {
+		switch {
+		case strings.HasPrefix(seg, ":"):
+			if parts[i] == "" {
+				return false
+			}
+		case strings.ContainsAny(seg, "*?["):
+			if ok, _ := path.Match(seg, parts[i]); !ok {
+				return false
+			}
+		default:
+			if seg != parts[i] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// isParamName reports whether s is a valid wildcard name (e.g., "path" in "*path").
+func isParamName(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '_' && (c < '0' || c > '9') && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			return false
+		}
+	}
+	return s != ""
+}
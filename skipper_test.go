@@ -0,0 +1,106 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// skipperContext creates a Context for the given method and path.
+func skipperContext(method, path string) *Context {
+	c := newContext()
+	c.Request = httptest.NewRequest(method, path, http.NoBody)
+	return c
+}
+
+// TestSkipPaths tests pattern matching of SkipPaths.
+func TestSkipPaths(t *testing.T) {
+	skip := SkipPaths("/health", "/static/*", "/files/*path", "/users/:id/avatar", "/assets/*.css", "POST /login")
+
+	tests := []struct {
+		method string
+		path   string
+		want   bool
+	}{
+		{http.MethodGet, "/health", true},
+		{http.MethodGet, "/healthz", false},
+		{http.MethodGet, "/static/js/app.js", true},
+		{http.MethodGet, "/static", true},
+		{http.MethodGet, "/statics/app.js", false},
+		{http.MethodGet, "/files/a/b/c.txt", true},
+		{http.MethodGet, "/users/42/avatar", true},
+		{http.MethodGet, "/users/42", false},
+		{http.MethodGet, "/users//avatar", false},
+		{http.MethodGet, "/assets/site.css", true},
+		{http.MethodGet, "/assets/site.js", false},
+		{http.MethodPost, "/login", true},
+		{http.MethodGet, "/login", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			if got := skip(skipperContext(tt.method, tt.path)); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestSkipPaths_HeadMatchesGet tests that HEAD requests match GET patterns.
+func TestSkipPaths_HeadMatchesGet(t *testing.T) {
+	skip := SkipPaths("GET /health")
+
+	if !skip(skipperContext(http.MethodHead, "/health")) {
+		t.Error("expected HEAD to match GET pattern")
+	}
+	if skip(skipperContext(http.MethodPost, "/health")) {
+		t.Error("expected POST not to match GET pattern")
+	}
+}
+
+// TestSkipMethods tests method-based skipping.
+func TestSkipMethods(t *testing.T) {
+	skip := SkipMethods("options", http.MethodHead)
+
+	if !skip(skipperContext(http.MethodOptions, "/users")) {
+		t.Error("expected OPTIONS to be skipped")
+	}
+	if skip(skipperContext(http.MethodGet, "/users")) {
+		t.Error("expected GET not to be skipped")
+	}
+}
+
+// TestSkipper_Combinators tests Or, And and nil handling.
+func TestSkipper_Combinators(t *testing.T) {
+	var none Skipper
+	if none.ShouldSkip(skipperContext(http.MethodGet, "/")) {
+		t.Error("nil Skipper should never skip")
+	}
+
+	paths := SkipPaths("/public/*")
+	methods := SkipMethods(http.MethodGet)
+
+	or := paths.Or(methods)
+	and := paths.And(methods)
+
+	if !or.ShouldSkip(skipperContext(http.MethodPost, "/public/a")) {
+		t.Error("Or: expected skip for matching path")
+	}
+	if !or.ShouldSkip(skipperContext(http.MethodGet, "/private")) {
+		t.Error("Or: expected skip for matching method")
+	}
+	if and.ShouldSkip(skipperContext(http.MethodPost, "/public/a")) {
+		t.Error("And: expected no skip when method differs")
+	}
+	if !and.ShouldSkip(skipperContext(http.MethodGet, "/public/a")) {
+		t.Error("And: expected skip when both match")
+	}
+
+	if got := none.Or(paths); got == nil || !got.ShouldSkip(skipperContext(http.MethodGet, "/public/x")) {
+		t.Error("nil.Or(paths) should behave like paths")
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// fieldsParam is the query parameter selecting sparse fieldsets.
+const fieldsParam = "fields"
+
+// fieldSet is a tree of selected JSON fields. A field mapped to nil is
+// selected with all its subfields.
+type fieldSet map[string]fieldSet
+
+// parseFieldSet parses a comma-separated list of dotted field paths.
+func parseFieldSet(paths []string) fieldSet {
+	set := fieldSet{}
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		node := set
+		parts := strings.Split(path, ".")
+		for i, part := range parts {
+			child, ok := node[part]
+			if ok && child == nil {
+				break // Already selected with all its subfields.
+			}
+			if i == len(parts)-1 {
+				node[part] = nil
+				break
+			}
+			if child == nil {
+				child = fieldSet{}
+				node[part] = child
+			}
+			node = child
+		}
+	}
+	return set
+}
+
+// allows reports whether the allowlist set selects path.
+func (s fieldSet) allows(path string) bool {
+	node := s
+	for part := range strings.SplitSeq(path, ".") {
+		child, ok := node[part]
+		if !ok {
+			return false
+		}
+		if child == nil {
+			return true
+		}
+		node = child
+	}
+	return false
+}
+
+// jsonDocument returns the generic JSON document of obj, with numbers
+// decoded as json.Number so they are encoded unchanged.
+func jsonDocument(obj any) (any, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// prune removes the fields of v that are not selected. Arrays are pruned
+// element by element, so list responses select the fields of their items.
+func (s fieldSet) prune(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, child := range v {
+			sub, ok := s[key]
+			switch {
+			case !ok:
+				delete(v, key)
+			case sub != nil:
+				v[key] = sub.prune(child)
+			}
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = s.prune(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// fieldsPolicy selects the sparse fieldset of the request among allowed.
+// Requests for fields outside allowed are rejected with 400 Bad Request;
+// without a selection, responses are pruned to allowed. Only JSON
+// responses are pruned.
+func fieldsPolicy(next HandlerFunc, allowed []string) HandlerFunc {
+	allowlist := parseFieldSet(allowed)
+	return func(c *Context) error {
+		c.fields = allowlist
+		value := c.Query(fieldsParam)
+		if value == "" {
+			return next(c)
+		}
+
+		paths := strings.Split(value, ",")
+		for _, path := range paths {
+			if path = strings.TrimSpace(path); path != "" && !allowlist.allows(path) {
+				return c.Problem(BadRequest("field " + path + " cannot be selected"))
+			}
+		}
+		if set := parseFieldSet(paths); len(set) > 0 {
+			c.fields = set
+		}
+		return next(c)
+	}
+}
+
+// fieldsParameter documents the fields query parameter of a route
+// selecting among allowed.
+func fieldsParameter(allowed []string) RouteParameter {
+	return RouteParameter{
+		Name:        fieldsParam,
+		In:          "query",
+		Description: "Comma-separated fields to include in the response: " + strings.Join(allowed, ", "),
+		Type:        reflect.TypeFor[string](),
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// sparseUser is a response with nested and hidden fields.
+type sparseUser struct {
+	ID       int           `json:"id"`
+	Name     string        `json:"name"`
+	Email    string        `json:"email"`
+	Address  sparseAddress `json:"address"`
+	Password string        `json:"password"`
+}
+
+type sparseAddress struct {
+	City   string `json:"city"`
+	Street string `json:"street"`
+}
+
+// TestSparseFields tests pruning JSON responses with the fields parameter.
+func TestSparseFields(t *testing.T) {
+	user := sparseUser{ID: 1, Name: "Alice", Email: "a@example.com", Address: sparseAddress{City: "Paris", Street: "Rue"}, Password: "secret"}
+
+	r := New()
+	r.HandleWithOptions(http.MethodGet, "/users", func(c *Context) error {
+		return c.JSON(http.StatusOK, []sparseUser{user})
+	}, &RouteOptions{Fields: []string{"id", "name", "email", "address.city"}})
+	GET[Empty, sparseUser](r, "/users/:id", func(c *Box[Empty, sparseUser]) error {
+		return c.OK(user)
+	}, Fields("id", "address"))
+
+	tests := []struct {
+		path     string
+		wantCode int
+		wantBody string
+	}{
+		{"/users?fields=id,address.city", http.StatusOK, `[{"address":{"city":"Paris"},"id":1}]`},
+		{"/users?fields=name", http.StatusOK, `[{"name":"Alice"}]`},
+		{"/users?fields=password", http.StatusBadRequest, ""},
+		{"/users?fields=,", http.StatusOK, `[{"address":{"city":"Paris"},"email":"a@example.com","id":1,"name":"Alice"}]`},
+		{"/users?fields=address", http.StatusBadRequest, ""},
+		{"/users/1?fields=address", http.StatusOK, `{"address":{"city":"Paris","street":"Rue"}}`},
+		{"/users/1?fields=address.street,id", http.StatusOK, `{"address":{"street":"Rue"},"id":1}`},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+		if w.Code != tt.wantCode {
+			t.Errorf("%s: status = %d, want %d", tt.path, w.Code, tt.wantCode)
+		}
+		if tt.wantBody != "" && strings.TrimSpace(w.Body.String()) != tt.wantBody {
+			t.Errorf("%s: body = %s, want %s", tt.path, w.Body.String(), tt.wantBody)
+		}
+	}
+
+	// Without the fields parameter, the response is pruned to the allowlist.
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users", http.NoBody))
+	if want := `[{"address":{"city":"Paris"},"email":"a@example.com","id":1,"name":"Alice"}]`; strings.TrimSpace(w.Body.String()) != want {
+		t.Errorf("allowlisted response expected, got %s", w.Body.String())
+	}
+
+	// The fields parameter is documented.
+	params := r.Routes()[0].Parameters
+	if len(params) != 1 || params[0].Name != "fields" || params[0].In != "query" {
+		t.Errorf("expected fields parameter, got %+v", params)
+	}
+}
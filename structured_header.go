@@ -0,0 +1,584 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SFToken is a token of a structured field value (RFC 8941), e.g. the
+// "gzip" of "Accept-Features: gzip". Strings are parsed as string.
+type SFToken string
+
+// SFParam is a parameter of a structured field item or inner list.
+type SFParam struct {
+	// Key is the parameter name.
+	Key string
+
+	// Value is the parameter value (true if the parameter has no value).
+	Value any
+}
+
+// SFItem is an item of a structured field value (RFC 8941).
+//
+// Value is an int64, a float64, a string, an SFToken, a []byte or a bool.
+type SFItem struct {
+	// Value is the bare item.
+	Value any
+
+	// Params are the parameters of the item, in order.
+	Params []SFParam
+}
+
+// Param returns the value of the named parameter.
+func (i SFItem) Param(key string) (any, bool) {
+	return sfParam(i.Params, key)
+}
+
+// String serializes the item (RFC 8941 Section 4.1.3), e.g. "2;beta".
+func (i SFItem) String() string {
+	var b strings.Builder
+	writeSFItem(&b, i)
+	return b.String()
+}
+
+// SFMember is a member of a structured field list or dictionary: an item,
+// or an inner list if InnerList is not nil (the parameters of the inner
+// list are then in Params and Value is nil).
+type SFMember struct {
+	SFItem
+
+	// InnerList holds the items of an inner list (e.g., "(a b);q=1").
+	InnerList []SFItem
+}
+
+// String serializes the item or inner list (RFC 8941 Section 4.1),
+// e.g. `("@method" "@path");created=1618884473`.
+func (m SFMember) String() string {
+	if m.InnerList == nil {
+		return m.SFItem.String()
+	}
+	var b strings.Builder
+	b.WriteByte('(')
+	for i, item := range m.InnerList {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		writeSFItem(&b, item)
+	}
+	b.WriteByte(')')
+	writeSFParams(&b, m.Params)
+	return b.String()
+}
+
+// SFList is a structured field list (RFC 8941), e.g. "gzip, br;q=0.5".
+type SFList []SFMember
+
+// SFDictionaryMember is a member of a structured field dictionary.
+type SFDictionaryMember struct {
+	// Key is the member name.
+	Key string
+
+	SFMember
+}
+
+// String serializes the member (RFC 8941 Section 4.1.2), e.g. "version=2"
+// or "beta" for the boolean true.
+func (m SFDictionaryMember) String() string {
+	if m.Value == true && m.InnerList == nil {
+		var b strings.Builder
+		b.WriteString(m.Key)
+		writeSFParams(&b, m.Params)
+		return b.String()
+	}
+	return m.Key + "=" + m.SFMember.String()
+}
+
+// SFDictionary is a structured field dictionary (RFC 8941), in order,
+// e.g. "beta, dark-mode=?0, version=2".
+type SFDictionary []SFDictionaryMember
+
+// Get returns the member named key (the last one if repeated).
+func (d SFDictionary) Get(key string) (SFMember, bool) {
+	for i := len(d) - 1; i >= 0; i-- {
+		if d[i].Key == key {
+			return d[i].SFMember, true
+		}
+	}
+	return SFMember{}, false
+}
+
+// ParseSFItem parses a structured field item (RFC 8941 Section 4.2),
+// e.g. the value of "API-Version: 2;beta".
+func ParseSFItem(value string) (SFItem, error) {
+	p := &sfParser{s: value}
+	p.skipSP()
+	item, err := p.item()
+	if err != nil {
+		return SFItem{}, err
+	}
+	p.skipSP()
+	if !p.done() {
+		return SFItem{}, p.errorf("unexpected %q after item", p.peek())
+	}
+	return item, nil
+}
+
+// ParseSFList parses a structured field list (RFC 8941 Section 4.2).
+// Combine repeated header fields with ", " first (see Context.HeaderList).
+func ParseSFList(value string) (SFList, error) {
+	p := &sfParser{s: value}
+	var list SFList
+	p.skipSP()
+	for !p.done() {
+		member, err := p.member()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, member)
+		if err := p.memberSeparator(); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+// ParseSFDictionary parses a structured field dictionary (RFC 8941
+// Section 4.2). Members without a value are the boolean true.
+// Combine repeated header fields with ", " first (see Context.HeaderDictionary).
+func ParseSFDictionary(value string) (SFDictionary, error) {
+	p := &sfParser{s: value}
+	var dict SFDictionary
+	p.skipSP()
+	for !p.done() {
+		key, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+
+		var member SFMember
+		if p.consume('=') {
+			if member, err = p.member(); err != nil {
+				return nil, err
+			}
+		} else {
+			member.Value = true
+			if member.Params, err = p.params(); err != nil {
+				return nil, err
+			}
+		}
+		dict = append(dict, SFDictionaryMember{Key: key, SFMember: member})
+
+		if err := p.memberSeparator(); err != nil {
+			return nil, err
+		}
+	}
+	return dict, nil
+}
+
+// HeaderItem parses the named request header as a structured field item.
+// Returns a zero item and no error if the header is missing, and a 400
+// Bad Request problem if it is invalid.
+//
+// Example:
+//
+//	// API-Version: 2;beta
+//	v, err := c.HeaderItem("API-Version")
+//	if err != nil {
+//	    return err
+//	}
+//	version, _ := v.Value.(int64) // 2
+//	_, beta := v.Param("beta")    // true
+func (c *Context) HeaderItem(name string) (SFItem, error) {
+	value, ok := c.structuredHeader(name)
+	if !ok {
+		return SFItem{}, nil
+	}
+	item, err := ParseSFItem(value)
+	if err != nil {
+		return SFItem{}, headerProblem(name, err)
+	}
+	return item, nil
+}
+
+// HeaderList parses the named request header (all its fields) as
+// a structured field list. Returns nil and no error if the header is
+// missing, and a 400 Bad Request problem if it is invalid.
+func (c *Context) HeaderList(name string) (SFList, error) {
+	value, ok := c.structuredHeader(name)
+	if !ok {
+		return nil, nil
+	}
+	list, err := ParseSFList(value)
+	if err != nil {
+		return nil, headerProblem(name, err)
+	}
+	return list, nil
+}
+
+// HeaderDictionary parses the named request header (all its fields) as
+// a structured field dictionary. Returns nil and no error if the header is
+// missing, and a 400 Bad Request problem if it is invalid.
+//
+// Example:
+//
+//	// Features: beta, dark-mode=?0
+//	features, err := c.HeaderDictionary("Features")
+//	if err != nil {
+//	    return err
+//	}
+//	if m, ok := features.Get("beta"); ok && m.Value == true {
+//	    // Beta features enabled.
+//	}
+func (c *Context) HeaderDictionary(name string) (SFDictionary, error) {
+	value, ok := c.structuredHeader(name)
+	if !ok {
+		return nil, nil
+	}
+	dict, err := ParseSFDictionary(value)
+	if err != nil {
+		return nil, headerProblem(name, err)
+	}
+	return dict, nil
+}
+
+// structuredHeader returns the fields of the named request header,
+// combined with ", ".
+func (c *Context) structuredHeader(name string) (string, bool) {
+	values := c.Request.Header.Values(name)
+	if len(values) == 0 {
+		return "", false
+	}
+	return strings.Join(values, ", "), true
+}
+
+// headerProblem returns the 400 Bad Request problem of an invalid header.
+func headerProblem(name string, err error) Problem {
+	return BadRequest(fmt.Sprintf("header %q %s", name, err))
+}
+
+// sfParam returns the value of the parameter key.
+func sfParam(params []SFParam, key string) (any, bool) {
+	for i := len(params) - 1; i >= 0; i-- {
+		if params[i].Key == key {
+			return params[i].Value, true
+		}
+	}
+	return nil, false
+}
+
+// writeSFItem serializes an item (RFC 8941 Section 4.1.3).
+func writeSFItem(b *strings.Builder, item SFItem) {
+	writeSFBareItem(b, item.Value)
+	writeSFParams(b, item.Params)
+}
+
+// writeSFParams serializes parameters (RFC 8941 Section 4.1.1.2).
+// Parameters set to true have no value.
+func writeSFParams(b *strings.Builder, params []SFParam) {
+	for _, param := range params {
+		b.WriteByte(';')
+		b.WriteString(param.Key)
+		if param.Value != true {
+			b.WriteByte('=')
+			writeSFBareItem(b, param.Value)
+		}
+	}
+}
+
+// writeSFBareItem serializes a bare item (RFC 8941 Section 4.1.3.1).
+func writeSFBareItem(b *strings.Builder, value any) {
+	switch v := value.(type) {
+	case int64:
+		b.WriteString(strconv.FormatInt(v, 10))
+	case float64:
+		text := strings.TrimRight(strconv.FormatFloat(v, 'f', 3, 64), "0")
+		if strings.HasSuffix(text, ".") {
+			text += "0"
+		}
+		b.WriteString(text)
+	case string:
+		b.WriteByte('"')
+		b.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v))
+		b.WriteByte('"')
+	case SFToken:
+		b.WriteString(string(v))
+	case []byte:
+		b.WriteByte(':')
+		b.WriteString(base64.StdEncoding.EncodeToString(v))
+		b.WriteByte(':')
+	case bool:
+		if v {
+			b.WriteString("?1")
+		} else {
+			b.WriteString("?0")
+		}
+	default:
+		fmt.Fprint(b, v)
+	}
+}
+
+// sfParser parses structured field values (RFC 8941 Section 4.2).
+type sfParser struct {
+	s   string
+	pos int
+}
+
+func (p *sfParser) done() bool { return p.pos >= len(p.s) }
+
+func (p *sfParser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+// consume consumes c if it is the next character.
+func (p *sfParser) consume(c byte) bool {
+	if p.peek() == c && !p.done() {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *sfParser) skipSP() {
+	for p.peek() == ' ' {
+		p.pos++
+	}
+}
+
+func (p *sfParser) skipOWS() {
+	for p.peek() == ' ' || p.peek() == '\t' {
+		p.pos++
+	}
+}
+
+func (p *sfParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("is not a valid structured field: "+format, args...)
+}
+
+// memberSeparator parses the separator after a list or dictionary member.
+func (p *sfParser) memberSeparator() error {
+	p.skipOWS()
+	if p.done() {
+		return nil
+	}
+	if !p.consume(',') {
+		return p.errorf("expected ',' at offset %d", p.pos)
+	}
+	p.skipOWS()
+	if p.done() {
+		return p.errorf("trailing ','")
+	}
+	return nil
+}
+
+// member parses an item or an inner list.
+func (p *sfParser) member() (SFMember, error) {
+	if p.peek() != '(' {
+		item, err := p.item()
+		return SFMember{SFItem: item}, err
+	}
+
+	p.pos++
+	member := SFMember{InnerList: []SFItem{}}
+	for {
+		p.skipSP()
+		if p.consume(')') {
+			params, err := p.params()
+			member.Params = params
+			return member, err
+		}
+		item, err := p.item()
+		if err != nil {
+			return SFMember{}, err
+		}
+		member.InnerList = append(member.InnerList, item)
+		if c := p.peek(); c != ' ' && c != ')' {
+			return SFMember{}, p.errorf("expected ' ' or ')' in inner list at offset %d", p.pos)
+		}
+	}
+}
+
+// item parses a bare item and its parameters.
+func (p *sfParser) item() (SFItem, error) {
+	value, err := p.bareItem()
+	if err != nil {
+		return SFItem{}, err
+	}
+	params, err := p.params()
+	return SFItem{Value: value, Params: params}, err
+}
+
+// params parses the parameters of an item or inner list.
+func (p *sfParser) params() ([]SFParam, error) {
+	var params []SFParam
+	for p.consume(';') {
+		p.skipSP()
+		key, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+		var value any = true
+		if p.consume('=') {
+			if value, err = p.bareItem(); err != nil {
+				return nil, err
+			}
+		}
+		params = append(params, SFParam{Key: key, Value: value})
+	}
+	return params, nil
+}
+
+// key parses a parameter or dictionary key.
+func (p *sfParser) key() (string, error) {
+	start := p.pos
+	if c := p.peek(); !(c >= 'a' && c <= 'z') && c != '*' {
+		return "", p.errorf("invalid key at offset %d", p.pos)
+	}
+	for !p.done() {
+		c := p.peek()
+		if !(c >= 'a' && c <= 'z') && !(c >= '0' && c <= '9') && !strings.ContainsRune("_-.*", rune(c)) {
+			break
+		}
+		p.pos++
+	}
+	return p.s[start:p.pos], nil
+}
+
+// bareItem parses an integer, decimal, string, token, byte sequence or boolean.
+func (p *sfParser) bareItem() (any, error) {
+	c := p.peek()
+	switch {
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.number()
+	case c == '"':
+		return p.string()
+	case c == '*' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+		return p.token(), nil
+	case c == ':':
+		return p.bytes()
+	case c == '?':
+		return p.boolean()
+	default:
+		return nil, p.errorf("invalid item at offset %d", p.pos)
+	}
+}
+
+// number parses an integer (at most 15 digits) or a decimal (at most 12
+// integer and 3 fraction digits).
+func (p *sfParser) number() (any, error) {
+	start := p.pos
+	p.consume('-')
+	digits, dot := 0, -1
+	for !p.done() {
+		c := p.peek()
+		if c == '.' && dot < 0 {
+			dot = digits
+		} else if c < '0' || c > '9' {
+			break
+		} else {
+			digits++
+		}
+		p.pos++
+	}
+	text := p.s[start:p.pos]
+
+	if dot < 0 {
+		if digits == 0 || digits > 15 {
+			return nil, p.errorf("invalid integer %q", text)
+		}
+		n, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return nil, p.errorf("invalid integer %q", text)
+		}
+		return n, nil
+	}
+	if fraction := digits - dot; dot == 0 || dot > 12 || fraction == 0 || fraction > 3 {
+		return nil, p.errorf("invalid decimal %q", text)
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil, p.errorf("invalid decimal %q", text)
+	}
+	return f, nil
+}
+
+// string parses a quoted string.
+func (p *sfParser) string() (string, error) {
+	p.pos++ // Opening quote.
+	var b strings.Builder
+	for !p.done() {
+		c := p.s[p.pos]
+		p.pos++
+		switch {
+		case c == '\\':
+			if next := p.peek(); next != '"' && next != '\\' {
+				return "", p.errorf("invalid escape in string")
+			}
+			b.WriteByte(p.s[p.pos])
+			p.pos++
+		case c == '"':
+			return b.String(), nil
+		case c < 0x20 || c > 0x7e:
+			return "", p.errorf("invalid character in string")
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// token parses a token.
+func (p *sfParser) token() SFToken {
+	start := p.pos
+	p.pos++
+	for !p.done() {
+		c := p.peek()
+		if !isTokenChar(c) && c != ':' && c != '/' {
+			break
+		}
+		p.pos++
+	}
+	return SFToken(p.s[start:p.pos])
+}
+
+// bytes parses a base64 byte sequence between colons.
+func (p *sfParser) bytes() ([]byte, error) {
+	p.pos++ // Opening colon.
+	end := strings.IndexByte(p.s[p.pos:], ':')
+	if end < 0 {
+		return nil, p.errorf("unterminated byte sequence")
+	}
+	data, err := base64.StdEncoding.DecodeString(p.s[p.pos : p.pos+end])
+	if err != nil {
+		return nil, p.errorf("invalid byte sequence")
+	}
+	p.pos += end + 1
+	return data, nil
+}
+
+// boolean parses ?0 or ?1.
+func (p *sfParser) boolean() (bool, error) {
+	p.pos++ // Question mark.
+	switch {
+	case p.consume('1'):
+		return true, nil
+	case p.consume('0'):
+		return false, nil
+	default:
+		return false, p.errorf("invalid boolean at offset %d", p.pos)
+	}
+}
+
+// isTokenChar reports whether c is a tchar (RFC 9110).
+func isTokenChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0
+}
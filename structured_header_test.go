@@ -0,0 +1,174 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestParseSFItem tests parsing structured field items.
+func TestParseSFItem(t *testing.T) {
+	tests := []struct {
+		value string
+		want  any
+	}{
+		{"2", int64(2)},
+		{"-1.5", -1.5},
+		{`"hello \"world\""`, `hello "world"`},
+		{"gzip", SFToken("gzip")},
+		{"?1", true},
+		{":aGk=:", "hi"},
+	}
+	for _, tt := range tests {
+		item, err := ParseSFItem(tt.value)
+		if err != nil {
+			t.Errorf("ParseSFItem(%q) error: %v", tt.value, err)
+			continue
+		}
+		got := item.Value
+		if b, ok := got.([]byte); ok {
+			got = string(b)
+		}
+		if got != tt.want {
+			t.Errorf("ParseSFItem(%q) = %#v, want %#v", tt.value, got, tt.want)
+		}
+	}
+
+	item, err := ParseSFItem("2;beta;q=0.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := item.Param("beta"); !ok || v != true {
+		t.Errorf("Param(beta) = %v, %v", v, ok)
+	}
+	if v, _ := item.Param("q"); v != 0.5 {
+		t.Errorf("Param(q) = %v", v)
+	}
+
+	for _, invalid := range []string{"", "1 2", `"open`, "?2", "1234567890123456", "1.2345", "(a"} {
+		if _, err := ParseSFItem(invalid); err == nil {
+			t.Errorf("ParseSFItem(%q) expected error", invalid)
+		}
+	}
+}
+
+// TestParseSFList tests parsing structured field lists with inner lists.
+func TestParseSFList(t *testing.T) {
+	list, err := ParseSFList(`gzip, br;q=0.5, ("a" "b");lvl=1`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 3 {
+		t.Fatalf("len = %d, want 3", len(list))
+	}
+	if list[0].Value != SFToken("gzip") {
+		t.Errorf("list[0] = %v", list[0].Value)
+	}
+	if v, _ := list[1].Param("q"); v != 0.5 {
+		t.Errorf("list[1] q = %v", v)
+	}
+	if len(list[2].InnerList) != 2 || list[2].InnerList[1].Value != "b" {
+		t.Errorf("list[2] inner list = %+v", list[2].InnerList)
+	}
+	if v, _ := list[2].Param("lvl"); v != int64(1) {
+		t.Errorf("list[2] lvl = %v", v)
+	}
+
+	for _, invalid := range []string{"a,", "a b", "a,,b"} {
+		if _, err := ParseSFList(invalid); err == nil {
+			t.Errorf("ParseSFList(%q) expected error", invalid)
+		}
+	}
+}
+
+// TestParseSFDictionary tests parsing structured field dictionaries.
+func TestParseSFDictionary(t *testing.T) {
+	dict, err := ParseSFDictionary("beta, dark-mode=?0, version=2;pre, version=3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m, ok := dict.Get("beta"); !ok || m.Value != true {
+		t.Errorf("beta = %+v, %v", m, ok)
+	}
+	if m, _ := dict.Get("dark-mode"); m.Value != false {
+		t.Errorf("dark-mode = %v", m.Value)
+	}
+	if m, _ := dict.Get("version"); m.Value != int64(3) {
+		t.Errorf("version = %v, want the last member", m.Value)
+	}
+	if _, ok := dict.Get("missing"); ok {
+		t.Error("Get(missing) should report false")
+	}
+
+	if _, err := ParseSFDictionary("Beta"); err == nil {
+		t.Error("expected error for uppercase key")
+	}
+}
+
+// TestSF_String tests serializing parsed structured fields canonically.
+func TestSF_String(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{`2;beta`, `2;beta`},
+		{`("@method"   "a\"b";k=?0);created=16;alg=ed25519`, `("@method" "a\"b";k=?0);created=16;alg=ed25519`},
+		{`1.50;q=?1`, `1.5;q`},
+		{`:aGk=:`, `:aGk=:`},
+	}
+	for _, tt := range tests {
+		list, err := ParseSFList(tt.in)
+		if err != nil || len(list) != 1 {
+			t.Fatalf("ParseSFList(%q) = %v, %v", tt.in, list, err)
+		}
+		if got := list[0].String(); got != tt.want {
+			t.Errorf("String(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	dict, err := ParseSFDictionary("beta;x=1, version=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dict[0].String() != "beta;x=1" || dict[1].String() != "version=2" {
+		t.Errorf("dictionary members = %q, %q", dict[0], dict[1])
+	}
+}
+
+// TestContext_StructuredHeaders tests parsing request headers as
+// structured fields.
+func TestContext_StructuredHeaders(t *testing.T) {
+	c := newContext()
+	c.Request = httptest.NewRequest("GET", "/", http.NoBody)
+	c.Request.Header.Set("API-Version", "2;beta")
+	c.Request.Header.Add("Features", "beta")
+	c.Request.Header.Add("Features", "dark-mode=?0")
+	c.Request.Header.Set("Accept-Features", "a, b")
+
+	item, err := c.HeaderItem("API-Version")
+	if err != nil || item.Value != int64(2) {
+		t.Errorf("HeaderItem = %+v, %v", item, err)
+	}
+
+	dict, err := c.HeaderDictionary("Features")
+	if err != nil || len(dict) != 2 {
+		t.Errorf("HeaderDictionary = %+v, %v (fields should be combined)", dict, err)
+	}
+
+	list, err := c.HeaderList("Accept-Features")
+	if err != nil || len(list) != 2 {
+		t.Errorf("HeaderList = %+v, %v", list, err)
+	}
+
+	if list, err := c.HeaderList("Missing"); list != nil || err != nil {
+		t.Errorf("HeaderList(missing) = %v, %v", list, err)
+	}
+
+	c.Request.Header.Set("API-Version", "2 3")
+	var p Problem
+	if _, err := c.HeaderItem("API-Version"); !errors.As(err, &p) || p.Status != http.StatusBadRequest {
+		t.Errorf("expected 400 problem, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// LogSummary logs a structured startup report of the router:
+//   - the listening address and TLS status of the server set with SetServer
+//   - the number of routes per HTTP method
+//   - the global middleware, by name
+//   - the OpenAPI endpoint registered with ServeOpenAPI
+//
+// The report is a single Info record; each route is also logged at Debug
+// level with its middleware chain.
+// If logger is nil, the router logger is used (see SetLogger).
+//
+// Example:
+//
+//	srv := &http.Server{Addr: ":8080", Handler: router}
+//	router.SetServer(srv)
+//	router.LogSummary(slog.Default())
+//	// INFO server starting addr=:8080 tls=false routes.total=5 routes.GET=3 routes.POST=2
+//	//      middleware="[middleware.Logger middleware.Recovery]" openapi=/openapi.json
+//
+//	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+//	    log.Fatal(err)
+//	}
+func (r *Router) LogSummary(logger *slog.Logger) {
+	if logger == nil {
+		logger = r.log()
+	}
+
+	attrs := make([]slog.Attr, 0, 5)
+	if r.server != nil {
+		attrs = append(attrs,
+			slog.String("addr", r.server.Addr),
+			slog.Bool("tls", r.server.TLSConfig != nil),
+		)
+	}
+
+	attrs = append(attrs, slog.Group("routes", routeCountAttrs(r.routeList())...))
+
+	attrs = append(attrs, slog.Any("middleware", middlewareNames(r.middleware)))
+
+	if r.openAPIPath != "" {
+		attrs = append(attrs, slog.String("openapi", r.openAPIPath))
+	}
+
+	ctx := context.Background()
+	logger.LogAttrs(ctx, slog.LevelInfo, "server starting", attrs...)
+
+	for _, route := range r.Routes() {
+		logger.LogAttrs(ctx, slog.LevelDebug, "route",
+			slog.String("method", route.Method),
+			slog.String("path", route.Path),
+			slog.Any("middleware", route.Middleware),
+		)
+	}
+}
+
+// routeCountAttrs returns the total number of routes and the number of
+// routes per method (sorted by method).
+func routeCountAttrs(routes []RouteInfo) []any {
+	counts := make(map[string]int)
+	for _, route := range routes {
+		counts[route.Method]++
+	}
+
+	methods := make([]string, 0, len(counts))
+	for method := range counts {
+		methods = append(methods, method)
+	}
+	slices.Sort(methods)
+
+	attrs := []any{slog.Int("total", len(routes))}
+	for _, method := range methods {
+		attrs = append(attrs, slog.Int(method, counts[method]))
+	}
+	return attrs
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+)
+
+// summaryMiddleware returns a middleware closure for name tests.
+func summaryMiddleware() HandlerFunc {
+	return func(c *Context) error { return c.Next() }
+}
+
+// summaryAuth is a type whose method is used as middleware.
+type summaryAuth struct{}
+
+func (summaryAuth) Check(c *Context) error { return c.Next() }
+
+// TestRouter_LogSummary tests the startup report.
+func TestRouter_LogSummary(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	r := New()
+	r.Use(summaryMiddleware(), summaryAuth{}.Check)
+	r.GET("/users", func(c *Context) error { return nil })
+	r.GET("/users/:id", func(c *Context) error { return nil })
+	r.POST("/users", func(c *Context) error { return nil })
+	r.ServeOpenAPI("/openapi.json")
+	r.SetServer(&http.Server{Addr: ":8443", TLSConfig: &tls.Config{MinVersion: tls.VersionTLS12}})
+
+	r.LogSummary(logger)
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 5 {
+		t.Fatalf("expected 1 summary + 4 route records, got %d:\n%s", len(lines), buf.String())
+	}
+
+	var summary struct {
+		Msg        string         `json:"msg"`
+		Addr       string         `json:"addr"`
+		TLS        bool           `json:"tls"`
+		Routes     map[string]int `json:"routes"`
+		Middleware []string       `json:"middleware"`
+		OpenAPI    string         `json:"openapi"`
+	}
+	if err := json.Unmarshal(lines[0], &summary); err != nil {
+		t.Fatal(err)
+	}
+
+	if summary.Msg != "server starting" || summary.Addr != ":8443" || !summary.TLS {
+		t.Errorf("unexpected server fields: %s", lines[0])
+	}
+	if summary.Routes["total"] != 4 || summary.Routes["GET"] != 3 || summary.Routes["POST"] != 1 {
+		t.Errorf("unexpected route counts: %v", summary.Routes)
+	}
+	wantMiddleware := []string{"fursy.summaryMiddleware", "fursy.summaryAuth.Check"}
+	if len(summary.Middleware) != 2 || summary.Middleware[0] != wantMiddleware[0] || summary.Middleware[1] != wantMiddleware[1] {
+		t.Errorf("middleware = %v, want %v", summary.Middleware, wantMiddleware)
+	}
+	if summary.OpenAPI != "/openapi.json" {
+		t.Errorf("openapi = %q, want %q", summary.OpenAPI, "/openapi.json")
+	}
+}
+
+// TestRouter_LogSummary_NoServer tests the report without a server.
+func TestRouter_LogSummary_NoServer(t *testing.T) {
+	var buf bytes.Buffer
+
+	r := New()
+	r.SetLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	r.LogSummary(nil)
+
+	var summary map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &summary); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := summary["addr"]; ok {
+		t.Errorf("addr should be omitted without a server: %s", buf.String())
+	}
+	if _, ok := summary["openapi"]; ok {
+		t.Errorf("openapi should be omitted without ServeOpenAPI: %s", buf.String())
+	}
+}
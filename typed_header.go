@@ -0,0 +1,192 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TypedHeader is a structured response header that formats its own value,
+// avoiding quoting and encoding mistakes of hand-written header strings.
+type TypedHeader interface {
+	// HeaderName returns the canonical header name (e.g., "Content-Disposition").
+	HeaderName() string
+
+	// String returns the header value.
+	String() string
+}
+
+// SetTypedHeader sets the response header h.
+// This must be called before writing the response body.
+//
+// Example:
+//
+//	c.SetTypedHeader(fursy.ContentDisposition{Filename: "report 2025.pdf"})
+//	c.SetTypedHeader(fursy.RetryAfter{After: 30 * time.Second})
+//	c.SetTypedHeader(fursy.Links{
+//	    {URL: "/users?page=3", Rel: "next"},
+//	    {URL: "/users?page=1", Rel: "prev"},
+//	})
+func (c *Context) SetTypedHeader(h TypedHeader) {
+	c.Response.Header().Set(h.HeaderName(), h.String())
+}
+
+// ContentDisposition is the Content-Disposition header (RFC 6266).
+// Non-ASCII filenames are sent as filename* (RFC 8187) with an ASCII fallback.
+type ContentDisposition struct {
+	// Inline displays the content in the browser instead of downloading it.
+	// Default: false (attachment)
+	Inline bool
+
+	// Filename is the suggested filename.
+	// Default: "" (no filename parameter)
+	Filename string
+}
+
+// HeaderName implements TypedHeader.
+func (ContentDisposition) HeaderName() string { return "Content-Disposition" }
+
+// String implements TypedHeader.
+func (d ContentDisposition) String() string {
+	value := "attachment"
+	if d.Inline {
+		value = "inline"
+	}
+	if d.Filename == "" {
+		return value
+	}
+
+	ascii := true
+	fallback := []byte(d.Filename)
+	for i, b := range fallback {
+		if b < 0x20 || b >= 0x7f {
+			fallback[i] = '_'
+			ascii = false
+		}
+	}
+
+	value += `; filename="` + quoteEscape(string(fallback)) + `"`
+	if !ascii {
+		value += "; filename*=UTF-8''" + percentEncodeAttr(d.Filename)
+	}
+	return value
+}
+
+// RetryAfter is the Retry-After header (RFC 9110).
+// After is sent as delay seconds (rounded up); if zero, At is sent as an HTTP date.
+type RetryAfter struct {
+	// After is the delay before retrying.
+	After time.Duration
+
+	// At is the time after which to retry. Used if After is zero.
+	At time.Time
+}
+
+// HeaderName implements TypedHeader.
+func (RetryAfter) HeaderName() string { return "Retry-After" }
+
+// String implements TypedHeader.
+func (r RetryAfter) String() string {
+	if r.After <= 0 && !r.At.IsZero() {
+		return r.At.UTC().Format(http.TimeFormat)
+	}
+	return strconv.FormatInt(int64(max((r.After+time.Second-1)/time.Second, 0)), 10)
+}
+
+// Link is a web link of the Link header (RFC 8288).
+type Link struct {
+	// URL is the target of the link.
+	URL string
+
+	// Rel is the relation type (e.g., "next", "prev", "alternate").
+	Rel string
+
+	// Type is the media type of the target (optional).
+	Type string
+
+	// Title is a human-readable label (optional).
+	Title string
+}
+
+// Links is the Link header, listing one or more links.
+type Links []Link
+
+// HeaderName implements TypedHeader.
+func (Links) HeaderName() string { return "Link" }
+
+// String implements TypedHeader.
+func (l Links) String() string {
+	parts := make([]string, 0, len(l))
+	for _, link := range l {
+		s := "<" + link.URL + ">"
+		if link.Rel != "" {
+			s += `; rel="` + quoteEscape(link.Rel) + `"`
+		}
+		if link.Type != "" {
+			s += `; type="` + quoteEscape(link.Type) + `"`
+		}
+		if link.Title != "" {
+			s += `; title="` + quoteEscape(link.Title) + `"`
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ETag is the ETag header (RFC 9110).
+type ETag struct {
+	// Value is the opaque entity tag, without quotes.
+	Value string
+
+	// Weak marks a weak validator (W/ prefix).
+	Weak bool
+}
+
+// HeaderName implements TypedHeader.
+func (ETag) HeaderName() string { return "ETag" }
+
+// String implements TypedHeader.
+func (e ETag) String() string {
+	tag := `"` + strings.ReplaceAll(e.Value, `"`, "") + `"`
+	if e.Weak {
+		return "W/" + tag
+	}
+	return tag
+}
+
+// quoteEscape escapes backslashes and double quotes for a quoted-string.
+func quoteEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+// percentEncodeAttr percent-encodes s as an RFC 8187 ext-value, keeping
+// only attr-char unescaped.
+func percentEncodeAttr(s string) string {
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isAttrChar(c) {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hex[c>>4])
+		b.WriteByte(hex[c&0x0f])
+	}
+	return b.String()
+}
+
+// isAttrChar reports whether c is an RFC 8187 attr-char.
+func isAttrChar(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+	return strings.IndexByte("!#$&+-.^_`|~", c) >= 0
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fursy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestTypedHeader_String tests formatting of typed headers.
+func TestTypedHeader_String(t *testing.T) {
+	at := time.Date(2025, 11, 5, 8, 30, 0, 0, time.FixedZone("CET", 3600))
+
+	tests := []struct {
+		name   string
+		header TypedHeader
+		want   string
+	}{
+		{"attachment", ContentDisposition{}, "attachment"},
+		{"inline with filename", ContentDisposition{Inline: true, Filename: "report.pdf"}, `inline; filename="report.pdf"`},
+		{"quoted filename", ContentDisposition{Filename: `a "b".txt`}, `attachment; filename="a \"b\".txt"`},
+		{"non-ASCII filename", ContentDisposition{Filename: "€ rates.pdf"}, `attachment; filename="___ rates.pdf"; filename*=UTF-8''%E2%82%AC%20rates.pdf`},
+		{"retry after delay", RetryAfter{After: 1500 * time.Millisecond}, "2"},
+		{"retry after date", RetryAfter{At: at}, "Wed, 05 Nov 2025 07:30:00 GMT"},
+		{"links", Links{{URL: "/users?page=2", Rel: "next"}, {URL: "/docs", Rel: "help", Type: "text/html", Title: "Docs"}},
+			`</users?page=2>; rel="next", </docs>; rel="help"; type="text/html"; title="Docs"`},
+		{"strong etag", ETag{Value: "v1"}, `"v1"`},
+		{"weak etag", ETag{Value: "v1", Weak: true}, `W/"v1"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.header.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestContext_SetTypedHeader tests setting typed headers on the response.
+func TestContext_SetTypedHeader(t *testing.T) {
+	r := New()
+	r.GET("/export", func(c *Context) error {
+		c.SetTypedHeader(ContentDisposition{Filename: "users.csv"})
+		c.SetTypedHeader(ETag{Value: "abc"})
+		return c.String(http.StatusOK, "id,name")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/export", http.NoBody)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Disposition"); got != `attachment; filename="users.csv"` {
+		t.Errorf("unexpected Content-Disposition %q", got)
+	}
+	if got := w.Header().Get("ETag"); got != `"abc"` {
+		t.Errorf("unexpected ETag %q", got)
+	}
+}
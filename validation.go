@@ -42,6 +42,34 @@ type ValidationError struct {
 
 	// Message is a human-readable error message.
 	Message string `json:"message"`
+
+	// Pointer is the JSON Pointer (RFC 6901) to the field in the request
+	// body (e.g., "/address/zip_code"). If empty, it is derived from Field.
+	Pointer string `json:"pointer,omitempty"`
+
+	// Params are the parameters of the validation rule
+	// (e.g., {"limit": 8} for min=8).
+	Params map[string]any `json:"params,omitempty"`
+}
+
+// Violation is the machine-readable entry of a validation error in the
+// "errors" extension of ValidationProblem, so that clients can map errors
+// to form inputs without parsing messages.
+type Violation struct {
+	// Detail is the human-readable error message.
+	Detail string `json:"detail"`
+
+	// Pointer is the JSON Pointer to the invalid field (e.g., "/address/zip_code").
+	Pointer string `json:"pointer"`
+
+	// Field is the field name as reported by the validator.
+	Field string `json:"field"`
+
+	// Code is the violated rule (e.g., "required", "email", "min").
+	Code string `json:"code"`
+
+	// Params are the parameters of the rule, if any.
+	Params map[string]any `json:"params,omitempty"`
 }
 
 // Error implements the error interface.
@@ -96,6 +124,60 @@ func (ve ValidationErrors) Fields() map[string]string {
 	return fields
 }
 
+// Violations returns the machine-readable entries of the errors,
+// in order. A missing Pointer is derived from the dotted Field name.
+//
+// Example:
+//
+//	[
+//	  {"detail": "must be at least 8 characters", "pointer": "/password",
+//	   "field": "password", "code": "min", "params": {"limit": 8}}
+//	]
+func (ve ValidationErrors) Violations() []Violation {
+	violations := make([]Violation, 0, len(ve))
+	for _, err := range ve {
+		pointer := err.Pointer
+		if pointer == "" {
+			pointer = FieldPointer(err.Field)
+		}
+		violations = append(violations, Violation{
+			Detail:  err.Message,
+			Pointer: pointer,
+			Field:   err.Field,
+			Code:    err.Tag,
+			Params:  err.Params,
+		})
+	}
+	return violations
+}
+
+// FieldPointer converts a dotted field path (e.g., "address.zip_code" or
+// "items[0].name") to a JSON Pointer ("/address/zip_code", "/items/0/name").
+func FieldPointer(field string) string {
+	if field == "" {
+		return ""
+	}
+	field = strings.NewReplacer("[", ".", "]", "").Replace(field)
+	var sb strings.Builder
+	for _, token := range strings.Split(field, ".") {
+		if token == "" {
+			continue
+		}
+		sb.WriteByte('/')
+		sb.WriteString(EscapePointerToken(token))
+	}
+	return sb.String()
+}
+
+// EscapePointerToken escapes a JSON Pointer reference token (RFC 6901):
+// "~" becomes "~0" and "/" becomes "~1".
+func EscapePointerToken(token string) string {
+	if !strings.ContainsAny(token, "~/") {
+		return token
+	}
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
 // IsEmpty returns true if there are no validation errors.
 func (ve ValidationErrors) IsEmpty() bool {
 	return len(ve) == 0
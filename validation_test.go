@@ -142,6 +142,34 @@ func TestValidationErrors_Fields(t *testing.T) {
 	}
 }
 
+// TestValidationErrors_Violations tests the machine-readable error entries.
+func TestValidationErrors_Violations(t *testing.T) {
+	errs := ValidationErrors{
+		{Field: "Address.ZipCode", Tag: "len", Message: "zip code must be 5 digits",
+			Pointer: "/address/zip_code", Params: map[string]any{"limit": 5}},
+		{Field: "items[0].sku", Tag: "required", Message: "sku is required"},
+		{Field: "a/b~c", Tag: "required", Message: "a/b~c is required"},
+	}
+
+	violations := errs.Violations()
+	want := []struct{ pointer, code string }{
+		{"/address/zip_code", "len"},
+		{"/items/0/sku", "required"},
+		{"/a~1b~0c", "required"},
+	}
+	if len(violations) != len(want) {
+		t.Fatalf("Violations() returned %d entries, want %d", len(violations), len(want))
+	}
+	for i, w := range want {
+		if violations[i].Pointer != w.pointer || violations[i].Code != w.code {
+			t.Errorf("violations[%d] = %+v, want pointer %q code %q", i, violations[i], w.pointer, w.code)
+		}
+	}
+	if violations[0].Params["limit"] != 5 || violations[0].Detail != "zip code must be 5 digits" {
+		t.Errorf("violations[0] = %+v, want detail and params", violations[0])
+	}
+}
+
 // TestValidationErrors_IsEmpty tests ValidationErrors.IsEmpty().
 func TestValidationErrors_IsEmpty(t *testing.T) {
 	tests := []struct {
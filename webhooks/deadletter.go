@@ -0,0 +1,100 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package webhooks
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DeadLetter is a delivery that failed after all attempts.
+type DeadLetter struct {
+	// DeliveryID is the unique delivery ID (sent in the X-Webhook-ID header).
+	DeliveryID string
+
+	// EndpointID is the ID of the endpoint.
+	EndpointID string
+
+	// URL is the endpoint URL at the time of delivery.
+	URL string
+
+	// Event is the event name.
+	Event string
+
+	// Payload is the JSON body.
+	Payload []byte
+
+	// Attempts is the number of attempts made.
+	Attempts int
+
+	// StatusCode is the last response status (0 if no response was received).
+	StatusCode int
+
+	// Error describes the last failure.
+	Error string
+
+	// FailedAt is the time of the final failure.
+	FailedAt time.Time
+}
+
+// DeadLetterStore records failed deliveries.
+//
+// Implement it to persist dead letters (e.g., in a database table) so
+// they can be inspected and retried with Dispatcher.Redeliver.
+type DeadLetterStore interface {
+	// Record stores a failed delivery.
+	Record(ctx context.Context, dl DeadLetter) error
+}
+
+// MemoryDeadLetters is an in-memory DeadLetterStore keeping the most recent entries.
+// It is safe for concurrent use.
+type MemoryDeadLetters struct {
+	mu      sync.Mutex
+	letters []DeadLetter
+	max     int
+}
+
+// NewMemoryDeadLetters creates an in-memory store keeping at most maxEntries
+// dead letters (oldest are dropped first). Zero or negative means unlimited.
+func NewMemoryDeadLetters(maxEntries int) *MemoryDeadLetters {
+	return &MemoryDeadLetters{max: maxEntries}
+}
+
+// Record implements DeadLetterStore.
+func (m *MemoryDeadLetters) Record(_ context.Context, dl DeadLetter) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.letters = append(m.letters, dl)
+	if m.max > 0 && len(m.letters) > m.max {
+		m.letters = slices.Delete(m.letters, 0, len(m.letters)-m.max)
+	}
+	return nil
+}
+
+// List returns the recorded dead letters, oldest first.
+func (m *MemoryDeadLetters) List() []DeadLetter {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.letters)
+}
+
+// Remove deletes the dead letter with the given delivery ID (e.g., after a
+// successful Redeliver). It reports whether an entry was removed.
+func (m *MemoryDeadLetters) Remove(deliveryID string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := slices.IndexFunc(m.letters, func(dl DeadLetter) bool {
+		return dl.DeliveryID == deliveryID
+	})
+	if i < 0 {
+		return false
+	}
+	m.letters = slices.Delete(m.letters, i, i+1)
+	return true
+}
@@ -0,0 +1,441 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default values for the Dispatcher.
+const (
+	// DefaultMaxAttempts is the default number of delivery attempts per endpoint.
+	DefaultMaxAttempts = 5
+
+	// DefaultTimeout is the default HTTP client timeout for a single attempt.
+	DefaultTimeout = 10 * time.Second
+
+	// DefaultUserAgent is the default User-Agent of outbound deliveries.
+	DefaultUserAgent = "fursy-webhooks"
+
+	// DefaultMaxConcurrent is the default number of concurrent background deliveries.
+	DefaultMaxConcurrent = 100
+
+	// maxResponseDrain is the maximum number of response body bytes read before closing.
+	maxResponseDrain = 64 << 10
+)
+
+// ErrDispatcherClosed is returned by Send after Shutdown.
+var ErrDispatcherClosed = errors.New("webhooks: dispatcher is shut down")
+
+// Endpoint is a registered webhook receiver.
+type Endpoint struct {
+	// ID uniquely identifies the endpoint (required).
+	ID string
+
+	// URL is the http(s) URL deliveries are posted to (required).
+	URL string
+
+	// Secret is the HMAC key used to sign deliveries (required).
+	Secret []byte
+
+	// Events lists the events delivered to this endpoint.
+	// Supports exact names ("order.created"), prefixes ("order.*") and "*".
+	// Default: nil (all events)
+	Events []string
+}
+
+// Matches reports whether the endpoint subscribes to event.
+func (ep *Endpoint) Matches(event string) bool {
+	if len(ep.Events) == 0 {
+		return true
+	}
+	for _, pattern := range ep.Events {
+		if pattern == "*" || pattern == event {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(event, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Config defines the configuration for a Dispatcher.
+type Config struct {
+	// Client is the HTTP client used for deliveries.
+	// Default: http.Client with a 10 second timeout
+	Client *http.Client
+
+	// MaxAttempts is the maximum number of attempts per delivery.
+	// Default: 5
+	MaxAttempts int
+
+	// Backoff returns the delay before the given retry (attempt starts at 1).
+	// Default: ExponentialBackoff(time.Second, time.Hour)
+	Backoff func(attempt int) time.Duration
+
+	// DeadLetters records deliveries that failed after all attempts.
+	// Default: NewMemoryDeadLetters(1000)
+	DeadLetters DeadLetterStore
+
+	// UserAgent is the User-Agent header of deliveries.
+	// Default: "fursy-webhooks"
+	UserAgent string
+
+	// MaxConcurrent is the maximum number of background deliveries
+	// (including their retries) in progress at once. When it is reached,
+	// Send waits for a delivery to finish.
+	// Default: 100
+	MaxConcurrent int
+}
+
+// Dispatcher delivers signed webhook events to registered endpoints.
+//
+// Deliveries run in the background. Failed attempts (network errors,
+// 408, 429 and 5xx responses) are retried with backoff; other 4xx responses
+// are not retried. Deliveries that finally fail are recorded in the
+// DeadLetterStore and can be retried with Redeliver.
+//
+// Dispatcher is safe for concurrent use.
+type Dispatcher struct {
+	config Config
+
+	mu        sync.RWMutex
+	endpoints map[string]Endpoint
+	closed    bool
+
+	sem    chan struct{} // Background delivery slots.
+	wg     sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// New creates a Dispatcher.
+//
+// Example:
+//
+//	hooks := webhooks.New(webhooks.Config{MaxAttempts: 8})
+//	_ = hooks.Register(webhooks.Endpoint{
+//	    ID:     "acme",
+//	    URL:    "https://acme.example.com/hooks",
+//	    Secret: []byte(secret),
+//	    Events: []string{"order.*"},
+//	})
+//
+//	hooks.Send("order.created", order)
+//
+//	// On shutdown:
+//	_ = hooks.Shutdown(ctx)
+func New(config Config) *Dispatcher {
+	// Set defaults.
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: DefaultTimeout}
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.Backoff == nil {
+		config.Backoff = ExponentialBackoff(time.Second, time.Hour)
+	}
+	if config.DeadLetters == nil {
+		config.DeadLetters = NewMemoryDeadLetters(1000)
+	}
+	if config.UserAgent == "" {
+		config.UserAgent = DefaultUserAgent
+	}
+	if config.MaxConcurrent <= 0 {
+		config.MaxConcurrent = DefaultMaxConcurrent
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Dispatcher{
+		config:    config,
+		endpoints: make(map[string]Endpoint),
+		sem:       make(chan struct{}, config.MaxConcurrent),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// Register adds or replaces an endpoint.
+func (d *Dispatcher) Register(ep Endpoint) error {
+	if ep.ID == "" {
+		return errors.New("webhooks: endpoint ID is required")
+	}
+	if len(ep.Secret) == 0 {
+		return fmt.Errorf("webhooks: endpoint %q: secret is required", ep.ID)
+	}
+	u, err := url.Parse(ep.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("webhooks: endpoint %q: invalid URL %q", ep.ID, ep.URL)
+	}
+
+	d.mu.Lock()
+	d.endpoints[ep.ID] = ep
+	d.mu.Unlock()
+	return nil
+}
+
+// Unregister removes an endpoint. Deliveries already in progress are not canceled.
+func (d *Dispatcher) Unregister(id string) {
+	d.mu.Lock()
+	delete(d.endpoints, id)
+	d.mu.Unlock()
+}
+
+// Endpoints returns the registered endpoints sorted by ID.
+func (d *Dispatcher) Endpoints() []Endpoint {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	endpoints := make([]Endpoint, 0, len(d.endpoints))
+	for _, ep := range d.endpoints {
+		endpoints = append(endpoints, ep)
+	}
+	slices.SortFunc(endpoints, func(a, b Endpoint) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return endpoints
+}
+
+// Send encodes payload as JSON and delivers it in the background to every
+// endpoint subscribed to event. It returns the number of deliveries started.
+// If Config.MaxConcurrent deliveries are in progress, Send waits for one
+// to finish before starting the next.
+func (d *Dispatcher) Send(event string, payload any) (int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("webhooks: encode payload: %w", err)
+	}
+	return d.SendJSON(event, body)
+}
+
+// SendJSON is like Send for an already encoded JSON body.
+func (d *Dispatcher) SendJSON(event string, body []byte) (int, error) {
+	d.mu.RLock()
+	if d.closed {
+		d.mu.RUnlock()
+		return 0, ErrDispatcherClosed
+	}
+	var endpoints []Endpoint
+	for _, ep := range d.endpoints {
+		if ep.Matches(event) {
+			endpoints = append(endpoints, ep)
+		}
+	}
+	d.wg.Add(len(endpoints))
+	d.mu.RUnlock()
+
+	for _, ep := range endpoints {
+		select {
+		case d.sem <- struct{}{}:
+		case <-d.ctx.Done():
+			// Shutdown gave up waiting: the delivery fails immediately
+			// and is recorded as a dead letter.
+			_ = d.deliver(d.ctx, ep, newDeliveryID(), event, body)
+			d.wg.Done()
+			continue
+		}
+		go func(ep Endpoint) {
+			defer func() {
+				<-d.sem
+				d.wg.Done()
+			}()
+			_ = d.deliver(d.ctx, ep, newDeliveryID(), event, body)
+		}(ep)
+	}
+	return len(endpoints), nil
+}
+
+// Deliver synchronously delivers a JSON body to a single endpoint, retrying
+// with backoff. On final failure the delivery is recorded as a dead letter
+// and a *DeliveryError is returned.
+func (d *Dispatcher) Deliver(ctx context.Context, ep Endpoint, event string, body []byte) error {
+	return d.deliver(ctx, ep, newDeliveryID(), event, body)
+}
+
+// Redeliver retries a dead letter to its (currently registered) endpoint,
+// keeping the original delivery ID so receivers can deduplicate.
+func (d *Dispatcher) Redeliver(ctx context.Context, dl DeadLetter) error {
+	d.mu.RLock()
+	ep, ok := d.endpoints[dl.EndpointID]
+	d.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("webhooks: endpoint %q is not registered", dl.EndpointID)
+	}
+	return d.deliver(ctx, ep, dl.DeliveryID, dl.Event, dl.Payload)
+}
+
+// Shutdown stops accepting new events and waits for in-progress deliveries.
+// If ctx is done first, pending retries are canceled (and recorded as dead
+// letters) and ctx.Err() is returned.
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.mu.Lock()
+	d.closed = true
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		d.cancel()
+		return nil
+	case <-ctx.Done():
+		d.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// deliver performs a delivery with retries and dead-letter recording.
+func (d *Dispatcher) deliver(ctx context.Context, ep Endpoint, id, event string, body []byte) error {
+	var (
+		status  int
+		lastErr error
+		attempt int
+	)
+
+	for attempt = 1; attempt <= d.config.MaxAttempts; attempt++ {
+		var retry bool
+		status, retry, lastErr = d.attempt(ctx, ep, id, event, body)
+		if lastErr == nil {
+			return nil
+		}
+		if !retry || attempt == d.config.MaxAttempts {
+			break
+		}
+
+		timer := time.NewTimer(d.config.Backoff(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			lastErr = ctx.Err()
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	attempt = min(attempt, d.config.MaxAttempts)
+
+	err := &DeliveryError{
+		EndpointID: ep.ID,
+		Attempts:   attempt,
+		StatusCode: status,
+		Err:        lastErr,
+	}
+
+	_ = d.config.DeadLetters.Record(context.WithoutCancel(ctx), DeadLetter{
+		DeliveryID: id,
+		EndpointID: ep.ID,
+		URL:        ep.URL,
+		Event:      event,
+		Payload:    body,
+		Attempts:   attempt,
+		StatusCode: status,
+		Error:      lastErr.Error(),
+		FailedAt:   time.Now(),
+	})
+
+	return err
+}
+
+// attempt performs a single delivery attempt.
+// It returns the response status (0 on network errors) and whether a failure is retryable.
+func (d *Dispatcher) attempt(ctx context.Context, ep Endpoint, id, event string, body []byte) (int, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ep.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", d.config.UserAgent)
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(IDHeader, id)
+	req.Header.Set(SignatureHeader, Sign(ep.Secret, time.Now(), body))
+
+	resp, err := d.config.Client.Do(req)
+	if err != nil {
+		return 0, ctx.Err() == nil, err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseDrain))
+	_ = resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, false, nil
+	}
+
+	retry := resp.StatusCode >= 500 ||
+		resp.StatusCode == http.StatusRequestTimeout ||
+		resp.StatusCode == http.StatusTooManyRequests
+	return resp.StatusCode, retry, fmt.Errorf("unexpected status %d", resp.StatusCode)
+}
+
+// DeliveryError describes a delivery that failed after all attempts.
+type DeliveryError struct {
+	// EndpointID is the ID of the endpoint.
+	EndpointID string
+
+	// Attempts is the number of attempts made.
+	Attempts int
+
+	// StatusCode is the last response status (0 if no response was received).
+	StatusCode int
+
+	// Err is the last error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *DeliveryError) Error() string {
+	return fmt.Sprintf("webhooks: delivery to %q failed after %d attempt(s): %v", e.EndpointID, e.Attempts, e.Err)
+}
+
+// Unwrap returns the last error.
+func (e *DeliveryError) Unwrap() error {
+	return e.Err
+}
+
+// ExponentialBackoff returns a backoff function doubling the delay from base
+// up to maxDelay, with random jitter (50-100% of the delay) to spread retries.
+func ExponentialBackoff(base, maxDelay time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		delay := base
+		for i := 1; i < attempt && delay < maxDelay; i++ {
+			delay *= 2
+		}
+		delay = min(delay, maxDelay)
+
+		half := delay / 2
+		if half <= 0 {
+			return delay
+		}
+		return half + rand.N(half+1) //nolint:gosec // Jitter does not need a secure source.
+	}
+}
+
+// newDeliveryID returns a random delivery ID.
+func newDeliveryID() string {
+	b := make([]byte, 16)
+	_, _ = cryptorand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,294 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// noBackoff retries immediately.
+func noBackoff(int) time.Duration { return time.Millisecond }
+
+// TestDispatcher_Send tests end-to-end delivery to a receiver using Verify.
+func TestDispatcher_Send(t *testing.T) {
+	secret := []byte("whsec")
+
+	var (
+		mu       sync.Mutex
+		received []string
+	)
+	receiver := fursy.New()
+	hooks := receiver.Group("/hooks", Verify(secret))
+	hooks.POST("/orders", func(c *fursy.Context) error {
+		body, _ := io.ReadAll(c.Request.Body)
+		mu.Lock()
+		received = append(received, c.GetHeader(EventHeader)+" "+string(body))
+		mu.Unlock()
+		return c.NoContent(http.StatusNoContent)
+	})
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	d := New(Config{Backoff: noBackoff})
+	if err := d.Register(Endpoint{ID: "orders", URL: srv.URL + "/hooks/orders", Secret: secret, Events: []string{"order.*"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Register(Endpoint{ID: "users", URL: srv.URL + "/hooks/users", Secret: secret, Events: []string{"user.created"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := d.Send("order.created", map[string]int{"id": 1})
+	if err != nil || n != 1 {
+		t.Fatalf("expected 1 delivery, got %d (%v)", n, err)
+	}
+	if err := d.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	if len(received) != 1 || received[0] != `order.created {"id":1}` {
+		t.Errorf("unexpected deliveries: %v", received)
+	}
+	if _, err := d.Send("order.created", nil); !errors.Is(err, ErrDispatcherClosed) {
+		t.Errorf("expected ErrDispatcherClosed, got %v", err)
+	}
+}
+
+// TestDispatcher_MaxConcurrent tests that background deliveries are bounded.
+func TestDispatcher_MaxConcurrent(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	d := New(Config{MaxConcurrent: 2})
+	for _, id := range []string{"a", "b", "c", "d"} {
+		if err := d.Register(Endpoint{ID: id, URL: srv.URL, Secret: []byte("s")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for range 3 {
+		if n, err := d.Send("ping", nil); err != nil || n != 4 {
+			t.Fatalf("expected 4 deliveries, got %d (%v)", n, err)
+		}
+	}
+	if err := d.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if peak.Load() > 2 {
+		t.Errorf("peak concurrent deliveries = %d, want at most 2", peak.Load())
+	}
+}
+
+// TestDispatcher_Retry tests retries on 5xx and the stable delivery ID.
+func TestDispatcher_Retry(t *testing.T) {
+	var (
+		calls atomic.Int32
+		ids   sync.Map
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids.Store(r.Header.Get(IDHeader), true)
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	d := New(Config{Backoff: noBackoff})
+	ep := Endpoint{ID: "ep", URL: srv.URL, Secret: []byte("s")}
+
+	if err := d.Deliver(context.Background(), ep, "ping", []byte(`{}`)); err != nil {
+		t.Fatalf("expected delivery to succeed, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls.Load())
+	}
+	count := 0
+	ids.Range(func(_, _ any) bool { count++; return true })
+	if count != 1 {
+		t.Errorf("expected a single delivery ID across retries, got %d", count)
+	}
+}
+
+// TestDispatcher_DeadLetter tests dead-letter recording and redelivery.
+func TestDispatcher_DeadLetter(t *testing.T) {
+	var (
+		calls  atomic.Int32
+		status atomic.Int32
+	)
+	status.Store(http.StatusInternalServerError)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer srv.Close()
+
+	store := NewMemoryDeadLetters(10)
+	d := New(Config{MaxAttempts: 3, Backoff: noBackoff, DeadLetters: store})
+	ep := Endpoint{ID: "ep", URL: srv.URL, Secret: []byte("s")}
+	if err := d.Register(ep); err != nil {
+		t.Fatal(err)
+	}
+
+	err := d.Deliver(context.Background(), ep, "ping", []byte(`{"n":1}`))
+	var deliveryErr *DeliveryError
+	if !errors.As(err, &deliveryErr) || deliveryErr.Attempts != 3 || deliveryErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	letters := store.List()
+	if len(letters) != 1 || letters[0].Attempts != 3 || letters[0].Event != "ping" || string(letters[0].Payload) != `{"n":1}` {
+		t.Fatalf("unexpected dead letters: %+v", letters)
+	}
+
+	status.Store(http.StatusOK)
+	if err := d.Redeliver(context.Background(), letters[0]); err != nil {
+		t.Fatalf("Redeliver failed: %v", err)
+	}
+	if !store.Remove(letters[0].DeliveryID) || len(store.List()) != 0 {
+		t.Error("expected dead letter to be removed")
+	}
+}
+
+// TestDispatcher_NoRetryOn4xx tests that client errors are not retried.
+func TestDispatcher_NoRetryOn4xx(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer srv.Close()
+
+	d := New(Config{Backoff: noBackoff})
+	err := d.Deliver(context.Background(), Endpoint{ID: "ep", URL: srv.URL, Secret: []byte("s")}, "ping", nil)
+	if err == nil || calls.Load() != 1 {
+		t.Errorf("expected a single failed attempt, got %d (%v)", calls.Load(), err)
+	}
+}
+
+// TestDispatcher_ShutdownCancelsRetries tests that Shutdown cancels pending retries.
+func TestDispatcher_ShutdownCancelsRetries(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	store := NewMemoryDeadLetters(0)
+	d := New(Config{Backoff: func(int) time.Duration { return time.Hour }, DeadLetters: store})
+	if err := d.Register(Endpoint{ID: "ep", URL: srv.URL, Secret: []byte("s")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Send("ping", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := d.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+	if letters := store.List(); len(letters) != 1 || letters[0].Attempts != 1 {
+		t.Errorf("expected canceled delivery to be dead-lettered, got %+v", letters)
+	}
+}
+
+// TestDispatcher_Register tests endpoint validation and listing.
+func TestDispatcher_Register(t *testing.T) {
+	d := New(Config{})
+
+	invalid := []Endpoint{
+		{URL: "https://example.com", Secret: []byte("s")},
+		{ID: "a", URL: "https://example.com"},
+		{ID: "a", URL: "ftp://example.com", Secret: []byte("s")},
+		{ID: "a", URL: "/relative", Secret: []byte("s")},
+	}
+	for _, ep := range invalid {
+		if err := d.Register(ep); err == nil {
+			t.Errorf("expected error for %+v", ep)
+		}
+	}
+
+	_ = d.Register(Endpoint{ID: "b", URL: "https://b.example.com", Secret: []byte("s")})
+	_ = d.Register(Endpoint{ID: "a", URL: "https://a.example.com", Secret: []byte("s")})
+	if eps := d.Endpoints(); len(eps) != 2 || eps[0].ID != "a" {
+		t.Errorf("unexpected endpoints: %+v", eps)
+	}
+
+	d.Unregister("a")
+	if eps := d.Endpoints(); len(eps) != 1 || eps[0].ID != "b" {
+		t.Errorf("unexpected endpoints after Unregister: %+v", eps)
+	}
+}
+
+// TestEndpoint_Matches tests event subscription patterns.
+func TestEndpoint_Matches(t *testing.T) {
+	tests := []struct {
+		events []string
+		event  string
+		want   bool
+	}{
+		{nil, "order.created", true},
+		{[]string{"*"}, "order.created", true},
+		{[]string{"order.*"}, "order.created", true},
+		{[]string{"order.*"}, "user.created", false},
+		{[]string{"order.created"}, "order.created", true},
+		{[]string{"order.created"}, "order.deleted", false},
+	}
+
+	for _, tt := range tests {
+		ep := Endpoint{Events: tt.events}
+		if got := ep.Matches(tt.event); got != tt.want {
+			t.Errorf("%v matches %q: expected %v, got %v", tt.events, tt.event, tt.want, got)
+		}
+	}
+}
+
+// TestExponentialBackoff tests delay growth, cap and jitter bounds.
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(time.Second, 10*time.Second)
+
+	for attempt, full := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 10: 10 * time.Second} {
+		for range 20 {
+			d := backoff(attempt)
+			if d < full/2 || d > full {
+				t.Fatalf("attempt %d: delay %v outside [%v, %v]", attempt, d, full/2, full)
+			}
+		}
+	}
+}
+
+// TestMemoryDeadLetters_Max tests that the oldest entries are dropped.
+func TestMemoryDeadLetters_Max(t *testing.T) {
+	store := NewMemoryDeadLetters(2)
+	for _, id := range []string{"1", "2", "3"} {
+		_ = store.Record(context.Background(), DeadLetter{DeliveryID: id})
+	}
+
+	letters := store.List()
+	if len(letters) != 2 || letters[0].DeliveryID != "2" || letters[1].DeliveryID != "3" {
+		t.Errorf("unexpected dead letters: %+v", letters)
+	}
+}
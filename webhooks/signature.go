@@ -0,0 +1,151 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package webhooks provides webhook signing, delivery and verification for fursy.
+//
+// Outbound: a Dispatcher sends signed JSON events to registered endpoints,
+// retrying failed deliveries with exponential backoff and recording
+// deliveries that finally fail in a DeadLetterStore.
+//
+// Inbound: the Verify middleware checks signatures of callbacks received
+// from other services (Stripe-style timestamped or GitHub-style signatures).
+//
+// Signatures use HMAC-SHA256. The default (timestamped) format is:
+//
+//	X-Webhook-Signature: t=1735689600,v1=5257a869e7ecebeda32affa62cdca3fa51cad7e77a0e56ff536d0ce8e108d8bd
+//
+// where v1 is the hex HMAC of "<t>.<body>". Including the timestamp in the
+// signature prevents replay of old deliveries.
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Header names used by outbound deliveries.
+const (
+	// SignatureHeader carries the timestamped signature ("t=...,v1=...").
+	SignatureHeader = "X-Webhook-Signature"
+
+	// EventHeader carries the event name (e.g., "order.created").
+	EventHeader = "X-Webhook-Event"
+
+	// IDHeader carries the unique delivery ID (stable across retries).
+	IDHeader = "X-Webhook-ID"
+
+	// GitHubSignatureHeader is the header used by GitHub-style signatures ("sha256=...").
+	GitHubSignatureHeader = "X-Hub-Signature-256"
+)
+
+// DefaultTolerance is the default maximum age of a timestamped signature.
+const DefaultTolerance = 5 * time.Minute
+
+// Signature errors.
+var (
+	// ErrMissingSignature is returned when the signature header is missing or malformed.
+	ErrMissingSignature = errors.New("webhooks: missing signature")
+
+	// ErrInvalidSignature is returned when no signature matches the payload.
+	ErrInvalidSignature = errors.New("webhooks: invalid signature")
+
+	// ErrSignatureExpired is returned when the signature timestamp is outside the tolerance.
+	ErrSignatureExpired = errors.New("webhooks: signature timestamp outside tolerance")
+)
+
+// Sign returns the timestamped signature header value for payload.
+//
+// Example:
+//
+//	req.Header.Set(webhooks.SignatureHeader, webhooks.Sign(secret, time.Now(), body))
+func Sign(secret []byte, timestamp time.Time, payload []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + t + ",v1=" + computeSignature(secret, t, payload)
+}
+
+// VerifySignature checks a timestamped signature header value.
+//
+// The header may contain several v1 signatures (e.g., during secret rotation);
+// the payload is valid if any of them matches. Signatures older (or newer)
+// than tolerance are rejected; zero tolerance disables the timestamp check.
+//
+// Example:
+//
+//	err := webhooks.VerifySignature(secret, r.Header.Get("Stripe-Signature"), body, 5*time.Minute)
+func VerifySignature(secret []byte, header string, payload []byte, tolerance time.Duration) error {
+	var (
+		timestamp  string
+		signatures []string
+	)
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrMissingSignature
+	}
+
+	expected := computeSignature(secret, timestamp, payload)
+	valid := false
+	for _, sig := range signatures {
+		if hmac.Equal([]byte(sig), []byte(expected)) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+
+	if tolerance > 0 {
+		age := time.Since(time.Unix(unix, 0))
+		if age > tolerance || age < -tolerance {
+			return ErrSignatureExpired
+		}
+	}
+
+	return nil
+}
+
+// SignGitHub returns a GitHub-style signature header value ("sha256=<hex>").
+func SignGitHub(secret []byte, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyGitHubSignature checks a GitHub-style signature header value ("sha256=<hex>").
+func VerifyGitHubSignature(secret []byte, header string, payload []byte) error {
+	if !strings.HasPrefix(header, "sha256=") {
+		return ErrMissingSignature
+	}
+	if !hmac.Equal([]byte(header), []byte(SignGitHub(secret, payload))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// computeSignature returns the hex HMAC-SHA256 of "<timestamp>.<payload>".
+func computeSignature(secret []byte, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte{'.'})
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package webhooks
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSign_Verify tests timestamped signature round trips and failures.
+func TestSign_Verify(t *testing.T) {
+	secret := []byte("whsec")
+	payload := []byte(`{"id":1}`)
+	now := time.Now()
+
+	valid := Sign(secret, now, payload)
+	if !strings.HasPrefix(valid, "t=") || !strings.Contains(valid, ",v1=") {
+		t.Fatalf("unexpected signature format: %s", valid)
+	}
+
+	tests := []struct {
+		name      string
+		secret    []byte
+		header    string
+		payload   []byte
+		tolerance time.Duration
+		want      error
+	}{
+		{"valid", secret, valid, payload, DefaultTolerance, nil},
+		{"rotated secrets", secret, Sign([]byte("old"), now, payload) + "," + strings.Split(valid, ",")[1], payload, DefaultTolerance, nil},
+		{"wrong secret", []byte("other"), valid, payload, DefaultTolerance, ErrInvalidSignature},
+		{"tampered payload", secret, valid, []byte(`{"id":2}`), DefaultTolerance, ErrInvalidSignature},
+		{"expired", secret, Sign(secret, now.Add(-time.Hour), payload), payload, DefaultTolerance, ErrSignatureExpired},
+		{"expired tolerance disabled", secret, Sign(secret, now.Add(-time.Hour), payload), payload, 0, nil},
+		{"missing", secret, "", payload, DefaultTolerance, ErrMissingSignature},
+		{"no timestamp", secret, "v1=abc", payload, DefaultTolerance, ErrMissingSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifySignature(tt.secret, tt.header, tt.payload, tt.tolerance)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestSignGitHub tests GitHub-style signatures.
+func TestSignGitHub(t *testing.T) {
+	secret := []byte("It's a Secret to Everybody")
+	payload := []byte("Hello, World!")
+
+	// Test vector from the GitHub webhook documentation.
+	want := "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"
+	if got := SignGitHub(secret, payload); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	if err := VerifyGitHubSignature(secret, want, payload); err != nil {
+		t.Errorf("expected valid signature, got %v", err)
+	}
+	if err := VerifyGitHubSignature(secret, want, []byte("tampered")); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+	if err := VerifyGitHubSignature(secret, "sha1=abc", payload); !errors.Is(err, ErrMissingSignature) {
+		t.Errorf("expected ErrMissingSignature, got %v", err)
+	}
+}
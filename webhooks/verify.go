@@ -0,0 +1,168 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package webhooks
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// DefaultMaxBodySize is the default maximum webhook body size read by Verify (1 MB).
+const DefaultMaxBodySize = 1 << 20
+
+// Scheme is a webhook signature format.
+type Scheme int
+
+const (
+	// SchemeTimestamped is the Stripe-style format "t=<unix>,v1=<hex>"
+	// signing "<t>.<body>" (used by Dispatcher).
+	SchemeTimestamped Scheme = iota
+
+	// SchemeGitHub is the GitHub-style format "sha256=<hex>" signing the body.
+	SchemeGitHub
+)
+
+// VerifyConfig defines the configuration for the Verify middleware.
+type VerifyConfig struct {
+	// Secret is the HMAC key shared with the sender.
+	// Required.
+	Secret []byte
+
+	// Scheme is the signature format.
+	// Default: SchemeTimestamped
+	Scheme Scheme
+
+	// Header is the request header containing the signature.
+	// Default: "X-Webhook-Signature" (SchemeTimestamped) or "X-Hub-Signature-256" (SchemeGitHub)
+	Header string
+
+	// Tolerance is the maximum age of a timestamped signature (replay protection).
+	// Negative value disables the check.
+	// Default: 5 minutes
+	Tolerance time.Duration
+
+	// MaxBodySize is the maximum body size in bytes.
+	// Default: 1 MB
+	MaxBodySize int64
+
+	// Skipper defines a function to skip the middleware.
+	// Use fursy.SkipPaths and fursy.SkipMethods for pattern-based skipping.
+	// Default: nil (middleware always executes)
+	Skipper fursy.Skipper
+
+	// ErrorHandler is called when verification fails.
+	// err is ErrMissingSignature, ErrInvalidSignature or ErrSignatureExpired.
+	// Default: 401 Unauthorized problem
+	ErrorHandler func(c *fursy.Context, err error) error
+}
+
+// Verify returns a middleware that verifies timestamped webhook signatures
+// (as sent by Dispatcher) before the handler runs.
+//
+// The body is read, verified and restored, so handlers can bind it as usual.
+//
+// Example:
+//
+//	hooks := router.Group("/hooks", webhooks.Verify(secret))
+//	hooks.POST("/orders", handleOrderHook)
+func Verify(secret []byte) fursy.HandlerFunc {
+	return VerifyWithConfig(VerifyConfig{
+		Secret: secret,
+	})
+}
+
+// VerifyWithConfig returns a middleware with custom configuration.
+//
+// Example (Stripe):
+//
+//	router.Use(webhooks.VerifyWithConfig(webhooks.VerifyConfig{
+//	    Secret: []byte(os.Getenv("STRIPE_WEBHOOK_SECRET")),
+//	    Header: "Stripe-Signature",
+//	}))
+//
+// Example (GitHub):
+//
+//	router.Use(webhooks.VerifyWithConfig(webhooks.VerifyConfig{
+//	    Secret: []byte(os.Getenv("GITHUB_WEBHOOK_SECRET")),
+//	    Scheme: webhooks.SchemeGitHub,
+//	}))
+func VerifyWithConfig(config VerifyConfig) fursy.HandlerFunc {
+	// Validate config.
+	if len(config.Secret) == 0 {
+		panic("fursy/webhooks: Verify secret cannot be empty")
+	}
+
+	// Set defaults.
+	if config.Header == "" {
+		config.Header = SignatureHeader
+		if config.Scheme == SchemeGitHub {
+			config.Header = GitHubSignatureHeader
+		}
+	}
+	if config.Tolerance == 0 {
+		config.Tolerance = DefaultTolerance
+	}
+	if config.MaxBodySize <= 0 {
+		config.MaxBodySize = DefaultMaxBodySize
+	}
+	if config.ErrorHandler == nil {
+		config.ErrorHandler = defaultVerifyErrorHandler
+	}
+
+	return func(c *fursy.Context) error {
+		// Skip if Skipper returns true.
+		if config.Skipper.ShouldSkip(c) {
+			return c.Next()
+		}
+
+		header := c.Request.Header.Get(config.Header)
+		if header == "" {
+			return config.ErrorHandler(c, ErrMissingSignature)
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			var err error
+			body, err = io.ReadAll(io.LimitReader(c.Request.Body, config.MaxBodySize+1))
+			if err != nil {
+				return err
+			}
+			if int64(len(body)) > config.MaxBodySize {
+				return c.Problem(fursy.NewProblem(http.StatusRequestEntityTooLarge, "Content Too Large",
+					"webhook body exceeds the allowed size"))
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		var err error
+		if config.Scheme == SchemeGitHub {
+			err = VerifyGitHubSignature(config.Secret, header, body)
+		} else {
+			err = VerifySignature(config.Secret, header, body, max(config.Tolerance, 0))
+		}
+		if err != nil {
+			return config.ErrorHandler(c, err)
+		}
+
+		return c.Next()
+	}
+}
+
+// defaultVerifyErrorHandler sends a 401 Unauthorized problem.
+func defaultVerifyErrorHandler(c *fursy.Context, err error) error {
+	detail := "invalid webhook signature"
+	switch {
+	case errors.Is(err, ErrMissingSignature):
+		detail = "missing webhook signature"
+	case errors.Is(err, ErrSignatureExpired):
+		detail = "webhook signature has expired"
+	}
+	return c.Problem(fursy.Unauthorized(detail))
+}
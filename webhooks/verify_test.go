@@ -0,0 +1,91 @@
+// Copyright 2025 coregx. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package webhooks
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coregx/fursy"
+)
+
+// TestVerify tests the Verify middleware.
+func TestVerify(t *testing.T) {
+	secret := []byte("whsec")
+	payload := `{"type":"invoice.paid"}`
+
+	r := fursy.New()
+	r.Use(VerifyWithConfig(VerifyConfig{Secret: secret, Header: "Stripe-Signature", MaxBodySize: 64}))
+	r.POST("/hooks", func(c *fursy.Context) error {
+		body, _ := io.ReadAll(c.Request.Body)
+		return c.String(http.StatusOK, string(body))
+	})
+
+	tests := []struct {
+		name      string
+		body      string
+		signature string
+		want      int
+	}{
+		{"valid", payload, Sign(secret, time.Now(), []byte(payload)), http.StatusOK},
+		{"missing", payload, "", http.StatusUnauthorized},
+		{"invalid", payload, Sign([]byte("other"), time.Now(), []byte(payload)), http.StatusUnauthorized},
+		{"replayed", payload, Sign(secret, time.Now().Add(-time.Hour), []byte(payload)), http.StatusUnauthorized},
+		{"too large", strings.Repeat("x", 65), "t=1,v1=x", http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/hooks", strings.NewReader(tt.body))
+			if tt.signature != "" {
+				req.Header.Set("Stripe-Signature", tt.signature)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("expected status %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+			if tt.want == http.StatusOK && w.Body.String() != payload {
+				t.Errorf("expected body to be restored, got %q", w.Body.String())
+			}
+		})
+	}
+}
+
+// TestVerify_GitHub tests GitHub-style verification.
+func TestVerify_GitHub(t *testing.T) {
+	secret := []byte("gh")
+	payload := []byte(`{"action":"opened"}`)
+
+	r := fursy.New()
+	r.Use(VerifyWithConfig(VerifyConfig{Secret: secret, Scheme: SchemeGitHub}))
+	r.POST("/hooks", func(c *fursy.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/hooks", strings.NewReader(string(payload)))
+	req.Header.Set(GitHubSignatureHeader, SignGitHub(secret, payload))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected status 204, got %d", w.Code)
+	}
+}
+
+// TestVerify_EmptySecret tests that an empty secret panics.
+func TestVerify_EmptySecret(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for empty secret")
+		}
+	}()
+	Verify(nil)
+}